@@ -0,0 +1,39 @@
+package constants
+
+type bedStatus struct {
+	AVAILABLE string
+	OCCUPIED  string
+	CLEANING  string
+	RESERVED  string
+}
+
+var BedStatus = bedStatus{
+	AVAILABLE: "available",
+	OCCUPIED:  "occupied",
+	CLEANING:  "cleaning",
+	RESERVED:  "reserved",
+}
+
+type admissionStatus struct {
+	ADMITTED   string
+	DISCHARGED string
+}
+
+var AdmissionStatus = admissionStatus{
+	ADMITTED:   "admitted",
+	DISCHARGED: "discharged",
+}
+
+type dischargeDisposition struct {
+	HOME           string
+	TRANSFERRED    string
+	AGAINST_ADVICE string
+	DECEASED       string
+}
+
+var DischargeDisposition = dischargeDisposition{
+	HOME:           "home",
+	TRANSFERRED:    "transferred",
+	AGAINST_ADVICE: "against_advice",
+	DECEASED:       "deceased",
+}
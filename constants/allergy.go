@@ -0,0 +1,29 @@
+package constants
+
+type allergySeverity struct {
+	MILD             string
+	MODERATE         string
+	SEVERE           string
+	LIFE_THREATENING string
+}
+
+var AllergySeverity = allergySeverity{
+	MILD:             "mild",
+	MODERATE:         "moderate",
+	SEVERE:           "severe",
+	LIFE_THREATENING: "life_threatening",
+}
+
+type allergyVerificationStatus struct {
+	UNCONFIRMED      string
+	CONFIRMED        string
+	REFUTED          string
+	ENTERED_IN_ERROR string
+}
+
+var AllergyVerificationStatus = allergyVerificationStatus{
+	UNCONFIRMED:      "unconfirmed",
+	CONFIRMED:        "confirmed",
+	REFUTED:          "refuted",
+	ENTERED_IN_ERROR: "entered_in_error",
+}
@@ -0,0 +1,49 @@
+package constants
+
+type auditAction struct {
+	ALLERGY_OVERRIDE          string
+	PATIENT_MERGE             string
+	PATIENT_UPDATE            string
+	APPOINTMENT_STATUS_CHANGE string
+	INVOICE_VOID              string
+	INVOICE_DISCOUNT          string
+	PAYMENT_REFUND            string
+	FEE_WAIVER_REQUEST        string
+	FEE_WAIVER_APPROVE        string
+	FEE_WAIVER_REJECT         string
+	FEE_WAIVER_CANCEL         string
+}
+
+var AuditActions = auditAction{
+	ALLERGY_OVERRIDE:          "allergy_override",
+	PATIENT_MERGE:             "patient_merge",
+	PATIENT_UPDATE:            "patient_update",
+	APPOINTMENT_STATUS_CHANGE: "appointment_status_change",
+	INVOICE_VOID:              "invoice_void",
+	INVOICE_DISCOUNT:          "invoice_discount",
+	PAYMENT_REFUND:            "payment_refund",
+	FEE_WAIVER_REQUEST:        "fee_waiver_request",
+	FEE_WAIVER_APPROVE:        "fee_waiver_approve",
+	FEE_WAIVER_REJECT:         "fee_waiver_reject",
+	FEE_WAIVER_CANCEL:         "fee_waiver_cancel",
+}
+
+type auditEntity struct {
+	PRESCRIPTION  string
+	CLINICAL_NOTE string
+	PATIENT       string
+	APPOINTMENT   string
+	INVOICE       string
+	PAYMENT       string
+	FEE_WAIVER    string
+}
+
+var AuditEntities = auditEntity{
+	PRESCRIPTION:  "prescription",
+	CLINICAL_NOTE: "clinical_note",
+	PATIENT:       "patient",
+	APPOINTMENT:   "appointment",
+	INVOICE:       "invoice",
+	PAYMENT:       "payment",
+	FEE_WAIVER:    "fee_waiver",
+}
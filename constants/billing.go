@@ -0,0 +1,88 @@
+package constants
+
+type chargeCategory struct {
+	CONSULTATION string
+	LAB_TEST     string
+	MEDICATION   string
+	PROCEDURE    string
+	OTHER        string
+}
+
+var ChargeCategories = chargeCategory{
+	CONSULTATION: "consultation",
+	LAB_TEST:     "lab_test",
+	MEDICATION:   "medication",
+	PROCEDURE:    "procedure",
+	OTHER:        "other",
+}
+
+type invoiceStatus struct {
+	DRAFT          string
+	ISSUED         string
+	PARTIALLY_PAID string
+	PAID           string
+	VOID           string
+}
+
+var InvoiceStatus = invoiceStatus{
+	DRAFT:          "draft",
+	ISSUED:         "issued",
+	PARTIALLY_PAID: "partially_paid",
+	PAID:           "paid",
+	VOID:           "void",
+}
+
+// InvoiceSequence is the counter invoices are numbered from. Each branch
+// has its own counter.
+const InvoiceSequence = "invoice"
+
+type paymentMethod struct {
+	CASH      string
+	CARD      string
+	TRANSFER  string
+	INSURANCE string
+}
+
+// PaymentMethods are the ways an invoice can be settled. INSURANCE records a
+// remittance from the patient's insurer.
+var PaymentMethods = paymentMethod{
+	CASH:      "cash",
+	CARD:      "card",
+	TRANSFER:  "transfer",
+	INSURANCE: "insurance",
+}
+
+type cashierShiftStatus struct {
+	OPEN   string
+	CLOSED string
+}
+
+var CashierShiftStatus = cashierShiftStatus{
+	OPEN:   "open",
+	CLOSED: "closed",
+}
+
+// ReceiptSequence is the counter payment receipts are numbered from. Each
+// branch has its own counter.
+const ReceiptSequence = "receipt"
+
+type feeWaiverStatus struct {
+	PENDING   string
+	APPROVED  string
+	REJECTED  string
+	CANCELLED string
+}
+
+var FeeWaiverStatus = feeWaiverStatus{
+	PENDING:   "pending",
+	APPROVED:  "approved",
+	REJECTED:  "rejected",
+	CANCELLED: "cancelled",
+}
+
+// Waivers above either threshold need a second approver. The percentage is
+// of the invoice total before waivers.
+const (
+	DefaultWaiverSecondApprovalAmount  = 50000.0
+	DefaultWaiverSecondApprovalPercent = 50.0
+)
@@ -0,0 +1,120 @@
+package constants
+
+import "time"
+
+// CdsTriggers are the saves that clinical decision support rules run on. A
+// rule is evaluated when a record of its trigger is created or changed.
+type cdsTrigger struct {
+	CLINICAL_NOTE string
+	PRESCRIPTION  string
+	LAB_RESULT    string
+}
+
+var CdsTriggers = cdsTrigger{
+	CLINICAL_NOTE: "clinical_note",
+	PRESCRIPTION:  "prescription",
+	LAB_RESULT:    "lab_result",
+}
+
+// CdsSeverity decides what a firing rule does. Advisory alerts are returned
+// with the saved record; blocking alerts stop the save until they are
+// acknowledged with an override reason.
+type cdsSeverity struct {
+	ADVISORY string
+	BLOCKING string
+}
+
+var CdsSeverity = cdsSeverity{
+	ADVISORY: "advisory",
+	BLOCKING: "blocking",
+}
+
+type cdsOperator struct {
+	EQ       string
+	NEQ      string
+	IN       string
+	CONTAINS string
+	GT       string
+	GTE      string
+	LT       string
+	LTE      string
+}
+
+var CdsOperators = cdsOperator{
+	EQ:       "eq",
+	NEQ:      "neq",
+	IN:       "in",
+	CONTAINS: "contains",
+	GT:       "gt",
+	GTE:      "gte",
+	LT:       "lt",
+	LTE:      "lte",
+}
+
+// cdsFact names the values a rule condition can test. Patient and vitals
+// facts are available on every trigger; the others only on their own.
+type cdsFact struct {
+	PATIENT_GENOTYPE    string
+	PATIENT_BLOOD_GROUP string
+	PATIENT_GENDER      string
+	PATIENT_AGE_YEARS   string
+	PATIENT_AGE_DAYS    string
+	PATIENT_WEIGHT_KG   string
+
+	VITALS_TEMPERATURE_C     string
+	VITALS_PULSE_RATE        string
+	VITALS_RESPIRATORY_RATE  string
+	VITALS_SYSTOLIC_BP       string
+	VITALS_OXYGEN_SATURATION string
+
+	NOTE_TEXT            string
+	NOTE_COMPLAINTS      string
+	NOTE_DIAGNOSIS_CODES string
+
+	PRESCRIPTION_MEDICATION        string
+	PRESCRIPTION_DRUG_CLASS        string
+	PRESCRIPTION_ROUTE             string
+	PRESCRIPTION_DOSE_UNIT         string
+	PRESCRIPTION_DOSE_AMOUNT       string
+	PRESCRIPTION_DOSE_PER_KG       string
+	PRESCRIPTION_DAILY_DOSE_PER_KG string
+
+	LAB_VALUE string
+	LAB_FLAG  string
+	LAB_TEXT  string
+}
+
+var CdsFacts = cdsFact{
+	PATIENT_GENOTYPE:    "patient.genotype",
+	PATIENT_BLOOD_GROUP: "patient.bloodGroup",
+	PATIENT_GENDER:      "patient.gender",
+	PATIENT_AGE_YEARS:   "patient.ageYears",
+	PATIENT_AGE_DAYS:    "patient.ageDays",
+	PATIENT_WEIGHT_KG:   "patient.weightKg",
+
+	VITALS_TEMPERATURE_C:     "vitals.temperatureC",
+	VITALS_PULSE_RATE:        "vitals.pulseRate",
+	VITALS_RESPIRATORY_RATE:  "vitals.respiratoryRate",
+	VITALS_SYSTOLIC_BP:       "vitals.systolicBp",
+	VITALS_OXYGEN_SATURATION: "vitals.oxygenSaturation",
+
+	NOTE_TEXT:            "note.text",
+	NOTE_COMPLAINTS:      "note.complaints",
+	NOTE_DIAGNOSIS_CODES: "note.diagnosisCodes",
+
+	PRESCRIPTION_MEDICATION:        "prescription.medication",
+	PRESCRIPTION_DRUG_CLASS:        "prescription.drugClass",
+	PRESCRIPTION_ROUTE:             "prescription.route",
+	PRESCRIPTION_DOSE_UNIT:         "prescription.doseUnit",
+	PRESCRIPTION_DOSE_AMOUNT:       "prescription.doseAmount",
+	PRESCRIPTION_DOSE_PER_KG:       "prescription.dosePerKg",
+	PRESCRIPTION_DAILY_DOSE_PER_KG: "prescription.dailyDosePerKg",
+
+	LAB_VALUE: "lab.value",
+	LAB_FLAG:  "lab.flag",
+	LAB_TEXT:  "lab.text",
+}
+
+// CdsVitalsWindow is how recent the latest vital signs must be for rules to
+// see them. Older observations say nothing about how the patient presents.
+const CdsVitalsWindow = 24 * time.Hour
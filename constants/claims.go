@@ -0,0 +1,72 @@
+package constants
+
+type claimBatchStatus struct {
+	GENERATED  string
+	SUBMITTED  string
+	RECONCILED string
+}
+
+var ClaimBatchStatus = claimBatchStatus{
+	GENERATED:  "generated",
+	SUBMITTED:  "submitted",
+	RECONCILED: "reconciled",
+}
+
+// ClaimStatus applies to both claims and their lines. A claim is pending
+// while any of its lines awaits the payer's remittance.
+type claimStatus struct {
+	PENDING        string
+	PAID           string
+	PARTIALLY_PAID string
+	REJECTED       string
+}
+
+var ClaimStatus = claimStatus{
+	PENDING:        "pending",
+	PAID:           "paid",
+	PARTIALLY_PAID: "partially_paid",
+	REJECTED:       "rejected",
+}
+
+type claimFileFormat struct {
+	CSV  string
+	JSON string
+}
+
+var ClaimFileFormats = claimFileFormat{
+	CSV:  "csv",
+	JSON: "json",
+}
+
+// claimField names the values a payer's claim layout can export.
+type claimField struct {
+	BATCH_NUMBER   string
+	PAYER_CODE     string
+	INVOICE_NUMBER string
+	LINE_ID        string
+	MEMBER_NUMBER  string
+	PATIENT_NAME   string
+	SERVICE_DATE   string
+	CATEGORY       string
+	DESCRIPTION    string
+	QUANTITY       string
+	AMOUNT_CLAIMED string
+}
+
+var ClaimFields = claimField{
+	BATCH_NUMBER:   "batchNumber",
+	PAYER_CODE:     "payerCode",
+	INVOICE_NUMBER: "invoiceNumber",
+	LINE_ID:        "lineId",
+	MEMBER_NUMBER:  "memberNumber",
+	PATIENT_NAME:   "patientName",
+	SERVICE_DATE:   "serviceDate",
+	CATEGORY:       "category",
+	DESCRIPTION:    "description",
+	QUANTITY:       "quantity",
+	AMOUNT_CLAIMED: "amountClaimed",
+}
+
+// ClaimBatchSequence is the counter claim batches are numbered from. Each
+// branch has its own counter.
+const ClaimBatchSequence = "claim_batch"
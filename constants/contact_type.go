@@ -0,0 +1,16 @@
+package constants
+
+type contactType struct {
+	NEXT_OF_KIN string
+	GUARDIAN    string
+	EMERGENCY   string
+}
+
+var ContactTypes = contactType{
+	NEXT_OF_KIN: "next_of_kin",
+	GUARDIAN:    "guardian",
+	EMERGENCY:   "emergency",
+}
+
+// AgeOfMajority is the age below which a patient must have a guardian contact.
+const AgeOfMajority = 18
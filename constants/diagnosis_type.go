@@ -0,0 +1,11 @@
+package constants
+
+type diagnosisType struct {
+	PRIMARY   string
+	SECONDARY string
+}
+
+var DiagnosisTypes = diagnosisType{
+	PRIMARY:   "primary",
+	SECONDARY: "secondary",
+}
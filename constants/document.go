@@ -0,0 +1,33 @@
+package constants
+
+type documentCategory struct {
+	REFERRAL_LETTER string
+	LAB_RESULT      string
+	IMAGING         string
+	ID_CARD         string
+	INSURANCE_CARD  string
+	CONSENT_FORM    string
+	OTHER           string
+}
+
+var DocumentCategories = documentCategory{
+	REFERRAL_LETTER: "referral_letter",
+	LAB_RESULT:      "lab_result",
+	IMAGING:         "imaging",
+	ID_CARD:         "id_card",
+	INSURANCE_CARD:  "insurance_card",
+	CONSENT_FORM:    "consent_form",
+	OTHER:           "other",
+}
+
+// DocumentContentTypes are the sniffed content types accepted for upload.
+var DocumentContentTypes = []string{
+	"application/pdf",
+	"image/jpeg",
+	"image/png",
+	"image/tiff",
+	"image/webp",
+}
+
+// DefaultDocumentMaxSizeMB applies when DOCUMENT_MAX_SIZE_MB is not set.
+const DefaultDocumentMaxSizeMB = 10
@@ -0,0 +1,59 @@
+package constants
+
+type doseFrequency struct {
+	OD    string
+	BD    string
+	TDS   string
+	QDS   string
+	Q4H   string
+	Q6H   string
+	Q8H   string
+	Q12H  string
+	NOCTE string
+	STAT  string
+	PRN   string
+}
+
+var DoseFrequency = doseFrequency{
+	OD:    "OD",
+	BD:    "BD",
+	TDS:   "TDS",
+	QDS:   "QDS",
+	Q4H:   "Q4H",
+	Q6H:   "Q6H",
+	Q8H:   "Q8H",
+	Q12H:  "Q12H",
+	NOCTE: "NOCTE",
+	STAT:  "STAT",
+	PRN:   "PRN",
+}
+
+// DoseFrequencyLabels spells out the prescription shorthand for printed output.
+var DoseFrequencyLabels = map[string]string{
+	DoseFrequency.OD:    "once daily",
+	DoseFrequency.BD:    "twice daily",
+	DoseFrequency.TDS:   "three times daily",
+	DoseFrequency.QDS:   "four times daily",
+	DoseFrequency.Q4H:   "every 4 hours",
+	DoseFrequency.Q6H:   "every 6 hours",
+	DoseFrequency.Q8H:   "every 8 hours",
+	DoseFrequency.Q12H:  "every 12 hours",
+	DoseFrequency.NOCTE: "at night",
+	DoseFrequency.STAT:  "immediately, once",
+	DoseFrequency.PRN:   "when required",
+}
+
+// DoseScheduleHours are the ward's standard administration times, as hours of
+// the day, for each scheduled frequency. A STAT dose is due when prescribed
+// and PRN doses are given as needed, so neither has set times.
+var DoseScheduleHours = map[string][]int{
+	DoseFrequency.OD:    {8},
+	DoseFrequency.BD:    {8, 20},
+	DoseFrequency.TDS:   {6, 14, 22},
+	DoseFrequency.QDS:   {6, 12, 18, 22},
+	DoseFrequency.Q4H:   {2, 6, 10, 14, 18, 22},
+	DoseFrequency.Q6H:   {0, 6, 12, 18},
+	DoseFrequency.Q8H:   {6, 14, 22},
+	DoseFrequency.Q12H:  {8, 20},
+	DoseFrequency.NOCTE: {22},
+}
@@ -0,0 +1,17 @@
+package constants
+
+type growthFlag struct {
+	WEIGHT_FALTERING string
+	HEIGHT_FALTERING string
+	WEIGHT_LOSS      string
+}
+
+var GrowthFlags = growthFlag{
+	WEIGHT_FALTERING: "weight_faltering",
+	HEIGHT_FALTERING: "height_faltering",
+	WEIGHT_LOSS:      "weight_loss",
+}
+
+// FalteringZScoreDrop is the fall in z-score between two visits, about one
+// major centile space, that is flagged as faltering growth.
+const FalteringZScoreDrop = 0.67
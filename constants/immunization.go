@@ -0,0 +1,38 @@
+package constants
+
+type immunizationStatus struct {
+	GIVEN    string
+	UPCOMING string
+	DUE      string
+	OVERDUE  string
+}
+
+// ImmunizationStatus describes where a scheduled dose stands for a patient.
+var ImmunizationStatus = immunizationStatus{
+	GIVEN:    "given",
+	UPCOMING: "upcoming",
+	DUE:      "due",
+	OVERDUE:  "overdue",
+}
+
+type injectionSite struct {
+	LEFT_ARM    string
+	RIGHT_ARM   string
+	LEFT_THIGH  string
+	RIGHT_THIGH string
+	ORAL        string
+	INTRANASAL  string
+}
+
+var InjectionSites = injectionSite{
+	LEFT_ARM:    "left_arm",
+	RIGHT_ARM:   "right_arm",
+	LEFT_THIGH:  "left_thigh",
+	RIGHT_THIGH: "right_thigh",
+	ORAL:        "oral",
+	INTRANASAL:  "intranasal",
+}
+
+// DefaultOverdueAfterDays is the grace period after a dose's due age before
+// it counts as overdue, when the schedule does not set one.
+const DefaultOverdueAfterDays = 28
@@ -0,0 +1,35 @@
+package constants
+
+type payerType struct {
+	HMO        string
+	INSURER    string
+	GOVERNMENT string
+	CORPORATE  string
+}
+
+var PayerTypes = payerType{
+	HMO:        "hmo",
+	INSURER:    "insurer",
+	GOVERNMENT: "government",
+	CORPORATE:  "corporate",
+}
+
+type coveragePriority struct {
+	PRIMARY   string
+	SECONDARY string
+}
+
+var CoveragePriority = coveragePriority{
+	PRIMARY:   "primary",
+	SECONDARY: "secondary",
+}
+
+type eligibilityStatus struct {
+	ELIGIBLE     string
+	NOT_ELIGIBLE string
+}
+
+var EligibilityStatus = eligibilityStatus{
+	ELIGIBLE:     "eligible",
+	NOT_ELIGIBLE: "not_eligible",
+}
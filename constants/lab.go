@@ -0,0 +1,55 @@
+package constants
+
+type labOrderStatus struct {
+	ORDERED          string
+	SAMPLE_COLLECTED string
+	RESULTED         string
+	VERIFIED         string
+	CANCELLED        string
+}
+
+var LabOrderStatus = labOrderStatus{
+	ORDERED:          "ordered",
+	SAMPLE_COLLECTED: "sample_collected",
+	RESULTED:         "resulted",
+	VERIFIED:         "verified",
+	CANCELLED:        "cancelled",
+}
+
+type labPriority struct {
+	ROUTINE string
+	URGENT  string
+	STAT    string
+}
+
+var LabPriority = labPriority{
+	ROUTINE: "routine",
+	URGENT:  "urgent",
+	STAT:    "stat",
+}
+
+type labResultType struct {
+	NUMERIC string
+	TEXT    string
+}
+
+var LabResultType = labResultType{
+	NUMERIC: "numeric",
+	TEXT:    "text",
+}
+
+type labResultFlag struct {
+	NORMAL        string
+	LOW           string
+	HIGH          string
+	CRITICAL_LOW  string
+	CRITICAL_HIGH string
+}
+
+var LabResultFlag = labResultFlag{
+	NORMAL:        "normal",
+	LOW:           "low",
+	HIGH:          "high",
+	CRITICAL_LOW:  "critical_low",
+	CRITICAL_HIGH: "critical_high",
+}
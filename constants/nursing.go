@@ -0,0 +1,54 @@
+package constants
+
+import "time"
+
+type nursingTaskType struct {
+	MEDICATION        string
+	SAMPLE_COLLECTION string
+	VITAL_SIGNS       string
+}
+
+var NursingTaskTypes = nursingTaskType{
+	MEDICATION:        "medication",
+	SAMPLE_COLLECTION: "sample_collection",
+	VITAL_SIGNS:       "vital_signs",
+}
+
+// VitalSignsInterval is how often admitted patients are due observations.
+const VitalSignsInterval = 4 * time.Hour
+
+type administrationStatus struct {
+	GIVEN   string
+	HELD    string
+	REFUSED string
+}
+
+var AdministrationStatus = administrationStatus{
+	GIVEN:   "given",
+	HELD:    "held",
+	REFUSED: "refused",
+}
+
+// marDoseStatus extends the administration statuses with the states of a
+// scheduled dose that has not been charted yet.
+type marDoseStatus struct {
+	UPCOMING string
+	DUE      string
+	OVERDUE  string
+}
+
+var MarDoseStatus = marDoseStatus{
+	UPCOMING: "upcoming",
+	DUE:      "due",
+	OVERDUE:  "overdue",
+}
+
+// DefaultMarOverdueMinutes applies when MAR_OVERDUE_MINUTES is not set.
+const DefaultMarOverdueMinutes = 60
+
+// MarLookback is how far back the ward dashboard and task list look for
+// doses that were never charted.
+const MarLookback = 24 * time.Hour
+
+// NursingTaskLookahead lists doses on the task list this far ahead of time.
+const NursingTaskLookahead = 2 * time.Hour
@@ -0,0 +1,14 @@
+package constants
+
+type stockMovementType struct {
+	RECEIPT  string
+	DISPENSE string
+}
+
+var StockMovementTypes = stockMovementType{
+	RECEIPT:  "receipt",
+	DISPENSE: "dispense",
+}
+
+// DefaultExpiryAlertDays applies when PHARMACY_EXPIRY_ALERT_DAYS is not set.
+const DefaultExpiryAlertDays = 90
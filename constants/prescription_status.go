@@ -0,0 +1,13 @@
+package constants
+
+type prescriptionStatus struct {
+	ACTIVE       string
+	DISPENSED    string
+	DISCONTINUED string
+}
+
+var PrescriptionStatus = prescriptionStatus{
+	ACTIVE:       "active",
+	DISPENSED:    "dispensed",
+	DISCONTINUED: "discontinued",
+}
@@ -0,0 +1,13 @@
+package constants
+
+type problemStatus struct {
+	ACTIVE   string
+	INACTIVE string
+	RESOLVED string
+}
+
+var ProblemStatus = problemStatus{
+	ACTIVE:   "active",
+	INACTIVE: "inactive",
+	RESOLVED: "resolved",
+}
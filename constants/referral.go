@@ -0,0 +1,29 @@
+package constants
+
+type referralStatus struct {
+	PENDING   string
+	ACCEPTED  string
+	SCHEDULED string
+	COMPLETED string
+	DECLINED  string
+}
+
+var ReferralStatus = referralStatus{
+	PENDING:   "pending",
+	ACCEPTED:  "accepted",
+	SCHEDULED: "scheduled",
+	COMPLETED: "completed",
+	DECLINED:  "declined",
+}
+
+type referralUrgency struct {
+	ROUTINE   string
+	URGENT    string
+	EMERGENCY string
+}
+
+var ReferralUrgency = referralUrgency{
+	ROUTINE:   "routine",
+	URGENT:    "urgent",
+	EMERGENCY: "emergency",
+}
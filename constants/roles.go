@@ -1,13 +1,21 @@
 package constants
 
 type role struct {
-	ADMIN        string
-	DOCTOR       string
-	RECEPTIONIST string
+	ADMIN           string
+	DOCTOR          string
+	RECEPTIONIST    string
+	NURSE           string
+	PHARMACIST      string
+	CASHIER         string
+	FINANCE_MANAGER string
 }
 
 var Roles = role{
-	ADMIN:        "admin",
-	DOCTOR:       "doctor",
-	RECEPTIONIST: "receptionist",
+	ADMIN:           "admin",
+	DOCTOR:          "doctor",
+	RECEPTIONIST:    "receptionist",
+	NURSE:           "nurse",
+	PHARMACIST:      "pharmacist",
+	CASHIER:         "cashier",
+	FINANCE_MANAGER: "finance_manager",
 }
@@ -0,0 +1,19 @@
+package constants
+
+type timelineEventType struct {
+	APPOINTMENT      string
+	CLINICAL_NOTE    string
+	STATUS_CHANGE    string
+	DEMOGRAPHIC_EDIT string
+	VITAL_SIGNS      string
+	NURSING_NOTE     string
+}
+
+var TimelineEventTypes = timelineEventType{
+	APPOINTMENT:      "appointment",
+	CLINICAL_NOTE:    "clinical_note",
+	STATUS_CHANGE:    "status_change",
+	DEMOGRAPHIC_EDIT: "demographic_edit",
+	VITAL_SIGNS:      "vital_signs",
+	NURSING_NOTE:     "nursing_note",
+}
@@ -0,0 +1,132 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/responses"
+	"github.com/ofojichigozie/hms-go-backend/services"
+)
+
+type AdmissionController struct {
+	admissionService services.AdmissionService
+}
+
+func NewAdmissionController(admissionService services.AdmissionService) *AdmissionController {
+	return &AdmissionController{admissionService}
+}
+
+func (ac *AdmissionController) AdmitPatient(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	var input models.AdmitPatientInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	admission, err := ac.admissionService.AdmitPatient(input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to admit patient", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusCreated, "Patient admitted successfully", admission)
+}
+
+func (ac *AdmissionController) GetAdmissions(ctx *gin.Context) {
+	var query models.AdmissionQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+	query.Normalize()
+
+	admissions, total, err := ac.admissionService.GetAdmissions(query)
+	if err != nil {
+		responses.Error(ctx, http.StatusInternalServerError, "Failed to retrieve admissions", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Admissions retrieved successfully",
+		responses.NewPage(admissions, query.Page, query.PageSize, total))
+}
+
+func (ac *AdmissionController) GetAdmissionByID(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid admission ID", "Admission ID must be a positive integer")
+		return
+	}
+
+	admission, err := ac.admissionService.GetAdmissionByID(uint(id))
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Admission not found", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Admission retrieved successfully", admission)
+}
+
+func (ac *AdmissionController) TransferPatient(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid admission ID", "Admission ID must be a positive integer")
+		return
+	}
+
+	var input models.TransferInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	admission, err := ac.admissionService.TransferPatient(uint(id), input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to transfer patient", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Patient transferred successfully", admission)
+}
+
+func (ac *AdmissionController) DischargePatient(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid admission ID", "Admission ID must be a positive integer")
+		return
+	}
+
+	var input models.DischargeInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	admission, err := ac.admissionService.DischargePatient(uint(id), input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to discharge patient", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Patient discharged successfully", admission)
+}
@@ -0,0 +1,98 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/responses"
+	"github.com/ofojichigozie/hms-go-backend/services"
+)
+
+type AllergyController struct {
+	allergyService services.AllergyService
+}
+
+func NewAllergyController(allergyService services.AllergyService) *AllergyController {
+	return &AllergyController{allergyService}
+}
+
+func (ac *AllergyController) CreateAllergy(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	patientID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid patient ID", "Patient ID must be a positive integer")
+		return
+	}
+
+	var input models.CreateAllergyInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	allergy, err := ac.allergyService.CreateAllergy(uint(patientID), input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to record allergy", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusCreated, "Allergy recorded successfully", allergy)
+}
+
+func (ac *AllergyController) GetAllergies(ctx *gin.Context) {
+	patientID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid patient ID", "Patient ID must be a positive integer")
+		return
+	}
+
+	allergies, err := ac.allergyService.GetAllergiesByPatientID(uint(patientID))
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Failed to retrieve allergies", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Allergies retrieved successfully", allergies)
+}
+
+func (ac *AllergyController) UpdateAllergy(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	patientID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid patient ID", "Patient ID must be a positive integer")
+		return
+	}
+
+	allergyID, err := strconv.ParseUint(ctx.Param("allergyId"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid allergy ID", "Allergy ID must be a positive integer")
+		return
+	}
+
+	var input models.UpdateAllergyInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid input", err.Error())
+		return
+	}
+
+	allergy, err := ac.allergyService.UpdateAllergy(uint(patientID), uint(allergyID), input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to update allergy", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Allergy updated successfully", allergy)
+}
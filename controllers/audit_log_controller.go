@@ -0,0 +1,45 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/responses"
+	"github.com/ofojichigozie/hms-go-backend/services"
+)
+
+type AuditLogController struct {
+	auditLogService services.AuditLogService
+}
+
+func NewAuditLogController(auditLogService services.AuditLogService) *AuditLogController {
+	return &AuditLogController{auditLogService}
+}
+
+func (ac *AuditLogController) GetAuditLogs(ctx *gin.Context) {
+	filters := make(map[string]interface{})
+
+	if patientID := ctx.Query("patientId"); patientID != "" {
+		filters["patient_id"] = patientID
+	}
+	if staffID := ctx.Query("staffId"); staffID != "" {
+		filters["staff_id"] = staffID
+	}
+	if action := ctx.Query("action"); action != "" {
+		filters["action"] = action
+	}
+	if entityType := ctx.Query("entityType"); entityType != "" {
+		filters["entity_type"] = entityType
+	}
+	if entityID := ctx.Query("entityId"); entityID != "" {
+		filters["entity_id"] = entityID
+	}
+
+	entries, err := ac.auditLogService.GetAuditLogs(filters)
+	if err != nil {
+		responses.Error(ctx, http.StatusInternalServerError, "Failed to fetch audit logs", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Audit logs retrieved successfully", entries)
+}
@@ -0,0 +1,114 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/responses"
+	"github.com/ofojichigozie/hms-go-backend/services"
+)
+
+type CashierShiftController struct {
+	cashierShiftService services.CashierShiftService
+}
+
+func NewCashierShiftController(cashierShiftService services.CashierShiftService) *CashierShiftController {
+	return &CashierShiftController{cashierShiftService}
+}
+
+func (cc *CashierShiftController) OpenShift(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	var input models.OpenCashierShiftInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	shift, err := cc.cashierShiftService.OpenShift(input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to open shift", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusCreated, "Shift opened successfully", shift)
+}
+
+func (cc *CashierShiftController) GetCurrentShift(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	report, err := cc.cashierShiftService.GetCurrentShift(currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Shift not found", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Shift retrieved successfully", report)
+}
+
+func (cc *CashierShiftController) CloseShift(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	var input models.CloseCashierShiftInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	report, err := cc.cashierShiftService.CloseShift(input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to close shift", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Shift closed successfully", report)
+}
+
+func (cc *CashierShiftController) GetShifts(ctx *gin.Context) {
+	var query models.CashierShiftQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+	query.Normalize()
+
+	shifts, total, err := cc.cashierShiftService.GetShifts(query)
+	if err != nil {
+		responses.Error(ctx, http.StatusInternalServerError, "Failed to retrieve shifts", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Shifts retrieved successfully",
+		responses.NewPage(shifts, query.Page, query.PageSize, total))
+}
+
+func (cc *CashierShiftController) GetShiftByID(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid shift ID", "Shift ID must be a positive integer")
+		return
+	}
+
+	report, err := cc.cashierShiftService.GetShiftReport(uint(id))
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Shift not found", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Shift retrieved successfully", report)
+}
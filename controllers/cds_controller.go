@@ -0,0 +1,158 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/responses"
+	"github.com/ofojichigozie/hms-go-backend/services"
+)
+
+type CdsController struct {
+	cdsService services.CdsService
+}
+
+func NewCdsController(cdsService services.CdsService) *CdsController {
+	return &CdsController{cdsService}
+}
+
+func (cc *CdsController) CreateRule(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	var input models.CreateCdsRuleInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	rule, err := cc.cdsService.CreateRule(input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to create rule", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusCreated, "Rule created successfully", rule)
+}
+
+func (cc *CdsController) GetRules(ctx *gin.Context) {
+	var query models.CdsRuleQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	rules, err := cc.cdsService.GetRules(query)
+	if err != nil {
+		responses.Error(ctx, http.StatusInternalServerError, "Failed to retrieve rules", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Rules retrieved successfully", rules)
+}
+
+func (cc *CdsController) GetRuleByID(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid rule ID", "Rule ID must be a positive integer")
+		return
+	}
+
+	rule, err := cc.cdsService.GetRuleByID(uint(id))
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Rule not found", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Rule retrieved successfully", rule)
+}
+
+func (cc *CdsController) UpdateRule(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid rule ID", "Rule ID must be a positive integer")
+		return
+	}
+
+	var input models.UpdateCdsRuleInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	rule, err := cc.cdsService.UpdateRule(uint(id), input)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to update rule", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Rule updated successfully", rule)
+}
+
+func (cc *CdsController) GetAlerts(ctx *gin.Context) {
+	var query models.CdsAlertQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+	query.Normalize()
+
+	alerts, total, err := cc.cdsService.GetAlerts(query)
+	if err != nil {
+		responses.Error(ctx, http.StatusInternalServerError, "Failed to retrieve alerts", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Alerts retrieved successfully",
+		responses.NewPage(alerts, query.Page, query.PageSize, total))
+}
+
+func (cc *CdsController) GetAlertByID(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid alert ID", "Alert ID must be a positive integer")
+		return
+	}
+
+	alert, err := cc.cdsService.GetAlertByID(uint(id))
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Alert not found", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Alert retrieved successfully", alert)
+}
+
+func (cc *CdsController) AcknowledgeAlert(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid alert ID", "Alert ID must be a positive integer")
+		return
+	}
+
+	var input models.AcknowledgeCdsAlertInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	alert, err := cc.cdsService.AcknowledgeAlert(uint(id), input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to acknowledge alert", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Alert acknowledged successfully", alert)
+}
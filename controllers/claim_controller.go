@@ -0,0 +1,197 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/responses"
+	"github.com/ofojichigozie/hms-go-backend/services"
+)
+
+type ClaimController struct {
+	claimService services.ClaimService
+}
+
+func NewClaimController(claimService services.ClaimService) *ClaimController {
+	return &ClaimController{claimService}
+}
+
+func (cc *ClaimController) GenerateBatch(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	var input models.GenerateClaimBatchInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	batch, err := cc.claimService.GenerateBatch(input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to generate claim batch", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusCreated, "Claim batch generated successfully", batch)
+}
+
+func (cc *ClaimController) GetBatches(ctx *gin.Context) {
+	var query models.ClaimBatchQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+	query.Normalize()
+
+	batches, total, err := cc.claimService.GetBatches(query)
+	if err != nil {
+		responses.Error(ctx, http.StatusInternalServerError, "Failed to retrieve claim batches", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Claim batches retrieved successfully",
+		responses.NewPage(batches, query.Page, query.PageSize, total))
+}
+
+func (cc *ClaimController) GetBatchByID(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid claim batch ID", "Claim batch ID must be a positive integer")
+		return
+	}
+
+	batch, err := cc.claimService.GetBatchByID(uint(id))
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Claim batch not found", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Claim batch retrieved successfully", batch)
+}
+
+func (cc *ClaimController) ExportBatch(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid claim batch ID", "Claim batch ID must be a positive integer")
+		return
+	}
+
+	var query models.ClaimExportQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	file, err := cc.claimService.ExportBatch(uint(id), query.Format)
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Failed to export claim batch", err.Error())
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", file.FileName))
+	ctx.Data(http.StatusOK, file.ContentType, file.Content)
+}
+
+func (cc *ClaimController) SubmitBatch(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid claim batch ID", "Claim batch ID must be a positive integer")
+		return
+	}
+
+	batch, err := cc.claimService.SubmitBatch(uint(id), currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to submit claim batch", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Claim batch submitted successfully", batch)
+}
+
+func (cc *ClaimController) ImportRemittance(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid claim batch ID", "Claim batch ID must be a positive integer")
+		return
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", "A CSV or JSON file is required in the 'file' field")
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to read uploaded file", err.Error())
+		return
+	}
+	defer file.Close()
+
+	format := constants.ClaimFileFormats.CSV
+	if strings.EqualFold(filepath.Ext(fileHeader.Filename), ".json") {
+		format = constants.ClaimFileFormats.JSON
+	}
+
+	result, err := cc.claimService.ImportRemittance(uint(id), file, format)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to import remittance", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Remittance imported successfully", result)
+}
+
+func (cc *ClaimController) GetClaimLayout(ctx *gin.Context) {
+	payerID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid payer ID", "Payer ID must be a positive integer")
+		return
+	}
+
+	layout, err := cc.claimService.GetClaimLayout(uint(payerID))
+	if err != nil {
+		responses.Error(ctx, http.StatusInternalServerError, "Failed to retrieve claim layout", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Claim layout retrieved successfully", layout)
+}
+
+func (cc *ClaimController) SaveClaimLayout(ctx *gin.Context) {
+	payerID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid payer ID", "Payer ID must be a positive integer")
+		return
+	}
+
+	var input models.SaveClaimLayoutInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	layout, err := cc.claimService.SaveClaimLayout(uint(payerID), input)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to save claim layout", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Claim layout saved successfully", layout)
+}
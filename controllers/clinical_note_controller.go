@@ -34,6 +34,9 @@ func (c *ClinicalNoteController) CreateNote(ctx *gin.Context) {
 
 	note, err := c.clinicalNoteService.CreateNote(input, currentStaff.ID)
 	if err != nil {
+		if respondAllergyConflict(ctx, err) || respondCdsAlerts(ctx, err) {
+			return
+		}
 		responses.Error(ctx, http.StatusBadRequest, "Failed to create clinical note", err.Error())
 		return
 	}
@@ -96,6 +99,9 @@ func (c *ClinicalNoteController) UpdateNote(ctx *gin.Context) {
 
 	note, err := c.clinicalNoteService.UpdateNote(uint(clinicalNoteId), input, currentStaff.ID)
 	if err != nil {
+		if respondAllergyConflict(ctx, err) || respondCdsAlerts(ctx, err) {
+			return
+		}
 		responses.Error(ctx, http.StatusBadRequest, "Failed to update clinical note", err.Error())
 		return
 	}
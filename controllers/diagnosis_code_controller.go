@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/responses"
+	"github.com/ofojichigozie/hms-go-backend/services"
+)
+
+type DiagnosisCodeController struct {
+	diagnosisCodeService services.DiagnosisCodeService
+}
+
+func NewDiagnosisCodeController(diagnosisCodeService services.DiagnosisCodeService) *DiagnosisCodeController {
+	return &DiagnosisCodeController{diagnosisCodeService}
+}
+
+func (dc *DiagnosisCodeController) SearchCodes(ctx *gin.Context) {
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "0"))
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid limit", "Limit must be an integer")
+		return
+	}
+
+	codes, err := dc.diagnosisCodeService.SearchCodes(ctx.Query("q"), limit)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to search diagnosis codes", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Diagnosis codes retrieved successfully", codes)
+}
+
+func (dc *DiagnosisCodeController) GetByCode(ctx *gin.Context) {
+	code, err := dc.diagnosisCodeService.GetByCode(ctx.Param("code"))
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Diagnosis code not found", nil)
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Diagnosis code retrieved successfully", code)
+}
+
+func (dc *DiagnosisCodeController) ImportCSV(ctx *gin.Context) {
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", "A CSV file is required in the 'file' field")
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to read uploaded file", err.Error())
+		return
+	}
+	defer file.Close()
+
+	count, err := dc.diagnosisCodeService.ImportCSV(file)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to import diagnosis codes", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Diagnosis codes imported successfully", gin.H{"imported": count})
+}
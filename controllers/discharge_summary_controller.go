@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"text/template"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/pdf"
+	"github.com/ofojichigozie/hms-go-backend/responses"
+	"github.com/ofojichigozie/hms-go-backend/services"
+)
+
+type DischargeSummaryController struct {
+	summaryService  services.DischargeSummaryService
+	summaryTemplate *template.Template
+}
+
+func NewDischargeSummaryController(summaryService services.DischargeSummaryService, summaryTemplate *template.Template) *DischargeSummaryController {
+	return &DischargeSummaryController{summaryService, summaryTemplate}
+}
+
+func (dc *DischargeSummaryController) GetNoteSummary(ctx *gin.Context) {
+	noteID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid note ID", "Note ID must be a positive integer")
+		return
+	}
+
+	document, err := dc.summaryService.GetNoteSummary(uint(noteID))
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Failed to generate summary", err.Error())
+		return
+	}
+
+	dc.render(ctx, document, fmt.Sprintf("encounter-summary-%d.pdf", noteID))
+}
+
+func (dc *DischargeSummaryController) GetAdmissionSummary(ctx *gin.Context) {
+	admissionID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid admission ID", "Admission ID must be a positive integer")
+		return
+	}
+
+	document, err := dc.summaryService.GetAdmissionSummary(uint(admissionID))
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to generate summary", err.Error())
+		return
+	}
+
+	dc.render(ctx, document, fmt.Sprintf("discharge-summary-%d.pdf", admissionID))
+}
+
+func (dc *DischargeSummaryController) render(ctx *gin.Context, document *services.SummaryDocument, fileName string) {
+	var markup bytes.Buffer
+	if err := dc.summaryTemplate.Execute(&markup, document); err != nil {
+		responses.Error(ctx, http.StatusInternalServerError, "Failed to render summary", err.Error())
+		return
+	}
+
+	title := fmt.Sprintf("%s - %s %s", document.Title, document.Patient.FirstName, document.Patient.LastName)
+	ctx.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", fileName))
+	ctx.Data(http.StatusOK, "application/pdf", pdf.FromMarkup(title, markup.String()).Bytes())
+}
@@ -0,0 +1,148 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/responses"
+	"github.com/ofojichigozie/hms-go-backend/services"
+)
+
+type FeeWaiverController struct {
+	feeWaiverService services.FeeWaiverService
+}
+
+func NewFeeWaiverController(feeWaiverService services.FeeWaiverService) *FeeWaiverController {
+	return &FeeWaiverController{feeWaiverService}
+}
+
+func (fc *FeeWaiverController) RequestWaiver(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	var input models.CreateFeeWaiverInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	waiver, err := fc.feeWaiverService.RequestWaiver(input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to request fee waiver", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusCreated, "Fee waiver requested successfully", waiver)
+}
+
+func (fc *FeeWaiverController) GetWaivers(ctx *gin.Context) {
+	var query models.FeeWaiverQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+	query.Normalize()
+
+	waivers, total, err := fc.feeWaiverService.GetWaivers(query)
+	if err != nil {
+		responses.Error(ctx, http.StatusInternalServerError, "Failed to retrieve fee waivers", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Fee waivers retrieved successfully",
+		responses.NewPage(waivers, query.Page, query.PageSize, total))
+}
+
+func (fc *FeeWaiverController) GetWaiverByID(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid fee waiver ID", "Fee waiver ID must be a positive integer")
+		return
+	}
+
+	waiver, err := fc.feeWaiverService.GetWaiverByID(uint(id))
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Fee waiver not found", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Fee waiver retrieved successfully", waiver)
+}
+
+func (fc *FeeWaiverController) ApproveWaiver(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid fee waiver ID", "Fee waiver ID must be a positive integer")
+		return
+	}
+
+	waiver, err := fc.feeWaiverService.ApproveWaiver(uint(id), currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to approve fee waiver", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Fee waiver approved successfully", waiver)
+}
+
+func (fc *FeeWaiverController) RejectWaiver(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid fee waiver ID", "Fee waiver ID must be a positive integer")
+		return
+	}
+
+	var input models.RejectFeeWaiverInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	waiver, err := fc.feeWaiverService.RejectWaiver(uint(id), input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to reject fee waiver", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Fee waiver rejected successfully", waiver)
+}
+
+func (fc *FeeWaiverController) CancelWaiver(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid fee waiver ID", "Fee waiver ID must be a positive integer")
+		return
+	}
+
+	waiver, err := fc.feeWaiverService.CancelWaiver(uint(id), currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to cancel fee waiver", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Fee waiver cancelled successfully", waiver)
+}
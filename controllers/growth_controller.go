@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/responses"
+	"github.com/ofojichigozie/hms-go-backend/services"
+)
+
+type GrowthController struct {
+	growthService services.GrowthService
+}
+
+func NewGrowthController(growthService services.GrowthService) *GrowthController {
+	return &GrowthController{growthService}
+}
+
+func (gc *GrowthController) RecordMeasurement(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	patientID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid patient ID", "Patient ID must be a positive integer")
+		return
+	}
+
+	var input models.RecordGrowthInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	measurement, err := gc.growthService.RecordMeasurement(uint(patientID), input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to record measurement", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusCreated, "Measurement recorded successfully", measurement)
+}
+
+func (gc *GrowthController) GetGrowthChart(ctx *gin.Context) {
+	patientID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid patient ID", "Patient ID must be a positive integer")
+		return
+	}
+
+	chart, err := gc.growthService.GetGrowthChart(uint(patientID))
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Failed to retrieve growth chart", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Growth chart retrieved successfully", chart)
+}
@@ -0,0 +1,55 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/responses"
+	"github.com/ofojichigozie/hms-go-backend/services"
+)
+
+// respondAllergyConflict writes a 409 carrying the matched allergies when err
+// is an allergy conflict, and reports whether it handled the error.
+func respondAllergyConflict(ctx *gin.Context, err error) bool {
+	var conflict *services.AllergyConflictError
+	if !errors.As(err, &conflict) {
+		return false
+	}
+
+	responses.Error(ctx, http.StatusConflict, "Allergy warning requires override", gin.H{
+		"reason": conflict.Error(),
+		"alerts": conflict.Alerts,
+	})
+	return true
+}
+
+// respondCdsAlerts writes a 409 carrying the alerts when err is a blocking
+// decision support alert, and reports whether it handled the error.
+func respondCdsAlerts(ctx *gin.Context, err error) bool {
+	var blocked *services.CdsAlertError
+	if !errors.As(err, &blocked) {
+		return false
+	}
+
+	responses.Error(ctx, http.StatusConflict, "Clinical alert requires acknowledgement", gin.H{
+		"reason": blocked.Error(),
+		"alerts": blocked.Alerts,
+	})
+	return true
+}
+
+// respondDuplicatePatients writes a 409 listing the possible duplicates when
+// err is a duplicate patient error, and reports whether it handled the error.
+func respondDuplicatePatients(ctx *gin.Context, err error) bool {
+	var duplicate *services.DuplicatePatientError
+	if !errors.As(err, &duplicate) {
+		return false
+	}
+
+	responses.Error(ctx, http.StatusConflict, "Possible duplicate patient", gin.H{
+		"reason":     duplicate.Error(),
+		"candidates": duplicate.Candidates,
+	})
+	return true
+}
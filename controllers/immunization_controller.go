@@ -0,0 +1,98 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/responses"
+	"github.com/ofojichigozie/hms-go-backend/services"
+)
+
+type ImmunizationController struct {
+	immunizationService services.ImmunizationService
+}
+
+func NewImmunizationController(immunizationService services.ImmunizationService) *ImmunizationController {
+	return &ImmunizationController{immunizationService}
+}
+
+func (ic *ImmunizationController) RecordImmunization(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	patientID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid patient ID", "Patient ID must be a positive integer")
+		return
+	}
+
+	var input models.RecordImmunizationInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	immunization, err := ic.immunizationService.RecordImmunization(uint(patientID), input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to record immunization", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusCreated, "Immunization recorded successfully", immunization)
+}
+
+func (ic *ImmunizationController) GetImmunizations(ctx *gin.Context) {
+	patientID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid patient ID", "Patient ID must be a positive integer")
+		return
+	}
+
+	immunizations, err := ic.immunizationService.GetImmunizationsByPatientID(uint(patientID))
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Failed to retrieve immunizations", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Immunizations retrieved successfully", immunizations)
+}
+
+func (ic *ImmunizationController) GetSchedule(ctx *gin.Context) {
+	patientID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid patient ID", "Patient ID must be a positive integer")
+		return
+	}
+
+	schedule, err := ic.immunizationService.GetSchedule(uint(patientID))
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Failed to retrieve immunization schedule", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Immunization schedule retrieved successfully", schedule)
+}
+
+func (ic *ImmunizationController) GetOverdue(ctx *gin.Context) {
+	var query models.OverdueImmunizationQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+	query.Normalize()
+
+	overdue, total, err := ic.immunizationService.GetOverdue(query)
+	if err != nil {
+		responses.Error(ctx, http.StatusInternalServerError, "Failed to retrieve overdue immunizations", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Overdue immunizations retrieved successfully",
+		responses.NewPage(overdue, query.Page, query.PageSize, total))
+}
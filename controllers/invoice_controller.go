@@ -0,0 +1,232 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/responses"
+	"github.com/ofojichigozie/hms-go-backend/services"
+)
+
+type InvoiceController struct {
+	invoiceService services.InvoiceService
+}
+
+func NewInvoiceController(invoiceService services.InvoiceService) *InvoiceController {
+	return &InvoiceController{invoiceService}
+}
+
+func (ic *InvoiceController) CreateInvoice(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	var input models.CreateInvoiceInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	invoice, err := ic.invoiceService.CreateInvoice(input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to create invoice", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusCreated, "Invoice created successfully", invoice)
+}
+
+func (ic *InvoiceController) GetInvoices(ctx *gin.Context) {
+	var query models.InvoiceQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+	query.Normalize()
+
+	invoices, total, err := ic.invoiceService.GetInvoices(query)
+	if err != nil {
+		responses.Error(ctx, http.StatusInternalServerError, "Failed to retrieve invoices", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Invoices retrieved successfully",
+		responses.NewPage(invoices, query.Page, query.PageSize, total))
+}
+
+func (ic *InvoiceController) GetInvoiceByID(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid invoice ID", "Invoice ID must be a positive integer")
+		return
+	}
+
+	invoice, err := ic.invoiceService.GetInvoiceByID(uint(id))
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Invoice not found", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Invoice retrieved successfully", invoice)
+}
+
+func (ic *InvoiceController) AddLine(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid invoice ID", "Invoice ID must be a positive integer")
+		return
+	}
+
+	var input models.AddInvoiceLineInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	invoice, err := ic.invoiceService.AddLine(uint(id), input)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to add invoice line", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusCreated, "Invoice line added successfully", invoice)
+}
+
+func (ic *InvoiceController) UpdateLine(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid invoice ID", "Invoice ID must be a positive integer")
+		return
+	}
+
+	lineID, err := strconv.ParseUint(ctx.Param("lineId"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid invoice line ID", "Invoice line ID must be a positive integer")
+		return
+	}
+
+	var input models.UpdateInvoiceLineInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	invoice, err := ic.invoiceService.UpdateLine(uint(id), uint(lineID), input)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to update invoice line", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Invoice line updated successfully", invoice)
+}
+
+func (ic *InvoiceController) DiscountLine(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid invoice ID", "Invoice ID must be a positive integer")
+		return
+	}
+
+	lineID, err := strconv.ParseUint(ctx.Param("lineId"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid invoice line ID", "Invoice line ID must be a positive integer")
+		return
+	}
+
+	var input models.DiscountInvoiceLineInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	invoice, err := ic.invoiceService.DiscountLine(uint(id), uint(lineID), input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to discount invoice line", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Invoice line discounted successfully", invoice)
+}
+
+func (ic *InvoiceController) RemoveLine(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid invoice ID", "Invoice ID must be a positive integer")
+		return
+	}
+
+	lineID, err := strconv.ParseUint(ctx.Param("lineId"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid invoice line ID", "Invoice line ID must be a positive integer")
+		return
+	}
+
+	invoice, err := ic.invoiceService.RemoveLine(uint(id), uint(lineID))
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to remove invoice line", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Invoice line removed successfully", invoice)
+}
+
+func (ic *InvoiceController) IssueInvoice(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid invoice ID", "Invoice ID must be a positive integer")
+		return
+	}
+
+	invoice, err := ic.invoiceService.IssueInvoice(uint(id), currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to issue invoice", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Invoice issued successfully", invoice)
+}
+
+func (ic *InvoiceController) VoidInvoice(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid invoice ID", "Invoice ID must be a positive integer")
+		return
+	}
+
+	var input models.VoidInvoiceInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	invoice, err := ic.invoiceService.VoidInvoice(uint(id), input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to void invoice", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Invoice voided successfully", invoice)
+}
@@ -0,0 +1,224 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/responses"
+	"github.com/ofojichigozie/hms-go-backend/services"
+)
+
+type LabOrderController struct {
+	labOrderService services.LabOrderService
+}
+
+func NewLabOrderController(labOrderService services.LabOrderService) *LabOrderController {
+	return &LabOrderController{labOrderService}
+}
+
+func (lc *LabOrderController) CreateLabOrder(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	var input models.CreateLabOrderInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	order, err := lc.labOrderService.CreateLabOrder(input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to create lab order", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusCreated, "Lab order created successfully", order)
+}
+
+func (lc *LabOrderController) GetAllLabOrders(ctx *gin.Context) {
+	filters := make(map[string]interface{})
+
+	if patientID := ctx.Query("patientId"); patientID != "" {
+		filters["patient_id"] = patientID
+	}
+	if orderedBy := ctx.Query("orderedBy"); orderedBy != "" {
+		filters["ordered_by"] = orderedBy
+	}
+	if status := ctx.Query("status"); status != "" {
+		filters["status"] = status
+	}
+	if priority := ctx.Query("priority"); priority != "" {
+		filters["priority"] = priority
+	}
+
+	orders, err := lc.labOrderService.GetAllLabOrders(filters)
+	if err != nil {
+		responses.Error(ctx, http.StatusInternalServerError, "Failed to retrieve lab orders", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Lab orders retrieved successfully", orders)
+}
+
+func (lc *LabOrderController) GetLabOrderByID(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid lab order ID", "Lab order ID must be a positive integer")
+		return
+	}
+
+	order, err := lc.labOrderService.GetLabOrderByID(uint(id))
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Lab order not found", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Lab order retrieved successfully", order)
+}
+
+func (lc *LabOrderController) CollectSample(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid lab order ID", "Lab order ID must be a positive integer")
+		return
+	}
+
+	order, err := lc.labOrderService.CollectSample(uint(id), currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to record sample collection", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Sample collection recorded successfully", order)
+}
+
+func (lc *LabOrderController) EnterResults(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid lab order ID", "Lab order ID must be a positive integer")
+		return
+	}
+
+	var input models.EnterLabResultsInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	order, err := lc.labOrderService.EnterResults(uint(id), input, currentStaff.ID)
+	if err != nil {
+		if respondCdsAlerts(ctx, err) {
+			return
+		}
+		responses.Error(ctx, http.StatusBadRequest, "Failed to record results", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Results recorded successfully", order)
+}
+
+func (lc *LabOrderController) VerifyResults(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid lab order ID", "Lab order ID must be a positive integer")
+		return
+	}
+
+	order, err := lc.labOrderService.VerifyResults(uint(id), currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to verify results", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Results verified successfully", order)
+}
+
+func (lc *LabOrderController) CancelLabOrder(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid lab order ID", "Lab order ID must be a positive integer")
+		return
+	}
+
+	var input models.CancelLabOrderInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	order, err := lc.labOrderService.CancelLabOrder(uint(id), input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to cancel lab order", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Lab order cancelled successfully", order)
+}
+
+func (lc *LabOrderController) GetResultsInbox(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	orders, err := lc.labOrderService.GetResultsInbox(currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusInternalServerError, "Failed to retrieve results inbox", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Results inbox retrieved successfully", orders)
+}
+
+func (lc *LabOrderController) AcknowledgeResults(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid lab order ID", "Lab order ID must be a positive integer")
+		return
+	}
+
+	order, err := lc.labOrderService.AcknowledgeResults(uint(id), currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to acknowledge results", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Results acknowledged successfully", order)
+}
@@ -0,0 +1,85 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/responses"
+	"github.com/ofojichigozie/hms-go-backend/services"
+)
+
+type LabTestController struct {
+	labTestService services.LabTestService
+}
+
+func NewLabTestController(labTestService services.LabTestService) *LabTestController {
+	return &LabTestController{labTestService}
+}
+
+func (lc *LabTestController) CreateLabTest(ctx *gin.Context) {
+	var input models.CreateLabTestInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	labTest, err := lc.labTestService.CreateLabTest(input)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to create lab test", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusCreated, "Lab test created successfully", labTest)
+}
+
+func (lc *LabTestController) GetAllLabTests(ctx *gin.Context) {
+	activeOnly := ctx.DefaultQuery("active", "true") != "false"
+
+	labTests, err := lc.labTestService.GetAllLabTests(activeOnly)
+	if err != nil {
+		responses.Error(ctx, http.StatusInternalServerError, "Failed to retrieve lab tests", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Lab tests retrieved successfully", labTests)
+}
+
+func (lc *LabTestController) GetLabTestByID(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid lab test ID", "Lab test ID must be a positive integer")
+		return
+	}
+
+	labTest, err := lc.labTestService.GetLabTestByID(uint(id))
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Lab test not found", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Lab test retrieved successfully", labTest)
+}
+
+func (lc *LabTestController) UpdateLabTest(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid lab test ID", "Lab test ID must be a positive integer")
+		return
+	}
+
+	var input models.UpdateLabTestInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid input", err.Error())
+		return
+	}
+
+	labTest, err := lc.labTestService.UpdateLabTest(uint(id), input)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to update lab test", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Lab test updated successfully", labTest)
+}
@@ -0,0 +1,87 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/responses"
+	"github.com/ofojichigozie/hms-go-backend/services"
+)
+
+type MedicationController struct {
+	medicationService services.MedicationService
+}
+
+func NewMedicationController(medicationService services.MedicationService) *MedicationController {
+	return &MedicationController{medicationService}
+}
+
+func (mc *MedicationController) CreateMedication(ctx *gin.Context) {
+	var input models.CreateMedicationInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	medication, err := mc.medicationService.CreateMedication(input)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to create medication", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusCreated, "Medication created successfully", medication)
+}
+
+func (mc *MedicationController) GetAllMedications(ctx *gin.Context) {
+	activeOnly := ctx.DefaultQuery("active", "true") != "false"
+
+	medications, err := mc.medicationService.GetAllMedications(ctx.Query("q"), activeOnly)
+	if err != nil {
+		responses.Error(ctx, http.StatusInternalServerError, "Failed to fetch medications", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Medications retrieved successfully", medications)
+}
+
+func (mc *MedicationController) GetMedicationByID(ctx *gin.Context) {
+	medicationID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest,
+			"Invalid medication ID", "Medication ID must be a positive integer")
+		return
+	}
+
+	medication, err := mc.medicationService.GetMedicationByID(uint(medicationID))
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Medication not found", nil)
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Medication retrieved successfully", medication)
+}
+
+func (mc *MedicationController) UpdateMedication(ctx *gin.Context) {
+	medicationID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest,
+			"Invalid medication ID", "Medication ID must be a positive integer")
+		return
+	}
+
+	var input models.UpdateMedicationInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid input", err.Error())
+		return
+	}
+
+	medication, err := mc.medicationService.UpdateMedication(uint(medicationID), input)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to update medication", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Medication updated successfully", medication)
+}
@@ -0,0 +1,230 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/responses"
+	"github.com/ofojichigozie/hms-go-backend/services"
+)
+
+type NursingController struct {
+	nursingService services.NursingService
+}
+
+func NewNursingController(nursingService services.NursingService) *NursingController {
+	return &NursingController{nursingService}
+}
+
+func (nc *NursingController) RecordVitals(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	patientID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid patient ID", "Patient ID must be a positive integer")
+		return
+	}
+
+	var input models.RecordVitalsInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	vitals, err := nc.nursingService.RecordVitals(uint(patientID), input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to record vital signs", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusCreated, "Vital signs recorded successfully", vitals)
+}
+
+func (nc *NursingController) GetVitals(ctx *gin.Context) {
+	patientID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid patient ID", "Patient ID must be a positive integer")
+		return
+	}
+
+	var page models.PageQuery
+	if err := ctx.ShouldBindQuery(&page); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+	page.Normalize()
+
+	vitals, total, err := nc.nursingService.GetVitals(uint(patientID), page)
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Failed to retrieve vital signs", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Vital signs retrieved successfully",
+		responses.NewPage(vitals, page.Page, page.PageSize, total))
+}
+
+func (nc *NursingController) CreateNursingNote(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	patientID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid patient ID", "Patient ID must be a positive integer")
+		return
+	}
+
+	var input models.CreateNursingNoteInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	note, err := nc.nursingService.CreateNursingNote(uint(patientID), input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to create nursing note", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusCreated, "Nursing note created successfully", note)
+}
+
+func (nc *NursingController) GetNursingNotes(ctx *gin.Context) {
+	patientID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid patient ID", "Patient ID must be a positive integer")
+		return
+	}
+
+	var page models.PageQuery
+	if err := ctx.ShouldBindQuery(&page); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+	page.Normalize()
+
+	notes, total, err := nc.nursingService.GetNursingNotes(uint(patientID), page)
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Failed to retrieve nursing notes", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Nursing notes retrieved successfully",
+		responses.NewPage(notes, page.Page, page.PageSize, total))
+}
+
+func (nc *NursingController) AdministerMedication(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	patientID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid patient ID", "Patient ID must be a positive integer")
+		return
+	}
+
+	var input models.AdministerMedicationInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	administration, err := nc.nursingService.AdministerMedication(uint(patientID), input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to record administration", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusCreated, "Medication administration recorded successfully", administration)
+}
+
+func (nc *NursingController) GetMedicationAdministrations(ctx *gin.Context) {
+	patientID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid patient ID", "Patient ID must be a positive integer")
+		return
+	}
+
+	var page models.PageQuery
+	if err := ctx.ShouldBindQuery(&page); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+	page.Normalize()
+
+	administrations, total, err := nc.nursingService.GetMedicationAdministrations(uint(patientID), page)
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Failed to retrieve medication administrations", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Medication administrations retrieved successfully",
+		responses.NewPage(administrations, page.Page, page.PageSize, total))
+}
+
+func (nc *NursingController) GetTasks(ctx *gin.Context) {
+	wardID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid ward ID", "Ward ID must be a positive integer")
+		return
+	}
+
+	tasks, err := nc.nursingService.GetTasks(uint(wardID))
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Failed to retrieve nursing tasks", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Nursing tasks retrieved successfully", tasks)
+}
+
+func (nc *NursingController) GetAdmissionMAR(ctx *gin.Context) {
+	admissionID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid admission ID", "Admission ID must be a positive integer")
+		return
+	}
+
+	var query models.MarQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	record, err := nc.nursingService.GetAdmissionMAR(uint(admissionID), query)
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Failed to retrieve medication administration record", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Medication administration record retrieved successfully", record)
+}
+
+func (nc *NursingController) GetMedicationDashboard(ctx *gin.Context) {
+	wardID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid ward ID", "Ward ID must be a positive integer")
+		return
+	}
+
+	dashboard, err := nc.nursingService.GetMedicationDashboard(uint(wardID))
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Failed to retrieve medication dashboard", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Medication dashboard retrieved successfully", dashboard)
+}
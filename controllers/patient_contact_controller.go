@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/responses"
+	"github.com/ofojichigozie/hms-go-backend/services"
+)
+
+type PatientContactController struct {
+	patientContactService services.PatientContactService
+}
+
+func NewPatientContactController(patientContactService services.PatientContactService) *PatientContactController {
+	return &PatientContactController{patientContactService}
+}
+
+func (pc *PatientContactController) CreateContact(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	patientID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid patient ID", "Patient ID must be a positive integer")
+		return
+	}
+
+	var input models.PatientContactInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	contact, err := pc.patientContactService.CreateContact(uint(patientID), input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to add contact", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusCreated, "Contact added successfully", contact)
+}
+
+func (pc *PatientContactController) GetContacts(ctx *gin.Context) {
+	patientID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid patient ID", "Patient ID must be a positive integer")
+		return
+	}
+
+	contacts, err := pc.patientContactService.GetContactsByPatientID(uint(patientID))
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Failed to retrieve contacts", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Contacts retrieved successfully", contacts)
+}
+
+func (pc *PatientContactController) UpdateContact(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	patientID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid patient ID", "Patient ID must be a positive integer")
+		return
+	}
+
+	contactID, err := strconv.ParseUint(ctx.Param("contactId"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid contact ID", "Contact ID must be a positive integer")
+		return
+	}
+
+	var input models.UpdatePatientContactInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid input", err.Error())
+		return
+	}
+
+	contact, err := pc.patientContactService.UpdateContact(uint(patientID), uint(contactID), input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to update contact", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Contact updated successfully", contact)
+}
+
+func (pc *PatientContactController) DeleteContact(ctx *gin.Context) {
+	patientID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid patient ID", "Patient ID must be a positive integer")
+		return
+	}
+
+	contactID, err := strconv.ParseUint(ctx.Param("contactId"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid contact ID", "Contact ID must be a positive integer")
+		return
+	}
+
+	if err := pc.patientContactService.DeleteContact(uint(patientID), uint(contactID)); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to delete contact", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Contact deleted successfully", nil)
+}
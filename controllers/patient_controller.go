@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/ofojichigozie/hms-go-backend/models"
 	"github.com/ofojichigozie/hms-go-backend/responses"
 	"github.com/ofojichigozie/hms-go-backend/services"
+	"github.com/ofojichigozie/hms-go-backend/utils"
 )
 
 type PatientController struct {
@@ -33,6 +35,9 @@ func (pc *PatientController) CreatePatient(ctx *gin.Context) {
 	}
 
 	patient, err := pc.patientService.CreatePatient(input, currentStaff.ID)
+	if respondDuplicatePatients(ctx, err) {
+		return
+	}
 	if err != nil {
 		responses.Error(ctx, http.StatusBadRequest, "Failed to create patient record", err.Error())
 		return
@@ -42,19 +47,21 @@ func (pc *PatientController) CreatePatient(ctx *gin.Context) {
 }
 
 func (pc *PatientController) GetAllPatients(ctx *gin.Context) {
-	filters := make(map[string]interface{})
-	if registrationNumber := ctx.Query("registrationNumber"); registrationNumber != "" {
-		filters["registration_number"] = registrationNumber
+	var query models.PatientSearchQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid search parameters", err.Error())
+		return
 	}
-	// TODO: Add more filter parameters from query string
+	query.Normalize()
 
-	patients, err := pc.patientService.GetAllPatients(filters)
+	patients, total, err := pc.patientService.SearchPatients(query)
 	if err != nil {
-		responses.Error(ctx, http.StatusInternalServerError, "Failed to fetch patients", err.Error())
+		responses.Error(ctx, http.StatusBadRequest, "Failed to fetch patients", err.Error())
 		return
 	}
 
-	responses.Success(ctx, http.StatusOK, "Patients retrieved successfully", patients)
+	responses.Success(ctx, http.StatusOK, "Patients retrieved successfully",
+		responses.NewPage(patients, query.Page, query.PageSize, total))
 }
 
 func (pc *PatientController) GetPatientByID(ctx *gin.Context) {
@@ -75,6 +82,20 @@ func (pc *PatientController) GetPatientByID(ctx *gin.Context) {
 	responses.Success(ctx, http.StatusOK, "Patient retrieved successfully", patient)
 }
 
+func (pc *PatientController) GetPatientByRegistrationNumber(ctx *gin.Context) {
+	patient, err := pc.patientService.GetPatientByRegistrationNumber(ctx.Param("registrationNumber"))
+	if errors.Is(err, utils.ErrInvalidCheckDigit) {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid registration number", err.Error())
+		return
+	}
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Patient not found", nil)
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Patient retrieved successfully", patient)
+}
+
 func (pc *PatientController) UpdatePatient(ctx *gin.Context) {
 	currentStaff, err := middleware.GetCurrentStaff(ctx)
 	if err != nil {
@@ -122,3 +143,25 @@ func (pc *PatientController) DeletePatient(ctx *gin.Context) {
 
 	responses.Success(ctx, http.StatusOK, "Patient deleted successfully", nil)
 }
+
+func (pc *PatientController) MergePatients(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	var input models.MergePatientsInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	patient, err := pc.patientService.MergePatients(input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to merge patients", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Patients merged successfully", patient)
+}
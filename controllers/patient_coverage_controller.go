@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/responses"
+	"github.com/ofojichigozie/hms-go-backend/services"
+)
+
+type PatientCoverageController struct {
+	patientCoverageService services.PatientCoverageService
+}
+
+func NewPatientCoverageController(patientCoverageService services.PatientCoverageService) *PatientCoverageController {
+	return &PatientCoverageController{patientCoverageService}
+}
+
+func (cc *PatientCoverageController) CreateCoverage(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	patientID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid patient ID", "Patient ID must be a positive integer")
+		return
+	}
+
+	var input models.CreateCoverageInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	coverage, err := cc.patientCoverageService.CreateCoverage(uint(patientID), input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to add coverage", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusCreated, "Coverage added successfully", coverage)
+}
+
+func (cc *PatientCoverageController) GetCoverages(ctx *gin.Context) {
+	patientID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid patient ID", "Patient ID must be a positive integer")
+		return
+	}
+
+	coverages, err := cc.patientCoverageService.GetCoveragesByPatientID(uint(patientID))
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Failed to retrieve coverages", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Coverages retrieved successfully", coverages)
+}
+
+func (cc *PatientCoverageController) UpdateCoverage(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	patientID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid patient ID", "Patient ID must be a positive integer")
+		return
+	}
+
+	coverageID, err := strconv.ParseUint(ctx.Param("coverageId"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid coverage ID", "Coverage ID must be a positive integer")
+		return
+	}
+
+	var input models.UpdateCoverageInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid input", err.Error())
+		return
+	}
+
+	coverage, err := cc.patientCoverageService.UpdateCoverage(uint(patientID), uint(coverageID), input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to update coverage", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Coverage updated successfully", coverage)
+}
+
+func (cc *PatientCoverageController) CheckEligibility(ctx *gin.Context) {
+	patientID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid patient ID", "Patient ID must be a positive integer")
+		return
+	}
+
+	coverageID, err := strconv.ParseUint(ctx.Param("coverageId"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid coverage ID", "Coverage ID must be a positive integer")
+		return
+	}
+
+	result, err := cc.patientCoverageService.CheckEligibility(uint(patientID), uint(coverageID))
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to check eligibility", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Eligibility checked successfully", result)
+}
@@ -0,0 +1,141 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/responses"
+	"github.com/ofojichigozie/hms-go-backend/services"
+)
+
+type PatientDocumentController struct {
+	patientDocumentService services.PatientDocumentService
+}
+
+func NewPatientDocumentController(patientDocumentService services.PatientDocumentService) *PatientDocumentController {
+	return &PatientDocumentController{patientDocumentService}
+}
+
+func (dc *PatientDocumentController) UploadDocument(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	patientID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid patient ID", "Patient ID must be a positive integer")
+		return
+	}
+
+	// Leave room for the other form fields on top of the file itself.
+	maxSize := dc.patientDocumentService.MaxSizeBytes()
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxSize+1<<20)
+
+	var input models.UploadDocumentInput
+	if err := ctx.ShouldBind(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			responses.Error(ctx, http.StatusRequestEntityTooLarge, "Failed to upload document", services.ErrDocumentTooLarge.Error())
+			return
+		}
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", "A file is required in the 'file' field")
+		return
+	}
+	if fileHeader.Size > maxSize {
+		responses.Error(ctx, http.StatusRequestEntityTooLarge, "Failed to upload document", services.ErrDocumentTooLarge.Error())
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to upload document", err.Error())
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, maxSize+1))
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to upload document", err.Error())
+		return
+	}
+
+	document, err := dc.patientDocumentService.UploadDocument(uint(patientID), input, fileHeader.Filename, content, currentStaff.ID)
+	if err != nil {
+		status := http.StatusBadRequest
+		switch {
+		case errors.Is(err, services.ErrDocumentTooLarge):
+			status = http.StatusRequestEntityTooLarge
+		case errors.Is(err, services.ErrUnsupportedDocumentType):
+			status = http.StatusUnsupportedMediaType
+		case errors.Is(err, services.ErrDocumentRejected):
+			status = http.StatusUnprocessableEntity
+		}
+		responses.Error(ctx, status, "Failed to upload document", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusCreated, "Document uploaded successfully", document)
+}
+
+func (dc *PatientDocumentController) GetDocuments(ctx *gin.Context) {
+	patientID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid patient ID", "Patient ID must be a positive integer")
+		return
+	}
+
+	var query models.DocumentQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	documents, err := dc.patientDocumentService.GetDocumentsByPatientID(uint(patientID), query)
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Failed to retrieve documents", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Documents retrieved successfully", documents)
+}
+
+func (dc *PatientDocumentController) DownloadDocument(ctx *gin.Context) {
+	patientID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid patient ID", "Patient ID must be a positive integer")
+		return
+	}
+
+	documentID, err := strconv.ParseUint(ctx.Param("documentId"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid document ID", "Document ID must be a positive integer")
+		return
+	}
+
+	document, content, err := dc.patientDocumentService.OpenDocument(uint(patientID), uint(documentID))
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Failed to retrieve document", err.Error())
+		return
+	}
+	defer content.Close()
+
+	ctx.DataFromReader(http.StatusOK, document.SizeBytes, document.ContentType, content, map[string]string{
+		"Content-Disposition":    fmt.Sprintf("attachment; filename=%q", document.FileName),
+		"X-Content-Type-Options": "nosniff",
+		"X-Checksum-Sha256":      document.ChecksumSHA256,
+	})
+}
@@ -0,0 +1,132 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/responses"
+	"github.com/ofojichigozie/hms-go-backend/services"
+)
+
+type PatientProblemController struct {
+	patientProblemService services.PatientProblemService
+}
+
+func NewPatientProblemController(patientProblemService services.PatientProblemService) *PatientProblemController {
+	return &PatientProblemController{patientProblemService}
+}
+
+func (pc *PatientProblemController) CreateProblem(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	patientID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid patient ID", "Patient ID must be a positive integer")
+		return
+	}
+
+	var input models.CreateProblemInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	problem, err := pc.patientProblemService.CreateProblem(uint(patientID), input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to add problem", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusCreated, "Problem added successfully", problem)
+}
+
+func (pc *PatientProblemController) PromoteDiagnosis(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	patientID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid patient ID", "Patient ID must be a positive integer")
+		return
+	}
+
+	var input models.PromoteDiagnosisInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	problem, err := pc.patientProblemService.PromoteDiagnosis(uint(patientID), input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to add problem", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusCreated, "Diagnosis added to problem list", problem)
+}
+
+func (pc *PatientProblemController) GetProblems(ctx *gin.Context) {
+	patientID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid patient ID", "Patient ID must be a positive integer")
+		return
+	}
+
+	status := ctx.Query("status")
+	if status != "" && status != "active" && status != "inactive" && status != "resolved" {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid status", "Status must be active, inactive or resolved")
+		return
+	}
+
+	problems, err := pc.patientProblemService.GetProblemsByPatientID(uint(patientID), status)
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Failed to retrieve problems", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Problems retrieved successfully", problems)
+}
+
+func (pc *PatientProblemController) UpdateProblem(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	patientID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid patient ID", "Patient ID must be a positive integer")
+		return
+	}
+
+	problemID, err := strconv.ParseUint(ctx.Param("problemId"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid problem ID", "Problem ID must be a positive integer")
+		return
+	}
+
+	var input models.UpdateProblemInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid input", err.Error())
+		return
+	}
+
+	problem, err := pc.patientProblemService.UpdateProblem(uint(patientID), uint(problemID), input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to update problem", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Problem updated successfully", problem)
+}
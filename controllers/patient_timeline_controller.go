@@ -0,0 +1,43 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/responses"
+	"github.com/ofojichigozie/hms-go-backend/services"
+)
+
+type PatientTimelineController struct {
+	patientTimelineService services.PatientTimelineService
+}
+
+func NewPatientTimelineController(patientTimelineService services.PatientTimelineService) *PatientTimelineController {
+	return &PatientTimelineController{patientTimelineService}
+}
+
+func (tc *PatientTimelineController) GetTimeline(ctx *gin.Context) {
+	patientID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid patient ID", "Patient ID must be a positive integer")
+		return
+	}
+
+	var query models.TimelineQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+	query.Normalize()
+
+	events, total, err := tc.patientTimelineService.GetTimeline(uint(patientID), query)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to retrieve timeline", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Timeline retrieved successfully",
+		responses.NewPage(events, query.Page, query.PageSize, total))
+}
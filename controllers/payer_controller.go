@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/responses"
+	"github.com/ofojichigozie/hms-go-backend/services"
+)
+
+type PayerController struct {
+	payerService services.PayerService
+}
+
+func NewPayerController(payerService services.PayerService) *PayerController {
+	return &PayerController{payerService}
+}
+
+func (pc *PayerController) CreatePayer(ctx *gin.Context) {
+	var input models.CreatePayerInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	payer, err := pc.payerService.CreatePayer(input)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to create payer", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusCreated, "Payer created successfully", payer)
+}
+
+func (pc *PayerController) GetAllPayers(ctx *gin.Context) {
+	activeOnly := ctx.DefaultQuery("active", "true") != "false"
+
+	payers, err := pc.payerService.GetAllPayers(activeOnly)
+	if err != nil {
+		responses.Error(ctx, http.StatusInternalServerError, "Failed to retrieve payers", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Payers retrieved successfully", payers)
+}
+
+func (pc *PayerController) GetPayerByID(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid payer ID", "Payer ID must be a positive integer")
+		return
+	}
+
+	payer, err := pc.payerService.GetPayerByID(uint(id))
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Payer not found", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Payer retrieved successfully", payer)
+}
+
+func (pc *PayerController) UpdatePayer(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid payer ID", "Payer ID must be a positive integer")
+		return
+	}
+
+	var input models.UpdatePayerInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid input", err.Error())
+		return
+	}
+
+	payer, err := pc.payerService.UpdatePayer(uint(id), input)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to update payer", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Payer updated successfully", payer)
+}
+
+func (pc *PayerController) CreatePlan(ctx *gin.Context) {
+	payerID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid payer ID", "Payer ID must be a positive integer")
+		return
+	}
+
+	var input models.CreateInsurancePlanInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	plan, err := pc.payerService.CreatePlan(uint(payerID), input)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to create plan", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusCreated, "Plan created successfully", plan)
+}
+
+func (pc *PayerController) UpdatePlan(ctx *gin.Context) {
+	payerID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid payer ID", "Payer ID must be a positive integer")
+		return
+	}
+
+	planID, err := strconv.ParseUint(ctx.Param("planId"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid plan ID", "Plan ID must be a positive integer")
+		return
+	}
+
+	var input models.UpdateInsurancePlanInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid input", err.Error())
+		return
+	}
+
+	plan, err := pc.payerService.UpdatePlan(uint(payerID), uint(planID), input)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to update plan", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Plan updated successfully", plan)
+}
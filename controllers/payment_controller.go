@@ -0,0 +1,128 @@
+package controllers
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/responses"
+	"github.com/ofojichigozie/hms-go-backend/services"
+	"github.com/ofojichigozie/hms-go-backend/templates"
+)
+
+type PaymentController struct {
+	paymentService services.PaymentService
+}
+
+func NewPaymentController(paymentService services.PaymentService) *PaymentController {
+	return &PaymentController{paymentService}
+}
+
+func (pc *PaymentController) RecordPayment(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	var input models.CreatePaymentInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	payment, err := pc.paymentService.RecordPayment(input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to record payment", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusCreated, "Payment recorded successfully", payment)
+}
+
+func (pc *PaymentController) GetPayments(ctx *gin.Context) {
+	var query models.PaymentQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+	query.Normalize()
+
+	payments, total, err := pc.paymentService.GetPayments(query)
+	if err != nil {
+		responses.Error(ctx, http.StatusInternalServerError, "Failed to retrieve payments", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Payments retrieved successfully",
+		responses.NewPage(payments, query.Page, query.PageSize, total))
+}
+
+func (pc *PaymentController) GetPaymentByID(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid payment ID", "Payment ID must be a positive integer")
+		return
+	}
+
+	payment, err := pc.paymentService.GetPaymentByID(uint(id))
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Payment not found", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Payment retrieved successfully", payment)
+}
+
+func (pc *PaymentController) PrintReceipt(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid payment ID", "Payment ID must be a positive integer")
+		return
+	}
+
+	document, err := pc.paymentService.GetPrintableReceipt(uint(id))
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Failed to print receipt", err.Error())
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := templates.Receipt.Execute(&buf, document); err != nil {
+		responses.Error(ctx, http.StatusInternalServerError, "Failed to render receipt", err.Error())
+		return
+	}
+
+	ctx.Data(http.StatusOK, "text/html; charset=utf-8", buf.Bytes())
+}
+
+func (pc *PaymentController) RefundPayment(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid payment ID", "Payment ID must be a positive integer")
+		return
+	}
+
+	var input models.RefundPaymentInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	payment, err := pc.paymentService.RefundPayment(uint(id), input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to refund payment", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusCreated, "Payment refunded successfully", payment)
+}
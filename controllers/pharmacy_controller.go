@@ -0,0 +1,214 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/responses"
+	"github.com/ofojichigozie/hms-go-backend/services"
+)
+
+type PharmacyController struct {
+	pharmacyService services.PharmacyService
+}
+
+func NewPharmacyController(pharmacyService services.PharmacyService) *PharmacyController {
+	return &PharmacyController{pharmacyService}
+}
+
+func (pc *PharmacyController) ReceiveGoods(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	var input models.CreateGoodsReceiptInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	receipt, err := pc.pharmacyService.ReceiveGoods(input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to receive goods", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusCreated, "Goods received successfully", receipt)
+}
+
+func (pc *PharmacyController) GetGoodsReceipts(ctx *gin.Context) {
+	var page models.PageQuery
+	if err := ctx.ShouldBindQuery(&page); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+	page.Normalize()
+
+	receipts, total, err := pc.pharmacyService.GetGoodsReceipts(page)
+	if err != nil {
+		responses.Error(ctx, http.StatusInternalServerError, "Failed to retrieve goods receipts", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Goods receipts retrieved successfully",
+		responses.NewPage(receipts, page.Page, page.PageSize, total))
+}
+
+func (pc *PharmacyController) GetGoodsReceiptByID(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid goods receipt ID", "Goods receipt ID must be a positive integer")
+		return
+	}
+
+	receipt, err := pc.pharmacyService.GetGoodsReceiptByID(uint(id))
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Goods receipt not found", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Goods receipt retrieved successfully", receipt)
+}
+
+func (pc *PharmacyController) GetStockLevels(ctx *gin.Context) {
+	levels, err := pc.pharmacyService.GetStockLevels()
+	if err != nil {
+		responses.Error(ctx, http.StatusInternalServerError, "Failed to retrieve stock levels", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Stock levels retrieved successfully", levels)
+}
+
+func (pc *PharmacyController) GetBatches(ctx *gin.Context) {
+	medicationID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid medication ID", "Medication ID must be a positive integer")
+		return
+	}
+
+	batches, err := pc.pharmacyService.GetBatches(uint(medicationID))
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Failed to retrieve stock batches", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Stock batches retrieved successfully", batches)
+}
+
+func (pc *PharmacyController) GetStockLedger(ctx *gin.Context) {
+	medicationID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid medication ID", "Medication ID must be a positive integer")
+		return
+	}
+
+	var page models.PageQuery
+	if err := ctx.ShouldBindQuery(&page); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+	page.Normalize()
+
+	entries, total, err := pc.pharmacyService.GetStockLedger(uint(medicationID), page)
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Failed to retrieve stock ledger", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Stock ledger retrieved successfully",
+		responses.NewPage(entries, page.Page, page.PageSize, total))
+}
+
+func (pc *PharmacyController) GetLowStockAlerts(ctx *gin.Context) {
+	alerts, err := pc.pharmacyService.GetLowStockAlerts()
+	if err != nil {
+		responses.Error(ctx, http.StatusInternalServerError, "Failed to retrieve low-stock alerts", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Low-stock alerts retrieved successfully", alerts)
+}
+
+func (pc *PharmacyController) GetExpiryAlerts(ctx *gin.Context) {
+	var query models.ExpiryAlertQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	alerts, err := pc.pharmacyService.GetExpiryAlerts(query)
+	if err != nil {
+		responses.Error(ctx, http.StatusInternalServerError, "Failed to retrieve expiry alerts", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Expiry alerts retrieved successfully", alerts)
+}
+
+func (pc *PharmacyController) GetDispensingQueue(ctx *gin.Context) {
+	var page models.PageQuery
+	if err := ctx.ShouldBindQuery(&page); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+	page.Normalize()
+
+	prescriptions, total, err := pc.pharmacyService.GetDispensingQueue(page)
+	if err != nil {
+		responses.Error(ctx, http.StatusInternalServerError, "Failed to retrieve dispensing queue", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Dispensing queue retrieved successfully",
+		responses.NewPage(prescriptions, page.Page, page.PageSize, total))
+}
+
+func (pc *PharmacyController) DispensePrescription(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	prescriptionID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid prescription ID", "Prescription ID must be a positive integer")
+		return
+	}
+
+	var input models.DispensePrescriptionInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	dispensation, err := pc.pharmacyService.DispensePrescription(uint(prescriptionID), input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to dispense prescription", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusCreated, "Prescription dispensed successfully", dispensation)
+}
+
+func (pc *PharmacyController) GetDispensations(ctx *gin.Context) {
+	prescriptionID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid prescription ID", "Prescription ID must be a positive integer")
+		return
+	}
+
+	dispensations, err := pc.pharmacyService.GetDispensations(uint(prescriptionID))
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Failed to retrieve dispensations", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Dispensations retrieved successfully", dispensations)
+}
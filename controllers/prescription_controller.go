@@ -0,0 +1,148 @@
+package controllers
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/responses"
+	"github.com/ofojichigozie/hms-go-backend/services"
+	"github.com/ofojichigozie/hms-go-backend/templates"
+)
+
+type PrescriptionController struct {
+	prescriptionService services.PrescriptionService
+}
+
+func NewPrescriptionController(prescriptionService services.PrescriptionService) *PrescriptionController {
+	return &PrescriptionController{prescriptionService}
+}
+
+func (pc *PrescriptionController) CreatePrescription(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	var input models.CreatePrescriptionInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	prescription, err := pc.prescriptionService.CreatePrescription(input, currentStaff.ID)
+	if err != nil {
+		if respondAllergyConflict(ctx, err) || respondCdsAlerts(ctx, err) {
+			return
+		}
+		responses.Error(ctx, http.StatusBadRequest, "Failed to create prescription", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusCreated, "Prescription created successfully", prescription)
+}
+
+func (pc *PrescriptionController) GetPrescriptionByID(ctx *gin.Context) {
+	prescriptionID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest,
+			"Invalid prescription ID", "Prescription ID must be a positive integer")
+		return
+	}
+
+	prescription, err := pc.prescriptionService.GetPrescriptionByID(uint(prescriptionID))
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Prescription not found", nil)
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Prescription retrieved successfully", prescription)
+}
+
+func (pc *PrescriptionController) GetPrescriptionsByNoteID(ctx *gin.Context) {
+	noteID, err := strconv.ParseUint(ctx.Param("noteId"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid note ID", "Note ID must be a positive integer")
+		return
+	}
+
+	prescriptions, err := pc.prescriptionService.GetPrescriptionsByNoteID(uint(noteID))
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Failed to retrieve prescriptions", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Prescriptions retrieved successfully", prescriptions)
+}
+
+func (pc *PrescriptionController) GetCurrentMedications(ctx *gin.Context) {
+	patientID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid patient ID", "Patient ID must be a positive integer")
+		return
+	}
+
+	prescriptions, err := pc.prescriptionService.GetCurrentMedications(uint(patientID))
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Failed to retrieve medications", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Current medications retrieved successfully", prescriptions)
+}
+
+func (pc *PrescriptionController) UpdatePrescriptionStatus(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	prescriptionID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest,
+			"Invalid prescription ID", "Prescription ID must be a positive integer")
+		return
+	}
+
+	var input models.UpdatePrescriptionStatusInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid input", err.Error())
+		return
+	}
+
+	prescription, err := pc.prescriptionService.UpdatePrescriptionStatus(uint(prescriptionID), input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to update prescription status", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Prescription status updated successfully", prescription)
+}
+
+func (pc *PrescriptionController) PrintPrescription(ctx *gin.Context) {
+	prescriptionID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest,
+			"Invalid prescription ID", "Prescription ID must be a positive integer")
+		return
+	}
+
+	document, err := pc.prescriptionService.GetPrintablePrescription(uint(prescriptionID))
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Failed to print prescription", err.Error())
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := templates.Prescription.Execute(&buf, document); err != nil {
+		responses.Error(ctx, http.StatusInternalServerError, "Failed to render prescription", err.Error())
+		return
+	}
+
+	ctx.Data(http.StatusOK, "text/html; charset=utf-8", buf.Bytes())
+}
@@ -0,0 +1,202 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/responses"
+	"github.com/ofojichigozie/hms-go-backend/services"
+)
+
+type ReferralController struct {
+	referralService services.ReferralService
+}
+
+func NewReferralController(referralService services.ReferralService) *ReferralController {
+	return &ReferralController{referralService}
+}
+
+func (rc *ReferralController) CreateReferral(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	var input models.CreateReferralInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	referral, err := rc.referralService.CreateReferral(input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to create referral", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusCreated, "Referral created successfully", referral)
+}
+
+func (rc *ReferralController) GetAllReferrals(ctx *gin.Context) {
+	filters := make(map[string]interface{})
+
+	if patientID := ctx.Query("patientId"); patientID != "" {
+		filters["patient_id"] = patientID
+	}
+	if referringDoctorID := ctx.Query("referringDoctorId"); referringDoctorID != "" {
+		filters["referring_doctor_id"] = referringDoctorID
+	}
+	if targetDoctorID := ctx.Query("targetDoctorId"); targetDoctorID != "" {
+		filters["target_doctor_id"] = targetDoctorID
+	}
+	if department := ctx.Query("department"); department != "" {
+		filters["target_department"] = department
+	}
+	if status := ctx.Query("status"); status != "" {
+		filters["status"] = status
+	}
+
+	referrals, err := rc.referralService.GetAllReferrals(filters)
+	if err != nil {
+		responses.Error(ctx, http.StatusInternalServerError, "Failed to retrieve referrals", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Referrals retrieved successfully", referrals)
+}
+
+func (rc *ReferralController) GetWorklist(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	referrals, err := rc.referralService.GetWorklist(ctx.Query("department"), ctx.Query("status"), currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to retrieve referral worklist", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Referral worklist retrieved successfully", referrals)
+}
+
+func (rc *ReferralController) GetReferralByID(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid referral ID", "Referral ID must be a positive integer")
+		return
+	}
+
+	referral, err := rc.referralService.GetReferralByID(uint(id))
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Referral not found", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Referral retrieved successfully", referral)
+}
+
+func (rc *ReferralController) AcceptReferral(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid referral ID", "Referral ID must be a positive integer")
+		return
+	}
+
+	var input models.AcceptReferralInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	referral, err := rc.referralService.AcceptReferral(uint(id), input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to accept referral", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Referral accepted successfully", referral)
+}
+
+func (rc *ReferralController) ScheduleReferral(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid referral ID", "Referral ID must be a positive integer")
+		return
+	}
+
+	var input models.ScheduleReferralInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	referral, err := rc.referralService.ScheduleReferral(uint(id), input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to schedule referral", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Referral scheduled successfully", referral)
+}
+
+func (rc *ReferralController) CompleteReferral(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid referral ID", "Referral ID must be a positive integer")
+		return
+	}
+
+	referral, err := rc.referralService.CompleteReferral(uint(id))
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to complete referral", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Referral completed successfully", referral)
+}
+
+func (rc *ReferralController) DeclineReferral(ctx *gin.Context) {
+	currentStaff, err := middleware.GetCurrentStaff(ctx)
+	if err != nil {
+		responses.Error(ctx, http.StatusUnauthorized, "Authentication required", err.Error())
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid referral ID", "Referral ID must be a positive integer")
+		return
+	}
+
+	var input models.DeclineReferralInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	referral, err := rc.referralService.DeclineReferral(uint(id), input, currentStaff.ID)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to decline referral", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Referral declined successfully", referral)
+}
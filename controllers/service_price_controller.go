@@ -0,0 +1,89 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/responses"
+	"github.com/ofojichigozie/hms-go-backend/services"
+)
+
+type ServicePriceController struct {
+	servicePriceService services.ServicePriceService
+}
+
+func NewServicePriceController(servicePriceService services.ServicePriceService) *ServicePriceController {
+	return &ServicePriceController{servicePriceService}
+}
+
+func (sc *ServicePriceController) CreateServicePrice(ctx *gin.Context) {
+	var input models.CreateServicePriceInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	price, err := sc.servicePriceService.CreateServicePrice(input)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to create service price", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusCreated, "Service price created successfully", price)
+}
+
+func (sc *ServicePriceController) GetServicePrices(ctx *gin.Context) {
+	var query models.ServicePriceQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	prices, err := sc.servicePriceService.GetServicePrices(query)
+	if err != nil {
+		responses.Error(ctx, http.StatusInternalServerError, "Failed to retrieve service prices", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Service prices retrieved successfully", prices)
+}
+
+func (sc *ServicePriceController) GetServicePriceByID(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid service price ID", "Service price ID must be a positive integer")
+		return
+	}
+
+	price, err := sc.servicePriceService.GetServicePriceByID(uint(id))
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Service price not found", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Service price retrieved successfully", price)
+}
+
+func (sc *ServicePriceController) UpdateServicePrice(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid service price ID", "Service price ID must be a positive integer")
+		return
+	}
+
+	var input models.UpdateServicePriceInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	price, err := sc.servicePriceService.UpdateServicePrice(uint(id), input)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to update service price", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Service price updated successfully", price)
+}
@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/responses"
+	"github.com/ofojichigozie/hms-go-backend/services"
+)
+
+type VaccineController struct {
+	vaccineService services.VaccineService
+}
+
+func NewVaccineController(vaccineService services.VaccineService) *VaccineController {
+	return &VaccineController{vaccineService}
+}
+
+func (vc *VaccineController) CreateVaccine(ctx *gin.Context) {
+	var input models.CreateVaccineInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	vaccine, err := vc.vaccineService.CreateVaccine(input)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to create vaccine", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusCreated, "Vaccine created successfully", vaccine)
+}
+
+func (vc *VaccineController) GetAllVaccines(ctx *gin.Context) {
+	activeOnly := ctx.DefaultQuery("active", "true") != "false"
+
+	vaccines, err := vc.vaccineService.GetAllVaccines(activeOnly)
+	if err != nil {
+		responses.Error(ctx, http.StatusInternalServerError, "Failed to retrieve vaccines", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Vaccines retrieved successfully", vaccines)
+}
+
+func (vc *VaccineController) UpdateVaccine(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid vaccine ID", "Vaccine ID must be a positive integer")
+		return
+	}
+
+	var input models.UpdateVaccineInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid input", err.Error())
+		return
+	}
+
+	vaccine, err := vc.vaccineService.UpdateVaccine(uint(id), input)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to update vaccine", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Vaccine updated successfully", vaccine)
+}
+
+func (vc *VaccineController) ReplaceSchedule(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid vaccine ID", "Vaccine ID must be a positive integer")
+		return
+	}
+
+	var input models.ReplaceScheduleInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid input", err.Error())
+		return
+	}
+
+	vaccine, err := vc.vaccineService.ReplaceSchedule(uint(id), input)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to update schedule", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Schedule updated successfully", vaccine)
+}
@@ -0,0 +1,161 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/responses"
+	"github.com/ofojichigozie/hms-go-backend/services"
+)
+
+type WardController struct {
+	wardService services.WardService
+}
+
+func NewWardController(wardService services.WardService) *WardController {
+	return &WardController{wardService}
+}
+
+func (wc *WardController) CreateWard(ctx *gin.Context) {
+	var input models.CreateWardInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	ward, err := wc.wardService.CreateWard(input)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to create ward", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusCreated, "Ward created successfully", ward)
+}
+
+func (wc *WardController) GetAllWards(ctx *gin.Context) {
+	activeOnly := ctx.DefaultQuery("active", "true") != "false"
+
+	wards, err := wc.wardService.GetAllWards(activeOnly)
+	if err != nil {
+		responses.Error(ctx, http.StatusInternalServerError, "Failed to retrieve wards", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Wards retrieved successfully", wards)
+}
+
+func (wc *WardController) GetWardByID(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid ward ID", "Ward ID must be a positive integer")
+		return
+	}
+
+	ward, err := wc.wardService.GetWardByID(uint(id))
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Ward not found", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Ward retrieved successfully", ward)
+}
+
+func (wc *WardController) UpdateWard(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid ward ID", "Ward ID must be a positive integer")
+		return
+	}
+
+	var input models.UpdateWardInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid input", err.Error())
+		return
+	}
+
+	ward, err := wc.wardService.UpdateWard(uint(id), input)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to update ward", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Ward updated successfully", ward)
+}
+
+func (wc *WardController) AddBed(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid ward ID", "Ward ID must be a positive integer")
+		return
+	}
+
+	var input models.CreateBedInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	bed, err := wc.wardService.AddBed(uint(id), input)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to add bed", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusCreated, "Bed added successfully", bed)
+}
+
+func (wc *WardController) UpdateBedStatus(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid ward ID", "Ward ID must be a positive integer")
+		return
+	}
+
+	bedID, err := strconv.ParseUint(ctx.Param("bedId"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid bed ID", "Bed ID must be a positive integer")
+		return
+	}
+
+	var input models.UpdateBedStatusInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid input", err.Error())
+		return
+	}
+
+	bed, err := wc.wardService.UpdateBedStatus(uint(id), uint(bedID), input)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Failed to update bed", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Bed updated successfully", bed)
+}
+
+func (wc *WardController) GetBoard(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		responses.Error(ctx, http.StatusBadRequest, "Invalid ward ID", "Ward ID must be a positive integer")
+		return
+	}
+
+	board, err := wc.wardService.GetBoard(uint(id))
+	if err != nil {
+		responses.Error(ctx, http.StatusNotFound, "Failed to retrieve bed board", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Bed board retrieved successfully", board)
+}
+
+func (wc *WardController) GetBoards(ctx *gin.Context) {
+	boards, err := wc.wardService.GetBoards()
+	if err != nil {
+		responses.Error(ctx, http.StatusInternalServerError, "Failed to retrieve bed boards", err.Error())
+		return
+	}
+
+	responses.Success(ctx, http.StatusOK, "Bed boards retrieved successfully", boards)
+}
@@ -0,0 +1,143 @@
+// Package growth computes anthropometric z-scores and percentiles against the
+// WHO Child Growth Standards using the LMS method.
+package growth
+
+import (
+	"math"
+	"sort"
+)
+
+// Indicators supported by the reference tables.
+const (
+	WeightForAge = "weight_for_age"
+	HeightForAge = "height_for_age"
+	BMIForAge    = "bmi_for_age"
+)
+
+// DaysPerMonth is the average month length the WHO standards use to convert
+// age in days to months.
+const DaysPerMonth = 30.4375
+
+// MaxAgeDays is the oldest age covered by the shipped tables (five years).
+const MaxAgeDays = 1826
+
+// lengthToHeightAgeDays is the age at which the standards switch from
+// recumbent length to standing height.
+const lengthToHeightAgeDays = 731
+
+// LMS holds the Box-Cox power (L), median (M) and coefficient of variation
+// (S) of a measurement at one age.
+type LMS struct {
+	AgeMonths float64
+	L         float64
+	M         float64
+	S         float64
+}
+
+// Score is a measurement expressed against the reference population.
+type Score struct {
+	ZScore     float64 `json:"zScore"`
+	Percentile float64 `json:"percentile"`
+}
+
+// Compute scores value for the indicator at the given age. It returns false
+// when the indicator, sex or age is not covered by the tables.
+func Compute(indicator string, sex string, ageDays int, value float64) (Score, bool) {
+	if ageDays < 0 || ageDays > MaxAgeDays || value <= 0 {
+		return Score{}, false
+	}
+
+	table := referenceTable(indicator, sex, ageDays)
+	if len(table) == 0 {
+		return Score{}, false
+	}
+
+	lms := interpolate(table, float64(ageDays)/DaysPerMonth)
+	z := zScore(lms, value, indicator != HeightForAge)
+	return Score{ZScore: round(z, 2), Percentile: round(percentile(z), 1)}, true
+}
+
+// BMI returns body mass index from weight in kilograms and height in
+// centimetres.
+func BMI(weightKg, heightCm float64) float64 {
+	metres := heightCm / 100
+	return weightKg / (metres * metres)
+}
+
+func referenceTable(indicator string, sex string, ageDays int) []LMS {
+	tables, ok := referenceTables[indicator]
+	if !ok {
+		return nil
+	}
+	key := sex
+	if indicator != WeightForAge {
+		if ageDays < lengthToHeightAgeDays {
+			key += "_length"
+		} else {
+			key += "_height"
+		}
+	}
+	return tables[key]
+}
+
+// interpolate returns the LMS values at ageMonths by linear interpolation
+// between the nearest table rows.
+func interpolate(table []LMS, ageMonths float64) LMS {
+	i := sort.Search(len(table), func(i int) bool { return table[i].AgeMonths >= ageMonths })
+	switch {
+	case i == 0:
+		return table[0]
+	case i == len(table):
+		return table[len(table)-1]
+	}
+
+	lower, upper := table[i-1], table[i]
+	fraction := (ageMonths - lower.AgeMonths) / (upper.AgeMonths - lower.AgeMonths)
+	return LMS{
+		AgeMonths: ageMonths,
+		L:         lower.L + fraction*(upper.L-lower.L),
+		M:         lower.M + fraction*(upper.M-lower.M),
+		S:         lower.S + fraction*(upper.S-lower.S),
+	}
+}
+
+// zScore applies the LMS formula. For skewed indicators (weight and BMI) WHO
+// restricts the LMS curve to the -3 to +3 range and extrapolates linearly
+// beyond it using the distance between the 2 and 3 SD lines.
+func zScore(lms LMS, value float64, restrictTails bool) float64 {
+	z := lmsZ(lms, value)
+	if !restrictTails || math.Abs(z) <= 3 {
+		return z
+	}
+
+	if z > 3 {
+		sd3 := lmsValue(lms, 3)
+		return 3 + (value-sd3)/(sd3-lmsValue(lms, 2))
+	}
+	sd3 := lmsValue(lms, -3)
+	return -3 + (value-sd3)/(lmsValue(lms, -2)-sd3)
+}
+
+func lmsZ(lms LMS, value float64) float64 {
+	if lms.L == 0 {
+		return math.Log(value/lms.M) / lms.S
+	}
+	return (math.Pow(value/lms.M, lms.L) - 1) / (lms.L * lms.S)
+}
+
+// lmsValue is the measurement that sits at z on the LMS curve.
+func lmsValue(lms LMS, z float64) float64 {
+	if lms.L == 0 {
+		return lms.M * math.Exp(lms.S*z)
+	}
+	return lms.M * math.Pow(1+lms.L*lms.S*z, 1/lms.L)
+}
+
+func percentile(z float64) float64 {
+	return 50 * math.Erfc(-z/math.Sqrt2)
+}
+
+func round(value float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(value*scale) / scale
+}
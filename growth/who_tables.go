@@ -0,0 +1,128 @@
+package growth
+
+// referenceTables holds a reduced copy of the WHO Child Growth Standards
+// (2006) LMS parameters, sampled monthly to six months and at wider steps up
+// to five years. Ages in between are interpolated. Length-based tables apply
+// below two years and height-based tables from two years.
+var referenceTables = map[string]map[string][]LMS{
+	WeightForAge: {
+		"male": {
+			{0, 0.3487, 3.3464, 0.14602},
+			{1, 0.2297, 4.4709, 0.13395},
+			{2, 0.1970, 5.5675, 0.12385},
+			{3, 0.1738, 6.3762, 0.11727},
+			{4, 0.1553, 7.0023, 0.11316},
+			{5, 0.1395, 7.5105, 0.11080},
+			{6, 0.1257, 7.9340, 0.10958},
+			{9, 0.0917, 8.9014, 0.10881},
+			{12, 0.0644, 9.6479, 0.10925},
+			{15, 0.0409, 10.3108, 0.11014},
+			{18, 0.0197, 10.9385, 0.11119},
+			{24, -0.0137, 12.1515, 0.11426},
+			{36, -0.0747, 14.3429, 0.12131},
+			{48, -0.1127, 16.3489, 0.12829},
+			{60, -0.1506, 18.3366, 0.13372},
+		},
+		"female": {
+			{0, 0.3809, 3.2322, 0.14171},
+			{1, 0.1714, 4.1873, 0.13724},
+			{2, 0.0962, 5.1282, 0.13000},
+			{3, 0.0402, 5.8458, 0.12619},
+			{4, -0.0050, 6.4237, 0.12402},
+			{5, -0.0430, 6.8985, 0.12274},
+			{6, -0.0756, 7.2970, 0.12204},
+			{9, -0.1551, 8.2254, 0.12145},
+			{12, -0.2024, 8.9481, 0.12268},
+			{15, -0.2359, 9.6008, 0.12432},
+			{18, -0.2622, 10.2315, 0.12602},
+			{24, -0.2941, 11.4775, 0.13004},
+			{36, -0.3316, 13.8503, 0.13596},
+			{48, -0.3552, 16.0697, 0.14208},
+			{60, -0.3833, 18.2193, 0.14611},
+		},
+	},
+	HeightForAge: {
+		"male_length": {
+			{0, 1, 49.8842, 0.03795},
+			{1, 1, 54.7244, 0.03557},
+			{2, 1, 58.4249, 0.03424},
+			{3, 1, 61.4292, 0.03328},
+			{4, 1, 63.8860, 0.03257},
+			{5, 1, 65.9026, 0.03204},
+			{6, 1, 67.6236, 0.03165},
+			{9, 1, 72.0000, 0.03117},
+			{12, 1, 75.7488, 0.03137},
+			{15, 1, 79.1458, 0.03197},
+			{18, 1, 82.2587, 0.03261},
+			{24, 1, 87.8161, 0.03479},
+		},
+		"male_height": {
+			{24, 1, 87.1161, 0.03507},
+			{36, 1, 96.0835, 0.03707},
+			{48, 1, 103.3273, 0.03909},
+			{60, 1, 110.2647, 0.04036},
+		},
+		"female_length": {
+			{0, 1, 49.1477, 0.03790},
+			{1, 1, 53.6872, 0.03640},
+			{2, 1, 57.0673, 0.03568},
+			{3, 1, 59.8029, 0.03520},
+			{4, 1, 62.0899, 0.03486},
+			{5, 1, 64.0301, 0.03463},
+			{6, 1, 65.7311, 0.03448},
+			{9, 1, 70.1435, 0.03445},
+			{12, 1, 74.0150, 0.03479},
+			{15, 1, 77.5099, 0.03542},
+			{18, 1, 80.7079, 0.03608},
+			{24, 1, 86.4153, 0.03734},
+		},
+		"female_height": {
+			{24, 1, 85.7153, 0.03764},
+			{36, 1, 95.0515, 0.03933},
+			{48, 1, 102.7312, 0.04123},
+			{60, 1, 109.4233, 0.04246},
+		},
+	},
+	BMIForAge: {
+		"male_length": {
+			{0, -0.3053, 13.4069, 0.09560},
+			{1, 0.2708, 14.9441, 0.09027},
+			{2, 0.1118, 16.3195, 0.08677},
+			{3, 0.0068, 16.8987, 0.08495},
+			{4, -0.0727, 17.1579, 0.08378},
+			{5, -0.1370, 17.2919, 0.08296},
+			{6, -0.1913, 17.3422, 0.08234},
+			{9, -0.3149, 17.1843, 0.08126},
+			{12, -0.4057, 16.8571, 0.08034},
+			{15, -0.4748, 16.5046, 0.07978},
+			{18, -0.5284, 16.2152, 0.07957},
+			{24, -0.6187, 16.0189, 0.07785},
+		},
+		"male_height": {
+			{24, -0.6187, 16.3202, 0.07779},
+			{36, -0.5328, 15.7839, 0.07726},
+			{48, -0.4717, 15.4868, 0.07933},
+			{60, -0.4415, 15.2641, 0.08211},
+		},
+		"female_length": {
+			{0, -0.0631, 13.3363, 0.09272},
+			{1, 0.3448, 14.5679, 0.09556},
+			{2, 0.1749, 15.7679, 0.09371},
+			{3, 0.0643, 16.3574, 0.09254},
+			{4, -0.0191, 16.6703, 0.09166},
+			{5, -0.0864, 16.8386, 0.09096},
+			{6, -0.1429, 16.9083, 0.09036},
+			{9, -0.2727, 16.7056, 0.08922},
+			{12, -0.3725, 16.4258, 0.08852},
+			{15, -0.4459, 16.1254, 0.08820},
+			{18, -0.5030, 15.8632, 0.08810},
+			{24, -0.5684, 15.6881, 0.08454},
+		},
+		"female_height": {
+			{24, -0.5684, 15.9836, 0.08460},
+			{36, -0.5767, 15.5290, 0.08608},
+			{48, -0.6225, 15.2824, 0.08906},
+			{60, -0.6788, 15.2441, 0.09290},
+		},
+	},
+}
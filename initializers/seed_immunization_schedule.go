@@ -0,0 +1,67 @@
+package initializers
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"gorm.io/gorm"
+)
+
+type scheduledVaccine struct {
+	code, name string
+	dueAgeDays []int
+	maxAgeDays int
+}
+
+// defaultImmunizationSchedule follows the national routine immunization
+// schedule for children. Admins can change it through the vaccines API; it is
+// only loaded into an empty vaccine catalog.
+var defaultImmunizationSchedule = []scheduledVaccine{
+	{"BCG", "Bacillus Calmette-Guerin", []int{0}, 365},
+	{"HEPB", "Hepatitis B (birth dose)", []int{0}, 42},
+	{"OPV", "Oral polio vaccine", []int{0, 42, 70, 98}, 1826},
+	{"PENTA", "Pentavalent (DTP-HepB-Hib)", []int{42, 70, 98}, 1826},
+	{"PCV", "Pneumococcal conjugate vaccine", []int{42, 70, 98}, 1826},
+	{"ROTA", "Rotavirus vaccine", []int{42, 70, 98}, 365},
+	{"IPV", "Inactivated polio vaccine", []int{98, 270}, 1826},
+	{"MCV", "Measles-containing vaccine", []int{270, 450}, 1826},
+	{"YF", "Yellow fever", []int{270}, 1826},
+	{"MENA", "Meningococcal A conjugate", []int{270}, 1826},
+}
+
+func SeedImmunizationSchedule(db *gorm.DB) (int, error) {
+	var count int64
+	if err := db.Model(&models.Vaccine{}).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("database error checking vaccines: %w", err)
+	}
+	if count > 0 {
+		log.Printf("Vaccine catalog already configured (%d vaccines)", count)
+		return 0, nil
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, scheduled := range defaultImmunizationSchedule {
+			vaccine := models.Vaccine{Code: scheduled.code, Name: scheduled.name, IsActive: true}
+			for i, dueAge := range scheduled.dueAgeDays {
+				vaccine.Doses = append(vaccine.Doses, models.VaccineScheduleDose{
+					DoseNumber:       i + 1,
+					DueAgeDays:       dueAge,
+					OverdueAfterDays: constants.DefaultOverdueAfterDays,
+					MaxAgeDays:       scheduled.maxAgeDays,
+				})
+			}
+			if err := tx.Create(&vaccine).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to seed immunization schedule: %w", err)
+	}
+
+	log.Printf("Loaded default immunization schedule (%d vaccines)", len(defaultImmunizationSchedule))
+	return len(defaultImmunizationSchedule), nil
+}
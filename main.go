@@ -19,6 +19,9 @@ func main() {
 	} else {
 		log.Println("Admin initialization completed")
 	}
+	if _, err := initializers.SeedImmunizationSchedule(initializers.DB); err != nil {
+		log.Printf("Warning: %v", err)
+	}
 
 	r := gin.Default()
 	routes.AuthRoute(r, initializers.DB)
@@ -26,5 +29,33 @@ func main() {
 	routes.PatientRoutes(r, initializers.DB)
 	routes.AppointmentRoutes(r, initializers.DB)
 	routes.ClinicalNoteRoutes(r, initializers.DB)
+	routes.DiagnosisCodeRoutes(r, initializers.DB)
+	routes.MedicationRoutes(r, initializers.DB)
+	routes.PrescriptionRoutes(r, initializers.DB)
+	routes.AllergyRoutes(r, initializers.DB)
+	routes.PatientContactRoutes(r, initializers.DB)
+	routes.PayerRoutes(r, initializers.DB)
+	routes.PatientCoverageRoutes(r, initializers.DB)
+	routes.PatientDocumentRoutes(r, initializers.DB)
+	routes.PatientTimelineRoutes(r, initializers.DB)
+	routes.PatientProblemRoutes(r, initializers.DB)
+	routes.VaccineRoutes(r, initializers.DB)
+	routes.ImmunizationRoutes(r, initializers.DB)
+	routes.GrowthRoutes(r, initializers.DB)
+	routes.WardRoutes(r, initializers.DB)
+	routes.AdmissionRoutes(r, initializers.DB)
+	routes.DischargeSummaryRoutes(r, initializers.DB)
+	routes.NursingRoutes(r, initializers.DB)
+	routes.PharmacyRoutes(r, initializers.DB)
+	routes.ServicePriceRoutes(r, initializers.DB)
+	routes.InvoiceRoutes(r, initializers.DB)
+	routes.PaymentRoutes(r, initializers.DB)
+	routes.ClaimRoutes(r, initializers.DB)
+	routes.FeeWaiverRoutes(r, initializers.DB)
+	routes.CdsRoutes(r, initializers.DB)
+	routes.AuditLogRoutes(r, initializers.DB)
+	routes.LabTestRoutes(r, initializers.DB)
+	routes.LabOrderRoutes(r, initializers.DB)
+	routes.ReferralRoutes(r, initializers.DB)
 	r.Run()
 }
@@ -13,16 +13,71 @@ func init() {
 }
 
 func main() {
+	createExtensions()
 	createEnums()
 
 	err := initializers.DB.AutoMigrate(&models.Staff{}, &models.Patient{},
-		&models.Appointment{}, &models.ClinicalNote{})
+		&models.Appointment{}, &models.ClinicalNote{}, &models.DiagnosisCode{},
+		&models.NoteDiagnosis{}, &models.Medication{}, &models.Prescription{},
+		&models.PatientAllergy{}, &models.AuditLog{}, &models.LabTest{},
+		&models.LabReferenceRange{}, &models.LabOrder{}, &models.LabResult{},
+		&models.Referral{}, &models.Sequence{}, &models.PatientAlias{},
+		&models.PatientContact{}, &models.Payer{}, &models.InsurancePlan{},
+		&models.PatientCoverage{}, &models.PatientDocument{},
+		&models.PatientProblem{}, &models.Vaccine{}, &models.VaccineScheduleDose{},
+		&models.Immunization{}, &models.GrowthMeasurement{}, &models.Ward{},
+		&models.Bed{}, &models.Admission{}, &models.BedAssignment{},
+		&models.VitalSign{}, &models.NursingNote{}, &models.MedicationAdministration{},
+		&models.StockBatch{}, &models.GoodsReceipt{}, &models.GoodsReceiptLine{},
+		&models.Dispensation{}, &models.DispensationItem{}, &models.StockMovement{},
+		&models.ServicePrice{}, &models.Invoice{}, &models.InvoiceLine{},
+		&models.CashierShift{}, &models.Payment{}, &models.Refund{},
+		&models.ClaimLayout{}, &models.ClaimBatch{}, &models.Claim{}, &models.ClaimLine{},
+		&models.FeeWaiver{}, &models.CdsRule{}, &models.CdsAlert{})
 	if err != nil {
 		panic("Migration failed: " + err.Error())
 	}
+	createIndexes()
 	fmt.Println("Database migration successful")
 }
 
+func createExtensions() {
+	DB := initializers.DB
+
+	DB.Exec(`CREATE EXTENSION IF NOT EXISTS pg_trgm;`)
+}
+
+func createIndexes() {
+	DB := initializers.DB
+
+	DB.Exec(`CREATE INDEX IF NOT EXISTS idx_diagnosis_codes_description_trgm
+		ON diagnosis_codes USING gin (description gin_trgm_ops);`)
+
+	DB.Exec(`CREATE INDEX IF NOT EXISTS idx_patients_full_name_trgm
+		ON patients USING gin ((first_name || ' ' || last_name) gin_trgm_ops);`)
+
+	DB.Exec(`CREATE INDEX IF NOT EXISTS idx_patients_phone_digits_trgm
+		ON patients USING gin ((REGEXP_REPLACE(phone_number, '[^0-9]', '', 'g')) gin_trgm_ops);`)
+
+	DB.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_admissions_active_patient
+		ON admissions (patient_id) WHERE status = 'admitted' AND deleted_at IS NULL;`)
+	DB.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_medication_administrations_scheduled_dose
+		ON medication_administrations (prescription_id, scheduled_at)
+		WHERE scheduled_at IS NOT NULL AND deleted_at IS NULL;`)
+	DB.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_immunizations_patient_dose
+		ON immunizations (patient_id, vaccine_id, dose_number) WHERE deleted_at IS NULL;`)
+	DB.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_invoices_open_appointment
+		ON invoices (appointment_id) WHERE status <> 'void' AND deleted_at IS NULL;`)
+	DB.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_cashier_shifts_open_staff
+		ON cashier_shifts (staff_id) WHERE status = 'open' AND deleted_at IS NULL;`)
+	DB.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_claims_open_invoice
+		ON claims (invoice_id) WHERE status <> 'rejected' AND deleted_at IS NULL;`)
+	DB.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_fee_waivers_pending_invoice
+		ON fee_waivers (invoice_id) WHERE status = 'pending' AND deleted_at IS NULL;`)
+	DB.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_cds_alerts_rule_entity
+		ON cds_alerts (rule_id, trigger, entity_id) WHERE deleted_at IS NULL;`)
+}
+
 func createEnums() {
 	DB := initializers.DB
 
@@ -34,6 +89,12 @@ func createEnums() {
 	END
 	$$;`)
 
+	// Roles added after role_enum was first created.
+	DB.Exec(`ALTER TYPE role_enum ADD VALUE IF NOT EXISTS 'nurse';`)
+	DB.Exec(`ALTER TYPE role_enum ADD VALUE IF NOT EXISTS 'pharmacist';`)
+	DB.Exec(`ALTER TYPE role_enum ADD VALUE IF NOT EXISTS 'cashier';`)
+	DB.Exec(`ALTER TYPE role_enum ADD VALUE IF NOT EXISTS 'finance_manager';`)
+
 	DB.Exec(`DO $$
 	BEGIN
 		IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'gender_enum') THEN
@@ -93,4 +154,278 @@ func createEnums() {
 	END
 	$$;
 	`)
+
+	DB.Exec(`DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'diagnosis_type') THEN
+			CREATE TYPE diagnosis_type AS ENUM ('primary', 'secondary');
+		END IF;
+	END
+	$$;`)
+	DB.Exec(`DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'medication_route') THEN
+			CREATE TYPE medication_route AS ENUM (
+				'oral', 'iv', 'im', 'sc', 'topical', 'inhaled',
+				'rectal', 'sublingual', 'ophthalmic', 'otic', 'nasal'
+			);
+		END IF;
+	END
+	$$;`)
+
+	DB.Exec(`DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'dose_frequency') THEN
+			CREATE TYPE dose_frequency AS ENUM (
+				'OD', 'BD', 'TDS', 'QDS', 'Q4H', 'Q6H', 'Q8H', 'Q12H', 'NOCTE', 'STAT', 'PRN'
+			);
+		END IF;
+	END
+	$$;`)
+
+	DB.Exec(`DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'prescription_status') THEN
+			CREATE TYPE prescription_status AS ENUM ('active', 'dispensed', 'discontinued');
+		END IF;
+	END
+	$$;`)
+
+	DB.Exec(`DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'allergy_severity') THEN
+			CREATE TYPE allergy_severity AS ENUM ('mild', 'moderate', 'severe', 'life_threatening');
+		END IF;
+	END
+	$$;`)
+
+	DB.Exec(`DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'allergy_verification_status') THEN
+			CREATE TYPE allergy_verification_status AS ENUM (
+				'unconfirmed', 'confirmed', 'refuted', 'entered_in_error'
+			);
+		END IF;
+	END
+	$$;`)
+
+	DB.Exec(`DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'lab_result_type') THEN
+			CREATE TYPE lab_result_type AS ENUM ('numeric', 'text');
+		END IF;
+	END
+	$$;`)
+
+	DB.Exec(`DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'lab_priority') THEN
+			CREATE TYPE lab_priority AS ENUM ('routine', 'urgent', 'stat');
+		END IF;
+	END
+	$$;`)
+
+	DB.Exec(`DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'lab_order_status') THEN
+			CREATE TYPE lab_order_status AS ENUM (
+				'ordered', 'sample_collected', 'resulted', 'verified', 'cancelled'
+			);
+		END IF;
+	END
+	$$;`)
+
+	DB.Exec(`DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'referral_urgency') THEN
+			CREATE TYPE referral_urgency AS ENUM ('routine', 'urgent', 'emergency');
+		END IF;
+	END
+	$$;`)
+
+	DB.Exec(`DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'referral_status') THEN
+			CREATE TYPE referral_status AS ENUM (
+				'pending', 'accepted', 'scheduled', 'completed', 'declined'
+			);
+		END IF;
+	END
+	$$;`)
+
+	DB.Exec(`DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'contact_type') THEN
+			CREATE TYPE contact_type AS ENUM ('next_of_kin', 'guardian', 'emergency');
+		END IF;
+	END
+	$$;`)
+
+	DB.Exec(`DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'contact_relationship') THEN
+			CREATE TYPE contact_relationship AS ENUM (
+				'parent', 'spouse', 'partner', 'child', 'sibling', 'grandparent',
+				'relative', 'legal_guardian', 'friend', 'other'
+			);
+		END IF;
+	END
+	$$;`)
+
+	DB.Exec(`DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'payer_type') THEN
+			CREATE TYPE payer_type AS ENUM ('hmo', 'insurer', 'government', 'corporate');
+		END IF;
+	END
+	$$;`)
+
+	DB.Exec(`DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'coverage_priority') THEN
+			CREATE TYPE coverage_priority AS ENUM ('primary', 'secondary');
+		END IF;
+	END
+	$$;`)
+
+	DB.Exec(`DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'document_category') THEN
+			CREATE TYPE document_category AS ENUM (
+				'referral_letter', 'lab_result', 'imaging', 'id_card',
+				'insurance_card', 'consent_form', 'other'
+			);
+		END IF;
+	END
+	$$;`)
+
+	DB.Exec(`DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'problem_status') THEN
+			CREATE TYPE problem_status AS ENUM ('active', 'inactive', 'resolved');
+		END IF;
+	END
+	$$;`)
+
+	DB.Exec(`DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'injection_site') THEN
+			CREATE TYPE injection_site AS ENUM (
+				'left_arm', 'right_arm', 'left_thigh', 'right_thigh', 'oral', 'intranasal'
+			);
+		END IF;
+	END
+	$$;`)
+
+	DB.Exec(`DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'bed_status') THEN
+			CREATE TYPE bed_status AS ENUM ('available', 'occupied', 'cleaning', 'reserved');
+		END IF;
+	END
+	$$;`)
+
+	DB.Exec(`DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'admission_status') THEN
+			CREATE TYPE admission_status AS ENUM ('admitted', 'discharged');
+		END IF;
+	END
+	$$;`)
+
+	DB.Exec(`DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'discharge_disposition') THEN
+			CREATE TYPE discharge_disposition AS ENUM ('home', 'transferred', 'against_advice', 'deceased');
+		END IF;
+	END
+	$$;`)
+
+	DB.Exec(`DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'administration_status') THEN
+			CREATE TYPE administration_status AS ENUM ('given', 'held', 'refused');
+		END IF;
+	END
+	$$;`)
+
+	DB.Exec(`DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'stock_movement_type') THEN
+			CREATE TYPE stock_movement_type AS ENUM ('receipt', 'dispense');
+		END IF;
+	END
+	$$;`)
+
+	DB.Exec(`DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'charge_category') THEN
+			CREATE TYPE charge_category AS ENUM ('consultation', 'lab_test', 'medication', 'procedure', 'other');
+		END IF;
+	END
+	$$;`)
+
+	DB.Exec(`DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'invoice_status') THEN
+			CREATE TYPE invoice_status AS ENUM ('draft', 'issued', 'partially_paid', 'paid', 'void');
+		END IF;
+	END
+	$$;`)
+
+	DB.Exec(`DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'payment_method') THEN
+			CREATE TYPE payment_method AS ENUM ('cash', 'card', 'transfer', 'insurance');
+		END IF;
+	END
+	$$;`)
+
+	DB.Exec(`DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'cashier_shift_status') THEN
+			CREATE TYPE cashier_shift_status AS ENUM ('open', 'closed');
+		END IF;
+	END
+	$$;`)
+
+	DB.Exec(`DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'claim_batch_status') THEN
+			CREATE TYPE claim_batch_status AS ENUM ('generated', 'submitted', 'reconciled');
+		END IF;
+	END
+	$$;`)
+
+	DB.Exec(`DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'claim_status') THEN
+			CREATE TYPE claim_status AS ENUM ('pending', 'paid', 'partially_paid', 'rejected');
+		END IF;
+	END
+	$$;`)
+
+	DB.Exec(`DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'fee_waiver_status') THEN
+			CREATE TYPE fee_waiver_status AS ENUM ('pending', 'approved', 'rejected', 'cancelled');
+		END IF;
+	END
+	$$;`)
+
+	DB.Exec(`DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'cds_trigger') THEN
+			CREATE TYPE cds_trigger AS ENUM ('clinical_note', 'prescription', 'lab_result');
+		END IF;
+	END
+	$$;`)
+
+	DB.Exec(`DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'cds_severity') THEN
+			CREATE TYPE cds_severity AS ENUM ('advisory', 'blocking');
+		END IF;
+	END
+	$$;`)
 }
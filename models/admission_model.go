@@ -0,0 +1,122 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Ward struct {
+	gorm.Model
+	Name        string `json:"name" gorm:"unique;not null"`
+	Department  string `json:"department" gorm:"type:department_type;not null"`
+	Description string `json:"description,omitempty"`
+	IsActive    bool   `json:"isActive" gorm:"default:true"`
+	Beds        []Bed  `json:"beds,omitempty"`
+}
+
+type Bed struct {
+	gorm.Model
+	WardID uint   `json:"wardId" gorm:"not null;uniqueIndex:idx_ward_bed_label"`
+	Label  string `json:"label" gorm:"not null;size:20;uniqueIndex:idx_ward_bed_label"`
+	Status string `json:"status" gorm:"type:bed_status;default:'available'"`
+}
+
+// Admission is an inpatient stay. WardID and BedID always point at the
+// patient's current bed; earlier beds are kept in BedAssignments.
+type Admission struct {
+	gorm.Model
+	PatientID            uint            `json:"patientId" gorm:"not null;index"`
+	AppointmentID        *uint           `json:"appointmentId,omitempty"`
+	ReferralID           *uint           `json:"referralId,omitempty"`
+	AdmittingDoctorID    uint            `json:"admittingDoctorId" gorm:"not null"`
+	WardID               uint            `json:"wardId" gorm:"not null;index"`
+	Ward                 *Ward           `json:"ward,omitempty"`
+	BedID                uint            `json:"bedId" gorm:"not null"`
+	Bed                  *Bed            `json:"bed,omitempty"`
+	Reason               string          `json:"reason" gorm:"size:1000;not null"`
+	Status               string          `json:"status" gorm:"type:admission_status;default:'admitted';index"`
+	AdmittedAt           time.Time       `json:"admittedAt" gorm:"not null"`
+	DischargedAt         *time.Time      `json:"dischargedAt,omitempty"`
+	DischargedBy         *uint           `json:"dischargedBy,omitempty"`
+	DischargeDisposition string          `json:"dischargeDisposition,omitempty" gorm:"type:discharge_disposition"`
+	DischargeNotes       string          `json:"dischargeNotes,omitempty" gorm:"size:1000"`
+	BedAssignments       []BedAssignment `json:"bedAssignments,omitempty"`
+}
+
+// BedAssignment records one bed an admitted patient occupied and when.
+type BedAssignment struct {
+	gorm.Model
+	AdmissionID uint       `json:"admissionId" gorm:"not null;index"`
+	BedID       uint       `json:"bedId" gorm:"not null"`
+	Bed         *Bed       `json:"bed,omitempty"`
+	AssignedAt  time.Time  `json:"assignedAt" gorm:"not null"`
+	ReleasedAt  *time.Time `json:"releasedAt,omitempty"`
+	AssignedBy  uint       `json:"assignedBy"`
+	Reason      string     `json:"reason,omitempty" gorm:"size:500"`
+}
+
+type CreateWardInput struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Department  string `json:"department" binding:"required,oneof=general cardiology pediatrics"`
+	Description string `json:"description" binding:"omitempty,max=500"`
+}
+
+type UpdateWardInput struct {
+	Name        *string `json:"name,omitempty" binding:"omitempty,max=100"`
+	Description *string `json:"description,omitempty" binding:"omitempty,max=500"`
+	IsActive    *bool   `json:"isActive,omitempty"`
+}
+
+type CreateBedInput struct {
+	Label string `json:"label" binding:"required,max=20"`
+}
+
+// UpdateBedStatusInput changes the housekeeping state of an unoccupied bed.
+// Beds only become occupied through an admission.
+type UpdateBedStatusInput struct {
+	Status string `json:"status" binding:"required,oneof=available cleaning reserved"`
+}
+
+type AdmitPatientInput struct {
+	AppointmentID *uint  `json:"appointmentId" binding:"required_without=ReferralID"`
+	ReferralID    *uint  `json:"referralId" binding:"required_without=AppointmentID"`
+	BedID         uint   `json:"bedId" binding:"required"`
+	Reason        string `json:"reason" binding:"required,max=1000"`
+}
+
+type TransferInput struct {
+	BedID  uint   `json:"bedId" binding:"required"`
+	Reason string `json:"reason" binding:"omitempty,max=500"`
+}
+
+type DischargeInput struct {
+	Disposition string `json:"disposition" binding:"required,oneof=home transferred against_advice deceased"`
+	Notes       string `json:"notes" binding:"omitempty,max=1000"`
+}
+
+type AdmissionQuery struct {
+	PageQuery
+	Status    string `form:"status" binding:"omitempty,oneof=admitted discharged"`
+	WardID    uint   `form:"wardId"`
+	PatientID uint   `form:"patientId"`
+}
+
+// BedOccupancy is one bed on the ward board with its current patient.
+type BedOccupancy struct {
+	BedID       uint       `json:"bedId"`
+	Label       string     `json:"label"`
+	Status      string     `json:"status"`
+	AdmissionID *uint      `json:"admissionId,omitempty"`
+	PatientID   *uint      `json:"patientId,omitempty"`
+	PatientName string     `json:"patientName,omitempty"`
+	AdmittedAt  *time.Time `json:"admittedAt,omitempty"`
+}
+
+type WardBoard struct {
+	WardID     uint           `json:"wardId"`
+	WardName   string         `json:"wardName"`
+	Department string         `json:"department"`
+	Counts     map[string]int `json:"counts"`
+	Beds       []BedOccupancy `json:"beds"`
+}
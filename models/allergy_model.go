@@ -0,0 +1,31 @@
+package models
+
+import "gorm.io/gorm"
+
+type PatientAllergy struct {
+	gorm.Model
+	PatientID          uint   `json:"patientId" gorm:"not null;index"`
+	Substance          string `json:"substance" gorm:"not null"`
+	SubstanceClass     string `json:"substanceClass,omitempty"`
+	Reaction           string `json:"reaction,omitempty" gorm:"size:500"`
+	Severity           string `json:"severity" gorm:"type:allergy_severity;not null"`
+	VerificationStatus string `json:"verificationStatus" gorm:"type:allergy_verification_status;default:'unconfirmed'"`
+	RecordedBy         uint   `json:"recordedBy"`
+	UpdatedBy          uint   `json:"updatedBy"`
+}
+
+type CreateAllergyInput struct {
+	Substance          string `json:"substance" binding:"required,max=100"`
+	SubstanceClass     string `json:"substanceClass" binding:"omitempty,max=100"`
+	Reaction           string `json:"reaction" binding:"omitempty,max=500"`
+	Severity           string `json:"severity" binding:"required,oneof=mild moderate severe life_threatening"`
+	VerificationStatus string `json:"verificationStatus" binding:"omitempty,oneof=unconfirmed confirmed refuted entered_in_error"`
+}
+
+type UpdateAllergyInput struct {
+	Substance          *string `json:"substance,omitempty" binding:"omitempty,max=100"`
+	SubstanceClass     *string `json:"substanceClass,omitempty" binding:"omitempty,max=100"`
+	Reaction           *string `json:"reaction,omitempty" binding:"omitempty,max=500"`
+	Severity           *string `json:"severity,omitempty" binding:"omitempty,oneof=mild moderate severe life_threatening"`
+	VerificationStatus *string `json:"verificationStatus,omitempty" binding:"omitempty,oneof=unconfirmed confirmed refuted entered_in_error"`
+}
@@ -16,7 +16,11 @@ type Appointment struct {
 	Duration       int       `json:"duration" gorm:"default:30"`
 	Status         string    `json:"status" gorm:"type:appointment_status;default:'scheduled'"`
 	Reason         string    `json:"reason,omitempty" gorm:"size:500"`
+	ReferralID     *uint     `json:"referralId,omitempty"`
 	UpdatedBy      uint      `json:"updatedBy"`
+
+	// Warnings are advisory messages raised while booking; they are not stored.
+	Warnings []string `json:"warnings,omitempty" gorm:"-"`
 }
 
 type CreateAppointmentInput struct {
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+type AuditLog struct {
+	ID         uint      `json:"id" gorm:"primarykey"`
+	CreatedAt  time.Time `json:"createdAt" gorm:"index"`
+	Action     string    `json:"action" gorm:"not null;index"`
+	EntityType string    `json:"entityType" gorm:"not null"`
+	EntityID   uint      `json:"entityId" gorm:"not null"`
+	PatientID  *uint     `json:"patientId,omitempty" gorm:"index"`
+	StaffID    uint      `json:"staffId" gorm:"not null"`
+	Reason     string    `json:"reason,omitempty" gorm:"size:500"`
+	Details    string    `json:"details,omitempty" gorm:"type:text"`
+}
+
+// FieldChange is recorded in audit details for each field an update changed.
+type FieldChange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
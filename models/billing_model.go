@@ -0,0 +1,137 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ServicePrice is an entry in the price catalog. A price with a department
+// applies to that department only and takes precedence over the
+// hospital-wide price, which has none. Lab test and medication prices are
+// linked to the item they charge for.
+type ServicePrice struct {
+	gorm.Model
+	Code         string  `json:"code" gorm:"unique;not null;size:30"`
+	Name         string  `json:"name" gorm:"not null"`
+	Category     string  `json:"category" gorm:"type:charge_category;not null;index"`
+	Department   *string `json:"department,omitempty" gorm:"type:department_type"`
+	LabTestID    *uint   `json:"labTestId,omitempty" gorm:"index"`
+	MedicationID *uint   `json:"medicationId,omitempty" gorm:"index"`
+	UnitPrice    float64 `json:"unitPrice" gorm:"type:numeric(12,2);not null"`
+	TaxPercent   float64 `json:"taxPercent" gorm:"type:numeric(5,2);default:0"`
+	IsActive     bool    `json:"isActive" gorm:"default:true"`
+}
+
+// Invoice bills a patient, usually for an appointment. When the patient has
+// insurance the insurer pays all but the plan's copay on each line. Approved
+// fee waivers come off the patient's share and the total.
+type Invoice struct {
+	gorm.Model
+	InvoiceNumber string        `json:"invoiceNumber" gorm:"unique;not null;size:30"`
+	Branch        string        `json:"branch,omitempty" gorm:"size:20"`
+	PatientID     uint          `json:"patientId" gorm:"not null;index"`
+	AppointmentID *uint         `json:"appointmentId,omitempty" gorm:"index"`
+	Status        string        `json:"status" gorm:"type:invoice_status;default:'draft'"`
+	CoverageID    *uint         `json:"coverageId,omitempty"`
+	PayerID       *uint         `json:"payerId,omitempty" gorm:"index"`
+	CopayPercent  float64       `json:"copayPercent" gorm:"type:numeric(5,2);default:0"`
+	Subtotal      float64       `json:"subtotal" gorm:"type:numeric(12,2);default:0"`
+	DiscountTotal float64       `json:"discountTotal" gorm:"type:numeric(12,2);default:0"`
+	TaxTotal      float64       `json:"taxTotal" gorm:"type:numeric(12,2);default:0"`
+	Total         float64       `json:"total" gorm:"type:numeric(12,2);default:0"`
+	InsurerAmount float64       `json:"insurerAmount" gorm:"type:numeric(12,2);default:0"`
+	PatientAmount float64       `json:"patientAmount" gorm:"type:numeric(12,2);default:0"`
+	WaiverTotal   float64       `json:"waiverTotal" gorm:"type:numeric(12,2);default:0"`
+	AmountPaid    float64       `json:"amountPaid" gorm:"type:numeric(12,2);default:0"`
+	CreatedBy     uint          `json:"createdBy" gorm:"not null"`
+	IssuedAt      *time.Time    `json:"issuedAt,omitempty"`
+	IssuedBy      *uint         `json:"issuedBy,omitempty"`
+	VoidedAt      *time.Time    `json:"voidedAt,omitempty"`
+	VoidedBy      *uint         `json:"voidedBy,omitempty"`
+	VoidReason    string        `json:"voidReason,omitempty" gorm:"size:500"`
+	Lines         []InvoiceLine `json:"lines"`
+
+	// Warnings list charges that could not be priced when the invoice was
+	// generated; they are not stored.
+	Warnings []string `json:"warnings,omitempty" gorm:"-"`
+}
+
+// InvoiceLine is one charge. Total is the quantity at the unit price, less
+// the discount, plus tax on the discounted amount.
+type InvoiceLine struct {
+	gorm.Model
+	InvoiceID      uint    `json:"invoiceId" gorm:"not null;index"`
+	ServicePriceID *uint   `json:"servicePriceId,omitempty"`
+	Category       string  `json:"category" gorm:"type:charge_category;not null"`
+	Description    string  `json:"description" gorm:"not null"`
+	LabOrderID     *uint   `json:"labOrderId,omitempty" gorm:"index"`
+	PrescriptionID *uint   `json:"prescriptionId,omitempty" gorm:"index"`
+	Quantity       int     `json:"quantity" gorm:"not null"`
+	UnitPrice      float64 `json:"unitPrice" gorm:"type:numeric(12,2);not null"`
+	Discount       float64 `json:"discount" gorm:"type:numeric(12,2);default:0"`
+	TaxPercent     float64 `json:"taxPercent" gorm:"type:numeric(5,2);default:0"`
+	TaxAmount      float64 `json:"taxAmount" gorm:"type:numeric(12,2);default:0"`
+	Total          float64 `json:"total" gorm:"type:numeric(12,2);default:0"`
+	InsurerAmount  float64 `json:"insurerAmount" gorm:"type:numeric(12,2);default:0"`
+	PatientAmount  float64 `json:"patientAmount" gorm:"type:numeric(12,2);default:0"`
+}
+
+type CreateServicePriceInput struct {
+	Code         string  `json:"code" binding:"required,max=30"`
+	Name         string  `json:"name" binding:"required,max=100"`
+	Category     string  `json:"category" binding:"required,oneof=consultation lab_test medication procedure other"`
+	Department   *string `json:"department" binding:"omitempty,oneof=general cardiology pediatrics"`
+	LabTestID    *uint   `json:"labTestId"`
+	MedicationID *uint   `json:"medicationId"`
+	UnitPrice    float64 `json:"unitPrice" binding:"min=0"`
+	TaxPercent   float64 `json:"taxPercent" binding:"omitempty,min=0,max=100"`
+}
+
+type UpdateServicePriceInput struct {
+	Name       *string  `json:"name,omitempty" binding:"omitempty,max=100"`
+	UnitPrice  *float64 `json:"unitPrice,omitempty" binding:"omitempty,min=0"`
+	TaxPercent *float64 `json:"taxPercent,omitempty" binding:"omitempty,min=0,max=100"`
+	IsActive   *bool    `json:"isActive,omitempty"`
+}
+
+type ServicePriceQuery struct {
+	Category   string `form:"category" binding:"omitempty,oneof=consultation lab_test medication procedure other"`
+	Department string `form:"department" binding:"omitempty,oneof=general cardiology pediatrics"`
+	Q          string `form:"q"`
+	ActiveOnly bool   `form:"activeOnly"`
+}
+
+type CreateInvoiceInput struct {
+	AppointmentID uint `json:"appointmentId" binding:"required"`
+}
+
+// AddInvoiceLineInput adds a catalog item, such as a procedure, to a draft
+// invoice.
+type AddInvoiceLineInput struct {
+	ServicePriceID uint   `json:"servicePriceId" binding:"required"`
+	Quantity       int    `json:"quantity" binding:"omitempty,min=1"`
+	Description    string `json:"description" binding:"omitempty,max=255"`
+}
+
+type UpdateInvoiceLineInput struct {
+	Quantity *int `json:"quantity,omitempty" binding:"omitempty,min=1"`
+}
+
+// DiscountInvoiceLineInput sets a draft line's discount. Discounts are
+// approved by finance and audited with their reason.
+type DiscountInvoiceLineInput struct {
+	Discount float64 `json:"discount" binding:"min=0"`
+	Reason   string  `json:"reason" binding:"required,max=500"`
+}
+
+type VoidInvoiceInput struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+type InvoiceQuery struct {
+	PageQuery
+	PatientID     uint   `form:"patientId"`
+	AppointmentID uint   `form:"appointmentId"`
+	Status        string `form:"status" binding:"omitempty,oneof=draft issued partially_paid paid void"`
+}
@@ -0,0 +1,120 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CdsRule is a clinical decision support rule. It fires when every condition
+// in All holds and, if Any is not empty, at least one condition in Any does.
+type CdsRule struct {
+	gorm.Model
+	Name        string        `json:"name" gorm:"unique;not null;size:100"`
+	Description string        `json:"description,omitempty" gorm:"size:500"`
+	Trigger     string        `json:"trigger" gorm:"type:cds_trigger;not null;index"`
+	Severity    string        `json:"severity" gorm:"type:cds_severity;not null"`
+	Message     string        `json:"message" gorm:"not null;size:500"`
+	Conditions  CdsConditions `json:"conditions" gorm:"type:jsonb;serializer:json"`
+	IsActive    bool          `json:"isActive" gorm:"default:true"`
+	CreatedBy   uint          `json:"createdBy"`
+}
+
+type CdsConditions struct {
+	All []CdsCondition `json:"all,omitempty" binding:"omitempty,dive"`
+	Any []CdsCondition `json:"any,omitempty" binding:"omitempty,dive"`
+}
+
+// CdsCondition compares a fact about the patient or the record being saved.
+// Text operators (eq, neq) take Value and list operators (in, contains) take
+// Values, matched without regard to case; numeric operators take Number.
+// Contains matches whole words and skips a term negated earlier in its clause
+// ("no fever", "denies fever"). LabTest is the test code lab facts refer to.
+// A condition on a fact that is not recorded never holds, except that a rule
+// needing the patient's weight still raises an advisory alert saying it could
+// not be checked.
+type CdsCondition struct {
+	Fact     string   `json:"fact" binding:"required"`
+	Operator string   `json:"operator" binding:"required,oneof=eq neq in contains gt gte lt lte"`
+	Value    string   `json:"value,omitempty" binding:"omitempty,max=100"`
+	Values   []string `json:"values,omitempty" binding:"omitempty,dive,max=100"`
+	Number   *float64 `json:"number,omitempty"`
+	LabTest  string   `json:"labTest,omitempty" binding:"omitempty,max=20"`
+}
+
+// CdsAlert records a rule firing on a saved record: the clinical note,
+// prescription or lab order named by Trigger and EntityID. Facts holds the
+// values the rule's conditions were tested against.
+type CdsAlert struct {
+	gorm.Model
+	RuleID                uint              `json:"ruleId" gorm:"not null"`
+	RuleName              string            `json:"ruleName" gorm:"not null;size:100"`
+	PatientID             uint              `json:"patientId" gorm:"not null;index"`
+	Trigger               string            `json:"trigger" gorm:"type:cds_trigger;not null"`
+	EntityID              uint              `json:"entityId" gorm:"not null"`
+	Severity              string            `json:"severity" gorm:"type:cds_severity;not null"`
+	Message               string            `json:"message" gorm:"not null;size:500"`
+	Facts                 map[string]string `json:"facts,omitempty" gorm:"type:jsonb;serializer:json"`
+	TriggeredBy           uint              `json:"triggeredBy" gorm:"not null"`
+	AcknowledgedBy        *uint             `json:"acknowledgedBy,omitempty"`
+	AcknowledgedAt        *time.Time        `json:"acknowledgedAt,omitempty"`
+	AcknowledgementReason string            `json:"acknowledgementReason,omitempty" gorm:"size:500"`
+}
+
+// CdsEvent is a record about to be saved, passed to the rules of its trigger.
+// EntityID is zero when the record is new. Prescription.Medication and the
+// lab tests of LabOrder.Results must be loaded.
+type CdsEvent struct {
+	Trigger      string
+	PatientID    uint
+	EntityID     uint
+	Note         *ClinicalNote
+	Prescription *Prescription
+	LabOrder     *LabOrder
+}
+
+// ClinicalRecordWrites is saved in the transaction that saves a clinical note,
+// prescription or lab results, so the record is never kept without its alerts
+// or audit. New alerts and allergy overrides take the record's ID once it is
+// saved; AuditEntries already name what they describe.
+type ClinicalRecordWrites struct {
+	NewAlerts    []*CdsAlert
+	Acknowledged []*CdsAlert
+	Overrides    []*AuditLog
+	AuditEntries []*AuditLog
+}
+
+type CreateCdsRuleInput struct {
+	Name        string        `json:"name" binding:"required,max=100"`
+	Description string        `json:"description" binding:"omitempty,max=500"`
+	Trigger     string        `json:"trigger" binding:"required,oneof=clinical_note prescription lab_result"`
+	Severity    string        `json:"severity" binding:"required,oneof=advisory blocking"`
+	Message     string        `json:"message" binding:"required,max=500"`
+	Conditions  CdsConditions `json:"conditions" binding:"required"`
+}
+
+type UpdateCdsRuleInput struct {
+	Name        *string        `json:"name,omitempty" binding:"omitempty,max=100"`
+	Description *string        `json:"description,omitempty" binding:"omitempty,max=500"`
+	Severity    *string        `json:"severity,omitempty" binding:"omitempty,oneof=advisory blocking"`
+	Message     *string        `json:"message,omitempty" binding:"omitempty,max=500"`
+	Conditions  *CdsConditions `json:"conditions,omitempty"`
+	IsActive    *bool          `json:"isActive,omitempty"`
+}
+
+type CdsRuleQuery struct {
+	Trigger    string `form:"trigger" binding:"omitempty,oneof=clinical_note prescription lab_result"`
+	ActiveOnly bool   `form:"activeOnly"`
+}
+
+type CdsAlertQuery struct {
+	PageQuery
+	PatientID    uint   `form:"patientId"`
+	Trigger      string `form:"trigger" binding:"omitempty,oneof=clinical_note prescription lab_result"`
+	Severity     string `form:"severity" binding:"omitempty,oneof=advisory blocking"`
+	Acknowledged *bool  `form:"acknowledged"`
+}
+
+type AcknowledgeCdsAlertInput struct {
+	Reason string `json:"reason" binding:"omitempty,max=500"`
+}
@@ -0,0 +1,113 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ClaimBatch gathers the claims sent to one payer for invoices issued in a
+// period.
+type ClaimBatch struct {
+	gorm.Model
+	BatchNumber  string     `json:"batchNumber" gorm:"unique;not null;size:30"`
+	Branch       string     `json:"branch,omitempty" gorm:"size:20"`
+	PayerID      uint       `json:"payerId" gorm:"not null;index"`
+	Payer        *Payer     `json:"payer,omitempty"`
+	PeriodStart  time.Time  `json:"periodStart" gorm:"type:date;not null"`
+	PeriodEnd    time.Time  `json:"periodEnd" gorm:"type:date;not null"`
+	Status       string     `json:"status" gorm:"type:claim_batch_status;default:'generated'"`
+	TotalClaimed float64    `json:"totalClaimed" gorm:"type:numeric(12,2);default:0"`
+	TotalPaid    float64    `json:"totalPaid" gorm:"type:numeric(12,2);default:0"`
+	CreatedBy    uint       `json:"createdBy" gorm:"not null"`
+	SubmittedAt  *time.Time `json:"submittedAt,omitempty"`
+	SubmittedBy  *uint      `json:"submittedBy,omitempty"`
+	ReconciledAt *time.Time `json:"reconciledAt,omitempty"`
+	Claims       []Claim    `json:"claims,omitempty"`
+}
+
+// Claim asks the payer for the insurer's share of one invoice. An invoice is
+// claimed once unless the payer rejects the claim.
+type Claim struct {
+	gorm.Model
+	ClaimBatchID  uint        `json:"claimBatchId" gorm:"not null;index"`
+	InvoiceID     uint        `json:"invoiceId" gorm:"not null"`
+	InvoiceNumber string      `json:"invoiceNumber" gorm:"not null;size:30"`
+	PatientID     uint        `json:"patientId" gorm:"not null;index"`
+	PatientName   string      `json:"patientName"`
+	CoverageID    *uint       `json:"coverageId,omitempty"`
+	MemberNumber  string      `json:"memberNumber,omitempty"`
+	ServiceDate   time.Time   `json:"serviceDate" gorm:"type:date;not null"`
+	AmountClaimed float64     `json:"amountClaimed" gorm:"type:numeric(12,2);default:0"`
+	AmountPaid    float64     `json:"amountPaid" gorm:"type:numeric(12,2);default:0"`
+	Status        string      `json:"status" gorm:"type:claim_status;default:'pending'"`
+	Lines         []ClaimLine `json:"lines,omitempty"`
+}
+
+// ClaimLine claims the insurer's share of one invoice line. Its ID is the
+// reference payers quote back in their remittance.
+type ClaimLine struct {
+	gorm.Model
+	ClaimID         uint    `json:"claimId" gorm:"not null;index"`
+	InvoiceLineID   uint    `json:"invoiceLineId" gorm:"not null"`
+	Category        string  `json:"category" gorm:"type:charge_category;not null"`
+	Description     string  `json:"description" gorm:"not null"`
+	Quantity        int     `json:"quantity" gorm:"not null"`
+	AmountClaimed   float64 `json:"amountClaimed" gorm:"type:numeric(12,2);not null"`
+	AmountPaid      float64 `json:"amountPaid" gorm:"type:numeric(12,2);default:0"`
+	Status          string  `json:"status" gorm:"type:claim_status;default:'pending'"`
+	RejectionReason string  `json:"rejectionReason,omitempty" gorm:"size:500"`
+}
+
+// ClaimLayout is a payer's file layout: the columns claims are exported in
+// and the columns its remittance files use for the line reference, amount
+// paid and reason.
+type ClaimLayout struct {
+	gorm.Model
+	PayerID                uint                `json:"payerId" gorm:"unique;not null"`
+	Format                 string              `json:"format" gorm:"not null;size:10"`
+	Columns                []ClaimLayoutColumn `json:"columns" gorm:"type:jsonb;serializer:json"`
+	RemittanceLineColumn   string              `json:"remittanceLineColumn" gorm:"size:50"`
+	RemittanceAmountColumn string              `json:"remittanceAmountColumn" gorm:"size:50"`
+	RemittanceReasonColumn string              `json:"remittanceReasonColumn" gorm:"size:50"`
+}
+
+type ClaimLayoutColumn struct {
+	Header string `json:"header" binding:"required,max=50"`
+	Field  string `json:"field" binding:"required"`
+}
+
+type GenerateClaimBatchInput struct {
+	PayerID     uint   `json:"payerId" binding:"required"`
+	PeriodStart string `json:"periodStart" binding:"required,datetime=2006-01-02"`
+	PeriodEnd   string `json:"periodEnd" binding:"required,datetime=2006-01-02"`
+}
+
+type ClaimBatchQuery struct {
+	PageQuery
+	PayerID uint   `form:"payerId"`
+	Status  string `form:"status" binding:"omitempty,oneof=generated submitted reconciled"`
+}
+
+type ClaimExportQuery struct {
+	Format string `form:"format" binding:"omitempty,oneof=csv json"`
+}
+
+type SaveClaimLayoutInput struct {
+	Format                 string              `json:"format" binding:"required,oneof=csv json"`
+	Columns                []ClaimLayoutColumn `json:"columns" binding:"required,min=1,dive"`
+	RemittanceLineColumn   string              `json:"remittanceLineColumn" binding:"omitempty,max=50"`
+	RemittanceAmountColumn string              `json:"remittanceAmountColumn" binding:"omitempty,max=50"`
+	RemittanceReasonColumn string              `json:"remittanceReasonColumn" binding:"omitempty,max=50"`
+}
+
+// RemittanceResult summarises a remittance import. Unmatched lists the line
+// references in the file that are not in the batch.
+type RemittanceResult struct {
+	Batch         *ClaimBatch `json:"batch"`
+	Matched       int         `json:"matched"`
+	Paid          int         `json:"paid"`
+	PartiallyPaid int         `json:"partiallyPaid"`
+	Rejected      int         `json:"rejected"`
+	Unmatched     []string    `json:"unmatched"`
+}
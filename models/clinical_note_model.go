@@ -4,29 +4,44 @@ import "gorm.io/gorm"
 
 type ClinicalNote struct {
 	gorm.Model
-	AppointmentID        uint   `json:"appointmentId" gorm:"not null;unique"`
-	PatientID            uint   `json:"patientId" gorm:"not null"`
-	DoctorID             uint   `json:"doctorId" gorm:"not null"`
-	PresentingComplaints string `json:"presentingComplaints" gorm:"type:text"`
-	PastMedicalHistory   string `json:"pastMedicalHistory" gorm:"type:text"`
-	ClinicalDiagnosis    string `json:"clinicalHistoryDiagnosis" gorm:"type:text"`
-	TreatmentPlan        string `json:"treatmentPlan" gorm:"type:text"`
-	Recommendation       string `json:"recommendation" gorm:"type:text"`
+	// Outpatient notes belong to an appointment and inpatient notes to an
+	// admission; exactly one of the two is set.
+	AppointmentID        *uint           `json:"appointmentId,omitempty" gorm:"unique"`
+	AdmissionID          *uint           `json:"admissionId,omitempty" gorm:"index"`
+	PatientID            uint            `json:"patientId" gorm:"not null"`
+	DoctorID             uint            `json:"doctorId" gorm:"not null"`
+	PresentingComplaints string          `json:"presentingComplaints" gorm:"type:text"`
+	PastMedicalHistory   string          `json:"pastMedicalHistory" gorm:"type:text"`
+	ClinicalDiagnosis    string          `json:"clinicalDiagnosis" gorm:"type:text"`
+	TreatmentPlan        string          `json:"treatmentPlan" gorm:"type:text"`
+	Recommendation       string          `json:"recommendation" gorm:"type:text"`
+	Diagnoses            []NoteDiagnosis `json:"diagnoses,omitempty" gorm:"foreignKey:ClinicalNoteID"`
+	// Alerts are the decision support alerts raised when the note was saved.
+	Alerts []CdsAlert `json:"alerts,omitempty" gorm:"-"`
 }
 
 type CreateNoteInput struct {
-	AppointmentID        uint   `json:"appointmentId" binding:"required"`
-	PresentingComplaints string `json:"presentingComplaints" binding:"required,max=1000"`
-	PastMedicalHistory   string `json:"pastMedicalHistory" binding:"max=1000"`
-	ClinicalDiagnosis    string `json:"clinicalDiagnosis" binding:"max=1000"`
-	TreatmentPlan        string `json:"treatmentPlan" binding:"required,max=1000"`
-	Recommendation       string `json:"recommendation" binding:"required,max=1000"`
+	AppointmentID        *uint                `json:"appointmentId" binding:"required_without=AdmissionID,excluded_with=AdmissionID"`
+	AdmissionID          *uint                `json:"admissionId" binding:"required_without=AppointmentID"`
+	PresentingComplaints string               `json:"presentingComplaints" binding:"required,max=1000"`
+	PastMedicalHistory   string               `json:"pastMedicalHistory" binding:"max=1000"`
+	ClinicalDiagnosis    string               `json:"clinicalDiagnosis" binding:"max=1000"`
+	TreatmentPlan        string               `json:"treatmentPlan" binding:"required,max=1000"`
+	Recommendation       string               `json:"recommendation" binding:"required,max=1000"`
+	Diagnoses            []NoteDiagnosisInput `json:"diagnoses" binding:"omitempty,dive"`
+
+	AllergyOverrideReason string `json:"allergyOverrideReason" binding:"omitempty,max=500"`
+	AlertOverrideReason   string `json:"alertOverrideReason" binding:"omitempty,max=500"`
 }
 
 type UpdateNoteInput struct {
-	PresentingComplaints *string `json:"presentingComplaints,omitempty" binding:"omitempty,max=1000"`
-	PastMedicalHistory   *string `json:"pastMedicalHistory,omitempty" binding:"omitempty,max=1000"`
-	ClinicalDiagnosis    *string `json:"clinicalDiagnosis,omitempty" binding:"omitempty,max=1000"`
-	TreatmentPlan        *string `json:"treatmentPlan,omitempty" binding:"omitempty,max=1000"`
-	Recommendation       *string `json:"recommendation,omitempty" binding:"omitempty,max=1000"`
+	PresentingComplaints *string               `json:"presentingComplaints,omitempty" binding:"omitempty,max=1000"`
+	PastMedicalHistory   *string               `json:"pastMedicalHistory,omitempty" binding:"omitempty,max=1000"`
+	ClinicalDiagnosis    *string               `json:"clinicalDiagnosis,omitempty" binding:"omitempty,max=1000"`
+	TreatmentPlan        *string               `json:"treatmentPlan,omitempty" binding:"omitempty,max=1000"`
+	Recommendation       *string               `json:"recommendation,omitempty" binding:"omitempty,max=1000"`
+	Diagnoses            *[]NoteDiagnosisInput `json:"diagnoses,omitempty" binding:"omitempty,dive"`
+
+	AllergyOverrideReason string `json:"allergyOverrideReason" binding:"omitempty,max=500"`
+	AlertOverrideReason   string `json:"alertOverrideReason" binding:"omitempty,max=500"`
 }
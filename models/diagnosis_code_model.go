@@ -0,0 +1,23 @@
+package models
+
+import "gorm.io/gorm"
+
+type DiagnosisCode struct {
+	gorm.Model
+	Code        string `json:"code" gorm:"unique;not null;size:10"`
+	Description string `json:"description" gorm:"not null"`
+	Category    string `json:"category,omitempty"`
+}
+
+type NoteDiagnosis struct {
+	gorm.Model
+	ClinicalNoteID  uint           `json:"clinicalNoteId" gorm:"not null;index"`
+	DiagnosisCodeID uint           `json:"diagnosisCodeId" gorm:"not null"`
+	DiagnosisCode   *DiagnosisCode `json:"diagnosisCode,omitempty"`
+	Type            string         `json:"type" gorm:"type:diagnosis_type;not null"`
+}
+
+type NoteDiagnosisInput struct {
+	Code string `json:"code" binding:"required,max=10"`
+	Type string `json:"type" binding:"required,oneof=primary secondary"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// FeeWaiver is a request to reduce what a patient owes on an invoice. It
+// applies only once approved, by a second approver as well when it is over
+// the configured thresholds.
+type FeeWaiver struct {
+	gorm.Model
+	InvoiceID         uint       `json:"invoiceId" gorm:"not null;index"`
+	PatientID         uint       `json:"patientId" gorm:"not null;index"`
+	Amount            float64    `json:"amount" gorm:"type:numeric(12,2);not null"`
+	Reason            string     `json:"reason" gorm:"not null;size:500"`
+	Status            string     `json:"status" gorm:"type:fee_waiver_status;default:'pending'"`
+	RequiredApprovals int        `json:"requiredApprovals" gorm:"not null;default:1"`
+	RequestedBy       uint       `json:"requestedBy" gorm:"not null"`
+	RequestedAt       time.Time  `json:"requestedAt" gorm:"not null"`
+	FirstApprovedBy   *uint      `json:"firstApprovedBy,omitempty"`
+	FirstApprovedAt   *time.Time `json:"firstApprovedAt,omitempty"`
+	SecondApprovedBy  *uint      `json:"secondApprovedBy,omitempty"`
+	SecondApprovedAt  *time.Time `json:"secondApprovedAt,omitempty"`
+	RejectedBy        *uint      `json:"rejectedBy,omitempty"`
+	RejectedAt        *time.Time `json:"rejectedAt,omitempty"`
+	RejectionReason   string     `json:"rejectionReason,omitempty" gorm:"size:500"`
+	CancelledAt       *time.Time `json:"cancelledAt,omitempty"`
+}
+
+type CreateFeeWaiverInput struct {
+	InvoiceID uint    `json:"invoiceId" binding:"required"`
+	Amount    float64 `json:"amount" binding:"required,gt=0"`
+	Reason    string  `json:"reason" binding:"required,max=500"`
+}
+
+type RejectFeeWaiverInput struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+type FeeWaiverQuery struct {
+	PageQuery
+	InvoiceID uint   `form:"invoiceId"`
+	PatientID uint   `form:"patientId"`
+	Status    string `form:"status" binding:"omitempty,oneof=pending approved rejected cancelled"`
+}
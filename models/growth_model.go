@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/growth"
+	"gorm.io/gorm"
+)
+
+type GrowthMeasurement struct {
+	gorm.Model
+	PatientID  uint      `json:"patientId" gorm:"not null;index"`
+	MeasuredAt time.Time `json:"measuredAt" gorm:"type:date;not null"`
+	WeightKg   *float64  `json:"weightKg,omitempty" gorm:"type:numeric(6,3)"`
+	// HeightCm is recumbent length below two years and standing height after.
+	HeightCm   *float64 `json:"heightCm,omitempty" gorm:"type:numeric(5,1)"`
+	Notes      string   `json:"notes,omitempty"`
+	RecordedBy uint     `json:"recordedBy"`
+}
+
+type RecordGrowthInput struct {
+	MeasuredAt string   `json:"measuredAt" binding:"required,datetime=2006-01-02"`
+	WeightKg   *float64 `json:"weightKg" binding:"omitempty,gt=0,max=200"`
+	HeightCm   *float64 `json:"heightCm" binding:"omitempty,gt=0,max=250"`
+	Notes      string   `json:"notes" binding:"omitempty,max=500"`
+}
+
+// GrowthPoint is one measurement scored against the WHO standards. Scores
+// are omitted when the child is outside the range of the reference tables.
+type GrowthPoint struct {
+	MeasurementID uint          `json:"measurementId"`
+	MeasuredAt    time.Time     `json:"measuredAt"`
+	AgeDays       int           `json:"ageDays"`
+	WeightKg      *float64      `json:"weightKg,omitempty"`
+	HeightCm      *float64      `json:"heightCm,omitempty"`
+	BMI           *float64      `json:"bmi,omitempty"`
+	WeightForAge  *growth.Score `json:"weightForAge,omitempty"`
+	HeightForAge  *growth.Score `json:"heightForAge,omitempty"`
+	BMIForAge     *growth.Score `json:"bmiForAge,omitempty"`
+	// Flags marks faltering growth since the previous measurement.
+	Flags []string `json:"flags"`
+}
+
+type GrowthChart struct {
+	PatientID uint          `json:"patientId"`
+	Gender    string        `json:"gender"`
+	Points    []GrowthPoint `json:"points"`
+}
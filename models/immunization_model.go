@@ -0,0 +1,116 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Vaccine struct {
+	gorm.Model
+	Code        string                `json:"code" gorm:"unique;not null;size:20"`
+	Name        string                `json:"name" gorm:"not null"`
+	Description string                `json:"description,omitempty"`
+	IsActive    bool                  `json:"isActive" gorm:"default:true"`
+	Doses       []VaccineScheduleDose `json:"doses,omitempty"`
+}
+
+// VaccineScheduleDose places one dose of a vaccine on the national schedule
+// by the child's age in days.
+type VaccineScheduleDose struct {
+	gorm.Model
+	VaccineID        uint `json:"vaccineId" gorm:"not null;uniqueIndex:idx_vaccine_dose"`
+	DoseNumber       int  `json:"doseNumber" gorm:"not null;uniqueIndex:idx_vaccine_dose"`
+	DueAgeDays       int  `json:"dueAgeDays" gorm:"not null"`
+	OverdueAfterDays int  `json:"overdueAfterDays" gorm:"not null"`
+	// MaxAgeDays is the oldest age at which the dose is still given; zero
+	// means there is no limit.
+	MaxAgeDays int `json:"maxAgeDays"`
+}
+
+type Immunization struct {
+	gorm.Model
+	PatientID      uint      `json:"patientId" gorm:"not null;index"`
+	VaccineID      uint      `json:"vaccineId" gorm:"not null"`
+	Vaccine        *Vaccine  `json:"vaccine,omitempty"`
+	DoseNumber     int       `json:"doseNumber" gorm:"not null"`
+	LotNumber      string    `json:"lotNumber,omitempty"`
+	Site           string    `json:"site,omitempty" gorm:"type:injection_site"`
+	AdministeredAt time.Time `json:"administeredAt" gorm:"type:date;not null"`
+	// AdministeredBy is the staff member who gave the dose; it is empty for
+	// doses given at another facility.
+	AdministeredBy *uint  `json:"administeredBy,omitempty"`
+	Facility       string `json:"facility,omitempty"`
+	Notes          string `json:"notes,omitempty"`
+	RecordedBy     uint   `json:"recordedBy"`
+}
+
+type CreateVaccineInput struct {
+	Code        string `json:"code" binding:"required,max=20"`
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description" binding:"omitempty,max=500"`
+}
+
+type UpdateVaccineInput struct {
+	Name        *string `json:"name,omitempty" binding:"omitempty,max=100"`
+	Description *string `json:"description,omitempty" binding:"omitempty,max=500"`
+	IsActive    *bool   `json:"isActive,omitempty"`
+}
+
+type ScheduleDoseInput struct {
+	DoseNumber       int `json:"doseNumber" binding:"required,min=1,max=10"`
+	DueAgeDays       int `json:"dueAgeDays" binding:"min=0,max=36500"`
+	OverdueAfterDays int `json:"overdueAfterDays" binding:"omitempty,min=1,max=3650"`
+	MaxAgeDays       int `json:"maxAgeDays" binding:"omitempty,min=1,max=36500"`
+}
+
+type ReplaceScheduleInput struct {
+	Doses []ScheduleDoseInput `json:"doses" binding:"required,dive"`
+}
+
+type RecordImmunizationInput struct {
+	VaccineID      uint   `json:"vaccineId" binding:"required"`
+	DoseNumber     int    `json:"doseNumber" binding:"required,min=1,max=10"`
+	LotNumber      string `json:"lotNumber" binding:"omitempty,max=50"`
+	Site           string `json:"site" binding:"omitempty,oneof=left_arm right_arm left_thigh right_thigh oral intranasal"`
+	AdministeredAt string `json:"administeredAt" binding:"required,datetime=2006-01-02"`
+	// Facility is set for doses given elsewhere; otherwise the recording
+	// staff member is taken as the one who administered it.
+	Facility string `json:"facility" binding:"omitempty,max=150"`
+	Notes    string `json:"notes" binding:"omitempty,max=500"`
+}
+
+// ImmunizationScheduleItem is one scheduled dose and its state for a patient.
+type ImmunizationScheduleItem struct {
+	VaccineID      uint       `json:"vaccineId"`
+	VaccineCode    string     `json:"vaccineCode"`
+	VaccineName    string     `json:"vaccineName"`
+	DoseNumber     int        `json:"doseNumber"`
+	DueDate        time.Time  `json:"dueDate"`
+	OverdueDate    time.Time  `json:"overdueDate"`
+	Status         string     `json:"status"`
+	AdministeredAt *time.Time `json:"administeredAt,omitempty"`
+}
+
+type OverdueImmunizationQuery struct {
+	PageQuery
+	Vaccine string `form:"vaccine" binding:"omitempty,max=20"`
+}
+
+// OverdueImmunization is a child who has missed a scheduled dose, with the
+// details reception needs to follow up.
+type OverdueImmunization struct {
+	PatientID          uint      `json:"patientId"`
+	RegistrationNumber string    `json:"registrationNumber"`
+	FirstName          string    `json:"firstName"`
+	LastName           string    `json:"lastName"`
+	DateOfBirth        time.Time `json:"dateOfBirth"`
+	PhoneNumber        string    `json:"phoneNumber"`
+	GuardianName       string    `json:"guardianName,omitempty"`
+	GuardianPhone      string    `json:"guardianPhone,omitempty"`
+	VaccineCode        string    `json:"vaccineCode"`
+	VaccineName        string    `json:"vaccineName"`
+	DoseNumber         int       `json:"doseNumber"`
+	DueDate            time.Time `json:"dueDate"`
+	DaysOverdue        int       `json:"daysOverdue"`
+}
@@ -0,0 +1,103 @@
+package models
+
+import (
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/utils"
+	"gorm.io/gorm"
+)
+
+type Payer struct {
+	gorm.Model
+	Code         string          `json:"code" gorm:"unique;not null;size:20"`
+	Name         string          `json:"name" gorm:"unique;not null"`
+	PayerType    string          `json:"payerType" gorm:"type:payer_type;not null"`
+	ContactEmail string          `json:"contactEmail,omitempty"`
+	ContactPhone string          `json:"contactPhone,omitempty"`
+	IsActive     bool            `json:"isActive" gorm:"default:true"`
+	Plans        []InsurancePlan `json:"plans,omitempty"`
+}
+
+type InsurancePlan struct {
+	gorm.Model
+	PayerID      uint    `json:"payerId" gorm:"not null;index"`
+	Payer        *Payer  `json:"payer,omitempty"`
+	Code         string  `json:"code" gorm:"not null;size:30"`
+	Name         string  `json:"name" gorm:"not null"`
+	CopayPercent float64 `json:"copayPercent" gorm:"default:0"`
+	Description  string  `json:"description,omitempty" gorm:"size:1000"`
+	IsActive     bool    `json:"isActive" gorm:"default:true"`
+}
+
+// PatientCoverage enrols a patient in a plan. A nil ValidTo means the
+// coverage has no end date.
+type PatientCoverage struct {
+	gorm.Model
+	PatientID            uint           `json:"patientId" gorm:"not null;index"`
+	PlanID               uint           `json:"planId" gorm:"not null"`
+	Plan                 *InsurancePlan `json:"plan,omitempty"`
+	MemberNumber         string         `json:"memberNumber" gorm:"not null"`
+	Priority             string         `json:"priority" gorm:"type:coverage_priority;default:'primary'"`
+	ValidFrom            time.Time      `json:"validFrom" gorm:"not null"`
+	ValidTo              *time.Time     `json:"validTo,omitempty"`
+	IsActive             bool           `json:"isActive" gorm:"default:true"`
+	EligibilityStatus    string         `json:"eligibilityStatus,omitempty"`
+	EligibilityCheckedAt *time.Time     `json:"eligibilityCheckedAt,omitempty"`
+	CreatedBy            uint           `json:"createdBy"`
+	UpdatedBy            uint           `json:"updatedBy"`
+}
+
+// CoversDate reports whether the coverage is in force on the calendar day of at.
+func (c PatientCoverage) CoversDate(at time.Time) bool {
+	day := utils.CalendarDay(at)
+	if day.Before(utils.CalendarDay(c.ValidFrom)) {
+		return false
+	}
+	return c.ValidTo == nil || !day.After(utils.CalendarDay(*c.ValidTo))
+}
+
+type CreatePayerInput struct {
+	Code         string `json:"code" binding:"required,max=20"`
+	Name         string `json:"name" binding:"required,max=100"`
+	PayerType    string `json:"payerType" binding:"required,oneof=hmo insurer government corporate"`
+	ContactEmail string `json:"contactEmail" binding:"omitempty,email"`
+	ContactPhone string `json:"contactPhone" binding:"omitempty,max=20"`
+}
+
+type UpdatePayerInput struct {
+	Name         *string `json:"name,omitempty" binding:"omitempty,max=100"`
+	PayerType    *string `json:"payerType,omitempty" binding:"omitempty,oneof=hmo insurer government corporate"`
+	ContactEmail *string `json:"contactEmail,omitempty" binding:"omitempty,email"`
+	ContactPhone *string `json:"contactPhone,omitempty" binding:"omitempty,max=20"`
+	IsActive     *bool   `json:"isActive,omitempty"`
+}
+
+type CreateInsurancePlanInput struct {
+	Code         string  `json:"code" binding:"required,max=30"`
+	Name         string  `json:"name" binding:"required,max=100"`
+	CopayPercent float64 `json:"copayPercent" binding:"omitempty,min=0,max=100"`
+	Description  string  `json:"description" binding:"omitempty,max=1000"`
+}
+
+type UpdateInsurancePlanInput struct {
+	Name         *string  `json:"name,omitempty" binding:"omitempty,max=100"`
+	CopayPercent *float64 `json:"copayPercent,omitempty" binding:"omitempty,min=0,max=100"`
+	Description  *string  `json:"description,omitempty" binding:"omitempty,max=1000"`
+	IsActive     *bool    `json:"isActive,omitempty"`
+}
+
+type CreateCoverageInput struct {
+	PlanID       uint   `json:"planId" binding:"required"`
+	MemberNumber string `json:"memberNumber" binding:"required,max=50"`
+	Priority     string `json:"priority" binding:"omitempty,oneof=primary secondary"`
+	ValidFrom    string `json:"validFrom" binding:"required,datetime=2006-01-02"`
+	ValidTo      string `json:"validTo" binding:"omitempty,datetime=2006-01-02"`
+}
+
+type UpdateCoverageInput struct {
+	MemberNumber *string `json:"memberNumber,omitempty" binding:"omitempty,max=50"`
+	Priority     *string `json:"priority,omitempty" binding:"omitempty,oneof=primary secondary"`
+	ValidFrom    *string `json:"validFrom,omitempty" binding:"omitempty,datetime=2006-01-02"`
+	ValidTo      *string `json:"validTo,omitempty" binding:"omitempty,datetime=2006-01-02"`
+	IsActive     *bool   `json:"isActive,omitempty"`
+}
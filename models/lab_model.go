@@ -0,0 +1,119 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type LabTest struct {
+	gorm.Model
+	Code            string              `json:"code" gorm:"unique;not null"`
+	Name            string              `json:"name" gorm:"not null"`
+	SampleType      string              `json:"sampleType" gorm:"not null"`
+	ResultType      string              `json:"resultType" gorm:"type:lab_result_type;default:'numeric'"`
+	Unit            string              `json:"unit,omitempty"`
+	IsActive        bool                `json:"isActive" gorm:"default:true"`
+	ReferenceRanges []LabReferenceRange `json:"referenceRanges,omitempty" gorm:"foreignKey:LabTestID"`
+}
+
+// LabReferenceRange applies to patients of the given sex (empty for any) whose
+// age in days falls in [MinAgeDays, MaxAgeDays).
+type LabReferenceRange struct {
+	gorm.Model
+	LabTestID    uint     `json:"labTestId" gorm:"not null;index"`
+	Sex          string   `json:"sex,omitempty"`
+	MinAgeDays   int      `json:"minAgeDays" gorm:"default:0"`
+	MaxAgeDays   *int     `json:"maxAgeDays,omitempty"`
+	Low          *float64 `json:"low,omitempty"`
+	High         *float64 `json:"high,omitempty"`
+	CriticalLow  *float64 `json:"criticalLow,omitempty"`
+	CriticalHigh *float64 `json:"criticalHigh,omitempty"`
+}
+
+type LabOrder struct {
+	gorm.Model
+	PatientID         uint        `json:"patientId" gorm:"not null;index"`
+	AppointmentID     *uint       `json:"appointmentId,omitempty" gorm:"index"`
+	ClinicalNoteID    *uint       `json:"clinicalNoteId,omitempty" gorm:"index"`
+	OrderedBy         uint        `json:"orderedBy" gorm:"not null;index"`
+	Priority          string      `json:"priority" gorm:"type:lab_priority;default:'routine'"`
+	Status            string      `json:"status" gorm:"type:lab_order_status;default:'ordered'"`
+	ClinicalInfo      string      `json:"clinicalInfo,omitempty" gorm:"size:500"`
+	SampleCollectedAt *time.Time  `json:"sampleCollectedAt,omitempty"`
+	SampleCollectedBy *uint       `json:"sampleCollectedBy,omitempty"`
+	ResultedAt        *time.Time  `json:"resultedAt,omitempty"`
+	ResultedBy        *uint       `json:"resultedBy,omitempty"`
+	VerifiedAt        *time.Time  `json:"verifiedAt,omitempty"`
+	VerifiedBy        *uint       `json:"verifiedBy,omitempty"`
+	ReviewedAt        *time.Time  `json:"reviewedAt,omitempty"`
+	CancelReason      string      `json:"cancelReason,omitempty" gorm:"size:500"`
+	Results           []LabResult `json:"results,omitempty" gorm:"foreignKey:LabOrderID"`
+	// Alerts are the decision support alerts raised when results were entered.
+	Alerts []CdsAlert `json:"alerts,omitempty" gorm:"-"`
+}
+
+type LabResult struct {
+	gorm.Model
+	LabOrderID    uint     `json:"labOrderId" gorm:"not null;index"`
+	LabTestID     uint     `json:"labTestId" gorm:"not null"`
+	LabTest       *LabTest `json:"labTest,omitempty"`
+	NumericValue  *float64 `json:"numericValue,omitempty"`
+	TextValue     string   `json:"textValue,omitempty"`
+	Unit          string   `json:"unit,omitempty"`
+	ReferenceLow  *float64 `json:"referenceLow,omitempty"`
+	ReferenceHigh *float64 `json:"referenceHigh,omitempty"`
+	Flag          string   `json:"flag,omitempty"`
+	Comment       string   `json:"comment,omitempty" gorm:"size:500"`
+}
+
+type LabReferenceRangeInput struct {
+	Sex          string   `json:"sex" binding:"omitempty,oneof=male female"`
+	MinAgeDays   int      `json:"minAgeDays" binding:"min=0"`
+	MaxAgeDays   *int     `json:"maxAgeDays" binding:"omitempty,gtfield=MinAgeDays"`
+	Low          *float64 `json:"low"`
+	High         *float64 `json:"high"`
+	CriticalLow  *float64 `json:"criticalLow"`
+	CriticalHigh *float64 `json:"criticalHigh"`
+}
+
+type CreateLabTestInput struct {
+	Code            string                   `json:"code" binding:"required,max=20"`
+	Name            string                   `json:"name" binding:"required,max=100"`
+	SampleType      string                   `json:"sampleType" binding:"required,max=50"`
+	ResultType      string                   `json:"resultType" binding:"omitempty,oneof=numeric text"`
+	Unit            string                   `json:"unit" binding:"omitempty,max=20"`
+	ReferenceRanges []LabReferenceRangeInput `json:"referenceRanges" binding:"omitempty,dive"`
+}
+
+type UpdateLabTestInput struct {
+	Name            *string                   `json:"name,omitempty" binding:"omitempty,max=100"`
+	SampleType      *string                   `json:"sampleType,omitempty" binding:"omitempty,max=50"`
+	Unit            *string                   `json:"unit,omitempty" binding:"omitempty,max=20"`
+	IsActive        *bool                     `json:"isActive,omitempty"`
+	ReferenceRanges *[]LabReferenceRangeInput `json:"referenceRanges,omitempty" binding:"omitempty,dive"`
+}
+
+type CreateLabOrderInput struct {
+	AppointmentID  *uint  `json:"appointmentId" binding:"required_without=ClinicalNoteID"`
+	ClinicalNoteID *uint  `json:"clinicalNoteId" binding:"required_without=AppointmentID"`
+	LabTestIDs     []uint `json:"labTestIds" binding:"required,min=1,dive,required"`
+	Priority       string `json:"priority" binding:"omitempty,oneof=routine urgent stat"`
+	ClinicalInfo   string `json:"clinicalInfo" binding:"omitempty,max=500"`
+}
+
+type LabResultEntryInput struct {
+	LabTestID    uint     `json:"labTestId" binding:"required"`
+	NumericValue *float64 `json:"numericValue"`
+	TextValue    string   `json:"textValue" binding:"omitempty,max=500"`
+	Comment      string   `json:"comment" binding:"omitempty,max=500"`
+}
+
+type EnterLabResultsInput struct {
+	Results             []LabResultEntryInput `json:"results" binding:"required,min=1,dive"`
+	AlertOverrideReason string                `json:"alertOverrideReason" binding:"omitempty,max=500"`
+}
+
+type CancelLabOrderInput struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import "gorm.io/gorm"
+
+type Medication struct {
+	gorm.Model
+	Name        string `json:"name" gorm:"not null;uniqueIndex:idx_medication_name_form_strength"`
+	GenericName string `json:"genericName,omitempty"`
+	DrugClass   string `json:"drugClass,omitempty"`
+	Form        string `json:"form" gorm:"not null;uniqueIndex:idx_medication_name_form_strength"`
+	Strength    string `json:"strength,omitempty" gorm:"uniqueIndex:idx_medication_name_form_strength"`
+	IsActive    bool   `json:"isActive" gorm:"default:true"`
+
+	// ReorderLevel raises a low-stock alert once usable stock falls to it.
+	// Zero turns the alert off.
+	ReorderLevel int `json:"reorderLevel" gorm:"default:0"`
+}
+
+type CreateMedicationInput struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	GenericName string `json:"genericName" binding:"omitempty,max=100"`
+	DrugClass   string `json:"drugClass" binding:"omitempty,max=100"`
+	Form        string `json:"form" binding:"required,max=50"`
+	Strength    string `json:"strength" binding:"omitempty,max=50"`
+
+	ReorderLevel int `json:"reorderLevel" binding:"omitempty,min=0"`
+}
+
+type UpdateMedicationInput struct {
+	Name        *string `json:"name,omitempty" binding:"omitempty,max=100"`
+	GenericName *string `json:"genericName,omitempty" binding:"omitempty,max=100"`
+	DrugClass   *string `json:"drugClass,omitempty" binding:"omitempty,max=100"`
+	Form        *string `json:"form,omitempty" binding:"omitempty,max=50"`
+	Strength    *string `json:"strength,omitempty" binding:"omitempty,max=50"`
+	IsActive    *bool   `json:"isActive,omitempty"`
+
+	ReorderLevel *int `json:"reorderLevel,omitempty" binding:"omitempty,min=0"`
+}
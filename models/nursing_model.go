@@ -0,0 +1,157 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// VitalSign is one set of observations. AdmissionID is filled in when the
+// patient was admitted at the time they were taken.
+type VitalSign struct {
+	gorm.Model
+	PatientID        uint      `json:"patientId" gorm:"not null;index"`
+	AdmissionID      *uint     `json:"admissionId,omitempty" gorm:"index"`
+	RecordedAt       time.Time `json:"recordedAt" gorm:"not null"`
+	TemperatureC     *float64  `json:"temperatureC,omitempty" gorm:"type:numeric(4,1)"`
+	PulseRate        *int      `json:"pulseRate,omitempty"`
+	RespiratoryRate  *int      `json:"respiratoryRate,omitempty"`
+	SystolicBP       *int      `json:"systolicBp,omitempty"`
+	DiastolicBP      *int      `json:"diastolicBp,omitempty"`
+	OxygenSaturation *int      `json:"oxygenSaturation,omitempty"`
+	PainScore        *int      `json:"painScore,omitempty"`
+	Notes            string    `json:"notes,omitempty" gorm:"size:500"`
+	RecordedBy       uint      `json:"recordedBy" gorm:"not null"`
+}
+
+type NursingNote struct {
+	gorm.Model
+	PatientID   uint   `json:"patientId" gorm:"not null;index"`
+	AdmissionID *uint  `json:"admissionId,omitempty" gorm:"index"`
+	NurseID     uint   `json:"nurseId" gorm:"not null"`
+	Note        string `json:"note" gorm:"size:2000;not null"`
+}
+
+// MedicationAdministration records a dose given, held or refused against a
+// prescription. ScheduledAt is the dose time on the medication administration
+// record it answers, and is empty for as-needed doses. For held and refused
+// doses AdministeredAt is when the decision was charted and DoseAmount is 0.
+type MedicationAdministration struct {
+	gorm.Model
+	PrescriptionID uint          `json:"prescriptionId" gorm:"not null;index"`
+	Prescription   *Prescription `json:"prescription,omitempty"`
+	PatientID      uint          `json:"patientId" gorm:"not null;index"`
+	AdmissionID    *uint         `json:"admissionId,omitempty" gorm:"index"`
+	ScheduledAt    *time.Time    `json:"scheduledAt,omitempty"`
+	Status         string        `json:"status" gorm:"type:administration_status;default:'given'"`
+	Reason         string        `json:"reason,omitempty" gorm:"size:500"`
+	DoseAmount     float64       `json:"doseAmount" gorm:"not null"`
+	DoseUnit       string        `json:"doseUnit" gorm:"not null"`
+	Route          string        `json:"route" gorm:"type:medication_route;not null"`
+	AdministeredAt time.Time     `json:"administeredAt" gorm:"not null"`
+	AdministeredBy uint          `json:"administeredBy" gorm:"not null"`
+	Notes          string        `json:"notes,omitempty" gorm:"size:500"`
+}
+
+type RecordVitalsInput struct {
+	RecordedAt       *time.Time `json:"recordedAt"`
+	TemperatureC     *float64   `json:"temperatureC" binding:"omitempty,min=25,max=45"`
+	PulseRate        *int       `json:"pulseRate" binding:"omitempty,min=20,max=250"`
+	RespiratoryRate  *int       `json:"respiratoryRate" binding:"omitempty,min=4,max=80"`
+	SystolicBP       *int       `json:"systolicBp" binding:"omitempty,min=40,max=300,required_with=DiastolicBP"`
+	DiastolicBP      *int       `json:"diastolicBp" binding:"omitempty,min=20,max=200,required_with=SystolicBP"`
+	OxygenSaturation *int       `json:"oxygenSaturation" binding:"omitempty,min=50,max=100"`
+	PainScore        *int       `json:"painScore" binding:"omitempty,min=0,max=10"`
+	Notes            string     `json:"notes" binding:"omitempty,max=500"`
+}
+
+type CreateNursingNoteInput struct {
+	Note string `json:"note" binding:"required,max=2000"`
+}
+
+// AdministerMedicationInput defaults the status to given, the dose to the
+// prescribed amount and the time to now when they are left out. Held and
+// refused doses need the scheduled time and a reason.
+type AdministerMedicationInput struct {
+	PrescriptionID uint       `json:"prescriptionId" binding:"required"`
+	ScheduledAt    *time.Time `json:"scheduledAt"`
+	Status         string     `json:"status" binding:"omitempty,oneof=given held refused"`
+	Reason         string     `json:"reason" binding:"omitempty,max=500"`
+	DoseAmount     *float64   `json:"doseAmount" binding:"omitempty,gt=0"`
+	AdministeredAt *time.Time `json:"administeredAt"`
+	Notes          string     `json:"notes" binding:"omitempty,max=500"`
+}
+
+// NursingTask is an item of work derived from doctors' orders for an
+// admitted patient. Tasks are worked out on request rather than stored.
+type NursingTask struct {
+	Type           string    `json:"type"`
+	AdmissionID    uint      `json:"admissionId"`
+	PatientID      uint      `json:"patientId"`
+	PatientName    string    `json:"patientName"`
+	BedLabel       string    `json:"bedLabel"`
+	Description    string    `json:"description"`
+	PrescriptionID *uint     `json:"prescriptionId,omitempty"`
+	LabOrderID     *uint     `json:"labOrderId,omitempty"`
+	DueAt          time.Time `json:"dueAt"`
+	Overdue        bool      `json:"overdue"`
+}
+
+type NursingTaskList struct {
+	WardID   uint          `json:"wardId"`
+	WardName string        `json:"wardName"`
+	Tasks    []NursingTask `json:"tasks"`
+}
+
+type MarQuery struct {
+	Date string `form:"date" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// MarDose is one scheduled dose. Status is the charted status, or upcoming,
+// due or overdue while nothing has been charted.
+type MarDose struct {
+	ScheduledAt    time.Time                 `json:"scheduledAt"`
+	Status         string                    `json:"status"`
+	Administration *MedicationAdministration `json:"administration,omitempty"`
+}
+
+// MarEntry is one prescription's row on the medication administration
+// record. Unscheduled holds as-needed and other doses not tied to a time.
+type MarEntry struct {
+	Prescription Prescription               `json:"prescription"`
+	Doses        []MarDose                  `json:"doses"`
+	Unscheduled  []MedicationAdministration `json:"unscheduled"`
+}
+
+type MedicationAdministrationRecord struct {
+	AdmissionID uint       `json:"admissionId"`
+	PatientID   uint       `json:"patientId"`
+	Date        string     `json:"date"`
+	Entries     []MarEntry `json:"entries"`
+}
+
+type OverdueDose struct {
+	PrescriptionID uint      `json:"prescriptionId"`
+	Description    string    `json:"description"`
+	ScheduledAt    time.Time `json:"scheduledAt"`
+	MinutesOverdue int       `json:"minutesOverdue"`
+}
+
+type PatientDoseSummary struct {
+	AdmissionID  uint          `json:"admissionId"`
+	PatientID    uint          `json:"patientId"`
+	PatientName  string        `json:"patientName"`
+	BedLabel     string        `json:"bedLabel"`
+	DueCount     int           `json:"dueCount"`
+	OverdueCount int           `json:"overdueCount"`
+	OverdueDoses []OverdueDose `json:"overdueDoses"`
+}
+
+// WardMedicationDashboard flags, for each admitted patient, doses that are due
+// or more than OverdueWindowMinutes late without being charted.
+type WardMedicationDashboard struct {
+	WardID               uint                 `json:"wardId"`
+	WardName             string               `json:"wardName"`
+	OverdueWindowMinutes int                  `json:"overdueWindowMinutes"`
+	Patients             []PatientDoseSummary `json:"patients"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// PatientAlias keeps a merged-away registration number resolving to the
+// surviving patient record.
+type PatientAlias struct {
+	ID                 uint      `json:"id" gorm:"primaryKey"`
+	CreatedAt          time.Time `json:"createdAt"`
+	RegistrationNumber string    `json:"registrationNumber" gorm:"unique;not null"`
+	PatientID          uint      `json:"patientId" gorm:"not null;index"`
+	MergedPatientID    uint      `json:"mergedPatientId" gorm:"not null"`
+	MergedBy           uint      `json:"mergedBy"`
+	Reason             string    `json:"reason" gorm:"size:500"`
+}
+
+type MergePatientsInput struct {
+	SurvivorID  uint   `json:"survivorId" binding:"required"`
+	DuplicateID uint   `json:"duplicateId" binding:"required,nefield=SurvivorID"`
+	Reason      string `json:"reason" binding:"required,max=500"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import "gorm.io/gorm"
+
+type PatientContact struct {
+	gorm.Model
+	PatientID    uint   `json:"patientId" gorm:"not null;index"`
+	ContactType  string `json:"contactType" gorm:"type:contact_type;not null"`
+	Relationship string `json:"relationship" gorm:"type:contact_relationship;not null"`
+	Priority     int    `json:"priority" gorm:"not null;default:1"`
+	FullName     string `json:"fullName" gorm:"not null"`
+	PhoneNumber  string `json:"phoneNumber" gorm:"not null"`
+	Email        string `json:"email,omitempty"`
+	Address      string `json:"address,omitempty"`
+	CreatedBy    uint   `json:"createdBy"`
+	UpdatedBy    uint   `json:"updatedBy"`
+}
+
+type PatientContactInput struct {
+	ContactType  string `json:"contactType" binding:"required,oneof=next_of_kin guardian emergency"`
+	Relationship string `json:"relationship" binding:"required,oneof=parent spouse partner child sibling grandparent relative legal_guardian friend other"`
+	Priority     int    `json:"priority" binding:"omitempty,min=1,max=10"`
+	FullName     string `json:"fullName" binding:"required,min=2,max=100"`
+	PhoneNumber  string `json:"phoneNumber" binding:"required,e164"`
+	Email        string `json:"email" binding:"omitempty,email,max=100"`
+	Address      string `json:"address" binding:"omitempty,max=200"`
+}
+
+type UpdatePatientContactInput struct {
+	ContactType  *string `json:"contactType,omitempty" binding:"omitempty,oneof=next_of_kin guardian emergency"`
+	Relationship *string `json:"relationship,omitempty" binding:"omitempty,oneof=parent spouse partner child sibling grandparent relative legal_guardian friend other"`
+	Priority     *int    `json:"priority,omitempty" binding:"omitempty,min=1,max=10"`
+	FullName     *string `json:"fullName,omitempty" binding:"omitempty,min=2,max=100"`
+	PhoneNumber  *string `json:"phoneNumber,omitempty" binding:"omitempty,e164"`
+	Email        *string `json:"email,omitempty" binding:"omitempty,email,max=100"`
+	Address      *string `json:"address,omitempty" binding:"omitempty,max=200"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import "gorm.io/gorm"
+
+type PatientDocument struct {
+	gorm.Model
+	PatientID   uint   `json:"patientId" gorm:"not null;index"`
+	Category    string `json:"category" gorm:"type:document_category;not null"`
+	Title       string `json:"title" gorm:"not null"`
+	Description string `json:"description,omitempty"`
+	FileName    string `json:"fileName" gorm:"not null"`
+	// ContentType is sniffed from the file content, not taken from the client.
+	ContentType    string `json:"contentType" gorm:"not null"`
+	SizeBytes      int64  `json:"sizeBytes" gorm:"not null"`
+	ChecksumSHA256 string `json:"checksumSha256" gorm:"size:64;not null;index"`
+	StorageBackend string `json:"-" gorm:"not null"`
+	StorageKey     string `json:"-" gorm:"not null;uniqueIndex"`
+	UploadedBy     uint   `json:"uploadedBy"`
+}
+
+// UploadDocumentInput carries the form fields sent alongside the file.
+type UploadDocumentInput struct {
+	Category    string `form:"category" binding:"required,oneof=referral_letter lab_result imaging id_card insurance_card consent_form other"`
+	Title       string `form:"title" binding:"required,max=150"`
+	Description string `form:"description" binding:"omitempty,max=1000"`
+}
+
+type DocumentQuery struct {
+	Category string `form:"category" binding:"omitempty,oneof=referral_letter lab_result imaging id_card insurance_card consent_form other"`
+}
@@ -20,6 +20,10 @@ type Patient struct {
 	Genotype           string    `json:"genotype,omitempty" gorm:"type:genotype_enum;default:'unknown'"`
 	CreatedBy          uint      `json:"createdBy"`
 	UpdatedBy          uint      `json:"updatedBy"`
+
+	Contacts []PatientContact `json:"contacts,omitempty"`
+	// Problems holds the active problem list when patient details are fetched.
+	Problems []PatientProblem `json:"problems,omitempty"`
 }
 
 type CreatePatientInput struct {
@@ -30,6 +34,13 @@ type CreatePatientInput struct {
 	PhoneNumber string `json:"phoneNumber" binding:"required,e164"`
 	Email       string `json:"email" binding:"omitempty,email,max=100"`
 	Address     string `json:"address" binding:"omitempty,max=200"`
+
+	// Contacts must include a guardian when the patient is under 18.
+	Contacts []PatientContactInput `json:"contacts" binding:"omitempty,dive"`
+
+	// ConfirmNotDuplicate registers the patient even when likely duplicates
+	// were found, after reception has reviewed them.
+	ConfirmNotDuplicate bool `json:"confirmNotDuplicate"`
 }
 
 type UpdatePatientInput struct {
@@ -43,3 +54,16 @@ type UpdatePatientInput struct {
 	BloodGroup  *string `json:"bloodGroup,omitempty" binding:"omitempty,oneof=A+ A- B+ B- AB+ AB- O+ O- unknown"`
 	Genotype    *string `json:"genotype,omitempty" binding:"omitempty,oneof=AA AS AC SS SC CC unknown"`
 }
+
+// PatientSearchQuery combines fuzzy name matching with phone, date of birth,
+// email and registration number filters. Sort defaults to relevance when a
+// name is given and to newest first otherwise.
+type PatientSearchQuery struct {
+	PageQuery
+	Name               string `form:"name" binding:"omitempty,max=100"`
+	Phone              string `form:"phone" binding:"omitempty,max=20"`
+	DateOfBirth        string `form:"dateOfBirth" binding:"omitempty,datetime=2006-01-02"`
+	Email              string `form:"email" binding:"omitempty,max=100"`
+	RegistrationNumber string `form:"registrationNumber" binding:"omitempty,max=50"`
+	Sort               string `form:"sort" binding:"omitempty,oneof=relevance name -name dateOfBirth -dateOfBirth createdAt -createdAt"`
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PatientProblem is an entry on a patient's longitudinal problem list, such
+// as a chronic condition that should be visible at every visit.
+type PatientProblem struct {
+	gorm.Model
+	PatientID       uint           `json:"patientId" gorm:"not null;index"`
+	Title           string         `json:"title" gorm:"not null"`
+	DiagnosisCodeID *uint          `json:"diagnosisCodeId,omitempty"`
+	DiagnosisCode   *DiagnosisCode `json:"diagnosisCode,omitempty"`
+	Status          string         `json:"status" gorm:"type:problem_status;not null;default:'active'"`
+	OnsetDate       *time.Time     `json:"onsetDate,omitempty" gorm:"type:date"`
+	ResolvedDate    *time.Time     `json:"resolvedDate,omitempty" gorm:"type:date"`
+	Notes           string         `json:"notes,omitempty" gorm:"type:text"`
+	// SourceNoteID is the clinical note the problem was promoted from, if any.
+	SourceNoteID *uint `json:"sourceNoteId,omitempty"`
+	RecordedBy   uint  `json:"recordedBy"`
+	UpdatedBy    uint  `json:"updatedBy"`
+}
+
+type CreateProblemInput struct {
+	Title     string `json:"title" binding:"required,max=200"`
+	Code      string `json:"code" binding:"omitempty,max=10"`
+	Status    string `json:"status" binding:"omitempty,oneof=active inactive resolved"`
+	OnsetDate string `json:"onsetDate" binding:"omitempty,datetime=2006-01-02"`
+	Notes     string `json:"notes" binding:"omitempty,max=1000"`
+}
+
+type UpdateProblemInput struct {
+	Title        *string `json:"title,omitempty" binding:"omitempty,max=200"`
+	Status       *string `json:"status,omitempty" binding:"omitempty,oneof=active inactive resolved"`
+	OnsetDate    *string `json:"onsetDate,omitempty" binding:"omitempty,datetime=2006-01-02"`
+	ResolvedDate *string `json:"resolvedDate,omitempty" binding:"omitempty,datetime=2006-01-02"`
+	Notes        *string `json:"notes,omitempty" binding:"omitempty,max=1000"`
+}
+
+// PromoteDiagnosisInput copies a coded diagnosis from a clinical note onto
+// the problem list. OnsetDate defaults to the date of the note.
+type PromoteDiagnosisInput struct {
+	ClinicalNoteID uint   `json:"clinicalNoteId" binding:"required"`
+	Code           string `json:"code" binding:"required,max=10"`
+	OnsetDate      string `json:"onsetDate" binding:"omitempty,datetime=2006-01-02"`
+	Notes          string `json:"notes" binding:"omitempty,max=1000"`
+}
@@ -0,0 +1,107 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Payment is money received against an issued invoice. Cash is always taken
+// in a cashier shift; other methods are linked to the shift open at the time,
+// if any.
+type Payment struct {
+	gorm.Model
+	ReceiptNumber  string    `json:"receiptNumber" gorm:"unique;not null;size:30"`
+	InvoiceID      uint      `json:"invoiceId" gorm:"not null;index"`
+	PatientID      uint      `json:"patientId" gorm:"not null;index"`
+	Method         string    `json:"method" gorm:"type:payment_method;not null"`
+	Amount         float64   `json:"amount" gorm:"type:numeric(12,2);not null"`
+	AmountRefunded float64   `json:"amountRefunded" gorm:"type:numeric(12,2);default:0"`
+	Reference      string    `json:"reference,omitempty" gorm:"size:100"`
+	Notes          string    `json:"notes,omitempty" gorm:"size:500"`
+	ShiftID        *uint     `json:"shiftId,omitempty" gorm:"index"`
+	ReceivedBy     uint      `json:"receivedBy" gorm:"not null"`
+	ReceivedAt     time.Time `json:"receivedAt" gorm:"not null"`
+	Refunds        []Refund  `json:"refunds,omitempty"`
+}
+
+// Refund returns part or all of a payment by the method it was made with.
+type Refund struct {
+	gorm.Model
+	PaymentID  uint      `json:"paymentId" gorm:"not null;index"`
+	InvoiceID  uint      `json:"invoiceId" gorm:"not null;index"`
+	Method     string    `json:"method" gorm:"type:payment_method;not null"`
+	Amount     float64   `json:"amount" gorm:"type:numeric(12,2);not null"`
+	Reason     string    `json:"reason" gorm:"not null;size:500"`
+	ShiftID    *uint     `json:"shiftId,omitempty" gorm:"index"`
+	RefundedBy uint      `json:"refundedBy" gorm:"not null"`
+	RefundedAt time.Time `json:"refundedAt" gorm:"not null"`
+}
+
+// CashierShift is a staff member's spell at the till. Closing it compares
+// the cash the shift should hold with the cash counted.
+type CashierShift struct {
+	gorm.Model
+	StaffID      uint       `json:"staffId" gorm:"not null;index"`
+	Status       string     `json:"status" gorm:"type:cashier_shift_status;default:'open'"`
+	OpenedAt     time.Time  `json:"openedAt" gorm:"not null"`
+	OpeningFloat float64    `json:"openingFloat" gorm:"type:numeric(12,2);default:0"`
+	ClosedAt     *time.Time `json:"closedAt,omitempty"`
+	ExpectedCash *float64   `json:"expectedCash,omitempty" gorm:"type:numeric(12,2)"`
+	CountedCash  *float64   `json:"countedCash,omitempty" gorm:"type:numeric(12,2)"`
+	Variance     *float64   `json:"variance,omitempty" gorm:"type:numeric(12,2)"`
+	Notes        string     `json:"notes,omitempty" gorm:"size:500"`
+}
+
+type CreatePaymentInput struct {
+	InvoiceID uint    `json:"invoiceId" binding:"required"`
+	Method    string  `json:"method" binding:"required,oneof=cash card transfer insurance"`
+	Amount    float64 `json:"amount" binding:"required,gt=0"`
+	Reference string  `json:"reference" binding:"omitempty,max=100"`
+	Notes     string  `json:"notes" binding:"omitempty,max=500"`
+}
+
+type RefundPaymentInput struct {
+	Amount float64 `json:"amount" binding:"required,gt=0"`
+	Reason string  `json:"reason" binding:"required,max=500"`
+}
+
+type PaymentQuery struct {
+	PageQuery
+	InvoiceID uint   `form:"invoiceId"`
+	PatientID uint   `form:"patientId"`
+	ShiftID   uint   `form:"shiftId"`
+	Method    string `form:"method" binding:"omitempty,oneof=cash card transfer insurance"`
+}
+
+type OpenCashierShiftInput struct {
+	OpeningFloat float64 `json:"openingFloat" binding:"omitempty,min=0"`
+}
+
+type CloseCashierShiftInput struct {
+	CountedCash *float64 `json:"countedCash" binding:"required,min=0"`
+	Notes       string   `json:"notes" binding:"omitempty,max=500"`
+}
+
+type CashierShiftQuery struct {
+	PageQuery
+	StaffID uint   `form:"staffId"`
+	Status  string `form:"status" binding:"omitempty,oneof=open closed"`
+}
+
+// PaymentMethodTotal sums a shift's takings for one payment method.
+type PaymentMethodTotal struct {
+	Method   string  `json:"method"`
+	Payments int     `json:"payments"`
+	Received float64 `json:"received"`
+	Refunded float64 `json:"refunded"`
+	Net      float64 `json:"net"`
+}
+
+// CashierShiftReport is a shift with its takings. ExpectedCash is the
+// opening float plus cash received less cash refunded.
+type CashierShiftReport struct {
+	Shift        CashierShift         `json:"shift"`
+	Totals       []PaymentMethodTotal `json:"totals"`
+	ExpectedCash float64              `json:"expectedCash"`
+}
@@ -0,0 +1,141 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// StockBatch is the stock of one medication received under one supplier
+// batch number. Batches are drawn down first-expiry-first-out and stop being
+// dispensable after their expiry date.
+type StockBatch struct {
+	gorm.Model
+	MedicationID   uint        `json:"medicationId" gorm:"not null;uniqueIndex:idx_stock_batch_medication_number"`
+	Medication     *Medication `json:"medication,omitempty"`
+	BatchNumber    string      `json:"batchNumber" gorm:"size:50;not null;uniqueIndex:idx_stock_batch_medication_number"`
+	ExpiryDate     time.Time   `json:"expiryDate" gorm:"type:date;not null;index"`
+	QuantityOnHand int         `json:"quantityOnHand" gorm:"not null;default:0"`
+}
+
+type GoodsReceipt struct {
+	gorm.Model
+	Supplier      string             `json:"supplier" gorm:"size:100;not null"`
+	InvoiceNumber string             `json:"invoiceNumber,omitempty" gorm:"size:50"`
+	ReceivedAt    time.Time          `json:"receivedAt" gorm:"not null"`
+	ReceivedBy    uint               `json:"receivedBy" gorm:"not null"`
+	Notes         string             `json:"notes,omitempty" gorm:"size:500"`
+	Lines         []GoodsReceiptLine `json:"lines"`
+}
+
+type GoodsReceiptLine struct {
+	gorm.Model
+	GoodsReceiptID uint        `json:"goodsReceiptId" gorm:"not null;index"`
+	MedicationID   uint        `json:"medicationId" gorm:"not null"`
+	Medication     *Medication `json:"medication,omitempty"`
+	StockBatchID   uint        `json:"stockBatchId" gorm:"not null"`
+	BatchNumber    string      `json:"batchNumber" gorm:"size:50;not null"`
+	ExpiryDate     time.Time   `json:"expiryDate" gorm:"type:date;not null"`
+	Quantity       int         `json:"quantity" gorm:"not null"`
+	UnitCost       *float64    `json:"unitCost,omitempty" gorm:"type:numeric(12,2)"`
+}
+
+// Dispensation is one issue of stock against a prescription. A prescription
+// may be dispensed in several parts until its quantity is used up.
+type Dispensation struct {
+	gorm.Model
+	PrescriptionID uint               `json:"prescriptionId" gorm:"not null;index"`
+	PatientID      uint               `json:"patientId" gorm:"not null;index"`
+	MedicationID   uint               `json:"medicationId" gorm:"not null"`
+	Quantity       int                `json:"quantity" gorm:"not null"`
+	DispensedAt    time.Time          `json:"dispensedAt" gorm:"not null"`
+	DispensedBy    uint               `json:"dispensedBy" gorm:"not null"`
+	Notes          string             `json:"notes,omitempty" gorm:"size:500"`
+	Items          []DispensationItem `json:"items"`
+}
+
+// DispensationItem is the part of a dispensation taken from one batch.
+type DispensationItem struct {
+	gorm.Model
+	DispensationID uint      `json:"dispensationId" gorm:"not null;index"`
+	StockBatchID   uint      `json:"stockBatchId" gorm:"not null"`
+	BatchNumber    string    `json:"batchNumber" gorm:"size:50;not null"`
+	ExpiryDate     time.Time `json:"expiryDate" gorm:"type:date;not null"`
+	Quantity       int       `json:"quantity" gorm:"not null"`
+}
+
+// StockMovement is a line of the stock ledger. Quantity is positive for
+// stock coming in and negative for stock going out.
+type StockMovement struct {
+	gorm.Model
+	MedicationID   uint      `json:"medicationId" gorm:"not null;index"`
+	StockBatchID   uint      `json:"stockBatchId" gorm:"not null;index"`
+	Type           string    `json:"type" gorm:"type:stock_movement_type;not null"`
+	Quantity       int       `json:"quantity" gorm:"not null"`
+	GoodsReceiptID *uint     `json:"goodsReceiptId,omitempty"`
+	DispensationID *uint     `json:"dispensationId,omitempty"`
+	PrescriptionID *uint     `json:"prescriptionId,omitempty"`
+	OccurredAt     time.Time `json:"occurredAt" gorm:"not null"`
+	StaffID        uint      `json:"staffId" gorm:"not null"`
+}
+
+type GoodsReceiptLineInput struct {
+	MedicationID uint     `json:"medicationId" binding:"required"`
+	BatchNumber  string   `json:"batchNumber" binding:"required,max=50"`
+	ExpiryDate   string   `json:"expiryDate" binding:"required,datetime=2006-01-02"`
+	Quantity     int      `json:"quantity" binding:"required,min=1"`
+	UnitCost     *float64 `json:"unitCost" binding:"omitempty,min=0"`
+}
+
+type CreateGoodsReceiptInput struct {
+	Supplier      string                  `json:"supplier" binding:"required,max=100"`
+	InvoiceNumber string                  `json:"invoiceNumber" binding:"omitempty,max=50"`
+	Notes         string                  `json:"notes" binding:"omitempty,max=500"`
+	Lines         []GoodsReceiptLineInput `json:"lines" binding:"required,min=1,dive"`
+}
+
+// DispensePrescriptionInput dispenses whatever is left on the prescription
+// when Quantity is left out.
+type DispensePrescriptionInput struct {
+	Quantity *int   `json:"quantity" binding:"omitempty,min=1"`
+	Notes    string `json:"notes" binding:"omitempty,max=500"`
+}
+
+// StockLevel totals a medication's stock. OnHand counts only batches that
+// have not expired; expired stock awaiting disposal is in Expired.
+type StockLevel struct {
+	MedicationID uint       `json:"medicationId"`
+	Name         string     `json:"name"`
+	Form         string     `json:"form"`
+	Strength     string     `json:"strength,omitempty"`
+	ReorderLevel int        `json:"reorderLevel"`
+	OnHand       int        `json:"onHand"`
+	Expired      int        `json:"expired"`
+	NextExpiry   *time.Time `json:"nextExpiry,omitempty"`
+}
+
+type ExpiryAlert struct {
+	Batch        StockBatch `json:"batch"`
+	DaysToExpiry int        `json:"daysToExpiry"`
+	Expired      bool       `json:"expired"`
+}
+
+type ExpiryAlertQuery struct {
+	WithinDays int `form:"withinDays" binding:"omitempty,min=1,max=730"`
+}
+
+// StockLedgerEntry is a stock movement with the medication's running
+// balance after it.
+type StockLedgerEntry struct {
+	ID             uint      `json:"id"`
+	StockBatchID   uint      `json:"stockBatchId"`
+	BatchNumber    string    `json:"batchNumber"`
+	Type           string    `json:"type"`
+	Quantity       int       `json:"quantity"`
+	Balance        int       `json:"balance"`
+	GoodsReceiptID *uint     `json:"goodsReceiptId,omitempty"`
+	DispensationID *uint     `json:"dispensationId,omitempty"`
+	PrescriptionID *uint     `json:"prescriptionId,omitempty"`
+	OccurredAt     time.Time `json:"occurredAt"`
+	StaffID        uint      `json:"staffId"`
+}
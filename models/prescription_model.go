@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Prescription struct {
+	gorm.Model
+	ClinicalNoteID        uint        `json:"clinicalNoteId" gorm:"not null;index"`
+	PatientID             uint        `json:"patientId" gorm:"not null;index"`
+	DoctorID              uint        `json:"doctorId" gorm:"not null"`
+	MedicationID          uint        `json:"medicationId" gorm:"not null"`
+	Medication            *Medication `json:"medication,omitempty"`
+	DoseAmount            float64     `json:"doseAmount" gorm:"not null"`
+	DoseUnit              string      `json:"doseUnit" gorm:"not null"`
+	Route                 string      `json:"route" gorm:"type:medication_route;not null"`
+	Frequency             string      `json:"frequency" gorm:"type:dose_frequency;not null"`
+	DurationDays          int         `json:"durationDays" gorm:"not null"`
+	Quantity              int         `json:"quantity" gorm:"not null"`
+	QuantityDispensed     int         `json:"quantityDispensed" gorm:"not null;default:0"`
+	Instructions          string      `json:"instructions,omitempty" gorm:"size:500"`
+	AllergyOverrideReason string      `json:"allergyOverrideReason,omitempty" gorm:"size:500"`
+	Status                string      `json:"status" gorm:"type:prescription_status;default:'active'"`
+	StatusReason          string      `json:"statusReason,omitempty" gorm:"size:500"`
+	StatusChangedBy       *uint       `json:"statusChangedBy,omitempty"`
+	StatusChangedAt       *time.Time  `json:"statusChangedAt,omitempty"`
+	// Alerts are the decision support alerts raised when it was prescribed.
+	Alerts []CdsAlert `json:"alerts,omitempty" gorm:"-"`
+}
+
+type CreatePrescriptionInput struct {
+	ClinicalNoteID uint    `json:"clinicalNoteId" binding:"required"`
+	MedicationID   uint    `json:"medicationId" binding:"required"`
+	DoseAmount     float64 `json:"doseAmount" binding:"required,gt=0"`
+	DoseUnit       string  `json:"doseUnit" binding:"required,max=20"`
+	Route          string  `json:"route" binding:"required,oneof=oral iv im sc topical inhaled rectal sublingual ophthalmic otic nasal"`
+	Frequency      string  `json:"frequency" binding:"required,oneof=OD BD TDS QDS Q4H Q6H Q8H Q12H NOCTE STAT PRN"`
+	DurationDays   int     `json:"durationDays" binding:"required,min=1,max=365"`
+	Quantity       int     `json:"quantity" binding:"required,min=1"`
+	Instructions   string  `json:"instructions" binding:"omitempty,max=500"`
+
+	AllergyOverrideReason string `json:"allergyOverrideReason" binding:"omitempty,max=500"`
+	AlertOverrideReason   string `json:"alertOverrideReason" binding:"omitempty,max=500"`
+}
+
+// UpdatePrescriptionStatusInput only discontinues a prescription; it is marked
+// dispensed by the pharmacy when stock is issued against it.
+type UpdatePrescriptionStatusInput struct {
+	Status string `json:"status" binding:"required,oneof=discontinued"`
+	Reason string `json:"reason" binding:"omitempty,max=500"`
+}
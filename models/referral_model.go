@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Referral struct {
+	gorm.Model
+	PatientID           uint         `json:"patientId" gorm:"not null;index"`
+	SourceAppointmentID *uint        `json:"sourceAppointmentId,omitempty"`
+	SourceNoteID        *uint        `json:"sourceNoteId,omitempty"`
+	ReferringDoctorID   uint         `json:"referringDoctorId" gorm:"not null"`
+	TargetDepartment    string       `json:"targetDepartment" gorm:"type:department_type;not null;index"`
+	TargetDoctorID      *uint        `json:"targetDoctorId,omitempty"`
+	Urgency             string       `json:"urgency" gorm:"type:referral_urgency;default:'routine'"`
+	Reason              string       `json:"reason" gorm:"size:1000;not null"`
+	Status              string       `json:"status" gorm:"type:referral_status;default:'pending'"`
+	AppointmentID       *uint        `json:"appointmentId,omitempty"`
+	Appointment         *Appointment `json:"appointment,omitempty" gorm:"foreignKey:AppointmentID"`
+	RespondedBy         *uint        `json:"respondedBy,omitempty"`
+	RespondedAt         *time.Time   `json:"respondedAt,omitempty"`
+	DeclineReason       string       `json:"declineReason,omitempty" gorm:"size:500"`
+	CompletedAt         *time.Time   `json:"completedAt,omitempty"`
+}
+
+type CreateReferralInput struct {
+	SourceAppointmentID *uint  `json:"sourceAppointmentId" binding:"required_without=SourceNoteID"`
+	SourceNoteID        *uint  `json:"sourceNoteId" binding:"required_without=SourceAppointmentID"`
+	TargetDepartment    string `json:"targetDepartment" binding:"required,oneof=general cardiology pediatrics"`
+	TargetDoctorID      *uint  `json:"targetDoctorId"`
+	Urgency             string `json:"urgency" binding:"omitempty,oneof=routine urgent emergency"`
+	Reason              string `json:"reason" binding:"required,max=1000"`
+}
+
+type AcceptReferralInput struct {
+	DoctorID *uint `json:"doctorId"`
+	Duration int   `json:"duration" binding:"omitempty,min=15,max=120"`
+}
+
+type ScheduleReferralInput struct {
+	ScheduledAt time.Time `json:"scheduledAt" binding:"required"`
+	DoctorID    *uint     `json:"doctorId"`
+}
+
+type DeclineReferralInput struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
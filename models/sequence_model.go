@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// Sequence is a named counter. Each name is incremented atomically, so values
+// are never handed out twice.
+type Sequence struct {
+	Name      string    `json:"name" gorm:"primaryKey;size:100"`
+	Value     int64     `json:"value" gorm:"not null;default:0"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
@@ -29,7 +29,7 @@ type CreateStaffInput struct {
 	PhoneNumber    string  `json:"phoneNumber" binding:"required"`
 	Email          string  `json:"email" binding:"required,email"`
 	Password       string  `json:"password" binding:"required,min=8"`
-	Role           string  `json:"role" binding:"required,oneof=admin doctor receptionist"`
+	Role           string  `json:"role" binding:"required,oneof=admin doctor receptionist nurse pharmacist cashier finance_manager"`
 	LicenseNumber  *string `json:"licenseNumber,omitempty" binding:"required_if=Role doctor"`
 	Specialization *string `json:"specialization,omitempty"`
 	Department     *string `json:"department,omitempty"`
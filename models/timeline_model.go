@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// TimelineEvent is one entry in a patient's history. It points at the record
+// it came from through EntityType and EntityID.
+type TimelineEvent struct {
+	Type       string    `json:"type"`
+	OccurredAt time.Time `json:"occurredAt"`
+	EntityType string    `json:"entityType"`
+	EntityID   uint      `json:"entityId"`
+	StaffID    *uint     `json:"staffId,omitempty"`
+	Summary    string    `json:"summary"`
+	Details    string    `json:"details,omitempty"`
+	// Changes lists the edited fields for events read from the audit log.
+	Changes map[string]FieldChange `json:"changes,omitempty" gorm:"-"`
+}
+
+// TimelineQuery filters a patient's timeline. Types is a comma-separated list
+// of event types; From and To are inclusive dates.
+type TimelineQuery struct {
+	PageQuery
+	Types string `form:"types" binding:"omitempty,max=200"`
+	From  string `form:"from" binding:"omitempty,datetime=2006-01-02"`
+	To    string `form:"to" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// TimelineFilter is the parsed form of TimelineQuery. To is exclusive.
+type TimelineFilter struct {
+	Types []string
+	From  *time.Time
+	To    *time.Time
+}
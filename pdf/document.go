@@ -0,0 +1,234 @@
+// Package pdf is a small PDF writer for printable reports. It lays out
+// wrapped text in the standard Helvetica fonts on A4 pages, which every PDF
+// reader provides, so no fonts are embedded.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	pageWidth    = 595.0 // A4 in points
+	pageHeight   = 842.0
+	margin       = 50.0
+	footerHeight = 20.0
+)
+
+// Font styles available to the writer.
+const (
+	Regular = iota
+	Bold
+)
+
+var fontNames = []string{"Helvetica", "Helvetica-Bold"}
+
+// Document collects pages of laid-out text. The zero value is not usable;
+// create documents with New.
+type Document struct {
+	title string
+	pages []*bytes.Buffer
+	y     float64
+}
+
+func New(title string) *Document {
+	d := &Document{title: title}
+	d.newPage()
+	return d
+}
+
+// Text writes a paragraph wrapped to the page width, starting a new page
+// when the current one is full. Indent shifts the paragraph right.
+func (d *Document) Text(text string, style int, size float64, indent float64) {
+	width := pageWidth - 2*margin - indent
+	lineHeight := size * 1.35
+	for _, line := range wrap(text, style, size, width) {
+		d.ensureSpace(lineHeight)
+		d.y -= lineHeight
+		d.showText(line, style, size, margin+indent, d.y+size*0.3)
+	}
+}
+
+// Bullet writes a paragraph with a bullet and a hanging indent.
+func (d *Document) Bullet(text string, size float64) {
+	lineHeight := size * 1.35
+	d.ensureSpace(lineHeight)
+	d.showText("-", Regular, size, margin+4, d.y-lineHeight+size*0.3)
+	d.Text(text, Regular, size, 16)
+}
+
+// Rule draws a horizontal line across the text area.
+func (d *Document) Rule() {
+	d.ensureSpace(10)
+	d.y -= 5
+	fmt.Fprintf(d.page(), "0.5 w %.2f %.2f m %.2f %.2f l S\n", margin, d.y, pageWidth-margin, d.y)
+	d.y -= 5
+}
+
+// Space moves down by the given number of points.
+func (d *Document) Space(points float64) {
+	if d.y-points < margin+footerHeight {
+		d.newPage()
+		return
+	}
+	d.y -= points
+}
+
+// WriteTo writes the finished document, numbering the pages in the footer.
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	var out bytes.Buffer
+	offsets := []int{}
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Objects 1-4 are the catalog, page tree, fonts and info; each page then
+	// takes a page object followed by its content stream.
+	const firstPageObject = 6
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPageObject+2*i)
+	}
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object(fontObject(fontNames[Regular]))
+	object(fontObject(fontNames[Bold]))
+	object(fmt.Sprintf("<< /Title (%s) /Producer (hms-go-backend) >>", escape(d.title)))
+
+	for i, page := range d.pages {
+		footer := fmt.Sprintf("Page %d of %d", i+1, len(d.pages))
+		x := pageWidth - margin - textWidth(footer, Regular, 8)
+		content := page.String() + textCommand(footer, Regular, 8, x, margin-footerHeight)
+
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, firstPageObject+2*i+1))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(content), content))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	n, err := w.Write(out.Bytes())
+	return int64(n), err
+}
+
+// Bytes returns the finished document.
+func (d *Document) Bytes() []byte {
+	var buf bytes.Buffer
+	d.WriteTo(&buf)
+	return buf.Bytes()
+}
+
+func (d *Document) page() *bytes.Buffer {
+	return d.pages[len(d.pages)-1]
+}
+
+func (d *Document) newPage() {
+	d.pages = append(d.pages, new(bytes.Buffer))
+	d.y = pageHeight - margin
+}
+
+func (d *Document) ensureSpace(height float64) {
+	if d.y-height < margin+footerHeight {
+		d.newPage()
+	}
+}
+
+func (d *Document) showText(text string, style int, size, x, y float64) {
+	d.page().WriteString(textCommand(text, style, size, x, y))
+}
+
+func textCommand(text string, style int, size, x, y float64) string {
+	return fmt.Sprintf("BT /F%d %.1f Tf %.2f %.2f Td (%s) Tj ET\n", style+1, size, x, y, escape(text))
+}
+
+func fontObject(name string) string {
+	return fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", name)
+}
+
+// escape encodes text as a PDF literal string. Characters outside Latin-1
+// cannot be shown by the standard fonts and are replaced with '?'.
+func escape(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\t':
+			b.WriteByte(' ')
+		case r < 0x20 || r > 0xff:
+			b.WriteByte('?')
+		default:
+			b.WriteByte(byte(r))
+		}
+	}
+	return b.String()
+}
+
+// wrap breaks text into lines no wider than width, splitting words that do
+// not fit on a line of their own.
+func wrap(text string, style int, size, width float64) []string {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return []string{""}
+	}
+
+	var lines []string
+	line := ""
+	for _, word := range words {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+		if textWidth(candidate, style, size) <= width {
+			line = candidate
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+		for textWidth(word, style, size) > width {
+			cut := fitPrefix(word, style, size, width)
+			lines = append(lines, word[:cut])
+			word = word[cut:]
+		}
+		line = word
+	}
+	return append(lines, line)
+}
+
+func fitPrefix(word string, style int, size, width float64) int {
+	cut := 0
+	for i := range word {
+		if i > 0 && textWidth(word[:i], style, size) > width {
+			break
+		}
+		cut = i
+	}
+	if cut == 0 {
+		_, size := utf8.DecodeRuneInString(word)
+		return size
+	}
+	return cut
+}
+
+func textWidth(text string, style int, size float64) float64 {
+	total := 0
+	for _, r := range text {
+		total += glyphWidth(r, style)
+	}
+	return float64(total) * size / 1000
+}
@@ -0,0 +1,52 @@
+package pdf
+
+import "strings"
+
+// FromMarkup lays out a report written in a small line-based markup:
+//
+//	# Title          large bold heading
+//	## Section       bold section heading
+//	- item           bulleted paragraph
+//	---              horizontal rule
+//	\text            body text, even if it starts like one of the above
+//	(blank line)     paragraph spacing
+//
+// Any other line is a paragraph of body text.
+func FromMarkup(title string, source string) *Document {
+	doc := New(title)
+	for _, line := range strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			doc.Space(6)
+		case strings.HasPrefix(trimmed, `\`):
+			doc.Text(trimmed[1:], Regular, 10, 0)
+		case trimmed == "---":
+			doc.Rule()
+		case strings.HasPrefix(trimmed, "## "):
+			doc.Space(6)
+			doc.Text(strings.TrimPrefix(trimmed, "## "), Bold, 12, 0)
+		case strings.HasPrefix(trimmed, "# "):
+			doc.Text(strings.TrimPrefix(trimmed, "# "), Bold, 16, 0)
+		case strings.HasPrefix(trimmed, "- "):
+			doc.Bullet(strings.TrimPrefix(trimmed, "- "), 10)
+		default:
+			doc.Text(trimmed, Regular, 10, 0)
+		}
+	}
+	return doc
+}
+
+// EscapeMarkup marks every line of text that FromMarkup would read as a
+// heading, bullet, rule or escape as body text, so free text entered by
+// users cannot change the layout it is placed in.
+func EscapeMarkup(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "-") || strings.HasPrefix(trimmed, `\`) {
+			lines[i] = `\` + trimmed
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,153 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"gorm.io/gorm"
+)
+
+type AdmissionRepository interface {
+	Admit(admission *models.Admission, assignedBy uint) error
+	FindByID(id uint) (*models.Admission, error)
+	FindActiveByPatientID(patientID uint) (*models.Admission, error)
+	FindAll(query models.AdmissionQuery) ([]models.Admission, int64, error)
+	Transfer(admission *models.Admission, bed *models.Bed, reason string, assignedBy uint) error
+	Discharge(admission *models.Admission) error
+}
+
+type admissionRepository struct {
+	db *gorm.DB
+}
+
+func NewAdmissionRepository(db *gorm.DB) AdmissionRepository {
+	return &admissionRepository{db: db}
+}
+
+// Admit claims the bed, creates the admission and opens its first bed
+// assignment in a single transaction.
+func (ar *admissionRepository) Admit(admission *models.Admission, assignedBy uint) error {
+	return ar.db.Transaction(func(tx *gorm.DB) error {
+		if err := claimBed(tx, admission.BedID); err != nil {
+			return err
+		}
+		if err := tx.Omit("Ward", "Bed", "BedAssignments").Create(admission).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.BedAssignment{
+			AdmissionID: admission.ID,
+			BedID:       admission.BedID,
+			AssignedAt:  admission.AdmittedAt,
+			AssignedBy:  assignedBy,
+			Reason:      "admission",
+		}).Error
+	})
+}
+
+func (ar *admissionRepository) FindByID(id uint) (*models.Admission, error) {
+	var admission models.Admission
+	err := ar.db.Preload("Ward").Preload("Bed").
+		Preload("BedAssignments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("assigned_at ASC")
+		}).
+		Preload("BedAssignments.Bed").
+		First(&admission, id).Error
+	return &admission, err
+}
+
+func (ar *admissionRepository) FindActiveByPatientID(patientID uint) (*models.Admission, error) {
+	var admission models.Admission
+	err := ar.db.Where("patient_id = ? AND status = ?", patientID, constants.AdmissionStatus.ADMITTED).
+		First(&admission).Error
+	return &admission, err
+}
+
+func (ar *admissionRepository) FindAll(query models.AdmissionQuery) ([]models.Admission, int64, error) {
+	var admissions []models.Admission
+	var total int64
+
+	db := ar.db.Model(&models.Admission{})
+	if query.Status != "" {
+		db = db.Where("status = ?", query.Status)
+	}
+	if query.WardID != 0 {
+		db = db.Where("ward_id = ?", query.WardID)
+	}
+	if query.PatientID != 0 {
+		db = db.Where("patient_id = ?", query.PatientID)
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := db.Preload("Ward").Preload("Bed").
+		Order("admitted_at DESC").
+		Offset(query.Offset()).Limit(query.PageSize).
+		Find(&admissions).Error
+	return admissions, total, err
+}
+
+// Transfer moves the patient to another bed, closing the current assignment
+// and sending the old bed for cleaning.
+func (ar *admissionRepository) Transfer(admission *models.Admission, bed *models.Bed, reason string, assignedBy uint) error {
+	return ar.db.Transaction(func(tx *gorm.DB) error {
+		if err := claimBed(tx, bed.ID); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if err := releaseBed(tx, admission, now); err != nil {
+			return err
+		}
+		if err := tx.Create(&models.BedAssignment{
+			AdmissionID: admission.ID,
+			BedID:       bed.ID,
+			AssignedAt:  now,
+			AssignedBy:  assignedBy,
+			Reason:      reason,
+		}).Error; err != nil {
+			return err
+		}
+
+		admission.WardID = bed.WardID
+		admission.BedID = bed.ID
+		return tx.Omit("Ward", "Bed", "BedAssignments").Save(admission).Error
+	})
+}
+
+func (ar *admissionRepository) Discharge(admission *models.Admission) error {
+	return ar.db.Transaction(func(tx *gorm.DB) error {
+		if err := releaseBed(tx, admission, *admission.DischargedAt); err != nil {
+			return err
+		}
+		return tx.Omit("Ward", "Bed", "BedAssignments").Save(admission).Error
+	})
+}
+
+// claimBed marks an available or reserved bed as occupied. The conditional
+// update keeps two admissions from taking the same bed.
+func claimBed(tx *gorm.DB, bedID uint) error {
+	result := tx.Model(&models.Bed{}).
+		Where("id = ? AND status IN ?", bedID, []string{constants.BedStatus.AVAILABLE, constants.BedStatus.RESERVED}).
+		Update("status", constants.BedStatus.OCCUPIED)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("bed is no longer available")
+	}
+	return nil
+}
+
+func releaseBed(tx *gorm.DB, admission *models.Admission, at time.Time) error {
+	if err := tx.Model(&models.BedAssignment{}).
+		Where("admission_id = ? AND released_at IS NULL", admission.ID).
+		Update("released_at", at).Error; err != nil {
+		return err
+	}
+	return tx.Model(&models.Bed{}).Where("id = ?", admission.BedID).
+		Update("status", constants.BedStatus.CLEANING).Error
+}
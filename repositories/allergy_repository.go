@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"gorm.io/gorm"
+)
+
+type AllergyRepository interface {
+	Create(allergy *models.PatientAllergy) error
+	FindByID(id uint) (*models.PatientAllergy, error)
+	FindByPatientID(patientID uint) ([]models.PatientAllergy, error)
+	Update(allergy *models.PatientAllergy) error
+}
+
+type allergyRepository struct {
+	db *gorm.DB
+}
+
+func NewAllergyRepository(db *gorm.DB) AllergyRepository {
+	return &allergyRepository{db: db}
+}
+
+func (ar *allergyRepository) Create(allergy *models.PatientAllergy) error {
+	return ar.db.Create(allergy).Error
+}
+
+func (ar *allergyRepository) FindByID(id uint) (*models.PatientAllergy, error) {
+	var allergy models.PatientAllergy
+	err := ar.db.First(&allergy, id).Error
+	return &allergy, err
+}
+
+func (ar *allergyRepository) FindByPatientID(patientID uint) ([]models.PatientAllergy, error) {
+	var allergies []models.PatientAllergy
+	err := ar.db.Where("patient_id = ?", patientID).Order("created_at").Find(&allergies).Error
+	return allergies, err
+}
+
+func (ar *allergyRepository) Update(allergy *models.PatientAllergy) error {
+	return ar.db.Save(allergy).Error
+}
@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"gorm.io/gorm"
+)
+
+type AuditLogRepository interface {
+	Create(entry *models.AuditLog) error
+	FindAll(filters map[string]interface{}) ([]models.AuditLog, error)
+}
+
+type auditLogRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditLogRepository(db *gorm.DB) AuditLogRepository {
+	return &auditLogRepository{db: db}
+}
+
+func (ar *auditLogRepository) Create(entry *models.AuditLog) error {
+	return ar.db.Create(entry).Error
+}
+
+func (ar *auditLogRepository) FindAll(filters map[string]interface{}) ([]models.AuditLog, error) {
+	var entries []models.AuditLog
+	query := ar.db.Model(&models.AuditLog{})
+
+	for key, value := range filters {
+		query = query.Where(key+" = ?", value)
+	}
+
+	err := query.Order("created_at DESC").Find(&entries).Error
+	return entries, err
+}
@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"gorm.io/gorm"
+)
+
+type CashierShiftRepository interface {
+	Create(shift *models.CashierShift) error
+	FindByID(id uint) (*models.CashierShift, error)
+	FindOpenByStaffID(staffID uint) (*models.CashierShift, error)
+	FindAll(query models.CashierShiftQuery) ([]models.CashierShift, int64, error)
+	Close(shift *models.CashierShift) error
+}
+
+type cashierShiftRepository struct {
+	db *gorm.DB
+}
+
+func NewCashierShiftRepository(db *gorm.DB) CashierShiftRepository {
+	return &cashierShiftRepository{db: db}
+}
+
+func (cr *cashierShiftRepository) Create(shift *models.CashierShift) error {
+	return cr.db.Create(shift).Error
+}
+
+func (cr *cashierShiftRepository) FindByID(id uint) (*models.CashierShift, error) {
+	var shift models.CashierShift
+	err := cr.db.First(&shift, id).Error
+	return &shift, err
+}
+
+func (cr *cashierShiftRepository) FindOpenByStaffID(staffID uint) (*models.CashierShift, error) {
+	var shift models.CashierShift
+	err := cr.db.Where("staff_id = ? AND status = ?", staffID, constants.CashierShiftStatus.OPEN).
+		First(&shift).Error
+	return &shift, err
+}
+
+func (cr *cashierShiftRepository) FindAll(query models.CashierShiftQuery) ([]models.CashierShift, int64, error) {
+	var shifts []models.CashierShift
+	var total int64
+
+	db := cr.db.Model(&models.CashierShift{})
+	if query.StaffID != 0 {
+		db = db.Where("staff_id = ?", query.StaffID)
+	}
+	if query.Status != "" {
+		db = db.Where("status = ?", query.Status)
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := db.Order("opened_at DESC, id DESC").
+		Offset(query.Offset()).Limit(query.PageSize).
+		Find(&shifts).Error
+	return shifts, total, err
+}
+
+// Close saves the reconciliation only if the shift is still open, so a shift
+// cannot be closed twice.
+func (cr *cashierShiftRepository) Close(shift *models.CashierShift) error {
+	result := cr.db.Model(&models.CashierShift{}).
+		Where("id = ? AND status = ?", shift.ID, constants.CashierShiftStatus.OPEN).
+		Updates(map[string]interface{}{
+			"status":        shift.Status,
+			"closed_at":     shift.ClosedAt,
+			"expected_cash": shift.ExpectedCash,
+			"counted_cash":  shift.CountedCash,
+			"variance":      shift.Variance,
+			"notes":         shift.Notes,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("shift is already closed")
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"gorm.io/gorm"
+)
+
+type CdsAlertRepository interface {
+	Create(alert *models.CdsAlert) error
+	FindByID(id uint) (*models.CdsAlert, error)
+	FindAll(query models.CdsAlertQuery) ([]models.CdsAlert, int64, error)
+	FindByEntity(trigger string, entityID uint) ([]models.CdsAlert, error)
+	Acknowledge(alert *models.CdsAlert) error
+}
+
+type cdsAlertRepository struct {
+	db *gorm.DB
+}
+
+func NewCdsAlertRepository(db *gorm.DB) CdsAlertRepository {
+	return &cdsAlertRepository{db: db}
+}
+
+func (cr *cdsAlertRepository) Create(alert *models.CdsAlert) error {
+	return cr.db.Create(alert).Error
+}
+
+func (cr *cdsAlertRepository) FindByID(id uint) (*models.CdsAlert, error) {
+	var alert models.CdsAlert
+	err := cr.db.First(&alert, id).Error
+	return &alert, err
+}
+
+// FindAll returns matching alerts newest first.
+func (cr *cdsAlertRepository) FindAll(query models.CdsAlertQuery) ([]models.CdsAlert, int64, error) {
+	var alerts []models.CdsAlert
+	var total int64
+
+	db := cr.db.Model(&models.CdsAlert{})
+	if query.PatientID != 0 {
+		db = db.Where("patient_id = ?", query.PatientID)
+	}
+	if query.Trigger != "" {
+		db = db.Where("trigger = ?", query.Trigger)
+	}
+	if query.Severity != "" {
+		db = db.Where("severity = ?", query.Severity)
+	}
+	if query.Acknowledged != nil {
+		if *query.Acknowledged {
+			db = db.Where("acknowledged_at IS NOT NULL")
+		} else {
+			db = db.Where("acknowledged_at IS NULL")
+		}
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := db.Order("created_at DESC, id DESC").
+		Offset(query.Offset()).Limit(query.PageSize).
+		Find(&alerts).Error
+	return alerts, total, err
+}
+
+func (cr *cdsAlertRepository) FindByEntity(trigger string, entityID uint) ([]models.CdsAlert, error) {
+	var alerts []models.CdsAlert
+	err := cr.db.Where("trigger = ? AND entity_id = ?", trigger, entityID).
+		Order("id").
+		Find(&alerts).Error
+	return alerts, err
+}
+
+// Acknowledge saves the acknowledgement only if the alert has not been
+// acknowledged already, so the first acknowledgement is the one kept.
+func (cr *cdsAlertRepository) Acknowledge(alert *models.CdsAlert) error {
+	return acknowledgeCdsAlert(cr.db, alert)
+}
+
+func acknowledgeCdsAlert(db *gorm.DB, alert *models.CdsAlert) error {
+	result := db.Model(&models.CdsAlert{}).
+		Where("id = ? AND acknowledged_at IS NULL", alert.ID).
+		Updates(map[string]interface{}{
+			"acknowledged_by":        alert.AcknowledgedBy,
+			"acknowledged_at":        alert.AcknowledgedAt,
+			"acknowledgement_reason": alert.AcknowledgementReason,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("alert has already been acknowledged")
+	}
+	return nil
+}
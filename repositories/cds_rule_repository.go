@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"gorm.io/gorm"
+)
+
+type CdsRuleRepository interface {
+	Create(rule *models.CdsRule) error
+	FindByID(id uint) (*models.CdsRule, error)
+	FindAll(query models.CdsRuleQuery) ([]models.CdsRule, error)
+	FindActiveByTrigger(trigger string) ([]models.CdsRule, error)
+	Update(rule *models.CdsRule) error
+}
+
+type cdsRuleRepository struct {
+	db *gorm.DB
+}
+
+func NewCdsRuleRepository(db *gorm.DB) CdsRuleRepository {
+	return &cdsRuleRepository{db: db}
+}
+
+func (cr *cdsRuleRepository) Create(rule *models.CdsRule) error {
+	return cr.db.Create(rule).Error
+}
+
+func (cr *cdsRuleRepository) FindByID(id uint) (*models.CdsRule, error) {
+	var rule models.CdsRule
+	err := cr.db.First(&rule, id).Error
+	return &rule, err
+}
+
+func (cr *cdsRuleRepository) FindAll(query models.CdsRuleQuery) ([]models.CdsRule, error) {
+	var rules []models.CdsRule
+
+	db := cr.db.Model(&models.CdsRule{})
+	if query.Trigger != "" {
+		db = db.Where("trigger = ?", query.Trigger)
+	}
+	if query.ActiveOnly {
+		db = db.Where("is_active = ?", true)
+	}
+
+	err := db.Order("trigger, name").Find(&rules).Error
+	return rules, err
+}
+
+func (cr *cdsRuleRepository) FindActiveByTrigger(trigger string) ([]models.CdsRule, error) {
+	var rules []models.CdsRule
+	err := cr.db.Where("trigger = ? AND is_active = ?", trigger, true).
+		Order("id").
+		Find(&rules).Error
+	return rules, err
+}
+
+func (cr *cdsRuleRepository) Update(rule *models.CdsRule) error {
+	return cr.db.Save(rule).Error
+}
@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"gorm.io/gorm"
+)
+
+type ClaimLayoutRepository interface {
+	FindByPayerID(payerID uint) (*models.ClaimLayout, error)
+	Save(layout *models.ClaimLayout) error
+}
+
+type claimLayoutRepository struct {
+	db *gorm.DB
+}
+
+func NewClaimLayoutRepository(db *gorm.DB) ClaimLayoutRepository {
+	return &claimLayoutRepository{db: db}
+}
+
+func (lr *claimLayoutRepository) FindByPayerID(payerID uint) (*models.ClaimLayout, error) {
+	var layout models.ClaimLayout
+	err := lr.db.Where("payer_id = ?", payerID).First(&layout).Error
+	return &layout, err
+}
+
+func (lr *claimLayoutRepository) Save(layout *models.ClaimLayout) error {
+	return lr.db.Save(layout).Error
+}
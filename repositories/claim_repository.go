@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"gorm.io/gorm"
+)
+
+type ClaimRepository interface {
+	CreateBatch(batch *models.ClaimBatch) error
+	FindBatchByID(id uint) (*models.ClaimBatch, error)
+	FindBatches(query models.ClaimBatchQuery) ([]models.ClaimBatch, int64, error)
+	UpdateBatch(batch *models.ClaimBatch) error
+	SaveReconciliation(batch *models.ClaimBatch) error
+	FindClaimableInvoices(payerID uint, from time.Time, to time.Time) ([]models.Invoice, error)
+}
+
+type claimRepository struct {
+	db *gorm.DB
+}
+
+func NewClaimRepository(db *gorm.DB) ClaimRepository {
+	return &claimRepository{db: db}
+}
+
+// CreateBatch saves the batch with its claims and lines in a single
+// transaction.
+func (cr *claimRepository) CreateBatch(batch *models.ClaimBatch) error {
+	return cr.db.Omit("Payer").Create(batch).Error
+}
+
+func (cr *claimRepository) FindBatchByID(id uint) (*models.ClaimBatch, error) {
+	var batch models.ClaimBatch
+	err := cr.db.Preload("Payer").
+		Preload("Claims", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id ASC")
+		}).
+		Preload("Claims.Lines", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id ASC")
+		}).
+		First(&batch, id).Error
+	return &batch, err
+}
+
+func (cr *claimRepository) FindBatches(query models.ClaimBatchQuery) ([]models.ClaimBatch, int64, error) {
+	var batches []models.ClaimBatch
+	var total int64
+
+	db := cr.db.Model(&models.ClaimBatch{})
+	if query.PayerID != 0 {
+		db = db.Where("payer_id = ?", query.PayerID)
+	}
+	if query.Status != "" {
+		db = db.Where("status = ?", query.Status)
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := db.Preload("Payer").
+		Order("created_at DESC, id DESC").
+		Offset(query.Offset()).Limit(query.PageSize).
+		Find(&batches).Error
+	return batches, total, err
+}
+
+// UpdateBatch saves the batch header only.
+func (cr *claimRepository) UpdateBatch(batch *models.ClaimBatch) error {
+	return cr.db.Omit("Payer", "Claims").Save(batch).Error
+}
+
+// SaveReconciliation saves the batch with the amounts and statuses of all its
+// claims and lines in a single transaction.
+func (cr *claimRepository) SaveReconciliation(batch *models.ClaimBatch) error {
+	return cr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Payer", "Claims").Save(batch).Error; err != nil {
+			return err
+		}
+		for i := range batch.Claims {
+			claim := &batch.Claims[i]
+			if err := tx.Omit("Lines").Save(claim).Error; err != nil {
+				return err
+			}
+			for j := range claim.Lines {
+				if err := tx.Save(&claim.Lines[j]).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// FindClaimableInvoices returns the payer's invoices issued from from up to,
+// but not including, to that carry an insurer share and are not on a claim
+// the payer has yet to reject.
+func (cr *claimRepository) FindClaimableInvoices(payerID uint, from time.Time, to time.Time) ([]models.Invoice, error) {
+	statuses := constants.InvoiceStatus
+	var invoices []models.Invoice
+	err := cr.db.Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).
+		Where("payer_id = ? AND status IN ? AND insurer_amount > 0", payerID,
+			[]string{statuses.ISSUED, statuses.PARTIALLY_PAID, statuses.PAID}).
+		Where("issued_at >= ? AND issued_at < ?", from, to).
+		Where("NOT EXISTS (SELECT 1 FROM claims WHERE claims.invoice_id = invoices.id AND claims.status <> ? AND claims.deleted_at IS NULL)",
+			constants.ClaimStatus.REJECTED).
+		Order("issued_at ASC, id ASC").
+		Find(&invoices).Error
+	return invoices, err
+}
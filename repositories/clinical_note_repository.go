@@ -6,11 +6,13 @@ import (
 )
 
 type ClinicalNoteRepository interface {
-	Create(note *models.ClinicalNote) error
+	Create(note *models.ClinicalNote, completedAppointment *models.Appointment, writes models.ClinicalRecordWrites) error
 	FindByID(id uint) (*models.ClinicalNote, error)
 	FindByAppointmentID(appointmentID uint) (*models.ClinicalNote, error)
 	FindByPatientID(patientID uint) ([]models.ClinicalNote, error)
-	Update(note *models.ClinicalNote) error
+	FindByAdmissionID(admissionID uint) ([]models.ClinicalNote, error)
+	Update(note *models.ClinicalNote, writes models.ClinicalRecordWrites) error
+	UpdateWithDiagnoses(note *models.ClinicalNote, diagnoses []models.NoteDiagnosis, writes models.ClinicalRecordWrites) error
 	Delete(id uint) error
 }
 
@@ -22,30 +24,85 @@ func NewClinicalNoteRepository(db *gorm.DB) ClinicalNoteRepository {
 	return &clinicalNoteRepository{db}
 }
 
-func (r *clinicalNoteRepository) Create(note *models.ClinicalNote) error {
-	return r.db.Create(note).Error
+// Create saves a new note with its alerts and audit entries and, when the note
+// completes an appointment, the appointment too, all in one transaction.
+func (r *clinicalNoteRepository) Create(note *models.ClinicalNote, completedAppointment *models.Appointment,
+	writes models.ClinicalRecordWrites) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(note).Error; err != nil {
+			return err
+		}
+		if completedAppointment != nil {
+			if err := tx.Save(completedAppointment).Error; err != nil {
+				return err
+			}
+		}
+		return saveClinicalRecordWrites(tx, note.ID, writes)
+	})
 }
 
 func (r *clinicalNoteRepository) FindByID(id uint) (*models.ClinicalNote, error) {
 	var note models.ClinicalNote
-	err := r.db.First(&note, id).Error
+	err := r.db.Preload("Diagnoses.DiagnosisCode").First(&note, id).Error
 	return &note, err
 }
 
 func (r *clinicalNoteRepository) FindByAppointmentID(appointmentID uint) (*models.ClinicalNote, error) {
 	var note models.ClinicalNote
-	err := r.db.Where("appointment_id = ?", appointmentID).First(&note).Error
+	err := r.db.Preload("Diagnoses.DiagnosisCode").
+		Where("appointment_id = ?", appointmentID).First(&note).Error
 	return &note, err
 }
 
 func (r *clinicalNoteRepository) FindByPatientID(patientID uint) ([]models.ClinicalNote, error) {
 	var notes []models.ClinicalNote
-	err := r.db.Where("patient_id = ?", patientID).Find(&notes).Error
+	err := r.db.Preload("Diagnoses.DiagnosisCode").
+		Where("patient_id = ?", patientID).Find(&notes).Error
 	return notes, err
 }
 
-func (r *clinicalNoteRepository) Update(note *models.ClinicalNote) error {
-	return r.db.Save(note).Error
+// FindByAdmissionID returns the notes written during an admission, oldest
+// first.
+func (r *clinicalNoteRepository) FindByAdmissionID(admissionID uint) ([]models.ClinicalNote, error) {
+	var notes []models.ClinicalNote
+	err := r.db.Preload("Diagnoses.DiagnosisCode").
+		Where("admission_id = ?", admissionID).Order("created_at ASC").Find(&notes).Error
+	return notes, err
+}
+
+// Update saves the note, leaving its diagnoses alone, with its alerts and
+// audit entries in one transaction.
+func (r *clinicalNoteRepository) Update(note *models.ClinicalNote, writes models.ClinicalRecordWrites) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Diagnoses").Save(note).Error; err != nil {
+			return err
+		}
+		return saveClinicalRecordWrites(tx, note.ID, writes)
+	})
+}
+
+// UpdateWithDiagnoses saves the note like Update and replaces its diagnoses
+// in the same transaction, so a failed write leaves both as they were.
+func (r *clinicalNoteRepository) UpdateWithDiagnoses(note *models.ClinicalNote, diagnoses []models.NoteDiagnosis,
+	writes models.ClinicalRecordWrites) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Diagnoses").Save(note).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("clinical_note_id = ?", note.ID).
+			Delete(&models.NoteDiagnosis{}).Error; err != nil {
+			return err
+		}
+		if len(diagnoses) > 0 {
+			for i := range diagnoses {
+				diagnoses[i].ClinicalNoteID = note.ID
+			}
+			if err := tx.Create(&diagnoses).Error; err != nil {
+				return err
+			}
+		}
+		return saveClinicalRecordWrites(tx, note.ID, writes)
+	})
 }
 
 func (r *clinicalNoteRepository) Delete(id uint) error {
@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"gorm.io/gorm"
+)
+
+// saveClinicalRecordWrites saves the alerts and audit entries of a clinical
+// record inside the transaction that saved the record itself.
+func saveClinicalRecordWrites(tx *gorm.DB, entityID uint, writes models.ClinicalRecordWrites) error {
+	for _, alert := range writes.NewAlerts {
+		alert.EntityID = entityID
+		if err := tx.Create(alert).Error; err != nil {
+			return err
+		}
+	}
+	for _, alert := range writes.Acknowledged {
+		if err := acknowledgeCdsAlert(tx, alert); err != nil {
+			return err
+		}
+	}
+	for _, entry := range writes.Overrides {
+		entry.EntityID = entityID
+		if err := tx.Create(entry).Error; err != nil {
+			return err
+		}
+	}
+	for _, entry := range writes.AuditEntries {
+		if err := tx.Create(entry).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package repositories
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DiagnosisCodeRepository interface {
+	Upsert(codes []models.DiagnosisCode) error
+	FindByCode(code string) (*models.DiagnosisCode, error)
+	Search(query string, limit int) ([]models.DiagnosisCode, error)
+}
+
+type diagnosisCodeRepository struct {
+	db *gorm.DB
+}
+
+func NewDiagnosisCodeRepository(db *gorm.DB) DiagnosisCodeRepository {
+	return &diagnosisCodeRepository{db: db}
+}
+
+func (dr *diagnosisCodeRepository) Upsert(codes []models.DiagnosisCode) error {
+	return dr.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "code"}},
+		DoUpdates: clause.AssignmentColumns([]string{"description", "category", "updated_at"}),
+	}).CreateInBatches(codes, 500).Error
+}
+
+func (dr *diagnosisCodeRepository) FindByCode(code string) (*models.DiagnosisCode, error) {
+	var diagnosisCode models.DiagnosisCode
+	err := dr.db.Where("code = ?", code).First(&diagnosisCode).Error
+	return &diagnosisCode, err
+}
+
+// Search matches codes by prefix and descriptions by substring or trigram
+// similarity (pg_trgm), ranking exact and prefix code matches first.
+func (dr *diagnosisCodeRepository) Search(query string, limit int) ([]models.DiagnosisCode, error) {
+	var codes []models.DiagnosisCode
+	prefix := query + "%"
+
+	err := dr.db.
+		Where("code ILIKE ? OR description ILIKE ? OR similarity(description, ?) > 0.3",
+			prefix, "%"+query+"%", query).
+		Order(clause.Expr{
+			SQL:  "CASE WHEN code ILIKE ? THEN 0 WHEN code ILIKE ? THEN 1 ELSE 2 END, similarity(description, ?) DESC, code",
+			Vars: []interface{}{query, prefix, query},
+		}).
+		Limit(limit).
+		Find(&codes).Error
+	return codes, err
+}
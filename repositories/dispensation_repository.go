@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"gorm.io/gorm"
+)
+
+type DispensationRepository interface {
+	Dispense(dispensation *models.Dispensation, prescription *models.Prescription) error
+	FindByPrescriptionID(prescriptionID uint) ([]models.Dispensation, error)
+}
+
+type dispensationRepository struct {
+	db *gorm.DB
+}
+
+func NewDispensationRepository(db *gorm.DB) DispensationRepository {
+	return &dispensationRepository{db: db}
+}
+
+// Dispense takes each item from its batch, saves the prescription's new
+// dispensed quantity and status, and records the dispensation and its ledger
+// movements in a single transaction. The conditional updates fail the whole
+// dispensation if a batch or the prescription changed since it was planned.
+func (dr *dispensationRepository) Dispense(dispensation *models.Dispensation, prescription *models.Prescription) error {
+	return dr.db.Transaction(func(tx *gorm.DB) error {
+		for _, item := range dispensation.Items {
+			result := tx.Model(&models.StockBatch{}).
+				Where("id = ? AND quantity_on_hand >= ?", item.StockBatchID, item.Quantity).
+				Update("quantity_on_hand", gorm.Expr("quantity_on_hand - ?", item.Quantity))
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return errors.New("stock changed while dispensing, please try again")
+			}
+		}
+
+		result := tx.Model(&models.Prescription{}).
+			Where("id = ? AND status = ? AND quantity_dispensed = ?", prescription.ID,
+				constants.PrescriptionStatus.ACTIVE, prescription.QuantityDispensed-dispensation.Quantity).
+			Updates(map[string]interface{}{
+				"quantity_dispensed": prescription.QuantityDispensed,
+				"status":             prescription.Status,
+				"status_changed_by":  prescription.StatusChangedBy,
+				"status_changed_at":  prescription.StatusChangedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("prescription changed while dispensing, please try again")
+		}
+
+		if err := tx.Create(dispensation).Error; err != nil {
+			return err
+		}
+
+		for _, item := range dispensation.Items {
+			if err := tx.Create(&models.StockMovement{
+				MedicationID:   dispensation.MedicationID,
+				StockBatchID:   item.StockBatchID,
+				Type:           constants.StockMovementTypes.DISPENSE,
+				Quantity:       -item.Quantity,
+				DispensationID: &dispensation.ID,
+				PrescriptionID: &dispensation.PrescriptionID,
+				OccurredAt:     dispensation.DispensedAt,
+				StaffID:        dispensation.DispensedBy,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (dr *dispensationRepository) FindByPrescriptionID(prescriptionID uint) ([]models.Dispensation, error) {
+	var dispensations []models.Dispensation
+	err := dr.db.Preload("Items").
+		Where("prescription_id = ?", prescriptionID).
+		Order("dispensed_at ASC, id ASC").
+		Find(&dispensations).Error
+	return dispensations, err
+}
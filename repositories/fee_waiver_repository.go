@@ -0,0 +1,156 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"gorm.io/gorm"
+)
+
+type FeeWaiverRepository interface {
+	Create(waiver *models.FeeWaiver, entry *models.AuditLog) error
+	FindByID(id uint) (*models.FeeWaiver, error)
+	FindAll(query models.FeeWaiverQuery) ([]models.FeeWaiver, int64, error)
+	FindPendingByInvoiceID(invoiceID uint) (*models.FeeWaiver, error)
+	RecordApproval(waiver *models.FeeWaiver, entry *models.AuditLog) error
+	UpdateStatus(waiver *models.FeeWaiver, entry *models.AuditLog) error
+}
+
+type feeWaiverRepository struct {
+	db *gorm.DB
+}
+
+func NewFeeWaiverRepository(db *gorm.DB) FeeWaiverRepository {
+	return &feeWaiverRepository{db: db}
+}
+
+// Create saves a new waiver and its audit entry in one transaction.
+func (fr *feeWaiverRepository) Create(waiver *models.FeeWaiver, entry *models.AuditLog) error {
+	return fr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(waiver).Error; err != nil {
+			return err
+		}
+		entry.EntityID = waiver.ID
+		return tx.Create(entry).Error
+	})
+}
+
+func (fr *feeWaiverRepository) FindByID(id uint) (*models.FeeWaiver, error) {
+	var waiver models.FeeWaiver
+	err := fr.db.First(&waiver, id).Error
+	return &waiver, err
+}
+
+func (fr *feeWaiverRepository) FindAll(query models.FeeWaiverQuery) ([]models.FeeWaiver, int64, error) {
+	var waivers []models.FeeWaiver
+	var total int64
+
+	db := fr.db.Model(&models.FeeWaiver{})
+	if query.InvoiceID != 0 {
+		db = db.Where("invoice_id = ?", query.InvoiceID)
+	}
+	if query.PatientID != 0 {
+		db = db.Where("patient_id = ?", query.PatientID)
+	}
+	if query.Status != "" {
+		db = db.Where("status = ?", query.Status)
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := db.Order("requested_at DESC, id DESC").
+		Offset(query.Offset()).Limit(query.PageSize).
+		Find(&waivers).Error
+	return waivers, total, err
+}
+
+func (fr *feeWaiverRepository) FindPendingByInvoiceID(invoiceID uint) (*models.FeeWaiver, error) {
+	var waiver models.FeeWaiver
+	err := fr.db.Where("invoice_id = ? AND status = ?", invoiceID, constants.FeeWaiverStatus.PENDING).
+		First(&waiver).Error
+	return &waiver, err
+}
+
+// RecordApproval saves an approval on a pending waiver with its audit entry.
+// The final approval also takes the waiver off the invoice in the same
+// transaction, provided the invoice and the patient's share of it still have
+// that much left to pay.
+func (fr *feeWaiverRepository) RecordApproval(waiver *models.FeeWaiver, entry *models.AuditLog) error {
+	return fr.db.Transaction(func(tx *gorm.DB) error {
+		approval := tx.Model(&models.FeeWaiver{}).
+			Where("id = ? AND status = ?", waiver.ID, constants.FeeWaiverStatus.PENDING)
+		if waiver.SecondApprovedBy == nil {
+			approval = approval.Where("first_approved_by IS NULL")
+		} else {
+			approval = approval.Where("first_approved_by IS NOT NULL AND second_approved_by IS NULL")
+		}
+		result := approval.Updates(map[string]interface{}{
+			"status":             waiver.Status,
+			"first_approved_by":  waiver.FirstApprovedBy,
+			"first_approved_at":  waiver.FirstApprovedAt,
+			"second_approved_by": waiver.SecondApprovedBy,
+			"second_approved_at": waiver.SecondApprovedAt,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("waiver changed while recording the approval, please try again")
+		}
+
+		if err := tx.Create(entry).Error; err != nil {
+			return err
+		}
+		if waiver.Status != constants.FeeWaiverStatus.APPROVED {
+			return nil
+		}
+
+		statuses := constants.InvoiceStatus
+		patientPaid := tx.Model(&models.Payment{}).
+			Select("COALESCE(SUM(amount - amount_refunded), 0)").
+			Where("invoice_id = invoices.id AND method <> ?", constants.PaymentMethods.INSURANCE)
+		result = tx.Model(&models.Invoice{}).
+			Where("id = ? AND status IN ? AND total - amount_paid >= ? AND patient_amount - (?) >= ?", waiver.InvoiceID,
+				[]string{statuses.ISSUED, statuses.PARTIALLY_PAID}, waiver.Amount, patientPaid, waiver.Amount).
+			Updates(map[string]interface{}{
+				"waiver_total":   gorm.Expr("waiver_total + ?", waiver.Amount),
+				"total":          gorm.Expr("total - ?", waiver.Amount),
+				"patient_amount": gorm.Expr("patient_amount - ?", waiver.Amount),
+				"status": gorm.Expr("CASE WHEN amount_paid >= total - ? THEN ?::invoice_status ELSE status END",
+					waiver.Amount, statuses.PAID),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("invoice changed while applying the waiver, please try again")
+		}
+		return nil
+	})
+}
+
+// UpdateStatus rejects or cancels a waiver that is still pending and writes
+// its audit entry in the same transaction.
+func (fr *feeWaiverRepository) UpdateStatus(waiver *models.FeeWaiver, entry *models.AuditLog) error {
+	return fr.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.FeeWaiver{}).
+			Where("id = ? AND status = ?", waiver.ID, constants.FeeWaiverStatus.PENDING).
+			Updates(map[string]interface{}{
+				"status":           waiver.Status,
+				"rejected_by":      waiver.RejectedBy,
+				"rejected_at":      waiver.RejectedAt,
+				"rejection_reason": waiver.RejectionReason,
+				"cancelled_at":     waiver.CancelledAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("waiver is no longer pending")
+		}
+		return tx.Create(entry).Error
+	})
+}
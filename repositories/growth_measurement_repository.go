@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"gorm.io/gorm"
+)
+
+type GrowthMeasurementRepository interface {
+	Create(measurement *models.GrowthMeasurement) error
+	FindByPatientID(patientID uint) ([]models.GrowthMeasurement, error)
+}
+
+type growthMeasurementRepository struct {
+	db *gorm.DB
+}
+
+func NewGrowthMeasurementRepository(db *gorm.DB) GrowthMeasurementRepository {
+	return &growthMeasurementRepository{db: db}
+}
+
+func (gr *growthMeasurementRepository) Create(measurement *models.GrowthMeasurement) error {
+	return gr.db.Create(measurement).Error
+}
+
+// FindByPatientID returns the patient's measurements oldest first.
+func (gr *growthMeasurementRepository) FindByPatientID(patientID uint) ([]models.GrowthMeasurement, error) {
+	var measurements []models.GrowthMeasurement
+	err := gr.db.Where("patient_id = ?", patientID).Order("measured_at ASC, id ASC").Find(&measurements).Error
+	return measurements, err
+}
@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ImmunizationRepository interface {
+	Create(immunization *models.Immunization) error
+	FindByPatientID(patientID uint) ([]models.Immunization, error)
+	FindOverdue(vaccineCode string, at time.Time, page models.PageQuery) ([]models.OverdueImmunization, int64, error)
+}
+
+type immunizationRepository struct {
+	db *gorm.DB
+}
+
+func NewImmunizationRepository(db *gorm.DB) ImmunizationRepository {
+	return &immunizationRepository{db: db}
+}
+
+// Create records the dose unless the same dose has been recorded in the
+// meantime, which the unique index on patient, vaccine and dose number
+// catches.
+func (ir *immunizationRepository) Create(immunization *models.Immunization) error {
+	result := ir.db.Omit("Vaccine").Clauses(clause.OnConflict{DoNothing: true}).Create(immunization)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("this dose is already recorded for the patient")
+	}
+	return nil
+}
+
+func (ir *immunizationRepository) FindByPatientID(patientID uint) ([]models.Immunization, error) {
+	var immunizations []models.Immunization
+	err := ir.db.Preload("Vaccine").
+		Where("patient_id = ?", patientID).
+		Order("administered_at, id").
+		Find(&immunizations).Error
+	return immunizations, err
+}
+
+// overdueImmunizations pairs every patient with every scheduled dose of an
+// active vaccine and keeps the doses that are past their grace period, still
+// within the age limit, and not yet recorded. The first guardian is joined in
+// for outreach.
+const overdueImmunizations = `
+	FROM patients p
+	CROSS JOIN vaccine_schedule_doses d
+	JOIN vaccines v ON v.id = d.vaccine_id AND v.is_active AND v.deleted_at IS NULL
+	LEFT JOIN LATERAL (
+		SELECT c.full_name, c.phone_number FROM patient_contacts c
+		WHERE c.patient_id = p.id AND c.contact_type = @guardian AND c.deleted_at IS NULL
+		ORDER BY c.priority LIMIT 1
+	) g ON TRUE
+	WHERE p.deleted_at IS NULL AND d.deleted_at IS NULL
+		AND p.date_of_birth::date + d.due_age_days + d.overdue_after_days < @today
+		AND (d.max_age_days = 0 OR p.date_of_birth::date + d.max_age_days >= @today)
+		AND (@vaccine = '' OR v.code = @vaccine)
+		AND NOT EXISTS (
+			SELECT 1 FROM immunizations i
+			WHERE i.patient_id = p.id AND i.vaccine_id = d.vaccine_id
+				AND i.dose_number = d.dose_number AND i.deleted_at IS NULL
+		)`
+
+func (ir *immunizationRepository) FindOverdue(vaccineCode string, at time.Time, page models.PageQuery) ([]models.OverdueImmunization, int64, error) {
+	var overdue []models.OverdueImmunization
+	var total int64
+
+	args := map[string]interface{}{
+		"today":    at.Format("2006-01-02"),
+		"vaccine":  vaccineCode,
+		"guardian": constants.ContactTypes.GUARDIAN,
+		"limit":    page.PageSize,
+		"offset":   page.Offset(),
+	}
+
+	if err := ir.db.Raw("SELECT COUNT(*)"+overdueImmunizations, args).Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := ir.db.Raw(`SELECT p.id AS patient_id, p.registration_number, p.first_name, p.last_name,
+			p.date_of_birth, p.phone_number,
+			g.full_name AS guardian_name, g.phone_number AS guardian_phone,
+			v.code AS vaccine_code, v.name AS vaccine_name, d.dose_number,
+			p.date_of_birth::date + d.due_age_days AS due_date,
+			@today::date - (p.date_of_birth::date + d.due_age_days + d.overdue_after_days) AS days_overdue`+
+		overdueImmunizations+`
+		ORDER BY v.code, d.dose_number, due_date, p.id
+		LIMIT @limit OFFSET @offset`, args).Scan(&overdue).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return overdue, total, nil
+}
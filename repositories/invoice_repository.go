@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"gorm.io/gorm"
+)
+
+type InvoiceRepository interface {
+	Create(invoice *models.Invoice) error
+	FindByID(id uint) (*models.Invoice, error)
+	FindAll(query models.InvoiceQuery) ([]models.Invoice, int64, error)
+	FindOpenByAppointmentID(appointmentID uint) (*models.Invoice, error)
+	Update(invoice *models.Invoice) error
+	UpdateAudited(invoice *models.Invoice, entry *models.AuditLog) error
+}
+
+type invoiceRepository struct {
+	db *gorm.DB
+}
+
+func NewInvoiceRepository(db *gorm.DB) InvoiceRepository {
+	return &invoiceRepository{db: db}
+}
+
+func (ir *invoiceRepository) Create(invoice *models.Invoice) error {
+	return ir.db.Create(invoice).Error
+}
+
+func (ir *invoiceRepository) FindByID(id uint) (*models.Invoice, error) {
+	var invoice models.Invoice
+	err := ir.db.Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).First(&invoice, id).Error
+	return &invoice, err
+}
+
+func (ir *invoiceRepository) FindAll(query models.InvoiceQuery) ([]models.Invoice, int64, error) {
+	var invoices []models.Invoice
+	var total int64
+
+	db := ir.db.Model(&models.Invoice{})
+	if query.PatientID != 0 {
+		db = db.Where("patient_id = ?", query.PatientID)
+	}
+	if query.AppointmentID != 0 {
+		db = db.Where("appointment_id = ?", query.AppointmentID)
+	}
+	if query.Status != "" {
+		db = db.Where("status = ?", query.Status)
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := db.Order("created_at DESC, id DESC").
+		Offset(query.Offset()).Limit(query.PageSize).
+		Find(&invoices).Error
+	return invoices, total, err
+}
+
+// FindOpenByAppointmentID returns the appointment's invoice unless it has
+// been voided.
+func (ir *invoiceRepository) FindOpenByAppointmentID(appointmentID uint) (*models.Invoice, error) {
+	var invoice models.Invoice
+	err := ir.db.Where("appointment_id = ? AND status <> ?", appointmentID, constants.InvoiceStatus.VOID).
+		First(&invoice).Error
+	return &invoice, err
+}
+
+// Update saves the invoice and its lines in a single transaction, deleting
+// lines that are no longer on it.
+func (ir *invoiceRepository) Update(invoice *models.Invoice) error {
+	return ir.db.Transaction(func(tx *gorm.DB) error {
+		return saveInvoice(tx, invoice)
+	})
+}
+
+// UpdateAudited saves the invoice like Update and writes the audit entry in
+// the same transaction, so the change is never left unaudited.
+func (ir *invoiceRepository) UpdateAudited(invoice *models.Invoice, entry *models.AuditLog) error {
+	return ir.db.Transaction(func(tx *gorm.DB) error {
+		if err := saveInvoice(tx, invoice); err != nil {
+			return err
+		}
+		return tx.Create(entry).Error
+	})
+}
+
+func saveInvoice(tx *gorm.DB, invoice *models.Invoice) error {
+	if err := tx.Omit("Lines").Save(invoice).Error; err != nil {
+		return err
+	}
+
+	keep := []uint{}
+	for i := range invoice.Lines {
+		line := &invoice.Lines[i]
+		line.InvoiceID = invoice.ID
+		if err := tx.Save(line).Error; err != nil {
+			return err
+		}
+		keep = append(keep, line.ID)
+	}
+
+	removed := tx.Where("invoice_id = ?", invoice.ID)
+	if len(keep) > 0 {
+		removed = removed.Where("id NOT IN ?", keep)
+	}
+	return removed.Delete(&models.InvoiceLine{}).Error
+}
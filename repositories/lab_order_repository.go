@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"gorm.io/gorm"
+)
+
+type LabOrderRepository interface {
+	Create(order *models.LabOrder) error
+	FindAll(filters map[string]interface{}) ([]models.LabOrder, error)
+	FindByID(id uint) (*models.LabOrder, error)
+	FindInbox(doctorID uint) ([]models.LabOrder, error)
+	Update(order *models.LabOrder) error
+	SaveResults(order *models.LabOrder, writes models.ClinicalRecordWrites) error
+}
+
+type labOrderRepository struct {
+	db *gorm.DB
+}
+
+func NewLabOrderRepository(db *gorm.DB) LabOrderRepository {
+	return &labOrderRepository{db: db}
+}
+
+func (lr *labOrderRepository) Create(order *models.LabOrder) error {
+	return lr.db.Omit("Results.LabTest").Create(order).Error
+}
+
+func (lr *labOrderRepository) FindAll(filters map[string]interface{}) ([]models.LabOrder, error) {
+	var orders []models.LabOrder
+	query := lr.db.Preload("Results.LabTest")
+
+	for key, value := range filters {
+		query = query.Where(key+" = ?", value)
+	}
+
+	err := query.Order("created_at DESC").Find(&orders).Error
+	return orders, err
+}
+
+func (lr *labOrderRepository) FindByID(id uint) (*models.LabOrder, error) {
+	var order models.LabOrder
+	err := lr.db.Preload("Results.LabTest.ReferenceRanges").First(&order, id).Error
+	return &order, err
+}
+
+// FindInbox returns the doctor's orders that have results awaiting review.
+func (lr *labOrderRepository) FindInbox(doctorID uint) ([]models.LabOrder, error) {
+	var orders []models.LabOrder
+	err := lr.db.Preload("Results.LabTest").
+		Where("ordered_by = ? AND reviewed_at IS NULL AND status IN ?", doctorID,
+			[]string{constants.LabOrderStatus.RESULTED, constants.LabOrderStatus.VERIFIED}).
+		Order("resulted_at DESC").
+		Find(&orders).Error
+	return orders, err
+}
+
+func (lr *labOrderRepository) Update(order *models.LabOrder) error {
+	return lr.db.Omit("Results").Save(order).Error
+}
+
+// SaveResults saves the order, its results and the alerts they raised in one
+// transaction.
+func (lr *labOrderRepository) SaveResults(order *models.LabOrder, writes models.ClinicalRecordWrites) error {
+	return lr.db.Transaction(func(tx *gorm.DB) error {
+		for i := range order.Results {
+			if err := tx.Omit("LabTest").Save(&order.Results[i]).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Omit("Results").Save(order).Error; err != nil {
+			return err
+		}
+		return saveClinicalRecordWrites(tx, order.ID, writes)
+	})
+}
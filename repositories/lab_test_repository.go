@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"gorm.io/gorm"
+)
+
+type LabTestRepository interface {
+	Create(labTest *models.LabTest) error
+	FindAll(activeOnly bool) ([]models.LabTest, error)
+	FindByID(id uint) (*models.LabTest, error)
+	FindByIDs(ids []uint) ([]models.LabTest, error)
+	Update(labTest *models.LabTest) error
+	ReplaceReferenceRanges(labTestID uint, ranges []models.LabReferenceRange) error
+}
+
+type labTestRepository struct {
+	db *gorm.DB
+}
+
+func NewLabTestRepository(db *gorm.DB) LabTestRepository {
+	return &labTestRepository{db: db}
+}
+
+func (lr *labTestRepository) Create(labTest *models.LabTest) error {
+	return lr.db.Create(labTest).Error
+}
+
+func (lr *labTestRepository) FindAll(activeOnly bool) ([]models.LabTest, error) {
+	var labTests []models.LabTest
+	query := lr.db.Preload("ReferenceRanges")
+
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+
+	err := query.Order("name").Find(&labTests).Error
+	return labTests, err
+}
+
+func (lr *labTestRepository) FindByID(id uint) (*models.LabTest, error) {
+	var labTest models.LabTest
+	err := lr.db.Preload("ReferenceRanges").First(&labTest, id).Error
+	return &labTest, err
+}
+
+func (lr *labTestRepository) FindByIDs(ids []uint) ([]models.LabTest, error) {
+	var labTests []models.LabTest
+	err := lr.db.Preload("ReferenceRanges").Where("id IN ?", ids).Find(&labTests).Error
+	return labTests, err
+}
+
+func (lr *labTestRepository) Update(labTest *models.LabTest) error {
+	return lr.db.Omit("ReferenceRanges").Save(labTest).Error
+}
+
+func (lr *labTestRepository) ReplaceReferenceRanges(labTestID uint, ranges []models.LabReferenceRange) error {
+	return lr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("lab_test_id = ?", labTestID).
+			Delete(&models.LabReferenceRange{}).Error; err != nil {
+			return err
+		}
+		if len(ranges) == 0 {
+			return nil
+		}
+		for i := range ranges {
+			ranges[i].LabTestID = labTestID
+		}
+		return tx.Create(&ranges).Error
+	})
+}
@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MedicationAdministrationRepository interface {
+	Create(administration *models.MedicationAdministration) error
+	FindByPatientID(patientID uint, page models.PageQuery) ([]models.MedicationAdministration, int64, error)
+	FindByAdmissionID(admissionID uint) ([]models.MedicationAdministration, error)
+	CountByPrescriptionID(prescriptionID uint) (int64, error)
+	CountByScheduledDose(prescriptionID uint, scheduledAt time.Time) (int64, error)
+}
+
+type medicationAdministrationRepository struct {
+	db *gorm.DB
+}
+
+func NewMedicationAdministrationRepository(db *gorm.DB) MedicationAdministrationRepository {
+	return &medicationAdministrationRepository{db: db}
+}
+
+// Create charts the dose unless its scheduled dose has been charted in the
+// meantime, which the unique index on prescription and scheduled time
+// catches.
+func (mr *medicationAdministrationRepository) Create(administration *models.MedicationAdministration) error {
+	result := mr.db.Omit("Prescription").Clauses(clause.OnConflict{DoNothing: true}).Create(administration)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("this dose has already been charted")
+	}
+	return nil
+}
+
+func (mr *medicationAdministrationRepository) FindByPatientID(patientID uint, page models.PageQuery) ([]models.MedicationAdministration, int64, error) {
+	var administrations []models.MedicationAdministration
+	var total int64
+
+	db := mr.db.Model(&models.MedicationAdministration{}).Where("patient_id = ?", patientID)
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := db.Preload("Prescription.Medication").
+		Order("administered_at DESC, id DESC").
+		Offset(page.Offset()).Limit(page.PageSize).
+		Find(&administrations).Error
+	return administrations, total, err
+}
+
+// FindByAdmissionID returns the doses given during the admission oldest first.
+func (mr *medicationAdministrationRepository) FindByAdmissionID(admissionID uint) ([]models.MedicationAdministration, error) {
+	var administrations []models.MedicationAdministration
+	err := mr.db.Where("admission_id = ?", admissionID).
+		Order("administered_at ASC, id ASC").
+		Find(&administrations).Error
+	return administrations, err
+}
+
+func (mr *medicationAdministrationRepository) CountByPrescriptionID(prescriptionID uint) (int64, error) {
+	var count int64
+	err := mr.db.Model(&models.MedicationAdministration{}).
+		Where("prescription_id = ?", prescriptionID).
+		Count(&count).Error
+	return count, err
+}
+
+func (mr *medicationAdministrationRepository) CountByScheduledDose(prescriptionID uint, scheduledAt time.Time) (int64, error) {
+	var count int64
+	err := mr.db.Model(&models.MedicationAdministration{}).
+		Where("prescription_id = ? AND scheduled_at = ?", prescriptionID, scheduledAt).
+		Count(&count).Error
+	return count, err
+}
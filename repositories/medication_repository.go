@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"gorm.io/gorm"
+)
+
+type MedicationRepository interface {
+	Create(medication *models.Medication) error
+	FindAll(query string, activeOnly bool) ([]models.Medication, error)
+	FindByID(id uint) (*models.Medication, error)
+	FindByNames(names []string) ([]models.Medication, error)
+	Update(medication *models.Medication) error
+}
+
+type medicationRepository struct {
+	db *gorm.DB
+}
+
+func NewMedicationRepository(db *gorm.DB) MedicationRepository {
+	return &medicationRepository{db: db}
+}
+
+func (mr *medicationRepository) Create(medication *models.Medication) error {
+	return mr.db.Create(medication).Error
+}
+
+func (mr *medicationRepository) FindAll(query string, activeOnly bool) ([]models.Medication, error) {
+	var medications []models.Medication
+	tx := mr.db.Model(&models.Medication{})
+
+	if query != "" {
+		pattern := "%" + query + "%"
+		tx = tx.Where("name ILIKE ? OR generic_name ILIKE ?", pattern, pattern)
+	}
+	if activeOnly {
+		tx = tx.Where("is_active = ?", true)
+	}
+
+	err := tx.Order("name").Find(&medications).Error
+	return medications, err
+}
+
+func (mr *medicationRepository) FindByID(id uint) (*models.Medication, error) {
+	var medication models.Medication
+	err := mr.db.First(&medication, id).Error
+	return &medication, err
+}
+
+// FindByNames returns the medications whose name or generic name is one of
+// names. Names are compared in lower case with punctuation read as a space,
+// so "co trimoxazole" finds Co-Trimoxazole.
+func (mr *medicationRepository) FindByNames(names []string) ([]models.Medication, error) {
+	var medications []models.Medication
+	if len(names) == 0 {
+		return medications, nil
+	}
+
+	err := mr.db.Where("TRIM(REGEXP_REPLACE(LOWER(name), '[^[:alnum:]]+', ' ', 'g')) IN ? OR "+
+		"TRIM(REGEXP_REPLACE(LOWER(generic_name), '[^[:alnum:]]+', ' ', 'g')) IN ?", names, names).
+		Order("name").Find(&medications).Error
+	return medications, err
+}
+
+func (mr *medicationRepository) Update(medication *models.Medication) error {
+	return mr.db.Save(medication).Error
+}
@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"gorm.io/gorm"
+)
+
+type NursingNoteRepository interface {
+	Create(note *models.NursingNote) error
+	FindByPatientID(patientID uint, page models.PageQuery) ([]models.NursingNote, int64, error)
+}
+
+type nursingNoteRepository struct {
+	db *gorm.DB
+}
+
+func NewNursingNoteRepository(db *gorm.DB) NursingNoteRepository {
+	return &nursingNoteRepository{db: db}
+}
+
+func (nr *nursingNoteRepository) Create(note *models.NursingNote) error {
+	return nr.db.Create(note).Error
+}
+
+func (nr *nursingNoteRepository) FindByPatientID(patientID uint, page models.PageQuery) ([]models.NursingNote, int64, error) {
+	var notes []models.NursingNote
+	var total int64
+
+	db := nr.db.Model(&models.NursingNote{}).Where("patient_id = ?", patientID)
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := db.Order("created_at DESC").
+		Offset(page.Offset()).Limit(page.PageSize).
+		Find(&notes).Error
+	return notes, total, err
+}
@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"gorm.io/gorm"
+)
+
+type PatientContactRepository interface {
+	Create(contact *models.PatientContact) error
+	FindByID(id uint) (*models.PatientContact, error)
+	FindByPatientID(patientID uint) ([]models.PatientContact, error)
+	Update(contact *models.PatientContact) error
+	Delete(id uint) error
+}
+
+type patientContactRepository struct {
+	db *gorm.DB
+}
+
+func NewPatientContactRepository(db *gorm.DB) PatientContactRepository {
+	return &patientContactRepository{db: db}
+}
+
+func (pr *patientContactRepository) Create(contact *models.PatientContact) error {
+	return pr.db.Create(contact).Error
+}
+
+func (pr *patientContactRepository) FindByID(id uint) (*models.PatientContact, error) {
+	var contact models.PatientContact
+	err := pr.db.First(&contact, id).Error
+	return &contact, err
+}
+
+func (pr *patientContactRepository) FindByPatientID(patientID uint) ([]models.PatientContact, error) {
+	var contacts []models.PatientContact
+	err := pr.db.Where("patient_id = ?", patientID).Order("priority, created_at").Find(&contacts).Error
+	return contacts, err
+}
+
+func (pr *patientContactRepository) Update(contact *models.PatientContact) error {
+	return pr.db.Save(contact).Error
+}
+
+func (pr *patientContactRepository) Delete(id uint) error {
+	return pr.db.Delete(&models.PatientContact{}, id).Error
+}
@@ -0,0 +1,45 @@
+package repositories
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"gorm.io/gorm"
+)
+
+type PatientCoverageRepository interface {
+	Create(coverage *models.PatientCoverage) error
+	FindByID(id uint) (*models.PatientCoverage, error)
+	FindByPatientID(patientID uint) ([]models.PatientCoverage, error)
+	Update(coverage *models.PatientCoverage) error
+}
+
+type patientCoverageRepository struct {
+	db *gorm.DB
+}
+
+func NewPatientCoverageRepository(db *gorm.DB) PatientCoverageRepository {
+	return &patientCoverageRepository{db: db}
+}
+
+func (cr *patientCoverageRepository) Create(coverage *models.PatientCoverage) error {
+	return cr.db.Omit("Plan").Create(coverage).Error
+}
+
+func (cr *patientCoverageRepository) FindByID(id uint) (*models.PatientCoverage, error) {
+	var coverage models.PatientCoverage
+	err := cr.db.Preload("Plan.Payer").First(&coverage, id).Error
+	return &coverage, err
+}
+
+// FindByPatientID lists primary coverages before secondary ones.
+func (cr *patientCoverageRepository) FindByPatientID(patientID uint) ([]models.PatientCoverage, error) {
+	var coverages []models.PatientCoverage
+	err := cr.db.Preload("Plan.Payer").
+		Where("patient_id = ?", patientID).
+		Order("priority, valid_from DESC").
+		Find(&coverages).Error
+	return coverages, err
+}
+
+func (cr *patientCoverageRepository) Update(coverage *models.PatientCoverage) error {
+	return cr.db.Omit("Plan").Save(coverage).Error
+}
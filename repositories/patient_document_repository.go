@@ -0,0 +1,40 @@
+package repositories
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"gorm.io/gorm"
+)
+
+type PatientDocumentRepository interface {
+	Create(document *models.PatientDocument) error
+	FindByID(id uint) (*models.PatientDocument, error)
+	FindByPatientID(patientID uint, category string) ([]models.PatientDocument, error)
+}
+
+type patientDocumentRepository struct {
+	db *gorm.DB
+}
+
+func NewPatientDocumentRepository(db *gorm.DB) PatientDocumentRepository {
+	return &patientDocumentRepository{db: db}
+}
+
+func (dr *patientDocumentRepository) Create(document *models.PatientDocument) error {
+	return dr.db.Create(document).Error
+}
+
+func (dr *patientDocumentRepository) FindByID(id uint) (*models.PatientDocument, error) {
+	var document models.PatientDocument
+	err := dr.db.First(&document, id).Error
+	return &document, err
+}
+
+func (dr *patientDocumentRepository) FindByPatientID(patientID uint, category string) ([]models.PatientDocument, error) {
+	var documents []models.PatientDocument
+	query := dr.db.Where("patient_id = ?", patientID)
+	if category != "" {
+		query = query.Where("category = ?", category)
+	}
+	err := query.Order("created_at DESC").Find(&documents).Error
+	return documents, err
+}
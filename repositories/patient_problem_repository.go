@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"gorm.io/gorm"
+)
+
+type PatientProblemRepository interface {
+	Create(problem *models.PatientProblem) error
+	FindByID(id uint) (*models.PatientProblem, error)
+	FindByPatientID(patientID uint, status string) ([]models.PatientProblem, error)
+	Update(problem *models.PatientProblem) error
+}
+
+type patientProblemRepository struct {
+	db *gorm.DB
+}
+
+func NewPatientProblemRepository(db *gorm.DB) PatientProblemRepository {
+	return &patientProblemRepository{db: db}
+}
+
+func (pr *patientProblemRepository) Create(problem *models.PatientProblem) error {
+	return pr.db.Omit("DiagnosisCode").Create(problem).Error
+}
+
+func (pr *patientProblemRepository) FindByID(id uint) (*models.PatientProblem, error) {
+	var problem models.PatientProblem
+	err := pr.db.Preload("DiagnosisCode").First(&problem, id).Error
+	return &problem, err
+}
+
+// FindByPatientID lists active problems first, then inactive and resolved
+// ones, each by onset date.
+func (pr *patientProblemRepository) FindByPatientID(patientID uint, status string) ([]models.PatientProblem, error) {
+	var problems []models.PatientProblem
+	query := pr.db.Preload("DiagnosisCode").Where("patient_id = ?", patientID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Order("status, onset_date NULLS LAST, id").Find(&problems).Error
+	return problems, err
+}
+
+func (pr *patientProblemRepository) Update(problem *models.PatientProblem) error {
+	return pr.db.Omit("DiagnosisCode").Save(problem).Error
+}
@@ -1,17 +1,67 @@
 package repositories
 
 import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
 	"github.com/ofojichigozie/hms-go-backend/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+const patientFullName = "(first_name || ' ' || last_name)"
+
+var patientSortColumns = map[string]string{
+	"name":         "last_name ASC, first_name ASC",
+	"-name":        "last_name DESC, first_name DESC",
+	"dateOfBirth":  "date_of_birth ASC",
+	"-dateOfBirth": "date_of_birth DESC",
+	"createdAt":    "created_at ASC",
+	"-createdAt":   "created_at DESC",
+}
+
+// patientOwnedTables lists every table whose rows belong to a patient through
+// a patient_id column. Merging moves these rows to the surviving record, so
+// new patient-linked tables must be added here.
+var patientOwnedTables = []string{
+	"appointments",
+	"clinical_notes",
+	"prescriptions",
+	"patient_allergies",
+	"patient_contacts",
+	"patient_coverages",
+	"patient_documents",
+	"patient_problems",
+	"immunizations",
+	"growth_measurements",
+	"admissions",
+	"vital_signs",
+	"nursing_notes",
+	"medication_administrations",
+	"dispensations",
+	"invoices",
+	"payments",
+	"claims",
+	"fee_waivers",
+	"cds_alerts",
+	"lab_orders",
+	"referrals",
+	"audit_logs",
+}
+
 type PatientRepository interface {
 	Create(patient *models.Patient) error
 	FindAll(filters map[string]interface{}) ([]models.Patient, error)
 	FindByID(id uint) (*models.Patient, error)
+	FindSummaryByID(id uint) (*models.Patient, error)
 	FindByRegistrationNumber(regNumber string) (*models.Patient, error)
+	Search(query models.PatientSearchQuery, dateOfBirth *time.Time) ([]models.Patient, int64, error)
+	FindDuplicateCandidates(firstName, lastName string, dateOfBirth time.Time, phone, email string) ([]models.Patient, error)
 	Update(patient *models.Patient) error
 	Delete(id uint) error
+	Merge(survivorID, duplicateID uint, alias *models.PatientAlias, supersededImmunizationIDs []uint) error
 }
 
 type patientRepository struct {
@@ -44,12 +94,101 @@ func (pr *patientRepository) FindByID(id uint) (*models.Patient, error) {
 	return &patient, err
 }
 
+// FindSummaryByID loads the patient with the active problem list.
+func (pr *patientRepository) FindSummaryByID(id uint) (*models.Patient, error) {
+	var patient models.Patient
+	err := pr.db.Preload("Problems", func(db *gorm.DB) *gorm.DB {
+		return db.Where("status = ?", constants.ProblemStatus.ACTIVE).Order("onset_date NULLS LAST, id")
+	}).Preload("Problems.DiagnosisCode").First(&patient, id).Error
+	return &patient, err
+}
+
+// Search matches names by substring or trigram similarity, phones on their
+// digits only, and emails and registration numbers by substring. It returns
+// one page of results together with the total number of matches.
+func (pr *patientRepository) Search(query models.PatientSearchQuery, dateOfBirth *time.Time) ([]models.Patient, int64, error) {
+	var patients []models.Patient
+	var total int64
+
+	db := pr.db.Model(&models.Patient{})
+	if query.Name != "" {
+		db = db.Where(patientFullName+" ILIKE ? OR similarity("+patientFullName+", ?) > 0.3",
+			"%"+query.Name+"%", query.Name)
+	}
+	if query.Phone != "" {
+		db = db.Where("REGEXP_REPLACE(phone_number, '[^0-9]', '', 'g') LIKE ?", "%"+query.Phone+"%")
+	}
+	if dateOfBirth != nil {
+		db = db.Where("date_of_birth::date = ?::date", *dateOfBirth)
+	}
+	if query.Email != "" {
+		db = db.Where("email ILIKE ?", "%"+query.Email+"%")
+	}
+	if query.RegistrationNumber != "" {
+		db = db.Where("registration_number ILIKE ?", "%"+query.RegistrationNumber+"%")
+	}
+	db = db.Session(&gorm.Session{})
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	sort := query.Sort
+	if sort == "" {
+		sort = "relevance"
+	}
+	if sort == "relevance" && query.Name == "" {
+		sort = "-createdAt"
+	}
+	if sort == "relevance" {
+		db = db.Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:  "similarity(" + patientFullName + ", ?) DESC",
+			Vars: []interface{}{query.Name},
+		}})
+	} else {
+		db = db.Order(patientSortColumns[sort])
+	}
+
+	err := db.Order("id ASC").Offset(query.Offset()).Limit(query.PageSize).Find(&patients).Error
+	return patients, total, err
+}
+
+// FindByRegistrationNumber also resolves numbers of records that were merged
+// away, returning the surviving patient.
 func (pr *patientRepository) FindByRegistrationNumber(regNumber string) (*models.Patient, error) {
 	var patient models.Patient
 	err := pr.db.Where("registration_number = ?", regNumber).First(&patient).Error
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return &patient, err
+	}
+
+	var alias models.PatientAlias
+	if err := pr.db.Where("registration_number = ?", regNumber).First(&alias).Error; err != nil {
+		return &patient, err
+	}
+	err = pr.db.First(&patient, alias.PatientID).Error
 	return &patient, err
 }
 
+// FindDuplicateCandidates returns patients sharing any identifying detail
+// with the given one, or whose name is similar. Scoring is left to the caller.
+func (pr *patientRepository) FindDuplicateCandidates(firstName, lastName string, dateOfBirth time.Time,
+	phone, email string) ([]models.Patient, error) {
+	var patients []models.Patient
+
+	query := pr.db.Where("date_of_birth::date = ?::date", dateOfBirth).
+		Or("similarity(first_name || ' ' || last_name, ?) > 0.4", firstName+" "+lastName)
+	if phone != "" {
+		query = query.Or("RIGHT(REGEXP_REPLACE(phone_number, '[^0-9]', '', 'g'), 10) = ?", phone)
+	}
+	if email != "" {
+		query = query.Or("LOWER(email) = ?", strings.ToLower(email))
+	}
+
+	err := query.Limit(50).Find(&patients).Error
+	return patients, err
+}
+
 func (pr *patientRepository) Update(patient *models.Patient) error {
 	return pr.db.Save(patient).Error
 }
@@ -57,3 +196,36 @@ func (pr *patientRepository) Update(patient *models.Patient) error {
 func (pr *patientRepository) Delete(id uint) error {
 	return pr.db.Delete(&models.Patient{}, id).Error
 }
+
+// Merge moves every record owned by the duplicate to the survivor, keeps the
+// duplicate's registration numbers resolving through aliases and soft-deletes
+// the duplicate, all in one transaction. The superseded immunizations are the
+// duplicate's doses the survivor already has; they are soft-deleted first so
+// the survivor still holds each dose once.
+func (pr *patientRepository) Merge(survivorID, duplicateID uint, alias *models.PatientAlias, supersededImmunizationIDs []uint) error {
+	return pr.db.Transaction(func(tx *gorm.DB) error {
+		if len(supersededImmunizationIDs) > 0 {
+			if err := tx.Where("patient_id = ?", duplicateID).
+				Delete(&models.Immunization{}, supersededImmunizationIDs).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, table := range patientOwnedTables {
+			if err := tx.Table(table).Where("patient_id = ?", duplicateID).
+				Update("patient_id", survivorID).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&models.PatientAlias{}).Where("patient_id = ?", duplicateID).
+			Update("patient_id", survivorID).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(alias).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&models.Patient{}, duplicateID).Error
+	})
+}
@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"gorm.io/gorm"
+)
+
+type PayerRepository interface {
+	Create(payer *models.Payer) error
+	FindAll(activeOnly bool) ([]models.Payer, error)
+	FindByID(id uint) (*models.Payer, error)
+	Update(payer *models.Payer) error
+	CreatePlan(plan *models.InsurancePlan) error
+	FindPlanByID(id uint) (*models.InsurancePlan, error)
+	UpdatePlan(plan *models.InsurancePlan) error
+}
+
+type payerRepository struct {
+	db *gorm.DB
+}
+
+func NewPayerRepository(db *gorm.DB) PayerRepository {
+	return &payerRepository{db: db}
+}
+
+func (pr *payerRepository) Create(payer *models.Payer) error {
+	return pr.db.Create(payer).Error
+}
+
+func (pr *payerRepository) FindAll(activeOnly bool) ([]models.Payer, error) {
+	var payers []models.Payer
+	query := pr.db.Preload("Plans", func(db *gorm.DB) *gorm.DB {
+		if activeOnly {
+			db = db.Where("is_active = ?", true)
+		}
+		return db.Order("name")
+	})
+
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+
+	err := query.Order("name").Find(&payers).Error
+	return payers, err
+}
+
+func (pr *payerRepository) FindByID(id uint) (*models.Payer, error) {
+	var payer models.Payer
+	err := pr.db.Preload("Plans", func(db *gorm.DB) *gorm.DB {
+		return db.Order("name")
+	}).First(&payer, id).Error
+	return &payer, err
+}
+
+func (pr *payerRepository) Update(payer *models.Payer) error {
+	return pr.db.Omit("Plans").Save(payer).Error
+}
+
+func (pr *payerRepository) CreatePlan(plan *models.InsurancePlan) error {
+	return pr.db.Omit("Payer").Create(plan).Error
+}
+
+func (pr *payerRepository) FindPlanByID(id uint) (*models.InsurancePlan, error) {
+	var plan models.InsurancePlan
+	err := pr.db.Preload("Payer").First(&plan, id).Error
+	return &plan, err
+}
+
+func (pr *payerRepository) UpdatePlan(plan *models.InsurancePlan) error {
+	return pr.db.Omit("Payer").Save(plan).Error
+}
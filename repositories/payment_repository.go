@@ -0,0 +1,152 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"gorm.io/gorm"
+)
+
+type PaymentRepository interface {
+	Create(payment *models.Payment) error
+	Refund(refund *models.Refund, entry *models.AuditLog) error
+	FindByID(id uint) (*models.Payment, error)
+	FindAll(query models.PaymentQuery) ([]models.Payment, int64, error)
+	FindByShiftID(shiftID uint) ([]models.Payment, error)
+	FindRefundsByShiftID(shiftID uint) ([]models.Refund, error)
+	SumPatientPaid(invoiceID uint) (float64, error)
+}
+
+type paymentRepository struct {
+	db *gorm.DB
+}
+
+func NewPaymentRepository(db *gorm.DB) PaymentRepository {
+	return &paymentRepository{db: db}
+}
+
+// Create records the payment and adds it to the invoice's amount paid in a
+// single transaction. The invoice update is conditional on the payment still
+// fitting the balance, so concurrent payments cannot overpay it.
+func (pr *paymentRepository) Create(payment *models.Payment) error {
+	statuses := constants.InvoiceStatus
+	return pr.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Invoice{}).
+			Where("id = ? AND status IN ? AND amount_paid + ? <= total", payment.InvoiceID,
+				[]string{statuses.ISSUED, statuses.PARTIALLY_PAID}, payment.Amount).
+			Updates(map[string]interface{}{
+				"amount_paid": gorm.Expr("amount_paid + ?", payment.Amount),
+				"status": gorm.Expr("CASE WHEN amount_paid + ? >= total THEN ?::invoice_status ELSE ?::invoice_status END",
+					payment.Amount, statuses.PAID, statuses.PARTIALLY_PAID),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("invoice balance changed while recording the payment, please try again")
+		}
+
+		return tx.Create(payment).Error
+	})
+}
+
+// Refund records the refund and its audit entry and takes it off both the
+// payment and the invoice's amount paid in a single transaction. An invoice
+// with nothing left paid goes back to issued.
+func (pr *paymentRepository) Refund(refund *models.Refund, entry *models.AuditLog) error {
+	statuses := constants.InvoiceStatus
+	return pr.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Payment{}).
+			Where("id = ? AND amount_refunded + ? <= amount", refund.PaymentID, refund.Amount).
+			Update("amount_refunded", gorm.Expr("amount_refunded + ?", refund.Amount))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("payment changed while recording the refund, please try again")
+		}
+
+		result = tx.Model(&models.Invoice{}).
+			Where("id = ? AND amount_paid >= ?", refund.InvoiceID, refund.Amount).
+			Updates(map[string]interface{}{
+				"amount_paid": gorm.Expr("amount_paid - ?", refund.Amount),
+				"status": gorm.Expr("CASE WHEN amount_paid - ? <= 0 THEN ?::invoice_status ELSE ?::invoice_status END",
+					refund.Amount, statuses.ISSUED, statuses.PARTIALLY_PAID),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("invoice changed while recording the refund, please try again")
+		}
+
+		if err := tx.Create(refund).Error; err != nil {
+			return err
+		}
+		return tx.Create(entry).Error
+	})
+}
+
+func (pr *paymentRepository) FindByID(id uint) (*models.Payment, error) {
+	var payment models.Payment
+	err := pr.db.Preload("Refunds", func(db *gorm.DB) *gorm.DB {
+		return db.Order("refunded_at ASC, id ASC")
+	}).First(&payment, id).Error
+	return &payment, err
+}
+
+func (pr *paymentRepository) FindAll(query models.PaymentQuery) ([]models.Payment, int64, error) {
+	var payments []models.Payment
+	var total int64
+
+	db := pr.db.Model(&models.Payment{})
+	if query.InvoiceID != 0 {
+		db = db.Where("invoice_id = ?", query.InvoiceID)
+	}
+	if query.PatientID != 0 {
+		db = db.Where("patient_id = ?", query.PatientID)
+	}
+	if query.ShiftID != 0 {
+		db = db.Where("shift_id = ?", query.ShiftID)
+	}
+	if query.Method != "" {
+		db = db.Where("method = ?", query.Method)
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := db.Order("received_at DESC, id DESC").
+		Offset(query.Offset()).Limit(query.PageSize).
+		Find(&payments).Error
+	return payments, total, err
+}
+
+func (pr *paymentRepository) FindByShiftID(shiftID uint) ([]models.Payment, error) {
+	var payments []models.Payment
+	err := pr.db.Where("shift_id = ?", shiftID).
+		Order("received_at ASC, id ASC").
+		Find(&payments).Error
+	return payments, err
+}
+
+func (pr *paymentRepository) FindRefundsByShiftID(shiftID uint) ([]models.Refund, error) {
+	var refunds []models.Refund
+	err := pr.db.Where("shift_id = ?", shiftID).
+		Order("refunded_at ASC, id ASC").
+		Find(&refunds).Error
+	return refunds, err
+}
+
+// SumPatientPaid totals what the patient has paid on an invoice, net of
+// refunds. Insurance payments are the insurer's and are left out.
+func (pr *paymentRepository) SumPatientPaid(invoiceID uint) (float64, error) {
+	var paid float64
+	err := pr.db.Model(&models.Payment{}).
+		Where("invoice_id = ? AND method <> ?", invoiceID, constants.PaymentMethods.INSURANCE).
+		Select("COALESCE(SUM(amount - amount_refunded), 0)").
+		Scan(&paid).Error
+	return paid, err
+}
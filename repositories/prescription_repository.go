@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"gorm.io/gorm"
+)
+
+type PrescriptionRepository interface {
+	Create(prescription *models.Prescription, writes models.ClinicalRecordWrites) error
+	FindByID(id uint) (*models.Prescription, error)
+	FindByNoteID(noteID uint) ([]models.Prescription, error)
+	FindByPatientID(patientID uint, statuses []string) ([]models.Prescription, error)
+	FindAwaitingDispensing(page models.PageQuery) ([]models.Prescription, int64, error)
+	Update(prescription *models.Prescription) error
+}
+
+type prescriptionRepository struct {
+	db *gorm.DB
+}
+
+func NewPrescriptionRepository(db *gorm.DB) PrescriptionRepository {
+	return &prescriptionRepository{db: db}
+}
+
+// Create saves a new prescription with its alerts and audit entries in one
+// transaction.
+func (pr *prescriptionRepository) Create(prescription *models.Prescription, writes models.ClinicalRecordWrites) error {
+	return pr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Medication").Create(prescription).Error; err != nil {
+			return err
+		}
+		return saveClinicalRecordWrites(tx, prescription.ID, writes)
+	})
+}
+
+func (pr *prescriptionRepository) FindByID(id uint) (*models.Prescription, error) {
+	var prescription models.Prescription
+	err := pr.db.Preload("Medication").First(&prescription, id).Error
+	return &prescription, err
+}
+
+func (pr *prescriptionRepository) FindByNoteID(noteID uint) ([]models.Prescription, error) {
+	var prescriptions []models.Prescription
+	err := pr.db.Preload("Medication").
+		Where("clinical_note_id = ?", noteID).
+		Order("created_at").
+		Find(&prescriptions).Error
+	return prescriptions, err
+}
+
+func (pr *prescriptionRepository) FindByPatientID(patientID uint, statuses []string) ([]models.Prescription, error) {
+	var prescriptions []models.Prescription
+	query := pr.db.Preload("Medication").Where("patient_id = ?", patientID)
+
+	if len(statuses) > 0 {
+		query = query.Where("status IN ?", statuses)
+	}
+
+	err := query.Order("created_at DESC").Find(&prescriptions).Error
+	return prescriptions, err
+}
+
+// FindAwaitingDispensing pages through active prescriptions that still have
+// quantity left to dispense, oldest first.
+func (pr *prescriptionRepository) FindAwaitingDispensing(page models.PageQuery) ([]models.Prescription, int64, error) {
+	var prescriptions []models.Prescription
+	var total int64
+
+	db := pr.db.Model(&models.Prescription{}).
+		Where("status = ? AND quantity_dispensed < quantity", constants.PrescriptionStatus.ACTIVE)
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := db.Preload("Medication").
+		Order("created_at ASC, id ASC").
+		Offset(page.Offset()).Limit(page.PageSize).
+		Find(&prescriptions).Error
+	return prescriptions, total, err
+}
+
+func (pr *prescriptionRepository) Update(prescription *models.Prescription) error {
+	return pr.db.Omit("Medication").Save(prescription).Error
+}
@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"gorm.io/gorm"
+)
+
+type ReferralRepository interface {
+	Create(referral *models.Referral) error
+	FindAll(filters map[string]interface{}) ([]models.Referral, error)
+	FindByID(id uint) (*models.Referral, error)
+	FindWorklist(department string, statuses []string) ([]models.Referral, error)
+	Update(referral *models.Referral) error
+	Accept(referral *models.Referral, appointment *models.Appointment) error
+}
+
+type referralRepository struct {
+	db *gorm.DB
+}
+
+func NewReferralRepository(db *gorm.DB) ReferralRepository {
+	return &referralRepository{db: db}
+}
+
+func (rr *referralRepository) Create(referral *models.Referral) error {
+	return rr.db.Omit("Appointment").Create(referral).Error
+}
+
+func (rr *referralRepository) FindAll(filters map[string]interface{}) ([]models.Referral, error) {
+	var referrals []models.Referral
+	query := rr.db.Preload("Appointment")
+
+	for key, value := range filters {
+		query = query.Where(key+" = ?", value)
+	}
+
+	err := query.Order("created_at DESC").Find(&referrals).Error
+	return referrals, err
+}
+
+func (rr *referralRepository) FindByID(id uint) (*models.Referral, error) {
+	var referral models.Referral
+	err := rr.db.Preload("Appointment").First(&referral, id).Error
+	return &referral, err
+}
+
+// FindWorklist returns the department's referrals in the given statuses,
+// most urgent first and oldest first within the same urgency.
+func (rr *referralRepository) FindWorklist(department string, statuses []string) ([]models.Referral, error) {
+	var referrals []models.Referral
+	err := rr.db.Preload("Appointment").
+		Where("target_department = ? AND status IN ?", department, statuses).
+		Order("CASE urgency WHEN 'emergency' THEN 0 WHEN 'urgent' THEN 1 ELSE 2 END").
+		Order("created_at ASC").
+		Find(&referrals).Error
+	return referrals, err
+}
+
+func (rr *referralRepository) Update(referral *models.Referral) error {
+	return rr.db.Omit("Appointment").Save(referral).Error
+}
+
+// Accept creates the downstream appointment and links it to the referral in
+// both directions within a single transaction.
+func (rr *referralRepository) Accept(referral *models.Referral, appointment *models.Appointment) error {
+	return rr.db.Transaction(func(tx *gorm.DB) error {
+		appointment.ReferralID = &referral.ID
+		if err := tx.Create(appointment).Error; err != nil {
+			return err
+		}
+
+		referral.AppointmentID = &appointment.ID
+		return tx.Omit("Appointment").Save(referral).Error
+	})
+}
@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"gorm.io/gorm"
+)
+
+type SequenceRepository interface {
+	Next(name string) (int64, error)
+}
+
+type sequenceRepository struct {
+	db *gorm.DB
+}
+
+func NewSequenceRepository(db *gorm.DB) SequenceRepository {
+	return &sequenceRepository{db: db}
+}
+
+// Next creates the sequence on first use and returns its incremented value.
+// The upsert takes a row lock, so concurrent callers never share a value.
+func (sr *sequenceRepository) Next(name string) (int64, error) {
+	var sequence models.Sequence
+	err := sr.db.Raw(`INSERT INTO sequences (name, value, updated_at) VALUES (?, 1, NOW())
+		ON CONFLICT (name) DO UPDATE SET value = sequences.value + 1, updated_at = NOW()
+		RETURNING name, value, updated_at`, name).Scan(&sequence).Error
+	return sequence.Value, err
+}
@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"gorm.io/gorm"
+)
+
+type ServicePriceRepository interface {
+	Create(price *models.ServicePrice) error
+	FindAll(query models.ServicePriceQuery) ([]models.ServicePrice, error)
+	FindByID(id uint) (*models.ServicePrice, error)
+	Update(price *models.ServicePrice) error
+}
+
+type servicePriceRepository struct {
+	db *gorm.DB
+}
+
+func NewServicePriceRepository(db *gorm.DB) ServicePriceRepository {
+	return &servicePriceRepository{db: db}
+}
+
+func (sr *servicePriceRepository) Create(price *models.ServicePrice) error {
+	return sr.db.Create(price).Error
+}
+
+func (sr *servicePriceRepository) FindAll(query models.ServicePriceQuery) ([]models.ServicePrice, error) {
+	var prices []models.ServicePrice
+	tx := sr.db.Model(&models.ServicePrice{})
+
+	if query.Category != "" {
+		tx = tx.Where("category = ?", query.Category)
+	}
+	if query.Department != "" {
+		tx = tx.Where("department = ?", query.Department)
+	}
+	if query.Q != "" {
+		pattern := "%" + query.Q + "%"
+		tx = tx.Where("name ILIKE ? OR code ILIKE ?", pattern, pattern)
+	}
+	if query.ActiveOnly {
+		tx = tx.Where("is_active = ?", true)
+	}
+
+	err := tx.Order("category, name").Find(&prices).Error
+	return prices, err
+}
+
+func (sr *servicePriceRepository) FindByID(id uint) (*models.ServicePrice, error) {
+	var price models.ServicePrice
+	err := sr.db.First(&price, id).Error
+	return &price, err
+}
+
+func (sr *servicePriceRepository) Update(price *models.ServicePrice) error {
+	return sr.db.Save(price).Error
+}
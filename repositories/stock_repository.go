@@ -0,0 +1,194 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StockRepository interface {
+	ReceiveGoods(receipt *models.GoodsReceipt) error
+	FindReceipts(page models.PageQuery) ([]models.GoodsReceipt, int64, error)
+	FindReceiptByID(id uint) (*models.GoodsReceipt, error)
+	FindAvailableBatches(medicationID uint, today string) ([]models.StockBatch, error)
+	FindBatchesByMedicationID(medicationID uint) ([]models.StockBatch, error)
+	FindExpiringBatches(before string) ([]models.StockBatch, error)
+	FindStockLevels(today string) ([]models.StockLevel, error)
+	FindLedger(medicationID uint, page models.PageQuery) ([]models.StockLedgerEntry, int64, error)
+}
+
+type stockRepository struct {
+	db *gorm.DB
+}
+
+func NewStockRepository(db *gorm.DB) StockRepository {
+	return &stockRepository{db: db}
+}
+
+// ReceiveGoods adds each line to its batch, creating the batch the first time
+// it is received, and writes the receipt and its ledger movements in a single
+// transaction.
+func (sr *stockRepository) ReceiveGoods(receipt *models.GoodsReceipt) error {
+	return sr.db.Transaction(func(tx *gorm.DB) error {
+		for i := range receipt.Lines {
+			line := &receipt.Lines[i]
+			batchID, err := addToBatch(tx, line)
+			if err != nil {
+				return err
+			}
+			line.StockBatchID = batchID
+		}
+
+		if err := tx.Omit("Lines.Medication").Create(receipt).Error; err != nil {
+			return err
+		}
+
+		for _, line := range receipt.Lines {
+			if err := tx.Create(&models.StockMovement{
+				MedicationID:   line.MedicationID,
+				StockBatchID:   line.StockBatchID,
+				Type:           constants.StockMovementTypes.RECEIPT,
+				Quantity:       line.Quantity,
+				GoodsReceiptID: &receipt.ID,
+				OccurredAt:     receipt.ReceivedAt,
+				StaffID:        receipt.ReceivedBy,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// addToBatch tops up an existing batch or opens a new one. A batch number
+// already on file must arrive with the expiry date it was first recorded with.
+func addToBatch(tx *gorm.DB, line *models.GoodsReceiptLine) (uint, error) {
+	var batch models.StockBatch
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("medication_id = ? AND batch_number = ?", line.MedicationID, line.BatchNumber).
+		First(&batch).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		batch = models.StockBatch{
+			MedicationID:   line.MedicationID,
+			BatchNumber:    line.BatchNumber,
+			ExpiryDate:     line.ExpiryDate,
+			QuantityOnHand: line.Quantity,
+		}
+		err = tx.Create(&batch).Error
+		return batch.ID, err
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if batch.ExpiryDate.Format("2006-01-02") != line.ExpiryDate.Format("2006-01-02") {
+		return 0, fmt.Errorf("batch %s is already recorded with expiry date %s",
+			batch.BatchNumber, batch.ExpiryDate.Format("2006-01-02"))
+	}
+	err = tx.Model(&batch).
+		Update("quantity_on_hand", gorm.Expr("quantity_on_hand + ?", line.Quantity)).Error
+	return batch.ID, err
+}
+
+func (sr *stockRepository) FindReceipts(page models.PageQuery) ([]models.GoodsReceipt, int64, error) {
+	var receipts []models.GoodsReceipt
+	var total int64
+
+	db := sr.db.Model(&models.GoodsReceipt{})
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := db.Preload("Lines").
+		Order("received_at DESC, id DESC").
+		Offset(page.Offset()).Limit(page.PageSize).
+		Find(&receipts).Error
+	return receipts, total, err
+}
+
+func (sr *stockRepository) FindReceiptByID(id uint) (*models.GoodsReceipt, error) {
+	var receipt models.GoodsReceipt
+	err := sr.db.Preload("Lines.Medication").First(&receipt, id).Error
+	return &receipt, err
+}
+
+// FindAvailableBatches returns the medication's unexpired batches with stock,
+// soonest expiry first. today is a YYYY-MM-DD date; a batch can still be
+// dispensed on its expiry date.
+func (sr *stockRepository) FindAvailableBatches(medicationID uint, today string) ([]models.StockBatch, error) {
+	var batches []models.StockBatch
+	err := sr.db.Where("medication_id = ? AND quantity_on_hand > 0 AND expiry_date >= ?", medicationID, today).
+		Order("expiry_date ASC, id ASC").
+		Find(&batches).Error
+	return batches, err
+}
+
+func (sr *stockRepository) FindBatchesByMedicationID(medicationID uint) ([]models.StockBatch, error) {
+	var batches []models.StockBatch
+	err := sr.db.Where("medication_id = ? AND quantity_on_hand > 0", medicationID).
+		Order("expiry_date ASC, id ASC").
+		Find(&batches).Error
+	return batches, err
+}
+
+// FindExpiringBatches returns batches with stock that expire on or before the
+// given YYYY-MM-DD date, including those already expired.
+func (sr *stockRepository) FindExpiringBatches(before string) ([]models.StockBatch, error) {
+	var batches []models.StockBatch
+	err := sr.db.Preload("Medication").
+		Where("quantity_on_hand > 0 AND expiry_date <= ?", before).
+		Order("expiry_date ASC, id ASC").
+		Find(&batches).Error
+	return batches, err
+}
+
+// FindStockLevels totals the stock of every active medication, splitting off
+// batches that expired before today.
+func (sr *stockRepository) FindStockLevels(today string) ([]models.StockLevel, error) {
+	var levels []models.StockLevel
+	err := sr.db.Raw(`
+		SELECT m.id AS medication_id, m.name, m.form, m.strength, m.reorder_level,
+			COALESCE(SUM(b.quantity_on_hand) FILTER (WHERE b.expiry_date >= @today), 0) AS on_hand,
+			COALESCE(SUM(b.quantity_on_hand) FILTER (WHERE b.expiry_date < @today), 0) AS expired,
+			MIN(b.expiry_date) FILTER (WHERE b.expiry_date >= @today) AS next_expiry
+		FROM medications m
+		LEFT JOIN stock_batches b ON b.medication_id = m.id
+			AND b.quantity_on_hand > 0 AND b.deleted_at IS NULL
+		WHERE m.is_active AND m.deleted_at IS NULL
+		GROUP BY m.id
+		ORDER BY m.name ASC, m.form ASC, m.strength ASC`, map[string]interface{}{"today": today}).
+		Scan(&levels).Error
+	return levels, err
+}
+
+// FindLedger pages through the medication's stock movements newest first,
+// each with the running balance of the medication after it.
+func (sr *stockRepository) FindLedger(medicationID uint, page models.PageQuery) ([]models.StockLedgerEntry, int64, error) {
+	var entries []models.StockLedgerEntry
+	var total int64
+
+	if err := sr.db.Model(&models.StockMovement{}).
+		Where("medication_id = ?", medicationID).
+		Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := sr.db.Raw(`
+		SELECT * FROM (
+			SELECT sm.id, sm.stock_batch_id, b.batch_number, sm.type, sm.quantity,
+				SUM(sm.quantity) OVER (ORDER BY sm.occurred_at ASC, sm.id ASC) AS balance,
+				sm.goods_receipt_id, sm.dispensation_id, sm.prescription_id,
+				sm.occurred_at, sm.staff_id
+			FROM stock_movements sm
+			JOIN stock_batches b ON b.id = sm.stock_batch_id
+			WHERE sm.medication_id = ? AND sm.deleted_at IS NULL
+		) ledger
+		ORDER BY occurred_at DESC, id DESC
+		LIMIT ? OFFSET ?`, medicationID, page.PageSize, page.Offset()).
+		Scan(&entries).Error
+	return entries, total, err
+}
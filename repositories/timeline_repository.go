@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"strings"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"gorm.io/gorm"
+)
+
+// timelineSources selects each event type into the common timeline columns.
+// A new source only needs an entry here and a constant in TimelineEventTypes.
+var timelineSources = map[string]string{
+	constants.TimelineEventTypes.APPOINTMENT: `SELECT 'appointment' AS type, scheduled_at AS occurred_at,
+		'appointment' AS entity_type, id AS entity_id, doctor_id AS staff_id,
+		INITCAP(department::text) || ' appointment (' || REPLACE(status::text, '_', ' ') || ')' AS summary,
+		reason AS details
+		FROM appointments WHERE patient_id = @patientID AND deleted_at IS NULL`,
+	constants.TimelineEventTypes.CLINICAL_NOTE: `SELECT 'clinical_note' AS type, created_at AS occurred_at,
+		'clinical_note' AS entity_type, id AS entity_id, doctor_id AS staff_id,
+		COALESCE(NULLIF(clinical_diagnosis, ''), presenting_complaints) AS summary,
+		treatment_plan AS details
+		FROM clinical_notes WHERE patient_id = @patientID AND deleted_at IS NULL`,
+	constants.TimelineEventTypes.STATUS_CHANGE: `SELECT 'status_change' AS type, created_at AS occurred_at,
+		entity_type, entity_id, staff_id, action AS summary, details
+		FROM audit_logs WHERE patient_id = @patientID AND action = @statusChangeAction`,
+	constants.TimelineEventTypes.DEMOGRAPHIC_EDIT: `SELECT 'demographic_edit' AS type, created_at AS occurred_at,
+		entity_type, entity_id, staff_id, action AS summary, details
+		FROM audit_logs WHERE patient_id = @patientID AND action = @demographicEditAction`,
+	constants.TimelineEventTypes.VITAL_SIGNS: `SELECT 'vital_signs' AS type, recorded_at AS occurred_at,
+		'vital_signs' AS entity_type, id AS entity_id, recorded_by AS staff_id,
+		COALESCE(NULLIF(CONCAT_WS(', ', 'T ' || temperature_c || ' C', 'HR ' || pulse_rate,
+			'RR ' || respiratory_rate, 'BP ' || systolic_bp || '/' || diastolic_bp,
+			'SpO2 ' || oxygen_saturation || '%', 'Pain ' || pain_score || '/10'), ''), 'Vital signs') AS summary,
+		notes AS details
+		FROM vital_signs WHERE patient_id = @patientID AND deleted_at IS NULL`,
+	constants.TimelineEventTypes.NURSING_NOTE: `SELECT 'nursing_note' AS type, created_at AS occurred_at,
+		'nursing_note' AS entity_type, id AS entity_id, nurse_id AS staff_id,
+		'Nursing note' AS summary, note AS details
+		FROM nursing_notes WHERE patient_id = @patientID AND deleted_at IS NULL`,
+}
+
+type TimelineRepository interface {
+	FindByPatientID(patientID uint, filter models.TimelineFilter, page models.PageQuery) ([]models.TimelineEvent, int64, error)
+}
+
+type timelineRepository struct {
+	db *gorm.DB
+}
+
+func NewTimelineRepository(db *gorm.DB) TimelineRepository {
+	return &timelineRepository{db: db}
+}
+
+// FindByPatientID merges every requested source into one feed, newest first,
+// so paging works across event types.
+func (tr *timelineRepository) FindByPatientID(patientID uint, filter models.TimelineFilter, page models.PageQuery) ([]models.TimelineEvent, int64, error) {
+	var events []models.TimelineEvent
+	var total int64
+
+	var selects []string
+	for _, eventType := range filter.Types {
+		if source, ok := timelineSources[eventType]; ok {
+			selects = append(selects, source)
+		}
+	}
+	if len(selects) == 0 {
+		return events, 0, nil
+	}
+
+	args := map[string]interface{}{
+		"patientID":             patientID,
+		"statusChangeAction":    constants.AuditActions.APPOINTMENT_STATUS_CHANGE,
+		"demographicEditAction": constants.AuditActions.PATIENT_UPDATE,
+		"limit":                 page.PageSize,
+		"offset":                page.Offset(),
+	}
+	var conditions []string
+	if filter.From != nil {
+		conditions = append(conditions, "occurred_at >= @from")
+		args["from"] = *filter.From
+	}
+	if filter.To != nil {
+		conditions = append(conditions, "occurred_at < @to")
+		args["to"] = *filter.To
+	}
+
+	timeline := "(" + strings.Join(selects, " UNION ALL ") + ") AS timeline"
+	if len(conditions) > 0 {
+		timeline += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	if err := tr.db.Raw("SELECT COUNT(*) FROM "+timeline, args).Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := tr.db.Raw("SELECT * FROM "+timeline+
+		" ORDER BY occurred_at DESC, type, entity_id DESC LIMIT @limit OFFSET @offset", args).
+		Scan(&events).Error
+	return events, total, err
+}
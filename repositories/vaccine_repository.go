@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"gorm.io/gorm"
+)
+
+type VaccineRepository interface {
+	Create(vaccine *models.Vaccine) error
+	FindAll(activeOnly bool) ([]models.Vaccine, error)
+	FindByID(id uint) (*models.Vaccine, error)
+	Update(vaccine *models.Vaccine) error
+	ReplaceSchedule(vaccineID uint, doses []models.VaccineScheduleDose) error
+}
+
+type vaccineRepository struct {
+	db *gorm.DB
+}
+
+func NewVaccineRepository(db *gorm.DB) VaccineRepository {
+	return &vaccineRepository{db: db}
+}
+
+func (vr *vaccineRepository) Create(vaccine *models.Vaccine) error {
+	return vr.db.Create(vaccine).Error
+}
+
+func (vr *vaccineRepository) FindAll(activeOnly bool) ([]models.Vaccine, error) {
+	var vaccines []models.Vaccine
+	query := vr.db.Preload("Doses", func(db *gorm.DB) *gorm.DB {
+		return db.Order("dose_number")
+	})
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+	err := query.Order("code").Find(&vaccines).Error
+	return vaccines, err
+}
+
+func (vr *vaccineRepository) FindByID(id uint) (*models.Vaccine, error) {
+	var vaccine models.Vaccine
+	err := vr.db.Preload("Doses", func(db *gorm.DB) *gorm.DB {
+		return db.Order("dose_number")
+	}).First(&vaccine, id).Error
+	return &vaccine, err
+}
+
+func (vr *vaccineRepository) Update(vaccine *models.Vaccine) error {
+	return vr.db.Omit("Doses").Save(vaccine).Error
+}
+
+// ReplaceSchedule swaps a vaccine's doses in one transaction. Old doses are
+// removed outright so their dose numbers can be reused.
+func (vr *vaccineRepository) ReplaceSchedule(vaccineID uint, doses []models.VaccineScheduleDose) error {
+	return vr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("vaccine_id = ?", vaccineID).Delete(&models.VaccineScheduleDose{}).Error; err != nil {
+			return err
+		}
+		if len(doses) == 0 {
+			return nil
+		}
+		return tx.Create(&doses).Error
+	})
+}
@@ -0,0 +1,48 @@
+package repositories
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"gorm.io/gorm"
+)
+
+type VitalSignRepository interface {
+	Create(vitals *models.VitalSign) error
+	FindByPatientID(patientID uint, page models.PageQuery) ([]models.VitalSign, int64, error)
+	FindLatestByAdmissionID(admissionID uint) (*models.VitalSign, error)
+}
+
+type vitalSignRepository struct {
+	db *gorm.DB
+}
+
+func NewVitalSignRepository(db *gorm.DB) VitalSignRepository {
+	return &vitalSignRepository{db: db}
+}
+
+func (vr *vitalSignRepository) Create(vitals *models.VitalSign) error {
+	return vr.db.Create(vitals).Error
+}
+
+// FindByPatientID returns the patient's observations newest first.
+func (vr *vitalSignRepository) FindByPatientID(patientID uint, page models.PageQuery) ([]models.VitalSign, int64, error) {
+	var vitals []models.VitalSign
+	var total int64
+
+	db := vr.db.Model(&models.VitalSign{}).Where("patient_id = ?", patientID)
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := db.Order("recorded_at DESC, id DESC").
+		Offset(page.Offset()).Limit(page.PageSize).
+		Find(&vitals).Error
+	return vitals, total, err
+}
+
+func (vr *vitalSignRepository) FindLatestByAdmissionID(admissionID uint) (*models.VitalSign, error) {
+	var vitals models.VitalSign
+	err := vr.db.Where("admission_id = ?", admissionID).
+		Order("recorded_at DESC, id DESC").
+		First(&vitals).Error
+	return &vitals, err
+}
@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"gorm.io/gorm"
+)
+
+type WardRepository interface {
+	Create(ward *models.Ward) error
+	FindAll(activeOnly bool) ([]models.Ward, error)
+	FindByID(id uint) (*models.Ward, error)
+	Update(ward *models.Ward) error
+	CreateBed(bed *models.Bed) error
+	FindBedByID(id uint) (*models.Bed, error)
+	UpdateBedStatus(bed *models.Bed) error
+	FindOccupancy(wardID uint) ([]models.BedOccupancy, error)
+}
+
+type wardRepository struct {
+	db *gorm.DB
+}
+
+func NewWardRepository(db *gorm.DB) WardRepository {
+	return &wardRepository{db: db}
+}
+
+func (wr *wardRepository) Create(ward *models.Ward) error {
+	return wr.db.Create(ward).Error
+}
+
+func (wr *wardRepository) FindAll(activeOnly bool) ([]models.Ward, error) {
+	var wards []models.Ward
+	query := wr.db
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+	err := query.Order("name ASC").Find(&wards).Error
+	return wards, err
+}
+
+func (wr *wardRepository) FindByID(id uint) (*models.Ward, error) {
+	var ward models.Ward
+	err := wr.db.Preload("Beds", func(db *gorm.DB) *gorm.DB {
+		return db.Order("label ASC")
+	}).First(&ward, id).Error
+	return &ward, err
+}
+
+func (wr *wardRepository) Update(ward *models.Ward) error {
+	return wr.db.Omit("Beds").Save(ward).Error
+}
+
+func (wr *wardRepository) CreateBed(bed *models.Bed) error {
+	return wr.db.Create(bed).Error
+}
+
+func (wr *wardRepository) FindBedByID(id uint) (*models.Bed, error) {
+	var bed models.Bed
+	err := wr.db.First(&bed, id).Error
+	return &bed, err
+}
+
+// UpdateBedStatus sets the housekeeping status of a bed, refusing to touch a
+// bed that an admission has occupied in the meantime.
+func (wr *wardRepository) UpdateBedStatus(bed *models.Bed) error {
+	result := wr.db.Model(&models.Bed{}).
+		Where("id = ? AND status <> ?", bed.ID, constants.BedStatus.OCCUPIED).
+		Update("status", bed.Status)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("bed is occupied")
+	}
+	return nil
+}
+
+// FindOccupancy lists the ward's beds in label order with the patient
+// currently admitted to each.
+func (wr *wardRepository) FindOccupancy(wardID uint) ([]models.BedOccupancy, error) {
+	var beds []models.BedOccupancy
+	err := wr.db.Raw(`
+		SELECT b.id AS bed_id, b.label, b.status,
+			a.id AS admission_id, a.patient_id, a.admitted_at,
+			COALESCE(p.first_name || ' ' || p.last_name, '') AS patient_name
+		FROM beds b
+		LEFT JOIN admissions a ON a.bed_id = b.id AND a.status = ? AND a.deleted_at IS NULL
+		LEFT JOIN patients p ON p.id = a.patient_id
+		WHERE b.ward_id = ? AND b.deleted_at IS NULL
+		ORDER BY b.label ASC`, constants.AdmissionStatus.ADMITTED, wardID).
+		Scan(&beds).Error
+	return beds, err
+}
@@ -0,0 +1,42 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/controllers"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+	"github.com/ofojichigozie/hms-go-backend/services"
+	"gorm.io/gorm"
+)
+
+func AdmissionRoutes(r *gin.Engine, DB *gorm.DB) {
+	admissionRepository := repositories.NewAdmissionRepository(DB)
+	wardRepository := repositories.NewWardRepository(DB)
+	appointmentRepository := repositories.NewAppointmentRepository(DB)
+	referralRepository := repositories.NewReferralRepository(DB)
+	admissionService := services.NewAdmissionService(admissionRepository, wardRepository,
+		appointmentRepository, referralRepository)
+	admissionController := controllers.NewAdmissionController(admissionService)
+
+	roles := constants.Roles
+
+	admissionGroup := r.Group("/admissions")
+	admissionGroup.Use(middleware.AuthMiddleware())
+	{
+		doctorRoutes := admissionGroup.Group("")
+		doctorRoutes.Use(middleware.RoleMiddleware([]string{roles.DOCTOR}))
+		{
+			doctorRoutes.POST("", admissionController.AdmitPatient)
+			doctorRoutes.POST("/:id/discharge", admissionController.DischargePatient)
+		}
+
+		staffRoutes := admissionGroup.Group("")
+		staffRoutes.Use(middleware.RoleMiddleware([]string{roles.DOCTOR, roles.RECEPTIONIST, roles.NURSE}))
+		{
+			staffRoutes.GET("", admissionController.GetAdmissions)
+			staffRoutes.GET("/:id", admissionController.GetAdmissionByID)
+			staffRoutes.POST("/:id/transfer", admissionController.TransferPatient)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/controllers"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+	"github.com/ofojichigozie/hms-go-backend/services"
+	"gorm.io/gorm"
+)
+
+func AllergyRoutes(r *gin.Engine, DB *gorm.DB) {
+	allergyRepository := repositories.NewAllergyRepository(DB)
+	patientRepository := repositories.NewPatientRepository(DB)
+	allergyService := services.NewAllergyService(allergyRepository, patientRepository)
+	allergyController := controllers.NewAllergyController(allergyService)
+
+	roles := constants.Roles
+
+	allergyGroup := r.Group("/patients/:id/allergies")
+	allergyGroup.Use(middleware.AuthMiddleware())
+	allergyGroup.Use(middleware.RoleMiddleware([]string{roles.DOCTOR, roles.RECEPTIONIST, roles.NURSE}))
+	{
+		allergyGroup.GET("", allergyController.GetAllergies)
+		allergyGroup.POST("", allergyController.CreateAllergy)
+		allergyGroup.PATCH("/:allergyId", allergyController.UpdateAllergy)
+	}
+}
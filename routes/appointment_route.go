@@ -13,7 +13,10 @@ import (
 func AppointmentRoutes(r *gin.Engine, DB *gorm.DB) {
 	patientRepository := repositories.NewPatientRepository(DB)
 	appointmentRepository := repositories.NewAppointmentRepository(DB)
-	appointmentService := services.NewAppointmentService(appointmentRepository, patientRepository)
+	patientCoverageRepository := repositories.NewPatientCoverageRepository(DB)
+	auditLogRepository := repositories.NewAuditLogRepository(DB)
+	appointmentService := services.NewAppointmentService(appointmentRepository, patientRepository,
+		patientCoverageRepository, auditLogRepository)
 	appointmentController := controllers.NewAppointmentController(appointmentService)
 
 	roles := constants.Roles
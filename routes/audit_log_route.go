@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/controllers"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+	"github.com/ofojichigozie/hms-go-backend/services"
+	"gorm.io/gorm"
+)
+
+func AuditLogRoutes(r *gin.Engine, DB *gorm.DB) {
+	auditLogRepository := repositories.NewAuditLogRepository(DB)
+	auditLogService := services.NewAuditLogService(auditLogRepository)
+	auditLogController := controllers.NewAuditLogController(auditLogService)
+
+	roles := constants.Roles
+
+	auditLogGroup := r.Group("/audit-logs")
+	auditLogGroup.Use(middleware.AuthMiddleware())
+	auditLogGroup.Use(middleware.RoleMiddleware([]string{roles.ADMIN}))
+	{
+		auditLogGroup.GET("", auditLogController.GetAuditLogs)
+	}
+}
@@ -0,0 +1,55 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/controllers"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+	"github.com/ofojichigozie/hms-go-backend/services"
+	"gorm.io/gorm"
+)
+
+func CdsRoutes(r *gin.Engine, DB *gorm.DB) {
+	cdsRuleRepository := repositories.NewCdsRuleRepository(DB)
+	cdsAlertRepository := repositories.NewCdsAlertRepository(DB)
+	cdsService := services.NewCdsService(cdsRuleRepository, cdsAlertRepository)
+	cdsController := controllers.NewCdsController(cdsService)
+
+	roles := constants.Roles
+
+	ruleGroup := r.Group("/cds-rules")
+	ruleGroup.Use(middleware.AuthMiddleware())
+	{
+		adminRoutes := ruleGroup.Group("")
+		adminRoutes.Use(middleware.RoleMiddleware([]string{roles.ADMIN}))
+		{
+			adminRoutes.POST("", cdsController.CreateRule)
+			adminRoutes.PATCH("/:id", cdsController.UpdateRule)
+		}
+
+		staffRoutes := ruleGroup.Group("")
+		staffRoutes.Use(middleware.RoleMiddleware([]string{roles.ADMIN, roles.DOCTOR, roles.NURSE, roles.PHARMACIST}))
+		{
+			staffRoutes.GET("", cdsController.GetRules)
+			staffRoutes.GET("/:id", cdsController.GetRuleByID)
+		}
+	}
+
+	alertGroup := r.Group("/cds-alerts")
+	alertGroup.Use(middleware.AuthMiddleware())
+	{
+		clinicalRoutes := alertGroup.Group("")
+		clinicalRoutes.Use(middleware.RoleMiddleware([]string{roles.DOCTOR, roles.NURSE, roles.PHARMACIST}))
+		{
+			clinicalRoutes.POST("/:id/acknowledge", cdsController.AcknowledgeAlert)
+		}
+
+		staffRoutes := alertGroup.Group("")
+		staffRoutes.Use(middleware.RoleMiddleware([]string{roles.ADMIN, roles.DOCTOR, roles.NURSE, roles.PHARMACIST}))
+		{
+			staffRoutes.GET("", cdsController.GetAlerts)
+			staffRoutes.GET("/:id", cdsController.GetAlertByID)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package routes
+
+import (
+	"os"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/controllers"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+	"github.com/ofojichigozie/hms-go-backend/services"
+	"gorm.io/gorm"
+)
+
+func ClaimRoutes(r *gin.Engine, DB *gorm.DB) {
+	claimRepository := repositories.NewClaimRepository(DB)
+	claimLayoutRepository := repositories.NewClaimLayoutRepository(DB)
+	payerRepository := repositories.NewPayerRepository(DB)
+	patientRepository := repositories.NewPatientRepository(DB)
+	patientCoverageRepository := repositories.NewPatientCoverageRepository(DB)
+	sequenceRepository := repositories.NewSequenceRepository(DB)
+	claimService := services.NewClaimService(claimRepository, claimLayoutRepository, payerRepository,
+		patientRepository, patientCoverageRepository, sequenceRepository, os.Getenv("BRANCH_CODE"))
+	claimController := controllers.NewClaimController(claimService)
+
+	roles := constants.Roles
+
+	claimBatchGroup := r.Group("/claim-batches")
+	claimBatchGroup.Use(middleware.AuthMiddleware())
+	claimBatchGroup.Use(middleware.RoleMiddleware([]string{roles.ADMIN, roles.RECEPTIONIST}))
+	{
+		claimBatchGroup.POST("", claimController.GenerateBatch)
+		claimBatchGroup.GET("", claimController.GetBatches)
+		claimBatchGroup.GET("/:id", claimController.GetBatchByID)
+		claimBatchGroup.GET("/:id/export", claimController.ExportBatch)
+		claimBatchGroup.POST("/:id/submit", claimController.SubmitBatch)
+		claimBatchGroup.POST("/:id/remittance", claimController.ImportRemittance)
+	}
+
+	claimLayoutGroup := r.Group("/payers/:id/claim-layout")
+	claimLayoutGroup.Use(middleware.AuthMiddleware())
+	claimLayoutGroup.Use(middleware.RoleMiddleware([]string{roles.ADMIN}))
+	{
+		claimLayoutGroup.GET("", claimController.GetClaimLayout)
+		claimLayoutGroup.PUT("", claimController.SaveClaimLayout)
+	}
+}
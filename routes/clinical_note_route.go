@@ -14,8 +14,19 @@ func ClinicalNoteRoutes(r *gin.Engine, DB *gorm.DB) {
 	clinicalNoteRepository := repositories.NewClinicalNoteRepository(DB)
 	appointmentRepository := repositories.NewAppointmentRepository(DB)
 	patientRepository := repositories.NewPatientRepository(DB)
+	diagnosisCodeRepository := repositories.NewDiagnosisCodeRepository(DB)
+	allergyRepository := repositories.NewAllergyRepository(DB)
+	medicationRepository := repositories.NewMedicationRepository(DB)
+	admissionRepository := repositories.NewAdmissionRepository(DB)
+	cdsRuleRepository := repositories.NewCdsRuleRepository(DB)
+	cdsAlertRepository := repositories.NewCdsAlertRepository(DB)
+	vitalSignRepository := repositories.NewVitalSignRepository(DB)
+	growthMeasurementRepository := repositories.NewGrowthMeasurementRepository(DB)
+	decisionSupport := services.NewDecisionSupport(cdsRuleRepository, cdsAlertRepository,
+		patientRepository, vitalSignRepository, growthMeasurementRepository)
 	noteService := services.NewClinicalNoteService(clinicalNoteRepository,
-		appointmentRepository, patientRepository)
+		appointmentRepository, patientRepository, diagnosisCodeRepository,
+		allergyRepository, medicationRepository, admissionRepository, decisionSupport)
 	noteController := controllers.NewClinicalNoteController(noteService)
 
 	roles := constants.Roles
@@ -32,7 +43,7 @@ func ClinicalNoteRoutes(r *gin.Engine, DB *gorm.DB) {
 		}
 
 		staffRoutes := noteGroup.Group("")
-		staffRoutes.Use(middleware.RoleMiddleware([]string{roles.DOCTOR, roles.RECEPTIONIST}))
+		staffRoutes.Use(middleware.RoleMiddleware([]string{roles.DOCTOR, roles.RECEPTIONIST, roles.NURSE}))
 		{
 			staffRoutes.GET("/:id", noteController.GetNoteByID)
 			staffRoutes.GET("/patient/:patientId", noteController.GetNotesByPatientID)
@@ -0,0 +1,36 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/controllers"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+	"github.com/ofojichigozie/hms-go-backend/services"
+	"gorm.io/gorm"
+)
+
+func DiagnosisCodeRoutes(r *gin.Engine, DB *gorm.DB) {
+	diagnosisCodeRepository := repositories.NewDiagnosisCodeRepository(DB)
+	diagnosisCodeService := services.NewDiagnosisCodeService(diagnosisCodeRepository)
+	diagnosisCodeController := controllers.NewDiagnosisCodeController(diagnosisCodeService)
+
+	roles := constants.Roles
+
+	diagnosisCodeGroup := r.Group("/diagnosis-codes")
+	diagnosisCodeGroup.Use(middleware.AuthMiddleware())
+	{
+		adminRoutes := diagnosisCodeGroup.Group("")
+		adminRoutes.Use(middleware.RoleMiddleware([]string{roles.ADMIN}))
+		{
+			adminRoutes.POST("/import", diagnosisCodeController.ImportCSV)
+		}
+
+		staffRoutes := diagnosisCodeGroup.Group("")
+		staffRoutes.Use(middleware.RoleMiddleware([]string{roles.DOCTOR, roles.RECEPTIONIST}))
+		{
+			staffRoutes.GET("", diagnosisCodeController.SearchCodes)
+			staffRoutes.GET("/:code", diagnosisCodeController.GetByCode)
+		}
+	}
+}
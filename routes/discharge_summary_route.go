@@ -0,0 +1,48 @@
+package routes
+
+import (
+	"log"
+	"os"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/controllers"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+	"github.com/ofojichigozie/hms-go-backend/services"
+	"github.com/ofojichigozie/hms-go-backend/templates"
+	"gorm.io/gorm"
+)
+
+func DischargeSummaryRoutes(r *gin.Engine, DB *gorm.DB) {
+	summaryTemplate, err := templates.LoadDischargeSummary(os.Getenv("DISCHARGE_SUMMARY_TEMPLATE"))
+	if err != nil {
+		log.Fatalf("Discharge summary template is invalid: %v", err)
+	}
+
+	clinicalNoteRepository := repositories.NewClinicalNoteRepository(DB)
+	admissionRepository := repositories.NewAdmissionRepository(DB)
+	prescriptionRepository := repositories.NewPrescriptionRepository(DB)
+	appointmentRepository := repositories.NewAppointmentRepository(DB)
+	patientRepository := repositories.NewPatientRepository(DB)
+	staffRepository := repositories.NewStaffRepository(DB)
+	summaryService := services.NewDischargeSummaryService(clinicalNoteRepository, admissionRepository,
+		prescriptionRepository, appointmentRepository, patientRepository, staffRepository)
+	summaryController := controllers.NewDischargeSummaryController(summaryService, summaryTemplate)
+
+	roles := constants.Roles
+
+	noteGroup := r.Group("/clinical-notes")
+	noteGroup.Use(middleware.AuthMiddleware())
+	noteGroup.Use(middleware.RoleMiddleware([]string{roles.DOCTOR, roles.NURSE, roles.RECEPTIONIST}))
+	{
+		noteGroup.GET("/:id/summary.pdf", summaryController.GetNoteSummary)
+	}
+
+	admissionGroup := r.Group("/admissions")
+	admissionGroup.Use(middleware.AuthMiddleware())
+	admissionGroup.Use(middleware.RoleMiddleware([]string{roles.DOCTOR, roles.NURSE, roles.RECEPTIONIST}))
+	{
+		admissionGroup.GET("/:id/summary.pdf", summaryController.GetAdmissionSummary)
+	}
+}
@@ -0,0 +1,47 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/controllers"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+	"github.com/ofojichigozie/hms-go-backend/services"
+	"gorm.io/gorm"
+)
+
+func FeeWaiverRoutes(r *gin.Engine, DB *gorm.DB) {
+	feeWaiverRepository := repositories.NewFeeWaiverRepository(DB)
+	invoiceRepository := repositories.NewInvoiceRepository(DB)
+	paymentRepository := repositories.NewPaymentRepository(DB)
+	feeWaiverService := services.NewFeeWaiverService(feeWaiverRepository, invoiceRepository,
+		paymentRepository, services.WaiverThresholdsFromEnv())
+	feeWaiverController := controllers.NewFeeWaiverController(feeWaiverService)
+
+	roles := constants.Roles
+
+	feeWaiverGroup := r.Group("/fee-waivers")
+	feeWaiverGroup.Use(middleware.AuthMiddleware())
+	{
+		requesterRoutes := feeWaiverGroup.Group("")
+		requesterRoutes.Use(middleware.RoleMiddleware([]string{roles.RECEPTIONIST, roles.CASHIER}))
+		{
+			requesterRoutes.POST("", feeWaiverController.RequestWaiver)
+			requesterRoutes.POST("/:id/cancel", feeWaiverController.CancelWaiver)
+		}
+
+		approverRoutes := feeWaiverGroup.Group("")
+		approverRoutes.Use(middleware.RoleMiddleware([]string{roles.ADMIN, roles.FINANCE_MANAGER}))
+		{
+			approverRoutes.POST("/:id/approve", feeWaiverController.ApproveWaiver)
+			approverRoutes.POST("/:id/reject", feeWaiverController.RejectWaiver)
+		}
+
+		staffRoutes := feeWaiverGroup.Group("")
+		staffRoutes.Use(middleware.RoleMiddleware([]string{roles.ADMIN, roles.FINANCE_MANAGER, roles.RECEPTIONIST, roles.CASHIER}))
+		{
+			staffRoutes.GET("", feeWaiverController.GetWaivers)
+			staffRoutes.GET("/:id", feeWaiverController.GetWaiverByID)
+		}
+	}
+}
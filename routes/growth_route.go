@@ -0,0 +1,36 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/controllers"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+	"github.com/ofojichigozie/hms-go-backend/services"
+	"gorm.io/gorm"
+)
+
+func GrowthRoutes(r *gin.Engine, DB *gorm.DB) {
+	growthMeasurementRepository := repositories.NewGrowthMeasurementRepository(DB)
+	patientRepository := repositories.NewPatientRepository(DB)
+	growthService := services.NewGrowthService(growthMeasurementRepository, patientRepository)
+	growthController := controllers.NewGrowthController(growthService)
+
+	roles := constants.Roles
+
+	growthGroup := r.Group("/patients/:id/growth")
+	growthGroup.Use(middleware.AuthMiddleware())
+	{
+		doctorRoutes := growthGroup.Group("")
+		doctorRoutes.Use(middleware.RoleMiddleware([]string{roles.DOCTOR}))
+		{
+			doctorRoutes.POST("", growthController.RecordMeasurement)
+		}
+
+		staffRoutes := growthGroup.Group("")
+		staffRoutes.Use(middleware.RoleMiddleware([]string{roles.DOCTOR, roles.RECEPTIONIST, roles.NURSE}))
+		{
+			staffRoutes.GET("", growthController.GetGrowthChart)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/controllers"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+	"github.com/ofojichigozie/hms-go-backend/services"
+	"gorm.io/gorm"
+)
+
+func ImmunizationRoutes(r *gin.Engine, DB *gorm.DB) {
+	immunizationRepository := repositories.NewImmunizationRepository(DB)
+	vaccineRepository := repositories.NewVaccineRepository(DB)
+	patientRepository := repositories.NewPatientRepository(DB)
+	immunizationService := services.NewImmunizationService(immunizationRepository, vaccineRepository, patientRepository)
+	immunizationController := controllers.NewImmunizationController(immunizationService)
+
+	roles := constants.Roles
+
+	patientImmunizationGroup := r.Group("/patients/:id/immunizations")
+	patientImmunizationGroup.Use(middleware.AuthMiddleware())
+	{
+		doctorRoutes := patientImmunizationGroup.Group("")
+		doctorRoutes.Use(middleware.RoleMiddleware([]string{roles.DOCTOR}))
+		{
+			doctorRoutes.POST("", immunizationController.RecordImmunization)
+		}
+
+		staffRoutes := patientImmunizationGroup.Group("")
+		staffRoutes.Use(middleware.RoleMiddleware([]string{roles.DOCTOR, roles.RECEPTIONIST, roles.NURSE}))
+		{
+			staffRoutes.GET("", immunizationController.GetImmunizations)
+			staffRoutes.GET("/schedule", immunizationController.GetSchedule)
+		}
+	}
+
+	immunizationGroup := r.Group("/immunizations")
+	immunizationGroup.Use(middleware.AuthMiddleware())
+	immunizationGroup.Use(middleware.RoleMiddleware([]string{roles.DOCTOR, roles.RECEPTIONIST, roles.NURSE}))
+	{
+		immunizationGroup.GET("/overdue", immunizationController.GetOverdue)
+	}
+}
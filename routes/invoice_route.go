@@ -0,0 +1,64 @@
+package routes
+
+import (
+	"os"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/controllers"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+	"github.com/ofojichigozie/hms-go-backend/services"
+	"gorm.io/gorm"
+)
+
+func InvoiceRoutes(r *gin.Engine, DB *gorm.DB) {
+	invoiceRepository := repositories.NewInvoiceRepository(DB)
+	servicePriceRepository := repositories.NewServicePriceRepository(DB)
+	sequenceRepository := repositories.NewSequenceRepository(DB)
+	appointmentRepository := repositories.NewAppointmentRepository(DB)
+	clinicalNoteRepository := repositories.NewClinicalNoteRepository(DB)
+	prescriptionRepository := repositories.NewPrescriptionRepository(DB)
+	labOrderRepository := repositories.NewLabOrderRepository(DB)
+	patientCoverageRepository := repositories.NewPatientCoverageRepository(DB)
+	invoiceService := services.NewInvoiceService(invoiceRepository, servicePriceRepository,
+		sequenceRepository, appointmentRepository, clinicalNoteRepository, prescriptionRepository,
+		labOrderRepository, patientCoverageRepository, services.NewLocalEligibilityChecker(),
+		os.Getenv("BRANCH_CODE"))
+	invoiceController := controllers.NewInvoiceController(invoiceService)
+
+	roles := constants.Roles
+
+	invoiceGroup := r.Group("/invoices")
+	invoiceGroup.Use(middleware.AuthMiddleware())
+	{
+		adminRoutes := invoiceGroup.Group("")
+		adminRoutes.Use(middleware.RoleMiddleware([]string{roles.ADMIN}))
+		{
+			adminRoutes.POST("/:id/void", invoiceController.VoidInvoice)
+		}
+
+		financeRoutes := invoiceGroup.Group("")
+		financeRoutes.Use(middleware.RoleMiddleware([]string{roles.ADMIN, roles.FINANCE_MANAGER}))
+		{
+			financeRoutes.POST("/:id/lines/:lineId/discount", invoiceController.DiscountLine)
+		}
+
+		staffRoutes := invoiceGroup.Group("")
+		staffRoutes.Use(middleware.RoleMiddleware([]string{roles.ADMIN, roles.RECEPTIONIST}))
+		{
+			staffRoutes.POST("", invoiceController.CreateInvoice)
+			staffRoutes.POST("/:id/lines", invoiceController.AddLine)
+			staffRoutes.PATCH("/:id/lines/:lineId", invoiceController.UpdateLine)
+			staffRoutes.DELETE("/:id/lines/:lineId", invoiceController.RemoveLine)
+			staffRoutes.POST("/:id/issue", invoiceController.IssueInvoice)
+		}
+
+		readRoutes := invoiceGroup.Group("")
+		readRoutes.Use(middleware.RoleMiddleware([]string{roles.ADMIN, roles.RECEPTIONIST, roles.CASHIER, roles.FINANCE_MANAGER}))
+		{
+			readRoutes.GET("", invoiceController.GetInvoices)
+			readRoutes.GET("/:id", invoiceController.GetInvoiceByID)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/controllers"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+	"github.com/ofojichigozie/hms-go-backend/services"
+	"gorm.io/gorm"
+)
+
+func LabOrderRoutes(r *gin.Engine, DB *gorm.DB) {
+	labOrderRepository := repositories.NewLabOrderRepository(DB)
+	labTestRepository := repositories.NewLabTestRepository(DB)
+	appointmentRepository := repositories.NewAppointmentRepository(DB)
+	clinicalNoteRepository := repositories.NewClinicalNoteRepository(DB)
+	patientRepository := repositories.NewPatientRepository(DB)
+	cdsRuleRepository := repositories.NewCdsRuleRepository(DB)
+	cdsAlertRepository := repositories.NewCdsAlertRepository(DB)
+	vitalSignRepository := repositories.NewVitalSignRepository(DB)
+	growthMeasurementRepository := repositories.NewGrowthMeasurementRepository(DB)
+	decisionSupport := services.NewDecisionSupport(cdsRuleRepository, cdsAlertRepository,
+		patientRepository, vitalSignRepository, growthMeasurementRepository)
+	labOrderService := services.NewLabOrderService(labOrderRepository, labTestRepository,
+		appointmentRepository, clinicalNoteRepository, patientRepository, decisionSupport)
+	labOrderController := controllers.NewLabOrderController(labOrderService)
+
+	roles := constants.Roles
+
+	labOrderGroup := r.Group("/lab-orders")
+	labOrderGroup.Use(middleware.AuthMiddleware())
+	{
+		doctorRoutes := labOrderGroup.Group("")
+		doctorRoutes.Use(middleware.RoleMiddleware([]string{roles.DOCTOR}))
+		{
+			doctorRoutes.POST("", labOrderController.CreateLabOrder)
+			doctorRoutes.GET("/inbox", labOrderController.GetResultsInbox)
+			doctorRoutes.POST("/:id/verify", labOrderController.VerifyResults)
+			doctorRoutes.POST("/:id/acknowledge", labOrderController.AcknowledgeResults)
+		}
+
+		staffRoutes := labOrderGroup.Group("")
+		staffRoutes.Use(middleware.RoleMiddleware([]string{roles.DOCTOR, roles.RECEPTIONIST}))
+		{
+			staffRoutes.POST("/:id/results", labOrderController.EnterResults)
+			staffRoutes.POST("/:id/cancel", labOrderController.CancelLabOrder)
+		}
+
+		collectionRoutes := labOrderGroup.Group("")
+		collectionRoutes.Use(middleware.RoleMiddleware([]string{roles.DOCTOR, roles.RECEPTIONIST, roles.NURSE}))
+		{
+			collectionRoutes.GET("", labOrderController.GetAllLabOrders)
+			collectionRoutes.GET("/:id", labOrderController.GetLabOrderByID)
+			collectionRoutes.POST("/:id/collect", labOrderController.CollectSample)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/controllers"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+	"github.com/ofojichigozie/hms-go-backend/services"
+	"gorm.io/gorm"
+)
+
+func LabTestRoutes(r *gin.Engine, DB *gorm.DB) {
+	labTestRepository := repositories.NewLabTestRepository(DB)
+	labTestService := services.NewLabTestService(labTestRepository)
+	labTestController := controllers.NewLabTestController(labTestService)
+
+	roles := constants.Roles
+
+	labTestGroup := r.Group("/lab-tests")
+	labTestGroup.Use(middleware.AuthMiddleware())
+	{
+		adminRoutes := labTestGroup.Group("")
+		adminRoutes.Use(middleware.RoleMiddleware([]string{roles.ADMIN}))
+		{
+			adminRoutes.POST("", labTestController.CreateLabTest)
+			adminRoutes.PATCH("/:id", labTestController.UpdateLabTest)
+		}
+
+		staffRoutes := labTestGroup.Group("")
+		staffRoutes.Use(middleware.RoleMiddleware([]string{roles.ADMIN, roles.DOCTOR, roles.RECEPTIONIST}))
+		{
+			staffRoutes.GET("", labTestController.GetAllLabTests)
+			staffRoutes.GET("/:id", labTestController.GetLabTestByID)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/controllers"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+	"github.com/ofojichigozie/hms-go-backend/services"
+	"gorm.io/gorm"
+)
+
+func MedicationRoutes(r *gin.Engine, DB *gorm.DB) {
+	medicationRepository := repositories.NewMedicationRepository(DB)
+	medicationService := services.NewMedicationService(medicationRepository)
+	medicationController := controllers.NewMedicationController(medicationService)
+
+	roles := constants.Roles
+
+	medicationGroup := r.Group("/medications")
+	medicationGroup.Use(middleware.AuthMiddleware())
+	{
+		adminRoutes := medicationGroup.Group("")
+		adminRoutes.Use(middleware.RoleMiddleware([]string{roles.ADMIN}))
+		{
+			adminRoutes.POST("", medicationController.CreateMedication)
+			adminRoutes.PATCH("/:id", medicationController.UpdateMedication)
+		}
+
+		staffRoutes := medicationGroup.Group("")
+		staffRoutes.Use(middleware.RoleMiddleware([]string{roles.ADMIN, roles.DOCTOR, roles.RECEPTIONIST, roles.NURSE, roles.PHARMACIST}))
+		{
+			staffRoutes.GET("", medicationController.GetAllMedications)
+			staffRoutes.GET("/:id", medicationController.GetMedicationByID)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/controllers"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+	"github.com/ofojichigozie/hms-go-backend/services"
+	"gorm.io/gorm"
+)
+
+func NursingRoutes(r *gin.Engine, DB *gorm.DB) {
+	vitalSignRepository := repositories.NewVitalSignRepository(DB)
+	nursingNoteRepository := repositories.NewNursingNoteRepository(DB)
+	medicationAdministrationRepository := repositories.NewMedicationAdministrationRepository(DB)
+	prescriptionRepository := repositories.NewPrescriptionRepository(DB)
+	labOrderRepository := repositories.NewLabOrderRepository(DB)
+	admissionRepository := repositories.NewAdmissionRepository(DB)
+	wardRepository := repositories.NewWardRepository(DB)
+	patientRepository := repositories.NewPatientRepository(DB)
+	nursingService := services.NewNursingService(vitalSignRepository, nursingNoteRepository,
+		medicationAdministrationRepository, prescriptionRepository, labOrderRepository,
+		admissionRepository, wardRepository, patientRepository, services.MarOverdueWindowFromEnv())
+	nursingController := controllers.NewNursingController(nursingService)
+
+	roles := constants.Roles
+
+	patientGroup := r.Group("/patients/:id")
+	patientGroup.Use(middleware.AuthMiddleware())
+	{
+		nurseRoutes := patientGroup.Group("")
+		nurseRoutes.Use(middleware.RoleMiddleware([]string{roles.NURSE}))
+		{
+			nurseRoutes.POST("/nursing-notes", nursingController.CreateNursingNote)
+			nurseRoutes.POST("/medication-administrations", nursingController.AdministerMedication)
+		}
+
+		clinicalRoutes := patientGroup.Group("")
+		clinicalRoutes.Use(middleware.RoleMiddleware([]string{roles.NURSE, roles.DOCTOR}))
+		{
+			clinicalRoutes.POST("/vitals", nursingController.RecordVitals)
+			clinicalRoutes.GET("/nursing-notes", nursingController.GetNursingNotes)
+			clinicalRoutes.GET("/medication-administrations", nursingController.GetMedicationAdministrations)
+		}
+
+		staffRoutes := patientGroup.Group("")
+		staffRoutes.Use(middleware.RoleMiddleware([]string{roles.NURSE, roles.DOCTOR, roles.RECEPTIONIST}))
+		{
+			staffRoutes.GET("/vitals", nursingController.GetVitals)
+		}
+	}
+
+	wardGroup := r.Group("/wards")
+	wardGroup.Use(middleware.AuthMiddleware())
+	wardGroup.Use(middleware.RoleMiddleware([]string{roles.NURSE, roles.DOCTOR}))
+	{
+		wardGroup.GET("/:id/tasks", nursingController.GetTasks)
+		wardGroup.GET("/:id/medication-dashboard", nursingController.GetMedicationDashboard)
+	}
+
+	admissionGroup := r.Group("/admissions")
+	admissionGroup.Use(middleware.AuthMiddleware())
+	admissionGroup.Use(middleware.RoleMiddleware([]string{roles.NURSE, roles.DOCTOR}))
+	{
+		admissionGroup.GET("/:id/mar", nursingController.GetAdmissionMAR)
+	}
+}
@@ -0,0 +1,38 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/controllers"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+	"github.com/ofojichigozie/hms-go-backend/services"
+	"gorm.io/gorm"
+)
+
+func PatientContactRoutes(r *gin.Engine, DB *gorm.DB) {
+	patientContactRepository := repositories.NewPatientContactRepository(DB)
+	patientRepository := repositories.NewPatientRepository(DB)
+	patientContactService := services.NewPatientContactService(patientContactRepository, patientRepository)
+	patientContactController := controllers.NewPatientContactController(patientContactService)
+
+	roles := constants.Roles
+
+	contactGroup := r.Group("/patients/:id/contacts")
+	contactGroup.Use(middleware.AuthMiddleware())
+	{
+		receptionistRoutes := contactGroup.Group("")
+		receptionistRoutes.Use(middleware.RoleMiddleware([]string{roles.RECEPTIONIST}))
+		{
+			receptionistRoutes.POST("", patientContactController.CreateContact)
+			receptionistRoutes.PATCH("/:contactId", patientContactController.UpdateContact)
+			receptionistRoutes.DELETE("/:contactId", patientContactController.DeleteContact)
+		}
+
+		staffRoutes := contactGroup.Group("")
+		staffRoutes.Use(middleware.RoleMiddleware([]string{roles.RECEPTIONIST, roles.DOCTOR, roles.NURSE}))
+		{
+			staffRoutes.GET("", patientContactController.GetContacts)
+		}
+	}
+}
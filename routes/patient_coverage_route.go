@@ -0,0 +1,41 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/controllers"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+	"github.com/ofojichigozie/hms-go-backend/services"
+	"gorm.io/gorm"
+)
+
+func PatientCoverageRoutes(r *gin.Engine, DB *gorm.DB) {
+	patientCoverageRepository := repositories.NewPatientCoverageRepository(DB)
+	payerRepository := repositories.NewPayerRepository(DB)
+	patientRepository := repositories.NewPatientRepository(DB)
+	patientCoverageService := services.NewPatientCoverageService(
+		patientCoverageRepository, payerRepository, patientRepository, services.NewLocalEligibilityChecker(),
+	)
+	patientCoverageController := controllers.NewPatientCoverageController(patientCoverageService)
+
+	roles := constants.Roles
+
+	coverageGroup := r.Group("/patients/:id/coverages")
+	coverageGroup.Use(middleware.AuthMiddleware())
+	{
+		receptionistRoutes := coverageGroup.Group("")
+		receptionistRoutes.Use(middleware.RoleMiddleware([]string{roles.RECEPTIONIST}))
+		{
+			receptionistRoutes.POST("", patientCoverageController.CreateCoverage)
+			receptionistRoutes.PATCH("/:coverageId", patientCoverageController.UpdateCoverage)
+			receptionistRoutes.POST("/:coverageId/eligibility", patientCoverageController.CheckEligibility)
+		}
+
+		staffRoutes := coverageGroup.Group("")
+		staffRoutes.Use(middleware.RoleMiddleware([]string{roles.RECEPTIONIST, roles.DOCTOR}))
+		{
+			staffRoutes.GET("", patientCoverageController.GetCoverages)
+		}
+	}
+}
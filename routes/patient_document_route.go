@@ -0,0 +1,38 @@
+package routes
+
+import (
+	"log"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/controllers"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+	"github.com/ofojichigozie/hms-go-backend/services"
+	"github.com/ofojichigozie/hms-go-backend/storage"
+	"gorm.io/gorm"
+)
+
+func PatientDocumentRoutes(r *gin.Engine, DB *gorm.DB) {
+	documentStorage, err := storage.NewBackendFromEnv()
+	if err != nil {
+		log.Fatalf("Document storage is misconfigured: %v", err)
+	}
+
+	patientDocumentRepository := repositories.NewPatientDocumentRepository(DB)
+	patientRepository := repositories.NewPatientRepository(DB)
+	patientDocumentService := services.NewPatientDocumentService(patientDocumentRepository, patientRepository,
+		documentStorage, services.DocumentScannerFromEnv(), services.DocumentMaxSizeFromEnv())
+	patientDocumentController := controllers.NewPatientDocumentController(patientDocumentService)
+
+	roles := constants.Roles
+
+	documentGroup := r.Group("/patients/:id/documents")
+	documentGroup.Use(middleware.AuthMiddleware())
+	documentGroup.Use(middleware.RoleMiddleware([]string{roles.RECEPTIONIST, roles.DOCTOR}))
+	{
+		documentGroup.POST("", patientDocumentController.UploadDocument)
+		documentGroup.GET("", patientDocumentController.GetDocuments)
+		documentGroup.GET("/:documentId", patientDocumentController.DownloadDocument)
+	}
+}
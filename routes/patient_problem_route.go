@@ -0,0 +1,41 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/controllers"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+	"github.com/ofojichigozie/hms-go-backend/services"
+	"gorm.io/gorm"
+)
+
+func PatientProblemRoutes(r *gin.Engine, DB *gorm.DB) {
+	patientProblemRepository := repositories.NewPatientProblemRepository(DB)
+	patientRepository := repositories.NewPatientRepository(DB)
+	clinicalNoteRepository := repositories.NewClinicalNoteRepository(DB)
+	diagnosisCodeRepository := repositories.NewDiagnosisCodeRepository(DB)
+	patientProblemService := services.NewPatientProblemService(patientProblemRepository, patientRepository,
+		clinicalNoteRepository, diagnosisCodeRepository)
+	patientProblemController := controllers.NewPatientProblemController(patientProblemService)
+
+	roles := constants.Roles
+
+	problemGroup := r.Group("/patients/:id/problems")
+	problemGroup.Use(middleware.AuthMiddleware())
+	{
+		doctorRoutes := problemGroup.Group("")
+		doctorRoutes.Use(middleware.RoleMiddleware([]string{roles.DOCTOR}))
+		{
+			doctorRoutes.POST("", patientProblemController.CreateProblem)
+			doctorRoutes.POST("/from-note", patientProblemController.PromoteDiagnosis)
+			doctorRoutes.PATCH("/:problemId", patientProblemController.UpdateProblem)
+		}
+
+		staffRoutes := problemGroup.Group("")
+		staffRoutes.Use(middleware.RoleMiddleware([]string{roles.DOCTOR, roles.RECEPTIONIST, roles.NURSE}))
+		{
+			staffRoutes.GET("", patientProblemController.GetProblems)
+		}
+	}
+}
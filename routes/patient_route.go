@@ -1,19 +1,34 @@
 package routes
 
 import (
+	"log"
+
 	"github.com/gin-gonic/gin"
 	"github.com/ofojichigozie/hms-go-backend/constants"
 	"github.com/ofojichigozie/hms-go-backend/controllers"
 	"github.com/ofojichigozie/hms-go-backend/middleware"
 	"github.com/ofojichigozie/hms-go-backend/repositories"
 	"github.com/ofojichigozie/hms-go-backend/services"
+	"github.com/ofojichigozie/hms-go-backend/utils"
 	"gorm.io/gorm"
 )
 
 func PatientRoutes(r *gin.Engine, DB *gorm.DB) {
+	registrationFormat, err := utils.RegistrationNumberFormatFromEnv()
+	if err != nil {
+		log.Fatalf("Patient registration number format is invalid: %v", err)
+	}
+
 	patientRepository := repositories.NewPatientRepository(DB)
 	staffRepository := repositories.NewStaffRepository(DB)
-	patientService := services.NewPatientService(patientRepository, staffRepository)
+	sequenceRepository := repositories.NewSequenceRepository(DB)
+	auditLogRepository := repositories.NewAuditLogRepository(DB)
+	patientContactRepository := repositories.NewPatientContactRepository(DB)
+	admissionRepository := repositories.NewAdmissionRepository(DB)
+	immunizationRepository := repositories.NewImmunizationRepository(DB)
+	patientService := services.NewPatientService(patientRepository, staffRepository,
+		sequenceRepository, auditLogRepository, patientContactRepository, admissionRepository,
+		immunizationRepository, registrationFormat)
 	patientController := controllers.NewPatientController(patientService)
 
 	roles := constants.Roles
@@ -21,6 +36,12 @@ func PatientRoutes(r *gin.Engine, DB *gorm.DB) {
 	patientGroup := r.Group("/patients")
 	patientGroup.Use(middleware.AuthMiddleware())
 	{
+		adminRoutes := patientGroup.Group("")
+		adminRoutes.Use(middleware.RoleMiddleware([]string{roles.ADMIN}))
+		{
+			adminRoutes.POST("/merge", patientController.MergePatients)
+		}
+
 		receptionistRoutes := patientGroup.Group("")
 		receptionistRoutes.Use(middleware.RoleMiddleware([]string{roles.RECEPTIONIST}))
 		{
@@ -30,10 +51,11 @@ func PatientRoutes(r *gin.Engine, DB *gorm.DB) {
 		}
 
 		staffRoutes := patientGroup.Group("")
-		staffRoutes.Use(middleware.RoleMiddleware([]string{roles.RECEPTIONIST, roles.DOCTOR}))
+		staffRoutes.Use(middleware.RoleMiddleware([]string{roles.RECEPTIONIST, roles.DOCTOR, roles.NURSE}))
 		{
 			staffRoutes.GET("", patientController.GetAllPatients)
 			staffRoutes.GET("/:id", patientController.GetPatientByID)
+			staffRoutes.GET("/registration/:registrationNumber", patientController.GetPatientByRegistrationNumber)
 		}
 	}
 }
@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/controllers"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+	"github.com/ofojichigozie/hms-go-backend/services"
+	"gorm.io/gorm"
+)
+
+func PatientTimelineRoutes(r *gin.Engine, DB *gorm.DB) {
+	timelineRepository := repositories.NewTimelineRepository(DB)
+	patientRepository := repositories.NewPatientRepository(DB)
+	patientTimelineService := services.NewPatientTimelineService(timelineRepository, patientRepository)
+	patientTimelineController := controllers.NewPatientTimelineController(patientTimelineService)
+
+	roles := constants.Roles
+
+	timelineGroup := r.Group("/patients/:id/timeline")
+	timelineGroup.Use(middleware.AuthMiddleware())
+	timelineGroup.Use(middleware.RoleMiddleware([]string{roles.DOCTOR, roles.RECEPTIONIST, roles.NURSE}))
+	{
+		timelineGroup.GET("", patientTimelineController.GetTimeline)
+	}
+}
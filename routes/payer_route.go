@@ -0,0 +1,39 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/controllers"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+	"github.com/ofojichigozie/hms-go-backend/services"
+	"gorm.io/gorm"
+)
+
+func PayerRoutes(r *gin.Engine, DB *gorm.DB) {
+	payerRepository := repositories.NewPayerRepository(DB)
+	payerService := services.NewPayerService(payerRepository)
+	payerController := controllers.NewPayerController(payerService)
+
+	roles := constants.Roles
+
+	payerGroup := r.Group("/payers")
+	payerGroup.Use(middleware.AuthMiddleware())
+	{
+		adminRoutes := payerGroup.Group("")
+		adminRoutes.Use(middleware.RoleMiddleware([]string{roles.ADMIN}))
+		{
+			adminRoutes.POST("", payerController.CreatePayer)
+			adminRoutes.PATCH("/:id", payerController.UpdatePayer)
+			adminRoutes.POST("/:id/plans", payerController.CreatePlan)
+			adminRoutes.PATCH("/:id/plans/:planId", payerController.UpdatePlan)
+		}
+
+		staffRoutes := payerGroup.Group("")
+		staffRoutes.Use(middleware.RoleMiddleware([]string{roles.ADMIN, roles.RECEPTIONIST, roles.DOCTOR}))
+		{
+			staffRoutes.GET("", payerController.GetAllPayers)
+			staffRoutes.GET("/:id", payerController.GetPayerByID)
+		}
+	}
+}
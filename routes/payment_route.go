@@ -0,0 +1,69 @@
+package routes
+
+import (
+	"os"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/controllers"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+	"github.com/ofojichigozie/hms-go-backend/services"
+	"gorm.io/gorm"
+)
+
+func PaymentRoutes(r *gin.Engine, DB *gorm.DB) {
+	paymentRepository := repositories.NewPaymentRepository(DB)
+	invoiceRepository := repositories.NewInvoiceRepository(DB)
+	cashierShiftRepository := repositories.NewCashierShiftRepository(DB)
+	sequenceRepository := repositories.NewSequenceRepository(DB)
+	patientRepository := repositories.NewPatientRepository(DB)
+	staffRepository := repositories.NewStaffRepository(DB)
+	paymentService := services.NewPaymentService(paymentRepository, invoiceRepository,
+		cashierShiftRepository, sequenceRepository, patientRepository, staffRepository,
+		os.Getenv("BRANCH_CODE"))
+	paymentController := controllers.NewPaymentController(paymentService)
+
+	cashierShiftService := services.NewCashierShiftService(cashierShiftRepository, paymentRepository)
+	cashierShiftController := controllers.NewCashierShiftController(cashierShiftService)
+
+	roles := constants.Roles
+
+	paymentGroup := r.Group("/payments")
+	paymentGroup.Use(middleware.AuthMiddleware())
+	{
+		cashierRoutes := paymentGroup.Group("")
+		cashierRoutes.Use(middleware.RoleMiddleware([]string{roles.CASHIER, roles.RECEPTIONIST}))
+		{
+			cashierRoutes.POST("", paymentController.RecordPayment)
+			cashierRoutes.POST("/:id/refunds", paymentController.RefundPayment)
+		}
+
+		staffRoutes := paymentGroup.Group("")
+		staffRoutes.Use(middleware.RoleMiddleware([]string{roles.ADMIN, roles.CASHIER, roles.RECEPTIONIST}))
+		{
+			staffRoutes.GET("", paymentController.GetPayments)
+			staffRoutes.GET("/:id", paymentController.GetPaymentByID)
+			staffRoutes.GET("/:id/receipt", paymentController.PrintReceipt)
+		}
+	}
+
+	shiftGroup := r.Group("/cashier-shifts")
+	shiftGroup.Use(middleware.AuthMiddleware())
+	{
+		cashierRoutes := shiftGroup.Group("")
+		cashierRoutes.Use(middleware.RoleMiddleware([]string{roles.CASHIER, roles.RECEPTIONIST}))
+		{
+			cashierRoutes.POST("", cashierShiftController.OpenShift)
+			cashierRoutes.GET("/current", cashierShiftController.GetCurrentShift)
+			cashierRoutes.POST("/current/close", cashierShiftController.CloseShift)
+		}
+
+		adminRoutes := shiftGroup.Group("")
+		adminRoutes.Use(middleware.RoleMiddleware([]string{roles.ADMIN}))
+		{
+			adminRoutes.GET("", cashierShiftController.GetShifts)
+			adminRoutes.GET("/:id", cashierShiftController.GetShiftByID)
+		}
+	}
+}
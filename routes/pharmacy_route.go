@@ -0,0 +1,48 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/controllers"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+	"github.com/ofojichigozie/hms-go-backend/services"
+	"gorm.io/gorm"
+)
+
+func PharmacyRoutes(r *gin.Engine, DB *gorm.DB) {
+	stockRepository := repositories.NewStockRepository(DB)
+	dispensationRepository := repositories.NewDispensationRepository(DB)
+	prescriptionRepository := repositories.NewPrescriptionRepository(DB)
+	medicationRepository := repositories.NewMedicationRepository(DB)
+	pharmacyService := services.NewPharmacyService(stockRepository, dispensationRepository,
+		prescriptionRepository, medicationRepository, services.ExpiryAlertDaysFromEnv())
+	pharmacyController := controllers.NewPharmacyController(pharmacyService)
+
+	roles := constants.Roles
+
+	pharmacyGroup := r.Group("/pharmacy")
+	pharmacyGroup.Use(middleware.AuthMiddleware())
+	{
+		pharmacistRoutes := pharmacyGroup.Group("")
+		pharmacistRoutes.Use(middleware.RoleMiddleware([]string{roles.PHARMACIST}))
+		{
+			pharmacistRoutes.POST("/goods-receipts", pharmacyController.ReceiveGoods)
+			pharmacistRoutes.POST("/prescriptions/:id/dispense", pharmacyController.DispensePrescription)
+		}
+
+		staffRoutes := pharmacyGroup.Group("")
+		staffRoutes.Use(middleware.RoleMiddleware([]string{roles.PHARMACIST, roles.ADMIN}))
+		{
+			staffRoutes.GET("/goods-receipts", pharmacyController.GetGoodsReceipts)
+			staffRoutes.GET("/goods-receipts/:id", pharmacyController.GetGoodsReceiptByID)
+			staffRoutes.GET("/stock", pharmacyController.GetStockLevels)
+			staffRoutes.GET("/stock/:id/batches", pharmacyController.GetBatches)
+			staffRoutes.GET("/stock/:id/ledger", pharmacyController.GetStockLedger)
+			staffRoutes.GET("/alerts/low-stock", pharmacyController.GetLowStockAlerts)
+			staffRoutes.GET("/alerts/expiry", pharmacyController.GetExpiryAlerts)
+			staffRoutes.GET("/prescriptions", pharmacyController.GetDispensingQueue)
+			staffRoutes.GET("/prescriptions/:id/dispensations", pharmacyController.GetDispensations)
+		}
+	}
+}
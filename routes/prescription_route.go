@@ -0,0 +1,58 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/controllers"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+	"github.com/ofojichigozie/hms-go-backend/services"
+	"gorm.io/gorm"
+)
+
+func PrescriptionRoutes(r *gin.Engine, DB *gorm.DB) {
+	prescriptionRepository := repositories.NewPrescriptionRepository(DB)
+	medicationRepository := repositories.NewMedicationRepository(DB)
+	clinicalNoteRepository := repositories.NewClinicalNoteRepository(DB)
+	patientRepository := repositories.NewPatientRepository(DB)
+	staffRepository := repositories.NewStaffRepository(DB)
+	allergyRepository := repositories.NewAllergyRepository(DB)
+	cdsRuleRepository := repositories.NewCdsRuleRepository(DB)
+	cdsAlertRepository := repositories.NewCdsAlertRepository(DB)
+	vitalSignRepository := repositories.NewVitalSignRepository(DB)
+	growthMeasurementRepository := repositories.NewGrowthMeasurementRepository(DB)
+	decisionSupport := services.NewDecisionSupport(cdsRuleRepository, cdsAlertRepository,
+		patientRepository, vitalSignRepository, growthMeasurementRepository)
+	prescriptionService := services.NewPrescriptionService(prescriptionRepository,
+		medicationRepository, clinicalNoteRepository, patientRepository, staffRepository,
+		allergyRepository, decisionSupport)
+	prescriptionController := controllers.NewPrescriptionController(prescriptionService)
+
+	roles := constants.Roles
+
+	prescriptionGroup := r.Group("/prescriptions")
+	prescriptionGroup.Use(middleware.AuthMiddleware())
+	{
+		doctorRoutes := prescriptionGroup.Group("")
+		doctorRoutes.Use(middleware.RoleMiddleware([]string{roles.DOCTOR}))
+		{
+			doctorRoutes.POST("", prescriptionController.CreatePrescription)
+			doctorRoutes.PATCH("/:id/status", prescriptionController.UpdatePrescriptionStatus)
+		}
+
+		staffRoutes := prescriptionGroup.Group("")
+		staffRoutes.Use(middleware.RoleMiddleware([]string{roles.DOCTOR, roles.RECEPTIONIST, roles.NURSE, roles.PHARMACIST}))
+		{
+			staffRoutes.GET("/:id", prescriptionController.GetPrescriptionByID)
+			staffRoutes.GET("/:id/print", prescriptionController.PrintPrescription)
+			staffRoutes.GET("/note/:noteId", prescriptionController.GetPrescriptionsByNoteID)
+		}
+	}
+
+	patientGroup := r.Group("/patients")
+	patientGroup.Use(middleware.AuthMiddleware())
+	patientGroup.Use(middleware.RoleMiddleware([]string{roles.DOCTOR, roles.RECEPTIONIST, roles.NURSE, roles.PHARMACIST}))
+	{
+		patientGroup.GET("/:id/medications", prescriptionController.GetCurrentMedications)
+	}
+}
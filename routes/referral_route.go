@@ -0,0 +1,45 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/controllers"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+	"github.com/ofojichigozie/hms-go-backend/services"
+	"gorm.io/gorm"
+)
+
+func ReferralRoutes(r *gin.Engine, DB *gorm.DB) {
+	referralRepository := repositories.NewReferralRepository(DB)
+	appointmentRepository := repositories.NewAppointmentRepository(DB)
+	clinicalNoteRepository := repositories.NewClinicalNoteRepository(DB)
+	staffRepository := repositories.NewStaffRepository(DB)
+	referralService := services.NewReferralService(referralRepository, appointmentRepository,
+		clinicalNoteRepository, staffRepository)
+	referralController := controllers.NewReferralController(referralService)
+
+	roles := constants.Roles
+
+	referralGroup := r.Group("/referrals")
+	referralGroup.Use(middleware.AuthMiddleware())
+	{
+		doctorRoutes := referralGroup.Group("")
+		doctorRoutes.Use(middleware.RoleMiddleware([]string{roles.DOCTOR}))
+		{
+			doctorRoutes.POST("", referralController.CreateReferral)
+			doctorRoutes.POST("/:id/accept", referralController.AcceptReferral)
+			doctorRoutes.POST("/:id/decline", referralController.DeclineReferral)
+			doctorRoutes.POST("/:id/complete", referralController.CompleteReferral)
+		}
+
+		staffRoutes := referralGroup.Group("")
+		staffRoutes.Use(middleware.RoleMiddleware([]string{roles.DOCTOR, roles.RECEPTIONIST}))
+		{
+			staffRoutes.GET("", referralController.GetAllReferrals)
+			staffRoutes.GET("/worklist", referralController.GetWorklist)
+			staffRoutes.GET("/:id", referralController.GetReferralByID)
+			staffRoutes.POST("/:id/schedule", referralController.ScheduleReferral)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/controllers"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+	"github.com/ofojichigozie/hms-go-backend/services"
+	"gorm.io/gorm"
+)
+
+func ServicePriceRoutes(r *gin.Engine, DB *gorm.DB) {
+	servicePriceRepository := repositories.NewServicePriceRepository(DB)
+	labTestRepository := repositories.NewLabTestRepository(DB)
+	medicationRepository := repositories.NewMedicationRepository(DB)
+	servicePriceService := services.NewServicePriceService(servicePriceRepository,
+		labTestRepository, medicationRepository)
+	servicePriceController := controllers.NewServicePriceController(servicePriceService)
+
+	roles := constants.Roles
+
+	priceGroup := r.Group("/service-prices")
+	priceGroup.Use(middleware.AuthMiddleware())
+	{
+		adminRoutes := priceGroup.Group("")
+		adminRoutes.Use(middleware.RoleMiddleware([]string{roles.ADMIN}))
+		{
+			adminRoutes.POST("", servicePriceController.CreateServicePrice)
+			adminRoutes.PATCH("/:id", servicePriceController.UpdateServicePrice)
+		}
+
+		staffRoutes := priceGroup.Group("")
+		staffRoutes.Use(middleware.RoleMiddleware([]string{roles.ADMIN, roles.RECEPTIONIST}))
+		{
+			staffRoutes.GET("", servicePriceController.GetServicePrices)
+			staffRoutes.GET("/:id", servicePriceController.GetServicePriceByID)
+		}
+	}
+}
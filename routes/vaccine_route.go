@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/controllers"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+	"github.com/ofojichigozie/hms-go-backend/services"
+	"gorm.io/gorm"
+)
+
+func VaccineRoutes(r *gin.Engine, DB *gorm.DB) {
+	vaccineRepository := repositories.NewVaccineRepository(DB)
+	vaccineService := services.NewVaccineService(vaccineRepository)
+	vaccineController := controllers.NewVaccineController(vaccineService)
+
+	roles := constants.Roles
+
+	vaccineGroup := r.Group("/vaccines")
+	vaccineGroup.Use(middleware.AuthMiddleware())
+	{
+		adminRoutes := vaccineGroup.Group("")
+		adminRoutes.Use(middleware.RoleMiddleware([]string{roles.ADMIN}))
+		{
+			adminRoutes.POST("", vaccineController.CreateVaccine)
+			adminRoutes.PATCH("/:id", vaccineController.UpdateVaccine)
+			adminRoutes.PUT("/:id/schedule", vaccineController.ReplaceSchedule)
+		}
+
+		staffRoutes := vaccineGroup.Group("")
+		staffRoutes.Use(middleware.RoleMiddleware([]string{roles.ADMIN, roles.DOCTOR, roles.RECEPTIONIST}))
+		{
+			staffRoutes.GET("", vaccineController.GetAllVaccines)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/controllers"
+	"github.com/ofojichigozie/hms-go-backend/middleware"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+	"github.com/ofojichigozie/hms-go-backend/services"
+	"gorm.io/gorm"
+)
+
+func WardRoutes(r *gin.Engine, DB *gorm.DB) {
+	wardRepository := repositories.NewWardRepository(DB)
+	wardService := services.NewWardService(wardRepository)
+	wardController := controllers.NewWardController(wardService)
+
+	roles := constants.Roles
+
+	wardGroup := r.Group("/wards")
+	wardGroup.Use(middleware.AuthMiddleware())
+	{
+		adminRoutes := wardGroup.Group("")
+		adminRoutes.Use(middleware.RoleMiddleware([]string{roles.ADMIN}))
+		{
+			adminRoutes.POST("", wardController.CreateWard)
+			adminRoutes.PATCH("/:id", wardController.UpdateWard)
+			adminRoutes.POST("/:id/beds", wardController.AddBed)
+		}
+
+		housekeepingRoutes := wardGroup.Group("")
+		housekeepingRoutes.Use(middleware.RoleMiddleware([]string{roles.ADMIN, roles.RECEPTIONIST, roles.NURSE}))
+		{
+			housekeepingRoutes.PATCH("/:id/beds/:bedId", wardController.UpdateBedStatus)
+		}
+
+		staffRoutes := wardGroup.Group("")
+		staffRoutes.Use(middleware.RoleMiddleware([]string{roles.ADMIN, roles.DOCTOR, roles.RECEPTIONIST, roles.NURSE}))
+		{
+			staffRoutes.GET("", wardController.GetAllWards)
+			staffRoutes.GET("/board", wardController.GetBoards)
+			staffRoutes.GET("/:id", wardController.GetWardByID)
+			staffRoutes.GET("/:id/board", wardController.GetBoard)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+)
+
+type AdmissionService interface {
+	AdmitPatient(input models.AdmitPatientInput, doctorID uint) (*models.Admission, error)
+	GetAdmissionByID(id uint) (*models.Admission, error)
+	GetAdmissions(query models.AdmissionQuery) ([]models.Admission, int64, error)
+	TransferPatient(id uint, input models.TransferInput, staffID uint) (*models.Admission, error)
+	DischargePatient(id uint, input models.DischargeInput, doctorID uint) (*models.Admission, error)
+}
+
+type admissionService struct {
+	admissionRepository   repositories.AdmissionRepository
+	wardRepository        repositories.WardRepository
+	appointmentRepository repositories.AppointmentRepository
+	referralRepository    repositories.ReferralRepository
+}
+
+func NewAdmissionService(
+	admissionRepository repositories.AdmissionRepository,
+	wardRepository repositories.WardRepository,
+	appointmentRepository repositories.AppointmentRepository,
+	referralRepository repositories.ReferralRepository,
+) AdmissionService {
+	return &admissionService{
+		admissionRepository:   admissionRepository,
+		wardRepository:        wardRepository,
+		appointmentRepository: appointmentRepository,
+		referralRepository:    referralRepository,
+	}
+}
+
+// AdmitPatient admits the patient seen at an appointment, or sent in on an
+// accepted referral, to an available or reserved bed.
+func (as *admissionService) AdmitPatient(input models.AdmitPatientInput, doctorID uint) (*models.Admission, error) {
+	admission := &models.Admission{
+		AdmittingDoctorID: doctorID,
+		Reason:            strings.TrimSpace(input.Reason),
+		Status:            constants.AdmissionStatus.ADMITTED,
+		AdmittedAt:        time.Now(),
+	}
+
+	if input.AppointmentID != nil {
+		appointment, err := as.appointmentRepository.FindByID(*input.AppointmentID)
+		if err != nil {
+			return nil, errors.New("associated appointment record not found")
+		}
+		if appointment.Status == constants.AppointmentStatus.CANCELLED {
+			return nil, errors.New("cannot admit from a cancelled appointment")
+		}
+		admission.AppointmentID = &appointment.ID
+		admission.PatientID = appointment.PatientID
+	} else {
+		referral, err := as.referralRepository.FindByID(*input.ReferralID)
+		if err != nil {
+			return nil, errors.New("referral not found")
+		}
+		if referral.Status == constants.ReferralStatus.PENDING || referral.Status == constants.ReferralStatus.DECLINED {
+			return nil, errors.New("only accepted referrals can lead to an admission")
+		}
+		admission.ReferralID = &referral.ID
+		admission.AppointmentID = referral.AppointmentID
+		admission.PatientID = referral.PatientID
+	}
+
+	if _, err := as.admissionRepository.FindActiveByPatientID(admission.PatientID); err == nil {
+		return nil, errors.New("patient is already admitted")
+	}
+
+	bed, ward, err := as.findFreeBed(input.BedID)
+	if err != nil {
+		return nil, err
+	}
+	admission.WardID = ward.ID
+	admission.BedID = bed.ID
+
+	if err := as.admissionRepository.Admit(admission, doctorID); err != nil {
+		return nil, err
+	}
+
+	bed.Status = constants.BedStatus.OCCUPIED
+	ward.Beds = nil
+	admission.Ward = ward
+	admission.Bed = bed
+	return admission, nil
+}
+
+func (as *admissionService) GetAdmissionByID(id uint) (*models.Admission, error) {
+	admission, err := as.admissionRepository.FindByID(id)
+	if err != nil {
+		return nil, errors.New("admission not found")
+	}
+	return admission, nil
+}
+
+func (as *admissionService) GetAdmissions(query models.AdmissionQuery) ([]models.Admission, int64, error) {
+	query.Normalize()
+	return as.admissionRepository.FindAll(query)
+}
+
+func (as *admissionService) TransferPatient(id uint, input models.TransferInput, staffID uint) (*models.Admission, error) {
+	admission, err := as.findActiveAdmission(id)
+	if err != nil {
+		return nil, err
+	}
+	if admission.BedID == input.BedID {
+		return nil, errors.New("patient is already in this bed")
+	}
+
+	bed, _, err := as.findFreeBed(input.BedID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := as.admissionRepository.Transfer(admission, bed, strings.TrimSpace(input.Reason), staffID); err != nil {
+		return nil, err
+	}
+
+	return as.admissionRepository.FindByID(admission.ID)
+}
+
+func (as *admissionService) DischargePatient(id uint, input models.DischargeInput, doctorID uint) (*models.Admission, error) {
+	admission, err := as.findActiveAdmission(id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	admission.Status = constants.AdmissionStatus.DISCHARGED
+	admission.DischargedAt = &now
+	admission.DischargedBy = &doctorID
+	admission.DischargeDisposition = input.Disposition
+	admission.DischargeNotes = input.Notes
+
+	if err := as.admissionRepository.Discharge(admission); err != nil {
+		return nil, err
+	}
+
+	return admission, nil
+}
+
+func (as *admissionService) findActiveAdmission(id uint) (*models.Admission, error) {
+	admission, err := as.admissionRepository.FindByID(id)
+	if err != nil {
+		return nil, errors.New("admission not found")
+	}
+	if admission.Status != constants.AdmissionStatus.ADMITTED {
+		return nil, errors.New("patient has already been discharged")
+	}
+	return admission, nil
+}
+
+// findFreeBed returns a bed that can take a patient, together with its ward.
+func (as *admissionService) findFreeBed(bedID uint) (*models.Bed, *models.Ward, error) {
+	bed, err := as.wardRepository.FindBedByID(bedID)
+	if err != nil {
+		return nil, nil, errors.New("bed not found")
+	}
+	if bed.Status != constants.BedStatus.AVAILABLE && bed.Status != constants.BedStatus.RESERVED {
+		return nil, nil, errors.New("bed is not available")
+	}
+
+	ward, err := as.wardRepository.FindByID(bed.WardID)
+	if err != nil || !ward.IsActive {
+		return nil, nil, errors.New("ward not found or inactive")
+	}
+	return bed, ward, nil
+}
@@ -0,0 +1,203 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestAdmitPatient(t *testing.T) {
+	ward := &models.Ward{Model: gorm.Model{ID: 2}, Name: "Male Medical", IsActive: true}
+	appointment := &models.Appointment{Model: gorm.Model{ID: 10}, PatientID: 4, Status: constants.AppointmentStatus.COMPLETED}
+
+	t.Run("FromAppointment", func(t *testing.T) {
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
+		mockWardRepo := new(mocks.WardRepository)
+		mockAppointmentRepo := new(mocks.AppointmentRepository)
+
+		service := NewAdmissionService(mockAdmissionRepo, mockWardRepo, mockAppointmentRepo,
+			new(mocks.ReferralRepository))
+
+		mockAppointmentRepo.On("FindByID", uint(10)).Return(appointment, nil)
+		mockAdmissionRepo.On("FindActiveByPatientID", uint(4)).Return(&models.Admission{}, gorm.ErrRecordNotFound)
+		mockWardRepo.On("FindBedByID", uint(7)).Return(&models.Bed{Model: gorm.Model{ID: 7}, WardID: 2, Status: constants.BedStatus.AVAILABLE}, nil)
+		mockWardRepo.On("FindByID", uint(2)).Return(ward, nil)
+		mockAdmissionRepo.On("Admit", mock.AnythingOfType("*models.Admission"), uint(3)).Return(nil)
+
+		admission, err := service.AdmitPatient(models.AdmitPatientInput{
+			AppointmentID: uintPtr(10),
+			BedID:         7,
+			Reason:        " Community-acquired pneumonia ",
+		}, 3)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(4), admission.PatientID)
+		assert.Equal(t, uint(2), admission.WardID)
+		assert.Equal(t, constants.AdmissionStatus.ADMITTED, admission.Status)
+		assert.Equal(t, "Community-acquired pneumonia", admission.Reason)
+		assert.Equal(t, constants.BedStatus.OCCUPIED, admission.Bed.Status)
+		mockAdmissionRepo.AssertExpectations(t)
+	})
+
+	t.Run("FromAcceptedReferral", func(t *testing.T) {
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
+		mockWardRepo := new(mocks.WardRepository)
+		mockReferralRepo := new(mocks.ReferralRepository)
+
+		service := NewAdmissionService(mockAdmissionRepo, mockWardRepo, new(mocks.AppointmentRepository),
+			mockReferralRepo)
+
+		mockReferralRepo.On("FindByID", uint(5)).Return(&models.Referral{
+			Model: gorm.Model{ID: 5}, PatientID: 4, Status: constants.ReferralStatus.SCHEDULED, AppointmentID: uintPtr(11),
+		}, nil)
+		mockAdmissionRepo.On("FindActiveByPatientID", uint(4)).Return(&models.Admission{}, gorm.ErrRecordNotFound)
+		mockWardRepo.On("FindBedByID", uint(7)).Return(&models.Bed{Model: gorm.Model{ID: 7}, WardID: 2, Status: constants.BedStatus.RESERVED}, nil)
+		mockWardRepo.On("FindByID", uint(2)).Return(ward, nil)
+		mockAdmissionRepo.On("Admit", mock.AnythingOfType("*models.Admission"), uint(3)).Return(nil)
+
+		admission, err := service.AdmitPatient(models.AdmitPatientInput{ReferralID: uintPtr(5), BedID: 7, Reason: "Chest pain"}, 3)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(5), *admission.ReferralID)
+		assert.Equal(t, uint(11), *admission.AppointmentID)
+	})
+
+	t.Run("PendingReferral", func(t *testing.T) {
+		mockReferralRepo := new(mocks.ReferralRepository)
+
+		service := NewAdmissionService(new(mocks.AdmissionRepository), new(mocks.WardRepository),
+			new(mocks.AppointmentRepository), mockReferralRepo)
+
+		mockReferralRepo.On("FindByID", uint(5)).Return(&models.Referral{
+			Model: gorm.Model{ID: 5}, PatientID: 4, Status: constants.ReferralStatus.PENDING,
+		}, nil)
+
+		_, err := service.AdmitPatient(models.AdmitPatientInput{ReferralID: uintPtr(5), BedID: 7, Reason: "Chest pain"}, 3)
+
+		assert.EqualError(t, err, "only accepted referrals can lead to an admission")
+	})
+
+	t.Run("AlreadyAdmitted", func(t *testing.T) {
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
+		mockAppointmentRepo := new(mocks.AppointmentRepository)
+
+		service := NewAdmissionService(mockAdmissionRepo, new(mocks.WardRepository), mockAppointmentRepo,
+			new(mocks.ReferralRepository))
+
+		mockAppointmentRepo.On("FindByID", uint(10)).Return(appointment, nil)
+		mockAdmissionRepo.On("FindActiveByPatientID", uint(4)).Return(&models.Admission{Model: gorm.Model{ID: 1}}, nil)
+
+		_, err := service.AdmitPatient(models.AdmitPatientInput{AppointmentID: uintPtr(10), BedID: 7, Reason: "Sepsis"}, 3)
+
+		assert.EqualError(t, err, "patient is already admitted")
+		mockAdmissionRepo.AssertNotCalled(t, "Admit", mock.Anything, mock.Anything)
+	})
+
+	t.Run("BedNotAvailable", func(t *testing.T) {
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
+		mockWardRepo := new(mocks.WardRepository)
+		mockAppointmentRepo := new(mocks.AppointmentRepository)
+
+		service := NewAdmissionService(mockAdmissionRepo, mockWardRepo, mockAppointmentRepo,
+			new(mocks.ReferralRepository))
+
+		mockAppointmentRepo.On("FindByID", uint(10)).Return(appointment, nil)
+		mockAdmissionRepo.On("FindActiveByPatientID", uint(4)).Return(&models.Admission{}, gorm.ErrRecordNotFound)
+		mockWardRepo.On("FindBedByID", uint(7)).Return(&models.Bed{Model: gorm.Model{ID: 7}, WardID: 2, Status: constants.BedStatus.CLEANING}, nil)
+
+		_, err := service.AdmitPatient(models.AdmitPatientInput{AppointmentID: uintPtr(10), BedID: 7, Reason: "Sepsis"}, 3)
+
+		assert.EqualError(t, err, "bed is not available")
+	})
+
+	t.Run("BedTakenConcurrently", func(t *testing.T) {
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
+		mockWardRepo := new(mocks.WardRepository)
+		mockAppointmentRepo := new(mocks.AppointmentRepository)
+
+		service := NewAdmissionService(mockAdmissionRepo, mockWardRepo, mockAppointmentRepo,
+			new(mocks.ReferralRepository))
+
+		mockAppointmentRepo.On("FindByID", uint(10)).Return(appointment, nil)
+		mockAdmissionRepo.On("FindActiveByPatientID", uint(4)).Return(&models.Admission{}, gorm.ErrRecordNotFound)
+		mockWardRepo.On("FindBedByID", uint(7)).Return(&models.Bed{Model: gorm.Model{ID: 7}, WardID: 2, Status: constants.BedStatus.AVAILABLE}, nil)
+		mockWardRepo.On("FindByID", uint(2)).Return(ward, nil)
+		mockAdmissionRepo.On("Admit", mock.AnythingOfType("*models.Admission"), uint(3)).Return(errors.New("bed is no longer available"))
+
+		_, err := service.AdmitPatient(models.AdmitPatientInput{AppointmentID: uintPtr(10), BedID: 7, Reason: "Sepsis"}, 3)
+
+		assert.EqualError(t, err, "bed is no longer available")
+	})
+}
+
+func TestTransferPatient(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
+		mockWardRepo := new(mocks.WardRepository)
+
+		service := NewAdmissionService(mockAdmissionRepo, mockWardRepo, new(mocks.AppointmentRepository),
+			new(mocks.ReferralRepository))
+
+		admission := &models.Admission{Model: gorm.Model{ID: 1}, WardID: 2, BedID: 7, Status: constants.AdmissionStatus.ADMITTED}
+		newBed := &models.Bed{Model: gorm.Model{ID: 9}, WardID: 3, Status: constants.BedStatus.AVAILABLE}
+		mockAdmissionRepo.On("FindByID", uint(1)).Return(admission, nil)
+		mockWardRepo.On("FindBedByID", uint(9)).Return(newBed, nil)
+		mockWardRepo.On("FindByID", uint(3)).Return(&models.Ward{Model: gorm.Model{ID: 3}, IsActive: true}, nil)
+		mockAdmissionRepo.On("Transfer", admission, newBed, "Needs high dependency care", uint(6)).Return(nil)
+
+		_, err := service.TransferPatient(1, models.TransferInput{BedID: 9, Reason: "Needs high dependency care"}, 6)
+
+		assert.NoError(t, err)
+		mockAdmissionRepo.AssertExpectations(t)
+	})
+
+	t.Run("SameBed", func(t *testing.T) {
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
+
+		service := NewAdmissionService(mockAdmissionRepo, new(mocks.WardRepository),
+			new(mocks.AppointmentRepository), new(mocks.ReferralRepository))
+
+		mockAdmissionRepo.On("FindByID", uint(1)).Return(&models.Admission{Model: gorm.Model{ID: 1}, BedID: 7, Status: constants.AdmissionStatus.ADMITTED}, nil)
+
+		_, err := service.TransferPatient(1, models.TransferInput{BedID: 7}, 6)
+
+		assert.EqualError(t, err, "patient is already in this bed")
+	})
+
+	t.Run("Discharged", func(t *testing.T) {
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
+
+		service := NewAdmissionService(mockAdmissionRepo, new(mocks.WardRepository),
+			new(mocks.AppointmentRepository), new(mocks.ReferralRepository))
+
+		mockAdmissionRepo.On("FindByID", uint(1)).Return(&models.Admission{Model: gorm.Model{ID: 1}, BedID: 7, Status: constants.AdmissionStatus.DISCHARGED}, nil)
+
+		_, err := service.TransferPatient(1, models.TransferInput{BedID: 9}, 6)
+
+		assert.EqualError(t, err, "patient has already been discharged")
+	})
+}
+
+func TestDischargePatient(t *testing.T) {
+	mockAdmissionRepo := new(mocks.AdmissionRepository)
+
+	service := NewAdmissionService(mockAdmissionRepo, new(mocks.WardRepository),
+		new(mocks.AppointmentRepository), new(mocks.ReferralRepository))
+
+	mockAdmissionRepo.On("FindByID", uint(1)).Return(&models.Admission{Model: gorm.Model{ID: 1}, BedID: 7, Status: constants.AdmissionStatus.ADMITTED}, nil)
+	mockAdmissionRepo.On("Discharge", mock.AnythingOfType("*models.Admission")).Return(nil)
+
+	admission, err := service.DischargePatient(1, models.DischargeInput{Disposition: constants.DischargeDisposition.HOME}, 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, constants.AdmissionStatus.DISCHARGED, admission.Status)
+	assert.NotNil(t, admission.DischargedAt)
+	assert.Equal(t, uint(3), *admission.DischargedBy)
+	mockAdmissionRepo.AssertExpectations(t)
+}
@@ -0,0 +1,234 @@
+package services
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+)
+
+// AllergyAlert describes a recorded allergy that matches a drug being ordered.
+type AllergyAlert struct {
+	AllergyID          uint   `json:"allergyId"`
+	Substance          string `json:"substance"`
+	SubstanceClass     string `json:"substanceClass,omitempty"`
+	Reaction           string `json:"reaction,omitempty"`
+	Severity           string `json:"severity"`
+	VerificationStatus string `json:"verificationStatus"`
+	MatchedOn          string `json:"matchedOn"`
+}
+
+// AllergyConflictError blocks a prescription or treatment plan until the
+// doctor supplies an override reason.
+type AllergyConflictError struct {
+	Alerts []AllergyAlert
+}
+
+func (e *AllergyConflictError) Error() string {
+	return "order conflicts with recorded patient allergies; an allergyOverrideReason is required to proceed"
+}
+
+// allergyScreen matches drugs against a patient's allergy list. It is shared
+// by the prescription and clinical note services.
+type allergyScreen struct {
+	allergyRepository    repositories.AllergyRepository
+	medicationRepository repositories.MedicationRepository
+}
+
+func (as allergyScreen) activeAllergies(patientID uint) ([]models.PatientAllergy, error) {
+	allergies, err := as.allergyRepository.FindByPatientID(patientID)
+	if err != nil {
+		return nil, err
+	}
+
+	active := make([]models.PatientAllergy, 0, len(allergies))
+	for _, allergy := range allergies {
+		if allergy.VerificationStatus == constants.AllergyVerificationStatus.REFUTED ||
+			allergy.VerificationStatus == constants.AllergyVerificationStatus.ENTERED_IN_ERROR {
+			continue
+		}
+		active = append(active, allergy)
+	}
+	return active, nil
+}
+
+func (as allergyScreen) screenMedication(patientID uint, medication *models.Medication) ([]AllergyAlert, error) {
+	allergies, err := as.activeAllergies(patientID)
+	if err != nil || len(allergies) == 0 {
+		return nil, err
+	}
+	return matchMedicationAllergies(allergies, medication), nil
+}
+
+// screenText looks for allergy terms written directly into free text, and for
+// catalog drugs named in the text whose class matches a recorded allergy.
+// Terms and drug names are matched on whole words.
+func (as allergyScreen) screenText(patientID uint, text string) ([]AllergyAlert, error) {
+	words := termWords(text)
+	if len(words) == 0 {
+		return nil, nil
+	}
+
+	allergies, err := as.activeAllergies(patientID)
+	if err != nil || len(allergies) == 0 {
+		return nil, err
+	}
+
+	seen := make(map[uint]bool)
+	var alerts []AllergyAlert
+	for _, allergy := range allergies {
+		for _, term := range []string{allergy.Substance, allergy.SubstanceClass} {
+			if containsTerm(words, term) {
+				alerts = append(alerts, newAllergyAlert(allergy, term))
+				seen[allergy.ID] = true
+				break
+			}
+		}
+	}
+
+	medications, err := as.medicationRepository.FindByNames(termPhrases(words, maxMedicationNameWords))
+	if err != nil {
+		return nil, err
+	}
+	for i := range medications {
+		for _, alert := range matchMedicationAllergies(allergies, &medications[i]) {
+			if !seen[alert.AllergyID] {
+				alerts = append(alerts, alert)
+				seen[alert.AllergyID] = true
+			}
+		}
+	}
+
+	return alerts, nil
+}
+
+// allergyOverrideEntries builds an audit entry for each allergy alert the
+// staff member overrode. They are saved with the record, which gives them its
+// ID.
+func allergyOverrideEntries(alerts []AllergyAlert, entityType string, patientID uint, staffID uint,
+	reason string) ([]*models.AuditLog, error) {
+	var entries []*models.AuditLog
+	for _, alert := range alerts {
+		details, err := json.Marshal(alert)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, &models.AuditLog{
+			Action:     constants.AuditActions.ALLERGY_OVERRIDE,
+			EntityType: entityType,
+			PatientID:  &patientID,
+			StaffID:    staffID,
+			Reason:     reason,
+			Details:    string(details),
+		})
+	}
+	return entries, nil
+}
+
+func matchMedicationAllergies(allergies []models.PatientAllergy, medication *models.Medication) []AllergyAlert {
+	drugTerms := []string{medication.Name, medication.GenericName, medication.DrugClass}
+
+	var alerts []AllergyAlert
+	for _, allergy := range allergies {
+		if matched := matchAllergyTerms(allergy, drugTerms); matched != "" {
+			alerts = append(alerts, newAllergyAlert(allergy, matched))
+		}
+	}
+	return alerts
+}
+
+// matchAllergyTerms compares the allergy's substance and class with the
+// drug's names and class on whole words, either way round, so a "penicillin"
+// allergy matches "Penicillins" and "Amoxicillin/Penicillin" but a "sulfa"
+// allergy does not match "Ferrous Sulfate".
+func matchAllergyTerms(allergy models.PatientAllergy, drugTerms []string) string {
+	for _, allergyTerm := range []string{allergy.Substance, allergy.SubstanceClass} {
+		allergyWords := termWords(allergyTerm)
+		if len(allergyWords) == 0 {
+			continue
+		}
+		for _, drugTerm := range drugTerms {
+			drugWords := termWords(drugTerm)
+			if len(drugWords) == 0 {
+				continue
+			}
+			if containsTerm(drugWords, allergyTerm) || containsTerm(allergyWords, drugTerm) {
+				return strings.ToLower(strings.TrimSpace(drugTerm))
+			}
+		}
+	}
+	return ""
+}
+
+// maxMedicationNameWords is the longest catalog drug name, in words, that
+// screenText looks for in free text.
+const maxMedicationNameWords = 4
+
+// termWords splits text into lower-case words of letters and digits, so terms
+// are matched on word boundaries: "sulfa" is not found in "ferrous sulfate".
+func termWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// containsTerm reports whether the words of term appear together and in order
+// among words. Words are compared in the singular, so drug class names match
+// whether they are recorded as "penicillin" or "penicillins".
+func containsTerm(words []string, term string) bool {
+	want := termWords(term)
+	if len(want) == 0 {
+		return false
+	}
+	for i := 0; i+len(want) <= len(words); i++ {
+		if slices.EqualFunc(words[i:i+len(want)], want, func(a, b string) bool {
+			return singularWord(a) == singularWord(b)
+		}) {
+			return true
+		}
+	}
+	return false
+}
+
+// singularWord drops a plural "s" from a lower-case word. Words ending in
+// "ss", "us" or "is", such as "ferrous" or "class", are left as they are.
+func singularWord(word string) string {
+	if len(word) <= 3 || !strings.HasSuffix(word, "s") ||
+		strings.HasSuffix(word, "ss") || strings.HasSuffix(word, "us") || strings.HasSuffix(word, "is") {
+		return word
+	}
+	return strings.TrimSuffix(word, "s")
+}
+
+// termPhrases lists each run of up to maxWords consecutive words, joined by
+// single spaces, once.
+func termPhrases(words []string, maxWords int) []string {
+	seen := make(map[string]bool)
+	phrases := []string{}
+	for i := range words {
+		for n := 1; n <= maxWords && i+n <= len(words); n++ {
+			phrase := strings.Join(words[i:i+n], " ")
+			if !seen[phrase] {
+				seen[phrase] = true
+				phrases = append(phrases, phrase)
+			}
+		}
+	}
+	return phrases
+}
+
+func newAllergyAlert(allergy models.PatientAllergy, matchedOn string) AllergyAlert {
+	return AllergyAlert{
+		AllergyID:          allergy.ID,
+		Substance:          allergy.Substance,
+		SubstanceClass:     allergy.SubstanceClass,
+		Reaction:           allergy.Reaction,
+		Severity:           allergy.Severity,
+		VerificationStatus: allergy.VerificationStatus,
+		MatchedOn:          strings.ToLower(matchedOn),
+	}
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+)
+
+type AllergyService interface {
+	CreateAllergy(patientID uint, input models.CreateAllergyInput, recordedBy uint) (*models.PatientAllergy, error)
+	GetAllergiesByPatientID(patientID uint) ([]models.PatientAllergy, error)
+	UpdateAllergy(patientID uint, allergyID uint, input models.UpdateAllergyInput, updatedBy uint) (*models.PatientAllergy, error)
+}
+
+type allergyService struct {
+	allergyRepository repositories.AllergyRepository
+	patientRepository repositories.PatientRepository
+}
+
+func NewAllergyService(
+	allergyRepository repositories.AllergyRepository,
+	patientRepository repositories.PatientRepository,
+) AllergyService {
+	return &allergyService{
+		allergyRepository: allergyRepository,
+		patientRepository: patientRepository,
+	}
+}
+
+func (as *allergyService) CreateAllergy(patientID uint, input models.CreateAllergyInput, recordedBy uint) (*models.PatientAllergy, error) {
+	patient, err := as.patientRepository.FindByID(patientID)
+	if err != nil || patient == nil {
+		return nil, errors.New("patient record not found")
+	}
+
+	verificationStatus := input.VerificationStatus
+	if verificationStatus == "" {
+		verificationStatus = constants.AllergyVerificationStatus.UNCONFIRMED
+	}
+
+	allergy := &models.PatientAllergy{
+		PatientID:          patientID,
+		Substance:          strings.TrimSpace(input.Substance),
+		SubstanceClass:     strings.TrimSpace(input.SubstanceClass),
+		Reaction:           input.Reaction,
+		Severity:           input.Severity,
+		VerificationStatus: verificationStatus,
+		RecordedBy:         recordedBy,
+		UpdatedBy:          recordedBy,
+	}
+
+	if err := as.allergyRepository.Create(allergy); err != nil {
+		return nil, err
+	}
+
+	return allergy, nil
+}
+
+func (as *allergyService) GetAllergiesByPatientID(patientID uint) ([]models.PatientAllergy, error) {
+	patient, err := as.patientRepository.FindByID(patientID)
+	if err != nil || patient == nil {
+		return nil, errors.New("patient record not found")
+	}
+
+	return as.allergyRepository.FindByPatientID(patientID)
+}
+
+func (as *allergyService) UpdateAllergy(patientID uint, allergyID uint, input models.UpdateAllergyInput, updatedBy uint) (*models.PatientAllergy, error) {
+	allergy, err := as.allergyRepository.FindByID(allergyID)
+	if err != nil || allergy.PatientID != patientID {
+		return nil, errors.New("allergy record not found")
+	}
+
+	if input.Substance != nil {
+		allergy.Substance = strings.TrimSpace(*input.Substance)
+	}
+	if input.SubstanceClass != nil {
+		allergy.SubstanceClass = strings.TrimSpace(*input.SubstanceClass)
+	}
+	if input.Reaction != nil {
+		allergy.Reaction = *input.Reaction
+	}
+	if input.Severity != nil {
+		allergy.Severity = *input.Severity
+	}
+	if input.VerificationStatus != nil {
+		allergy.VerificationStatus = *input.VerificationStatus
+	}
+	allergy.UpdatedBy = updatedBy
+
+	if err := as.allergyRepository.Update(allergy); err != nil {
+		return nil, err
+	}
+
+	return allergy, nil
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestCreateAllergy(t *testing.T) {
+	t.Run("DefaultsToUnconfirmed", func(t *testing.T) {
+		mockAllergyRepo := new(mocks.AllergyRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		service := NewAllergyService(mockAllergyRepo, mockPatientRepo)
+
+		input := models.CreateAllergyInput{
+			Substance: "Penicillin",
+			Reaction:  "Urticaria",
+			Severity:  constants.AllergySeverity.MODERATE,
+		}
+
+		mockPatientRepo.On("FindByID", uint(1)).Return(&models.Patient{Model: gorm.Model{ID: 1}}, nil)
+		mockAllergyRepo.On("Create", mock.AnythingOfType("*models.PatientAllergy")).Return(nil).Run(func(args mock.Arguments) {
+			allergy := args.Get(0).(*models.PatientAllergy)
+			assert.Equal(t, uint(1), allergy.PatientID)
+			assert.Equal(t, constants.AllergyVerificationStatus.UNCONFIRMED, allergy.VerificationStatus)
+			assert.Equal(t, uint(5), allergy.RecordedBy)
+		})
+
+		result, err := service.CreateAllergy(1, input, 5)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		mockAllergyRepo.AssertExpectations(t)
+	})
+
+	t.Run("PatientNotFound", func(t *testing.T) {
+		mockAllergyRepo := new(mocks.AllergyRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		service := NewAllergyService(mockAllergyRepo, mockPatientRepo)
+
+		mockPatientRepo.On("FindByID", uint(1)).Return(&models.Patient{}, errors.New("not found"))
+
+		result, err := service.CreateAllergy(1, models.CreateAllergyInput{Substance: "Latex"}, 5)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Equal(t, "patient record not found", err.Error())
+		mockAllergyRepo.AssertNotCalled(t, "Create")
+	})
+}
+
+func TestUpdateAllergy(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockAllergyRepo := new(mocks.AllergyRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		service := NewAllergyService(mockAllergyRepo, mockPatientRepo)
+
+		existing := &models.PatientAllergy{Model: gorm.Model{ID: 3}, PatientID: 1,
+			VerificationStatus: constants.AllergyVerificationStatus.UNCONFIRMED}
+		confirmed := constants.AllergyVerificationStatus.CONFIRMED
+
+		mockAllergyRepo.On("FindByID", uint(3)).Return(existing, nil)
+		mockAllergyRepo.On("Update", mock.AnythingOfType("*models.PatientAllergy")).Return(nil)
+
+		result, err := service.UpdateAllergy(1, 3, models.UpdateAllergyInput{VerificationStatus: &confirmed}, 6)
+
+		assert.NoError(t, err)
+		assert.Equal(t, confirmed, result.VerificationStatus)
+		assert.Equal(t, uint(6), result.UpdatedBy)
+	})
+
+	t.Run("BelongsToAnotherPatient", func(t *testing.T) {
+		mockAllergyRepo := new(mocks.AllergyRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		service := NewAllergyService(mockAllergyRepo, mockPatientRepo)
+
+		mockAllergyRepo.On("FindByID", uint(3)).Return(&models.PatientAllergy{Model: gorm.Model{ID: 3}, PatientID: 2}, nil)
+
+		result, err := service.UpdateAllergy(1, 3, models.UpdateAllergyInput{}, 6)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Equal(t, "allergy record not found", err.Error())
+		mockAllergyRepo.AssertNotCalled(t, "Update")
+	})
+}
@@ -1,7 +1,9 @@
 package services
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/ofojichigozie/hms-go-backend/constants"
@@ -18,17 +20,23 @@ type AppointmentService interface {
 }
 
 type appointmentService struct {
-	appointmentRepository repositories.AppointmentRepository
-	patientRepository     repositories.PatientRepository
+	appointmentRepository     repositories.AppointmentRepository
+	patientRepository         repositories.PatientRepository
+	patientCoverageRepository repositories.PatientCoverageRepository
+	auditLogRepository        repositories.AuditLogRepository
 }
 
 func NewAppointmentService(
 	appointmentRepository repositories.AppointmentRepository,
 	patientRepository repositories.PatientRepository,
+	patientCoverageRepository repositories.PatientCoverageRepository,
+	auditLogRepository repositories.AuditLogRepository,
 ) AppointmentService {
 	return &appointmentService{
-		appointmentRepository: appointmentRepository,
-		patientRepository:     patientRepository,
+		appointmentRepository:     appointmentRepository,
+		patientRepository:         patientRepository,
+		patientCoverageRepository: patientCoverageRepository,
+		auditLogRepository:        auditLogRepository,
 	}
 }
 
@@ -52,6 +60,11 @@ func (as *appointmentService) CreateAppointment(input models.CreateAppointmentIn
 		return nil, err
 	}
 
+	// Coverage problems do not block booking; reception is warned instead.
+	if coverages, err := as.patientCoverageRepository.FindByPatientID(patient.ID); err == nil {
+		appointment.Warnings = coverageWarnings(coverages, appointment.ScheduledAt)
+	}
+
 	return appointment, nil
 }
 
@@ -75,6 +88,7 @@ func (as *appointmentService) UpdateAppointment(id uint, input models.UpdateAppo
 	if input.Department != nil {
 		appointment.Department = *input.Department
 	}
+	previousStatus := appointment.Status
 	if input.Status != nil {
 		appointment.Status = *input.Status
 	}
@@ -88,9 +102,48 @@ func (as *appointmentService) UpdateAppointment(id uint, input models.UpdateAppo
 		return nil, err
 	}
 
+	if err := auditAppointmentStatusChange(as.auditLogRepository, appointment, previousStatus, updatedBy); err != nil {
+		return nil, err
+	}
+
 	return appointment, nil
 }
 
+// auditAppointmentStatusChange writes the APPOINTMENT_STATUS_CHANGE entry for
+// an appointment saved with a new status. It does nothing when the status is
+// unchanged.
+func auditAppointmentStatusChange(auditLogRepository repositories.AuditLogRepository,
+	appointment *models.Appointment, previousStatus string, staffID uint) error {
+	if appointment.Status == previousStatus {
+		return nil
+	}
+
+	entry, err := appointmentStatusChangeEntry(appointment, previousStatus, staffID)
+	if err != nil {
+		return err
+	}
+	if err := auditLogRepository.Create(entry); err != nil {
+		return fmt.Errorf("appointment updated but the audit entry failed: %w", err)
+	}
+	return nil
+}
+
+func appointmentStatusChangeEntry(appointment *models.Appointment, previousStatus string,
+	staffID uint) (*models.AuditLog, error) {
+	details, err := json.Marshal(models.FieldChange{From: previousStatus, To: appointment.Status})
+	if err != nil {
+		return nil, err
+	}
+	return &models.AuditLog{
+		Action:     constants.AuditActions.APPOINTMENT_STATUS_CHANGE,
+		EntityType: constants.AuditEntities.APPOINTMENT,
+		EntityID:   appointment.ID,
+		PatientID:  &appointment.PatientID,
+		StaffID:    staffID,
+		Details:    string(details),
+	}, nil
+}
+
 func (as *appointmentService) DeleteAppointment(id uint) error {
 	appointment, err := as.appointmentRepository.FindByID(id)
 	if err != nil {
@@ -17,8 +17,9 @@ func TestCreateAppointment(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		mockAppointmentRepo := new(mocks.AppointmentRepository)
 		mockPatientRepo := new(mocks.PatientRepository)
+		mockCoverageRepo := new(mocks.PatientCoverageRepository)
 
-		service := NewAppointmentService(mockAppointmentRepo, mockPatientRepo)
+		service := NewAppointmentService(mockAppointmentRepo, mockPatientRepo, mockCoverageRepo, new(mocks.AuditLogRepository))
 
 		input := models.CreateAppointmentInput{
 			PatientID:  1,
@@ -45,20 +46,59 @@ func TestCreateAppointment(t *testing.T) {
 			assert.Equal(t, constants.AppointmentStatus.SCHEDULED, appointment.Status)
 			assert.WithinDuration(t, time.Now(), appointment.ScheduledAt, time.Second)
 		})
+		mockCoverageRepo.On("FindByPatientID", uint(1)).Return([]models.PatientCoverage{}, nil)
 
 		result, err := service.CreateAppointment(input, createdBy)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
+		assert.Empty(t, result.Warnings)
 		mockPatientRepo.AssertExpectations(t)
 		mockAppointmentRepo.AssertExpectations(t)
 	})
 
+	t.Run("WarnsOnExpiredCoverage", func(t *testing.T) {
+		mockAppointmentRepo := new(mocks.AppointmentRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockCoverageRepo := new(mocks.PatientCoverageRepository)
+
+		service := NewAppointmentService(mockAppointmentRepo, mockPatientRepo, mockCoverageRepo, new(mocks.AuditLogRepository))
+
+		expiredOn := time.Now().AddDate(0, -1, 0)
+		coverages := []models.PatientCoverage{{
+			PatientID:    1,
+			MemberNumber: "HMO-123",
+			IsActive:     true,
+			ValidFrom:    expiredOn.AddDate(-1, 0, 0),
+			ValidTo:      &expiredOn,
+			Plan: &models.InsurancePlan{
+				Name:  "Gold",
+				Payer: &models.Payer{Name: "Acme HMO"},
+			},
+		}}
+
+		mockPatientRepo.On("FindByID", uint(1)).Return(&models.Patient{Model: gorm.Model{ID: 1}}, nil)
+		mockAppointmentRepo.On("Create", mock.AnythingOfType("*models.Appointment")).Return(nil)
+		mockCoverageRepo.On("FindByPatientID", uint(1)).Return(coverages, nil)
+
+		result, err := service.CreateAppointment(models.CreateAppointmentInput{
+			PatientID:  1,
+			Department: "cardiology",
+			Duration:   30,
+		}, 2)
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Warnings, 1)
+		assert.Contains(t, result.Warnings[0], "expired on "+expiredOn.Format("2006-01-02"))
+		assert.Contains(t, result.Warnings[0], "HMO-123")
+	})
+
 	t.Run("PatientNotFound", func(t *testing.T) {
 		mockAppointmentRepo := new(mocks.AppointmentRepository)
 		mockPatientRepo := new(mocks.PatientRepository)
+		mockCoverageRepo := new(mocks.PatientCoverageRepository)
 
-		service := NewAppointmentService(mockAppointmentRepo, mockPatientRepo)
+		service := NewAppointmentService(mockAppointmentRepo, mockPatientRepo, mockCoverageRepo, new(mocks.AuditLogRepository))
 
 		input := models.CreateAppointmentInput{
 			PatientID:  1,
@@ -81,8 +121,9 @@ func TestCreateAppointment(t *testing.T) {
 	t.Run("RepositoryError", func(t *testing.T) {
 		mockAppointmentRepo := new(mocks.AppointmentRepository)
 		mockPatientRepo := new(mocks.PatientRepository)
+		mockCoverageRepo := new(mocks.PatientCoverageRepository)
 
-		service := NewAppointmentService(mockAppointmentRepo, mockPatientRepo)
+		service := NewAppointmentService(mockAppointmentRepo, mockPatientRepo, mockCoverageRepo, new(mocks.AuditLogRepository))
 
 		input := models.CreateAppointmentInput{
 			PatientID:  1,
@@ -113,8 +154,9 @@ func TestGetAllAppointments(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		mockAppointmentRepo := new(mocks.AppointmentRepository)
 		mockPatientRepo := new(mocks.PatientRepository)
+		mockCoverageRepo := new(mocks.PatientCoverageRepository)
 
-		service := NewAppointmentService(mockAppointmentRepo, mockPatientRepo)
+		service := NewAppointmentService(mockAppointmentRepo, mockPatientRepo, mockCoverageRepo, new(mocks.AuditLogRepository))
 
 		filters := map[string]interface{}{"status": "scheduled"}
 		expectedAppointments := []models.Appointment{
@@ -137,8 +179,9 @@ func TestGetAllAppointments(t *testing.T) {
 	t.Run("RepositoryError", func(t *testing.T) {
 		mockAppointmentRepo := new(mocks.AppointmentRepository)
 		mockPatientRepo := new(mocks.PatientRepository)
+		mockCoverageRepo := new(mocks.PatientCoverageRepository)
 
-		service := NewAppointmentService(mockAppointmentRepo, mockPatientRepo)
+		service := NewAppointmentService(mockAppointmentRepo, mockPatientRepo, mockCoverageRepo, new(mocks.AuditLogRepository))
 
 		filters := map[string]interface{}{}
 		mockAppointmentRepo.On("FindAll", filters).Return([]models.Appointment{}, errors.New("database error"))
@@ -156,8 +199,9 @@ func TestGetAppointmentByID(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		mockAppointmentRepo := new(mocks.AppointmentRepository)
 		mockPatientRepo := new(mocks.PatientRepository)
+		mockCoverageRepo := new(mocks.PatientCoverageRepository)
 
-		service := NewAppointmentService(mockAppointmentRepo, mockPatientRepo)
+		service := NewAppointmentService(mockAppointmentRepo, mockPatientRepo, mockCoverageRepo, new(mocks.AuditLogRepository))
 
 		expectedAppointment := &models.Appointment{
 			Model:      gorm.Model{ID: 1},
@@ -177,8 +221,9 @@ func TestGetAppointmentByID(t *testing.T) {
 	t.Run("NotFound", func(t *testing.T) {
 		mockAppointmentRepo := new(mocks.AppointmentRepository)
 		mockPatientRepo := new(mocks.PatientRepository)
+		mockCoverageRepo := new(mocks.PatientCoverageRepository)
 
-		service := NewAppointmentService(mockAppointmentRepo, mockPatientRepo)
+		service := NewAppointmentService(mockAppointmentRepo, mockPatientRepo, mockCoverageRepo, new(mocks.AuditLogRepository))
 
 		mockAppointmentRepo.On("FindByID", uint(1)).Return(&models.Appointment{}, errors.New("not found"))
 
@@ -194,8 +239,10 @@ func TestUpdateAppointment(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		mockAppointmentRepo := new(mocks.AppointmentRepository)
 		mockPatientRepo := new(mocks.PatientRepository)
+		mockCoverageRepo := new(mocks.PatientCoverageRepository)
+		mockAuditRepo := new(mocks.AuditLogRepository)
 
-		service := NewAppointmentService(mockAppointmentRepo, mockPatientRepo)
+		service := NewAppointmentService(mockAppointmentRepo, mockPatientRepo, mockCoverageRepo, mockAuditRepo)
 
 		existingAppointment := &models.Appointment{
 			Model:      gorm.Model{ID: 1},
@@ -218,6 +265,11 @@ func TestUpdateAppointment(t *testing.T) {
 
 		mockAppointmentRepo.On("FindByID", uint(1)).Return(existingAppointment, nil)
 		mockAppointmentRepo.On("Update", mock.AnythingOfType("*models.Appointment")).Return(nil)
+		mockAuditRepo.On("Create", mock.MatchedBy(func(entry *models.AuditLog) bool {
+			return entry.Action == constants.AuditActions.APPOINTMENT_STATUS_CHANGE &&
+				entry.EntityID == 1 && *entry.PatientID == 1 &&
+				entry.Details == `{"from":"scheduled","to":"completed"}`
+		})).Return(nil)
 
 		result, err := service.UpdateAppointment(1, input, updatedBy)
 
@@ -227,13 +279,15 @@ func TestUpdateAppointment(t *testing.T) {
 		assert.Equal(t, newReason, result.Reason)
 		assert.Equal(t, updatedBy, result.UpdatedBy)
 		mockAppointmentRepo.AssertExpectations(t)
+		mockAuditRepo.AssertExpectations(t)
 	})
 
 	t.Run("AppointmentNotFound", func(t *testing.T) {
 		mockAppointmentRepo := new(mocks.AppointmentRepository)
 		mockPatientRepo := new(mocks.PatientRepository)
+		mockCoverageRepo := new(mocks.PatientCoverageRepository)
 
-		service := NewAppointmentService(mockAppointmentRepo, mockPatientRepo)
+		service := NewAppointmentService(mockAppointmentRepo, mockPatientRepo, mockCoverageRepo, new(mocks.AuditLogRepository))
 
 		mockAppointmentRepo.On("FindByID", uint(1)).Return(&models.Appointment{}, errors.New("not found"))
 
@@ -253,8 +307,9 @@ func TestUpdateAppointment(t *testing.T) {
 	t.Run("RepositoryUpdateError", func(t *testing.T) {
 		mockAppointmentRepo := new(mocks.AppointmentRepository)
 		mockPatientRepo := new(mocks.PatientRepository)
+		mockCoverageRepo := new(mocks.PatientCoverageRepository)
 
-		service := NewAppointmentService(mockAppointmentRepo, mockPatientRepo)
+		service := NewAppointmentService(mockAppointmentRepo, mockPatientRepo, mockCoverageRepo, new(mocks.AuditLogRepository))
 
 		existingAppointment := &models.Appointment{
 			Model:      gorm.Model{ID: 1},
@@ -283,8 +338,9 @@ func TestDeleteAppointment(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		mockAppointmentRepo := new(mocks.AppointmentRepository)
 		mockPatientRepo := new(mocks.PatientRepository)
+		mockCoverageRepo := new(mocks.PatientCoverageRepository)
 
-		service := NewAppointmentService(mockAppointmentRepo, mockPatientRepo)
+		service := NewAppointmentService(mockAppointmentRepo, mockPatientRepo, mockCoverageRepo, new(mocks.AuditLogRepository))
 
 		existingAppointment := &models.Appointment{
 			Model:     gorm.Model{ID: 1},
@@ -304,8 +360,9 @@ func TestDeleteAppointment(t *testing.T) {
 	t.Run("AppointmentNotFound", func(t *testing.T) {
 		mockAppointmentRepo := new(mocks.AppointmentRepository)
 		mockPatientRepo := new(mocks.PatientRepository)
+		mockCoverageRepo := new(mocks.PatientCoverageRepository)
 
-		service := NewAppointmentService(mockAppointmentRepo, mockPatientRepo)
+		service := NewAppointmentService(mockAppointmentRepo, mockPatientRepo, mockCoverageRepo, new(mocks.AuditLogRepository))
 
 		mockAppointmentRepo.On("FindByID", uint(1)).Return(&models.Appointment{}, errors.New("appointment not found"))
 
@@ -320,8 +377,9 @@ func TestDeleteAppointment(t *testing.T) {
 	t.Run("CompletedAppointment", func(t *testing.T) {
 		mockAppointmentRepo := new(mocks.AppointmentRepository)
 		mockPatientRepo := new(mocks.PatientRepository)
+		mockCoverageRepo := new(mocks.PatientCoverageRepository)
 
-		service := NewAppointmentService(mockAppointmentRepo, mockPatientRepo)
+		service := NewAppointmentService(mockAppointmentRepo, mockPatientRepo, mockCoverageRepo, new(mocks.AuditLogRepository))
 
 		existingAppointment := &models.Appointment{
 			Model:     gorm.Model{ID: 1},
@@ -342,8 +400,9 @@ func TestDeleteAppointment(t *testing.T) {
 	t.Run("RepositoryDeleteError", func(t *testing.T) {
 		mockAppointmentRepo := new(mocks.AppointmentRepository)
 		mockPatientRepo := new(mocks.PatientRepository)
+		mockCoverageRepo := new(mocks.PatientCoverageRepository)
 
-		service := NewAppointmentService(mockAppointmentRepo, mockPatientRepo)
+		service := NewAppointmentService(mockAppointmentRepo, mockPatientRepo, mockCoverageRepo, new(mocks.AuditLogRepository))
 
 		existingAppointment := &models.Appointment{
 			Model:     gorm.Model{ID: 1},
@@ -0,0 +1,22 @@
+package services
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+)
+
+type AuditLogService interface {
+	GetAuditLogs(filters map[string]interface{}) ([]models.AuditLog, error)
+}
+
+type auditLogService struct {
+	auditLogRepository repositories.AuditLogRepository
+}
+
+func NewAuditLogService(auditLogRepository repositories.AuditLogRepository) AuditLogService {
+	return &auditLogService{auditLogRepository: auditLogRepository}
+}
+
+func (as *auditLogService) GetAuditLogs(filters map[string]interface{}) ([]models.AuditLog, error) {
+	return as.auditLogRepository.FindAll(filters)
+}
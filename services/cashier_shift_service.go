@@ -0,0 +1,162 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+)
+
+type CashierShiftService interface {
+	OpenShift(input models.OpenCashierShiftInput, staffID uint) (*models.CashierShift, error)
+	GetCurrentShift(staffID uint) (*models.CashierShiftReport, error)
+	CloseShift(input models.CloseCashierShiftInput, staffID uint) (*models.CashierShiftReport, error)
+	GetShifts(query models.CashierShiftQuery) ([]models.CashierShift, int64, error)
+	GetShiftReport(id uint) (*models.CashierShiftReport, error)
+}
+
+type cashierShiftService struct {
+	cashierShiftRepository repositories.CashierShiftRepository
+	paymentRepository      repositories.PaymentRepository
+}
+
+func NewCashierShiftService(
+	cashierShiftRepository repositories.CashierShiftRepository,
+	paymentRepository repositories.PaymentRepository,
+) CashierShiftService {
+	return &cashierShiftService{
+		cashierShiftRepository: cashierShiftRepository,
+		paymentRepository:      paymentRepository,
+	}
+}
+
+// OpenShift starts a shift with the cash float handed to the cashier. A
+// staff member has at most one open shift.
+func (cs *cashierShiftService) OpenShift(input models.OpenCashierShiftInput, staffID uint) (*models.CashierShift, error) {
+	if _, err := cs.cashierShiftRepository.FindOpenByStaffID(staffID); err == nil {
+		return nil, errors.New("you already have an open shift")
+	}
+
+	shift := &models.CashierShift{
+		StaffID:      staffID,
+		Status:       constants.CashierShiftStatus.OPEN,
+		OpenedAt:     time.Now(),
+		OpeningFloat: roundMoney(input.OpeningFloat),
+	}
+
+	if err := cs.cashierShiftRepository.Create(shift); err != nil {
+		return nil, err
+	}
+
+	return shift, nil
+}
+
+func (cs *cashierShiftService) GetCurrentShift(staffID uint) (*models.CashierShiftReport, error) {
+	shift, err := cs.cashierShiftRepository.FindOpenByStaffID(staffID)
+	if err != nil {
+		return nil, errors.New("you have no open shift")
+	}
+	return cs.report(shift)
+}
+
+// CloseShift reconciles the cash counted in the drawer against the cash the
+// shift should hold. A positive variance is an overage, a negative one a
+// shortage.
+func (cs *cashierShiftService) CloseShift(input models.CloseCashierShiftInput, staffID uint) (*models.CashierShiftReport, error) {
+	shift, err := cs.cashierShiftRepository.FindOpenByStaffID(staffID)
+	if err != nil {
+		return nil, errors.New("you have no open shift")
+	}
+
+	report, err := cs.report(shift)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	expected := report.ExpectedCash
+	counted := roundMoney(*input.CountedCash)
+	variance := roundMoney(counted - expected)
+	shift.Status = constants.CashierShiftStatus.CLOSED
+	shift.ClosedAt = &now
+	shift.ExpectedCash = &expected
+	shift.CountedCash = &counted
+	shift.Variance = &variance
+	shift.Notes = strings.TrimSpace(input.Notes)
+
+	if err := cs.cashierShiftRepository.Close(shift); err != nil {
+		return nil, err
+	}
+
+	report.Shift = *shift
+	return report, nil
+}
+
+func (cs *cashierShiftService) GetShifts(query models.CashierShiftQuery) ([]models.CashierShift, int64, error) {
+	return cs.cashierShiftRepository.FindAll(query)
+}
+
+func (cs *cashierShiftService) GetShiftReport(id uint) (*models.CashierShiftReport, error) {
+	shift, err := cs.cashierShiftRepository.FindByID(id)
+	if err != nil {
+		return nil, errors.New("shift not found")
+	}
+	return cs.report(shift)
+}
+
+func (cs *cashierShiftService) report(shift *models.CashierShift) (*models.CashierShiftReport, error) {
+	payments, err := cs.paymentRepository.FindByShiftID(shift.ID)
+	if err != nil {
+		return nil, err
+	}
+	refunds, err := cs.paymentRepository.FindRefundsByShiftID(shift.ID)
+	if err != nil {
+		return nil, err
+	}
+	return summarizeShift(shift, payments, refunds), nil
+}
+
+// summarizeShift totals a shift's payments and refunds by method. Refunds
+// count against the shift they were paid out in, which need not be the
+// shift that took the payment.
+func summarizeShift(shift *models.CashierShift, payments []models.Payment, refunds []models.Refund) *models.CashierShiftReport {
+	methods := constants.PaymentMethods
+	totals := []models.PaymentMethodTotal{}
+	for _, method := range []string{methods.CASH, methods.CARD, methods.TRANSFER, methods.INSURANCE} {
+		total := models.PaymentMethodTotal{Method: method}
+		for _, payment := range payments {
+			if payment.Method == method {
+				total.Payments++
+				total.Received += payment.Amount
+			}
+		}
+		for _, refund := range refunds {
+			if refund.Method == method {
+				total.Refunded += refund.Amount
+			}
+		}
+		if total.Payments == 0 && total.Refunded == 0 {
+			continue
+		}
+		total.Received = roundMoney(total.Received)
+		total.Refunded = roundMoney(total.Refunded)
+		total.Net = roundMoney(total.Received - total.Refunded)
+		totals = append(totals, total)
+	}
+
+	expected := shift.OpeningFloat
+	for _, total := range totals {
+		if total.Method == methods.CASH {
+			expected += total.Net
+		}
+	}
+
+	return &models.CashierShiftReport{
+		Shift:        *shift,
+		Totals:       totals,
+		ExpectedCash: roundMoney(expected),
+	}
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestSummarizeShift(t *testing.T) {
+	methods := constants.PaymentMethods
+	shift := &models.CashierShift{OpeningFloat: 1000}
+	payments := []models.Payment{
+		{Method: methods.CASH, Amount: 2500},
+		{Method: methods.CASH, Amount: 1200.5},
+		{Method: methods.CARD, Amount: 8000},
+	}
+	refunds := []models.Refund{
+		{Method: methods.CASH, Amount: 700},
+		{Method: methods.TRANSFER, Amount: 300},
+	}
+
+	report := summarizeShift(shift, payments, refunds)
+
+	assert.Equal(t, []models.PaymentMethodTotal{
+		{Method: methods.CASH, Payments: 2, Received: 3700.5, Refunded: 700, Net: 3000.5},
+		{Method: methods.CARD, Payments: 1, Received: 8000, Net: 8000},
+		{Method: methods.TRANSFER, Refunded: 300, Net: -300},
+	}, report.Totals)
+	assert.Equal(t, 4000.5, report.ExpectedCash)
+}
+
+func TestOpenShift(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockCashierShiftRepo := new(mocks.CashierShiftRepository)
+
+		service := NewCashierShiftService(mockCashierShiftRepo, new(mocks.PaymentRepository))
+
+		mockCashierShiftRepo.On("FindOpenByStaffID", uint(9)).Return(&models.CashierShift{}, gorm.ErrRecordNotFound)
+		mockCashierShiftRepo.On("Create", mock.AnythingOfType("*models.CashierShift")).Return(nil)
+
+		shift, err := service.OpenShift(models.OpenCashierShiftInput{OpeningFloat: 5000}, 9)
+
+		assert.NoError(t, err)
+		assert.Equal(t, constants.CashierShiftStatus.OPEN, shift.Status)
+		assert.Equal(t, 5000.0, shift.OpeningFloat)
+		assert.Equal(t, uint(9), shift.StaffID)
+	})
+
+	t.Run("AlreadyOpen", func(t *testing.T) {
+		mockCashierShiftRepo := new(mocks.CashierShiftRepository)
+
+		service := NewCashierShiftService(mockCashierShiftRepo, new(mocks.PaymentRepository))
+
+		mockCashierShiftRepo.On("FindOpenByStaffID", uint(9)).Return(&models.CashierShift{Model: gorm.Model{ID: 12}}, nil)
+
+		_, err := service.OpenShift(models.OpenCashierShiftInput{}, 9)
+
+		assert.EqualError(t, err, "you already have an open shift")
+		mockCashierShiftRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestCloseShift(t *testing.T) {
+	t.Run("Shortage", func(t *testing.T) {
+		mockCashierShiftRepo := new(mocks.CashierShiftRepository)
+		mockPaymentRepo := new(mocks.PaymentRepository)
+
+		service := NewCashierShiftService(mockCashierShiftRepo, mockPaymentRepo)
+
+		mockCashierShiftRepo.On("FindOpenByStaffID", uint(9)).Return(&models.CashierShift{
+			Model: gorm.Model{ID: 12}, StaffID: 9, Status: constants.CashierShiftStatus.OPEN, OpeningFloat: 1000,
+		}, nil)
+		mockPaymentRepo.On("FindByShiftID", uint(12)).Return([]models.Payment{
+			{Method: constants.PaymentMethods.CASH, Amount: 4000},
+		}, nil)
+		mockPaymentRepo.On("FindRefundsByShiftID", uint(12)).Return([]models.Refund{}, nil)
+		mockCashierShiftRepo.On("Close", mock.AnythingOfType("*models.CashierShift")).Return(nil)
+
+		report, err := service.CloseShift(models.CloseCashierShiftInput{CountedCash: floatPtr(4950)}, 9)
+
+		assert.NoError(t, err)
+		assert.Equal(t, constants.CashierShiftStatus.CLOSED, report.Shift.Status)
+		assert.Equal(t, 5000.0, *report.Shift.ExpectedCash)
+		assert.Equal(t, 4950.0, *report.Shift.CountedCash)
+		assert.Equal(t, -50.0, *report.Shift.Variance)
+		assert.NotNil(t, report.Shift.ClosedAt)
+		mockCashierShiftRepo.AssertExpectations(t)
+	})
+
+	t.Run("NoOpenShift", func(t *testing.T) {
+		mockCashierShiftRepo := new(mocks.CashierShiftRepository)
+
+		service := NewCashierShiftService(mockCashierShiftRepo, new(mocks.PaymentRepository))
+
+		mockCashierShiftRepo.On("FindOpenByStaffID", uint(9)).Return(&models.CashierShift{}, gorm.ErrRecordNotFound)
+
+		_, err := service.CloseShift(models.CloseCashierShiftInput{CountedCash: floatPtr(0)}, 9)
+
+		assert.EqualError(t, err, "you have no open shift")
+	})
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+)
+
+type CdsService interface {
+	CreateRule(input models.CreateCdsRuleInput, staffID uint) (*models.CdsRule, error)
+	GetRules(query models.CdsRuleQuery) ([]models.CdsRule, error)
+	GetRuleByID(id uint) (*models.CdsRule, error)
+	UpdateRule(id uint, input models.UpdateCdsRuleInput) (*models.CdsRule, error)
+	GetAlerts(query models.CdsAlertQuery) ([]models.CdsAlert, int64, error)
+	GetAlertByID(id uint) (*models.CdsAlert, error)
+	AcknowledgeAlert(id uint, input models.AcknowledgeCdsAlertInput, staffID uint) (*models.CdsAlert, error)
+}
+
+type cdsService struct {
+	cdsRuleRepository  repositories.CdsRuleRepository
+	cdsAlertRepository repositories.CdsAlertRepository
+}
+
+func NewCdsService(
+	cdsRuleRepository repositories.CdsRuleRepository,
+	cdsAlertRepository repositories.CdsAlertRepository,
+) CdsService {
+	return &cdsService{
+		cdsRuleRepository:  cdsRuleRepository,
+		cdsAlertRepository: cdsAlertRepository,
+	}
+}
+
+func (cs *cdsService) CreateRule(input models.CreateCdsRuleInput, staffID uint) (*models.CdsRule, error) {
+	conditions, err := validateCdsConditions(input.Trigger, input.Conditions)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := &models.CdsRule{
+		Name:        strings.TrimSpace(input.Name),
+		Description: input.Description,
+		Trigger:     input.Trigger,
+		Severity:    input.Severity,
+		Message:     strings.TrimSpace(input.Message),
+		Conditions:  conditions,
+		IsActive:    true,
+		CreatedBy:   staffID,
+	}
+
+	if err := cs.cdsRuleRepository.Create(rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (cs *cdsService) GetRules(query models.CdsRuleQuery) ([]models.CdsRule, error) {
+	return cs.cdsRuleRepository.FindAll(query)
+}
+
+func (cs *cdsService) GetRuleByID(id uint) (*models.CdsRule, error) {
+	return cs.cdsRuleRepository.FindByID(id)
+}
+
+// UpdateRule changes a rule in place. Alerts it has already raised keep the
+// severity and message they were raised with.
+func (cs *cdsService) UpdateRule(id uint, input models.UpdateCdsRuleInput) (*models.CdsRule, error) {
+	rule, err := cs.cdsRuleRepository.FindByID(id)
+	if err != nil {
+		return nil, errors.New("rule not found")
+	}
+
+	if input.Name != nil {
+		rule.Name = strings.TrimSpace(*input.Name)
+	}
+	if input.Description != nil {
+		rule.Description = *input.Description
+	}
+	if input.Severity != nil {
+		rule.Severity = *input.Severity
+	}
+	if input.Message != nil {
+		rule.Message = strings.TrimSpace(*input.Message)
+	}
+	if input.Conditions != nil {
+		conditions, err := validateCdsConditions(rule.Trigger, *input.Conditions)
+		if err != nil {
+			return nil, err
+		}
+		rule.Conditions = conditions
+	}
+	if input.IsActive != nil {
+		rule.IsActive = *input.IsActive
+	}
+
+	if err := cs.cdsRuleRepository.Update(rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (cs *cdsService) GetAlerts(query models.CdsAlertQuery) ([]models.CdsAlert, int64, error) {
+	return cs.cdsAlertRepository.FindAll(query)
+}
+
+func (cs *cdsService) GetAlertByID(id uint) (*models.CdsAlert, error) {
+	return cs.cdsAlertRepository.FindByID(id)
+}
+
+func (cs *cdsService) AcknowledgeAlert(id uint, input models.AcknowledgeCdsAlertInput, staffID uint) (*models.CdsAlert, error) {
+	alert, err := cs.cdsAlertRepository.FindByID(id)
+	if err != nil {
+		return nil, errors.New("alert not found")
+	}
+	if alert.AcknowledgedAt != nil {
+		return nil, errors.New("alert has already been acknowledged")
+	}
+
+	now := time.Now()
+	alert.AcknowledgedBy = &staffID
+	alert.AcknowledgedAt = &now
+	alert.AcknowledgementReason = strings.TrimSpace(input.Reason)
+
+	if err := cs.cdsAlertRepository.Acknowledge(alert); err != nil {
+		return nil, err
+	}
+
+	return alert, nil
+}
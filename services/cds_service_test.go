@@ -0,0 +1,162 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestCreateCdsRule(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockCdsRuleRepo := new(mocks.CdsRuleRepository)
+
+		service := NewCdsService(mockCdsRuleRepo, new(mocks.CdsAlertRepository))
+
+		mockCdsRuleRepo.On("Create", mock.AnythingOfType("*models.CdsRule")).Return(nil)
+
+		rule, err := service.CreateRule(models.CreateCdsRuleInput{
+			Name:     " Sickle cell with fever ",
+			Trigger:  constants.CdsTriggers.CLINICAL_NOTE,
+			Severity: constants.CdsSeverity.BLOCKING,
+			Message:  "Screen for sepsis",
+			Conditions: models.CdsConditions{
+				All: []models.CdsCondition{
+					{Fact: constants.CdsFacts.PATIENT_GENOTYPE, Operator: constants.CdsOperators.EQ, Value: " SS "},
+				},
+				Any: []models.CdsCondition{
+					{Fact: constants.CdsFacts.NOTE_COMPLAINTS, Operator: constants.CdsOperators.CONTAINS,
+						Values: []string{"fever"}},
+				},
+			},
+		}, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Sickle cell with fever", rule.Name)
+		assert.Equal(t, "SS", rule.Conditions.All[0].Value)
+		assert.True(t, rule.IsActive)
+		assert.Equal(t, uint(1), rule.CreatedBy)
+	})
+
+	t.Run("InvalidConditions", func(t *testing.T) {
+		mockCdsRuleRepo := new(mocks.CdsRuleRepository)
+
+		service := NewCdsService(mockCdsRuleRepo, new(mocks.CdsAlertRepository))
+
+		rule, err := service.CreateRule(models.CreateCdsRuleInput{
+			Name:     "Anaemia",
+			Trigger:  constants.CdsTriggers.CLINICAL_NOTE,
+			Severity: constants.CdsSeverity.ADVISORY,
+			Message:  "Low haemoglobin",
+			Conditions: models.CdsConditions{All: []models.CdsCondition{
+				{Fact: constants.CdsFacts.LAB_VALUE, LabTest: "HB", Operator: constants.CdsOperators.LT, Number: floatPtr(7)},
+			}},
+		}, 1)
+
+		assert.Nil(t, rule)
+		assert.EqualError(t, err, `fact "lab.value" is not available on clinical_note rules`)
+		mockCdsRuleRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestUpdateCdsRule(t *testing.T) {
+	t.Run("ValidatesAgainstTrigger", func(t *testing.T) {
+		mockCdsRuleRepo := new(mocks.CdsRuleRepository)
+
+		service := NewCdsService(mockCdsRuleRepo, new(mocks.CdsAlertRepository))
+
+		mockCdsRuleRepo.On("FindByID", uint(2)).Return(&models.CdsRule{Model: gorm.Model{ID: 2},
+			Trigger: constants.CdsTriggers.PRESCRIPTION}, nil)
+
+		rule, err := service.UpdateRule(2, models.UpdateCdsRuleInput{
+			Conditions: &models.CdsConditions{All: []models.CdsCondition{
+				{Fact: constants.CdsFacts.NOTE_TEXT, Operator: constants.CdsOperators.CONTAINS, Values: []string{"fever"}},
+			}},
+		})
+
+		assert.Nil(t, rule)
+		assert.EqualError(t, err, `fact "note.text" is not available on prescription rules`)
+		mockCdsRuleRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("Deactivate", func(t *testing.T) {
+		mockCdsRuleRepo := new(mocks.CdsRuleRepository)
+
+		service := NewCdsService(mockCdsRuleRepo, new(mocks.CdsAlertRepository))
+
+		inactive := false
+		mockCdsRuleRepo.On("FindByID", uint(2)).Return(&models.CdsRule{Model: gorm.Model{ID: 2}, IsActive: true}, nil)
+		mockCdsRuleRepo.On("Update", mock.AnythingOfType("*models.CdsRule")).Return(nil)
+
+		rule, err := service.UpdateRule(2, models.UpdateCdsRuleInput{IsActive: &inactive})
+
+		assert.NoError(t, err)
+		assert.False(t, rule.IsActive)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockCdsRuleRepo := new(mocks.CdsRuleRepository)
+
+		service := NewCdsService(mockCdsRuleRepo, new(mocks.CdsAlertRepository))
+
+		mockCdsRuleRepo.On("FindByID", uint(2)).Return(&models.CdsRule{}, gorm.ErrRecordNotFound)
+
+		rule, err := service.UpdateRule(2, models.UpdateCdsRuleInput{})
+
+		assert.Nil(t, rule)
+		assert.EqualError(t, err, "rule not found")
+	})
+}
+
+func TestAcknowledgeCdsAlert(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockCdsAlertRepo := new(mocks.CdsAlertRepository)
+
+		service := NewCdsService(new(mocks.CdsRuleRepository), mockCdsAlertRepo)
+
+		mockCdsAlertRepo.On("FindByID", uint(12)).Return(&models.CdsAlert{Model: gorm.Model{ID: 12},
+			Severity: constants.CdsSeverity.ADVISORY}, nil)
+		mockCdsAlertRepo.On("Acknowledge", mock.AnythingOfType("*models.CdsAlert")).Return(nil)
+
+		alert, err := service.AcknowledgeAlert(12, models.AcknowledgeCdsAlertInput{Reason: " Seen "}, 4)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(4), *alert.AcknowledgedBy)
+		assert.NotNil(t, alert.AcknowledgedAt)
+		assert.Equal(t, "Seen", alert.AcknowledgementReason)
+	})
+
+	t.Run("AlreadyAcknowledged", func(t *testing.T) {
+		mockCdsAlertRepo := new(mocks.CdsAlertRepository)
+
+		service := NewCdsService(new(mocks.CdsRuleRepository), mockCdsAlertRepo)
+
+		acknowledgedAt := time.Now()
+		mockCdsAlertRepo.On("FindByID", uint(12)).Return(&models.CdsAlert{Model: gorm.Model{ID: 12},
+			AcknowledgedAt: &acknowledgedAt}, nil)
+
+		alert, err := service.AcknowledgeAlert(12, models.AcknowledgeCdsAlertInput{}, 4)
+
+		assert.Nil(t, alert)
+		assert.EqualError(t, err, "alert has already been acknowledged")
+		mockCdsAlertRepo.AssertNotCalled(t, "Acknowledge", mock.Anything)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockCdsAlertRepo := new(mocks.CdsAlertRepository)
+
+		service := NewCdsService(new(mocks.CdsRuleRepository), mockCdsAlertRepo)
+
+		mockCdsAlertRepo.On("FindByID", uint(12)).Return(&models.CdsAlert{}, gorm.ErrRecordNotFound)
+
+		alert, err := service.AcknowledgeAlert(12, models.AcknowledgeCdsAlertInput{}, 4)
+
+		assert.Nil(t, alert)
+		assert.EqualError(t, err, "alert not found")
+	})
+}
@@ -0,0 +1,307 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+)
+
+// ClaimFile is an exported claim batch ready to send to the payer.
+type ClaimFile struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+// remittanceLine is one line of a payer's remittance.
+type remittanceLine struct {
+	Reference  string
+	LineID     uint
+	AmountPaid float64
+	Reason     string
+}
+
+var claimFieldNames = []string{
+	constants.ClaimFields.BATCH_NUMBER,
+	constants.ClaimFields.PAYER_CODE,
+	constants.ClaimFields.INVOICE_NUMBER,
+	constants.ClaimFields.LINE_ID,
+	constants.ClaimFields.MEMBER_NUMBER,
+	constants.ClaimFields.PATIENT_NAME,
+	constants.ClaimFields.SERVICE_DATE,
+	constants.ClaimFields.CATEGORY,
+	constants.ClaimFields.DESCRIPTION,
+	constants.ClaimFields.QUANTITY,
+	constants.ClaimFields.AMOUNT_CLAIMED,
+}
+
+// defaultClaimLayout exports every field under its own name, and reads
+// remittances with lineId, amountPaid and reason columns.
+func defaultClaimLayout(payerID uint) *models.ClaimLayout {
+	columns := make([]models.ClaimLayoutColumn, 0, len(claimFieldNames))
+	for _, field := range claimFieldNames {
+		columns = append(columns, models.ClaimLayoutColumn{Header: field, Field: field})
+	}
+	return &models.ClaimLayout{
+		PayerID:                payerID,
+		Format:                 constants.ClaimFileFormats.CSV,
+		Columns:                columns,
+		RemittanceLineColumn:   "lineId",
+		RemittanceAmountColumn: "amountPaid",
+		RemittanceReasonColumn: "reason",
+	}
+}
+
+// exportClaimBatch writes one row per claim line in the layout's columns.
+func exportClaimBatch(batch *models.ClaimBatch, layout *models.ClaimLayout, format string) (*ClaimFile, error) {
+	var content bytes.Buffer
+	file := &ClaimFile{FileName: batch.BatchNumber + "." + format}
+
+	switch format {
+	case constants.ClaimFileFormats.JSON:
+		rows := []map[string]interface{}{}
+		for i := range batch.Claims {
+			claim := &batch.Claims[i]
+			for j := range claim.Lines {
+				row := make(map[string]interface{}, len(layout.Columns))
+				for _, column := range layout.Columns {
+					row[column.Header] = claimFieldValue(column.Field, batch, claim, &claim.Lines[j])
+				}
+				rows = append(rows, row)
+			}
+		}
+		encoder := json.NewEncoder(&content)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(rows); err != nil {
+			return nil, err
+		}
+		file.ContentType = "application/json"
+	default:
+		writer := csv.NewWriter(&content)
+		header := make([]string, 0, len(layout.Columns))
+		for _, column := range layout.Columns {
+			header = append(header, column.Header)
+		}
+		if err := writer.Write(header); err != nil {
+			return nil, err
+		}
+		for i := range batch.Claims {
+			claim := &batch.Claims[i]
+			for j := range claim.Lines {
+				record := make([]string, 0, len(layout.Columns))
+				for _, column := range layout.Columns {
+					value := claimFieldValue(column.Field, batch, claim, &claim.Lines[j])
+					if amount, ok := value.(float64); ok {
+						record = append(record, strconv.FormatFloat(amount, 'f', 2, 64))
+						continue
+					}
+					record = append(record, fmt.Sprint(value))
+				}
+				if err := writer.Write(record); err != nil {
+					return nil, err
+				}
+			}
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return nil, err
+		}
+		file.ContentType = "text/csv; charset=utf-8"
+	}
+
+	file.Content = content.Bytes()
+	return file, nil
+}
+
+func claimFieldValue(field string, batch *models.ClaimBatch, claim *models.Claim, line *models.ClaimLine) interface{} {
+	fields := constants.ClaimFields
+	switch field {
+	case fields.BATCH_NUMBER:
+		return batch.BatchNumber
+	case fields.PAYER_CODE:
+		if batch.Payer != nil {
+			return batch.Payer.Code
+		}
+		return ""
+	case fields.INVOICE_NUMBER:
+		return claim.InvoiceNumber
+	case fields.LINE_ID:
+		return line.ID
+	case fields.MEMBER_NUMBER:
+		return claim.MemberNumber
+	case fields.PATIENT_NAME:
+		return claim.PatientName
+	case fields.SERVICE_DATE:
+		return claim.ServiceDate.Format("2006-01-02")
+	case fields.CATEGORY:
+		return line.Category
+	case fields.DESCRIPTION:
+		return line.Description
+	case fields.QUANTITY:
+		return line.Quantity
+	case fields.AMOUNT_CLAIMED:
+		return line.AmountClaimed
+	}
+	return ""
+}
+
+// parseRemittance reads a remittance file in the payer's layout. CSV files
+// need a header row; JSON files are an array of objects. A blank amount is
+// read as nothing paid.
+func parseRemittance(reader io.Reader, format string, layout *models.ClaimLayout) ([]remittanceLine, error) {
+	var records []map[string]string
+
+	switch format {
+	case constants.ClaimFileFormats.JSON:
+		decoder := json.NewDecoder(reader)
+		decoder.UseNumber()
+		var rows []map[string]interface{}
+		if err := decoder.Decode(&rows); err != nil {
+			return nil, fmt.Errorf("invalid JSON remittance: %w", err)
+		}
+		for _, row := range rows {
+			record := make(map[string]string, len(row))
+			for key, value := range row {
+				if value != nil {
+					record[key] = fmt.Sprint(value)
+				}
+			}
+			records = append(records, record)
+		}
+	default:
+		csvReader := csv.NewReader(reader)
+		csvReader.FieldsPerRecord = -1
+		csvReader.TrimLeadingSpace = true
+		header, err := csvReader.Read()
+		if err == io.EOF {
+			return nil, errors.New("remittance file is empty")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV at line 1: %w", err)
+		}
+		for line := 2; ; line++ {
+			row, err := csvReader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid CSV at line %d: %w", line, err)
+			}
+			record := make(map[string]string, len(header))
+			for i, name := range header {
+				if i < len(row) {
+					record[strings.TrimSpace(name)] = row[i]
+				}
+			}
+			records = append(records, record)
+		}
+	}
+
+	lines := make([]remittanceLine, 0, len(records))
+	for i, record := range records {
+		reference := strings.TrimSpace(record[layout.RemittanceLineColumn])
+		if reference == "" {
+			return nil, fmt.Errorf("row %d: %s is required", i+1, layout.RemittanceLineColumn)
+		}
+		line := remittanceLine{
+			Reference: reference,
+			Reason:    strings.TrimSpace(record[layout.RemittanceReasonColumn]),
+		}
+		if id, err := strconv.ParseUint(reference, 10, 32); err == nil {
+			line.LineID = uint(id)
+		}
+		if amount := strings.TrimSpace(record[layout.RemittanceAmountColumn]); amount != "" {
+			paid, err := strconv.ParseFloat(strings.ReplaceAll(amount, ",", ""), 64)
+			if err != nil || paid < 0 {
+				return nil, fmt.Errorf("row %d: invalid %s %q", i+1, layout.RemittanceAmountColumn, amount)
+			}
+			line.AmountPaid = roundMoney(paid)
+		}
+		lines = append(lines, line)
+	}
+
+	if len(lines) == 0 {
+		return nil, errors.New("no lines found in remittance file")
+	}
+	return lines, nil
+}
+
+// applyRemittance records what the payer paid on each line and rolls the
+// amounts and statuses up to the claims and the batch. A line paid in full
+// is paid, one paid in part is partially paid and one paid nothing is
+// rejected.
+func applyRemittance(batch *models.ClaimBatch, lines []remittanceLine) *models.RemittanceResult {
+	statuses := constants.ClaimStatus
+	result := &models.RemittanceResult{Batch: batch, Unmatched: []string{}}
+
+	index := make(map[uint]*models.ClaimLine)
+	for i := range batch.Claims {
+		for j := range batch.Claims[i].Lines {
+			line := &batch.Claims[i].Lines[j]
+			index[line.ID] = line
+		}
+	}
+
+	for _, remitted := range lines {
+		line, ok := index[remitted.LineID]
+		if !ok {
+			result.Unmatched = append(result.Unmatched, remitted.Reference)
+			continue
+		}
+		result.Matched++
+		line.AmountPaid = remitted.AmountPaid
+		line.RejectionReason = remitted.Reason
+		switch {
+		case remitted.AmountPaid <= 0:
+			line.Status = statuses.REJECTED
+			result.Rejected++
+		case remitted.AmountPaid < line.AmountClaimed:
+			line.Status = statuses.PARTIALLY_PAID
+			result.PartiallyPaid++
+		default:
+			line.Status = statuses.PAID
+			line.RejectionReason = ""
+			result.Paid++
+		}
+	}
+
+	batch.TotalPaid = 0
+	pending := false
+	for i := range batch.Claims {
+		claim := &batch.Claims[i]
+		claim.AmountPaid = 0
+		counts := make(map[string]int)
+		for _, line := range claim.Lines {
+			claim.AmountPaid += line.AmountPaid
+			counts[line.Status]++
+		}
+		claim.AmountPaid = roundMoney(claim.AmountPaid)
+		batch.TotalPaid += claim.AmountPaid
+
+		switch {
+		case counts[statuses.PENDING] > 0:
+			claim.Status = statuses.PENDING
+			pending = true
+		case counts[statuses.PAID] == len(claim.Lines):
+			claim.Status = statuses.PAID
+		case counts[statuses.REJECTED] == len(claim.Lines):
+			claim.Status = statuses.REJECTED
+		default:
+			claim.Status = statuses.PARTIALLY_PAID
+		}
+	}
+	batch.TotalPaid = roundMoney(batch.TotalPaid)
+
+	if !pending {
+		batch.Status = constants.ClaimBatchStatus.RECONCILED
+	}
+	return result
+}
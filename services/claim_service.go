@@ -0,0 +1,313 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+)
+
+type ClaimService interface {
+	GenerateBatch(input models.GenerateClaimBatchInput, staffID uint) (*models.ClaimBatch, error)
+	GetBatches(query models.ClaimBatchQuery) ([]models.ClaimBatch, int64, error)
+	GetBatchByID(id uint) (*models.ClaimBatch, error)
+	ExportBatch(id uint, format string) (*ClaimFile, error)
+	SubmitBatch(id uint, staffID uint) (*models.ClaimBatch, error)
+	ImportRemittance(id uint, reader io.Reader, format string) (*models.RemittanceResult, error)
+	GetClaimLayout(payerID uint) (*models.ClaimLayout, error)
+	SaveClaimLayout(payerID uint, input models.SaveClaimLayoutInput) (*models.ClaimLayout, error)
+}
+
+type claimService struct {
+	claimRepository           repositories.ClaimRepository
+	claimLayoutRepository     repositories.ClaimLayoutRepository
+	payerRepository           repositories.PayerRepository
+	patientRepository         repositories.PatientRepository
+	patientCoverageRepository repositories.PatientCoverageRepository
+	sequenceRepository        repositories.SequenceRepository
+	branch                    string
+}
+
+func NewClaimService(
+	claimRepository repositories.ClaimRepository,
+	claimLayoutRepository repositories.ClaimLayoutRepository,
+	payerRepository repositories.PayerRepository,
+	patientRepository repositories.PatientRepository,
+	patientCoverageRepository repositories.PatientCoverageRepository,
+	sequenceRepository repositories.SequenceRepository,
+	branch string,
+) ClaimService {
+	return &claimService{
+		claimRepository:           claimRepository,
+		claimLayoutRepository:     claimLayoutRepository,
+		payerRepository:           payerRepository,
+		patientRepository:         patientRepository,
+		patientCoverageRepository: patientCoverageRepository,
+		sequenceRepository:        sequenceRepository,
+		branch:                    strings.TrimSpace(branch),
+	}
+}
+
+// GenerateBatch claims the insurer's share of every invoice billed to the
+// payer and issued in the period that has not been claimed already. Lines
+// the patient pays in full are left off.
+func (cs *claimService) GenerateBatch(input models.GenerateClaimBatchInput, staffID uint) (*models.ClaimBatch, error) {
+	payer, err := cs.payerRepository.FindByID(input.PayerID)
+	if err != nil {
+		return nil, errors.New("payer not found")
+	}
+
+	periodStart, err := time.ParseInLocation("2006-01-02", input.PeriodStart, time.Local)
+	if err != nil {
+		return nil, errors.New("invalid periodStart format, use YYYY-MM-DD")
+	}
+	periodEnd, err := time.ParseInLocation("2006-01-02", input.PeriodEnd, time.Local)
+	if err != nil {
+		return nil, errors.New("invalid periodEnd format, use YYYY-MM-DD")
+	}
+	if periodEnd.Before(periodStart) {
+		return nil, errors.New("periodEnd cannot be before periodStart")
+	}
+
+	invoices, err := cs.claimRepository.FindClaimableInvoices(payer.ID, periodStart, periodEnd.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	if len(invoices) == 0 {
+		return nil, errors.New("no unclaimed invoices for the payer in the period")
+	}
+
+	batch := &models.ClaimBatch{
+		Branch:      cs.branch,
+		PayerID:     payer.ID,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		Status:      constants.ClaimBatchStatus.GENERATED,
+		CreatedBy:   staffID,
+	}
+	for _, invoice := range invoices {
+		claim, err := cs.newClaim(invoice)
+		if err != nil {
+			return nil, err
+		}
+		batch.Claims = append(batch.Claims, *claim)
+		batch.TotalClaimed += claim.AmountClaimed
+	}
+	batch.TotalClaimed = roundMoney(batch.TotalClaimed)
+
+	number, err := nextDocumentNumber(cs.sequenceRepository, constants.ClaimBatchSequence, "CLM", cs.branch, "claim batch")
+	if err != nil {
+		return nil, err
+	}
+	batch.BatchNumber = number
+
+	if err := cs.claimRepository.CreateBatch(batch); err != nil {
+		return nil, err
+	}
+
+	batch.Payer = payer
+	return batch, nil
+}
+
+func (cs *claimService) GetBatches(query models.ClaimBatchQuery) ([]models.ClaimBatch, int64, error) {
+	return cs.claimRepository.FindBatches(query)
+}
+
+func (cs *claimService) GetBatchByID(id uint) (*models.ClaimBatch, error) {
+	batch, err := cs.claimRepository.FindBatchByID(id)
+	if err != nil {
+		return nil, errors.New("claim batch not found")
+	}
+	return batch, nil
+}
+
+// ExportBatch writes the batch in the payer's layout, in the layout's format
+// unless another is asked for.
+func (cs *claimService) ExportBatch(id uint, format string) (*ClaimFile, error) {
+	batch, err := cs.claimRepository.FindBatchByID(id)
+	if err != nil {
+		return nil, errors.New("claim batch not found")
+	}
+
+	layout, err := cs.GetClaimLayout(batch.PayerID)
+	if err != nil {
+		return nil, err
+	}
+	if format == "" {
+		format = layout.Format
+	}
+
+	return exportClaimBatch(batch, layout, format)
+}
+
+func (cs *claimService) SubmitBatch(id uint, staffID uint) (*models.ClaimBatch, error) {
+	batch, err := cs.claimRepository.FindBatchByID(id)
+	if err != nil {
+		return nil, errors.New("claim batch not found")
+	}
+	if batch.Status != constants.ClaimBatchStatus.GENERATED {
+		return nil, errors.New("only generated batches can be submitted")
+	}
+
+	now := time.Now()
+	batch.Status = constants.ClaimBatchStatus.SUBMITTED
+	batch.SubmittedAt = &now
+	batch.SubmittedBy = &staffID
+
+	if err := cs.claimRepository.UpdateBatch(batch); err != nil {
+		return nil, err
+	}
+
+	return batch, nil
+}
+
+// ImportRemittance reconciles a payer's remittance file against a submitted
+// batch. A later remittance for the same line replaces the earlier one.
+func (cs *claimService) ImportRemittance(id uint, reader io.Reader, format string) (*models.RemittanceResult, error) {
+	batch, err := cs.claimRepository.FindBatchByID(id)
+	if err != nil {
+		return nil, errors.New("claim batch not found")
+	}
+	if batch.Status == constants.ClaimBatchStatus.GENERATED {
+		return nil, errors.New("submit the batch before importing a remittance")
+	}
+
+	layout, err := cs.GetClaimLayout(batch.PayerID)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := parseRemittance(reader, format, layout)
+	if err != nil {
+		return nil, err
+	}
+
+	wasReconciled := batch.Status == constants.ClaimBatchStatus.RECONCILED
+	result := applyRemittance(batch, lines)
+	if result.Matched == 0 {
+		return nil, errors.New("no lines in the remittance match the batch")
+	}
+	if batch.Status == constants.ClaimBatchStatus.RECONCILED && !wasReconciled {
+		now := time.Now()
+		batch.ReconciledAt = &now
+	}
+
+	if err := cs.claimRepository.SaveReconciliation(batch); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetClaimLayout returns the payer's layout, or the default layout when the
+// payer has none.
+func (cs *claimService) GetClaimLayout(payerID uint) (*models.ClaimLayout, error) {
+	layout, err := cs.claimLayoutRepository.FindByPayerID(payerID)
+	if err != nil {
+		return defaultClaimLayout(payerID), nil
+	}
+	return layout, nil
+}
+
+// SaveClaimLayout sets the payer's layout. The export must include the line
+// reference so the payer's remittance can be matched back to it.
+func (cs *claimService) SaveClaimLayout(payerID uint, input models.SaveClaimLayoutInput) (*models.ClaimLayout, error) {
+	if _, err := cs.payerRepository.FindByID(payerID); err != nil {
+		return nil, errors.New("payer not found")
+	}
+
+	known := make(map[string]bool, len(claimFieldNames))
+	for _, field := range claimFieldNames {
+		known[field] = true
+	}
+	headers := make(map[string]bool, len(input.Columns))
+	hasLineID := false
+	columns := make([]models.ClaimLayoutColumn, 0, len(input.Columns))
+	for _, column := range input.Columns {
+		header := strings.TrimSpace(column.Header)
+		if !known[column.Field] {
+			return nil, fmt.Errorf("unknown claim field %q", column.Field)
+		}
+		if headers[header] {
+			return nil, fmt.Errorf("duplicate column header %q", header)
+		}
+		headers[header] = true
+		hasLineID = hasLineID || column.Field == constants.ClaimFields.LINE_ID
+		columns = append(columns, models.ClaimLayoutColumn{Header: header, Field: column.Field})
+	}
+	if !hasLineID {
+		return nil, errors.New("the layout must include the lineId field so remittances can be matched")
+	}
+
+	layout, err := cs.claimLayoutRepository.FindByPayerID(payerID)
+	if err != nil {
+		layout = &models.ClaimLayout{PayerID: payerID}
+	}
+	defaults := defaultClaimLayout(payerID)
+	layout.Format = input.Format
+	layout.Columns = columns
+	layout.RemittanceLineColumn = valueOrDefault(input.RemittanceLineColumn, defaults.RemittanceLineColumn)
+	layout.RemittanceAmountColumn = valueOrDefault(input.RemittanceAmountColumn, defaults.RemittanceAmountColumn)
+	layout.RemittanceReasonColumn = valueOrDefault(input.RemittanceReasonColumn, defaults.RemittanceReasonColumn)
+
+	if err := cs.claimLayoutRepository.Save(layout); err != nil {
+		return nil, err
+	}
+
+	return layout, nil
+}
+
+func (cs *claimService) newClaim(invoice models.Invoice) (*models.Claim, error) {
+	claim := &models.Claim{
+		InvoiceID:     invoice.ID,
+		InvoiceNumber: invoice.InvoiceNumber,
+		PatientID:     invoice.PatientID,
+		CoverageID:    invoice.CoverageID,
+		Status:        constants.ClaimStatus.PENDING,
+	}
+	if invoice.IssuedAt != nil {
+		claim.ServiceDate = *invoice.IssuedAt
+	}
+
+	patient, err := cs.patientRepository.FindByID(invoice.PatientID)
+	if err != nil || patient == nil {
+		return nil, fmt.Errorf("patient record not found for invoice %s", invoice.InvoiceNumber)
+	}
+	claim.PatientName = strings.TrimSpace(patient.FirstName + " " + patient.LastName)
+
+	if invoice.CoverageID != nil {
+		if coverage, err := cs.patientCoverageRepository.FindByID(*invoice.CoverageID); err == nil {
+			claim.MemberNumber = coverage.MemberNumber
+		}
+	}
+
+	for _, line := range invoice.Lines {
+		if line.InsurerAmount <= 0 {
+			continue
+		}
+		claim.Lines = append(claim.Lines, models.ClaimLine{
+			InvoiceLineID: line.ID,
+			Category:      line.Category,
+			Description:   line.Description,
+			Quantity:      line.Quantity,
+			AmountClaimed: line.InsurerAmount,
+			Status:        constants.ClaimStatus.PENDING,
+		})
+		claim.AmountClaimed += line.InsurerAmount
+	}
+	claim.AmountClaimed = roundMoney(claim.AmountClaimed)
+
+	return claim, nil
+}
+
+func valueOrDefault(value string, fallback string) string {
+	if value = strings.TrimSpace(value); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,308 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type claimMocks struct {
+	claims    *mocks.ClaimRepository
+	layouts   *mocks.ClaimLayoutRepository
+	payers    *mocks.PayerRepository
+	patients  *mocks.PatientRepository
+	coverages *mocks.PatientCoverageRepository
+	sequences *mocks.SequenceRepository
+}
+
+func newClaimServiceWithMocks(branch string) (ClaimService, claimMocks) {
+	m := claimMocks{
+		claims:    new(mocks.ClaimRepository),
+		layouts:   new(mocks.ClaimLayoutRepository),
+		payers:    new(mocks.PayerRepository),
+		patients:  new(mocks.PatientRepository),
+		coverages: new(mocks.PatientCoverageRepository),
+		sequences: new(mocks.SequenceRepository),
+	}
+	service := NewClaimService(m.claims, m.layouts, m.payers, m.patients, m.coverages, m.sequences, branch)
+	return service, m
+}
+
+// submittedBatch has one claim with a consultation line and a lab test line.
+func submittedBatch() *models.ClaimBatch {
+	return &models.ClaimBatch{
+		Model:       gorm.Model{ID: 5},
+		BatchNumber: "CLM-000005",
+		PayerID:     2,
+		Payer:       &models.Payer{Code: "AXA"},
+		Status:      constants.ClaimBatchStatus.SUBMITTED,
+		Claims: []models.Claim{{
+			Model:         gorm.Model{ID: 8},
+			InvoiceNumber: "INV-000030",
+			PatientName:   "Ada Obi",
+			MemberNumber:  "AXA-1001",
+			ServiceDate:   time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC),
+			AmountClaimed: 7500,
+			Status:        constants.ClaimStatus.PENDING,
+			Lines: []models.ClaimLine{
+				{Model: gorm.Model{ID: 81}, Category: constants.ChargeCategories.CONSULTATION, Description: "General consultation",
+					Quantity: 1, AmountClaimed: 4500, Status: constants.ClaimStatus.PENDING},
+				{Model: gorm.Model{ID: 82}, Category: constants.ChargeCategories.LAB_TEST, Description: "Full blood count",
+					Quantity: 1, AmountClaimed: 3000, Status: constants.ClaimStatus.PENDING},
+			},
+		}},
+	}
+}
+
+func TestGenerateClaimBatch(t *testing.T) {
+	issuedAt := time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local)
+	input := models.GenerateClaimBatchInput{PayerID: 2, PeriodStart: "2026-03-01", PeriodEnd: "2026-03-31"}
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(2026, 4, 1, 0, 0, 0, 0, time.Local)
+
+	t.Run("Success", func(t *testing.T) {
+		service, m := newClaimServiceWithMocks("LOS")
+
+		m.payers.On("FindByID", uint(2)).Return(&models.Payer{Model: gorm.Model{ID: 2}, Code: "AXA"}, nil)
+		m.claims.On("FindClaimableInvoices", uint(2), from, to).Return([]models.Invoice{{
+			Model:         gorm.Model{ID: 30},
+			InvoiceNumber: "INV-LOS-000030",
+			PatientID:     3,
+			CoverageID:    uintPtr(4),
+			IssuedAt:      &issuedAt,
+			Lines: []models.InvoiceLine{
+				{Model: gorm.Model{ID: 61}, Category: constants.ChargeCategories.CONSULTATION, Description: "General consultation",
+					Quantity: 1, InsurerAmount: 4500, PatientAmount: 500},
+				{Model: gorm.Model{ID: 62}, Category: constants.ChargeCategories.OTHER, Description: "Medical report",
+					Quantity: 1, PatientAmount: 2000},
+			},
+		}}, nil)
+		m.patients.On("FindByID", uint(3)).Return(&models.Patient{FirstName: "Ada", LastName: "Obi"}, nil)
+		m.coverages.On("FindByID", uint(4)).Return(&models.PatientCoverage{MemberNumber: "AXA-1001"}, nil)
+		m.sequences.On("Next", "claim_batch:LOS").Return(int64(12), nil)
+		m.claims.On("CreateBatch", mock.AnythingOfType("*models.ClaimBatch")).Return(nil)
+
+		batch, err := service.GenerateBatch(input, 9)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "CLM-LOS-000012", batch.BatchNumber)
+		assert.Equal(t, constants.ClaimBatchStatus.GENERATED, batch.Status)
+		assert.Equal(t, 4500.0, batch.TotalClaimed)
+		assert.Len(t, batch.Claims, 1)
+		claim := batch.Claims[0]
+		assert.Equal(t, "Ada Obi", claim.PatientName)
+		assert.Equal(t, "AXA-1001", claim.MemberNumber)
+		assert.Len(t, claim.Lines, 1)
+		assert.Equal(t, uint(61), claim.Lines[0].InvoiceLineID)
+		m.claims.AssertExpectations(t)
+	})
+
+	t.Run("NothingToClaim", func(t *testing.T) {
+		service, m := newClaimServiceWithMocks("")
+
+		m.payers.On("FindByID", uint(2)).Return(&models.Payer{Model: gorm.Model{ID: 2}}, nil)
+		m.claims.On("FindClaimableInvoices", uint(2), from, to).Return([]models.Invoice{}, nil)
+
+		_, err := service.GenerateBatch(input, 9)
+
+		assert.EqualError(t, err, "no unclaimed invoices for the payer in the period")
+		m.claims.AssertNotCalled(t, "CreateBatch", mock.Anything)
+	})
+
+	t.Run("PeriodReversed", func(t *testing.T) {
+		service, m := newClaimServiceWithMocks("")
+
+		m.payers.On("FindByID", uint(2)).Return(&models.Payer{Model: gorm.Model{ID: 2}}, nil)
+
+		_, err := service.GenerateBatch(models.GenerateClaimBatchInput{
+			PayerID: 2, PeriodStart: "2026-03-31", PeriodEnd: "2026-03-01",
+		}, 9)
+
+		assert.EqualError(t, err, "periodEnd cannot be before periodStart")
+	})
+}
+
+func TestExportClaimBatch(t *testing.T) {
+	layout := &models.ClaimLayout{
+		Format: constants.ClaimFileFormats.CSV,
+		Columns: []models.ClaimLayoutColumn{
+			{Header: "Enrollee ID", Field: constants.ClaimFields.MEMBER_NUMBER},
+			{Header: "Ref", Field: constants.ClaimFields.LINE_ID},
+			{Header: "Service", Field: constants.ClaimFields.DESCRIPTION},
+			{Header: "Amount", Field: constants.ClaimFields.AMOUNT_CLAIMED},
+		},
+	}
+
+	t.Run("PayerLayoutCSV", func(t *testing.T) {
+		service, m := newClaimServiceWithMocks("")
+
+		m.claims.On("FindBatchByID", uint(5)).Return(submittedBatch(), nil)
+		m.layouts.On("FindByPayerID", uint(2)).Return(layout, nil)
+
+		file, err := service.ExportBatch(5, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "CLM-000005.csv", file.FileName)
+		assert.Equal(t, "Enrollee ID,Ref,Service,Amount\n"+
+			"AXA-1001,81,General consultation,4500.00\n"+
+			"AXA-1001,82,Full blood count,3000.00\n", string(file.Content))
+	})
+
+	t.Run("JSONOverride", func(t *testing.T) {
+		service, m := newClaimServiceWithMocks("")
+
+		m.claims.On("FindBatchByID", uint(5)).Return(submittedBatch(), nil)
+		m.layouts.On("FindByPayerID", uint(2)).Return(layout, nil)
+
+		file, err := service.ExportBatch(5, constants.ClaimFileFormats.JSON)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "application/json", file.ContentType)
+		assert.Contains(t, string(file.Content), `"Ref": 82`)
+		assert.Contains(t, string(file.Content), `"Amount": 4500`)
+	})
+
+	t.Run("DefaultLayout", func(t *testing.T) {
+		service, m := newClaimServiceWithMocks("")
+
+		m.claims.On("FindBatchByID", uint(5)).Return(submittedBatch(), nil)
+		m.layouts.On("FindByPayerID", uint(2)).Return(&models.ClaimLayout{}, gorm.ErrRecordNotFound)
+
+		file, err := service.ExportBatch(5, "")
+
+		assert.NoError(t, err)
+		header := strings.SplitN(string(file.Content), "\n", 2)[0]
+		assert.Equal(t, strings.Join(claimFieldNames, ","), header)
+		assert.Contains(t, string(file.Content), "CLM-000005,AXA,INV-000030,81,AXA-1001,Ada Obi,2026-03-04")
+	})
+}
+
+func TestImportRemittance(t *testing.T) {
+	t.Run("PartiallyPaidAndRejected", func(t *testing.T) {
+		service, m := newClaimServiceWithMocks("")
+
+		m.claims.On("FindBatchByID", uint(5)).Return(submittedBatch(), nil)
+		m.layouts.On("FindByPayerID", uint(2)).Return(&models.ClaimLayout{}, gorm.ErrRecordNotFound)
+		m.claims.On("SaveReconciliation", mock.AnythingOfType("*models.ClaimBatch")).Return(nil)
+
+		file := "lineId,amountPaid,reason\n81,\"4,000\",Tariff cap\n82,0,Not authorised\n99,100,\n"
+		result, err := service.ImportRemittance(5, strings.NewReader(file), constants.ClaimFileFormats.CSV)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, result.Matched)
+		assert.Equal(t, 1, result.PartiallyPaid)
+		assert.Equal(t, 1, result.Rejected)
+		assert.Equal(t, []string{"99"}, result.Unmatched)
+		claim := result.Batch.Claims[0]
+		assert.Equal(t, constants.ClaimStatus.PARTIALLY_PAID, claim.Status)
+		assert.Equal(t, 4000.0, claim.AmountPaid)
+		assert.Equal(t, "Not authorised", claim.Lines[1].RejectionReason)
+		assert.Equal(t, constants.ClaimBatchStatus.RECONCILED, result.Batch.Status)
+		assert.NotNil(t, result.Batch.ReconciledAt)
+		assert.Equal(t, 4000.0, result.Batch.TotalPaid)
+	})
+
+	t.Run("PayerColumnsJSON", func(t *testing.T) {
+		service, m := newClaimServiceWithMocks("")
+
+		m.claims.On("FindBatchByID", uint(5)).Return(submittedBatch(), nil)
+		m.layouts.On("FindByPayerID", uint(2)).Return(&models.ClaimLayout{
+			Format:                 constants.ClaimFileFormats.JSON,
+			RemittanceLineColumn:   "Ref",
+			RemittanceAmountColumn: "Paid",
+			RemittanceReasonColumn: "Remark",
+		}, nil)
+		m.claims.On("SaveReconciliation", mock.AnythingOfType("*models.ClaimBatch")).Return(nil)
+
+		file := `[{"Ref": 81, "Paid": 4500}]`
+		result, err := service.ImportRemittance(5, strings.NewReader(file), constants.ClaimFileFormats.JSON)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.Paid)
+		claim := result.Batch.Claims[0]
+		assert.Equal(t, constants.ClaimStatus.PAID, claim.Lines[0].Status)
+		assert.Equal(t, constants.ClaimStatus.PENDING, claim.Status)
+		assert.Equal(t, constants.ClaimBatchStatus.SUBMITTED, result.Batch.Status)
+		assert.Nil(t, result.Batch.ReconciledAt)
+	})
+
+	t.Run("NotSubmitted", func(t *testing.T) {
+		service, m := newClaimServiceWithMocks("")
+
+		batch := submittedBatch()
+		batch.Status = constants.ClaimBatchStatus.GENERATED
+		m.claims.On("FindBatchByID", uint(5)).Return(batch, nil)
+
+		_, err := service.ImportRemittance(5, strings.NewReader(""), constants.ClaimFileFormats.CSV)
+
+		assert.EqualError(t, err, "submit the batch before importing a remittance")
+	})
+
+	t.Run("InvalidAmount", func(t *testing.T) {
+		service, m := newClaimServiceWithMocks("")
+
+		m.claims.On("FindBatchByID", uint(5)).Return(submittedBatch(), nil)
+		m.layouts.On("FindByPayerID", uint(2)).Return(&models.ClaimLayout{}, gorm.ErrRecordNotFound)
+
+		file := "lineId,amountPaid\n81,abc\n"
+		_, err := service.ImportRemittance(5, strings.NewReader(file), constants.ClaimFileFormats.CSV)
+
+		assert.EqualError(t, err, `row 1: invalid amountPaid "abc"`)
+		m.claims.AssertNotCalled(t, "SaveReconciliation", mock.Anything)
+	})
+}
+
+func TestSaveClaimLayout(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		service, m := newClaimServiceWithMocks("")
+
+		m.payers.On("FindByID", uint(2)).Return(&models.Payer{Model: gorm.Model{ID: 2}}, nil)
+		m.layouts.On("FindByPayerID", uint(2)).Return(&models.ClaimLayout{}, gorm.ErrRecordNotFound)
+		m.layouts.On("Save", mock.AnythingOfType("*models.ClaimLayout")).Return(nil)
+
+		layout, err := service.SaveClaimLayout(2, models.SaveClaimLayoutInput{
+			Format:               constants.ClaimFileFormats.JSON,
+			Columns:              []models.ClaimLayoutColumn{{Header: " Ref ", Field: constants.ClaimFields.LINE_ID}},
+			RemittanceLineColumn: "Ref",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(2), layout.PayerID)
+		assert.Equal(t, "Ref", layout.Columns[0].Header)
+		assert.Equal(t, "Ref", layout.RemittanceLineColumn)
+		assert.Equal(t, "amountPaid", layout.RemittanceAmountColumn)
+	})
+
+	t.Run("MissingLineID", func(t *testing.T) {
+		service, m := newClaimServiceWithMocks("")
+
+		m.payers.On("FindByID", uint(2)).Return(&models.Payer{Model: gorm.Model{ID: 2}}, nil)
+
+		_, err := service.SaveClaimLayout(2, models.SaveClaimLayoutInput{
+			Format:  constants.ClaimFileFormats.CSV,
+			Columns: []models.ClaimLayoutColumn{{Header: "Amount", Field: constants.ClaimFields.AMOUNT_CLAIMED}},
+		})
+
+		assert.EqualError(t, err, "the layout must include the lineId field so remittances can be matched")
+	})
+
+	t.Run("UnknownField", func(t *testing.T) {
+		service, m := newClaimServiceWithMocks("")
+
+		m.payers.On("FindByID", uint(2)).Return(&models.Payer{Model: gorm.Model{ID: 2}}, nil)
+
+		_, err := service.SaveClaimLayout(2, models.SaveClaimLayoutInput{
+			Format:  constants.ClaimFileFormats.CSV,
+			Columns: []models.ClaimLayoutColumn{{Header: "Diagnosis", Field: "diagnosis"}},
+		})
+
+		assert.EqualError(t, err, `unknown claim field "diagnosis"`)
+	})
+}
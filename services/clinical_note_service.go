@@ -2,6 +2,7 @@ package services
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/ofojichigozie/hms-go-backend/constants"
 	"github.com/ofojichigozie/hms-go-backend/models"
@@ -18,32 +19,41 @@ type ClinicalNoteService interface {
 }
 
 type clinicalNoteService struct {
-	clinicalNoteRepository repositories.ClinicalNoteRepository
-	appointmentRespository repositories.AppointmentRepository
-	patientRespository     repositories.PatientRepository
+	clinicalNoteRepository  repositories.ClinicalNoteRepository
+	appointmentRespository  repositories.AppointmentRepository
+	patientRespository      repositories.PatientRepository
+	diagnosisCodeRepository repositories.DiagnosisCodeRepository
+	admissionRepository     repositories.AdmissionRepository
+	allergyScreen           allergyScreen
+	decisionSupport         DecisionSupport
 }
 
 func NewClinicalNoteService(
 	clinicalNoteRepository repositories.ClinicalNoteRepository,
 	appointmentRespository repositories.AppointmentRepository,
 	patientRespository repositories.PatientRepository,
+	diagnosisCodeRepository repositories.DiagnosisCodeRepository,
+	allergyRepository repositories.AllergyRepository,
+	medicationRepository repositories.MedicationRepository,
+	admissionRepository repositories.AdmissionRepository,
+	decisionSupport DecisionSupport,
 ) ClinicalNoteService {
 	return &clinicalNoteService{
-		clinicalNoteRepository: clinicalNoteRepository,
-		appointmentRespository: appointmentRespository,
-		patientRespository:     patientRespository,
+		clinicalNoteRepository:  clinicalNoteRepository,
+		appointmentRespository:  appointmentRespository,
+		patientRespository:      patientRespository,
+		diagnosisCodeRepository: diagnosisCodeRepository,
+		admissionRepository:     admissionRepository,
+		allergyScreen: allergyScreen{
+			allergyRepository:    allergyRepository,
+			medicationRepository: medicationRepository,
+		},
+		decisionSupport: decisionSupport,
 	}
 }
 
 func (cns *clinicalNoteService) CreateNote(input models.CreateNoteInput, doctorID uint) (*models.ClinicalNote, error) {
-	appointment, err := cns.appointmentRespository.FindByID(input.AppointmentID)
-	if err != nil {
-		return nil, errors.New("associated appointment record not found")
-	}
-
 	clinicalNote := &models.ClinicalNote{
-		AppointmentID:        input.AppointmentID,
-		PatientID:            appointment.PatientID,
 		DoctorID:             doctorID,
 		PresentingComplaints: input.PresentingComplaints,
 		PastMedicalHistory:   input.PastMedicalHistory,
@@ -52,12 +62,78 @@ func (cns *clinicalNoteService) CreateNote(input models.CreateNoteInput, doctorI
 		Recommendation:       input.Recommendation,
 	}
 
-	if err := cns.clinicalNoteRepository.Create(clinicalNote); err != nil {
+	var appointment *models.Appointment
+	if input.AdmissionID != nil {
+		admission, err := cns.admissionRepository.FindByID(*input.AdmissionID)
+		if err != nil {
+			return nil, errors.New("associated admission not found")
+		}
+		if admission.Status != constants.AdmissionStatus.ADMITTED {
+			return nil, errors.New("cannot add notes to a discharged admission")
+		}
+		clinicalNote.AdmissionID = &admission.ID
+		clinicalNote.PatientID = admission.PatientID
+	} else {
+		var err error
+		appointment, err = cns.appointmentRespository.FindByID(*input.AppointmentID)
+		if err != nil {
+			return nil, errors.New("associated appointment record not found")
+		}
+		clinicalNote.AppointmentID = &appointment.ID
+		clinicalNote.PatientID = appointment.PatientID
+	}
+
+	diagnoses, err := cns.resolveDiagnoses(input.Diagnoses)
+	if err != nil {
 		return nil, err
 	}
 
-	appointment.Status = constants.AppointmentStatus.COMPLETED
-	cns.appointmentRespository.Update(appointment)
+	alerts, err := cns.allergyScreen.screenText(clinicalNote.PatientID, input.TreatmentPlan)
+	if err != nil {
+		return nil, err
+	}
+	if len(alerts) > 0 && input.AllergyOverrideReason == "" {
+		return nil, &AllergyConflictError{Alerts: alerts}
+	}
+
+	clinicalNote.Diagnoses = diagnoses
+
+	cdsAlerts, err := cns.decisionSupport.Evaluate(models.CdsEvent{
+		Trigger:   constants.CdsTriggers.CLINICAL_NOTE,
+		PatientID: clinicalNote.PatientID,
+		Note:      clinicalNote,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := checkCdsAlerts(cdsAlerts, input.AlertOverrideReason); err != nil {
+		return nil, err
+	}
+
+	writes := cdsAlertWrites(cdsAlerts, doctorID, input.AlertOverrideReason)
+	writes.Overrides, err = allergyOverrideEntries(alerts, constants.AuditEntities.CLINICAL_NOTE,
+		clinicalNote.PatientID, doctorID, input.AllergyOverrideReason)
+	if err != nil {
+		return nil, err
+	}
+
+	var completedAppointment *models.Appointment
+	if appointment != nil && appointment.Status != constants.AppointmentStatus.COMPLETED {
+		previousStatus := appointment.Status
+		appointment.Status = constants.AppointmentStatus.COMPLETED
+		appointment.UpdatedBy = doctorID
+		statusChange, err := appointmentStatusChangeEntry(appointment, previousStatus, doctorID)
+		if err != nil {
+			return nil, err
+		}
+		completedAppointment = appointment
+		writes.AuditEntries = append(writes.AuditEntries, statusChange)
+	}
+
+	if err := cns.clinicalNoteRepository.Create(clinicalNote, completedAppointment, writes); err != nil {
+		return nil, err
+	}
+	clinicalNote.Alerts = cdsAlerts
 
 	return clinicalNote, nil
 }
@@ -67,7 +143,6 @@ func (cns *clinicalNoteService) GetNoteByID(id uint) (*models.ClinicalNote, erro
 }
 
 func (cns *clinicalNoteService) GetNoteByAppointmentID(appointmentID uint) (*models.ClinicalNote, error) {
-	// TODO: Fetch all related records (inner models)
 	return cns.clinicalNoteRepository.FindByAppointmentID(appointmentID)
 }
 
@@ -98,16 +173,64 @@ func (cns *clinicalNoteService) UpdateNote(id uint, input models.UpdateNoteInput
 	if input.ClinicalDiagnosis != nil {
 		clinicalNote.ClinicalDiagnosis = *input.ClinicalDiagnosis
 	}
+	var alerts []AllergyAlert
 	if input.TreatmentPlan != nil {
+		if *input.TreatmentPlan != clinicalNote.TreatmentPlan {
+			alerts, err = cns.allergyScreen.screenText(clinicalNote.PatientID, *input.TreatmentPlan)
+			if err != nil {
+				return nil, err
+			}
+			if len(alerts) > 0 && input.AllergyOverrideReason == "" {
+				return nil, &AllergyConflictError{Alerts: alerts}
+			}
+		}
 		clinicalNote.TreatmentPlan = *input.TreatmentPlan
 	}
 	if input.Recommendation != nil {
 		clinicalNote.Recommendation = *input.Recommendation
 	}
 
-	if err := cns.clinicalNoteRepository.Update(clinicalNote); err != nil {
+	var diagnoses []models.NoteDiagnosis
+	if input.Diagnoses != nil {
+		diagnoses, err = cns.resolveDiagnoses(*input.Diagnoses)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	evaluated := *clinicalNote
+	if input.Diagnoses != nil {
+		evaluated.Diagnoses = diagnoses
+	}
+	cdsAlerts, err := cns.decisionSupport.Evaluate(models.CdsEvent{
+		Trigger:   constants.CdsTriggers.CLINICAL_NOTE,
+		PatientID: clinicalNote.PatientID,
+		EntityID:  clinicalNote.ID,
+		Note:      &evaluated,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := checkCdsAlerts(cdsAlerts, input.AlertOverrideReason); err != nil {
+		return nil, err
+	}
+
+	writes := cdsAlertWrites(cdsAlerts, staffId, input.AlertOverrideReason)
+	writes.Overrides, err = allergyOverrideEntries(alerts, constants.AuditEntities.CLINICAL_NOTE,
+		clinicalNote.PatientID, staffId, input.AllergyOverrideReason)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Diagnoses != nil {
+		if err := cns.clinicalNoteRepository.UpdateWithDiagnoses(clinicalNote, diagnoses, writes); err != nil {
+			return nil, err
+		}
+		clinicalNote.Diagnoses = diagnoses
+	} else if err := cns.clinicalNoteRepository.Update(clinicalNote, writes); err != nil {
 		return nil, err
 	}
+	clinicalNote.Alerts = cdsAlerts
 
 	return clinicalNote, nil
 }
@@ -123,3 +246,42 @@ func (cns *clinicalNoteService) DeleteNote(id uint, staffId uint) error {
 
 	return cns.clinicalNoteRepository.Delete(id)
 }
+
+// resolveDiagnoses looks up each coded diagnosis and enforces a single
+// primary diagnosis whenever any codes are supplied.
+func (cns *clinicalNoteService) resolveDiagnoses(inputs []models.NoteDiagnosisInput) ([]models.NoteDiagnosis, error) {
+	if len(inputs) == 0 {
+		return nil, nil
+	}
+
+	diagnoses := make([]models.NoteDiagnosis, 0, len(inputs))
+	seen := make(map[string]bool)
+	primaryCount := 0
+	for _, input := range inputs {
+		code := NormalizeDiagnosisCode(input.Code)
+		if seen[code] {
+			return nil, fmt.Errorf("diagnosis code %s is listed more than once", code)
+		}
+		seen[code] = true
+
+		diagnosisCode, err := cns.diagnosisCodeRepository.FindByCode(code)
+		if err != nil {
+			return nil, fmt.Errorf("unknown diagnosis code %s", code)
+		}
+		if input.Type == constants.DiagnosisTypes.PRIMARY {
+			primaryCount++
+		}
+
+		diagnoses = append(diagnoses, models.NoteDiagnosis{
+			DiagnosisCodeID: diagnosisCode.ID,
+			DiagnosisCode:   diagnosisCode,
+			Type:            input.Type,
+		})
+	}
+
+	if primaryCount != 1 {
+		return nil, errors.New("exactly one primary diagnosis is required")
+	}
+
+	return diagnoses, nil
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"errors"
+	"slices"
 	"testing"
 
 	"github.com/ofojichigozie/hms-go-backend/constants"
@@ -17,11 +18,16 @@ func TestCreateNote(t *testing.T) {
 		mockNoteRepo := new(mocks.ClinicalNoteRepository)
 		mockAppointmentRepo := new(mocks.AppointmentRepository)
 		mockPatientRepo := new(mocks.PatientRepository)
+		mockDiagnosisRepo := new(mocks.DiagnosisCodeRepository)
+		mockAllergyRepo := new(mocks.AllergyRepository)
+		mockMedicationRepo := new(mocks.MedicationRepository)
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
 
-		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo)
+		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo, mockDiagnosisRepo,
+			mockAllergyRepo, mockMedicationRepo, mockAdmissionRepo, noCdsAlerts())
 
 		input := models.CreateNoteInput{
-			AppointmentID:        1,
+			AppointmentID:        uintPtr(1),
 			PresentingComplaints: "Headache",
 			PastMedicalHistory:   "None",
 			ClinicalDiagnosis:    "Migraine",
@@ -38,8 +44,9 @@ func TestCreateNote(t *testing.T) {
 		}
 
 		mockAppointmentRepo.On("FindByID", uint(1)).Return(expectedAppointment, nil)
-		mockAppointmentRepo.On("Update", mock.AnythingOfType("*models.Appointment")).Return(nil)
-		mockNoteRepo.On("Create", mock.AnythingOfType("*models.ClinicalNote")).Return(nil).Run(func(args mock.Arguments) {
+		mockAllergyRepo.On("FindByPatientID", uint(1)).Return([]models.PatientAllergy{}, nil)
+		mockNoteRepo.On("Create", mock.AnythingOfType("*models.ClinicalNote"), expectedAppointment,
+			mock.AnythingOfType("models.ClinicalRecordWrites")).Return(nil).Run(func(args mock.Arguments) {
 			note := args.Get(0).(*models.ClinicalNote)
 			assert.Equal(t, input.AppointmentID, note.AppointmentID)
 			assert.Equal(t, expectedAppointment.PatientID, note.PatientID)
@@ -49,6 +56,20 @@ func TestCreateNote(t *testing.T) {
 			assert.Equal(t, input.ClinicalDiagnosis, note.ClinicalDiagnosis)
 			assert.Equal(t, input.TreatmentPlan, note.TreatmentPlan)
 			assert.Equal(t, input.Recommendation, note.Recommendation)
+
+			appointment := args.Get(1).(*models.Appointment)
+			assert.Equal(t, constants.AppointmentStatus.COMPLETED, appointment.Status)
+			assert.Equal(t, doctorID, appointment.UpdatedBy)
+
+			writes := args.Get(2).(models.ClinicalRecordWrites)
+			assert.Empty(t, writes.Overrides)
+			assert.Len(t, writes.AuditEntries, 1)
+			entry := writes.AuditEntries[0]
+			assert.Equal(t, constants.AuditActions.APPOINTMENT_STATUS_CHANGE, entry.Action)
+			assert.Equal(t, constants.AuditEntities.APPOINTMENT, entry.EntityType)
+			assert.Equal(t, uint(1), entry.EntityID)
+			assert.Equal(t, doctorID, entry.StaffID)
+			assert.JSONEq(t, `{"from":"scheduled","to":"completed"}`, entry.Details)
 		})
 
 		result, err := service.CreateNote(input, doctorID)
@@ -57,17 +78,52 @@ func TestCreateNote(t *testing.T) {
 		assert.NotNil(t, result)
 		mockAppointmentRepo.AssertExpectations(t)
 		mockNoteRepo.AssertExpectations(t)
+		mockAppointmentRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("AlreadyCompletedAppointment", func(t *testing.T) {
+		mockNoteRepo := new(mocks.ClinicalNoteRepository)
+		mockAppointmentRepo := new(mocks.AppointmentRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockDiagnosisRepo := new(mocks.DiagnosisCodeRepository)
+		mockAllergyRepo := new(mocks.AllergyRepository)
+		mockMedicationRepo := new(mocks.MedicationRepository)
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
+
+		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo, mockDiagnosisRepo,
+			mockAllergyRepo, mockMedicationRepo, mockAdmissionRepo, noCdsAlerts())
+
+		mockAppointmentRepo.On("FindByID", uint(1)).Return(&models.Appointment{
+			Model: gorm.Model{ID: 1}, PatientID: 1, Status: constants.AppointmentStatus.COMPLETED,
+		}, nil)
+		mockAllergyRepo.On("FindByPatientID", uint(1)).Return([]models.PatientAllergy{}, nil)
+		mockNoteRepo.On("Create", mock.AnythingOfType("*models.ClinicalNote"), (*models.Appointment)(nil),
+			mock.MatchedBy(func(writes models.ClinicalRecordWrites) bool {
+				return len(writes.AuditEntries) == 0
+			})).Return(nil)
+
+		_, err := service.CreateNote(models.CreateNoteInput{
+			AppointmentID: uintPtr(1), PresentingComplaints: "Headache", TreatmentPlan: "Rest",
+		}, 2)
+
+		assert.NoError(t, err)
+		mockNoteRepo.AssertExpectations(t)
 	})
 
 	t.Run("AppointmentNotFound", func(t *testing.T) {
 		mockNoteRepo := new(mocks.ClinicalNoteRepository)
 		mockAppointmentRepo := new(mocks.AppointmentRepository)
 		mockPatientRepo := new(mocks.PatientRepository)
+		mockDiagnosisRepo := new(mocks.DiagnosisCodeRepository)
+		mockAllergyRepo := new(mocks.AllergyRepository)
+		mockMedicationRepo := new(mocks.MedicationRepository)
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
 
-		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo)
+		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo, mockDiagnosisRepo,
+			mockAllergyRepo, mockMedicationRepo, mockAdmissionRepo, noCdsAlerts())
 
 		input := models.CreateNoteInput{
-			AppointmentID: 1,
+			AppointmentID: uintPtr(1),
 		}
 
 		mockAppointmentRepo.On("FindByID", uint(1)).Return(&models.Appointment{}, errors.New("not found"))
@@ -81,15 +137,306 @@ func TestCreateNote(t *testing.T) {
 		mockNoteRepo.AssertNotCalled(t, "Create")
 	})
 
+	t.Run("InpatientNote", func(t *testing.T) {
+		mockNoteRepo := new(mocks.ClinicalNoteRepository)
+		mockAppointmentRepo := new(mocks.AppointmentRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockDiagnosisRepo := new(mocks.DiagnosisCodeRepository)
+		mockAllergyRepo := new(mocks.AllergyRepository)
+		mockMedicationRepo := new(mocks.MedicationRepository)
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
+
+		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo, mockDiagnosisRepo,
+			mockAllergyRepo, mockMedicationRepo, mockAdmissionRepo, noCdsAlerts())
+
+		input := models.CreateNoteInput{
+			AdmissionID:          uintPtr(6),
+			PresentingComplaints: "Ward round: afebrile overnight",
+			TreatmentPlan:        "Continue IV antibiotics",
+			Recommendation:       "Review in the morning",
+		}
+
+		mockAdmissionRepo.On("FindByID", uint(6)).Return(&models.Admission{
+			Model: gorm.Model{ID: 6}, PatientID: 4, Status: constants.AdmissionStatus.ADMITTED,
+		}, nil)
+		mockAllergyRepo.On("FindByPatientID", uint(4)).Return([]models.PatientAllergy{}, nil)
+		mockNoteRepo.On("Create", mock.AnythingOfType("*models.ClinicalNote"), mock.Anything, mock.Anything).Return(nil)
+
+		note, err := service.CreateNote(input, 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(6), *note.AdmissionID)
+		assert.Nil(t, note.AppointmentID)
+		assert.Equal(t, uint(4), note.PatientID)
+		mockAppointmentRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("DischargedAdmission", func(t *testing.T) {
+		mockNoteRepo := new(mocks.ClinicalNoteRepository)
+		mockAppointmentRepo := new(mocks.AppointmentRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockDiagnosisRepo := new(mocks.DiagnosisCodeRepository)
+		mockAllergyRepo := new(mocks.AllergyRepository)
+		mockMedicationRepo := new(mocks.MedicationRepository)
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
+
+		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo, mockDiagnosisRepo,
+			mockAllergyRepo, mockMedicationRepo, mockAdmissionRepo, noCdsAlerts())
+
+		mockAdmissionRepo.On("FindByID", uint(6)).Return(&models.Admission{
+			Model: gorm.Model{ID: 6}, PatientID: 4, Status: constants.AdmissionStatus.DISCHARGED,
+		}, nil)
+
+		_, err := service.CreateNote(models.CreateNoteInput{AdmissionID: uintPtr(6)}, 2)
+
+		assert.EqualError(t, err, "cannot add notes to a discharged admission")
+		mockNoteRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("WithCodedDiagnoses", func(t *testing.T) {
+		mockNoteRepo := new(mocks.ClinicalNoteRepository)
+		mockAppointmentRepo := new(mocks.AppointmentRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockDiagnosisRepo := new(mocks.DiagnosisCodeRepository)
+		mockAllergyRepo := new(mocks.AllergyRepository)
+		mockMedicationRepo := new(mocks.MedicationRepository)
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
+
+		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo, mockDiagnosisRepo,
+			mockAllergyRepo, mockMedicationRepo, mockAdmissionRepo, noCdsAlerts())
+
+		input := models.CreateNoteInput{
+			AppointmentID:        uintPtr(1),
+			PresentingComplaints: "Headache",
+			Diagnoses: []models.NoteDiagnosisInput{
+				{Code: "g430", Type: constants.DiagnosisTypes.PRIMARY},
+				{Code: "I10", Type: constants.DiagnosisTypes.SECONDARY},
+			},
+		}
+
+		expectedAppointment := &models.Appointment{
+			Model:     gorm.Model{ID: 1},
+			PatientID: 1,
+		}
+
+		mockAppointmentRepo.On("FindByID", uint(1)).Return(expectedAppointment, nil)
+		mockDiagnosisRepo.On("FindByCode", "G43.0").Return(&models.DiagnosisCode{Model: gorm.Model{ID: 10}, Code: "G43.0"}, nil)
+		mockDiagnosisRepo.On("FindByCode", "I10").Return(&models.DiagnosisCode{Model: gorm.Model{ID: 11}, Code: "I10"}, nil)
+		mockNoteRepo.On("Create", mock.AnythingOfType("*models.ClinicalNote"), mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			note := args.Get(0).(*models.ClinicalNote)
+			assert.Len(t, note.Diagnoses, 2)
+			assert.Equal(t, uint(10), note.Diagnoses[0].DiagnosisCodeID)
+			assert.Equal(t, constants.DiagnosisTypes.PRIMARY, note.Diagnoses[0].Type)
+			assert.Equal(t, uint(11), note.Diagnoses[1].DiagnosisCodeID)
+		})
+
+		result, err := service.CreateNote(input, 2)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		mockDiagnosisRepo.AssertExpectations(t)
+		mockNoteRepo.AssertExpectations(t)
+	})
+
+	t.Run("UnknownDiagnosisCode", func(t *testing.T) {
+		mockNoteRepo := new(mocks.ClinicalNoteRepository)
+		mockAppointmentRepo := new(mocks.AppointmentRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockDiagnosisRepo := new(mocks.DiagnosisCodeRepository)
+		mockAllergyRepo := new(mocks.AllergyRepository)
+		mockMedicationRepo := new(mocks.MedicationRepository)
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
+
+		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo, mockDiagnosisRepo,
+			mockAllergyRepo, mockMedicationRepo, mockAdmissionRepo, noCdsAlerts())
+
+		input := models.CreateNoteInput{
+			AppointmentID: uintPtr(1),
+			Diagnoses: []models.NoteDiagnosisInput{
+				{Code: "Z99.99", Type: constants.DiagnosisTypes.PRIMARY},
+			},
+		}
+
+		mockAppointmentRepo.On("FindByID", uint(1)).Return(&models.Appointment{Model: gorm.Model{ID: 1}}, nil)
+		mockDiagnosisRepo.On("FindByCode", "Z99.99").Return(&models.DiagnosisCode{}, errors.New("record not found"))
+
+		result, err := service.CreateNote(input, 2)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Equal(t, "unknown diagnosis code Z99.99", err.Error())
+		mockNoteRepo.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("MissingPrimaryDiagnosis", func(t *testing.T) {
+		mockNoteRepo := new(mocks.ClinicalNoteRepository)
+		mockAppointmentRepo := new(mocks.AppointmentRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockDiagnosisRepo := new(mocks.DiagnosisCodeRepository)
+		mockAllergyRepo := new(mocks.AllergyRepository)
+		mockMedicationRepo := new(mocks.MedicationRepository)
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
+
+		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo, mockDiagnosisRepo,
+			mockAllergyRepo, mockMedicationRepo, mockAdmissionRepo, noCdsAlerts())
+
+		input := models.CreateNoteInput{
+			AppointmentID: uintPtr(1),
+			Diagnoses: []models.NoteDiagnosisInput{
+				{Code: "I10", Type: constants.DiagnosisTypes.SECONDARY},
+			},
+		}
+
+		mockAppointmentRepo.On("FindByID", uint(1)).Return(&models.Appointment{Model: gorm.Model{ID: 1}}, nil)
+		mockDiagnosisRepo.On("FindByCode", "I10").Return(&models.DiagnosisCode{Model: gorm.Model{ID: 11}, Code: "I10"}, nil)
+
+		result, err := service.CreateNote(input, 2)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Equal(t, "exactly one primary diagnosis is required", err.Error())
+		mockNoteRepo.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("TreatmentPlanAllergyConflict", func(t *testing.T) {
+		mockNoteRepo := new(mocks.ClinicalNoteRepository)
+		mockAppointmentRepo := new(mocks.AppointmentRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockDiagnosisRepo := new(mocks.DiagnosisCodeRepository)
+		mockAllergyRepo := new(mocks.AllergyRepository)
+		mockMedicationRepo := new(mocks.MedicationRepository)
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
+
+		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo, mockDiagnosisRepo,
+			mockAllergyRepo, mockMedicationRepo, mockAdmissionRepo, noCdsAlerts())
+
+		input := models.CreateNoteInput{
+			AppointmentID: uintPtr(1),
+			TreatmentPlan: "Start amoxicillin 500mg TDS for 5 days",
+		}
+
+		allergies := []models.PatientAllergy{{
+			Model:              gorm.Model{ID: 4},
+			Substance:          "Penicillin",
+			SubstanceClass:     "Penicillins",
+			Severity:           constants.AllergySeverity.SEVERE,
+			VerificationStatus: constants.AllergyVerificationStatus.CONFIRMED,
+		}}
+		catalog := []models.Medication{{Name: "Amoxil", GenericName: "Amoxicillin", DrugClass: "Penicillins"}}
+
+		mockAppointmentRepo.On("FindByID", uint(1)).Return(&models.Appointment{Model: gorm.Model{ID: 1}, PatientID: 9}, nil)
+		mockAllergyRepo.On("FindByPatientID", uint(9)).Return(allergies, nil)
+		mockMedicationRepo.On("FindByNames", mock.MatchedBy(func(names []string) bool {
+			return slices.Contains(names, "amoxicillin") && !slices.Contains(names, "start amoxicillin 500mg tds for")
+		})).Return(catalog, nil)
+
+		result, err := service.CreateNote(input, 2)
+
+		assert.Nil(t, result)
+		var conflict *AllergyConflictError
+		assert.ErrorAs(t, err, &conflict)
+		assert.Len(t, conflict.Alerts, 1)
+		assert.Equal(t, uint(4), conflict.Alerts[0].AllergyID)
+		mockNoteRepo.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("TreatmentPlanMatchesWholeWords", func(t *testing.T) {
+		mockNoteRepo := new(mocks.ClinicalNoteRepository)
+		mockAppointmentRepo := new(mocks.AppointmentRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockDiagnosisRepo := new(mocks.DiagnosisCodeRepository)
+		mockAllergyRepo := new(mocks.AllergyRepository)
+		mockMedicationRepo := new(mocks.MedicationRepository)
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
+
+		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo, mockDiagnosisRepo,
+			mockAllergyRepo, mockMedicationRepo, mockAdmissionRepo, noCdsAlerts())
+
+		input := models.CreateNoteInput{
+			AppointmentID: uintPtr(1),
+			TreatmentPlan: "Ferrous sulfate 200mg daily; review in a fortnight",
+		}
+
+		allergies := []models.PatientAllergy{{
+			Model:              gorm.Model{ID: 4},
+			Substance:          "Sulfa",
+			Severity:           constants.AllergySeverity.SEVERE,
+			VerificationStatus: constants.AllergyVerificationStatus.CONFIRMED,
+		}}
+
+		mockAppointmentRepo.On("FindByID", uint(1)).Return(&models.Appointment{Model: gorm.Model{ID: 1}, PatientID: 9}, nil)
+		mockAllergyRepo.On("FindByPatientID", uint(9)).Return(allergies, nil)
+		mockMedicationRepo.On("FindByNames", mock.Anything).Return([]models.Medication{
+			{Model: gorm.Model{ID: 8}, Name: "Ferrous Sulfate", GenericName: "Ferrous Sulfate", DrugClass: "Iron Preparations"},
+		}, nil)
+		mockNoteRepo.On("Create", mock.AnythingOfType("*models.ClinicalNote"), mock.Anything,
+			mock.MatchedBy(func(writes models.ClinicalRecordWrites) bool {
+				return len(writes.Overrides) == 0
+			})).Return(nil)
+
+		result, err := service.CreateNote(input, 2)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		mockNoteRepo.AssertExpectations(t)
+	})
+
+	t.Run("TreatmentPlanAllergyOverride", func(t *testing.T) {
+		mockNoteRepo := new(mocks.ClinicalNoteRepository)
+		mockAppointmentRepo := new(mocks.AppointmentRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockDiagnosisRepo := new(mocks.DiagnosisCodeRepository)
+		mockAllergyRepo := new(mocks.AllergyRepository)
+		mockMedicationRepo := new(mocks.MedicationRepository)
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
+
+		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo, mockDiagnosisRepo,
+			mockAllergyRepo, mockMedicationRepo, mockAdmissionRepo, noCdsAlerts())
+
+		input := models.CreateNoteInput{
+			AppointmentID:         uintPtr(1),
+			TreatmentPlan:         "Penicillin desensitisation protocol",
+			AllergyOverrideReason: "Supervised desensitisation",
+		}
+
+		allergies := []models.PatientAllergy{{Model: gorm.Model{ID: 4}, Substance: "Penicillin"}}
+
+		mockAppointmentRepo.On("FindByID", uint(1)).Return(&models.Appointment{Model: gorm.Model{ID: 1}, PatientID: 9}, nil)
+		mockAllergyRepo.On("FindByPatientID", uint(9)).Return(allergies, nil)
+		mockMedicationRepo.On("FindByNames", mock.Anything).Return([]models.Medication{}, nil)
+		mockNoteRepo.On("Create", mock.AnythingOfType("*models.ClinicalNote"), mock.Anything,
+			mock.AnythingOfType("models.ClinicalRecordWrites")).Return(nil).Run(func(args mock.Arguments) {
+			writes := args.Get(2).(models.ClinicalRecordWrites)
+			assert.Len(t, writes.Overrides, 1)
+			entry := writes.Overrides[0]
+			assert.Equal(t, constants.AuditActions.ALLERGY_OVERRIDE, entry.Action)
+			assert.Equal(t, constants.AuditEntities.CLINICAL_NOTE, entry.EntityType)
+			assert.Equal(t, uint(9), *entry.PatientID)
+			assert.Equal(t, "Supervised desensitisation", entry.Reason)
+			assert.Len(t, writes.AuditEntries, 1)
+		})
+
+		result, err := service.CreateNote(input, 2)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		mockNoteRepo.AssertExpectations(t)
+	})
+
 	t.Run("RepositoryError", func(t *testing.T) {
 		mockNoteRepo := new(mocks.ClinicalNoteRepository)
 		mockAppointmentRepo := new(mocks.AppointmentRepository)
 		mockPatientRepo := new(mocks.PatientRepository)
+		mockDiagnosisRepo := new(mocks.DiagnosisCodeRepository)
+		mockAllergyRepo := new(mocks.AllergyRepository)
+		mockMedicationRepo := new(mocks.MedicationRepository)
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
 
-		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo)
+		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo, mockDiagnosisRepo,
+			mockAllergyRepo, mockMedicationRepo, mockAdmissionRepo, noCdsAlerts())
 
 		input := models.CreateNoteInput{
-			AppointmentID:        1,
+			AppointmentID:        uintPtr(1),
 			PresentingComplaints: "Headache",
 		}
 
@@ -99,7 +446,7 @@ func TestCreateNote(t *testing.T) {
 		}
 
 		mockAppointmentRepo.On("FindByID", uint(1)).Return(expectedAppointment, nil)
-		mockNoteRepo.On("Create", mock.AnythingOfType("*models.ClinicalNote")).Return(errors.New("database error"))
+		mockNoteRepo.On("Create", mock.AnythingOfType("*models.ClinicalNote"), mock.Anything, mock.Anything).Return(errors.New("database error"))
 
 		result, err := service.CreateNote(input, 2)
 
@@ -108,6 +455,7 @@ func TestCreateNote(t *testing.T) {
 		assert.Equal(t, "database error", err.Error())
 		mockAppointmentRepo.AssertExpectations(t)
 		mockNoteRepo.AssertExpectations(t)
+		mockAppointmentRepo.AssertNotCalled(t, "Update", mock.Anything)
 	})
 }
 
@@ -116,12 +464,17 @@ func TestGetNoteByID(t *testing.T) {
 		mockNoteRepo := new(mocks.ClinicalNoteRepository)
 		mockAppointmentRepo := new(mocks.AppointmentRepository)
 		mockPatientRepo := new(mocks.PatientRepository)
+		mockDiagnosisRepo := new(mocks.DiagnosisCodeRepository)
+		mockAllergyRepo := new(mocks.AllergyRepository)
+		mockMedicationRepo := new(mocks.MedicationRepository)
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
 
-		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo)
+		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo, mockDiagnosisRepo,
+			mockAllergyRepo, mockMedicationRepo, mockAdmissionRepo, noCdsAlerts())
 
 		expectedNote := &models.ClinicalNote{
 			Model:                gorm.Model{ID: 1},
-			AppointmentID:        1,
+			AppointmentID:        uintPtr(1),
 			PresentingComplaints: "Headache",
 		}
 
@@ -138,8 +491,13 @@ func TestGetNoteByID(t *testing.T) {
 		mockNoteRepo := new(mocks.ClinicalNoteRepository)
 		mockAppointmentRepo := new(mocks.AppointmentRepository)
 		mockPatientRepo := new(mocks.PatientRepository)
+		mockDiagnosisRepo := new(mocks.DiagnosisCodeRepository)
+		mockAllergyRepo := new(mocks.AllergyRepository)
+		mockMedicationRepo := new(mocks.MedicationRepository)
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
 
-		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo)
+		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo, mockDiagnosisRepo,
+			mockAllergyRepo, mockMedicationRepo, mockAdmissionRepo, noCdsAlerts())
 
 		mockNoteRepo.On("FindByID", uint(1)).Return(&models.ClinicalNote{}, errors.New("not found"))
 
@@ -156,12 +514,17 @@ func TestGetNoteByAppointmentID(t *testing.T) {
 		mockNoteRepo := new(mocks.ClinicalNoteRepository)
 		mockAppointmentRepo := new(mocks.AppointmentRepository)
 		mockPatientRepo := new(mocks.PatientRepository)
+		mockDiagnosisRepo := new(mocks.DiagnosisCodeRepository)
+		mockAllergyRepo := new(mocks.AllergyRepository)
+		mockMedicationRepo := new(mocks.MedicationRepository)
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
 
-		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo)
+		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo, mockDiagnosisRepo,
+			mockAllergyRepo, mockMedicationRepo, mockAdmissionRepo, noCdsAlerts())
 
 		expectedNote := &models.ClinicalNote{
 			Model:         gorm.Model{ID: 1},
-			AppointmentID: 1,
+			AppointmentID: uintPtr(1),
 		}
 
 		mockNoteRepo.On("FindByAppointmentID", uint(1)).Return(expectedNote, nil)
@@ -177,8 +540,13 @@ func TestGetNoteByAppointmentID(t *testing.T) {
 		mockNoteRepo := new(mocks.ClinicalNoteRepository)
 		mockAppointmentRepo := new(mocks.AppointmentRepository)
 		mockPatientRepo := new(mocks.PatientRepository)
+		mockDiagnosisRepo := new(mocks.DiagnosisCodeRepository)
+		mockAllergyRepo := new(mocks.AllergyRepository)
+		mockMedicationRepo := new(mocks.MedicationRepository)
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
 
-		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo)
+		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo, mockDiagnosisRepo,
+			mockAllergyRepo, mockMedicationRepo, mockAdmissionRepo, noCdsAlerts())
 
 		mockNoteRepo.On("FindByAppointmentID", uint(1)).Return(&models.ClinicalNote{}, errors.New("not found"))
 
@@ -195,8 +563,13 @@ func TestGetNotesByPatientID(t *testing.T) {
 		mockNoteRepo := new(mocks.ClinicalNoteRepository)
 		mockAppointmentRepo := new(mocks.AppointmentRepository)
 		mockPatientRepo := new(mocks.PatientRepository)
+		mockDiagnosisRepo := new(mocks.DiagnosisCodeRepository)
+		mockAllergyRepo := new(mocks.AllergyRepository)
+		mockMedicationRepo := new(mocks.MedicationRepository)
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
 
-		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo)
+		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo, mockDiagnosisRepo,
+			mockAllergyRepo, mockMedicationRepo, mockAdmissionRepo, noCdsAlerts())
 
 		expectedNotes := []models.ClinicalNote{
 			{
@@ -224,8 +597,13 @@ func TestGetNotesByPatientID(t *testing.T) {
 		mockNoteRepo := new(mocks.ClinicalNoteRepository)
 		mockAppointmentRepo := new(mocks.AppointmentRepository)
 		mockPatientRepo := new(mocks.PatientRepository)
+		mockDiagnosisRepo := new(mocks.DiagnosisCodeRepository)
+		mockAllergyRepo := new(mocks.AllergyRepository)
+		mockMedicationRepo := new(mocks.MedicationRepository)
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
 
-		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo)
+		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo, mockDiagnosisRepo,
+			mockAllergyRepo, mockMedicationRepo, mockAdmissionRepo, noCdsAlerts())
 
 		mockPatientRepo.On("FindByID", uint(1)).Return(&models.Patient{}, errors.New("not found"))
 
@@ -242,8 +620,13 @@ func TestGetNotesByPatientID(t *testing.T) {
 		mockNoteRepo := new(mocks.ClinicalNoteRepository)
 		mockAppointmentRepo := new(mocks.AppointmentRepository)
 		mockPatientRepo := new(mocks.PatientRepository)
+		mockDiagnosisRepo := new(mocks.DiagnosisCodeRepository)
+		mockAllergyRepo := new(mocks.AllergyRepository)
+		mockMedicationRepo := new(mocks.MedicationRepository)
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
 
-		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo)
+		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo, mockDiagnosisRepo,
+			mockAllergyRepo, mockMedicationRepo, mockAdmissionRepo, noCdsAlerts())
 
 		expectedPatient := &models.Patient{
 			Model: gorm.Model{ID: 1},
@@ -267,8 +650,13 @@ func TestUpdateNote(t *testing.T) {
 		mockNoteRepo := new(mocks.ClinicalNoteRepository)
 		mockAppointmentRepo := new(mocks.AppointmentRepository)
 		mockPatientRepo := new(mocks.PatientRepository)
+		mockDiagnosisRepo := new(mocks.DiagnosisCodeRepository)
+		mockAllergyRepo := new(mocks.AllergyRepository)
+		mockMedicationRepo := new(mocks.MedicationRepository)
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
 
-		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo)
+		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo, mockDiagnosisRepo,
+			mockAllergyRepo, mockMedicationRepo, mockAdmissionRepo, noCdsAlerts())
 
 		existingNote := &models.ClinicalNote{
 			Model:                gorm.Model{ID: 1},
@@ -282,7 +670,7 @@ func TestUpdateNote(t *testing.T) {
 		}
 
 		mockNoteRepo.On("FindByID", uint(1)).Return(existingNote, nil)
-		mockNoteRepo.On("Update", mock.AnythingOfType("*models.ClinicalNote")).Return(nil)
+		mockNoteRepo.On("Update", mock.AnythingOfType("*models.ClinicalNote"), mock.Anything).Return(nil)
 
 		result, err := service.UpdateNote(1, input, 2)
 
@@ -291,12 +679,83 @@ func TestUpdateNote(t *testing.T) {
 		mockNoteRepo.AssertExpectations(t)
 	})
 
+	t.Run("ReplacesDiagnoses", func(t *testing.T) {
+		mockNoteRepo := new(mocks.ClinicalNoteRepository)
+		mockAppointmentRepo := new(mocks.AppointmentRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockDiagnosisRepo := new(mocks.DiagnosisCodeRepository)
+		mockAllergyRepo := new(mocks.AllergyRepository)
+		mockMedicationRepo := new(mocks.MedicationRepository)
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
+
+		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo, mockDiagnosisRepo,
+			mockAllergyRepo, mockMedicationRepo, mockAdmissionRepo, noCdsAlerts())
+
+		existingNote := &models.ClinicalNote{
+			Model:    gorm.Model{ID: 1},
+			DoctorID: 2,
+		}
+
+		diagnoses := []models.NoteDiagnosisInput{{Code: "I10", Type: constants.DiagnosisTypes.PRIMARY}}
+		input := models.UpdateNoteInput{Diagnoses: &diagnoses}
+
+		mockNoteRepo.On("FindByID", uint(1)).Return(existingNote, nil)
+		mockDiagnosisRepo.On("FindByCode", "I10").Return(&models.DiagnosisCode{Model: gorm.Model{ID: 11}, Code: "I10"}, nil)
+		mockNoteRepo.On("UpdateWithDiagnoses", existingNote, mock.AnythingOfType("[]models.NoteDiagnosis"), mock.Anything).Return(nil)
+
+		result, err := service.UpdateNote(1, input, 2)
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Diagnoses, 1)
+		assert.Equal(t, uint(11), result.Diagnoses[0].DiagnosisCodeID)
+		mockNoteRepo.AssertExpectations(t)
+		mockNoteRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("DiagnosesWriteFails", func(t *testing.T) {
+		mockNoteRepo := new(mocks.ClinicalNoteRepository)
+		mockAppointmentRepo := new(mocks.AppointmentRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockDiagnosisRepo := new(mocks.DiagnosisCodeRepository)
+		mockAllergyRepo := new(mocks.AllergyRepository)
+		mockMedicationRepo := new(mocks.MedicationRepository)
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
+
+		decisionSupport := noCdsAlerts()
+		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo, mockDiagnosisRepo,
+			mockAllergyRepo, mockMedicationRepo, mockAdmissionRepo, decisionSupport)
+
+		existingNote := &models.ClinicalNote{
+			Model:    gorm.Model{ID: 1},
+			DoctorID: 2,
+		}
+
+		diagnoses := []models.NoteDiagnosisInput{{Code: "I10", Type: constants.DiagnosisTypes.PRIMARY}}
+		input := models.UpdateNoteInput{ClinicalDiagnosis: stringPtr("Hypertension"), Diagnoses: &diagnoses}
+
+		mockNoteRepo.On("FindByID", uint(1)).Return(existingNote, nil)
+		mockDiagnosisRepo.On("FindByCode", "I10").Return(&models.DiagnosisCode{Model: gorm.Model{ID: 11}, Code: "I10"}, nil)
+		mockNoteRepo.On("UpdateWithDiagnoses", existingNote, mock.AnythingOfType("[]models.NoteDiagnosis"), mock.Anything).
+			Return(errors.New("insert failed"))
+
+		result, err := service.UpdateNote(1, input, 2)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "insert failed")
+		mockNoteRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
 	t.Run("NoteNotFound", func(t *testing.T) {
 		mockNoteRepo := new(mocks.ClinicalNoteRepository)
 		mockAppointmentRepo := new(mocks.AppointmentRepository)
 		mockPatientRepo := new(mocks.PatientRepository)
+		mockDiagnosisRepo := new(mocks.DiagnosisCodeRepository)
+		mockAllergyRepo := new(mocks.AllergyRepository)
+		mockMedicationRepo := new(mocks.MedicationRepository)
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
 
-		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo)
+		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo, mockDiagnosisRepo,
+			mockAllergyRepo, mockMedicationRepo, mockAdmissionRepo, noCdsAlerts())
 
 		mockNoteRepo.On("FindByID", uint(1)).Return(&models.ClinicalNote{}, errors.New("not found"))
 
@@ -317,8 +776,13 @@ func TestUpdateNote(t *testing.T) {
 		mockNoteRepo := new(mocks.ClinicalNoteRepository)
 		mockAppointmentRepo := new(mocks.AppointmentRepository)
 		mockPatientRepo := new(mocks.PatientRepository)
+		mockDiagnosisRepo := new(mocks.DiagnosisCodeRepository)
+		mockAllergyRepo := new(mocks.AllergyRepository)
+		mockMedicationRepo := new(mocks.MedicationRepository)
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
 
-		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo)
+		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo, mockDiagnosisRepo,
+			mockAllergyRepo, mockMedicationRepo, mockAdmissionRepo, noCdsAlerts())
 
 		existingNote := &models.ClinicalNote{
 			Model:    gorm.Model{ID: 1},
@@ -344,8 +808,13 @@ func TestUpdateNote(t *testing.T) {
 		mockNoteRepo := new(mocks.ClinicalNoteRepository)
 		mockAppointmentRepo := new(mocks.AppointmentRepository)
 		mockPatientRepo := new(mocks.PatientRepository)
+		mockDiagnosisRepo := new(mocks.DiagnosisCodeRepository)
+		mockAllergyRepo := new(mocks.AllergyRepository)
+		mockMedicationRepo := new(mocks.MedicationRepository)
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
 
-		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo)
+		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo, mockDiagnosisRepo,
+			mockAllergyRepo, mockMedicationRepo, mockAdmissionRepo, noCdsAlerts())
 
 		existingNote := &models.ClinicalNote{
 			Model:    gorm.Model{ID: 1},
@@ -358,7 +827,7 @@ func TestUpdateNote(t *testing.T) {
 		}
 
 		mockNoteRepo.On("FindByID", uint(1)).Return(existingNote, nil)
-		mockNoteRepo.On("Update", mock.AnythingOfType("*models.ClinicalNote")).Return(errors.New("update failed"))
+		mockNoteRepo.On("Update", mock.AnythingOfType("*models.ClinicalNote"), mock.Anything).Return(errors.New("update failed"))
 
 		result, err := service.UpdateNote(1, input, 2)
 
@@ -374,8 +843,13 @@ func TestDeleteNote(t *testing.T) {
 		mockNoteRepo := new(mocks.ClinicalNoteRepository)
 		mockAppointmentRepo := new(mocks.AppointmentRepository)
 		mockPatientRepo := new(mocks.PatientRepository)
+		mockDiagnosisRepo := new(mocks.DiagnosisCodeRepository)
+		mockAllergyRepo := new(mocks.AllergyRepository)
+		mockMedicationRepo := new(mocks.MedicationRepository)
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
 
-		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo)
+		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo, mockDiagnosisRepo,
+			mockAllergyRepo, mockMedicationRepo, mockAdmissionRepo, noCdsAlerts())
 
 		existingNote := &models.ClinicalNote{
 			Model:    gorm.Model{ID: 1},
@@ -395,8 +869,13 @@ func TestDeleteNote(t *testing.T) {
 		mockNoteRepo := new(mocks.ClinicalNoteRepository)
 		mockAppointmentRepo := new(mocks.AppointmentRepository)
 		mockPatientRepo := new(mocks.PatientRepository)
+		mockDiagnosisRepo := new(mocks.DiagnosisCodeRepository)
+		mockAllergyRepo := new(mocks.AllergyRepository)
+		mockMedicationRepo := new(mocks.MedicationRepository)
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
 
-		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo)
+		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo, mockDiagnosisRepo,
+			mockAllergyRepo, mockMedicationRepo, mockAdmissionRepo, noCdsAlerts())
 
 		mockNoteRepo.On("FindByID", uint(1)).Return(&models.ClinicalNote{}, errors.New("not found"))
 
@@ -412,8 +891,13 @@ func TestDeleteNote(t *testing.T) {
 		mockNoteRepo := new(mocks.ClinicalNoteRepository)
 		mockAppointmentRepo := new(mocks.AppointmentRepository)
 		mockPatientRepo := new(mocks.PatientRepository)
+		mockDiagnosisRepo := new(mocks.DiagnosisCodeRepository)
+		mockAllergyRepo := new(mocks.AllergyRepository)
+		mockMedicationRepo := new(mocks.MedicationRepository)
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
 
-		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo)
+		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo, mockDiagnosisRepo,
+			mockAllergyRepo, mockMedicationRepo, mockAdmissionRepo, noCdsAlerts())
 
 		existingNote := &models.ClinicalNote{
 			Model:    gorm.Model{ID: 1},
@@ -434,8 +918,13 @@ func TestDeleteNote(t *testing.T) {
 		mockNoteRepo := new(mocks.ClinicalNoteRepository)
 		mockAppointmentRepo := new(mocks.AppointmentRepository)
 		mockPatientRepo := new(mocks.PatientRepository)
+		mockDiagnosisRepo := new(mocks.DiagnosisCodeRepository)
+		mockAllergyRepo := new(mocks.AllergyRepository)
+		mockMedicationRepo := new(mocks.MedicationRepository)
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
 
-		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo)
+		service := NewClinicalNoteService(mockNoteRepo, mockAppointmentRepo, mockPatientRepo, mockDiagnosisRepo,
+			mockAllergyRepo, mockMedicationRepo, mockAdmissionRepo, noCdsAlerts())
 
 		existingNote := &models.ClinicalNote{
 			Model:    gorm.Model{ID: 1},
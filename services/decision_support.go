@@ -0,0 +1,662 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+	"github.com/ofojichigozie/hms-go-backend/utils"
+)
+
+// DecisionSupport runs the clinical decision support rules of a trigger
+// against a record being saved. Evaluate is called before the save; the
+// alerts it returns are saved with the record through cdsAlertWrites.
+type DecisionSupport interface {
+	Evaluate(event models.CdsEvent) ([]models.CdsAlert, error)
+}
+
+// CdsAlertError blocks a save while a blocking alert is unacknowledged. It
+// carries every alert the record raised.
+type CdsAlertError struct {
+	Alerts []models.CdsAlert
+}
+
+func (e *CdsAlertError) Error() string {
+	return "record raises blocking clinical alerts; an alertOverrideReason is required to proceed"
+}
+
+// checkCdsAlerts fails with a CdsAlertError when a blocking alert has not
+// been acknowledged and no override reason was given.
+func checkCdsAlerts(alerts []models.CdsAlert, overrideReason string) error {
+	if strings.TrimSpace(overrideReason) != "" {
+		return nil
+	}
+	for _, alert := range alerts {
+		if alert.Severity == constants.CdsSeverity.BLOCKING && alert.AcknowledgedAt == nil {
+			return &CdsAlertError{Alerts: alerts}
+		}
+	}
+	return nil
+}
+
+// cdsAlertWrites prepares the alerts a record raised to be saved with it.
+// Blocking alerts are acknowledged by the staff member saving the record, with
+// the override reason they gave. Alerts raised on an earlier save are only
+// written again when they are acknowledged now.
+func cdsAlertWrites(alerts []models.CdsAlert, staffID uint, overrideReason string) models.ClinicalRecordWrites {
+	var writes models.ClinicalRecordWrites
+	now := time.Now()
+	for i := range alerts {
+		alert := &alerts[i]
+		overridden := alert.Severity == constants.CdsSeverity.BLOCKING && alert.AcknowledgedAt == nil
+		if overridden {
+			alert.AcknowledgedBy = &staffID
+			alert.AcknowledgedAt = &now
+			alert.AcknowledgementReason = overrideReason
+		}
+
+		if alert.ID != 0 {
+			if overridden {
+				writes.Acknowledged = append(writes.Acknowledged, alert)
+			}
+			continue
+		}
+
+		alert.TriggeredBy = staffID
+		writes.NewAlerts = append(writes.NewAlerts, alert)
+	}
+	return writes
+}
+
+type cdsFactSpec struct {
+	// trigger is empty for facts available on every trigger.
+	trigger string
+	numeric bool
+	labTest bool
+}
+
+var cdsFactSpecs = map[string]cdsFactSpec{
+	constants.CdsFacts.PATIENT_GENOTYPE:    {},
+	constants.CdsFacts.PATIENT_BLOOD_GROUP: {},
+	constants.CdsFacts.PATIENT_GENDER:      {},
+	constants.CdsFacts.PATIENT_AGE_YEARS:   {numeric: true},
+	constants.CdsFacts.PATIENT_AGE_DAYS:    {numeric: true},
+	constants.CdsFacts.PATIENT_WEIGHT_KG:   {numeric: true},
+
+	constants.CdsFacts.VITALS_TEMPERATURE_C:     {numeric: true},
+	constants.CdsFacts.VITALS_PULSE_RATE:        {numeric: true},
+	constants.CdsFacts.VITALS_RESPIRATORY_RATE:  {numeric: true},
+	constants.CdsFacts.VITALS_SYSTOLIC_BP:       {numeric: true},
+	constants.CdsFacts.VITALS_OXYGEN_SATURATION: {numeric: true},
+
+	constants.CdsFacts.NOTE_TEXT:            {trigger: constants.CdsTriggers.CLINICAL_NOTE},
+	constants.CdsFacts.NOTE_COMPLAINTS:      {trigger: constants.CdsTriggers.CLINICAL_NOTE},
+	constants.CdsFacts.NOTE_DIAGNOSIS_CODES: {trigger: constants.CdsTriggers.CLINICAL_NOTE},
+
+	constants.CdsFacts.PRESCRIPTION_MEDICATION:        {trigger: constants.CdsTriggers.PRESCRIPTION},
+	constants.CdsFacts.PRESCRIPTION_DRUG_CLASS:        {trigger: constants.CdsTriggers.PRESCRIPTION},
+	constants.CdsFacts.PRESCRIPTION_ROUTE:             {trigger: constants.CdsTriggers.PRESCRIPTION},
+	constants.CdsFacts.PRESCRIPTION_DOSE_UNIT:         {trigger: constants.CdsTriggers.PRESCRIPTION},
+	constants.CdsFacts.PRESCRIPTION_DOSE_AMOUNT:       {trigger: constants.CdsTriggers.PRESCRIPTION, numeric: true},
+	constants.CdsFacts.PRESCRIPTION_DOSE_PER_KG:       {trigger: constants.CdsTriggers.PRESCRIPTION, numeric: true},
+	constants.CdsFacts.PRESCRIPTION_DAILY_DOSE_PER_KG: {trigger: constants.CdsTriggers.PRESCRIPTION, numeric: true},
+
+	constants.CdsFacts.LAB_VALUE: {trigger: constants.CdsTriggers.LAB_RESULT, numeric: true, labTest: true},
+	constants.CdsFacts.LAB_FLAG:  {trigger: constants.CdsTriggers.LAB_RESULT, labTest: true},
+	constants.CdsFacts.LAB_TEXT:  {trigger: constants.CdsTriggers.LAB_RESULT, labTest: true},
+}
+
+type decisionSupport struct {
+	cdsRuleRepository           repositories.CdsRuleRepository
+	cdsAlertRepository          repositories.CdsAlertRepository
+	patientRepository           repositories.PatientRepository
+	vitalSignRepository         repositories.VitalSignRepository
+	growthMeasurementRepository repositories.GrowthMeasurementRepository
+}
+
+func NewDecisionSupport(
+	cdsRuleRepository repositories.CdsRuleRepository,
+	cdsAlertRepository repositories.CdsAlertRepository,
+	patientRepository repositories.PatientRepository,
+	vitalSignRepository repositories.VitalSignRepository,
+	growthMeasurementRepository repositories.GrowthMeasurementRepository,
+) DecisionSupport {
+	return &decisionSupport{
+		cdsRuleRepository:           cdsRuleRepository,
+		cdsAlertRepository:          cdsAlertRepository,
+		patientRepository:           patientRepository,
+		vitalSignRepository:         vitalSignRepository,
+		growthMeasurementRepository: growthMeasurementRepository,
+	}
+}
+
+// Evaluate returns an alert for each active rule of the event's trigger that
+// fires. A rule that would fire but needs the patient's weight, which has not
+// been recorded, raises an advisory alert saying it could not be checked.
+// When the record was saved before, a rule that already alerted on it returns
+// that alert, so an acknowledged alert does not block again.
+func (ds *decisionSupport) Evaluate(event models.CdsEvent) ([]models.CdsAlert, error) {
+	rules, err := ds.cdsRuleRepository.FindActiveByTrigger(event.Trigger)
+	if err != nil || len(rules) == 0 {
+		return nil, err
+	}
+
+	facts, err := ds.gatherFacts(event, rules)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := make(map[uint]models.CdsAlert)
+	if event.EntityID != 0 {
+		existing, err := ds.cdsAlertRepository.FindByEntity(event.Trigger, event.EntityID)
+		if err != nil {
+			return nil, err
+		}
+		for _, alert := range existing {
+			previous[alert.RuleID] = alert
+		}
+	}
+
+	var alerts []models.CdsAlert
+	for _, rule := range rules {
+		matched, missing := evaluateCdsRule(rule, facts)
+		if matched == nil {
+			continue
+		}
+		if alert, ok := previous[rule.ID]; ok {
+			alerts = append(alerts, alert)
+			continue
+		}
+
+		alert := models.CdsAlert{
+			RuleID:    rule.ID,
+			RuleName:  rule.Name,
+			PatientID: event.PatientID,
+			Trigger:   event.Trigger,
+			EntityID:  event.EntityID,
+			Severity:  rule.Severity,
+			Message:   rule.Message,
+			Facts:     matched,
+		}
+		if len(missing) > 0 {
+			alert.Severity = constants.CdsSeverity.ADVISORY
+			alert.Message = uncheckedCdsMessage(rule.Message)
+			alert.Facts[cdsMissingFactsKey] = strings.Join(missing, ", ")
+		}
+		alerts = append(alerts, alert)
+	}
+
+	return alerts, nil
+}
+
+// gatherFacts collects what the rules can test. Vital signs and weight are
+// only looked up when a rule refers to them.
+func (ds *decisionSupport) gatherFacts(event models.CdsEvent, rules []models.CdsRule) (cdsFacts, error) {
+	facts := newCdsFacts()
+
+	patient, err := ds.patientRepository.FindByID(event.PatientID)
+	if err != nil || patient == nil {
+		return facts, errors.New("patient record not found")
+	}
+	addPatientFacts(facts, patient, time.Now())
+
+	referenced := referencedCdsFacts(rules)
+	if referenced["vitals"] {
+		vitals, _, err := ds.vitalSignRepository.FindByPatientID(patient.ID, models.PageQuery{Page: 1, PageSize: 1})
+		if err != nil {
+			return facts, err
+		}
+		if len(vitals) > 0 && time.Since(vitals[0].RecordedAt) <= constants.CdsVitalsWindow {
+			addVitalsFacts(facts, vitals[0])
+		}
+	}
+
+	var weightKg *float64
+	if referenced[constants.CdsFacts.PATIENT_WEIGHT_KG] ||
+		referenced[constants.CdsFacts.PRESCRIPTION_DOSE_PER_KG] ||
+		referenced[constants.CdsFacts.PRESCRIPTION_DAILY_DOSE_PER_KG] {
+		measurements, err := ds.growthMeasurementRepository.FindByPatientID(patient.ID)
+		if err != nil {
+			return facts, err
+		}
+		weightKg = latestWeight(measurements)
+		if weightKg != nil && *weightKg > 0 {
+			facts.setNumber(constants.CdsFacts.PATIENT_WEIGHT_KG, *weightKg)
+		} else {
+			weightKg = nil
+			facts.unknown[constants.CdsFacts.PATIENT_WEIGHT_KG] = true
+			facts.unknown[constants.CdsFacts.PRESCRIPTION_DOSE_PER_KG] = true
+			if event.Prescription != nil && dosesPerDay(event.Prescription.Frequency) > 0 {
+				facts.unknown[constants.CdsFacts.PRESCRIPTION_DAILY_DOSE_PER_KG] = true
+			}
+		}
+	}
+
+	switch event.Trigger {
+	case constants.CdsTriggers.CLINICAL_NOTE:
+		if event.Note != nil {
+			addNoteFacts(facts, event.Note)
+		}
+	case constants.CdsTriggers.PRESCRIPTION:
+		if event.Prescription != nil {
+			addPrescriptionFacts(facts, event.Prescription, weightKg)
+		}
+	case constants.CdsTriggers.LAB_RESULT:
+		if event.LabOrder != nil {
+			addLabFacts(facts, event.LabOrder)
+		}
+	}
+
+	return facts, nil
+}
+
+// referencedCdsFacts lists the facts the rules test, with "vitals" standing
+// for any vital sign.
+func referencedCdsFacts(rules []models.CdsRule) map[string]bool {
+	referenced := make(map[string]bool)
+	for _, rule := range rules {
+		for _, conditions := range [][]models.CdsCondition{rule.Conditions.All, rule.Conditions.Any} {
+			for _, condition := range conditions {
+				referenced[condition.Fact] = true
+				if strings.HasPrefix(condition.Fact, "vitals.") {
+					referenced["vitals"] = true
+				}
+			}
+		}
+	}
+	return referenced
+}
+
+// latestWeight returns the most recent recorded weight. Measurements are
+// ordered oldest first.
+func latestWeight(measurements []models.GrowthMeasurement) *float64 {
+	for i := len(measurements) - 1; i >= 0; i-- {
+		if measurements[i].WeightKg != nil {
+			return measurements[i].WeightKg
+		}
+	}
+	return nil
+}
+
+// cdsFacts holds what is known about the patient and the record being saved.
+// A text fact can have several values, such as a drug's brand and generic
+// names. Lab facts are keyed by test code. Unknown lists the facts a rule
+// needs but which cannot be worked out because the patient's weight is not
+// recorded.
+type cdsFacts struct {
+	text    map[string][]string
+	numbers map[string]float64
+	unknown map[string]bool
+}
+
+func newCdsFacts() cdsFacts {
+	return cdsFacts{
+		text:    make(map[string][]string),
+		numbers: make(map[string]float64),
+		unknown: make(map[string]bool),
+	}
+}
+
+func cdsFactKey(fact string, labTest string) string {
+	if labTest == "" {
+		return fact
+	}
+	return fact + ":" + strings.ToUpper(labTest)
+}
+
+func (f cdsFacts) addText(key string, value string) {
+	value = strings.TrimSpace(value)
+	if value != "" {
+		f.text[key] = append(f.text[key], value)
+	}
+}
+
+func (f cdsFacts) setNumber(key string, value float64) {
+	f.numbers[key] = value
+}
+
+func addPatientFacts(facts cdsFacts, patient *models.Patient, at time.Time) {
+	facts.addText(constants.CdsFacts.PATIENT_GENOTYPE, patient.Genotype)
+	facts.addText(constants.CdsFacts.PATIENT_BLOOD_GROUP, patient.BloodGroup)
+	facts.addText(constants.CdsFacts.PATIENT_GENDER, patient.Gender)
+	if !patient.DateOfBirth.IsZero() {
+		facts.setNumber(constants.CdsFacts.PATIENT_AGE_YEARS, float64(utils.AgeInYears(patient.DateOfBirth, at)))
+		facts.setNumber(constants.CdsFacts.PATIENT_AGE_DAYS, float64(utils.AgeInDays(patient.DateOfBirth, at)))
+	}
+}
+
+func addVitalsFacts(facts cdsFacts, vitals models.VitalSign) {
+	if vitals.TemperatureC != nil {
+		facts.setNumber(constants.CdsFacts.VITALS_TEMPERATURE_C, *vitals.TemperatureC)
+	}
+	for fact, value := range map[string]*int{
+		constants.CdsFacts.VITALS_PULSE_RATE:        vitals.PulseRate,
+		constants.CdsFacts.VITALS_RESPIRATORY_RATE:  vitals.RespiratoryRate,
+		constants.CdsFacts.VITALS_SYSTOLIC_BP:       vitals.SystolicBP,
+		constants.CdsFacts.VITALS_OXYGEN_SATURATION: vitals.OxygenSaturation,
+	} {
+		if value != nil {
+			facts.setNumber(fact, float64(*value))
+		}
+	}
+}
+
+func addNoteFacts(facts cdsFacts, note *models.ClinicalNote) {
+	facts.addText(constants.CdsFacts.NOTE_COMPLAINTS, note.PresentingComplaints)
+	for _, text := range []string{note.PresentingComplaints, note.PastMedicalHistory,
+		note.ClinicalDiagnosis, note.TreatmentPlan, note.Recommendation} {
+		facts.addText(constants.CdsFacts.NOTE_TEXT, text)
+	}
+	for _, diagnosis := range note.Diagnoses {
+		if diagnosis.DiagnosisCode != nil {
+			facts.addText(constants.CdsFacts.NOTE_DIAGNOSIS_CODES, diagnosis.DiagnosisCode.Code)
+		}
+	}
+}
+
+// addPrescriptionFacts works out the dose per kilogram from the patient's
+// weight, in the prescribed dose unit. The daily dose counts the scheduled
+// doses of the frequency, so it is not known for as-needed doses.
+func addPrescriptionFacts(facts cdsFacts, prescription *models.Prescription, weightKg *float64) {
+	if medication := prescription.Medication; medication != nil {
+		facts.addText(constants.CdsFacts.PRESCRIPTION_MEDICATION, medication.Name)
+		facts.addText(constants.CdsFacts.PRESCRIPTION_MEDICATION, medication.GenericName)
+		facts.addText(constants.CdsFacts.PRESCRIPTION_DRUG_CLASS, medication.DrugClass)
+	}
+	facts.addText(constants.CdsFacts.PRESCRIPTION_ROUTE, prescription.Route)
+	facts.addText(constants.CdsFacts.PRESCRIPTION_DOSE_UNIT, prescription.DoseUnit)
+	facts.setNumber(constants.CdsFacts.PRESCRIPTION_DOSE_AMOUNT, prescription.DoseAmount)
+
+	if weightKg == nil || *weightKg <= 0 {
+		return
+	}
+	dosePerKg := prescription.DoseAmount / *weightKg
+	facts.setNumber(constants.CdsFacts.PRESCRIPTION_DOSE_PER_KG, dosePerKg)
+
+	if doses := dosesPerDay(prescription.Frequency); doses > 0 {
+		facts.setNumber(constants.CdsFacts.PRESCRIPTION_DAILY_DOSE_PER_KG, dosePerKg*float64(doses))
+	}
+}
+
+// dosesPerDay counts the scheduled doses of a frequency, which is none for
+// as-needed doses.
+func dosesPerDay(frequency string) int {
+	if frequency == constants.DoseFrequency.STAT {
+		return 1
+	}
+	return len(constants.DoseScheduleHours[frequency])
+}
+
+func addLabFacts(facts cdsFacts, order *models.LabOrder) {
+	for _, result := range order.Results {
+		if result.LabTest == nil {
+			continue
+		}
+		code := result.LabTest.Code
+		if result.NumericValue != nil {
+			facts.setNumber(cdsFactKey(constants.CdsFacts.LAB_VALUE, code), *result.NumericValue)
+		}
+		facts.addText(cdsFactKey(constants.CdsFacts.LAB_FLAG, code), result.Flag)
+		facts.addText(cdsFactKey(constants.CdsFacts.LAB_TEXT, code), result.TextValue)
+	}
+}
+
+// cdsMissingFactsKey lists, in an alert's facts, the facts a rule could not
+// be checked against.
+const cdsMissingFactsKey = "missing"
+
+// evaluateCdsRule returns the values of the facts its holding conditions
+// tested when the rule fires, and nil when it does not. A condition on an
+// unknown fact is taken to hold and is listed in missing, so the rule is
+// reported as unchecked rather than passing silently.
+func evaluateCdsRule(rule models.CdsRule, facts cdsFacts) (matched map[string]string, missing []string) {
+	matched = make(map[string]string)
+	for _, condition := range rule.Conditions.All {
+		if facts.holds(condition, matched) {
+			continue
+		}
+		if !facts.unknown[condition.Fact] {
+			return nil, nil
+		}
+		missing = appendMissing(missing, condition.Fact)
+	}
+
+	if len(rule.Conditions.Any) > 0 {
+		anyHeld := false
+		var anyMissing []string
+		for _, condition := range rule.Conditions.Any {
+			if facts.holds(condition, matched) {
+				anyHeld = true
+			} else if facts.unknown[condition.Fact] {
+				anyMissing = appendMissing(anyMissing, condition.Fact)
+			}
+		}
+		if !anyHeld {
+			if len(anyMissing) == 0 {
+				return nil, nil
+			}
+			for _, fact := range anyMissing {
+				missing = appendMissing(missing, fact)
+			}
+		}
+	}
+
+	return matched, missing
+}
+
+func appendMissing(missing []string, fact string) []string {
+	if slices.Contains(missing, fact) {
+		return missing
+	}
+	return append(missing, fact)
+}
+
+// uncheckedCdsMessage explains an alert raised for a rule that needs the
+// patient's weight, keeping within the alert's message length.
+func uncheckedCdsMessage(message string) string {
+	unchecked := "Not checked, the patient's weight is not recorded: " + message
+	if runes := []rune(unchecked); len(runes) > 500 {
+		unchecked = string(runes[:500])
+	}
+	return unchecked
+}
+
+// holds tests one condition, noting the fact's value in matched when it
+// holds.
+func (f cdsFacts) holds(condition models.CdsCondition, matched map[string]string) bool {
+	key := cdsFactKey(condition.Fact, condition.LabTest)
+	operators := constants.CdsOperators
+
+	switch condition.Operator {
+	case operators.GT, operators.GTE, operators.LT, operators.LTE:
+		value, ok := f.numbers[key]
+		if !ok || condition.Number == nil {
+			return false
+		}
+		limit := *condition.Number
+		held := (condition.Operator == operators.GT && value > limit) ||
+			(condition.Operator == operators.GTE && value >= limit) ||
+			(condition.Operator == operators.LT && value < limit) ||
+			(condition.Operator == operators.LTE && value <= limit)
+		if held {
+			matched[key] = strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+		}
+		return held
+	}
+
+	values := f.text[key]
+	if len(values) == 0 {
+		return false
+	}
+
+	var held bool
+	switch condition.Operator {
+	case operators.EQ:
+		held = anyTextMatches(values, []string{condition.Value}, strings.EqualFold)
+	case operators.NEQ:
+		held = !anyTextMatches(values, []string{condition.Value}, strings.EqualFold)
+	case operators.IN:
+		held = anyTextMatches(values, condition.Values, strings.EqualFold)
+	case operators.CONTAINS:
+		held = anyTextMatches(values, condition.Values, mentionsTerm)
+	}
+	if held {
+		matched[key] = strings.Join(values, "; ")
+	}
+	return held
+}
+
+// negationCues mark a term as absent when one of them comes up to
+// negationWindow words before it in the same clause, as in "no fever",
+// "denies chest pain" or "no history of seizures".
+var negationCues = map[string]bool{
+	"no": true, "not": true, "denies": true, "denied": true, "deny": true,
+	"without": true, "negative": true, "nil": true,
+}
+
+const negationWindow = 3
+
+// mentionsTerm reports whether the text mentions the term, on whole words,
+// other than to rule it out. Clauses end at punctuation and at "but", so in
+// "no cough but fever" only the cough is negated. Negation that follows the
+// term, as in "fever: absent", is not recognised.
+func mentionsTerm(text string, term string) bool {
+	want := termWords(term)
+	if len(want) == 0 {
+		return false
+	}
+
+	clauses := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return strings.ContainsRune(".,;:!?\n()", r)
+	})
+	for _, clause := range clauses {
+		var words []string
+		for _, word := range termWords(clause) {
+			if word == "but" {
+				if mentionedInClause(words, want) {
+					return true
+				}
+				words = nil
+				continue
+			}
+			words = append(words, word)
+		}
+		if mentionedInClause(words, want) {
+			return true
+		}
+	}
+	return false
+}
+
+func mentionedInClause(words []string, want []string) bool {
+	for i := 0; i+len(want) <= len(words); i++ {
+		if !slices.Equal(words[i:i+len(want)], want) {
+			continue
+		}
+		negated := false
+		for j := max(0, i-negationWindow); j < i; j++ {
+			if negationCues[words[j]] {
+				negated = true
+				break
+			}
+		}
+		if !negated {
+			return true
+		}
+	}
+	return false
+}
+
+func anyTextMatches(values []string, terms []string, match func(value, term string) bool) bool {
+	for _, value := range values {
+		for _, term := range terms {
+			if match(value, term) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// validateCdsConditions checks a rule's conditions against the facts of its
+// trigger and tidies their values.
+func validateCdsConditions(trigger string, conditions models.CdsConditions) (models.CdsConditions, error) {
+	if len(conditions.All)+len(conditions.Any) == 0 {
+		return conditions, errors.New("a rule needs at least one condition")
+	}
+
+	var err error
+	validated := models.CdsConditions{}
+	if validated.All, err = validateCdsConditionList(trigger, conditions.All); err != nil {
+		return conditions, err
+	}
+	if validated.Any, err = validateCdsConditionList(trigger, conditions.Any); err != nil {
+		return conditions, err
+	}
+	return validated, nil
+}
+
+func validateCdsConditionList(trigger string, conditions []models.CdsCondition) ([]models.CdsCondition, error) {
+	if len(conditions) == 0 {
+		return nil, nil
+	}
+
+	operators := constants.CdsOperators
+	validated := make([]models.CdsCondition, 0, len(conditions))
+	for _, condition := range conditions {
+		spec, ok := cdsFactSpecs[condition.Fact]
+		if !ok {
+			return nil, fmt.Errorf("unknown fact %q", condition.Fact)
+		}
+		if spec.trigger != "" && spec.trigger != trigger {
+			return nil, fmt.Errorf("fact %q is not available on %s rules", condition.Fact, trigger)
+		}
+
+		condition.LabTest = strings.ToUpper(strings.TrimSpace(condition.LabTest))
+		if spec.labTest && condition.LabTest == "" {
+			return nil, fmt.Errorf("fact %q needs a labTest code", condition.Fact)
+		}
+		if !spec.labTest && condition.LabTest != "" {
+			return nil, fmt.Errorf("fact %q does not take a labTest code", condition.Fact)
+		}
+
+		numericOperator := condition.Operator == operators.GT || condition.Operator == operators.GTE ||
+			condition.Operator == operators.LT || condition.Operator == operators.LTE
+		switch {
+		case spec.numeric:
+			if !numericOperator || condition.Number == nil {
+				return nil, fmt.Errorf("fact %q is numeric and needs gt, gte, lt or lte with a number", condition.Fact)
+			}
+			condition.Value, condition.Values = "", nil
+		case numericOperator:
+			return nil, fmt.Errorf("fact %q is text and cannot be compared with %s", condition.Fact, condition.Operator)
+		case condition.Operator == operators.EQ || condition.Operator == operators.NEQ:
+			condition.Value = strings.TrimSpace(condition.Value)
+			if condition.Value == "" {
+				return nil, fmt.Errorf("fact %q needs a value for %s", condition.Fact, condition.Operator)
+			}
+			condition.Values, condition.Number = nil, nil
+		default:
+			values := make([]string, 0, len(condition.Values))
+			for _, value := range condition.Values {
+				if value = strings.TrimSpace(value); value != "" {
+					values = append(values, value)
+				}
+			}
+			if len(values) == 0 {
+				return nil, fmt.Errorf("fact %q needs values for %s", condition.Fact, condition.Operator)
+			}
+			condition.Value, condition.Values, condition.Number = "", values, nil
+		}
+
+		validated = append(validated, condition)
+	}
+	return validated, nil
+}
@@ -0,0 +1,590 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// noCdsAlerts stands in for decision support when no rule fires.
+func noCdsAlerts() *mocks.DecisionSupport {
+	decisionSupport := new(mocks.DecisionSupport)
+	decisionSupport.On("Evaluate", mock.Anything).Return([]models.CdsAlert(nil), nil)
+	return decisionSupport
+}
+
+// raiseCdsAlerts makes the stand-in return the given alerts from Evaluate.
+func raiseCdsAlerts(decisionSupport *mocks.DecisionSupport, alerts ...models.CdsAlert) {
+	decisionSupport.ExpectedCalls = nil
+	decisionSupport.On("Evaluate", mock.Anything).Return(alerts, nil)
+}
+
+func sickleCellFeverRule() models.CdsRule {
+	return models.CdsRule{
+		Model:    gorm.Model{ID: 1},
+		Name:     "Sickle cell with fever",
+		Trigger:  constants.CdsTriggers.CLINICAL_NOTE,
+		Severity: constants.CdsSeverity.BLOCKING,
+		Message:  "HbSS patient with fever: screen for sepsis and start antibiotics within an hour",
+		Conditions: models.CdsConditions{
+			All: []models.CdsCondition{
+				{Fact: constants.CdsFacts.PATIENT_GENOTYPE, Operator: constants.CdsOperators.EQ, Value: "SS"},
+			},
+			Any: []models.CdsCondition{
+				{Fact: constants.CdsFacts.NOTE_COMPLAINTS, Operator: constants.CdsOperators.CONTAINS,
+					Values: []string{"fever", "pyrexia"}},
+				{Fact: constants.CdsFacts.VITALS_TEMPERATURE_C, Operator: constants.CdsOperators.GTE, Number: floatPtr(38)},
+			},
+		},
+	}
+}
+
+func paediatricParacetamolRule() models.CdsRule {
+	return models.CdsRule{
+		Model:    gorm.Model{ID: 2},
+		Name:     "Paediatric paracetamol dose",
+		Trigger:  constants.CdsTriggers.PRESCRIPTION,
+		Severity: constants.CdsSeverity.BLOCKING,
+		Message:  "Paracetamol dose exceeds 15 mg/kg",
+		Conditions: models.CdsConditions{
+			All: []models.CdsCondition{
+				{Fact: constants.CdsFacts.PATIENT_AGE_YEARS, Operator: constants.CdsOperators.LT, Number: floatPtr(12)},
+				{Fact: constants.CdsFacts.PRESCRIPTION_MEDICATION, Operator: constants.CdsOperators.CONTAINS,
+					Values: []string{"paracetamol"}},
+				{Fact: constants.CdsFacts.PRESCRIPTION_DOSE_UNIT, Operator: constants.CdsOperators.EQ, Value: "mg"},
+				{Fact: constants.CdsFacts.PRESCRIPTION_DOSE_PER_KG, Operator: constants.CdsOperators.GT, Number: floatPtr(15)},
+			},
+		},
+	}
+}
+
+func TestEvaluateCdsRules(t *testing.T) {
+	sicklePatient := &models.Patient{Model: gorm.Model{ID: 7}, Genotype: "SS", DateOfBirth: time.Now().AddDate(-9, 0, 0)}
+	latestVitals := models.PageQuery{Page: 1, PageSize: 1}
+
+	t.Run("SickleCellWithFever", func(t *testing.T) {
+		mockCdsRuleRepo := new(mocks.CdsRuleRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockVitalSignRepo := new(mocks.VitalSignRepository)
+		mockGrowthMeasurementRepo := new(mocks.GrowthMeasurementRepository)
+
+		decisionSupport := NewDecisionSupport(mockCdsRuleRepo, new(mocks.CdsAlertRepository),
+			mockPatientRepo, mockVitalSignRepo, mockGrowthMeasurementRepo)
+
+		note := &models.ClinicalNote{PatientID: 7, PresentingComplaints: "Joint pains since yesterday"}
+		vitals := []models.VitalSign{{PatientID: 7, RecordedAt: time.Now().Add(-time.Hour), TemperatureC: floatPtr(38.6)}}
+
+		mockCdsRuleRepo.On("FindActiveByTrigger", constants.CdsTriggers.CLINICAL_NOTE).
+			Return([]models.CdsRule{sickleCellFeverRule()}, nil)
+		mockPatientRepo.On("FindByID", uint(7)).Return(sicklePatient, nil)
+		mockVitalSignRepo.On("FindByPatientID", uint(7), latestVitals).Return(vitals, int64(1), nil)
+
+		alerts, err := decisionSupport.Evaluate(models.CdsEvent{
+			Trigger: constants.CdsTriggers.CLINICAL_NOTE, PatientID: 7, Note: note,
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, alerts, 1)
+		assert.Equal(t, uint(1), alerts[0].RuleID)
+		assert.Equal(t, constants.CdsSeverity.BLOCKING, alerts[0].Severity)
+		assert.Equal(t, map[string]string{
+			constants.CdsFacts.PATIENT_GENOTYPE:     "SS",
+			constants.CdsFacts.VITALS_TEMPERATURE_C: "38.6",
+		}, alerts[0].Facts)
+		mockGrowthMeasurementRepo.AssertNotCalled(t, "FindByPatientID", mock.Anything)
+	})
+
+	t.Run("FeverInComplaints", func(t *testing.T) {
+		mockCdsRuleRepo := new(mocks.CdsRuleRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockVitalSignRepo := new(mocks.VitalSignRepository)
+
+		decisionSupport := NewDecisionSupport(mockCdsRuleRepo, new(mocks.CdsAlertRepository),
+			mockPatientRepo, mockVitalSignRepo, new(mocks.GrowthMeasurementRepository))
+
+		note := &models.ClinicalNote{PatientID: 7, PresentingComplaints: "High Fever and chest pain"}
+
+		mockCdsRuleRepo.On("FindActiveByTrigger", constants.CdsTriggers.CLINICAL_NOTE).
+			Return([]models.CdsRule{sickleCellFeverRule()}, nil)
+		mockPatientRepo.On("FindByID", uint(7)).Return(sicklePatient, nil)
+		mockVitalSignRepo.On("FindByPatientID", uint(7), latestVitals).Return([]models.VitalSign{}, int64(0), nil)
+
+		alerts, err := decisionSupport.Evaluate(models.CdsEvent{
+			Trigger: constants.CdsTriggers.CLINICAL_NOTE, PatientID: 7, Note: note,
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, alerts, 1)
+		assert.Equal(t, "High Fever and chest pain", alerts[0].Facts[constants.CdsFacts.NOTE_COMPLAINTS])
+	})
+
+	t.Run("NegatedComplaints", func(t *testing.T) {
+		mockCdsRuleRepo := new(mocks.CdsRuleRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockVitalSignRepo := new(mocks.VitalSignRepository)
+
+		decisionSupport := NewDecisionSupport(mockCdsRuleRepo, new(mocks.CdsAlertRepository),
+			mockPatientRepo, mockVitalSignRepo, new(mocks.GrowthMeasurementRepository))
+
+		note := &models.ClinicalNote{PatientID: 7,
+			PresentingComplaints: "Joint pains since yesterday, no fever. Denies pyrexia or chills"}
+
+		mockCdsRuleRepo.On("FindActiveByTrigger", constants.CdsTriggers.CLINICAL_NOTE).
+			Return([]models.CdsRule{sickleCellFeverRule()}, nil)
+		mockPatientRepo.On("FindByID", uint(7)).Return(sicklePatient, nil)
+		mockVitalSignRepo.On("FindByPatientID", uint(7), latestVitals).Return([]models.VitalSign{}, int64(0), nil)
+
+		alerts, err := decisionSupport.Evaluate(models.CdsEvent{
+			Trigger: constants.CdsTriggers.CLINICAL_NOTE, PatientID: 7, Note: note,
+		})
+
+		assert.NoError(t, err)
+		assert.Empty(t, alerts)
+	})
+
+	t.Run("FeverAfterNegatedClause", func(t *testing.T) {
+		mockCdsRuleRepo := new(mocks.CdsRuleRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockVitalSignRepo := new(mocks.VitalSignRepository)
+
+		decisionSupport := NewDecisionSupport(mockCdsRuleRepo, new(mocks.CdsAlertRepository),
+			mockPatientRepo, mockVitalSignRepo, new(mocks.GrowthMeasurementRepository))
+
+		note := &models.ClinicalNote{PatientID: 7, PresentingComplaints: "No cough but feverish, fever since Monday"}
+
+		mockCdsRuleRepo.On("FindActiveByTrigger", constants.CdsTriggers.CLINICAL_NOTE).
+			Return([]models.CdsRule{sickleCellFeverRule()}, nil)
+		mockPatientRepo.On("FindByID", uint(7)).Return(sicklePatient, nil)
+		mockVitalSignRepo.On("FindByPatientID", uint(7), latestVitals).Return([]models.VitalSign{}, int64(0), nil)
+
+		alerts, err := decisionSupport.Evaluate(models.CdsEvent{
+			Trigger: constants.CdsTriggers.CLINICAL_NOTE, PatientID: 7, Note: note,
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, alerts, 1)
+	})
+
+	t.Run("IgnoresOldVitals", func(t *testing.T) {
+		mockCdsRuleRepo := new(mocks.CdsRuleRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockVitalSignRepo := new(mocks.VitalSignRepository)
+
+		decisionSupport := NewDecisionSupport(mockCdsRuleRepo, new(mocks.CdsAlertRepository),
+			mockPatientRepo, mockVitalSignRepo, new(mocks.GrowthMeasurementRepository))
+
+		note := &models.ClinicalNote{PatientID: 7, PresentingComplaints: "Routine review"}
+		vitals := []models.VitalSign{{PatientID: 7, RecordedAt: time.Now().AddDate(0, 0, -3), TemperatureC: floatPtr(39)}}
+
+		mockCdsRuleRepo.On("FindActiveByTrigger", constants.CdsTriggers.CLINICAL_NOTE).
+			Return([]models.CdsRule{sickleCellFeverRule()}, nil)
+		mockPatientRepo.On("FindByID", uint(7)).Return(sicklePatient, nil)
+		mockVitalSignRepo.On("FindByPatientID", uint(7), latestVitals).Return(vitals, int64(1), nil)
+
+		alerts, err := decisionSupport.Evaluate(models.CdsEvent{
+			Trigger: constants.CdsTriggers.CLINICAL_NOTE, PatientID: 7, Note: note,
+		})
+
+		assert.NoError(t, err)
+		assert.Empty(t, alerts)
+	})
+
+	t.Run("OtherGenotype", func(t *testing.T) {
+		mockCdsRuleRepo := new(mocks.CdsRuleRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockVitalSignRepo := new(mocks.VitalSignRepository)
+
+		decisionSupport := NewDecisionSupport(mockCdsRuleRepo, new(mocks.CdsAlertRepository),
+			mockPatientRepo, mockVitalSignRepo, new(mocks.GrowthMeasurementRepository))
+
+		note := &models.ClinicalNote{PatientID: 8, PresentingComplaints: "Fever"}
+
+		mockCdsRuleRepo.On("FindActiveByTrigger", constants.CdsTriggers.CLINICAL_NOTE).
+			Return([]models.CdsRule{sickleCellFeverRule()}, nil)
+		mockPatientRepo.On("FindByID", uint(8)).Return(&models.Patient{Model: gorm.Model{ID: 8}, Genotype: "AA"}, nil)
+		mockVitalSignRepo.On("FindByPatientID", uint(8), latestVitals).Return([]models.VitalSign{}, int64(0), nil)
+
+		alerts, err := decisionSupport.Evaluate(models.CdsEvent{
+			Trigger: constants.CdsTriggers.CLINICAL_NOTE, PatientID: 8, Note: note,
+		})
+
+		assert.NoError(t, err)
+		assert.Empty(t, alerts)
+	})
+
+	t.Run("NoRules", func(t *testing.T) {
+		mockCdsRuleRepo := new(mocks.CdsRuleRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+
+		decisionSupport := NewDecisionSupport(mockCdsRuleRepo, new(mocks.CdsAlertRepository),
+			mockPatientRepo, new(mocks.VitalSignRepository), new(mocks.GrowthMeasurementRepository))
+
+		mockCdsRuleRepo.On("FindActiveByTrigger", constants.CdsTriggers.LAB_RESULT).Return([]models.CdsRule{}, nil)
+
+		alerts, err := decisionSupport.Evaluate(models.CdsEvent{Trigger: constants.CdsTriggers.LAB_RESULT, PatientID: 7})
+
+		assert.NoError(t, err)
+		assert.Empty(t, alerts)
+		mockPatientRepo.AssertNotCalled(t, "FindByID", mock.Anything)
+	})
+
+	t.Run("PaediatricDoseOverLimit", func(t *testing.T) {
+		mockCdsRuleRepo := new(mocks.CdsRuleRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockVitalSignRepo := new(mocks.VitalSignRepository)
+		mockGrowthMeasurementRepo := new(mocks.GrowthMeasurementRepository)
+
+		decisionSupport := NewDecisionSupport(mockCdsRuleRepo, new(mocks.CdsAlertRepository),
+			mockPatientRepo, mockVitalSignRepo, mockGrowthMeasurementRepo)
+
+		prescription := &models.Prescription{
+			PatientID:  7,
+			Medication: &models.Medication{Name: "Panadol", GenericName: "Paracetamol"},
+			DoseAmount: 500,
+			DoseUnit:   "mg",
+			Frequency:  constants.DoseFrequency.QDS,
+		}
+		measurements := []models.GrowthMeasurement{
+			{WeightKg: floatPtr(14)},
+			{WeightKg: floatPtr(16)},
+			{HeightCm: floatPtr(120)},
+		}
+
+		mockCdsRuleRepo.On("FindActiveByTrigger", constants.CdsTriggers.PRESCRIPTION).
+			Return([]models.CdsRule{paediatricParacetamolRule()}, nil)
+		mockPatientRepo.On("FindByID", uint(7)).Return(sicklePatient, nil)
+		mockGrowthMeasurementRepo.On("FindByPatientID", uint(7)).Return(measurements, nil)
+
+		alerts, err := decisionSupport.Evaluate(models.CdsEvent{
+			Trigger: constants.CdsTriggers.PRESCRIPTION, PatientID: 7, Prescription: prescription,
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, alerts, 1)
+		assert.Equal(t, "31.25", alerts[0].Facts[constants.CdsFacts.PRESCRIPTION_DOSE_PER_KG])
+		assert.Equal(t, "Panadol; Paracetamol", alerts[0].Facts[constants.CdsFacts.PRESCRIPTION_MEDICATION])
+		mockVitalSignRepo.AssertNotCalled(t, "FindByPatientID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("DoseWithinLimit", func(t *testing.T) {
+		mockCdsRuleRepo := new(mocks.CdsRuleRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockGrowthMeasurementRepo := new(mocks.GrowthMeasurementRepository)
+
+		decisionSupport := NewDecisionSupport(mockCdsRuleRepo, new(mocks.CdsAlertRepository),
+			mockPatientRepo, new(mocks.VitalSignRepository), mockGrowthMeasurementRepo)
+
+		prescription := &models.Prescription{
+			PatientID:  7,
+			Medication: &models.Medication{Name: "Paracetamol"},
+			DoseAmount: 240,
+			DoseUnit:   "mg",
+		}
+
+		mockCdsRuleRepo.On("FindActiveByTrigger", constants.CdsTriggers.PRESCRIPTION).
+			Return([]models.CdsRule{paediatricParacetamolRule()}, nil)
+		mockPatientRepo.On("FindByID", uint(7)).Return(sicklePatient, nil)
+		mockGrowthMeasurementRepo.On("FindByPatientID", uint(7)).Return([]models.GrowthMeasurement{{WeightKg: floatPtr(16)}}, nil)
+
+		alerts, err := decisionSupport.Evaluate(models.CdsEvent{
+			Trigger: constants.CdsTriggers.PRESCRIPTION, PatientID: 7, Prescription: prescription,
+		})
+
+		assert.NoError(t, err)
+		assert.Empty(t, alerts)
+	})
+
+	t.Run("NoWeightRecorded", func(t *testing.T) {
+		mockCdsRuleRepo := new(mocks.CdsRuleRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockGrowthMeasurementRepo := new(mocks.GrowthMeasurementRepository)
+
+		decisionSupport := NewDecisionSupport(mockCdsRuleRepo, new(mocks.CdsAlertRepository),
+			mockPatientRepo, new(mocks.VitalSignRepository), mockGrowthMeasurementRepo)
+
+		prescription := &models.Prescription{
+			PatientID:  7,
+			Medication: &models.Medication{Name: "Paracetamol"},
+			DoseAmount: 1000,
+			DoseUnit:   "mg",
+		}
+
+		mockCdsRuleRepo.On("FindActiveByTrigger", constants.CdsTriggers.PRESCRIPTION).
+			Return([]models.CdsRule{paediatricParacetamolRule()}, nil)
+		mockPatientRepo.On("FindByID", uint(7)).Return(sicklePatient, nil)
+		mockGrowthMeasurementRepo.On("FindByPatientID", uint(7)).Return([]models.GrowthMeasurement{}, nil)
+
+		alerts, err := decisionSupport.Evaluate(models.CdsEvent{
+			Trigger: constants.CdsTriggers.PRESCRIPTION, PatientID: 7, Prescription: prescription,
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, alerts, 1)
+		assert.Equal(t, constants.CdsSeverity.ADVISORY, alerts[0].Severity)
+		assert.Equal(t, "Not checked, the patient's weight is not recorded: Paracetamol dose exceeds 15 mg/kg", alerts[0].Message)
+		assert.Equal(t, constants.CdsFacts.PRESCRIPTION_DOSE_PER_KG, alerts[0].Facts["missing"])
+		assert.NoError(t, checkCdsAlerts(alerts, ""))
+	})
+
+	t.Run("NoWeightOtherConditionsFail", func(t *testing.T) {
+		mockCdsRuleRepo := new(mocks.CdsRuleRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockGrowthMeasurementRepo := new(mocks.GrowthMeasurementRepository)
+
+		decisionSupport := NewDecisionSupport(mockCdsRuleRepo, new(mocks.CdsAlertRepository),
+			mockPatientRepo, new(mocks.VitalSignRepository), mockGrowthMeasurementRepo)
+
+		prescription := &models.Prescription{
+			PatientID:  7,
+			Medication: &models.Medication{Name: "Ibuprofen"},
+			DoseAmount: 200,
+			DoseUnit:   "mg",
+		}
+
+		mockCdsRuleRepo.On("FindActiveByTrigger", constants.CdsTriggers.PRESCRIPTION).
+			Return([]models.CdsRule{paediatricParacetamolRule()}, nil)
+		mockPatientRepo.On("FindByID", uint(7)).Return(sicklePatient, nil)
+		mockGrowthMeasurementRepo.On("FindByPatientID", uint(7)).Return([]models.GrowthMeasurement{}, nil)
+
+		alerts, err := decisionSupport.Evaluate(models.CdsEvent{
+			Trigger: constants.CdsTriggers.PRESCRIPTION, PatientID: 7, Prescription: prescription,
+		})
+
+		assert.NoError(t, err)
+		assert.Empty(t, alerts)
+	})
+
+	t.Run("LabResult", func(t *testing.T) {
+		mockCdsRuleRepo := new(mocks.CdsRuleRepository)
+		mockCdsAlertRepo := new(mocks.CdsAlertRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+
+		decisionSupport := NewDecisionSupport(mockCdsRuleRepo, mockCdsAlertRepo, mockPatientRepo,
+			new(mocks.VitalSignRepository), new(mocks.GrowthMeasurementRepository))
+
+		rule := models.CdsRule{
+			Model:    gorm.Model{ID: 3},
+			Name:     "Severe anaemia in sickle cell",
+			Trigger:  constants.CdsTriggers.LAB_RESULT,
+			Severity: constants.CdsSeverity.ADVISORY,
+			Message:  "Consider transfusion",
+			Conditions: models.CdsConditions{All: []models.CdsCondition{
+				{Fact: constants.CdsFacts.PATIENT_GENOTYPE, Operator: constants.CdsOperators.IN, Values: []string{"SS", "SC"}},
+				{Fact: constants.CdsFacts.LAB_VALUE, LabTest: "HB", Operator: constants.CdsOperators.LT, Number: floatPtr(6)},
+			}},
+		}
+		order := &models.LabOrder{
+			Model:     gorm.Model{ID: 40},
+			PatientID: 7,
+			Results: []models.LabResult{
+				{LabTestID: 4, LabTest: &models.LabTest{Code: "HB"}, NumericValue: floatPtr(5.2)},
+				{LabTestID: 5, LabTest: &models.LabTest{Code: "WBC"}},
+			},
+		}
+
+		mockCdsRuleRepo.On("FindActiveByTrigger", constants.CdsTriggers.LAB_RESULT).Return([]models.CdsRule{rule}, nil)
+		mockPatientRepo.On("FindByID", uint(7)).Return(sicklePatient, nil)
+		mockCdsAlertRepo.On("FindByEntity", constants.CdsTriggers.LAB_RESULT, uint(40)).Return([]models.CdsAlert{}, nil)
+
+		alerts, err := decisionSupport.Evaluate(models.CdsEvent{
+			Trigger: constants.CdsTriggers.LAB_RESULT, PatientID: 7, EntityID: 40, LabOrder: order,
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, alerts, 1)
+		assert.Equal(t, uint(40), alerts[0].EntityID)
+		assert.Equal(t, "5.2", alerts[0].Facts["lab.value:HB"])
+	})
+
+	t.Run("ReusesEarlierAlert", func(t *testing.T) {
+		mockCdsRuleRepo := new(mocks.CdsRuleRepository)
+		mockCdsAlertRepo := new(mocks.CdsAlertRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockVitalSignRepo := new(mocks.VitalSignRepository)
+
+		decisionSupport := NewDecisionSupport(mockCdsRuleRepo, mockCdsAlertRepo, mockPatientRepo,
+			mockVitalSignRepo, new(mocks.GrowthMeasurementRepository))
+
+		acknowledgedAt := time.Now().Add(-time.Hour)
+		earlier := models.CdsAlert{
+			Model:          gorm.Model{ID: 12},
+			RuleID:         1,
+			Severity:       constants.CdsSeverity.BLOCKING,
+			AcknowledgedAt: &acknowledgedAt,
+		}
+		note := &models.ClinicalNote{Model: gorm.Model{ID: 9}, PatientID: 7, PresentingComplaints: "Fever"}
+
+		mockCdsRuleRepo.On("FindActiveByTrigger", constants.CdsTriggers.CLINICAL_NOTE).
+			Return([]models.CdsRule{sickleCellFeverRule()}, nil)
+		mockPatientRepo.On("FindByID", uint(7)).Return(sicklePatient, nil)
+		mockVitalSignRepo.On("FindByPatientID", uint(7), latestVitals).Return([]models.VitalSign{}, int64(0), nil)
+		mockCdsAlertRepo.On("FindByEntity", constants.CdsTriggers.CLINICAL_NOTE, uint(9)).Return([]models.CdsAlert{earlier}, nil)
+
+		alerts, err := decisionSupport.Evaluate(models.CdsEvent{
+			Trigger: constants.CdsTriggers.CLINICAL_NOTE, PatientID: 7, EntityID: 9, Note: note,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []models.CdsAlert{earlier}, alerts)
+		assert.NoError(t, checkCdsAlerts(alerts, ""))
+	})
+}
+
+func TestCdsAlertWrites(t *testing.T) {
+	t.Run("AcknowledgesBlockingAlerts", func(t *testing.T) {
+		alerts := []models.CdsAlert{
+			{RuleID: 1, Severity: constants.CdsSeverity.BLOCKING},
+			{RuleID: 2, Severity: constants.CdsSeverity.ADVISORY},
+		}
+
+		writes := cdsAlertWrites(alerts, 2, "Reviewed; antibiotics started")
+
+		assert.Equal(t, []*models.CdsAlert{&alerts[0], &alerts[1]}, writes.NewAlerts)
+		assert.Empty(t, writes.Acknowledged)
+		assert.Equal(t, uint(2), alerts[0].TriggeredBy)
+		assert.Equal(t, uint(2), *alerts[0].AcknowledgedBy)
+		assert.NotNil(t, alerts[0].AcknowledgedAt)
+		assert.Equal(t, "Reviewed; antibiotics started", alerts[0].AcknowledgementReason)
+		assert.Nil(t, alerts[1].AcknowledgedAt)
+	})
+
+	t.Run("AcknowledgesEarlierBlockingAlert", func(t *testing.T) {
+		acknowledgedAt := time.Now()
+		alerts := []models.CdsAlert{
+			{Model: gorm.Model{ID: 12}, RuleID: 1, Severity: constants.CdsSeverity.BLOCKING},
+			{Model: gorm.Model{ID: 13}, RuleID: 2, Severity: constants.CdsSeverity.ADVISORY},
+			{Model: gorm.Model{ID: 14}, RuleID: 3, Severity: constants.CdsSeverity.BLOCKING, AcknowledgedAt: &acknowledgedAt},
+		}
+
+		writes := cdsAlertWrites(alerts, 2, "Discussed with haematology")
+
+		assert.Empty(t, writes.NewAlerts)
+		assert.Equal(t, []*models.CdsAlert{&alerts[0]}, writes.Acknowledged)
+		assert.Equal(t, "Discussed with haematology", alerts[0].AcknowledgementReason)
+		assert.Empty(t, alerts[2].AcknowledgementReason)
+	})
+}
+
+func TestCheckCdsAlerts(t *testing.T) {
+	acknowledgedAt := time.Now()
+	blocking := models.CdsAlert{Severity: constants.CdsSeverity.BLOCKING}
+	advisory := models.CdsAlert{Severity: constants.CdsSeverity.ADVISORY}
+
+	t.Run("BlockingWithoutReason", func(t *testing.T) {
+		err := checkCdsAlerts([]models.CdsAlert{advisory, blocking}, " ")
+
+		var blocked *CdsAlertError
+		assert.ErrorAs(t, err, &blocked)
+		assert.Len(t, blocked.Alerts, 2)
+	})
+
+	t.Run("BlockingWithReason", func(t *testing.T) {
+		assert.NoError(t, checkCdsAlerts([]models.CdsAlert{blocking}, "Dose confirmed with pharmacist"))
+	})
+
+	t.Run("AlreadyAcknowledged", func(t *testing.T) {
+		acknowledged := blocking
+		acknowledged.AcknowledgedAt = &acknowledgedAt
+		assert.NoError(t, checkCdsAlerts([]models.CdsAlert{acknowledged}, ""))
+	})
+
+	t.Run("AdvisoryOnly", func(t *testing.T) {
+		assert.NoError(t, checkCdsAlerts([]models.CdsAlert{advisory}, ""))
+	})
+}
+
+func TestValidateCdsConditions(t *testing.T) {
+	facts := constants.CdsFacts
+	operators := constants.CdsOperators
+
+	t.Run("TidiesValues", func(t *testing.T) {
+		conditions, err := validateCdsConditions(constants.CdsTriggers.LAB_RESULT, models.CdsConditions{
+			All: []models.CdsCondition{
+				{Fact: facts.LAB_VALUE, LabTest: " hb ", Operator: operators.LT, Number: floatPtr(7), Value: "ignored"},
+				{Fact: facts.PATIENT_GENOTYPE, Operator: operators.IN, Values: []string{" SS ", ""}},
+			},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "HB", conditions.All[0].LabTest)
+		assert.Empty(t, conditions.All[0].Value)
+		assert.Equal(t, []string{"SS"}, conditions.All[1].Values)
+		assert.Nil(t, conditions.Any)
+	})
+
+	t.Run("NoConditions", func(t *testing.T) {
+		_, err := validateCdsConditions(constants.CdsTriggers.CLINICAL_NOTE, models.CdsConditions{})
+
+		assert.EqualError(t, err, "a rule needs at least one condition")
+	})
+
+	t.Run("UnknownFact", func(t *testing.T) {
+		_, err := validateCdsConditions(constants.CdsTriggers.CLINICAL_NOTE, models.CdsConditions{All: []models.CdsCondition{
+			{Fact: "patient.shoeSize", Operator: operators.GT, Number: floatPtr(40)},
+		}})
+
+		assert.EqualError(t, err, `unknown fact "patient.shoeSize"`)
+	})
+
+	t.Run("FactOfAnotherTrigger", func(t *testing.T) {
+		_, err := validateCdsConditions(constants.CdsTriggers.CLINICAL_NOTE, models.CdsConditions{Any: []models.CdsCondition{
+			{Fact: facts.PRESCRIPTION_DOSE_PER_KG, Operator: operators.GT, Number: floatPtr(15)},
+		}})
+
+		assert.EqualError(t, err, `fact "prescription.dosePerKg" is not available on clinical_note rules`)
+	})
+
+	t.Run("MissingLabTest", func(t *testing.T) {
+		_, err := validateCdsConditions(constants.CdsTriggers.LAB_RESULT, models.CdsConditions{All: []models.CdsCondition{
+			{Fact: facts.LAB_FLAG, Operator: operators.EQ, Value: "critical_low"},
+		}})
+
+		assert.EqualError(t, err, `fact "lab.flag" needs a labTest code`)
+	})
+
+	t.Run("UnexpectedLabTest", func(t *testing.T) {
+		_, err := validateCdsConditions(constants.CdsTriggers.LAB_RESULT, models.CdsConditions{All: []models.CdsCondition{
+			{Fact: facts.PATIENT_GENOTYPE, LabTest: "HB", Operator: operators.EQ, Value: "SS"},
+		}})
+
+		assert.EqualError(t, err, `fact "patient.genotype" does not take a labTest code`)
+	})
+
+	t.Run("NumericWithoutNumber", func(t *testing.T) {
+		_, err := validateCdsConditions(constants.CdsTriggers.PRESCRIPTION, models.CdsConditions{All: []models.CdsCondition{
+			{Fact: facts.PATIENT_WEIGHT_KG, Operator: operators.LT},
+		}})
+
+		assert.EqualError(t, err, `fact "patient.weightKg" is numeric and needs gt, gte, lt or lte with a number`)
+	})
+
+	t.Run("TextComparedAsNumber", func(t *testing.T) {
+		_, err := validateCdsConditions(constants.CdsTriggers.PRESCRIPTION, models.CdsConditions{All: []models.CdsCondition{
+			{Fact: facts.PRESCRIPTION_ROUTE, Operator: operators.GT, Number: floatPtr(1)},
+		}})
+
+		assert.EqualError(t, err, `fact "prescription.route" is text and cannot be compared with gt`)
+	})
+
+	t.Run("MissingValue", func(t *testing.T) {
+		_, err := validateCdsConditions(constants.CdsTriggers.CLINICAL_NOTE, models.CdsConditions{All: []models.CdsCondition{
+			{Fact: facts.PATIENT_GENOTYPE, Operator: operators.EQ, Value: "  "},
+		}})
+
+		assert.EqualError(t, err, `fact "patient.genotype" needs a value for eq`)
+	})
+
+	t.Run("MissingValues", func(t *testing.T) {
+		_, err := validateCdsConditions(constants.CdsTriggers.CLINICAL_NOTE, models.CdsConditions{All: []models.CdsCondition{
+			{Fact: facts.NOTE_TEXT, Operator: operators.CONTAINS},
+		}})
+
+		assert.EqualError(t, err, `fact "note.text" needs values for contains`)
+	})
+}
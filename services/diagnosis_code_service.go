@@ -0,0 +1,131 @@
+package services
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+)
+
+const (
+	defaultDiagnosisSearchLimit = 20
+	maxDiagnosisSearchLimit     = 100
+)
+
+type DiagnosisCodeService interface {
+	ImportCSV(reader io.Reader) (int, error)
+	SearchCodes(query string, limit int) ([]models.DiagnosisCode, error)
+	GetByCode(code string) (*models.DiagnosisCode, error)
+}
+
+type diagnosisCodeService struct {
+	diagnosisCodeRepository repositories.DiagnosisCodeRepository
+}
+
+func NewDiagnosisCodeService(diagnosisCodeRepository repositories.DiagnosisCodeRepository) DiagnosisCodeService {
+	return &diagnosisCodeService{diagnosisCodeRepository: diagnosisCodeRepository}
+}
+
+// ImportCSV loads ICD-10 codes from rows of "code,description[,category]".
+// A leading header row is skipped and existing codes are updated in place.
+func (ds *diagnosisCodeService) ImportCSV(reader io.Reader) (int, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	var codes []models.DiagnosisCode
+	seen := make(map[string]bool)
+	line := 0
+	for {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		line++
+		if err != nil {
+			return 0, fmt.Errorf("invalid CSV at line %d: %w", line, err)
+		}
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "code") {
+			continue
+		}
+		if len(record) < 2 {
+			return 0, fmt.Errorf("line %d: expected at least code and description", line)
+		}
+
+		code := NormalizeDiagnosisCode(record[0])
+		description := strings.TrimSpace(record[1])
+		if code == "" || description == "" {
+			return 0, fmt.Errorf("line %d: code and description are required", line)
+		}
+		if seen[code] {
+			continue
+		}
+		seen[code] = true
+
+		diagnosisCode := models.DiagnosisCode{Code: code, Description: description}
+		if len(record) > 2 {
+			diagnosisCode.Category = strings.TrimSpace(record[2])
+		}
+		codes = append(codes, diagnosisCode)
+	}
+
+	if len(codes) == 0 {
+		return 0, errors.New("no diagnosis codes found in file")
+	}
+	if err := ds.diagnosisCodeRepository.Upsert(codes); err != nil {
+		return 0, err
+	}
+
+	return len(codes), nil
+}
+
+func (ds *diagnosisCodeService) SearchCodes(query string, limit int) ([]models.DiagnosisCode, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, errors.New("search query is required")
+	}
+	if limit <= 0 {
+		limit = defaultDiagnosisSearchLimit
+	}
+	if limit > maxDiagnosisSearchLimit {
+		limit = maxDiagnosisSearchLimit
+	}
+
+	if looksLikeDiagnosisCode(query) {
+		query = NormalizeDiagnosisCode(query)
+	}
+
+	return ds.diagnosisCodeRepository.Search(query, limit)
+}
+
+func (ds *diagnosisCodeService) GetByCode(code string) (*models.DiagnosisCode, error) {
+	diagnosisCode, err := ds.diagnosisCodeRepository.FindByCode(NormalizeDiagnosisCode(code))
+	if err != nil {
+		return nil, errors.New("diagnosis code not found")
+	}
+	return diagnosisCode, nil
+}
+
+// NormalizeDiagnosisCode upper-cases a code and inserts the ICD-10 dot after
+// the category (e.g. "e119" becomes "E11.9") so undotted source files and
+// user input compare equal.
+func NormalizeDiagnosisCode(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) > 3 && !strings.Contains(code, ".") {
+		code = code[:3] + "." + code[3:]
+	}
+	return code
+}
+
+func looksLikeDiagnosisCode(query string) bool {
+	if len(query) < 2 || len(query) > 8 || strings.Contains(query, " ") {
+		return false
+	}
+	first := query[0] | 0x20
+	second := query[1]
+	return first >= 'a' && first <= 'z' && second >= '0' && second <= '9'
+}
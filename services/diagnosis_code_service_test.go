@@ -0,0 +1,118 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestImportDiagnosisCodesCSV(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockDiagnosisRepo := new(mocks.DiagnosisCodeRepository)
+		service := NewDiagnosisCodeService(mockDiagnosisRepo)
+
+		csvData := "code,description,category\n" +
+			"A000,\"Cholera due to Vibrio cholerae 01, biovar cholerae\",Intestinal infectious diseases\n" +
+			"I10,Essential (primary) hypertension,Hypertensive diseases\n" +
+			"i10,Duplicate row,Hypertensive diseases\n"
+
+		mockDiagnosisRepo.On("Upsert", mock.AnythingOfType("[]models.DiagnosisCode")).Return(nil).Run(func(args mock.Arguments) {
+			codes := args.Get(0).([]models.DiagnosisCode)
+			assert.Len(t, codes, 2)
+			assert.Equal(t, "A00.0", codes[0].Code)
+			assert.Equal(t, "Cholera due to Vibrio cholerae 01, biovar cholerae", codes[0].Description)
+			assert.Equal(t, "Intestinal infectious diseases", codes[0].Category)
+			assert.Equal(t, "I10", codes[1].Code)
+		})
+
+		count, err := service.ImportCSV(strings.NewReader(csvData))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, count)
+		mockDiagnosisRepo.AssertExpectations(t)
+	})
+
+	t.Run("MissingDescription", func(t *testing.T) {
+		mockDiagnosisRepo := new(mocks.DiagnosisCodeRepository)
+		service := NewDiagnosisCodeService(mockDiagnosisRepo)
+
+		count, err := service.ImportCSV(strings.NewReader("I10\n"))
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, count)
+		assert.Equal(t, "line 1: expected at least code and description", err.Error())
+		mockDiagnosisRepo.AssertNotCalled(t, "Upsert")
+	})
+
+	t.Run("EmptyFile", func(t *testing.T) {
+		mockDiagnosisRepo := new(mocks.DiagnosisCodeRepository)
+		service := NewDiagnosisCodeService(mockDiagnosisRepo)
+
+		count, err := service.ImportCSV(strings.NewReader("code,description\n"))
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, count)
+		assert.Equal(t, "no diagnosis codes found in file", err.Error())
+	})
+}
+
+func TestSearchDiagnosisCodes(t *testing.T) {
+	t.Run("NormalizesCodeQueries", func(t *testing.T) {
+		mockDiagnosisRepo := new(mocks.DiagnosisCodeRepository)
+		service := NewDiagnosisCodeService(mockDiagnosisRepo)
+
+		expected := []models.DiagnosisCode{{Model: gorm.Model{ID: 1}, Code: "E11.9"}}
+		mockDiagnosisRepo.On("Search", "E11.9", defaultDiagnosisSearchLimit).Return(expected, nil)
+
+		result, err := service.SearchCodes("e119", 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+		mockDiagnosisRepo.AssertExpectations(t)
+	})
+
+	t.Run("FreeTextCapsLimit", func(t *testing.T) {
+		mockDiagnosisRepo := new(mocks.DiagnosisCodeRepository)
+		service := NewDiagnosisCodeService(mockDiagnosisRepo)
+
+		mockDiagnosisRepo.On("Search", "malaria", maxDiagnosisSearchLimit).Return([]models.DiagnosisCode{}, nil)
+
+		_, err := service.SearchCodes(" malaria ", 500)
+
+		assert.NoError(t, err)
+		mockDiagnosisRepo.AssertExpectations(t)
+	})
+
+	t.Run("EmptyQuery", func(t *testing.T) {
+		mockDiagnosisRepo := new(mocks.DiagnosisCodeRepository)
+		service := NewDiagnosisCodeService(mockDiagnosisRepo)
+
+		result, err := service.SearchCodes("  ", 10)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Equal(t, "search query is required", err.Error())
+		mockDiagnosisRepo.AssertNotCalled(t, "Search")
+	})
+}
+
+func TestGetDiagnosisCodeByCode(t *testing.T) {
+	t.Run("NotFound", func(t *testing.T) {
+		mockDiagnosisRepo := new(mocks.DiagnosisCodeRepository)
+		service := NewDiagnosisCodeService(mockDiagnosisRepo)
+
+		mockDiagnosisRepo.On("FindByCode", "B54").Return(&models.DiagnosisCode{}, errors.New("record not found"))
+
+		result, err := service.GetByCode("b54")
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Equal(t, "diagnosis code not found", err.Error())
+	})
+}
@@ -0,0 +1,231 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+)
+
+type DischargeSummaryService interface {
+	GetNoteSummary(noteID uint) (*SummaryDocument, error)
+	GetAdmissionSummary(admissionID uint) (*SummaryDocument, error)
+}
+
+// SummaryDocument gathers everything printed on an encounter or discharge
+// summary.
+type SummaryDocument struct {
+	HospitalName         string
+	HospitalAddress      string
+	Title                string
+	Patient              *models.Patient
+	Doctor               *models.Staff
+	Admission            *models.Admission
+	EncounterDate        time.Time
+	PresentingComplaints string
+	ClinicalDiagnosis    string
+	Diagnoses            []models.NoteDiagnosis
+	TreatmentPlan        string
+	Recommendation       string
+	Medications          []SummaryMedication
+	FollowUp             *models.Appointment
+	GeneratedAt          time.Time
+}
+
+type SummaryMedication struct {
+	Name         string
+	Dose         string
+	Route        string
+	Frequency    string
+	DurationDays int
+	Instructions string
+}
+
+type dischargeSummaryService struct {
+	clinicalNoteRepository repositories.ClinicalNoteRepository
+	admissionRepository    repositories.AdmissionRepository
+	prescriptionRepository repositories.PrescriptionRepository
+	appointmentRepository  repositories.AppointmentRepository
+	patientRepository      repositories.PatientRepository
+	staffRepository        repositories.StaffRepository
+}
+
+func NewDischargeSummaryService(
+	clinicalNoteRepository repositories.ClinicalNoteRepository,
+	admissionRepository repositories.AdmissionRepository,
+	prescriptionRepository repositories.PrescriptionRepository,
+	appointmentRepository repositories.AppointmentRepository,
+	patientRepository repositories.PatientRepository,
+	staffRepository repositories.StaffRepository,
+) DischargeSummaryService {
+	return &dischargeSummaryService{
+		clinicalNoteRepository: clinicalNoteRepository,
+		admissionRepository:    admissionRepository,
+		prescriptionRepository: prescriptionRepository,
+		appointmentRepository:  appointmentRepository,
+		patientRepository:      patientRepository,
+		staffRepository:        staffRepository,
+	}
+}
+
+// GetNoteSummary summarises a single encounter from its clinical note.
+func (ds *dischargeSummaryService) GetNoteSummary(noteID uint) (*SummaryDocument, error) {
+	note, err := ds.clinicalNoteRepository.FindByID(noteID)
+	if err != nil {
+		return nil, errors.New("clinical note not found")
+	}
+
+	document, err := ds.newDocument("Encounter Summary", note.PatientID, note.DoctorID)
+	if err != nil {
+		return nil, err
+	}
+	document.EncounterDate = note.CreatedAt
+
+	if err := ds.addNotes(document, []models.ClinicalNote{*note}); err != nil {
+		return nil, err
+	}
+	if err := ds.addFollowUp(document, note.CreatedAt); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// GetAdmissionSummary summarises a completed admission from every note
+// written during the stay.
+func (ds *dischargeSummaryService) GetAdmissionSummary(admissionID uint) (*SummaryDocument, error) {
+	admission, err := ds.admissionRepository.FindByID(admissionID)
+	if err != nil {
+		return nil, errors.New("admission not found")
+	}
+	if admission.Status != constants.AdmissionStatus.DISCHARGED || admission.DischargedBy == nil {
+		return nil, errors.New("patient has not been discharged yet")
+	}
+
+	document, err := ds.newDocument("Discharge Summary", admission.PatientID, *admission.DischargedBy)
+	if err != nil {
+		return nil, err
+	}
+	document.Admission = admission
+	document.EncounterDate = admission.AdmittedAt
+
+	notes, err := ds.clinicalNoteRepository.FindByAdmissionID(admission.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := ds.addNotes(document, notes); err != nil {
+		return nil, err
+	}
+	if document.PresentingComplaints == "" {
+		document.PresentingComplaints = admission.Reason
+	}
+	if err := ds.addFollowUp(document, *admission.DischargedAt); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+func (ds *dischargeSummaryService) newDocument(title string, patientID uint, doctorID uint) (*SummaryDocument, error) {
+	patient, err := ds.patientRepository.FindByID(patientID)
+	if err != nil || patient == nil {
+		return nil, errors.New("patient record not found")
+	}
+
+	doctor, err := ds.staffRepository.FindByID(doctorID)
+	if err != nil || doctor == nil {
+		return nil, errors.New("responsible doctor not found")
+	}
+
+	return &SummaryDocument{
+		HospitalName:    os.Getenv("HOSPITAL_NAME"),
+		HospitalAddress: os.Getenv("HOSPITAL_ADDRESS"),
+		Title:           title,
+		Patient:         patient,
+		Doctor:          doctor,
+		GeneratedAt:     time.Now(),
+	}, nil
+}
+
+// addNotes fills the clinical sections from notes in the order written: the
+// first note gives the presenting complaints, the latest gives the current
+// diagnosis, plan and recommendation, and coded diagnoses and prescriptions
+// are collected from all of them.
+func (ds *dischargeSummaryService) addNotes(document *SummaryDocument, notes []models.ClinicalNote) error {
+	seenCodes := make(map[uint]bool)
+	for i, note := range notes {
+		if i == 0 {
+			document.PresentingComplaints = note.PresentingComplaints
+		}
+		if note.ClinicalDiagnosis != "" {
+			document.ClinicalDiagnosis = note.ClinicalDiagnosis
+		}
+		document.TreatmentPlan = note.TreatmentPlan
+		document.Recommendation = note.Recommendation
+
+		for _, diagnosis := range note.Diagnoses {
+			if diagnosis.DiagnosisCode == nil || seenCodes[diagnosis.DiagnosisCodeID] {
+				continue
+			}
+			seenCodes[diagnosis.DiagnosisCodeID] = true
+			document.Diagnoses = append(document.Diagnoses, diagnosis)
+		}
+
+		prescriptions, err := ds.prescriptionRepository.FindByNoteID(note.ID)
+		if err != nil {
+			return err
+		}
+		for _, prescription := range prescriptions {
+			if prescription.Status == constants.PrescriptionStatus.DISCONTINUED {
+				continue
+			}
+			document.Medications = append(document.Medications, summaryMedication(prescription))
+		}
+	}
+	return nil
+}
+
+// addFollowUp attaches the patient's next scheduled appointment after the
+// encounter, if one has been booked.
+func (ds *dischargeSummaryService) addFollowUp(document *SummaryDocument, after time.Time) error {
+	appointments, err := ds.appointmentRepository.FindAll(map[string]interface{}{
+		"patient_id": document.Patient.ID,
+		"status":     constants.AppointmentStatus.SCHEDULED,
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := range appointments {
+		appointment := &appointments[i]
+		if !appointment.ScheduledAt.After(after) {
+			continue
+		}
+		if document.FollowUp == nil || appointment.ScheduledAt.Before(document.FollowUp.ScheduledAt) {
+			document.FollowUp = appointment
+		}
+	}
+	return nil
+}
+
+func summaryMedication(prescription models.Prescription) SummaryMedication {
+	medication := SummaryMedication{
+		Dose:         fmt.Sprintf("%g %s", prescription.DoseAmount, prescription.DoseUnit),
+		Route:        prescription.Route,
+		Frequency:    prescription.Frequency,
+		DurationDays: prescription.DurationDays,
+		Instructions: prescription.Instructions,
+	}
+	if label, ok := constants.DoseFrequencyLabels[prescription.Frequency]; ok {
+		medication.Frequency = label
+	}
+	if prescription.Medication != nil {
+		medication.Name = prescription.Medication.Name
+		if prescription.Medication.Strength != "" {
+			medication.Name += " " + prescription.Medication.Strength
+		}
+	}
+	return medication
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/services/mocks"
+	"github.com/ofojichigozie/hms-go-backend/templates"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type summaryMocks struct {
+	noteRepo         *mocks.ClinicalNoteRepository
+	admissionRepo    *mocks.AdmissionRepository
+	prescriptionRepo *mocks.PrescriptionRepository
+	appointmentRepo  *mocks.AppointmentRepository
+	patientRepo      *mocks.PatientRepository
+	staffRepo        *mocks.StaffRepository
+}
+
+func newSummaryServiceWithMocks() (DischargeSummaryService, summaryMocks) {
+	m := summaryMocks{
+		noteRepo:         new(mocks.ClinicalNoteRepository),
+		admissionRepo:    new(mocks.AdmissionRepository),
+		prescriptionRepo: new(mocks.PrescriptionRepository),
+		appointmentRepo:  new(mocks.AppointmentRepository),
+		patientRepo:      new(mocks.PatientRepository),
+		staffRepo:        new(mocks.StaffRepository),
+	}
+	service := NewDischargeSummaryService(m.noteRepo, m.admissionRepo, m.prescriptionRepo,
+		m.appointmentRepo, m.patientRepo, m.staffRepo)
+	return service, m
+}
+
+func TestGetNoteSummary(t *testing.T) {
+	t.Setenv("HOSPITAL_NAME", "Unity Specialist Hospital")
+
+	noteDate := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	pneumonia := &models.DiagnosisCode{Model: gorm.Model{ID: 4}, Code: "J18.9", Description: "Pneumonia, unspecified organism"}
+	note := &models.ClinicalNote{
+		Model:                gorm.Model{ID: 1, CreatedAt: noteDate},
+		PatientID:            7,
+		DoctorID:             2,
+		PresentingComplaints: "Cough and fever",
+		TreatmentPlan:        "Oral antibiotics",
+		Recommendation:       "Return if breathless",
+		Diagnoses:            []models.NoteDiagnosis{{DiagnosisCodeID: 4, DiagnosisCode: pneumonia, Type: constants.DiagnosisTypes.PRIMARY}},
+	}
+
+	service, m := newSummaryServiceWithMocks()
+	m.noteRepo.On("FindByID", uint(1)).Return(note, nil)
+	m.patientRepo.On("FindByID", uint(7)).Return(&models.Patient{Model: gorm.Model{ID: 7}, FirstName: "Ada"}, nil)
+	m.staffRepo.On("FindByID", uint(2)).Return(&models.Staff{Model: gorm.Model{ID: 2}, FirstName: "Emeka"}, nil)
+	m.prescriptionRepo.On("FindByNoteID", uint(1)).Return([]models.Prescription{
+		{Medication: &models.Medication{Name: "Amoxicillin", Strength: "500mg"}, DoseAmount: 500, DoseUnit: "mg",
+			Route: "oral", Frequency: constants.DoseFrequency.TDS, DurationDays: 5, Status: constants.PrescriptionStatus.ACTIVE},
+		{Medication: &models.Medication{Name: "Codeine"}, Status: constants.PrescriptionStatus.DISCONTINUED},
+	}, nil)
+	m.appointmentRepo.On("FindAll", mock.Anything).Return([]models.Appointment{
+		{Model: gorm.Model{ID: 20}, ScheduledAt: noteDate.AddDate(0, 0, -10)},
+		{Model: gorm.Model{ID: 21}, ScheduledAt: noteDate.AddDate(0, 0, 14)},
+		{Model: gorm.Model{ID: 22}, ScheduledAt: noteDate.AddDate(0, 0, 7)},
+	}, nil)
+
+	document, err := service.GetNoteSummary(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Unity Specialist Hospital", document.HospitalName)
+	assert.Equal(t, "Encounter Summary", document.Title)
+	assert.Equal(t, noteDate, document.EncounterDate)
+	assert.Len(t, document.Diagnoses, 1)
+	assert.Equal(t, []SummaryMedication{{
+		Name: "Amoxicillin 500mg", Dose: "500 mg", Route: "oral", Frequency: "three times daily", DurationDays: 5,
+	}}, document.Medications)
+	assert.Equal(t, uint(22), document.FollowUp.ID)
+}
+
+func TestGetAdmissionSummary(t *testing.T) {
+	admitted := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	discharged := admitted.AddDate(0, 0, 5)
+	dischargedBy := uint(3)
+	code := func(id uint, value string) *models.DiagnosisCode {
+		return &models.DiagnosisCode{Model: gorm.Model{ID: id}, Code: value}
+	}
+
+	t.Run("CombinesAdmissionNotes", func(t *testing.T) {
+		service, m := newSummaryServiceWithMocks()
+
+		m.admissionRepo.On("FindByID", uint(9)).Return(&models.Admission{
+			Model: gorm.Model{ID: 9}, PatientID: 7, Status: constants.AdmissionStatus.DISCHARGED,
+			AdmittedAt: admitted, DischargedAt: &discharged, DischargedBy: &dischargedBy, Reason: "Sepsis",
+		}, nil)
+		m.patientRepo.On("FindByID", uint(7)).Return(&models.Patient{Model: gorm.Model{ID: 7}}, nil)
+		m.staffRepo.On("FindByID", uint(3)).Return(&models.Staff{Model: gorm.Model{ID: 3}}, nil)
+		m.noteRepo.On("FindByAdmissionID", uint(9)).Return([]models.ClinicalNote{
+			{Model: gorm.Model{ID: 11}, PresentingComplaints: "Fever and confusion", TreatmentPlan: "IV antibiotics",
+				Diagnoses: []models.NoteDiagnosis{{DiagnosisCodeID: 1, DiagnosisCode: code(1, "A41.9")}}},
+			{Model: gorm.Model{ID: 12}, PresentingComplaints: "Improving", TreatmentPlan: "Switch to oral", Recommendation: "Review in clinic",
+				Diagnoses: []models.NoteDiagnosis{{DiagnosisCodeID: 1, DiagnosisCode: code(1, "A41.9")}, {DiagnosisCodeID: 2, DiagnosisCode: code(2, "N39.0")}}},
+		}, nil)
+		m.prescriptionRepo.On("FindByNoteID", mock.AnythingOfType("uint")).Return([]models.Prescription{}, nil)
+		m.appointmentRepo.On("FindAll", mock.Anything).Return([]models.Appointment{}, nil)
+
+		document, err := service.GetAdmissionSummary(9)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Discharge Summary", document.Title)
+		assert.Equal(t, "Fever and confusion", document.PresentingComplaints)
+		assert.Equal(t, "Switch to oral", document.TreatmentPlan)
+		assert.Equal(t, "Review in clinic", document.Recommendation)
+		assert.Len(t, document.Diagnoses, 2)
+		assert.Nil(t, document.FollowUp)
+	})
+
+	t.Run("NotDischarged", func(t *testing.T) {
+		service, m := newSummaryServiceWithMocks()
+
+		m.admissionRepo.On("FindByID", uint(9)).Return(&models.Admission{
+			Model: gorm.Model{ID: 9}, Status: constants.AdmissionStatus.ADMITTED,
+		}, nil)
+
+		_, err := service.GetAdmissionSummary(9)
+
+		assert.EqualError(t, err, "patient has not been discharged yet")
+	})
+}
+
+func TestDischargeSummaryTemplate(t *testing.T) {
+	t.Run("EscapesFreeText", func(t *testing.T) {
+		summaryTemplate, err := templates.LoadDischargeSummary("")
+		assert.NoError(t, err)
+
+		document := &SummaryDocument{
+			Title:                "Encounter Summary",
+			Patient:              &models.Patient{FirstName: "Ada", LastName: "Obi"},
+			Doctor:               &models.Staff{FirstName: "Kemi", LastName: "Bello"},
+			PresentingComplaints: "Cough\n# Fever\n  - chills",
+			TreatmentPlan:        "Amoxicillin\n---\n\\n",
+			Recommendation:       "Rest",
+			Medications:          []SummaryMedication{{Name: "Amoxicillin", Instructions: "With food\n## Not a section"}},
+		}
+
+		var markup bytes.Buffer
+		assert.NoError(t, summaryTemplate.Execute(&markup, document))
+
+		lines := strings.Split(markup.String(), "\n")
+		assert.Contains(t, lines, `\# Fever`)
+		assert.Contains(t, lines, `\- chills`)
+		assert.Contains(t, lines, `\---`)
+		assert.Contains(t, lines, `\\n`)
+		assert.Contains(t, lines, `\## Not a section`)
+		assert.Equal(t, 2, strings.Count(markup.String(), "\n---\n"))
+	})
+}
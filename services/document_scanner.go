@@ -0,0 +1,74 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// DocumentScanner inspects an upload before it is stored. Scan returns
+// ErrDocumentRejected when the content must not be kept.
+type DocumentScanner interface {
+	Scan(fileName string, content []byte) error
+}
+
+type noopDocumentScanner struct{}
+
+// NewNoopDocumentScanner accepts every upload. It is used when no scanner is
+// configured.
+func NewNoopDocumentScanner() DocumentScanner {
+	return noopDocumentScanner{}
+}
+
+func (noopDocumentScanner) Scan(fileName string, content []byte) error {
+	return nil
+}
+
+type commandDocumentScanner struct {
+	args    []string
+	timeout time.Duration
+}
+
+// NewCommandDocumentScanner pipes each upload to an external scanner such as
+// "clamdscan --no-summary -". Exit status 0 means clean and 1 means a threat
+// was found, following the ClamAV convention; anything else is a scan failure.
+func NewCommandDocumentScanner(command string) DocumentScanner {
+	return &commandDocumentScanner{args: strings.Fields(command), timeout: 2 * time.Minute}
+}
+
+// DocumentScannerFromEnv uses DOCUMENT_SCAN_COMMAND when it is set.
+func DocumentScannerFromEnv() DocumentScanner {
+	if command := os.Getenv("DOCUMENT_SCAN_COMMAND"); strings.TrimSpace(command) != "" {
+		return NewCommandDocumentScanner(command)
+	}
+	return NewNoopDocumentScanner()
+}
+
+func (cs *commandDocumentScanner) Scan(fileName string, content []byte) error {
+	cmd := exec.Command(cs.args[0], cs.args[1:]...)
+	cmd.Stdin = bytes.NewReader(content)
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("document scan failed: %w", err)
+	}
+	timer := time.AfterFunc(cs.timeout, func() { cmd.Process.Kill() })
+	err := cmd.Wait()
+	timer.Stop()
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &exitErr) && exitErr.ExitCode() == 1:
+		return fmt.Errorf("%w: %s", ErrDocumentRejected, strings.TrimSpace(output.String()))
+	default:
+		return fmt.Errorf("document scan failed: %v: %s", err, strings.TrimSpace(output.String()))
+	}
+}
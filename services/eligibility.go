@@ -0,0 +1,56 @@
+package services
+
+import (
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+)
+
+// EligibilityResult is a payer's answer on whether a coverage can be billed.
+type EligibilityResult struct {
+	CoverageID uint      `json:"coverageId"`
+	Status     string    `json:"status"`
+	Reason     string    `json:"reason,omitempty"`
+	Source     string    `json:"source"`
+	CheckedAt  time.Time `json:"checkedAt"`
+}
+
+// EligibilityChecker verifies a coverage with its payer. Implementations that
+// call a payer's API can replace the local checker without changing callers.
+type EligibilityChecker interface {
+	CheckEligibility(coverage models.PatientCoverage, at time.Time) (*EligibilityResult, error)
+}
+
+type localEligibilityChecker struct{}
+
+// NewLocalEligibilityChecker answers from the data held locally: the
+// coverage, plan and payer must be active and the date within validity.
+func NewLocalEligibilityChecker() EligibilityChecker {
+	return localEligibilityChecker{}
+}
+
+func (localEligibilityChecker) CheckEligibility(coverage models.PatientCoverage, at time.Time) (*EligibilityResult, error) {
+	result := &EligibilityResult{
+		CoverageID: coverage.ID,
+		Status:     constants.EligibilityStatus.ELIGIBLE,
+		Source:     "local",
+		CheckedAt:  at,
+	}
+
+	switch {
+	case !coverage.IsActive:
+		result.Reason = "coverage is inactive"
+	case coverage.Plan == nil || !coverage.Plan.IsActive:
+		result.Reason = "plan is inactive"
+	case coverage.Plan.Payer == nil || !coverage.Plan.Payer.IsActive:
+		result.Reason = "payer is inactive"
+	case !coverage.CoversDate(at):
+		result.Reason = "coverage is outside its validity period"
+	}
+
+	if result.Reason != "" {
+		result.Status = constants.EligibilityStatus.NOT_ELIGIBLE
+	}
+	return result, nil
+}
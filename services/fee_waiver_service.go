@@ -0,0 +1,291 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+)
+
+type FeeWaiverService interface {
+	RequestWaiver(input models.CreateFeeWaiverInput, staffID uint) (*models.FeeWaiver, error)
+	GetWaivers(query models.FeeWaiverQuery) ([]models.FeeWaiver, int64, error)
+	GetWaiverByID(id uint) (*models.FeeWaiver, error)
+	ApproveWaiver(id uint, staffID uint) (*models.FeeWaiver, error)
+	RejectWaiver(id uint, input models.RejectFeeWaiverInput, staffID uint) (*models.FeeWaiver, error)
+	CancelWaiver(id uint, staffID uint) (*models.FeeWaiver, error)
+}
+
+// WaiverThresholds decide when a waiver needs a second approver: when it is
+// over Amount, or over Percent of the invoice total before waivers.
+type WaiverThresholds struct {
+	Amount  float64
+	Percent float64
+}
+
+type feeWaiverService struct {
+	feeWaiverRepository repositories.FeeWaiverRepository
+	invoiceRepository   repositories.InvoiceRepository
+	paymentRepository   repositories.PaymentRepository
+	thresholds          WaiverThresholds
+}
+
+func NewFeeWaiverService(
+	feeWaiverRepository repositories.FeeWaiverRepository,
+	invoiceRepository repositories.InvoiceRepository,
+	paymentRepository repositories.PaymentRepository,
+	thresholds WaiverThresholds,
+) FeeWaiverService {
+	return &feeWaiverService{
+		feeWaiverRepository: feeWaiverRepository,
+		invoiceRepository:   invoiceRepository,
+		paymentRepository:   paymentRepository,
+		thresholds:          thresholds,
+	}
+}
+
+// WaiverThresholdsFromEnv reads the second approval thresholds, falling back
+// to the defaults when unset or invalid.
+func WaiverThresholdsFromEnv() WaiverThresholds {
+	thresholds := WaiverThresholds{
+		Amount:  constants.DefaultWaiverSecondApprovalAmount,
+		Percent: constants.DefaultWaiverSecondApprovalPercent,
+	}
+	if amount, err := strconv.ParseFloat(os.Getenv("FEE_WAIVER_SECOND_APPROVAL_AMOUNT"), 64); err == nil && amount >= 0 {
+		thresholds.Amount = amount
+	}
+	if percent, err := strconv.ParseFloat(os.Getenv("FEE_WAIVER_SECOND_APPROVAL_PERCENT"), 64); err == nil && percent >= 0 {
+		thresholds.Percent = percent
+	}
+	return thresholds
+}
+
+// RequestWaiver proposes taking an amount off what the patient still owes on
+// an issued invoice. An invoice has at most one pending waiver.
+func (fs *feeWaiverService) RequestWaiver(input models.CreateFeeWaiverInput, staffID uint) (*models.FeeWaiver, error) {
+	invoice, err := fs.invoiceRepository.FindByID(input.InvoiceID)
+	if err != nil {
+		return nil, errors.New("invoice not found")
+	}
+
+	amount := roundMoney(input.Amount)
+	if err := fs.checkWaivable(invoice, amount); err != nil {
+		return nil, err
+	}
+	if _, err := fs.feeWaiverRepository.FindPendingByInvoiceID(invoice.ID); err == nil {
+		return nil, errors.New("invoice already has a pending waiver")
+	}
+
+	waiver := &models.FeeWaiver{
+		InvoiceID:         invoice.ID,
+		PatientID:         invoice.PatientID,
+		Amount:            amount,
+		Reason:            strings.TrimSpace(input.Reason),
+		Status:            constants.FeeWaiverStatus.PENDING,
+		RequiredApprovals: fs.requiredApprovals(invoice, amount),
+		RequestedBy:       staffID,
+		RequestedAt:       time.Now(),
+	}
+
+	entry, err := waiverAuditEntry(waiver, constants.AuditActions.FEE_WAIVER_REQUEST, staffID, waiver.Reason, map[string]models.FieldChange{
+		"amount": {To: fmt.Sprintf("%.2f", waiver.Amount)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := fs.feeWaiverRepository.Create(waiver, entry); err != nil {
+		return nil, err
+	}
+
+	return waiver, nil
+}
+
+func (fs *feeWaiverService) GetWaivers(query models.FeeWaiverQuery) ([]models.FeeWaiver, int64, error) {
+	return fs.feeWaiverRepository.FindAll(query)
+}
+
+func (fs *feeWaiverService) GetWaiverByID(id uint) (*models.FeeWaiver, error) {
+	waiver, err := fs.feeWaiverRepository.FindByID(id)
+	if err != nil {
+		return nil, errors.New("fee waiver not found")
+	}
+	return waiver, nil
+}
+
+// ApproveWaiver records an approval. The requester cannot approve their own
+// waiver, and a second approval must come from someone else. The waiver is
+// taken off the invoice with its last required approval; an invoice left
+// with nothing to pay is marked paid.
+func (fs *feeWaiverService) ApproveWaiver(id uint, staffID uint) (*models.FeeWaiver, error) {
+	waiver, err := fs.pendingWaiver(id)
+	if err != nil {
+		return nil, err
+	}
+	if waiver.RequestedBy == staffID {
+		return nil, errors.New("you cannot approve a waiver you requested")
+	}
+	if waiver.FirstApprovedBy != nil && *waiver.FirstApprovedBy == staffID {
+		return nil, errors.New("the second approval must come from a different approver")
+	}
+
+	now := time.Now()
+	approvals := 1
+	if waiver.FirstApprovedBy == nil {
+		waiver.FirstApprovedBy = &staffID
+		waiver.FirstApprovedAt = &now
+	} else {
+		waiver.SecondApprovedBy = &staffID
+		waiver.SecondApprovedAt = &now
+		approvals = 2
+	}
+
+	status := constants.FeeWaiverStatus.PENDING
+	if approvals >= waiver.RequiredApprovals {
+		invoice, err := fs.invoiceRepository.FindByID(waiver.InvoiceID)
+		if err != nil {
+			return nil, errors.New("invoice not found")
+		}
+		if err := fs.checkWaivable(invoice, waiver.Amount); err != nil {
+			return nil, err
+		}
+		status = constants.FeeWaiverStatus.APPROVED
+	}
+	waiver.Status = status
+
+	entry, err := waiverAuditEntry(waiver, constants.AuditActions.FEE_WAIVER_APPROVE, staffID, "", map[string]models.FieldChange{
+		"approvals": {From: strconv.Itoa(approvals - 1), To: strconv.Itoa(approvals)},
+		"status":    {From: constants.FeeWaiverStatus.PENDING, To: waiver.Status},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := fs.feeWaiverRepository.RecordApproval(waiver, entry); err != nil {
+		return nil, err
+	}
+
+	return waiver, nil
+}
+
+func (fs *feeWaiverService) RejectWaiver(id uint, input models.RejectFeeWaiverInput, staffID uint) (*models.FeeWaiver, error) {
+	waiver, err := fs.pendingWaiver(id)
+	if err != nil {
+		return nil, err
+	}
+	if waiver.RequestedBy == staffID {
+		return nil, errors.New("you cannot decide a waiver you requested")
+	}
+
+	now := time.Now()
+	waiver.Status = constants.FeeWaiverStatus.REJECTED
+	waiver.RejectedBy = &staffID
+	waiver.RejectedAt = &now
+	waiver.RejectionReason = strings.TrimSpace(input.Reason)
+
+	entry, err := waiverAuditEntry(waiver, constants.AuditActions.FEE_WAIVER_REJECT, staffID, waiver.RejectionReason, map[string]models.FieldChange{
+		"status": {From: constants.FeeWaiverStatus.PENDING, To: waiver.Status},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := fs.feeWaiverRepository.UpdateStatus(waiver, entry); err != nil {
+		return nil, err
+	}
+
+	return waiver, nil
+}
+
+// CancelWaiver withdraws a pending waiver. Only the requester can cancel it.
+func (fs *feeWaiverService) CancelWaiver(id uint, staffID uint) (*models.FeeWaiver, error) {
+	waiver, err := fs.pendingWaiver(id)
+	if err != nil {
+		return nil, err
+	}
+	if waiver.RequestedBy != staffID {
+		return nil, errors.New("only the requester can cancel a waiver")
+	}
+
+	now := time.Now()
+	waiver.Status = constants.FeeWaiverStatus.CANCELLED
+	waiver.CancelledAt = &now
+
+	entry, err := waiverAuditEntry(waiver, constants.AuditActions.FEE_WAIVER_CANCEL, staffID, "", map[string]models.FieldChange{
+		"status": {From: constants.FeeWaiverStatus.PENDING, To: waiver.Status},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := fs.feeWaiverRepository.UpdateStatus(waiver, entry); err != nil {
+		return nil, err
+	}
+
+	return waiver, nil
+}
+
+func (fs *feeWaiverService) pendingWaiver(id uint) (*models.FeeWaiver, error) {
+	waiver, err := fs.feeWaiverRepository.FindByID(id)
+	if err != nil {
+		return nil, errors.New("fee waiver not found")
+	}
+	if waiver.Status != constants.FeeWaiverStatus.PENDING {
+		return nil, errors.New("only pending waivers can be changed")
+	}
+	return waiver, nil
+}
+
+func (fs *feeWaiverService) requiredApprovals(invoice *models.Invoice, amount float64) int {
+	gross := invoice.Total + invoice.WaiverTotal
+	if amount > fs.thresholds.Amount || (gross > 0 && amount*100/gross > fs.thresholds.Percent) {
+		return 2
+	}
+	return 1
+}
+
+// waiverAuditEntry builds the audit entry the repository writes with the
+// waiver change. A new waiver gets its ID when it is saved.
+func waiverAuditEntry(waiver *models.FeeWaiver, action string, staffID uint, reason string,
+	changes map[string]models.FieldChange) (*models.AuditLog, error) {
+	details, err := json.Marshal(changes)
+	if err != nil {
+		return nil, err
+	}
+	return &models.AuditLog{
+		Action:     action,
+		EntityType: constants.AuditEntities.FEE_WAIVER,
+		EntityID:   waiver.ID,
+		PatientID:  &waiver.PatientID,
+		StaffID:    staffID,
+		Reason:     reason,
+		Details:    string(details),
+	}, nil
+}
+
+// checkWaivable allows waivers on issued invoices, up to what the patient
+// still owes on them: their share, which already has approved waivers taken
+// off, less what they have paid. The insurer's balance cannot be waived.
+func (fs *feeWaiverService) checkWaivable(invoice *models.Invoice, amount float64) error {
+	statuses := constants.InvoiceStatus
+	if invoice.Status != statuses.ISSUED && invoice.Status != statuses.PARTIALLY_PAID {
+		return errors.New("waivers apply only to issued invoices with a balance")
+	}
+
+	patientPaid, err := fs.paymentRepository.SumPatientPaid(invoice.ID)
+	if err != nil {
+		return err
+	}
+	limit := roundMoney(invoice.Total - invoice.AmountPaid)
+	if owed := roundMoney(invoice.PatientAmount - patientPaid); owed < limit {
+		limit = owed
+	}
+	if amount > limit {
+		return fmt.Errorf("amount exceeds the waivable balance of %.2f", math.Max(limit, 0))
+	}
+	return nil
+}
@@ -0,0 +1,360 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func pendingWaiver(amount float64, requiredApprovals int) *models.FeeWaiver {
+	return &models.FeeWaiver{
+		Model:             gorm.Model{ID: 90},
+		InvoiceID:         30,
+		PatientID:         3,
+		Amount:            amount,
+		Status:            constants.FeeWaiverStatus.PENDING,
+		RequiredApprovals: requiredApprovals,
+		RequestedBy:       9,
+	}
+}
+
+func waivableInvoice() *models.Invoice {
+	invoice := issuedInvoice(20000, 2000)
+	invoice.PatientAmount = 20000
+	return invoice
+}
+
+func TestRequestWaiver(t *testing.T) {
+	t.Run("SingleApproval", func(t *testing.T) {
+		mockFeeWaiverRepo := new(mocks.FeeWaiverRepository)
+		mockInvoiceRepo := new(mocks.InvoiceRepository)
+		mockPaymentRepo := new(mocks.PaymentRepository)
+
+		service := NewFeeWaiverService(mockFeeWaiverRepo, mockInvoiceRepo, mockPaymentRepo,
+			WaiverThresholds{Amount: 10000, Percent: 50})
+
+		mockInvoiceRepo.On("FindByID", uint(30)).Return(waivableInvoice(), nil)
+		mockPaymentRepo.On("SumPatientPaid", uint(30)).Return(0.0, nil)
+		mockFeeWaiverRepo.On("FindPendingByInvoiceID", uint(30)).Return(&models.FeeWaiver{}, gorm.ErrRecordNotFound)
+		mockFeeWaiverRepo.On("Create", mock.AnythingOfType("*models.FeeWaiver"),
+			mock.MatchedBy(func(entry *models.AuditLog) bool {
+				return entry.Action == constants.AuditActions.FEE_WAIVER_REQUEST &&
+					entry.EntityType == constants.AuditEntities.FEE_WAIVER && entry.Reason == "Indigent patient"
+			})).Return(nil)
+
+		waiver, err := service.RequestWaiver(models.CreateFeeWaiverInput{
+			InvoiceID: 30, Amount: 5000, Reason: " Indigent patient ",
+		}, 9)
+
+		assert.NoError(t, err)
+		assert.Equal(t, constants.FeeWaiverStatus.PENDING, waiver.Status)
+		assert.Equal(t, 1, waiver.RequiredApprovals)
+		assert.Equal(t, uint(3), waiver.PatientID)
+		mockFeeWaiverRepo.AssertExpectations(t)
+	})
+
+	t.Run("OverAmountThreshold", func(t *testing.T) {
+		mockFeeWaiverRepo := new(mocks.FeeWaiverRepository)
+		mockInvoiceRepo := new(mocks.InvoiceRepository)
+		mockPaymentRepo := new(mocks.PaymentRepository)
+
+		service := NewFeeWaiverService(mockFeeWaiverRepo, mockInvoiceRepo, mockPaymentRepo,
+			WaiverThresholds{Amount: 10000, Percent: 50})
+
+		invoice := issuedInvoice(100000, 0)
+		invoice.PatientAmount = 100000
+		mockInvoiceRepo.On("FindByID", uint(30)).Return(invoice, nil)
+		mockPaymentRepo.On("SumPatientPaid", uint(30)).Return(0.0, nil)
+		mockFeeWaiverRepo.On("FindPendingByInvoiceID", uint(30)).Return(&models.FeeWaiver{}, gorm.ErrRecordNotFound)
+		mockFeeWaiverRepo.On("Create", mock.AnythingOfType("*models.FeeWaiver"), mock.AnythingOfType("*models.AuditLog")).
+			Return(nil)
+
+		waiver, err := service.RequestWaiver(models.CreateFeeWaiverInput{InvoiceID: 30, Amount: 10000.01, Reason: "Staff"}, 9)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, waiver.RequiredApprovals)
+	})
+
+	t.Run("OverPercentThreshold", func(t *testing.T) {
+		mockFeeWaiverRepo := new(mocks.FeeWaiverRepository)
+		mockInvoiceRepo := new(mocks.InvoiceRepository)
+		mockPaymentRepo := new(mocks.PaymentRepository)
+
+		service := NewFeeWaiverService(mockFeeWaiverRepo, mockInvoiceRepo, mockPaymentRepo,
+			WaiverThresholds{Amount: 10000, Percent: 50})
+
+		invoice := issuedInvoice(8000, 0)
+		invoice.PatientAmount = 8000
+		mockInvoiceRepo.On("FindByID", uint(30)).Return(invoice, nil)
+		mockPaymentRepo.On("SumPatientPaid", uint(30)).Return(0.0, nil)
+		mockFeeWaiverRepo.On("FindPendingByInvoiceID", uint(30)).Return(&models.FeeWaiver{}, gorm.ErrRecordNotFound)
+		mockFeeWaiverRepo.On("Create", mock.AnythingOfType("*models.FeeWaiver"), mock.AnythingOfType("*models.AuditLog")).
+			Return(nil)
+
+		waiver, err := service.RequestWaiver(models.CreateFeeWaiverInput{InvoiceID: 30, Amount: 4500, Reason: "Indigent"}, 9)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, waiver.RequiredApprovals)
+	})
+
+	t.Run("MoreThanPatientOwes", func(t *testing.T) {
+		mockInvoiceRepo := new(mocks.InvoiceRepository)
+		mockPaymentRepo := new(mocks.PaymentRepository)
+
+		service := NewFeeWaiverService(new(mocks.FeeWaiverRepository), mockInvoiceRepo, mockPaymentRepo,
+			WaiverThresholds{Amount: 10000, Percent: 50})
+
+		invoice := issuedInvoice(20000, 0)
+		invoice.PatientAmount = 2000
+		mockInvoiceRepo.On("FindByID", uint(30)).Return(invoice, nil)
+		mockPaymentRepo.On("SumPatientPaid", uint(30)).Return(0.0, nil)
+
+		_, err := service.RequestWaiver(models.CreateFeeWaiverInput{InvoiceID: 30, Amount: 2500, Reason: "Indigent"}, 9)
+
+		assert.EqualError(t, err, "amount exceeds the waivable balance of 2000.00")
+	})
+
+	t.Run("PatientPaidPartOfShare", func(t *testing.T) {
+		mockFeeWaiverRepo := new(mocks.FeeWaiverRepository)
+		mockInvoiceRepo := new(mocks.InvoiceRepository)
+		mockPaymentRepo := new(mocks.PaymentRepository)
+
+		service := NewFeeWaiverService(mockFeeWaiverRepo, mockInvoiceRepo, mockPaymentRepo,
+			WaiverThresholds{Amount: 10000, Percent: 50})
+
+		invoice := issuedInvoice(20000, 3000)
+		invoice.Status = constants.InvoiceStatus.PARTIALLY_PAID
+		invoice.PatientAmount = 4000
+		invoice.InsurerAmount = 16000
+		mockInvoiceRepo.On("FindByID", uint(30)).Return(invoice, nil)
+		mockPaymentRepo.On("SumPatientPaid", uint(30)).Return(3000.0, nil)
+
+		_, err := service.RequestWaiver(models.CreateFeeWaiverInput{InvoiceID: 30, Amount: 2000, Reason: "Indigent"}, 9)
+
+		assert.EqualError(t, err, "amount exceeds the waivable balance of 1000.00")
+		mockFeeWaiverRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("DraftInvoice", func(t *testing.T) {
+		mockInvoiceRepo := new(mocks.InvoiceRepository)
+
+		service := NewFeeWaiverService(new(mocks.FeeWaiverRepository), mockInvoiceRepo,
+			new(mocks.PaymentRepository), WaiverThresholds{Amount: 10000, Percent: 50})
+
+		mockInvoiceRepo.On("FindByID", uint(30)).Return(draftInvoice(), nil)
+
+		_, err := service.RequestWaiver(models.CreateFeeWaiverInput{InvoiceID: 30, Amount: 100, Reason: "Indigent"}, 9)
+
+		assert.EqualError(t, err, "waivers apply only to issued invoices with a balance")
+	})
+
+	t.Run("AlreadyPending", func(t *testing.T) {
+		mockFeeWaiverRepo := new(mocks.FeeWaiverRepository)
+		mockInvoiceRepo := new(mocks.InvoiceRepository)
+		mockPaymentRepo := new(mocks.PaymentRepository)
+
+		service := NewFeeWaiverService(mockFeeWaiverRepo, mockInvoiceRepo, mockPaymentRepo,
+			WaiverThresholds{Amount: 10000, Percent: 50})
+
+		mockInvoiceRepo.On("FindByID", uint(30)).Return(waivableInvoice(), nil)
+		mockPaymentRepo.On("SumPatientPaid", uint(30)).Return(0.0, nil)
+		mockFeeWaiverRepo.On("FindPendingByInvoiceID", uint(30)).Return(pendingWaiver(100, 1), nil)
+
+		_, err := service.RequestWaiver(models.CreateFeeWaiverInput{InvoiceID: 30, Amount: 100, Reason: "Indigent"}, 9)
+
+		assert.EqualError(t, err, "invoice already has a pending waiver")
+		mockFeeWaiverRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestApproveWaiver(t *testing.T) {
+	t.Run("SingleApprovalApplies", func(t *testing.T) {
+		mockFeeWaiverRepo := new(mocks.FeeWaiverRepository)
+		mockInvoiceRepo := new(mocks.InvoiceRepository)
+		mockPaymentRepo := new(mocks.PaymentRepository)
+
+		service := NewFeeWaiverService(mockFeeWaiverRepo, mockInvoiceRepo, mockPaymentRepo,
+			WaiverThresholds{Amount: 10000, Percent: 50})
+
+		mockFeeWaiverRepo.On("FindByID", uint(90)).Return(pendingWaiver(5000, 1), nil)
+		mockInvoiceRepo.On("FindByID", uint(30)).Return(waivableInvoice(), nil)
+		mockPaymentRepo.On("SumPatientPaid", uint(30)).Return(0.0, nil)
+		mockFeeWaiverRepo.On("RecordApproval", mock.MatchedBy(func(waiver *models.FeeWaiver) bool {
+			return waiver.Status == constants.FeeWaiverStatus.APPROVED && *waiver.FirstApprovedBy == 4
+		}), mock.MatchedBy(func(entry *models.AuditLog) bool {
+			return entry.Action == constants.AuditActions.FEE_WAIVER_APPROVE &&
+				entry.Details == `{"approvals":{"from":"0","to":"1"},"status":{"from":"pending","to":"approved"}}`
+		})).Return(nil)
+
+		waiver, err := service.ApproveWaiver(90, 4)
+
+		assert.NoError(t, err)
+		assert.Equal(t, constants.FeeWaiverStatus.APPROVED, waiver.Status)
+		mockFeeWaiverRepo.AssertExpectations(t)
+	})
+
+	t.Run("FirstOfTwoStaysPending", func(t *testing.T) {
+		mockFeeWaiverRepo := new(mocks.FeeWaiverRepository)
+		mockInvoiceRepo := new(mocks.InvoiceRepository)
+
+		service := NewFeeWaiverService(mockFeeWaiverRepo, mockInvoiceRepo, new(mocks.PaymentRepository),
+			WaiverThresholds{Amount: 10000, Percent: 50})
+
+		mockFeeWaiverRepo.On("FindByID", uint(90)).Return(pendingWaiver(15000, 2), nil)
+		mockFeeWaiverRepo.On("RecordApproval", mock.AnythingOfType("*models.FeeWaiver"), mock.AnythingOfType("*models.AuditLog")).
+			Return(nil)
+
+		waiver, err := service.ApproveWaiver(90, 4)
+
+		assert.NoError(t, err)
+		assert.Equal(t, constants.FeeWaiverStatus.PENDING, waiver.Status)
+		assert.Nil(t, waiver.SecondApprovedBy)
+		mockInvoiceRepo.AssertNotCalled(t, "FindByID", mock.Anything)
+	})
+
+	t.Run("SecondApprovalApplies", func(t *testing.T) {
+		mockFeeWaiverRepo := new(mocks.FeeWaiverRepository)
+		mockInvoiceRepo := new(mocks.InvoiceRepository)
+		mockPaymentRepo := new(mocks.PaymentRepository)
+
+		service := NewFeeWaiverService(mockFeeWaiverRepo, mockInvoiceRepo, mockPaymentRepo,
+			WaiverThresholds{Amount: 10000, Percent: 50})
+
+		waiver := pendingWaiver(15000, 2)
+		waiver.FirstApprovedBy = uintPtr(4)
+		mockFeeWaiverRepo.On("FindByID", uint(90)).Return(waiver, nil)
+		mockInvoiceRepo.On("FindByID", uint(30)).Return(waivableInvoice(), nil)
+		mockPaymentRepo.On("SumPatientPaid", uint(30)).Return(0.0, nil)
+		mockFeeWaiverRepo.On("RecordApproval", mock.AnythingOfType("*models.FeeWaiver"), mock.AnythingOfType("*models.AuditLog")).
+			Return(nil)
+
+		approved, err := service.ApproveWaiver(90, 5)
+
+		assert.NoError(t, err)
+		assert.Equal(t, constants.FeeWaiverStatus.APPROVED, approved.Status)
+		assert.Equal(t, uint(5), *approved.SecondApprovedBy)
+	})
+
+	t.Run("SameApproverTwice", func(t *testing.T) {
+		mockFeeWaiverRepo := new(mocks.FeeWaiverRepository)
+
+		service := NewFeeWaiverService(mockFeeWaiverRepo, new(mocks.InvoiceRepository),
+			new(mocks.PaymentRepository), WaiverThresholds{Amount: 10000, Percent: 50})
+
+		waiver := pendingWaiver(15000, 2)
+		waiver.FirstApprovedBy = uintPtr(4)
+		mockFeeWaiverRepo.On("FindByID", uint(90)).Return(waiver, nil)
+
+		_, err := service.ApproveWaiver(90, 4)
+
+		assert.EqualError(t, err, "the second approval must come from a different approver")
+	})
+
+	t.Run("OwnRequest", func(t *testing.T) {
+		mockFeeWaiverRepo := new(mocks.FeeWaiverRepository)
+
+		service := NewFeeWaiverService(mockFeeWaiverRepo, new(mocks.InvoiceRepository),
+			new(mocks.PaymentRepository), WaiverThresholds{Amount: 10000, Percent: 50})
+
+		mockFeeWaiverRepo.On("FindByID", uint(90)).Return(pendingWaiver(5000, 1), nil)
+
+		_, err := service.ApproveWaiver(90, 9)
+
+		assert.EqualError(t, err, "you cannot approve a waiver you requested")
+		mockFeeWaiverRepo.AssertNotCalled(t, "RecordApproval", mock.Anything, mock.Anything)
+	})
+
+	t.Run("InvoicePaidMeanwhile", func(t *testing.T) {
+		mockFeeWaiverRepo := new(mocks.FeeWaiverRepository)
+		mockInvoiceRepo := new(mocks.InvoiceRepository)
+		mockPaymentRepo := new(mocks.PaymentRepository)
+
+		service := NewFeeWaiverService(mockFeeWaiverRepo, mockInvoiceRepo, mockPaymentRepo,
+			WaiverThresholds{Amount: 10000, Percent: 50})
+
+		invoice := waivableInvoice()
+		invoice.AmountPaid = 18000
+		mockFeeWaiverRepo.On("FindByID", uint(90)).Return(pendingWaiver(5000, 1), nil)
+		mockInvoiceRepo.On("FindByID", uint(30)).Return(invoice, nil)
+		mockPaymentRepo.On("SumPatientPaid", uint(30)).Return(0.0, nil)
+
+		_, err := service.ApproveWaiver(90, 4)
+
+		assert.EqualError(t, err, "amount exceeds the waivable balance of 2000.00")
+	})
+}
+
+func TestRejectAndCancelWaiver(t *testing.T) {
+	t.Run("Reject", func(t *testing.T) {
+		mockFeeWaiverRepo := new(mocks.FeeWaiverRepository)
+
+		service := NewFeeWaiverService(mockFeeWaiverRepo, new(mocks.InvoiceRepository),
+			new(mocks.PaymentRepository), WaiverThresholds{Amount: 10000, Percent: 50})
+
+		mockFeeWaiverRepo.On("FindByID", uint(90)).Return(pendingWaiver(5000, 1), nil)
+		mockFeeWaiverRepo.On("UpdateStatus", mock.AnythingOfType("*models.FeeWaiver"),
+			mock.MatchedBy(func(entry *models.AuditLog) bool {
+				return entry.Action == constants.AuditActions.FEE_WAIVER_REJECT && entry.Reason == "Not eligible"
+			})).Return(nil)
+
+		waiver, err := service.RejectWaiver(90, models.RejectFeeWaiverInput{Reason: "Not eligible"}, 4)
+
+		assert.NoError(t, err)
+		assert.Equal(t, constants.FeeWaiverStatus.REJECTED, waiver.Status)
+		assert.Equal(t, uint(4), *waiver.RejectedBy)
+		mockFeeWaiverRepo.AssertExpectations(t)
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		mockFeeWaiverRepo := new(mocks.FeeWaiverRepository)
+
+		service := NewFeeWaiverService(mockFeeWaiverRepo, new(mocks.InvoiceRepository),
+			new(mocks.PaymentRepository), WaiverThresholds{Amount: 10000, Percent: 50})
+
+		mockFeeWaiverRepo.On("FindByID", uint(90)).Return(pendingWaiver(5000, 1), nil)
+		mockFeeWaiverRepo.On("UpdateStatus", mock.AnythingOfType("*models.FeeWaiver"),
+			mock.MatchedBy(func(entry *models.AuditLog) bool {
+				return entry.Action == constants.AuditActions.FEE_WAIVER_CANCEL && entry.EntityID == 90 &&
+					entry.Details == `{"status":{"from":"pending","to":"cancelled"}}`
+			})).Return(nil)
+
+		waiver, err := service.CancelWaiver(90, 9)
+
+		assert.NoError(t, err)
+		assert.Equal(t, constants.FeeWaiverStatus.CANCELLED, waiver.Status)
+		mockFeeWaiverRepo.AssertExpectations(t)
+	})
+
+	t.Run("CancelByOthers", func(t *testing.T) {
+		mockFeeWaiverRepo := new(mocks.FeeWaiverRepository)
+
+		service := NewFeeWaiverService(mockFeeWaiverRepo, new(mocks.InvoiceRepository),
+			new(mocks.PaymentRepository), WaiverThresholds{Amount: 10000, Percent: 50})
+
+		mockFeeWaiverRepo.On("FindByID", uint(90)).Return(pendingWaiver(5000, 1), nil)
+
+		_, err := service.CancelWaiver(90, 4)
+
+		assert.EqualError(t, err, "only the requester can cancel a waiver")
+	})
+
+	t.Run("AlreadyDecided", func(t *testing.T) {
+		mockFeeWaiverRepo := new(mocks.FeeWaiverRepository)
+
+		service := NewFeeWaiverService(mockFeeWaiverRepo, new(mocks.InvoiceRepository),
+			new(mocks.PaymentRepository), WaiverThresholds{Amount: 10000, Percent: 50})
+
+		waiver := pendingWaiver(5000, 1)
+		waiver.Status = constants.FeeWaiverStatus.APPROVED
+		mockFeeWaiverRepo.On("FindByID", uint(90)).Return(waiver, nil)
+
+		_, err := service.CancelWaiver(90, 9)
+
+		assert.EqualError(t, err, "only pending waivers can be changed")
+	})
+}
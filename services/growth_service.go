@@ -0,0 +1,154 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/growth"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+	"github.com/ofojichigozie/hms-go-backend/utils"
+)
+
+type GrowthService interface {
+	RecordMeasurement(patientID uint, input models.RecordGrowthInput, recordedBy uint) (*models.GrowthMeasurement, error)
+	GetGrowthChart(patientID uint) (*models.GrowthChart, error)
+}
+
+type growthService struct {
+	growthMeasurementRepository repositories.GrowthMeasurementRepository
+	patientRepository           repositories.PatientRepository
+}
+
+func NewGrowthService(
+	growthMeasurementRepository repositories.GrowthMeasurementRepository,
+	patientRepository repositories.PatientRepository,
+) GrowthService {
+	return &growthService{
+		growthMeasurementRepository: growthMeasurementRepository,
+		patientRepository:           patientRepository,
+	}
+}
+
+func (gs *growthService) RecordMeasurement(patientID uint, input models.RecordGrowthInput, recordedBy uint) (*models.GrowthMeasurement, error) {
+	patient, err := gs.patientRepository.FindByID(patientID)
+	if err != nil || patient == nil {
+		return nil, errors.New("patient record not found")
+	}
+
+	if input.WeightKg == nil && input.HeightCm == nil {
+		return nil, errors.New("a weight or height is required")
+	}
+
+	measuredAt, err := time.Parse("2006-01-02", input.MeasuredAt)
+	if err != nil {
+		return nil, errors.New("invalid date format, use YYYY-MM-DD")
+	}
+	if measuredAt.After(time.Now()) {
+		return nil, errors.New("measurement date cannot be in the future")
+	}
+	if measuredAt.Before(utils.CalendarDay(patient.DateOfBirth)) {
+		return nil, errors.New("measurement date cannot be before the patient's date of birth")
+	}
+
+	measurement := &models.GrowthMeasurement{
+		PatientID:  patientID,
+		MeasuredAt: measuredAt,
+		WeightKg:   input.WeightKg,
+		HeightCm:   input.HeightCm,
+		Notes:      input.Notes,
+		RecordedBy: recordedBy,
+	}
+
+	if err := gs.growthMeasurementRepository.Create(measurement); err != nil {
+		return nil, err
+	}
+
+	return measurement, nil
+}
+
+func (gs *growthService) GetGrowthChart(patientID uint) (*models.GrowthChart, error) {
+	patient, err := gs.patientRepository.FindByID(patientID)
+	if err != nil || patient == nil {
+		return nil, errors.New("patient record not found")
+	}
+
+	measurements, err := gs.growthMeasurementRepository.FindByPatientID(patientID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.GrowthChart{
+		PatientID: patient.ID,
+		Gender:    patient.Gender,
+		Points:    buildGrowthSeries(patient.DateOfBirth, patient.Gender, measurements),
+	}, nil
+}
+
+// buildGrowthSeries scores each measurement and flags faltering growth
+// against the previous measurement that has the same indicator.
+func buildGrowthSeries(dateOfBirth time.Time, gender string, measurements []models.GrowthMeasurement) []models.GrowthPoint {
+	points := make([]models.GrowthPoint, 0, len(measurements))
+	lastWeight, lastHeight := -1, -1
+
+	for _, measurement := range measurements {
+		point := models.GrowthPoint{
+			MeasurementID: measurement.ID,
+			MeasuredAt:    measurement.MeasuredAt,
+			AgeDays:       utils.AgeInDays(dateOfBirth, measurement.MeasuredAt),
+			WeightKg:      measurement.WeightKg,
+			HeightCm:      measurement.HeightCm,
+			Flags:         []string{},
+		}
+
+		if point.WeightKg != nil {
+			point.WeightForAge = growthScore(growth.WeightForAge, gender, point.AgeDays, *point.WeightKg)
+		}
+		if point.HeightCm != nil {
+			point.HeightForAge = growthScore(growth.HeightForAge, gender, point.AgeDays, *point.HeightCm)
+		}
+		if point.WeightKg != nil && point.HeightCm != nil {
+			bmi := math.Round(growth.BMI(*point.WeightKg, *point.HeightCm)*10) / 10
+			point.BMI = &bmi
+			point.BMIForAge = growthScore(growth.BMIForAge, gender, point.AgeDays, growth.BMI(*point.WeightKg, *point.HeightCm))
+		}
+
+		if point.WeightKg != nil && lastWeight >= 0 {
+			if *point.WeightKg < *points[lastWeight].WeightKg {
+				point.Flags = append(point.Flags, constants.GrowthFlags.WEIGHT_LOSS)
+			}
+			if falteringBetween(points[lastWeight].WeightForAge, point.WeightForAge) {
+				point.Flags = append(point.Flags, constants.GrowthFlags.WEIGHT_FALTERING)
+			}
+		}
+		if point.HeightCm != nil && lastHeight >= 0 && falteringBetween(points[lastHeight].HeightForAge, point.HeightForAge) {
+			point.Flags = append(point.Flags, constants.GrowthFlags.HEIGHT_FALTERING)
+		}
+
+		points = append(points, point)
+		if point.WeightKg != nil {
+			lastWeight = len(points) - 1
+		}
+		if point.HeightCm != nil {
+			lastHeight = len(points) - 1
+		}
+	}
+	return points
+}
+
+func growthScore(indicator string, gender string, ageDays int, value float64) *growth.Score {
+	score, ok := growth.Compute(indicator, gender, ageDays, value)
+	if !ok {
+		return nil
+	}
+	return &score
+}
+
+func falteringBetween(previous, current *growth.Score) bool {
+	if previous == nil || current == nil {
+		return false
+	}
+	return previous.ZScore-current.ZScore >= constants.FalteringZScoreDrop
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestRecordGrowthMeasurement(t *testing.T) {
+	child := &models.Patient{Model: gorm.Model{ID: 1}, Gender: "female", DateOfBirth: time.Now().AddDate(-1, 0, 0)}
+	yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+
+	t.Run("Success", func(t *testing.T) {
+		growthRepo := new(mocks.GrowthMeasurementRepository)
+		patientRepo := new(mocks.PatientRepository)
+		service := NewGrowthService(growthRepo, patientRepo)
+
+		patientRepo.On("FindByID", uint(1)).Return(child, nil)
+		growthRepo.On("Create", mock.AnythingOfType("*models.GrowthMeasurement")).Return(nil)
+
+		measurement, err := service.RecordMeasurement(1, models.RecordGrowthInput{
+			MeasuredAt: yesterday,
+			WeightKg:   floatPtr(8.9),
+			HeightCm:   floatPtr(73.5),
+		}, 4)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 8.9, *measurement.WeightKg)
+		assert.Equal(t, uint(4), measurement.RecordedBy)
+		growthRepo.AssertExpectations(t)
+	})
+
+	t.Run("NoMeasurements", func(t *testing.T) {
+		growthRepo := new(mocks.GrowthMeasurementRepository)
+		patientRepo := new(mocks.PatientRepository)
+		service := NewGrowthService(growthRepo, patientRepo)
+
+		patientRepo.On("FindByID", uint(1)).Return(child, nil)
+
+		_, err := service.RecordMeasurement(1, models.RecordGrowthInput{MeasuredAt: yesterday}, 4)
+
+		assert.EqualError(t, err, "a weight or height is required")
+		growthRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("FutureDate", func(t *testing.T) {
+		growthRepo := new(mocks.GrowthMeasurementRepository)
+		patientRepo := new(mocks.PatientRepository)
+		service := NewGrowthService(growthRepo, patientRepo)
+
+		patientRepo.On("FindByID", uint(1)).Return(child, nil)
+
+		_, err := service.RecordMeasurement(1, models.RecordGrowthInput{
+			MeasuredAt: time.Now().AddDate(0, 0, 3).Format("2006-01-02"),
+			WeightKg:   floatPtr(8.9),
+		}, 4)
+
+		assert.EqualError(t, err, "measurement date cannot be in the future")
+	})
+}
+
+func TestBuildGrowthSeries(t *testing.T) {
+	dob := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("MedianScoresNearZero", func(t *testing.T) {
+		points := buildGrowthSeries(dob, "male", []models.GrowthMeasurement{
+			{MeasuredAt: dob, WeightKg: floatPtr(3.3464), HeightCm: floatPtr(49.8842)},
+		})
+
+		assert.Len(t, points, 1)
+		assert.Equal(t, 0.0, points[0].WeightForAge.ZScore)
+		assert.Equal(t, 50.0, points[0].WeightForAge.Percentile)
+		assert.Equal(t, 0.0, points[0].HeightForAge.ZScore)
+		assert.NotNil(t, points[0].BMIForAge)
+		assert.Empty(t, points[0].Flags)
+	})
+
+	t.Run("LowBirthWeight", func(t *testing.T) {
+		points := buildGrowthSeries(dob, "male", []models.GrowthMeasurement{
+			{MeasuredAt: dob, WeightKg: floatPtr(2.459)},
+		})
+
+		assert.InDelta(t, -2.0, points[0].WeightForAge.ZScore, 0.01)
+		assert.InDelta(t, 2.3, points[0].WeightForAge.Percentile, 0.1)
+		assert.Nil(t, points[0].HeightForAge)
+		assert.Nil(t, points[0].BMI)
+	})
+
+	t.Run("FalteringBetweenVisits", func(t *testing.T) {
+		points := buildGrowthSeries(dob, "male", []models.GrowthMeasurement{
+			{Model: gorm.Model{ID: 1}, MeasuredAt: dob, WeightKg: floatPtr(3.4)},
+			{Model: gorm.Model{ID: 2}, MeasuredAt: dob.AddDate(0, 0, 122), WeightKg: floatPtr(7.0)},
+			{Model: gorm.Model{ID: 3}, MeasuredAt: dob.AddDate(0, 0, 150), HeightCm: floatPtr(64.5)},
+			{Model: gorm.Model{ID: 4}, MeasuredAt: dob.AddDate(0, 0, 183), WeightKg: floatPtr(6.6)},
+		})
+
+		assert.Empty(t, points[1].Flags)
+		assert.Empty(t, points[2].Flags)
+		assert.Equal(t, []string{constants.GrowthFlags.WEIGHT_LOSS, constants.GrowthFlags.WEIGHT_FALTERING}, points[3].Flags)
+		assert.Equal(t, 183, points[3].AgeDays)
+	})
+
+	t.Run("OutsideReferenceRange", func(t *testing.T) {
+		points := buildGrowthSeries(dob, "other", []models.GrowthMeasurement{
+			{MeasuredAt: dob.AddDate(0, 6, 0), WeightKg: floatPtr(7.5)},
+		})
+		older := buildGrowthSeries(dob.AddDate(-6, 0, 0), "female", []models.GrowthMeasurement{
+			{MeasuredAt: dob, WeightKg: floatPtr(20)},
+		})
+
+		assert.Nil(t, points[0].WeightForAge)
+		assert.Nil(t, older[0].WeightForAge)
+	})
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+	"github.com/ofojichigozie/hms-go-backend/utils"
+)
+
+type ImmunizationService interface {
+	RecordImmunization(patientID uint, input models.RecordImmunizationInput, recordedBy uint) (*models.Immunization, error)
+	GetImmunizationsByPatientID(patientID uint) ([]models.Immunization, error)
+	GetSchedule(patientID uint) ([]models.ImmunizationScheduleItem, error)
+	GetOverdue(query models.OverdueImmunizationQuery) ([]models.OverdueImmunization, int64, error)
+}
+
+type immunizationService struct {
+	immunizationRepository repositories.ImmunizationRepository
+	vaccineRepository      repositories.VaccineRepository
+	patientRepository      repositories.PatientRepository
+}
+
+func NewImmunizationService(
+	immunizationRepository repositories.ImmunizationRepository,
+	vaccineRepository repositories.VaccineRepository,
+	patientRepository repositories.PatientRepository,
+) ImmunizationService {
+	return &immunizationService{
+		immunizationRepository: immunizationRepository,
+		vaccineRepository:      vaccineRepository,
+		patientRepository:      patientRepository,
+	}
+}
+
+func (is *immunizationService) RecordImmunization(patientID uint, input models.RecordImmunizationInput, recordedBy uint) (*models.Immunization, error) {
+	patient, err := is.patientRepository.FindByID(patientID)
+	if err != nil || patient == nil {
+		return nil, errors.New("patient record not found")
+	}
+
+	vaccine, err := is.vaccineRepository.FindByID(input.VaccineID)
+	if err != nil || !vaccine.IsActive {
+		return nil, errors.New("vaccine not found or inactive")
+	}
+
+	if len(vaccine.Doses) == 0 {
+		return nil, fmt.Errorf("%s has no doses on the schedule", vaccine.Name)
+	}
+	if input.DoseNumber < 1 || input.DoseNumber > len(vaccine.Doses) {
+		return nil, fmt.Errorf("dose number must be between 1 and %d for %s", len(vaccine.Doses), vaccine.Name)
+	}
+
+	administeredAt, err := time.Parse("2006-01-02", input.AdministeredAt)
+	if err != nil {
+		return nil, errors.New("invalid date format, use YYYY-MM-DD")
+	}
+	if administeredAt.After(time.Now()) {
+		return nil, errors.New("administration date cannot be in the future")
+	}
+	if administeredAt.Before(utils.CalendarDay(patient.DateOfBirth)) {
+		return nil, errors.New("administration date cannot be before the patient's date of birth")
+	}
+
+	existing, err := is.immunizationRepository.FindByPatientID(patientID)
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range existing {
+		if record.VaccineID == vaccine.ID && record.DoseNumber == input.DoseNumber {
+			return nil, errors.New("this dose is already recorded for the patient")
+		}
+	}
+
+	immunization := &models.Immunization{
+		PatientID:      patientID,
+		VaccineID:      vaccine.ID,
+		DoseNumber:     input.DoseNumber,
+		LotNumber:      strings.TrimSpace(input.LotNumber),
+		Site:           input.Site,
+		AdministeredAt: administeredAt,
+		Facility:       strings.TrimSpace(input.Facility),
+		Notes:          input.Notes,
+		RecordedBy:     recordedBy,
+	}
+	if immunization.Facility == "" {
+		immunization.AdministeredBy = &recordedBy
+	}
+
+	if err := is.immunizationRepository.Create(immunization); err != nil {
+		return nil, err
+	}
+
+	immunization.Vaccine = vaccine
+	return immunization, nil
+}
+
+func (is *immunizationService) GetImmunizationsByPatientID(patientID uint) ([]models.Immunization, error) {
+	patient, err := is.patientRepository.FindByID(patientID)
+	if err != nil || patient == nil {
+		return nil, errors.New("patient record not found")
+	}
+
+	return is.immunizationRepository.FindByPatientID(patientID)
+}
+
+// GetSchedule lays the national schedule over the patient's date of birth and
+// marks each dose as given, upcoming, due or overdue.
+func (is *immunizationService) GetSchedule(patientID uint) ([]models.ImmunizationScheduleItem, error) {
+	patient, err := is.patientRepository.FindByID(patientID)
+	if err != nil || patient == nil {
+		return nil, errors.New("patient record not found")
+	}
+
+	vaccines, err := is.vaccineRepository.FindAll(true)
+	if err != nil {
+		return nil, err
+	}
+	records, err := is.immunizationRepository.FindByPatientID(patientID)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildImmunizationSchedule(patient.DateOfBirth, vaccines, records, time.Now()), nil
+}
+
+func (is *immunizationService) GetOverdue(query models.OverdueImmunizationQuery) ([]models.OverdueImmunization, int64, error) {
+	query.Normalize()
+	return is.immunizationRepository.FindOverdue(strings.ToUpper(strings.TrimSpace(query.Vaccine)), time.Now(), query.PageQuery)
+}
+
+func buildImmunizationSchedule(dateOfBirth time.Time, vaccines []models.Vaccine, records []models.Immunization, at time.Time) []models.ImmunizationScheduleItem {
+	type doseKey struct {
+		vaccineID uint
+		dose      int
+	}
+	given := make(map[doseKey]time.Time, len(records))
+	for _, record := range records {
+		given[doseKey{record.VaccineID, record.DoseNumber}] = record.AdministeredAt
+	}
+
+	birth := utils.CalendarDay(dateOfBirth)
+	today := utils.CalendarDay(at)
+
+	var items []models.ImmunizationScheduleItem
+	for _, vaccine := range vaccines {
+		for _, dose := range vaccine.Doses {
+			item := models.ImmunizationScheduleItem{
+				VaccineID:   vaccine.ID,
+				VaccineCode: vaccine.Code,
+				VaccineName: vaccine.Name,
+				DoseNumber:  dose.DoseNumber,
+				DueDate:     birth.AddDate(0, 0, dose.DueAgeDays),
+				OverdueDate: birth.AddDate(0, 0, dose.DueAgeDays+dose.OverdueAfterDays),
+			}
+
+			administeredAt, ok := given[doseKey{vaccine.ID, dose.DoseNumber}]
+			switch {
+			case ok:
+				item.Status = constants.ImmunizationStatus.GIVEN
+				item.AdministeredAt = &administeredAt
+			case dose.MaxAgeDays > 0 && today.After(birth.AddDate(0, 0, dose.MaxAgeDays)):
+				// Too old for this dose; it is no longer offered.
+				continue
+			case today.Before(item.DueDate):
+				item.Status = constants.ImmunizationStatus.UPCOMING
+			case today.After(item.OverdueDate):
+				item.Status = constants.ImmunizationStatus.OVERDUE
+			default:
+				item.Status = constants.ImmunizationStatus.DUE
+			}
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,228 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestRecordImmunization(t *testing.T) {
+	infant := &models.Patient{Model: gorm.Model{ID: 1}, DateOfBirth: time.Now().AddDate(0, -3, 0)}
+	penta := &models.Vaccine{Model: gorm.Model{ID: 4}, Code: "PENTA", Name: "Pentavalent", IsActive: true,
+		Doses: []models.VaccineScheduleDose{{DoseNumber: 1}, {DoseNumber: 2}, {DoseNumber: 3}}}
+	yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+
+	t.Run("Success", func(t *testing.T) {
+		mockImmunizationRepo := new(mocks.ImmunizationRepository)
+		mockVaccineRepo := new(mocks.VaccineRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+
+		service := NewImmunizationService(mockImmunizationRepo, mockVaccineRepo, mockPatientRepo)
+
+		mockPatientRepo.On("FindByID", uint(1)).Return(infant, nil)
+		mockVaccineRepo.On("FindByID", uint(4)).Return(penta, nil)
+		mockImmunizationRepo.On("FindByPatientID", uint(1)).Return([]models.Immunization{}, nil)
+		mockImmunizationRepo.On("Create", mock.AnythingOfType("*models.Immunization")).Return(nil)
+
+		immunization, err := service.RecordImmunization(1, models.RecordImmunizationInput{
+			VaccineID:      4,
+			DoseNumber:     1,
+			LotNumber:      " LOT-22 ",
+			Site:           "left_thigh",
+			AdministeredAt: yesterday,
+		}, 7)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "LOT-22", immunization.LotNumber)
+		assert.Equal(t, uint(7), *immunization.AdministeredBy)
+		assert.Equal(t, penta, immunization.Vaccine)
+		mockImmunizationRepo.AssertExpectations(t)
+	})
+
+	t.Run("GivenElsewhere", func(t *testing.T) {
+		mockImmunizationRepo := new(mocks.ImmunizationRepository)
+		mockVaccineRepo := new(mocks.VaccineRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+
+		service := NewImmunizationService(mockImmunizationRepo, mockVaccineRepo, mockPatientRepo)
+
+		mockPatientRepo.On("FindByID", uint(1)).Return(infant, nil)
+		mockVaccineRepo.On("FindByID", uint(4)).Return(penta, nil)
+		mockImmunizationRepo.On("FindByPatientID", uint(1)).Return([]models.Immunization{}, nil)
+		mockImmunizationRepo.On("Create", mock.AnythingOfType("*models.Immunization")).Return(nil)
+
+		immunization, err := service.RecordImmunization(1, models.RecordImmunizationInput{
+			VaccineID:      4,
+			DoseNumber:     1,
+			AdministeredAt: yesterday,
+			Facility:       "Ward 3 Health Post",
+		}, 7)
+
+		assert.NoError(t, err)
+		assert.Nil(t, immunization.AdministeredBy)
+		assert.Equal(t, uint(7), immunization.RecordedBy)
+	})
+
+	t.Run("DuplicateDose", func(t *testing.T) {
+		mockImmunizationRepo := new(mocks.ImmunizationRepository)
+		mockVaccineRepo := new(mocks.VaccineRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+
+		service := NewImmunizationService(mockImmunizationRepo, mockVaccineRepo, mockPatientRepo)
+
+		mockPatientRepo.On("FindByID", uint(1)).Return(infant, nil)
+		mockVaccineRepo.On("FindByID", uint(4)).Return(penta, nil)
+		mockImmunizationRepo.On("FindByPatientID", uint(1)).Return([]models.Immunization{
+			{VaccineID: 4, DoseNumber: 1},
+		}, nil)
+
+		_, err := service.RecordImmunization(1, models.RecordImmunizationInput{
+			VaccineID: 4, DoseNumber: 1, AdministeredAt: yesterday,
+		}, 7)
+
+		assert.EqualError(t, err, "this dose is already recorded for the patient")
+		mockImmunizationRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("DoseNotOnSchedule", func(t *testing.T) {
+		mockImmunizationRepo := new(mocks.ImmunizationRepository)
+		mockVaccineRepo := new(mocks.VaccineRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+
+		service := NewImmunizationService(mockImmunizationRepo, mockVaccineRepo, mockPatientRepo)
+
+		mockPatientRepo.On("FindByID", uint(1)).Return(infant, nil)
+		mockVaccineRepo.On("FindByID", uint(4)).Return(penta, nil)
+
+		_, err := service.RecordImmunization(1, models.RecordImmunizationInput{
+			VaccineID: 4, DoseNumber: 4, AdministeredAt: yesterday,
+		}, 7)
+
+		assert.EqualError(t, err, "dose number must be between 1 and 3 for Pentavalent")
+		mockImmunizationRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("VaccineNotOnSchedule", func(t *testing.T) {
+		mockImmunizationRepo := new(mocks.ImmunizationRepository)
+		mockVaccineRepo := new(mocks.VaccineRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+
+		service := NewImmunizationService(mockImmunizationRepo, mockVaccineRepo, mockPatientRepo)
+
+		mockPatientRepo.On("FindByID", uint(1)).Return(infant, nil)
+		mockVaccineRepo.On("FindByID", uint(5)).Return(&models.Vaccine{Model: gorm.Model{ID: 5}, Name: "Typhoid", IsActive: true}, nil)
+
+		_, err := service.RecordImmunization(1, models.RecordImmunizationInput{
+			VaccineID: 5, DoseNumber: 1, AdministeredAt: yesterday,
+		}, 7)
+
+		assert.EqualError(t, err, "Typhoid has no doses on the schedule")
+		mockImmunizationRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("FutureDate", func(t *testing.T) {
+		mockVaccineRepo := new(mocks.VaccineRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+
+		service := NewImmunizationService(new(mocks.ImmunizationRepository), mockVaccineRepo, mockPatientRepo)
+
+		mockPatientRepo.On("FindByID", uint(1)).Return(infant, nil)
+		mockVaccineRepo.On("FindByID", uint(4)).Return(penta, nil)
+
+		_, err := service.RecordImmunization(1, models.RecordImmunizationInput{
+			VaccineID: 4, DoseNumber: 1, AdministeredAt: time.Now().AddDate(0, 0, 2).Format("2006-01-02"),
+		}, 7)
+
+		assert.EqualError(t, err, "administration date cannot be in the future")
+	})
+
+	t.Run("BeforeBirth", func(t *testing.T) {
+		mockVaccineRepo := new(mocks.VaccineRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+
+		service := NewImmunizationService(new(mocks.ImmunizationRepository), mockVaccineRepo, mockPatientRepo)
+
+		mockPatientRepo.On("FindByID", uint(1)).Return(infant, nil)
+		mockVaccineRepo.On("FindByID", uint(4)).Return(penta, nil)
+
+		_, err := service.RecordImmunization(1, models.RecordImmunizationInput{
+			VaccineID: 4, DoseNumber: 1, AdministeredAt: time.Now().AddDate(-1, 0, 0).Format("2006-01-02"),
+		}, 7)
+
+		assert.EqualError(t, err, "administration date cannot be before the patient's date of birth")
+	})
+
+	t.Run("InactiveVaccine", func(t *testing.T) {
+		mockVaccineRepo := new(mocks.VaccineRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+
+		service := NewImmunizationService(new(mocks.ImmunizationRepository), mockVaccineRepo, mockPatientRepo)
+
+		mockPatientRepo.On("FindByID", uint(1)).Return(infant, nil)
+		mockVaccineRepo.On("FindByID", uint(9)).Return(&models.Vaccine{Model: gorm.Model{ID: 9}, IsActive: false}, nil)
+
+		_, err := service.RecordImmunization(1, models.RecordImmunizationInput{
+			VaccineID: 9, DoseNumber: 1, AdministeredAt: yesterday,
+		}, 7)
+
+		assert.EqualError(t, err, "vaccine not found or inactive")
+	})
+}
+
+func TestBuildImmunizationSchedule(t *testing.T) {
+	dob := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC) // 90 days old
+	given := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	vaccines := []models.Vaccine{
+		{Model: gorm.Model{ID: 1}, Code: "BCG", Doses: []models.VaccineScheduleDose{
+			{DoseNumber: 1, DueAgeDays: 0, OverdueAfterDays: 28},
+		}},
+		{Model: gorm.Model{ID: 2}, Code: "HEPB", Doses: []models.VaccineScheduleDose{
+			{DoseNumber: 1, DueAgeDays: 0, OverdueAfterDays: 28, MaxAgeDays: 42},
+		}},
+		{Model: gorm.Model{ID: 3}, Code: "PENTA", Doses: []models.VaccineScheduleDose{
+			{DoseNumber: 1, DueAgeDays: 42, OverdueAfterDays: 28},
+			{DoseNumber: 2, DueAgeDays: 70, OverdueAfterDays: 28},
+			{DoseNumber: 3, DueAgeDays: 98, OverdueAfterDays: 28},
+		}},
+	}
+	records := []models.Immunization{{VaccineID: 1, DoseNumber: 1, AdministeredAt: given}}
+
+	items := buildImmunizationSchedule(dob, vaccines, records, at)
+
+	statuses := map[string]string{}
+	for _, item := range items {
+		statuses[fmt.Sprintf("%s-%d", item.VaccineCode, item.DoseNumber)] = item.Status
+	}
+
+	assert.Len(t, items, 4, "the missed birth dose of HepB is past its maximum age")
+	assert.Equal(t, constants.ImmunizationStatus.GIVEN, statuses["BCG-1"])
+	assert.Equal(t, constants.ImmunizationStatus.OVERDUE, statuses["PENTA-1"])
+	assert.Equal(t, constants.ImmunizationStatus.DUE, statuses["PENTA-2"])
+	assert.Equal(t, constants.ImmunizationStatus.UPCOMING, statuses["PENTA-3"])
+	assert.Equal(t, given, *items[0].AdministeredAt)
+}
+
+func TestGetOverdueImmunizations(t *testing.T) {
+	mockImmunizationRepo := new(mocks.ImmunizationRepository)
+
+	service := NewImmunizationService(mockImmunizationRepo, new(mocks.VaccineRepository), new(mocks.PatientRepository))
+
+	mockImmunizationRepo.On("FindOverdue", "MCV", mock.AnythingOfType("time.Time"), models.PageQuery{Page: 1, PageSize: models.DefaultPageSize}).
+		Return([]models.OverdueImmunization{{PatientID: 1, VaccineCode: "MCV", DaysOverdue: 12}}, int64(1), nil)
+
+	overdue, total, err := service.GetOverdue(models.OverdueImmunizationQuery{Vaccine: " mcv "})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, 12, overdue[0].DaysOverdue)
+	mockImmunizationRepo.AssertExpectations(t)
+}
@@ -0,0 +1,549 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+)
+
+type InvoiceService interface {
+	CreateInvoice(input models.CreateInvoiceInput, staffID uint) (*models.Invoice, error)
+	GetInvoices(query models.InvoiceQuery) ([]models.Invoice, int64, error)
+	GetInvoiceByID(id uint) (*models.Invoice, error)
+	AddLine(invoiceID uint, input models.AddInvoiceLineInput) (*models.Invoice, error)
+	UpdateLine(invoiceID uint, lineID uint, input models.UpdateInvoiceLineInput) (*models.Invoice, error)
+	DiscountLine(invoiceID uint, lineID uint, input models.DiscountInvoiceLineInput, staffID uint) (*models.Invoice, error)
+	RemoveLine(invoiceID uint, lineID uint) (*models.Invoice, error)
+	IssueInvoice(id uint, staffID uint) (*models.Invoice, error)
+	VoidInvoice(id uint, input models.VoidInvoiceInput, staffID uint) (*models.Invoice, error)
+}
+
+type invoiceService struct {
+	invoiceRepository         repositories.InvoiceRepository
+	servicePriceRepository    repositories.ServicePriceRepository
+	sequenceRepository        repositories.SequenceRepository
+	appointmentRepository     repositories.AppointmentRepository
+	clinicalNoteRepository    repositories.ClinicalNoteRepository
+	prescriptionRepository    repositories.PrescriptionRepository
+	labOrderRepository        repositories.LabOrderRepository
+	patientCoverageRepository repositories.PatientCoverageRepository
+	eligibilityChecker        EligibilityChecker
+	branch                    string
+}
+
+func NewInvoiceService(
+	invoiceRepository repositories.InvoiceRepository,
+	servicePriceRepository repositories.ServicePriceRepository,
+	sequenceRepository repositories.SequenceRepository,
+	appointmentRepository repositories.AppointmentRepository,
+	clinicalNoteRepository repositories.ClinicalNoteRepository,
+	prescriptionRepository repositories.PrescriptionRepository,
+	labOrderRepository repositories.LabOrderRepository,
+	patientCoverageRepository repositories.PatientCoverageRepository,
+	eligibilityChecker EligibilityChecker,
+	branch string,
+) InvoiceService {
+	return &invoiceService{
+		invoiceRepository:         invoiceRepository,
+		servicePriceRepository:    servicePriceRepository,
+		sequenceRepository:        sequenceRepository,
+		appointmentRepository:     appointmentRepository,
+		clinicalNoteRepository:    clinicalNoteRepository,
+		prescriptionRepository:    prescriptionRepository,
+		labOrderRepository:        labOrderRepository,
+		patientCoverageRepository: patientCoverageRepository,
+		eligibilityChecker:        eligibilityChecker,
+		branch:                    strings.TrimSpace(branch),
+	}
+}
+
+// CreateInvoice drafts an invoice for an appointment, charging the
+// consultation, the lab tests ordered and the medications prescribed at it.
+// Charges with no catalog price are left off and reported as warnings.
+func (is *invoiceService) CreateInvoice(input models.CreateInvoiceInput, staffID uint) (*models.Invoice, error) {
+	appointment, err := is.appointmentRepository.FindByID(input.AppointmentID)
+	if err != nil {
+		return nil, errors.New("appointment not found")
+	}
+	if appointment.Status == constants.AppointmentStatus.CANCELLED {
+		return nil, errors.New("cannot invoice a cancelled appointment")
+	}
+	if existing, err := is.invoiceRepository.FindOpenByAppointmentID(appointment.ID); err == nil {
+		return nil, fmt.Errorf("appointment is already billed on invoice %s", existing.InvoiceNumber)
+	}
+
+	prices, err := is.servicePriceRepository.FindAll(models.ServicePriceQuery{ActiveOnly: true})
+	if err != nil {
+		return nil, err
+	}
+
+	invoice := &models.Invoice{
+		Branch:        is.branch,
+		PatientID:     appointment.PatientID,
+		AppointmentID: &appointment.ID,
+		Status:        constants.InvoiceStatus.DRAFT,
+		CreatedBy:     staffID,
+		Lines:         []models.InvoiceLine{},
+	}
+	if err := is.applyCoverage(invoice, appointment.ScheduledAt); err != nil {
+		return nil, err
+	}
+
+	categories := constants.ChargeCategories
+	if price := findServicePrice(prices, appointment.Department, func(p models.ServicePrice) bool {
+		return p.Category == categories.CONSULTATION
+	}); price != nil {
+		invoice.Lines = append(invoice.Lines, newInvoiceLine(price, price.Name, 1))
+	} else {
+		invoice.Warnings = append(invoice.Warnings, "no consultation price for the "+appointment.Department+" department")
+	}
+
+	var noteID *uint
+	if note, err := is.clinicalNoteRepository.FindByAppointmentID(appointment.ID); err == nil {
+		noteID = &note.ID
+	}
+
+	labOrders, err := is.appointmentLabOrders(appointment.ID, noteID)
+	if err != nil {
+		return nil, err
+	}
+	for _, order := range labOrders {
+		for _, result := range order.Results {
+			labTestID := result.LabTestID
+			name := fmt.Sprintf("lab test %d", labTestID)
+			if result.LabTest != nil {
+				name = result.LabTest.Name
+			}
+
+			price := findServicePrice(prices, appointment.Department, func(p models.ServicePrice) bool {
+				return p.Category == categories.LAB_TEST && p.LabTestID != nil && *p.LabTestID == labTestID
+			})
+			if price == nil {
+				invoice.Warnings = append(invoice.Warnings, "no price for "+name)
+				continue
+			}
+			line := newInvoiceLine(price, name, 1)
+			line.LabOrderID = &order.ID
+			invoice.Lines = append(invoice.Lines, line)
+		}
+	}
+
+	if noteID != nil {
+		prescriptions, err := is.prescriptionRepository.FindByNoteID(*noteID)
+		if err != nil {
+			return nil, err
+		}
+		for _, prescription := range prescriptions {
+			if prescription.Status == constants.PrescriptionStatus.DISCONTINUED {
+				continue
+			}
+			medicationID := prescription.MedicationID
+			name := medicationDescription(prescription.Medication, medicationID)
+
+			price := findServicePrice(prices, appointment.Department, func(p models.ServicePrice) bool {
+				return p.Category == categories.MEDICATION && p.MedicationID != nil && *p.MedicationID == medicationID
+			})
+			if price == nil {
+				invoice.Warnings = append(invoice.Warnings, "no price for "+name)
+				continue
+			}
+			line := newInvoiceLine(price, name, prescription.Quantity)
+			line.PrescriptionID = &prescription.ID
+			invoice.Lines = append(invoice.Lines, line)
+		}
+	}
+
+	recalculateInvoice(invoice)
+
+	number, err := is.nextInvoiceNumber()
+	if err != nil {
+		return nil, err
+	}
+	invoice.InvoiceNumber = number
+
+	if err := is.invoiceRepository.Create(invoice); err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+func (is *invoiceService) GetInvoices(query models.InvoiceQuery) ([]models.Invoice, int64, error) {
+	return is.invoiceRepository.FindAll(query)
+}
+
+func (is *invoiceService) GetInvoiceByID(id uint) (*models.Invoice, error) {
+	invoice, err := is.invoiceRepository.FindByID(id)
+	if err != nil {
+		return nil, errors.New("invoice not found")
+	}
+	return invoice, nil
+}
+
+// AddLine charges a catalog item, such as a procedure, on a draft invoice.
+func (is *invoiceService) AddLine(invoiceID uint, input models.AddInvoiceLineInput) (*models.Invoice, error) {
+	invoice, err := is.draftInvoice(invoiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	price, err := is.servicePriceRepository.FindByID(input.ServicePriceID)
+	if err != nil || !price.IsActive {
+		return nil, errors.New("service price not found")
+	}
+
+	quantity := input.Quantity
+	if quantity == 0 {
+		quantity = 1
+	}
+	description := strings.TrimSpace(input.Description)
+	if description == "" {
+		description = price.Name
+	}
+
+	line := newInvoiceLine(price, description, quantity)
+	invoice.Lines = append(invoice.Lines, line)
+
+	return is.saveDraft(invoice)
+}
+
+func (is *invoiceService) UpdateLine(invoiceID uint, lineID uint, input models.UpdateInvoiceLineInput) (*models.Invoice, error) {
+	invoice, err := is.draftInvoice(invoiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	line := findInvoiceLine(invoice, lineID)
+	if line == nil {
+		return nil, errors.New("invoice line not found")
+	}
+
+	if input.Quantity != nil {
+		line.Quantity = *input.Quantity
+	}
+	if line.Discount > lineGross(*line) {
+		return nil, errors.New("discount cannot exceed the line amount")
+	}
+
+	return is.saveDraft(invoice)
+}
+
+// DiscountLine sets a draft line's discount and audits the change with its
+// reason in the same transaction.
+func (is *invoiceService) DiscountLine(invoiceID uint, lineID uint, input models.DiscountInvoiceLineInput, staffID uint) (*models.Invoice, error) {
+	invoice, err := is.draftInvoice(invoiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	line := findInvoiceLine(invoice, lineID)
+	if line == nil {
+		return nil, errors.New("invoice line not found")
+	}
+
+	discount := roundMoney(input.Discount)
+	if discount > lineGross(*line) {
+		return nil, errors.New("discount cannot exceed the line amount")
+	}
+	if discount == line.Discount {
+		return nil, errors.New("line already has this discount")
+	}
+
+	details, err := json.Marshal(map[string]models.FieldChange{
+		fmt.Sprintf("lines.%d.discount", line.ID): {
+			From: fmt.Sprintf("%.2f", line.Discount),
+			To:   fmt.Sprintf("%.2f", discount),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	line.Discount = discount
+	recalculateInvoice(invoice)
+
+	entry := &models.AuditLog{
+		Action:     constants.AuditActions.INVOICE_DISCOUNT,
+		EntityType: constants.AuditEntities.INVOICE,
+		EntityID:   invoice.ID,
+		PatientID:  &invoice.PatientID,
+		StaffID:    staffID,
+		Reason:     strings.TrimSpace(input.Reason),
+		Details:    string(details),
+	}
+	if err := is.invoiceRepository.UpdateAudited(invoice, entry); err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+func (is *invoiceService) RemoveLine(invoiceID uint, lineID uint) (*models.Invoice, error) {
+	invoice, err := is.draftInvoice(invoiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := []models.InvoiceLine{}
+	for _, line := range invoice.Lines {
+		if line.ID != lineID {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) == len(invoice.Lines) {
+		return nil, errors.New("invoice line not found")
+	}
+	invoice.Lines = lines
+
+	return is.saveDraft(invoice)
+}
+
+// IssueInvoice finalises a draft. Its lines can no longer be changed.
+func (is *invoiceService) IssueInvoice(id uint, staffID uint) (*models.Invoice, error) {
+	invoice, err := is.draftInvoice(id)
+	if err != nil {
+		return nil, err
+	}
+	if len(invoice.Lines) == 0 {
+		return nil, errors.New("cannot issue an invoice without lines")
+	}
+
+	now := time.Now()
+	invoice.Status = constants.InvoiceStatus.ISSUED
+	invoice.IssuedAt = &now
+	invoice.IssuedBy = &staffID
+	recalculateInvoice(invoice)
+
+	if err := is.invoiceRepository.Update(invoice); err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+// VoidInvoice cancels a draft or issued invoice. The invoice keeps its number
+// so the sequence has no gaps, and the appointment can be billed again.
+func (is *invoiceService) VoidInvoice(id uint, input models.VoidInvoiceInput, staffID uint) (*models.Invoice, error) {
+	invoice, err := is.invoiceRepository.FindByID(id)
+	if err != nil {
+		return nil, errors.New("invoice not found")
+	}
+
+	statuses := constants.InvoiceStatus
+	switch invoice.Status {
+	case statuses.VOID:
+		return nil, errors.New("invoice is already void")
+	case statuses.PARTIALLY_PAID, statuses.PAID:
+		return nil, errors.New("cannot void an invoice with payments")
+	}
+
+	previousStatus := invoice.Status
+	now := time.Now()
+	invoice.Status = statuses.VOID
+	invoice.VoidedAt = &now
+	invoice.VoidedBy = &staffID
+	invoice.VoidReason = strings.TrimSpace(input.Reason)
+
+	details, err := json.Marshal(models.FieldChange{From: previousStatus, To: invoice.Status})
+	if err != nil {
+		return nil, err
+	}
+	entry := &models.AuditLog{
+		Action:     constants.AuditActions.INVOICE_VOID,
+		EntityType: constants.AuditEntities.INVOICE,
+		EntityID:   invoice.ID,
+		PatientID:  &invoice.PatientID,
+		StaffID:    staffID,
+		Reason:     invoice.VoidReason,
+		Details:    string(details),
+	}
+	if err := is.invoiceRepository.UpdateAudited(invoice, entry); err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+func (is *invoiceService) draftInvoice(id uint) (*models.Invoice, error) {
+	invoice, err := is.invoiceRepository.FindByID(id)
+	if err != nil {
+		return nil, errors.New("invoice not found")
+	}
+	if invoice.Status != constants.InvoiceStatus.DRAFT {
+		return nil, errors.New("only draft invoices can be changed")
+	}
+	return invoice, nil
+}
+
+func (is *invoiceService) saveDraft(invoice *models.Invoice) (*models.Invoice, error) {
+	recalculateInvoice(invoice)
+	if err := is.invoiceRepository.Update(invoice); err != nil {
+		return nil, err
+	}
+	return invoice, nil
+}
+
+// applyCoverage bills the insurer of the first coverage, primary before
+// secondary, that is eligible on the day of service.
+func (is *invoiceService) applyCoverage(invoice *models.Invoice, at time.Time) error {
+	coverages, err := is.patientCoverageRepository.FindByPatientID(invoice.PatientID)
+	if err != nil {
+		return err
+	}
+
+	for _, coverage := range coverages {
+		result, err := is.eligibilityChecker.CheckEligibility(coverage, at)
+		if err != nil || result.Status != constants.EligibilityStatus.ELIGIBLE {
+			continue
+		}
+		invoice.CoverageID = &coverage.ID
+		invoice.PayerID = &coverage.Plan.PayerID
+		invoice.CopayPercent = coverage.Plan.CopayPercent
+		return nil
+	}
+	return nil
+}
+
+// appointmentLabOrders gathers the lab orders placed against the appointment
+// or its clinical note, leaving out cancelled ones.
+func (is *invoiceService) appointmentLabOrders(appointmentID uint, noteID *uint) ([]models.LabOrder, error) {
+	orders, err := is.labOrderRepository.FindAll(map[string]interface{}{"appointment_id": appointmentID})
+	if err != nil {
+		return nil, err
+	}
+	if noteID != nil {
+		noteOrders, err := is.labOrderRepository.FindAll(map[string]interface{}{"clinical_note_id": *noteID})
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, noteOrders...)
+	}
+
+	seen := make(map[uint]bool)
+	billable := []models.LabOrder{}
+	for _, order := range orders {
+		if seen[order.ID] || order.Status == constants.LabOrderStatus.CANCELLED {
+			continue
+		}
+		seen[order.ID] = true
+		billable = append(billable, order)
+	}
+	return billable, nil
+}
+
+func (is *invoiceService) nextInvoiceNumber() (string, error) {
+	return nextDocumentNumber(is.sequenceRepository, constants.InvoiceSequence, "INV", is.branch, "invoice")
+}
+
+// nextDocumentNumber numbers billing documents such as invoices and receipts
+// from their own sequence, per branch when one is configured.
+func nextDocumentNumber(sequenceRepository repositories.SequenceRepository, sequence string, prefix string, branch string, document string) (string, error) {
+	name := sequence
+	prefix += "-"
+	if branch != "" {
+		name += ":" + branch
+		prefix += branch + "-"
+	}
+
+	seq, err := sequenceRepository.Next(name)
+	if err != nil {
+		return "", fmt.Errorf("failed to allocate %s number", document)
+	}
+	return fmt.Sprintf("%s%06d", prefix, seq), nil
+}
+
+// findServicePrice picks the department's own price for a charge, falling
+// back to the hospital-wide price.
+func findServicePrice(prices []models.ServicePrice, department string, matches func(models.ServicePrice) bool) *models.ServicePrice {
+	var fallback *models.ServicePrice
+	for i := range prices {
+		price := &prices[i]
+		if !matches(*price) {
+			continue
+		}
+		if price.Department != nil && *price.Department == department {
+			return price
+		}
+		if price.Department == nil && fallback == nil {
+			fallback = price
+		}
+	}
+	return fallback
+}
+
+func newInvoiceLine(price *models.ServicePrice, description string, quantity int) models.InvoiceLine {
+	return models.InvoiceLine{
+		ServicePriceID: &price.ID,
+		Category:       price.Category,
+		Description:    description,
+		Quantity:       quantity,
+		UnitPrice:      price.UnitPrice,
+		TaxPercent:     price.TaxPercent,
+	}
+}
+
+func findInvoiceLine(invoice *models.Invoice, lineID uint) *models.InvoiceLine {
+	for i := range invoice.Lines {
+		if invoice.Lines[i].ID == lineID {
+			return &invoice.Lines[i]
+		}
+	}
+	return nil
+}
+
+func medicationDescription(medication *models.Medication, medicationID uint) string {
+	if medication == nil {
+		return fmt.Sprintf("medication %d", medicationID)
+	}
+	return strings.TrimSpace(fmt.Sprintf("%s %s %s", medication.Name, medication.Strength, medication.Form))
+}
+
+func lineGross(line models.InvoiceLine) float64 {
+	return roundMoney(float64(line.Quantity) * line.UnitPrice)
+}
+
+// recalculateInvoice prices every line and totals the invoice. Tax is charged
+// on the discounted amount, and the patient pays the plan's copay share of
+// each line, or all of it when uninsured. Approved waivers then come off the
+// patient's share.
+func recalculateInvoice(invoice *models.Invoice) {
+	patientShare := 100.0
+	if invoice.CoverageID != nil {
+		patientShare = invoice.CopayPercent
+	}
+
+	invoice.Subtotal, invoice.DiscountTotal, invoice.TaxTotal = 0, 0, 0
+	invoice.Total, invoice.InsurerAmount, invoice.PatientAmount = 0, 0, 0
+	for i := range invoice.Lines {
+		line := &invoice.Lines[i]
+		gross := lineGross(*line)
+		line.TaxAmount = roundMoney((gross - line.Discount) * line.TaxPercent / 100)
+		line.Total = roundMoney(gross - line.Discount + line.TaxAmount)
+		line.PatientAmount = roundMoney(line.Total * patientShare / 100)
+		line.InsurerAmount = roundMoney(line.Total - line.PatientAmount)
+
+		invoice.Subtotal += gross
+		invoice.DiscountTotal += line.Discount
+		invoice.TaxTotal += line.TaxAmount
+		invoice.Total += line.Total
+		invoice.InsurerAmount += line.InsurerAmount
+		invoice.PatientAmount += line.PatientAmount
+	}
+
+	invoice.Subtotal = roundMoney(invoice.Subtotal)
+	invoice.DiscountTotal = roundMoney(invoice.DiscountTotal)
+	invoice.TaxTotal = roundMoney(invoice.TaxTotal)
+	invoice.Total = roundMoney(invoice.Total - invoice.WaiverTotal)
+	invoice.InsurerAmount = roundMoney(invoice.InsurerAmount)
+	invoice.PatientAmount = roundMoney(invoice.PatientAmount - invoice.WaiverTotal)
+}
+
+func roundMoney(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,432 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type invoiceMocks struct {
+	invoices      *mocks.InvoiceRepository
+	prices        *mocks.ServicePriceRepository
+	sequences     *mocks.SequenceRepository
+	appointments  *mocks.AppointmentRepository
+	notes         *mocks.ClinicalNoteRepository
+	prescriptions *mocks.PrescriptionRepository
+	labOrders     *mocks.LabOrderRepository
+	coverages     *mocks.PatientCoverageRepository
+}
+
+func newInvoiceServiceWithMocks(branch string) (InvoiceService, invoiceMocks) {
+	m := invoiceMocks{
+		invoices:      new(mocks.InvoiceRepository),
+		prices:        new(mocks.ServicePriceRepository),
+		sequences:     new(mocks.SequenceRepository),
+		appointments:  new(mocks.AppointmentRepository),
+		notes:         new(mocks.ClinicalNoteRepository),
+		prescriptions: new(mocks.PrescriptionRepository),
+		labOrders:     new(mocks.LabOrderRepository),
+		coverages:     new(mocks.PatientCoverageRepository),
+	}
+	service := NewInvoiceService(m.invoices, m.prices, m.sequences, m.appointments, m.notes,
+		m.prescriptions, m.labOrders, m.coverages, NewLocalEligibilityChecker(), branch)
+	return service, m
+}
+
+func draftInvoice(lines ...models.InvoiceLine) *models.Invoice {
+	invoice := &models.Invoice{
+		Model:         gorm.Model{ID: 30},
+		InvoiceNumber: "INV-000030",
+		PatientID:     3,
+		Status:        constants.InvoiceStatus.DRAFT,
+		Lines:         lines,
+	}
+	recalculateInvoice(invoice)
+	return invoice
+}
+
+func TestRecalculateInvoice(t *testing.T) {
+	t.Run("Uninsured", func(t *testing.T) {
+		invoice := &models.Invoice{Lines: []models.InvoiceLine{
+			{Quantity: 2, UnitPrice: 1500, Discount: 500, TaxPercent: 7.5},
+			{Quantity: 1, UnitPrice: 5000},
+		}}
+
+		recalculateInvoice(invoice)
+
+		assert.Equal(t, 187.5, invoice.Lines[0].TaxAmount)
+		assert.Equal(t, 2687.5, invoice.Lines[0].Total)
+		assert.Equal(t, 8000.0, invoice.Subtotal)
+		assert.Equal(t, 500.0, invoice.DiscountTotal)
+		assert.Equal(t, 187.5, invoice.TaxTotal)
+		assert.Equal(t, 7687.5, invoice.Total)
+		assert.Equal(t, 7687.5, invoice.PatientAmount)
+		assert.Equal(t, 0.0, invoice.InsurerAmount)
+	})
+
+	t.Run("CopaySplit", func(t *testing.T) {
+		invoice := &models.Invoice{
+			CoverageID:   uintPtr(4),
+			CopayPercent: 10,
+			Lines: []models.InvoiceLine{
+				{Quantity: 1, UnitPrice: 5000},
+				{Quantity: 3, UnitPrice: 333.33},
+			},
+		}
+
+		recalculateInvoice(invoice)
+
+		assert.Equal(t, 500.0, invoice.Lines[0].PatientAmount)
+		assert.Equal(t, 4500.0, invoice.Lines[0].InsurerAmount)
+		assert.Equal(t, 5999.99, invoice.Total)
+		assert.Equal(t, 600.0, invoice.PatientAmount)
+		assert.Equal(t, 5399.99, invoice.InsurerAmount)
+	})
+
+	t.Run("Waived", func(t *testing.T) {
+		invoice := &models.Invoice{
+			CoverageID:   uintPtr(4),
+			CopayPercent: 10,
+			WaiverTotal:  200,
+			Lines:        []models.InvoiceLine{{Quantity: 1, UnitPrice: 5000}},
+		}
+
+		recalculateInvoice(invoice)
+
+		assert.Equal(t, 4800.0, invoice.Total)
+		assert.Equal(t, 300.0, invoice.PatientAmount)
+		assert.Equal(t, 4500.0, invoice.InsurerAmount)
+	})
+}
+
+func TestFindServicePrice(t *testing.T) {
+	prices := []models.ServicePrice{
+		{Model: gorm.Model{ID: 1}, Category: constants.ChargeCategories.CONSULTATION, UnitPrice: 5000},
+		{Model: gorm.Model{ID: 2}, Category: constants.ChargeCategories.CONSULTATION, Department: stringPtr("cardiology"), UnitPrice: 15000},
+	}
+	isConsultation := func(p models.ServicePrice) bool {
+		return p.Category == constants.ChargeCategories.CONSULTATION
+	}
+
+	assert.Equal(t, uint(2), findServicePrice(prices, "cardiology", isConsultation).ID)
+	assert.Equal(t, uint(1), findServicePrice(prices, "pediatrics", isConsultation).ID)
+	assert.Nil(t, findServicePrice(prices, "general", func(p models.ServicePrice) bool {
+		return p.Category == constants.ChargeCategories.PROCEDURE
+	}))
+}
+
+func TestCreateInvoice(t *testing.T) {
+	appointment := &models.Appointment{
+		Model:       gorm.Model{ID: 20},
+		PatientID:   3,
+		Department:  "general",
+		ScheduledAt: time.Now(),
+		Status:      constants.AppointmentStatus.COMPLETED,
+	}
+	prices := []models.ServicePrice{
+		{Model: gorm.Model{ID: 1}, Name: "General consultation", Category: constants.ChargeCategories.CONSULTATION, UnitPrice: 5000},
+		{Model: gorm.Model{ID: 2}, Name: "FBC", Category: constants.ChargeCategories.LAB_TEST, LabTestID: uintPtr(7), UnitPrice: 3000},
+		{Model: gorm.Model{ID: 3}, Name: "Amoxicillin", Category: constants.ChargeCategories.MEDICATION, MedicationID: uintPtr(5), UnitPrice: 50, TaxPercent: 7.5},
+	}
+	labOrders := []models.LabOrder{{
+		Model:  gorm.Model{ID: 40},
+		Status: constants.LabOrderStatus.ORDERED,
+		Results: []models.LabResult{
+			{LabTestID: 7, LabTest: &models.LabTest{Name: "Full blood count"}},
+			{LabTestID: 8, LabTest: &models.LabTest{Name: "Malaria parasite"}},
+		},
+	}}
+	prescriptions := []models.Prescription{
+		{Model: gorm.Model{ID: 50}, MedicationID: 5, Quantity: 21, Status: constants.PrescriptionStatus.ACTIVE,
+			Medication: &models.Medication{Name: "Amoxicillin", Strength: "500mg", Form: "capsule"}},
+		{Model: gorm.Model{ID: 51}, MedicationID: 5, Quantity: 10, Status: constants.PrescriptionStatus.DISCONTINUED},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		service, m := newInvoiceServiceWithMocks("LOS")
+
+		m.appointments.On("FindByID", uint(20)).Return(appointment, nil)
+		m.invoices.On("FindOpenByAppointmentID", uint(20)).Return(&models.Invoice{}, gorm.ErrRecordNotFound)
+		m.prices.On("FindAll", models.ServicePriceQuery{ActiveOnly: true}).Return(prices, nil)
+		m.coverages.On("FindByPatientID", uint(3)).Return([]models.PatientCoverage{{
+			Model:     gorm.Model{ID: 4},
+			IsActive:  true,
+			ValidFrom: time.Now().AddDate(-1, 0, 0),
+			Plan: &models.InsurancePlan{PayerID: 2, CopayPercent: 10, IsActive: true,
+				Payer: &models.Payer{IsActive: true}},
+		}}, nil)
+		m.notes.On("FindByAppointmentID", uint(20)).Return(&models.ClinicalNote{Model: gorm.Model{ID: 60}}, nil)
+		m.labOrders.On("FindAll", map[string]interface{}{"appointment_id": uint(20)}).Return(labOrders, nil)
+		m.labOrders.On("FindAll", map[string]interface{}{"clinical_note_id": uint(60)}).Return(labOrders, nil)
+		m.prescriptions.On("FindByNoteID", uint(60)).Return(prescriptions, nil)
+		m.sequences.On("Next", "invoice:LOS").Return(int64(42), nil)
+		m.invoices.On("Create", mock.AnythingOfType("*models.Invoice")).Return(nil)
+
+		invoice, err := service.CreateInvoice(models.CreateInvoiceInput{AppointmentID: 20}, 9)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "INV-LOS-000042", invoice.InvoiceNumber)
+		assert.Equal(t, "LOS", invoice.Branch)
+		assert.Equal(t, constants.InvoiceStatus.DRAFT, invoice.Status)
+		assert.Equal(t, uint(2), *invoice.PayerID)
+		assert.Len(t, invoice.Lines, 3)
+		assert.Equal(t, "General consultation", invoice.Lines[0].Description)
+		assert.Equal(t, "Full blood count", invoice.Lines[1].Description)
+		assert.Equal(t, uint(40), *invoice.Lines[1].LabOrderID)
+		assert.Equal(t, "Amoxicillin 500mg capsule", invoice.Lines[2].Description)
+		assert.Equal(t, 21, invoice.Lines[2].Quantity)
+		assert.Equal(t, 9128.75, invoice.Total)
+		assert.Equal(t, 912.88, invoice.PatientAmount)
+		assert.Equal(t, []string{"no price for Malaria parasite"}, invoice.Warnings)
+		m.invoices.AssertExpectations(t)
+	})
+
+	t.Run("NoBranch", func(t *testing.T) {
+		service, m := newInvoiceServiceWithMocks("")
+
+		m.appointments.On("FindByID", uint(20)).Return(appointment, nil)
+		m.invoices.On("FindOpenByAppointmentID", uint(20)).Return(&models.Invoice{}, gorm.ErrRecordNotFound)
+		m.prices.On("FindAll", models.ServicePriceQuery{ActiveOnly: true}).Return([]models.ServicePrice{}, nil)
+		m.coverages.On("FindByPatientID", uint(3)).Return([]models.PatientCoverage{}, nil)
+		m.notes.On("FindByAppointmentID", uint(20)).Return(&models.ClinicalNote{}, gorm.ErrRecordNotFound)
+		m.labOrders.On("FindAll", map[string]interface{}{"appointment_id": uint(20)}).Return([]models.LabOrder{}, nil)
+		m.sequences.On("Next", "invoice").Return(int64(7), nil)
+		m.invoices.On("Create", mock.AnythingOfType("*models.Invoice")).Return(nil)
+
+		invoice, err := service.CreateInvoice(models.CreateInvoiceInput{AppointmentID: 20}, 9)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "INV-000007", invoice.InvoiceNumber)
+		assert.Nil(t, invoice.CoverageID)
+		assert.Empty(t, invoice.Lines)
+		assert.Equal(t, []string{"no consultation price for the general department"}, invoice.Warnings)
+	})
+
+	t.Run("AlreadyBilled", func(t *testing.T) {
+		service, m := newInvoiceServiceWithMocks("")
+
+		m.appointments.On("FindByID", uint(20)).Return(appointment, nil)
+		m.invoices.On("FindOpenByAppointmentID", uint(20)).Return(&models.Invoice{InvoiceNumber: "INV-000003"}, nil)
+
+		_, err := service.CreateInvoice(models.CreateInvoiceInput{AppointmentID: 20}, 9)
+
+		assert.EqualError(t, err, "appointment is already billed on invoice INV-000003")
+		m.sequences.AssertNotCalled(t, "Next", mock.Anything)
+	})
+
+	t.Run("CancelledAppointment", func(t *testing.T) {
+		service, m := newInvoiceServiceWithMocks("")
+
+		m.appointments.On("FindByID", uint(21)).Return(&models.Appointment{Status: constants.AppointmentStatus.CANCELLED}, nil)
+
+		_, err := service.CreateInvoice(models.CreateInvoiceInput{AppointmentID: 21}, 9)
+
+		assert.EqualError(t, err, "cannot invoice a cancelled appointment")
+	})
+}
+
+func TestInvoiceLines(t *testing.T) {
+	procedure := &models.ServicePrice{Model: gorm.Model{ID: 8}, Name: "Wound dressing",
+		Category: constants.ChargeCategories.PROCEDURE, UnitPrice: 2000, IsActive: true}
+
+	t.Run("AddProcedure", func(t *testing.T) {
+		service, m := newInvoiceServiceWithMocks("")
+
+		m.invoices.On("FindByID", uint(30)).Return(draftInvoice(), nil)
+		m.prices.On("FindByID", uint(8)).Return(procedure, nil)
+		m.invoices.On("Update", mock.AnythingOfType("*models.Invoice")).Return(nil)
+
+		invoice, err := service.AddLine(30, models.AddInvoiceLineInput{ServicePriceID: 8, Quantity: 2})
+
+		assert.NoError(t, err)
+		assert.Len(t, invoice.Lines, 1)
+		assert.Equal(t, "Wound dressing", invoice.Lines[0].Description)
+		assert.Equal(t, 4000.0, invoice.Total)
+	})
+
+	t.Run("QuantityBelowDiscount", func(t *testing.T) {
+		service, m := newInvoiceServiceWithMocks("")
+
+		m.invoices.On("FindByID", uint(30)).Return(draftInvoice(
+			models.InvoiceLine{Model: gorm.Model{ID: 1}, Quantity: 2, UnitPrice: 2000, Discount: 2500},
+		), nil)
+
+		quantity := 1
+		_, err := service.UpdateLine(30, 1, models.UpdateInvoiceLineInput{Quantity: &quantity})
+
+		assert.EqualError(t, err, "discount cannot exceed the line amount")
+		m.invoices.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("RemoveLine", func(t *testing.T) {
+		service, m := newInvoiceServiceWithMocks("")
+
+		m.invoices.On("FindByID", uint(30)).Return(draftInvoice(
+			models.InvoiceLine{Model: gorm.Model{ID: 1}, Quantity: 1, UnitPrice: 2000},
+			models.InvoiceLine{Model: gorm.Model{ID: 2}, Quantity: 1, UnitPrice: 500},
+		), nil)
+		m.invoices.On("Update", mock.AnythingOfType("*models.Invoice")).Return(nil)
+
+		invoice, err := service.RemoveLine(30, 1)
+
+		assert.NoError(t, err)
+		assert.Len(t, invoice.Lines, 1)
+		assert.Equal(t, 500.0, invoice.Total)
+	})
+
+	t.Run("IssuedInvoiceLocked", func(t *testing.T) {
+		service, m := newInvoiceServiceWithMocks("")
+
+		issued := draftInvoice()
+		issued.Status = constants.InvoiceStatus.ISSUED
+		m.invoices.On("FindByID", uint(30)).Return(issued, nil)
+
+		_, err := service.AddLine(30, models.AddInvoiceLineInput{ServicePriceID: 8})
+
+		assert.EqualError(t, err, "only draft invoices can be changed")
+	})
+}
+
+func TestIssueInvoice(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		service, m := newInvoiceServiceWithMocks("")
+
+		m.invoices.On("FindByID", uint(30)).Return(draftInvoice(
+			models.InvoiceLine{Model: gorm.Model{ID: 1}, Quantity: 1, UnitPrice: 2000},
+		), nil)
+		m.invoices.On("Update", mock.AnythingOfType("*models.Invoice")).Return(nil)
+
+		invoice, err := service.IssueInvoice(30, 9)
+
+		assert.NoError(t, err)
+		assert.Equal(t, constants.InvoiceStatus.ISSUED, invoice.Status)
+		assert.Equal(t, uint(9), *invoice.IssuedBy)
+		assert.NotNil(t, invoice.IssuedAt)
+	})
+
+	t.Run("NoLines", func(t *testing.T) {
+		service, m := newInvoiceServiceWithMocks("")
+
+		m.invoices.On("FindByID", uint(30)).Return(draftInvoice(), nil)
+
+		_, err := service.IssueInvoice(30, 9)
+
+		assert.EqualError(t, err, "cannot issue an invoice without lines")
+	})
+}
+
+func TestDiscountInvoiceLine(t *testing.T) {
+	t.Run("Audited", func(t *testing.T) {
+		service, m := newInvoiceServiceWithMocks("")
+
+		m.invoices.On("FindByID", uint(30)).Return(draftInvoice(
+			models.InvoiceLine{Model: gorm.Model{ID: 1}, Quantity: 2, UnitPrice: 2000},
+		), nil)
+		m.invoices.On("UpdateAudited", mock.AnythingOfType("*models.Invoice"), mock.MatchedBy(func(entry *models.AuditLog) bool {
+			return entry.Action == constants.AuditActions.INVOICE_DISCOUNT && entry.EntityID == 30 &&
+				entry.StaffID == 5 && entry.Reason == "Staff dependant" &&
+				entry.Details == `{"lines.1.discount":{"from":"0.00","to":"500.00"}}`
+		})).Return(nil)
+
+		invoice, err := service.DiscountLine(30, 1, models.DiscountInvoiceLineInput{
+			Discount: 500, Reason: " Staff dependant ",
+		}, 5)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 500.0, invoice.DiscountTotal)
+		assert.Equal(t, 3500.0, invoice.Total)
+		m.invoices.AssertExpectations(t)
+		m.invoices.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("TooLarge", func(t *testing.T) {
+		service, m := newInvoiceServiceWithMocks("")
+
+		m.invoices.On("FindByID", uint(30)).Return(draftInvoice(
+			models.InvoiceLine{Model: gorm.Model{ID: 1}, Quantity: 1, UnitPrice: 2000},
+		), nil)
+
+		_, err := service.DiscountLine(30, 1, models.DiscountInvoiceLineInput{Discount: 2500, Reason: "x"}, 5)
+
+		assert.EqualError(t, err, "discount cannot exceed the line amount")
+		m.invoices.AssertNotCalled(t, "UpdateAudited", mock.Anything, mock.Anything)
+	})
+
+	t.Run("IssuedInvoice", func(t *testing.T) {
+		service, m := newInvoiceServiceWithMocks("")
+
+		issued := draftInvoice(models.InvoiceLine{Model: gorm.Model{ID: 1}, Quantity: 1, UnitPrice: 2000})
+		issued.Status = constants.InvoiceStatus.ISSUED
+		m.invoices.On("FindByID", uint(30)).Return(issued, nil)
+
+		_, err := service.DiscountLine(30, 1, models.DiscountInvoiceLineInput{Discount: 500, Reason: "x"}, 5)
+
+		assert.EqualError(t, err, "only draft invoices can be changed")
+	})
+}
+
+func TestVoidInvoice(t *testing.T) {
+	t.Run("Audited", func(t *testing.T) {
+		service, m := newInvoiceServiceWithMocks("")
+
+		issued := draftInvoice(models.InvoiceLine{Model: gorm.Model{ID: 1}, Quantity: 1, UnitPrice: 2000})
+		issued.Status = constants.InvoiceStatus.ISSUED
+		m.invoices.On("FindByID", uint(30)).Return(issued, nil)
+		m.invoices.On("UpdateAudited", mock.AnythingOfType("*models.Invoice"), mock.MatchedBy(func(entry *models.AuditLog) bool {
+			return entry.Action == constants.AuditActions.INVOICE_VOID && entry.EntityID == 30 &&
+				entry.Reason == "Billed to the wrong patient" && *entry.PatientID == 3
+		})).Return(nil)
+
+		invoice, err := service.VoidInvoice(30, models.VoidInvoiceInput{Reason: "Billed to the wrong patient"}, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, constants.InvoiceStatus.VOID, invoice.Status)
+		m.invoices.AssertExpectations(t)
+		m.invoices.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("SaveFails", func(t *testing.T) {
+		service, m := newInvoiceServiceWithMocks("")
+
+		issued := draftInvoice(models.InvoiceLine{Model: gorm.Model{ID: 1}, Quantity: 1, UnitPrice: 2000})
+		issued.Status = constants.InvoiceStatus.ISSUED
+		m.invoices.On("FindByID", uint(30)).Return(issued, nil)
+		m.invoices.On("UpdateAudited", mock.Anything, mock.Anything).Return(errors.New("connection reset"))
+
+		invoice, err := service.VoidInvoice(30, models.VoidInvoiceInput{Reason: "Duplicate"}, 1)
+
+		assert.Nil(t, invoice)
+		assert.EqualError(t, err, "connection reset")
+	})
+
+	t.Run("HasPayments", func(t *testing.T) {
+		service, m := newInvoiceServiceWithMocks("")
+
+		paid := draftInvoice()
+		paid.Status = constants.InvoiceStatus.PARTIALLY_PAID
+		m.invoices.On("FindByID", uint(30)).Return(paid, nil)
+
+		_, err := service.VoidInvoice(30, models.VoidInvoiceInput{Reason: "x"}, 1)
+
+		assert.EqualError(t, err, "cannot void an invoice with payments")
+	})
+
+	t.Run("AlreadyVoid", func(t *testing.T) {
+		service, m := newInvoiceServiceWithMocks("")
+
+		void := draftInvoice()
+		void.Status = constants.InvoiceStatus.VOID
+		m.invoices.On("FindByID", uint(30)).Return(void, nil)
+
+		_, err := service.VoidInvoice(30, models.VoidInvoiceInput{Reason: "x"}, 1)
+
+		assert.EqualError(t, err, "invoice is already void")
+	})
+}
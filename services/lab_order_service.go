@@ -0,0 +1,356 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+	"github.com/ofojichigozie/hms-go-backend/utils"
+)
+
+type LabOrderService interface {
+	CreateLabOrder(input models.CreateLabOrderInput, doctorID uint) (*models.LabOrder, error)
+	GetAllLabOrders(filters map[string]interface{}) ([]models.LabOrder, error)
+	GetLabOrderByID(id uint) (*models.LabOrder, error)
+	CollectSample(id uint, staffID uint) (*models.LabOrder, error)
+	EnterResults(id uint, input models.EnterLabResultsInput, staffID uint) (*models.LabOrder, error)
+	VerifyResults(id uint, staffID uint) (*models.LabOrder, error)
+	CancelLabOrder(id uint, input models.CancelLabOrderInput, staffID uint) (*models.LabOrder, error)
+	GetResultsInbox(doctorID uint) ([]models.LabOrder, error)
+	AcknowledgeResults(id uint, doctorID uint) (*models.LabOrder, error)
+}
+
+type labOrderService struct {
+	labOrderRepository     repositories.LabOrderRepository
+	labTestRepository      repositories.LabTestRepository
+	appointmentRepository  repositories.AppointmentRepository
+	clinicalNoteRepository repositories.ClinicalNoteRepository
+	patientRepository      repositories.PatientRepository
+	decisionSupport        DecisionSupport
+}
+
+func NewLabOrderService(
+	labOrderRepository repositories.LabOrderRepository,
+	labTestRepository repositories.LabTestRepository,
+	appointmentRepository repositories.AppointmentRepository,
+	clinicalNoteRepository repositories.ClinicalNoteRepository,
+	patientRepository repositories.PatientRepository,
+	decisionSupport DecisionSupport,
+) LabOrderService {
+	return &labOrderService{
+		labOrderRepository:     labOrderRepository,
+		labTestRepository:      labTestRepository,
+		appointmentRepository:  appointmentRepository,
+		clinicalNoteRepository: clinicalNoteRepository,
+		patientRepository:      patientRepository,
+		decisionSupport:        decisionSupport,
+	}
+}
+
+func (ls *labOrderService) CreateLabOrder(input models.CreateLabOrderInput, doctorID uint) (*models.LabOrder, error) {
+	order := &models.LabOrder{
+		OrderedBy:    doctorID,
+		Priority:     input.Priority,
+		Status:       constants.LabOrderStatus.ORDERED,
+		ClinicalInfo: input.ClinicalInfo,
+	}
+	if order.Priority == "" {
+		order.Priority = constants.LabPriority.ROUTINE
+	}
+
+	if input.ClinicalNoteID != nil {
+		note, err := ls.clinicalNoteRepository.FindByID(*input.ClinicalNoteID)
+		if err != nil {
+			return nil, errors.New("associated clinical note not found")
+		}
+		order.ClinicalNoteID = &note.ID
+		order.AppointmentID = note.AppointmentID
+		order.PatientID = note.PatientID
+	} else {
+		appointment, err := ls.appointmentRepository.FindByID(*input.AppointmentID)
+		if err != nil {
+			return nil, errors.New("associated appointment record not found")
+		}
+		order.AppointmentID = &appointment.ID
+		order.PatientID = appointment.PatientID
+	}
+
+	labTests, err := ls.labTestRepository.FindByIDs(input.LabTestIDs)
+	if err != nil {
+		return nil, err
+	}
+	testsByID := make(map[uint]models.LabTest, len(labTests))
+	for _, labTest := range labTests {
+		testsByID[labTest.ID] = labTest
+	}
+
+	seen := make(map[uint]bool)
+	for _, labTestID := range input.LabTestIDs {
+		labTest, ok := testsByID[labTestID]
+		if !ok || !labTest.IsActive {
+			return nil, fmt.Errorf("lab test %d not found", labTestID)
+		}
+		if seen[labTestID] {
+			continue
+		}
+		seen[labTestID] = true
+		order.Results = append(order.Results, models.LabResult{
+			LabTestID: labTest.ID,
+			Unit:      labTest.Unit,
+		})
+	}
+
+	if err := ls.labOrderRepository.Create(order); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+func (ls *labOrderService) GetAllLabOrders(filters map[string]interface{}) ([]models.LabOrder, error) {
+	return ls.labOrderRepository.FindAll(filters)
+}
+
+func (ls *labOrderService) GetLabOrderByID(id uint) (*models.LabOrder, error) {
+	return ls.labOrderRepository.FindByID(id)
+}
+
+func (ls *labOrderService) CollectSample(id uint, staffID uint) (*models.LabOrder, error) {
+	order, err := ls.findOrderInStatus(id, constants.LabOrderStatus.ORDERED)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	order.Status = constants.LabOrderStatus.SAMPLE_COLLECTED
+	order.SampleCollectedAt = &now
+	order.SampleCollectedBy = &staffID
+
+	if err := ls.labOrderRepository.Update(order); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// EnterResults records values, resolves the reference range for the patient's
+// sex and age at sample collection, and flags abnormal values. Results may be
+// amended until they are verified. Decision support rules run on every entry.
+func (ls *labOrderService) EnterResults(id uint, input models.EnterLabResultsInput, staffID uint) (*models.LabOrder, error) {
+	order, err := ls.findOrderInStatus(id, constants.LabOrderStatus.SAMPLE_COLLECTED, constants.LabOrderStatus.RESULTED)
+	if err != nil {
+		return nil, err
+	}
+
+	patient, err := ls.patientRepository.FindByID(order.PatientID)
+	if err != nil || patient == nil {
+		return nil, errors.New("patient record not found")
+	}
+
+	sampledAt := time.Now()
+	if order.SampleCollectedAt != nil {
+		sampledAt = *order.SampleCollectedAt
+	}
+	ageInDays := utils.AgeInDays(patient.DateOfBirth, sampledAt)
+
+	for _, entry := range input.Results {
+		result := findOrderResult(order, entry.LabTestID)
+		if result == nil {
+			return nil, fmt.Errorf("lab test %d is not part of this order", entry.LabTestID)
+		}
+		if result.LabTest == nil {
+			return nil, fmt.Errorf("lab test %d not found", entry.LabTestID)
+		}
+
+		if result.LabTest.ResultType == constants.LabResultType.TEXT {
+			if entry.TextValue == "" {
+				return nil, fmt.Errorf("a text value is required for %s", result.LabTest.Code)
+			}
+			result.TextValue = entry.TextValue
+		} else {
+			if entry.NumericValue == nil {
+				return nil, fmt.Errorf("a numeric value is required for %s", result.LabTest.Code)
+			}
+			result.NumericValue = entry.NumericValue
+			referenceRange := SelectReferenceRange(result.LabTest.ReferenceRanges, patient.Gender, ageInDays)
+			result.ReferenceLow, result.ReferenceHigh = nil, nil
+			if referenceRange != nil {
+				result.ReferenceLow = referenceRange.Low
+				result.ReferenceHigh = referenceRange.High
+			}
+			result.Flag = FlagLabValue(*entry.NumericValue, referenceRange)
+		}
+		result.Comment = entry.Comment
+	}
+
+	status := constants.LabOrderStatus.RESULTED
+	for _, result := range order.Results {
+		if result.NumericValue == nil && result.TextValue == "" {
+			status = constants.LabOrderStatus.SAMPLE_COLLECTED
+			break
+		}
+	}
+
+	cdsAlerts, err := ls.decisionSupport.Evaluate(models.CdsEvent{
+		Trigger:   constants.CdsTriggers.LAB_RESULT,
+		PatientID: order.PatientID,
+		EntityID:  order.ID,
+		LabOrder:  order,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := checkCdsAlerts(cdsAlerts, input.AlertOverrideReason); err != nil {
+		return nil, err
+	}
+
+	order, err = ls.saveResults(order, status, staffID,
+		cdsAlertWrites(cdsAlerts, staffID, input.AlertOverrideReason))
+	if err != nil {
+		return nil, err
+	}
+	order.Alerts = cdsAlerts
+
+	return order, nil
+}
+
+func (ls *labOrderService) saveResults(order *models.LabOrder, status string, staffID uint,
+	writes models.ClinicalRecordWrites) (*models.LabOrder, error) {
+	order.Status = status
+	if status == constants.LabOrderStatus.RESULTED {
+		now := time.Now()
+		order.ResultedAt = &now
+		order.ResultedBy = &staffID
+	}
+
+	if err := ls.labOrderRepository.SaveResults(order, writes); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+func (ls *labOrderService) VerifyResults(id uint, staffID uint) (*models.LabOrder, error) {
+	order, err := ls.findOrderInStatus(id, constants.LabOrderStatus.RESULTED)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	order.Status = constants.LabOrderStatus.VERIFIED
+	order.VerifiedAt = &now
+	order.VerifiedBy = &staffID
+
+	if err := ls.labOrderRepository.Update(order); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+func (ls *labOrderService) CancelLabOrder(id uint, input models.CancelLabOrderInput, staffID uint) (*models.LabOrder, error) {
+	order, err := ls.findOrderInStatus(id, constants.LabOrderStatus.ORDERED, constants.LabOrderStatus.SAMPLE_COLLECTED)
+	if err != nil {
+		return nil, err
+	}
+
+	order.Status = constants.LabOrderStatus.CANCELLED
+	order.CancelReason = input.Reason
+
+	if err := ls.labOrderRepository.Update(order); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+func (ls *labOrderService) GetResultsInbox(doctorID uint) ([]models.LabOrder, error) {
+	return ls.labOrderRepository.FindInbox(doctorID)
+}
+
+func (ls *labOrderService) AcknowledgeResults(id uint, doctorID uint) (*models.LabOrder, error) {
+	order, err := ls.findOrderInStatus(id, constants.LabOrderStatus.RESULTED, constants.LabOrderStatus.VERIFIED)
+	if err != nil {
+		return nil, err
+	}
+	if order.OrderedBy != doctorID {
+		return nil, errors.New("only the ordering doctor can acknowledge these results")
+	}
+
+	now := time.Now()
+	order.ReviewedAt = &now
+
+	if err := ls.labOrderRepository.Update(order); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+func (ls *labOrderService) findOrderInStatus(id uint, statuses ...string) (*models.LabOrder, error) {
+	order, err := ls.labOrderRepository.FindByID(id)
+	if err != nil {
+		return nil, errors.New("lab order not found")
+	}
+
+	for _, status := range statuses {
+		if order.Status == status {
+			return order, nil
+		}
+	}
+
+	return nil, fmt.Errorf("lab order is %s and cannot be updated", order.Status)
+}
+
+func findOrderResult(order *models.LabOrder, labTestID uint) *models.LabResult {
+	for i := range order.Results {
+		if order.Results[i].LabTestID == labTestID {
+			return &order.Results[i]
+		}
+	}
+	return nil
+}
+
+// SelectReferenceRange picks the range covering the patient's age, preferring
+// a sex-specific range over one that applies to any sex.
+func SelectReferenceRange(ranges []models.LabReferenceRange, sex string, ageInDays int) *models.LabReferenceRange {
+	var fallback *models.LabReferenceRange
+	for i := range ranges {
+		referenceRange := &ranges[i]
+		if ageInDays < referenceRange.MinAgeDays ||
+			(referenceRange.MaxAgeDays != nil && ageInDays >= *referenceRange.MaxAgeDays) {
+			continue
+		}
+		if referenceRange.Sex == sex {
+			return referenceRange
+		}
+		if referenceRange.Sex == "" && fallback == nil {
+			fallback = referenceRange
+		}
+	}
+	return fallback
+}
+
+// FlagLabValue classifies a numeric result against its reference range. It
+// returns an empty flag when no range applies.
+func FlagLabValue(value float64, referenceRange *models.LabReferenceRange) string {
+	if referenceRange == nil {
+		return ""
+	}
+
+	flags := constants.LabResultFlag
+	switch {
+	case referenceRange.CriticalLow != nil && value <= *referenceRange.CriticalLow:
+		return flags.CRITICAL_LOW
+	case referenceRange.CriticalHigh != nil && value >= *referenceRange.CriticalHigh:
+		return flags.CRITICAL_HIGH
+	case referenceRange.Low != nil && value < *referenceRange.Low:
+		return flags.LOW
+	case referenceRange.High != nil && value > *referenceRange.High:
+		return flags.HIGH
+	}
+	return flags.NORMAL
+}
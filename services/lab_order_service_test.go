@@ -0,0 +1,311 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type labOrderServiceMocks struct {
+	labOrderRepo     *mocks.LabOrderRepository
+	labTestRepo      *mocks.LabTestRepository
+	appointmentRepo  *mocks.AppointmentRepository
+	clinicalNoteRepo *mocks.ClinicalNoteRepository
+	patientRepo      *mocks.PatientRepository
+	decisionSupport  *mocks.DecisionSupport
+}
+
+func newLabOrderServiceWithMocks() (LabOrderService, labOrderServiceMocks) {
+	m := labOrderServiceMocks{
+		labOrderRepo:     new(mocks.LabOrderRepository),
+		labTestRepo:      new(mocks.LabTestRepository),
+		appointmentRepo:  new(mocks.AppointmentRepository),
+		clinicalNoteRepo: new(mocks.ClinicalNoteRepository),
+		patientRepo:      new(mocks.PatientRepository),
+		decisionSupport:  noCdsAlerts(),
+	}
+	service := NewLabOrderService(m.labOrderRepo, m.labTestRepo, m.appointmentRepo,
+		m.clinicalNoteRepo, m.patientRepo, m.decisionSupport)
+	return service, m
+}
+
+func floatPtr(v float64) *float64 { return &v }
+
+func intPtr(v int) *int { return &v }
+
+func uintPtr(v uint) *uint { return &v }
+
+func haemoglobinTest() *models.LabTest {
+	return &models.LabTest{
+		Model:      gorm.Model{ID: 4},
+		Code:       "HB",
+		ResultType: constants.LabResultType.NUMERIC,
+		Unit:       "g/dL",
+		IsActive:   true,
+		ReferenceRanges: []models.LabReferenceRange{
+			{Sex: "", MinAgeDays: 0, MaxAgeDays: intPtr(6570), Low: floatPtr(11), High: floatPtr(16)},
+			{Sex: "male", MinAgeDays: 6570, Low: floatPtr(13.5), High: floatPtr(17.5), CriticalLow: floatPtr(7)},
+			{Sex: "female", MinAgeDays: 6570, Low: floatPtr(12), High: floatPtr(15.5), CriticalLow: floatPtr(7)},
+		},
+	}
+}
+
+func TestCreateLabOrder(t *testing.T) {
+	t.Run("FromClinicalNote", func(t *testing.T) {
+		service, m := newLabOrderServiceWithMocks()
+
+		noteID := uint(8)
+		note := &models.ClinicalNote{Model: gorm.Model{ID: 8}, AppointmentID: uintPtr(3), PatientID: 2}
+
+		m.clinicalNoteRepo.On("FindByID", noteID).Return(note, nil)
+		m.labTestRepo.On("FindByIDs", []uint{4, 4}).Return([]models.LabTest{*haemoglobinTest()}, nil)
+		m.labOrderRepo.On("Create", mock.AnythingOfType("*models.LabOrder")).Return(nil)
+
+		order, err := service.CreateLabOrder(models.CreateLabOrderInput{
+			ClinicalNoteID: &noteID,
+			LabTestIDs:     []uint{4, 4},
+		}, 5)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(2), order.PatientID)
+		assert.Equal(t, uint(3), *order.AppointmentID)
+		assert.Equal(t, constants.LabOrderStatus.ORDERED, order.Status)
+		assert.Equal(t, constants.LabPriority.ROUTINE, order.Priority)
+		assert.Len(t, order.Results, 1)
+		assert.Equal(t, "g/dL", order.Results[0].Unit)
+	})
+
+	t.Run("UnknownTest", func(t *testing.T) {
+		service, m := newLabOrderServiceWithMocks()
+
+		appointmentID := uint(3)
+		m.appointmentRepo.On("FindByID", appointmentID).Return(&models.Appointment{Model: gorm.Model{ID: 3}, PatientID: 2}, nil)
+		m.labTestRepo.On("FindByIDs", []uint{9}).Return([]models.LabTest{}, nil)
+
+		order, err := service.CreateLabOrder(models.CreateLabOrderInput{
+			AppointmentID: &appointmentID,
+			LabTestIDs:    []uint{9},
+		}, 5)
+
+		assert.Nil(t, order)
+		assert.EqualError(t, err, "lab test 9 not found")
+		m.labOrderRepo.AssertNotCalled(t, "Create")
+	})
+}
+
+func TestEnterLabResults(t *testing.T) {
+	collectedAt := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	newCollectedOrder := func() *models.LabOrder {
+		return &models.LabOrder{
+			Model:             gorm.Model{ID: 1},
+			PatientID:         2,
+			OrderedBy:         5,
+			Status:            constants.LabOrderStatus.SAMPLE_COLLECTED,
+			SampleCollectedAt: &collectedAt,
+			Results: []models.LabResult{
+				{LabTestID: 4, LabTest: haemoglobinTest(), Unit: "g/dL"},
+			},
+		}
+	}
+
+	t.Run("FlagsAgainstSexSpecificRange", func(t *testing.T) {
+		service, m := newLabOrderServiceWithMocks()
+
+		patient := &models.Patient{Model: gorm.Model{ID: 2}, Gender: "female",
+			DateOfBirth: time.Date(1990, 5, 1, 0, 0, 0, 0, time.UTC)}
+
+		m.labOrderRepo.On("FindByID", uint(1)).Return(newCollectedOrder(), nil)
+		m.patientRepo.On("FindByID", uint(2)).Return(patient, nil)
+		m.labOrderRepo.On("SaveResults", mock.AnythingOfType("*models.LabOrder"), mock.Anything).Return(nil)
+
+		order, err := service.EnterResults(1, models.EnterLabResultsInput{
+			Results: []models.LabResultEntryInput{{LabTestID: 4, NumericValue: floatPtr(11.2)}},
+		}, 7)
+
+		assert.NoError(t, err)
+		assert.Equal(t, constants.LabOrderStatus.RESULTED, order.Status)
+		assert.Equal(t, uint(7), *order.ResultedBy)
+		assert.Equal(t, constants.LabResultFlag.LOW, order.Results[0].Flag)
+		assert.Equal(t, 12.0, *order.Results[0].ReferenceLow)
+	})
+
+	t.Run("UsesPaediatricRangeForChildren", func(t *testing.T) {
+		service, m := newLabOrderServiceWithMocks()
+
+		patient := &models.Patient{Model: gorm.Model{ID: 2}, Gender: "male",
+			DateOfBirth: time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)}
+
+		m.labOrderRepo.On("FindByID", uint(1)).Return(newCollectedOrder(), nil)
+		m.patientRepo.On("FindByID", uint(2)).Return(patient, nil)
+		m.labOrderRepo.On("SaveResults", mock.AnythingOfType("*models.LabOrder"), mock.Anything).Return(nil)
+
+		order, err := service.EnterResults(1, models.EnterLabResultsInput{
+			Results: []models.LabResultEntryInput{{LabTestID: 4, NumericValue: floatPtr(12.5)}},
+		}, 7)
+
+		assert.NoError(t, err)
+		assert.Equal(t, constants.LabResultFlag.NORMAL, order.Results[0].Flag)
+		assert.Equal(t, 11.0, *order.Results[0].ReferenceLow)
+	})
+
+	t.Run("BlockingClinicalAlert", func(t *testing.T) {
+		service, m := newLabOrderServiceWithMocks()
+
+		patient := &models.Patient{Model: gorm.Model{ID: 2}, Gender: "female", Genotype: "SS",
+			DateOfBirth: time.Date(1990, 5, 1, 0, 0, 0, 0, time.UTC)}
+
+		m.labOrderRepo.On("FindByID", uint(1)).Return(newCollectedOrder(), nil)
+		m.patientRepo.On("FindByID", uint(2)).Return(patient, nil)
+		raiseCdsAlerts(m.decisionSupport, models.CdsAlert{RuleID: 3, PatientID: 2,
+			Severity: constants.CdsSeverity.BLOCKING})
+
+		order, err := service.EnterResults(1, models.EnterLabResultsInput{
+			Results: []models.LabResultEntryInput{{LabTestID: 4, NumericValue: floatPtr(4.8)}},
+		}, 7)
+
+		assert.Nil(t, order)
+		var blocked *CdsAlertError
+		assert.ErrorAs(t, err, &blocked)
+		m.labOrderRepo.AssertNotCalled(t, "SaveResults", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ClinicalAlertOverride", func(t *testing.T) {
+		service, m := newLabOrderServiceWithMocks()
+
+		patient := &models.Patient{Model: gorm.Model{ID: 2}, Gender: "female", Genotype: "SS",
+			DateOfBirth: time.Date(1990, 5, 1, 0, 0, 0, 0, time.UTC)}
+		earlier := models.CdsAlert{Model: gorm.Model{ID: 30}, RuleID: 3, PatientID: 2,
+			Severity: constants.CdsSeverity.BLOCKING}
+
+		m.labOrderRepo.On("FindByID", uint(1)).Return(newCollectedOrder(), nil)
+		m.patientRepo.On("FindByID", uint(2)).Return(patient, nil)
+		raiseCdsAlerts(m.decisionSupport, earlier)
+		m.labOrderRepo.On("SaveResults", mock.AnythingOfType("*models.LabOrder"),
+			mock.AnythingOfType("models.ClinicalRecordWrites")).Return(nil).Run(func(args mock.Arguments) {
+			writes := args.Get(1).(models.ClinicalRecordWrites)
+			assert.Empty(t, writes.NewAlerts)
+			assert.Len(t, writes.Acknowledged, 1)
+			assert.Equal(t, uint(7), *writes.Acknowledged[0].AcknowledgedBy)
+			assert.Equal(t, "Transfusion already arranged", writes.Acknowledged[0].AcknowledgementReason)
+		})
+
+		order, err := service.EnterResults(1, models.EnterLabResultsInput{
+			Results:             []models.LabResultEntryInput{{LabTestID: 4, NumericValue: floatPtr(4.8)}},
+			AlertOverrideReason: "Transfusion already arranged",
+		}, 7)
+
+		assert.NoError(t, err)
+		assert.Len(t, order.Alerts, 1)
+		m.labOrderRepo.AssertExpectations(t)
+	})
+
+	t.Run("TestNotOnOrder", func(t *testing.T) {
+		service, m := newLabOrderServiceWithMocks()
+
+		m.labOrderRepo.On("FindByID", uint(1)).Return(newCollectedOrder(), nil)
+		m.patientRepo.On("FindByID", uint(2)).Return(&models.Patient{Model: gorm.Model{ID: 2}}, nil)
+
+		order, err := service.EnterResults(1, models.EnterLabResultsInput{
+			Results: []models.LabResultEntryInput{{LabTestID: 6, NumericValue: floatPtr(1)}},
+		}, 7)
+
+		assert.Nil(t, order)
+		assert.EqualError(t, err, "lab test 6 is not part of this order")
+		m.labOrderRepo.AssertNotCalled(t, "SaveResults", mock.Anything, mock.Anything)
+	})
+
+	t.Run("SampleNotCollected", func(t *testing.T) {
+		service, m := newLabOrderServiceWithMocks()
+
+		m.labOrderRepo.On("FindByID", uint(1)).Return(&models.LabOrder{
+			Model:  gorm.Model{ID: 1},
+			Status: constants.LabOrderStatus.ORDERED,
+		}, nil)
+
+		order, err := service.EnterResults(1, models.EnterLabResultsInput{}, 7)
+
+		assert.Nil(t, order)
+		assert.EqualError(t, err, "lab order is ordered and cannot be updated")
+	})
+}
+
+func TestFlagLabValue(t *testing.T) {
+	referenceRange := &models.LabReferenceRange{Low: floatPtr(3.5), High: floatPtr(5.1),
+		CriticalLow: floatPtr(2.5), CriticalHigh: floatPtr(6.5)}
+
+	assert.Equal(t, constants.LabResultFlag.CRITICAL_LOW, FlagLabValue(2.4, referenceRange))
+	assert.Equal(t, constants.LabResultFlag.LOW, FlagLabValue(3.0, referenceRange))
+	assert.Equal(t, constants.LabResultFlag.NORMAL, FlagLabValue(4.2, referenceRange))
+	assert.Equal(t, constants.LabResultFlag.HIGH, FlagLabValue(5.9, referenceRange))
+	assert.Equal(t, constants.LabResultFlag.CRITICAL_HIGH, FlagLabValue(7.0, referenceRange))
+	assert.Equal(t, "", FlagLabValue(7.0, nil))
+}
+
+func TestAcknowledgeLabResults(t *testing.T) {
+	t.Run("OnlyOrderingDoctor", func(t *testing.T) {
+		service, m := newLabOrderServiceWithMocks()
+
+		m.labOrderRepo.On("FindByID", uint(1)).Return(&models.LabOrder{
+			Model:     gorm.Model{ID: 1},
+			OrderedBy: 5,
+			Status:    constants.LabOrderStatus.VERIFIED,
+		}, nil)
+
+		order, err := service.AcknowledgeResults(1, 6)
+
+		assert.Nil(t, order)
+		assert.EqualError(t, err, "only the ordering doctor can acknowledge these results")
+		m.labOrderRepo.AssertNotCalled(t, "Update")
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		service, m := newLabOrderServiceWithMocks()
+
+		m.labOrderRepo.On("FindByID", uint(1)).Return(&models.LabOrder{
+			Model:     gorm.Model{ID: 1},
+			OrderedBy: 5,
+			Status:    constants.LabOrderStatus.RESULTED,
+		}, nil)
+		m.labOrderRepo.On("Update", mock.AnythingOfType("*models.LabOrder")).Return(nil)
+
+		order, err := service.AcknowledgeResults(1, 5)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, order.ReviewedAt)
+	})
+}
+
+func TestCancelLabOrder(t *testing.T) {
+	t.Run("AlreadyResulted", func(t *testing.T) {
+		service, m := newLabOrderServiceWithMocks()
+
+		m.labOrderRepo.On("FindByID", uint(1)).Return(&models.LabOrder{
+			Model:  gorm.Model{ID: 1},
+			Status: constants.LabOrderStatus.RESULTED,
+		}, nil)
+
+		order, err := service.CancelLabOrder(1, models.CancelLabOrderInput{Reason: "duplicate"}, 5)
+
+		assert.Nil(t, order)
+		assert.Error(t, err)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		service, m := newLabOrderServiceWithMocks()
+
+		m.labOrderRepo.On("FindByID", uint(1)).Return(&models.LabOrder{}, errors.New("record not found"))
+
+		order, err := service.CancelLabOrder(1, models.CancelLabOrderInput{Reason: "duplicate"}, 5)
+
+		assert.Nil(t, order)
+		assert.EqualError(t, err, "lab order not found")
+	})
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+)
+
+type LabTestService interface {
+	CreateLabTest(input models.CreateLabTestInput) (*models.LabTest, error)
+	GetAllLabTests(activeOnly bool) ([]models.LabTest, error)
+	GetLabTestByID(id uint) (*models.LabTest, error)
+	UpdateLabTest(id uint, input models.UpdateLabTestInput) (*models.LabTest, error)
+}
+
+type labTestService struct {
+	labTestRepository repositories.LabTestRepository
+}
+
+func NewLabTestService(labTestRepository repositories.LabTestRepository) LabTestService {
+	return &labTestService{labTestRepository: labTestRepository}
+}
+
+func (ls *labTestService) CreateLabTest(input models.CreateLabTestInput) (*models.LabTest, error) {
+	ranges, err := buildReferenceRanges(input.ReferenceRanges)
+	if err != nil {
+		return nil, err
+	}
+
+	resultType := input.ResultType
+	if resultType == "" {
+		resultType = constants.LabResultType.NUMERIC
+	}
+
+	labTest := &models.LabTest{
+		Code:            strings.ToUpper(strings.TrimSpace(input.Code)),
+		Name:            strings.TrimSpace(input.Name),
+		SampleType:      strings.ToLower(strings.TrimSpace(input.SampleType)),
+		ResultType:      resultType,
+		Unit:            input.Unit,
+		IsActive:        true,
+		ReferenceRanges: ranges,
+	}
+
+	if err := ls.labTestRepository.Create(labTest); err != nil {
+		return nil, err
+	}
+
+	return labTest, nil
+}
+
+func (ls *labTestService) GetAllLabTests(activeOnly bool) ([]models.LabTest, error) {
+	return ls.labTestRepository.FindAll(activeOnly)
+}
+
+func (ls *labTestService) GetLabTestByID(id uint) (*models.LabTest, error) {
+	return ls.labTestRepository.FindByID(id)
+}
+
+func (ls *labTestService) UpdateLabTest(id uint, input models.UpdateLabTestInput) (*models.LabTest, error) {
+	labTest, err := ls.labTestRepository.FindByID(id)
+	if err != nil {
+		return nil, errors.New("lab test not found")
+	}
+
+	if input.Name != nil {
+		labTest.Name = strings.TrimSpace(*input.Name)
+	}
+	if input.SampleType != nil {
+		labTest.SampleType = strings.ToLower(strings.TrimSpace(*input.SampleType))
+	}
+	if input.Unit != nil {
+		labTest.Unit = *input.Unit
+	}
+	if input.IsActive != nil {
+		labTest.IsActive = *input.IsActive
+	}
+
+	var ranges []models.LabReferenceRange
+	if input.ReferenceRanges != nil {
+		ranges, err = buildReferenceRanges(*input.ReferenceRanges)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := ls.labTestRepository.Update(labTest); err != nil {
+		return nil, err
+	}
+
+	if input.ReferenceRanges != nil {
+		if err := ls.labTestRepository.ReplaceReferenceRanges(labTest.ID, ranges); err != nil {
+			return nil, err
+		}
+		labTest.ReferenceRanges = ranges
+	}
+
+	return labTest, nil
+}
+
+func buildReferenceRanges(inputs []models.LabReferenceRangeInput) ([]models.LabReferenceRange, error) {
+	ranges := make([]models.LabReferenceRange, 0, len(inputs))
+	for _, input := range inputs {
+		if input.Low != nil && input.High != nil && *input.Low > *input.High {
+			return nil, errors.New("reference range low value cannot exceed high value")
+		}
+		ranges = append(ranges, models.LabReferenceRange{
+			Sex:          input.Sex,
+			MinAgeDays:   input.MinAgeDays,
+			MaxAgeDays:   input.MaxAgeDays,
+			Low:          input.Low,
+			High:         input.High,
+			CriticalLow:  input.CriticalLow,
+			CriticalHigh: input.CriticalHigh,
+		})
+	}
+	return ranges, nil
+}
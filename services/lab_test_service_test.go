@@ -0,0 +1,73 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestCreateLabTest(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockLabTestRepo := new(mocks.LabTestRepository)
+		service := NewLabTestService(mockLabTestRepo)
+
+		mockLabTestRepo.On("Create", mock.AnythingOfType("*models.LabTest")).Return(nil)
+
+		labTest, err := service.CreateLabTest(models.CreateLabTestInput{
+			Code:       " fbs ",
+			Name:       "Fasting Blood Sugar",
+			SampleType: "Blood",
+			Unit:       "mmol/L",
+			ReferenceRanges: []models.LabReferenceRangeInput{
+				{Low: floatPtr(3.9), High: floatPtr(5.6)},
+			},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "FBS", labTest.Code)
+		assert.Equal(t, "blood", labTest.SampleType)
+		assert.Equal(t, constants.LabResultType.NUMERIC, labTest.ResultType)
+		assert.Len(t, labTest.ReferenceRanges, 1)
+	})
+
+	t.Run("InvertedRange", func(t *testing.T) {
+		mockLabTestRepo := new(mocks.LabTestRepository)
+		service := NewLabTestService(mockLabTestRepo)
+
+		labTest, err := service.CreateLabTest(models.CreateLabTestInput{
+			Code: "K",
+			Name: "Potassium",
+			ReferenceRanges: []models.LabReferenceRangeInput{
+				{Low: floatPtr(5.1), High: floatPtr(3.5)},
+			},
+		})
+
+		assert.Nil(t, labTest)
+		assert.EqualError(t, err, "reference range low value cannot exceed high value")
+		mockLabTestRepo.AssertNotCalled(t, "Create")
+	})
+}
+
+func TestUpdateLabTest(t *testing.T) {
+	t.Run("ReplacesReferenceRanges", func(t *testing.T) {
+		mockLabTestRepo := new(mocks.LabTestRepository)
+		service := NewLabTestService(mockLabTestRepo)
+
+		ranges := []models.LabReferenceRangeInput{{Sex: "male", Low: floatPtr(13.5), High: floatPtr(17.5)}}
+
+		mockLabTestRepo.On("FindByID", uint(4)).Return(&models.LabTest{Model: gorm.Model{ID: 4}}, nil)
+		mockLabTestRepo.On("Update", mock.AnythingOfType("*models.LabTest")).Return(nil)
+		mockLabTestRepo.On("ReplaceReferenceRanges", uint(4), mock.AnythingOfType("[]models.LabReferenceRange")).Return(nil)
+
+		labTest, err := service.UpdateLabTest(4, models.UpdateLabTestInput{ReferenceRanges: &ranges})
+
+		assert.NoError(t, err)
+		assert.Len(t, labTest.ReferenceRanges, 1)
+		mockLabTestRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+)
+
+// marPrescriptionStatuses are the prescriptions that can have doses on the
+// medication administration record. Discontinued ones keep the doses that
+// were scheduled before they were stopped.
+var marPrescriptionStatuses = []string{
+	constants.PrescriptionStatus.ACTIVE,
+	constants.PrescriptionStatus.DISPENSED,
+	constants.PrescriptionStatus.DISCONTINUED,
+}
+
+// courseWindow narrows [from, to) to the part of the prescription's course
+// inside it. A discontinued course ends when it was stopped.
+func courseWindow(prescription models.Prescription, from, to time.Time) (time.Time, time.Time) {
+	start := prescription.CreatedAt
+	end := prescription.CreatedAt.AddDate(0, 0, prescription.DurationDays)
+	if prescription.Status == constants.PrescriptionStatus.DISCONTINUED &&
+		prescription.StatusChangedAt != nil && prescription.StatusChangedAt.Before(end) {
+		end = *prescription.StatusChangedAt
+	}
+
+	if from.After(start) {
+		start = from
+	}
+	if to.Before(end) {
+		end = to
+	}
+	return start, end
+}
+
+// doseTimes lists the prescription's scheduled doses in [from, to) at the
+// standard administration times for its frequency, in the hospital's local
+// time. A STAT dose is scheduled when it was prescribed.
+func doseTimes(prescription models.Prescription, from, to time.Time) []time.Time {
+	var times []time.Time
+	start, end := courseWindow(prescription, from, to)
+	if !start.Before(end) {
+		return times
+	}
+
+	if prescription.Frequency == constants.DoseFrequency.STAT {
+		if !prescription.CreatedAt.Before(start) && prescription.CreatedAt.Before(end) {
+			times = append(times, prescription.CreatedAt)
+		}
+		return times
+	}
+
+	hours := constants.DoseScheduleHours[prescription.Frequency]
+	year, month, day := start.In(time.Local).Date()
+	for date := time.Date(year, month, day, 0, 0, 0, 0, time.Local); date.Before(end); date = date.AddDate(0, 0, 1) {
+		for _, hour := range hours {
+			doseTime := time.Date(date.Year(), date.Month(), date.Day(), hour, 0, 0, 0, time.Local)
+			if !doseTime.Before(start) && doseTime.Before(end) {
+				times = append(times, doseTime)
+			}
+		}
+	}
+	return times
+}
+
+type scheduledDose struct {
+	prescriptionID uint
+	at             int64
+}
+
+// buildMarEntries lays out the scheduled doses of each prescription in
+// [from, to) and matches them with the doses charted against them. Doses not
+// yet charted are upcoming, due, or overdue once more than overdueWindow late.
+func buildMarEntries(prescriptions []models.Prescription, administrations []models.MedicationAdministration,
+	from, to, now time.Time, overdueWindow time.Duration) []models.MarEntry {
+	charted := make(map[scheduledDose]*models.MedicationAdministration)
+	unscheduled := make(map[uint][]models.MedicationAdministration)
+	for i := range administrations {
+		administration := &administrations[i]
+		if administration.ScheduledAt != nil {
+			charted[scheduledDose{administration.PrescriptionID, administration.ScheduledAt.Unix()}] = administration
+		} else if !administration.AdministeredAt.Before(from) && administration.AdministeredAt.Before(to) {
+			unscheduled[administration.PrescriptionID] = append(unscheduled[administration.PrescriptionID], *administration)
+		}
+	}
+
+	entries := []models.MarEntry{}
+	for _, prescription := range prescriptions {
+		start, end := courseWindow(prescription, from, to)
+		if !start.Before(end) && len(unscheduled[prescription.ID]) == 0 {
+			continue
+		}
+
+		entry := models.MarEntry{
+			Prescription: prescription,
+			Doses:        []models.MarDose{},
+			Unscheduled:  []models.MedicationAdministration{},
+		}
+		entry.Unscheduled = append(entry.Unscheduled, unscheduled[prescription.ID]...)
+
+		for _, doseTime := range doseTimes(prescription, from, to) {
+			dose := models.MarDose{ScheduledAt: doseTime}
+			if administration, ok := charted[scheduledDose{prescription.ID, doseTime.Unix()}]; ok {
+				dose.Status = administration.Status
+				dose.Administration = administration
+			} else {
+				dose.Status = unchartedDoseStatus(doseTime, now, overdueWindow)
+			}
+			entry.Doses = append(entry.Doses, dose)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func unchartedDoseStatus(scheduledAt, now time.Time, overdueWindow time.Duration) string {
+	switch {
+	case scheduledAt.After(now):
+		return constants.MarDoseStatus.UPCOMING
+	case now.Sub(scheduledAt) > overdueWindow:
+		return constants.MarDoseStatus.OVERDUE
+	default:
+		return constants.MarDoseStatus.DUE
+	}
+}
+
+func doseDescription(prescription models.Prescription) string {
+	name := "medication"
+	if prescription.Medication != nil {
+		name = prescription.Medication.Name
+	}
+	return fmt.Sprintf("Give %s %g %s %s (%s)", name, prescription.DoseAmount,
+		prescription.DoseUnit, prescription.Route, prescription.Frequency)
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+)
+
+type MedicationService interface {
+	CreateMedication(input models.CreateMedicationInput) (*models.Medication, error)
+	GetAllMedications(query string, activeOnly bool) ([]models.Medication, error)
+	GetMedicationByID(id uint) (*models.Medication, error)
+	UpdateMedication(id uint, input models.UpdateMedicationInput) (*models.Medication, error)
+}
+
+type medicationService struct {
+	medicationRepository repositories.MedicationRepository
+}
+
+func NewMedicationService(medicationRepository repositories.MedicationRepository) MedicationService {
+	return &medicationService{medicationRepository: medicationRepository}
+}
+
+func (ms *medicationService) CreateMedication(input models.CreateMedicationInput) (*models.Medication, error) {
+	medication := &models.Medication{
+		Name:        strings.TrimSpace(input.Name),
+		GenericName: strings.TrimSpace(input.GenericName),
+		DrugClass:   strings.TrimSpace(input.DrugClass),
+		Form:        strings.ToLower(strings.TrimSpace(input.Form)),
+		Strength:    strings.TrimSpace(input.Strength),
+		IsActive:    true,
+
+		ReorderLevel: input.ReorderLevel,
+	}
+
+	if err := ms.medicationRepository.Create(medication); err != nil {
+		return nil, err
+	}
+
+	return medication, nil
+}
+
+func (ms *medicationService) GetAllMedications(query string, activeOnly bool) ([]models.Medication, error) {
+	return ms.medicationRepository.FindAll(strings.TrimSpace(query), activeOnly)
+}
+
+func (ms *medicationService) GetMedicationByID(id uint) (*models.Medication, error) {
+	return ms.medicationRepository.FindByID(id)
+}
+
+func (ms *medicationService) UpdateMedication(id uint, input models.UpdateMedicationInput) (*models.Medication, error) {
+	medication, err := ms.medicationRepository.FindByID(id)
+	if err != nil {
+		return nil, errors.New("medication not found")
+	}
+
+	if input.Name != nil {
+		medication.Name = strings.TrimSpace(*input.Name)
+	}
+	if input.GenericName != nil {
+		medication.GenericName = strings.TrimSpace(*input.GenericName)
+	}
+	if input.DrugClass != nil {
+		medication.DrugClass = strings.TrimSpace(*input.DrugClass)
+	}
+	if input.Form != nil {
+		medication.Form = strings.ToLower(strings.TrimSpace(*input.Form))
+	}
+	if input.Strength != nil {
+		medication.Strength = strings.TrimSpace(*input.Strength)
+	}
+	if input.IsActive != nil {
+		medication.IsActive = *input.IsActive
+	}
+	if input.ReorderLevel != nil {
+		medication.ReorderLevel = *input.ReorderLevel
+	}
+
+	if err := ms.medicationRepository.Update(medication); err != nil {
+		return nil, err
+	}
+
+	return medication, nil
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestCreateMedication(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockMedicationRepo := new(mocks.MedicationRepository)
+		service := NewMedicationService(mockMedicationRepo)
+
+		input := models.CreateMedicationInput{
+			Name:        " Amoxil ",
+			GenericName: "Amoxicillin",
+			DrugClass:   "Penicillins",
+			Form:        "Capsule",
+			Strength:    "500mg",
+		}
+
+		mockMedicationRepo.On("Create", mock.AnythingOfType("*models.Medication")).Return(nil).Run(func(args mock.Arguments) {
+			medication := args.Get(0).(*models.Medication)
+			assert.Equal(t, "Amoxil", medication.Name)
+			assert.Equal(t, "capsule", medication.Form)
+			assert.True(t, medication.IsActive)
+		})
+
+		result, err := service.CreateMedication(input)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		mockMedicationRepo.AssertExpectations(t)
+	})
+
+	t.Run("RepositoryError", func(t *testing.T) {
+		mockMedicationRepo := new(mocks.MedicationRepository)
+		service := NewMedicationService(mockMedicationRepo)
+
+		mockMedicationRepo.On("Create", mock.AnythingOfType("*models.Medication")).Return(errors.New("duplicate key"))
+
+		result, err := service.CreateMedication(models.CreateMedicationInput{Name: "Amoxil", Form: "capsule"})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Equal(t, "duplicate key", err.Error())
+	})
+}
+
+func TestUpdateMedication(t *testing.T) {
+	t.Run("Deactivate", func(t *testing.T) {
+		mockMedicationRepo := new(mocks.MedicationRepository)
+		service := NewMedicationService(mockMedicationRepo)
+
+		existing := &models.Medication{Model: gorm.Model{ID: 1}, Name: "Amoxil", IsActive: true}
+		inactive := false
+
+		mockMedicationRepo.On("FindByID", uint(1)).Return(existing, nil)
+		mockMedicationRepo.On("Update", mock.AnythingOfType("*models.Medication")).Return(nil)
+
+		result, err := service.UpdateMedication(1, models.UpdateMedicationInput{IsActive: &inactive})
+
+		assert.NoError(t, err)
+		assert.False(t, result.IsActive)
+		mockMedicationRepo.AssertExpectations(t)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockMedicationRepo := new(mocks.MedicationRepository)
+		service := NewMedicationService(mockMedicationRepo)
+
+		mockMedicationRepo.On("FindByID", uint(1)).Return(&models.Medication{}, errors.New("not found"))
+
+		result, err := service.UpdateMedication(1, models.UpdateMedicationInput{})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Equal(t, "medication not found", err.Error())
+		mockMedicationRepo.AssertNotCalled(t, "Update")
+	})
+}
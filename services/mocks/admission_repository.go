@@ -0,0 +1,40 @@
+package mocks
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type AdmissionRepository struct {
+	mock.Mock
+}
+
+func (m *AdmissionRepository) Admit(admission *models.Admission, assignedBy uint) error {
+	args := m.Called(admission, assignedBy)
+	return args.Error(0)
+}
+
+func (m *AdmissionRepository) FindByID(id uint) (*models.Admission, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.Admission), args.Error(1)
+}
+
+func (m *AdmissionRepository) FindActiveByPatientID(patientID uint) (*models.Admission, error) {
+	args := m.Called(patientID)
+	return args.Get(0).(*models.Admission), args.Error(1)
+}
+
+func (m *AdmissionRepository) FindAll(query models.AdmissionQuery) ([]models.Admission, int64, error) {
+	args := m.Called(query)
+	return args.Get(0).([]models.Admission), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *AdmissionRepository) Transfer(admission *models.Admission, bed *models.Bed, reason string, assignedBy uint) error {
+	args := m.Called(admission, bed, reason, assignedBy)
+	return args.Error(0)
+}
+
+func (m *AdmissionRepository) Discharge(admission *models.Admission) error {
+	args := m.Called(admission)
+	return args.Error(0)
+}
@@ -0,0 +1,30 @@
+package mocks
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type AllergyRepository struct {
+	mock.Mock
+}
+
+func (m *AllergyRepository) Create(allergy *models.PatientAllergy) error {
+	args := m.Called(allergy)
+	return args.Error(0)
+}
+
+func (m *AllergyRepository) FindByID(id uint) (*models.PatientAllergy, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.PatientAllergy), args.Error(1)
+}
+
+func (m *AllergyRepository) FindByPatientID(patientID uint) ([]models.PatientAllergy, error) {
+	args := m.Called(patientID)
+	return args.Get(0).([]models.PatientAllergy), args.Error(1)
+}
+
+func (m *AllergyRepository) Update(allergy *models.PatientAllergy) error {
+	args := m.Called(allergy)
+	return args.Error(0)
+}
@@ -0,0 +1,20 @@
+package mocks
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type AuditLogRepository struct {
+	mock.Mock
+}
+
+func (m *AuditLogRepository) Create(entry *models.AuditLog) error {
+	args := m.Called(entry)
+	return args.Error(0)
+}
+
+func (m *AuditLogRepository) FindAll(filters map[string]interface{}) ([]models.AuditLog, error) {
+	args := m.Called(filters)
+	return args.Get(0).([]models.AuditLog), args.Error(1)
+}
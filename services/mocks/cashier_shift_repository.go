@@ -0,0 +1,35 @@
+package mocks
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type CashierShiftRepository struct {
+	mock.Mock
+}
+
+func (m *CashierShiftRepository) Create(shift *models.CashierShift) error {
+	args := m.Called(shift)
+	return args.Error(0)
+}
+
+func (m *CashierShiftRepository) FindByID(id uint) (*models.CashierShift, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.CashierShift), args.Error(1)
+}
+
+func (m *CashierShiftRepository) FindOpenByStaffID(staffID uint) (*models.CashierShift, error) {
+	args := m.Called(staffID)
+	return args.Get(0).(*models.CashierShift), args.Error(1)
+}
+
+func (m *CashierShiftRepository) FindAll(query models.CashierShiftQuery) ([]models.CashierShift, int64, error) {
+	args := m.Called(query)
+	return args.Get(0).([]models.CashierShift), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *CashierShiftRepository) Close(shift *models.CashierShift) error {
+	args := m.Called(shift)
+	return args.Error(0)
+}
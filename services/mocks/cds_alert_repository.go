@@ -0,0 +1,35 @@
+package mocks
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type CdsAlertRepository struct {
+	mock.Mock
+}
+
+func (m *CdsAlertRepository) Create(alert *models.CdsAlert) error {
+	args := m.Called(alert)
+	return args.Error(0)
+}
+
+func (m *CdsAlertRepository) FindByID(id uint) (*models.CdsAlert, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.CdsAlert), args.Error(1)
+}
+
+func (m *CdsAlertRepository) FindAll(query models.CdsAlertQuery) ([]models.CdsAlert, int64, error) {
+	args := m.Called(query)
+	return args.Get(0).([]models.CdsAlert), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *CdsAlertRepository) FindByEntity(trigger string, entityID uint) ([]models.CdsAlert, error) {
+	args := m.Called(trigger, entityID)
+	return args.Get(0).([]models.CdsAlert), args.Error(1)
+}
+
+func (m *CdsAlertRepository) Acknowledge(alert *models.CdsAlert) error {
+	args := m.Called(alert)
+	return args.Error(0)
+}
@@ -0,0 +1,35 @@
+package mocks
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type CdsRuleRepository struct {
+	mock.Mock
+}
+
+func (m *CdsRuleRepository) Create(rule *models.CdsRule) error {
+	args := m.Called(rule)
+	return args.Error(0)
+}
+
+func (m *CdsRuleRepository) FindByID(id uint) (*models.CdsRule, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.CdsRule), args.Error(1)
+}
+
+func (m *CdsRuleRepository) FindAll(query models.CdsRuleQuery) ([]models.CdsRule, error) {
+	args := m.Called(query)
+	return args.Get(0).([]models.CdsRule), args.Error(1)
+}
+
+func (m *CdsRuleRepository) FindActiveByTrigger(trigger string) ([]models.CdsRule, error) {
+	args := m.Called(trigger)
+	return args.Get(0).([]models.CdsRule), args.Error(1)
+}
+
+func (m *CdsRuleRepository) Update(rule *models.CdsRule) error {
+	args := m.Called(rule)
+	return args.Error(0)
+}
@@ -0,0 +1,20 @@
+package mocks
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type ClaimLayoutRepository struct {
+	mock.Mock
+}
+
+func (m *ClaimLayoutRepository) FindByPayerID(payerID uint) (*models.ClaimLayout, error) {
+	args := m.Called(payerID)
+	return args.Get(0).(*models.ClaimLayout), args.Error(1)
+}
+
+func (m *ClaimLayoutRepository) Save(layout *models.ClaimLayout) error {
+	args := m.Called(layout)
+	return args.Error(0)
+}
@@ -0,0 +1,42 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type ClaimRepository struct {
+	mock.Mock
+}
+
+func (m *ClaimRepository) CreateBatch(batch *models.ClaimBatch) error {
+	args := m.Called(batch)
+	return args.Error(0)
+}
+
+func (m *ClaimRepository) FindBatchByID(id uint) (*models.ClaimBatch, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.ClaimBatch), args.Error(1)
+}
+
+func (m *ClaimRepository) FindBatches(query models.ClaimBatchQuery) ([]models.ClaimBatch, int64, error) {
+	args := m.Called(query)
+	return args.Get(0).([]models.ClaimBatch), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *ClaimRepository) UpdateBatch(batch *models.ClaimBatch) error {
+	args := m.Called(batch)
+	return args.Error(0)
+}
+
+func (m *ClaimRepository) SaveReconciliation(batch *models.ClaimBatch) error {
+	args := m.Called(batch)
+	return args.Error(0)
+}
+
+func (m *ClaimRepository) FindClaimableInvoices(payerID uint, from time.Time, to time.Time) ([]models.Invoice, error) {
+	args := m.Called(payerID, from, to)
+	return args.Get(0).([]models.Invoice), args.Error(1)
+}
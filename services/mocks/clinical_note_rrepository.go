@@ -9,8 +9,9 @@ type ClinicalNoteRepository struct {
 	mock.Mock
 }
 
-func (m *ClinicalNoteRepository) Create(note *models.ClinicalNote) error {
-	args := m.Called(note)
+func (m *ClinicalNoteRepository) Create(note *models.ClinicalNote, completedAppointment *models.Appointment,
+	writes models.ClinicalRecordWrites) error {
+	args := m.Called(note, completedAppointment, writes)
 	return args.Error(0)
 }
 
@@ -29,8 +30,8 @@ func (m *ClinicalNoteRepository) FindByPatientID(patientID uint) ([]models.Clini
 	return args.Get(0).([]models.ClinicalNote), args.Error(1)
 }
 
-func (m *ClinicalNoteRepository) Update(note *models.ClinicalNote) error {
-	args := m.Called(note)
+func (m *ClinicalNoteRepository) Update(note *models.ClinicalNote, writes models.ClinicalRecordWrites) error {
+	args := m.Called(note, writes)
 	return args.Error(0)
 }
 
@@ -38,3 +39,14 @@ func (m *ClinicalNoteRepository) Delete(id uint) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
+
+func (m *ClinicalNoteRepository) UpdateWithDiagnoses(note *models.ClinicalNote, diagnoses []models.NoteDiagnosis,
+	writes models.ClinicalRecordWrites) error {
+	args := m.Called(note, diagnoses, writes)
+	return args.Error(0)
+}
+
+func (m *ClinicalNoteRepository) FindByAdmissionID(admissionID uint) ([]models.ClinicalNote, error) {
+	args := m.Called(admissionID)
+	return args.Get(0).([]models.ClinicalNote), args.Error(1)
+}
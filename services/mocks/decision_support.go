@@ -0,0 +1,15 @@
+package mocks
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type DecisionSupport struct {
+	mock.Mock
+}
+
+func (m *DecisionSupport) Evaluate(event models.CdsEvent) ([]models.CdsAlert, error) {
+	args := m.Called(event)
+	return args.Get(0).([]models.CdsAlert), args.Error(1)
+}
@@ -0,0 +1,25 @@
+package mocks
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type DiagnosisCodeRepository struct {
+	mock.Mock
+}
+
+func (m *DiagnosisCodeRepository) Upsert(codes []models.DiagnosisCode) error {
+	args := m.Called(codes)
+	return args.Error(0)
+}
+
+func (m *DiagnosisCodeRepository) FindByCode(code string) (*models.DiagnosisCode, error) {
+	args := m.Called(code)
+	return args.Get(0).(*models.DiagnosisCode), args.Error(1)
+}
+
+func (m *DiagnosisCodeRepository) Search(query string, limit int) ([]models.DiagnosisCode, error) {
+	args := m.Called(query, limit)
+	return args.Get(0).([]models.DiagnosisCode), args.Error(1)
+}
@@ -0,0 +1,20 @@
+package mocks
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type DispensationRepository struct {
+	mock.Mock
+}
+
+func (m *DispensationRepository) Dispense(dispensation *models.Dispensation, prescription *models.Prescription) error {
+	args := m.Called(dispensation, prescription)
+	return args.Error(0)
+}
+
+func (m *DispensationRepository) FindByPrescriptionID(prescriptionID uint) ([]models.Dispensation, error) {
+	args := m.Called(prescriptionID)
+	return args.Get(0).([]models.Dispensation), args.Error(1)
+}
@@ -0,0 +1,12 @@
+package mocks
+
+import "github.com/stretchr/testify/mock"
+
+type DocumentScanner struct {
+	mock.Mock
+}
+
+func (m *DocumentScanner) Scan(fileName string, content []byte) error {
+	args := m.Called(fileName, content)
+	return args.Error(0)
+}
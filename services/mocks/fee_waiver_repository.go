@@ -0,0 +1,40 @@
+package mocks
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type FeeWaiverRepository struct {
+	mock.Mock
+}
+
+func (m *FeeWaiverRepository) Create(waiver *models.FeeWaiver, entry *models.AuditLog) error {
+	args := m.Called(waiver, entry)
+	return args.Error(0)
+}
+
+func (m *FeeWaiverRepository) FindByID(id uint) (*models.FeeWaiver, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.FeeWaiver), args.Error(1)
+}
+
+func (m *FeeWaiverRepository) FindAll(query models.FeeWaiverQuery) ([]models.FeeWaiver, int64, error) {
+	args := m.Called(query)
+	return args.Get(0).([]models.FeeWaiver), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *FeeWaiverRepository) FindPendingByInvoiceID(invoiceID uint) (*models.FeeWaiver, error) {
+	args := m.Called(invoiceID)
+	return args.Get(0).(*models.FeeWaiver), args.Error(1)
+}
+
+func (m *FeeWaiverRepository) RecordApproval(waiver *models.FeeWaiver, entry *models.AuditLog) error {
+	args := m.Called(waiver, entry)
+	return args.Error(0)
+}
+
+func (m *FeeWaiverRepository) UpdateStatus(waiver *models.FeeWaiver, entry *models.AuditLog) error {
+	args := m.Called(waiver, entry)
+	return args.Error(0)
+}
@@ -0,0 +1,20 @@
+package mocks
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type GrowthMeasurementRepository struct {
+	mock.Mock
+}
+
+func (m *GrowthMeasurementRepository) Create(measurement *models.GrowthMeasurement) error {
+	args := m.Called(measurement)
+	return args.Error(0)
+}
+
+func (m *GrowthMeasurementRepository) FindByPatientID(patientID uint) ([]models.GrowthMeasurement, error) {
+	args := m.Called(patientID)
+	return args.Get(0).([]models.GrowthMeasurement), args.Error(1)
+}
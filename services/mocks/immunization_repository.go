@@ -0,0 +1,27 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type ImmunizationRepository struct {
+	mock.Mock
+}
+
+func (m *ImmunizationRepository) Create(immunization *models.Immunization) error {
+	args := m.Called(immunization)
+	return args.Error(0)
+}
+
+func (m *ImmunizationRepository) FindByPatientID(patientID uint) ([]models.Immunization, error) {
+	args := m.Called(patientID)
+	return args.Get(0).([]models.Immunization), args.Error(1)
+}
+
+func (m *ImmunizationRepository) FindOverdue(vaccineCode string, at time.Time, page models.PageQuery) ([]models.OverdueImmunization, int64, error) {
+	args := m.Called(vaccineCode, at, page)
+	return args.Get(0).([]models.OverdueImmunization), args.Get(1).(int64), args.Error(2)
+}
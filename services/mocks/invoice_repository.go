@@ -0,0 +1,40 @@
+package mocks
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type InvoiceRepository struct {
+	mock.Mock
+}
+
+func (m *InvoiceRepository) Create(invoice *models.Invoice) error {
+	args := m.Called(invoice)
+	return args.Error(0)
+}
+
+func (m *InvoiceRepository) FindByID(id uint) (*models.Invoice, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.Invoice), args.Error(1)
+}
+
+func (m *InvoiceRepository) FindAll(query models.InvoiceQuery) ([]models.Invoice, int64, error) {
+	args := m.Called(query)
+	return args.Get(0).([]models.Invoice), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *InvoiceRepository) FindOpenByAppointmentID(appointmentID uint) (*models.Invoice, error) {
+	args := m.Called(appointmentID)
+	return args.Get(0).(*models.Invoice), args.Error(1)
+}
+
+func (m *InvoiceRepository) Update(invoice *models.Invoice) error {
+	args := m.Called(invoice)
+	return args.Error(0)
+}
+
+func (m *InvoiceRepository) UpdateAudited(invoice *models.Invoice, entry *models.AuditLog) error {
+	args := m.Called(invoice, entry)
+	return args.Error(0)
+}
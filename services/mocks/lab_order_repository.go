@@ -0,0 +1,40 @@
+package mocks
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type LabOrderRepository struct {
+	mock.Mock
+}
+
+func (m *LabOrderRepository) Create(order *models.LabOrder) error {
+	args := m.Called(order)
+	return args.Error(0)
+}
+
+func (m *LabOrderRepository) FindAll(filters map[string]interface{}) ([]models.LabOrder, error) {
+	args := m.Called(filters)
+	return args.Get(0).([]models.LabOrder), args.Error(1)
+}
+
+func (m *LabOrderRepository) FindByID(id uint) (*models.LabOrder, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.LabOrder), args.Error(1)
+}
+
+func (m *LabOrderRepository) FindInbox(doctorID uint) ([]models.LabOrder, error) {
+	args := m.Called(doctorID)
+	return args.Get(0).([]models.LabOrder), args.Error(1)
+}
+
+func (m *LabOrderRepository) Update(order *models.LabOrder) error {
+	args := m.Called(order)
+	return args.Error(0)
+}
+
+func (m *LabOrderRepository) SaveResults(order *models.LabOrder, writes models.ClinicalRecordWrites) error {
+	args := m.Called(order, writes)
+	return args.Error(0)
+}
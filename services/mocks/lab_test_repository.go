@@ -0,0 +1,40 @@
+package mocks
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type LabTestRepository struct {
+	mock.Mock
+}
+
+func (m *LabTestRepository) Create(labTest *models.LabTest) error {
+	args := m.Called(labTest)
+	return args.Error(0)
+}
+
+func (m *LabTestRepository) FindAll(activeOnly bool) ([]models.LabTest, error) {
+	args := m.Called(activeOnly)
+	return args.Get(0).([]models.LabTest), args.Error(1)
+}
+
+func (m *LabTestRepository) FindByID(id uint) (*models.LabTest, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.LabTest), args.Error(1)
+}
+
+func (m *LabTestRepository) FindByIDs(ids []uint) ([]models.LabTest, error) {
+	args := m.Called(ids)
+	return args.Get(0).([]models.LabTest), args.Error(1)
+}
+
+func (m *LabTestRepository) Update(labTest *models.LabTest) error {
+	args := m.Called(labTest)
+	return args.Error(0)
+}
+
+func (m *LabTestRepository) ReplaceReferenceRanges(labTestID uint, ranges []models.LabReferenceRange) error {
+	args := m.Called(labTestID, ranges)
+	return args.Error(0)
+}
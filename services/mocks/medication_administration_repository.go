@@ -0,0 +1,37 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MedicationAdministrationRepository struct {
+	mock.Mock
+}
+
+func (m *MedicationAdministrationRepository) Create(administration *models.MedicationAdministration) error {
+	args := m.Called(administration)
+	return args.Error(0)
+}
+
+func (m *MedicationAdministrationRepository) FindByPatientID(patientID uint, page models.PageQuery) ([]models.MedicationAdministration, int64, error) {
+	args := m.Called(patientID, page)
+	return args.Get(0).([]models.MedicationAdministration), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MedicationAdministrationRepository) FindByAdmissionID(admissionID uint) ([]models.MedicationAdministration, error) {
+	args := m.Called(admissionID)
+	return args.Get(0).([]models.MedicationAdministration), args.Error(1)
+}
+
+func (m *MedicationAdministrationRepository) CountByPrescriptionID(prescriptionID uint) (int64, error) {
+	args := m.Called(prescriptionID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MedicationAdministrationRepository) CountByScheduledDose(prescriptionID uint, scheduledAt time.Time) (int64, error) {
+	args := m.Called(prescriptionID, scheduledAt)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,35 @@
+package mocks
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MedicationRepository struct {
+	mock.Mock
+}
+
+func (m *MedicationRepository) Create(medication *models.Medication) error {
+	args := m.Called(medication)
+	return args.Error(0)
+}
+
+func (m *MedicationRepository) FindAll(query string, activeOnly bool) ([]models.Medication, error) {
+	args := m.Called(query, activeOnly)
+	return args.Get(0).([]models.Medication), args.Error(1)
+}
+
+func (m *MedicationRepository) FindByID(id uint) (*models.Medication, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.Medication), args.Error(1)
+}
+
+func (m *MedicationRepository) FindByNames(names []string) ([]models.Medication, error) {
+	args := m.Called(names)
+	return args.Get(0).([]models.Medication), args.Error(1)
+}
+
+func (m *MedicationRepository) Update(medication *models.Medication) error {
+	args := m.Called(medication)
+	return args.Error(0)
+}
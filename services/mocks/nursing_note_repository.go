@@ -0,0 +1,20 @@
+package mocks
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type NursingNoteRepository struct {
+	mock.Mock
+}
+
+func (m *NursingNoteRepository) Create(note *models.NursingNote) error {
+	args := m.Called(note)
+	return args.Error(0)
+}
+
+func (m *NursingNoteRepository) FindByPatientID(patientID uint, page models.PageQuery) ([]models.NursingNote, int64, error) {
+	args := m.Called(patientID, page)
+	return args.Get(0).([]models.NursingNote), args.Get(1).(int64), args.Error(2)
+}
@@ -0,0 +1,35 @@
+package mocks
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type PatientContactRepository struct {
+	mock.Mock
+}
+
+func (m *PatientContactRepository) Create(contact *models.PatientContact) error {
+	args := m.Called(contact)
+	return args.Error(0)
+}
+
+func (m *PatientContactRepository) FindByID(id uint) (*models.PatientContact, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.PatientContact), args.Error(1)
+}
+
+func (m *PatientContactRepository) FindByPatientID(patientID uint) ([]models.PatientContact, error) {
+	args := m.Called(patientID)
+	return args.Get(0).([]models.PatientContact), args.Error(1)
+}
+
+func (m *PatientContactRepository) Update(contact *models.PatientContact) error {
+	args := m.Called(contact)
+	return args.Error(0)
+}
+
+func (m *PatientContactRepository) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
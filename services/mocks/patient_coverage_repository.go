@@ -0,0 +1,30 @@
+package mocks
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type PatientCoverageRepository struct {
+	mock.Mock
+}
+
+func (m *PatientCoverageRepository) Create(coverage *models.PatientCoverage) error {
+	args := m.Called(coverage)
+	return args.Error(0)
+}
+
+func (m *PatientCoverageRepository) FindByID(id uint) (*models.PatientCoverage, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.PatientCoverage), args.Error(1)
+}
+
+func (m *PatientCoverageRepository) FindByPatientID(patientID uint) ([]models.PatientCoverage, error) {
+	args := m.Called(patientID)
+	return args.Get(0).([]models.PatientCoverage), args.Error(1)
+}
+
+func (m *PatientCoverageRepository) Update(coverage *models.PatientCoverage) error {
+	args := m.Called(coverage)
+	return args.Error(0)
+}
@@ -0,0 +1,25 @@
+package mocks
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type PatientDocumentRepository struct {
+	mock.Mock
+}
+
+func (m *PatientDocumentRepository) Create(document *models.PatientDocument) error {
+	args := m.Called(document)
+	return args.Error(0)
+}
+
+func (m *PatientDocumentRepository) FindByID(id uint) (*models.PatientDocument, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.PatientDocument), args.Error(1)
+}
+
+func (m *PatientDocumentRepository) FindByPatientID(patientID uint, category string) ([]models.PatientDocument, error) {
+	args := m.Called(patientID, category)
+	return args.Get(0).([]models.PatientDocument), args.Error(1)
+}
@@ -0,0 +1,30 @@
+package mocks
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type PatientProblemRepository struct {
+	mock.Mock
+}
+
+func (m *PatientProblemRepository) Create(problem *models.PatientProblem) error {
+	args := m.Called(problem)
+	return args.Error(0)
+}
+
+func (m *PatientProblemRepository) FindByID(id uint) (*models.PatientProblem, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.PatientProblem), args.Error(1)
+}
+
+func (m *PatientProblemRepository) FindByPatientID(patientID uint, status string) ([]models.PatientProblem, error) {
+	args := m.Called(patientID, status)
+	return args.Get(0).([]models.PatientProblem), args.Error(1)
+}
+
+func (m *PatientProblemRepository) Update(problem *models.PatientProblem) error {
+	args := m.Called(problem)
+	return args.Error(0)
+}
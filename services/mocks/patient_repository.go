@@ -1,6 +1,8 @@
 package mocks
 
 import (
+	"time"
+
 	"github.com/ofojichigozie/hms-go-backend/models"
 	"github.com/stretchr/testify/mock"
 )
@@ -24,6 +26,11 @@ func (m *PatientRepository) FindByID(id uint) (*models.Patient, error) {
 	return args.Get(0).(*models.Patient), args.Error(1)
 }
 
+func (m *PatientRepository) FindSummaryByID(id uint) (*models.Patient, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.Patient), args.Error(1)
+}
+
 func (m *PatientRepository) FindByRegistrationNumber(regNumber string) (*models.Patient, error) {
 	args := m.Called(regNumber)
 	return args.Get(0).(*models.Patient), args.Error(1)
@@ -38,3 +45,19 @@ func (m *PatientRepository) Delete(id uint) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
+
+func (m *PatientRepository) FindDuplicateCandidates(firstName, lastName string, dateOfBirth time.Time,
+	phone, email string) ([]models.Patient, error) {
+	args := m.Called(firstName, lastName, dateOfBirth, phone, email)
+	return args.Get(0).([]models.Patient), args.Error(1)
+}
+
+func (m *PatientRepository) Merge(survivorID, duplicateID uint, alias *models.PatientAlias, supersededImmunizationIDs []uint) error {
+	args := m.Called(survivorID, duplicateID, alias, supersededImmunizationIDs)
+	return args.Error(0)
+}
+
+func (m *PatientRepository) Search(query models.PatientSearchQuery, dateOfBirth *time.Time) ([]models.Patient, int64, error) {
+	args := m.Called(query, dateOfBirth)
+	return args.Get(0).([]models.Patient), args.Get(1).(int64), args.Error(2)
+}
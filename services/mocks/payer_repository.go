@@ -0,0 +1,45 @@
+package mocks
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type PayerRepository struct {
+	mock.Mock
+}
+
+func (m *PayerRepository) Create(payer *models.Payer) error {
+	args := m.Called(payer)
+	return args.Error(0)
+}
+
+func (m *PayerRepository) FindAll(activeOnly bool) ([]models.Payer, error) {
+	args := m.Called(activeOnly)
+	return args.Get(0).([]models.Payer), args.Error(1)
+}
+
+func (m *PayerRepository) FindByID(id uint) (*models.Payer, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.Payer), args.Error(1)
+}
+
+func (m *PayerRepository) Update(payer *models.Payer) error {
+	args := m.Called(payer)
+	return args.Error(0)
+}
+
+func (m *PayerRepository) CreatePlan(plan *models.InsurancePlan) error {
+	args := m.Called(plan)
+	return args.Error(0)
+}
+
+func (m *PayerRepository) FindPlanByID(id uint) (*models.InsurancePlan, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.InsurancePlan), args.Error(1)
+}
+
+func (m *PayerRepository) UpdatePlan(plan *models.InsurancePlan) error {
+	args := m.Called(plan)
+	return args.Error(0)
+}
@@ -0,0 +1,45 @@
+package mocks
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type PaymentRepository struct {
+	mock.Mock
+}
+
+func (m *PaymentRepository) Create(payment *models.Payment) error {
+	args := m.Called(payment)
+	return args.Error(0)
+}
+
+func (m *PaymentRepository) Refund(refund *models.Refund, entry *models.AuditLog) error {
+	args := m.Called(refund, entry)
+	return args.Error(0)
+}
+
+func (m *PaymentRepository) FindByID(id uint) (*models.Payment, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.Payment), args.Error(1)
+}
+
+func (m *PaymentRepository) FindAll(query models.PaymentQuery) ([]models.Payment, int64, error) {
+	args := m.Called(query)
+	return args.Get(0).([]models.Payment), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *PaymentRepository) FindByShiftID(shiftID uint) ([]models.Payment, error) {
+	args := m.Called(shiftID)
+	return args.Get(0).([]models.Payment), args.Error(1)
+}
+
+func (m *PaymentRepository) FindRefundsByShiftID(shiftID uint) ([]models.Refund, error) {
+	args := m.Called(shiftID)
+	return args.Get(0).([]models.Refund), args.Error(1)
+}
+
+func (m *PaymentRepository) SumPatientPaid(invoiceID uint) (float64, error) {
+	args := m.Called(invoiceID)
+	return args.Get(0).(float64), args.Error(1)
+}
@@ -0,0 +1,40 @@
+package mocks
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type PrescriptionRepository struct {
+	mock.Mock
+}
+
+func (m *PrescriptionRepository) Create(prescription *models.Prescription, writes models.ClinicalRecordWrites) error {
+	args := m.Called(prescription, writes)
+	return args.Error(0)
+}
+
+func (m *PrescriptionRepository) FindByID(id uint) (*models.Prescription, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.Prescription), args.Error(1)
+}
+
+func (m *PrescriptionRepository) FindByNoteID(noteID uint) ([]models.Prescription, error) {
+	args := m.Called(noteID)
+	return args.Get(0).([]models.Prescription), args.Error(1)
+}
+
+func (m *PrescriptionRepository) FindByPatientID(patientID uint, statuses []string) ([]models.Prescription, error) {
+	args := m.Called(patientID, statuses)
+	return args.Get(0).([]models.Prescription), args.Error(1)
+}
+
+func (m *PrescriptionRepository) FindAwaitingDispensing(page models.PageQuery) ([]models.Prescription, int64, error) {
+	args := m.Called(page)
+	return args.Get(0).([]models.Prescription), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *PrescriptionRepository) Update(prescription *models.Prescription) error {
+	args := m.Called(prescription)
+	return args.Error(0)
+}
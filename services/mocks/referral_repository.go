@@ -0,0 +1,40 @@
+package mocks
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type ReferralRepository struct {
+	mock.Mock
+}
+
+func (m *ReferralRepository) Create(referral *models.Referral) error {
+	args := m.Called(referral)
+	return args.Error(0)
+}
+
+func (m *ReferralRepository) FindAll(filters map[string]interface{}) ([]models.Referral, error) {
+	args := m.Called(filters)
+	return args.Get(0).([]models.Referral), args.Error(1)
+}
+
+func (m *ReferralRepository) FindByID(id uint) (*models.Referral, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.Referral), args.Error(1)
+}
+
+func (m *ReferralRepository) FindWorklist(department string, statuses []string) ([]models.Referral, error) {
+	args := m.Called(department, statuses)
+	return args.Get(0).([]models.Referral), args.Error(1)
+}
+
+func (m *ReferralRepository) Update(referral *models.Referral) error {
+	args := m.Called(referral)
+	return args.Error(0)
+}
+
+func (m *ReferralRepository) Accept(referral *models.Referral, appointment *models.Appointment) error {
+	args := m.Called(referral, appointment)
+	return args.Error(0)
+}
@@ -0,0 +1,30 @@
+package mocks
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type ServicePriceRepository struct {
+	mock.Mock
+}
+
+func (m *ServicePriceRepository) Create(price *models.ServicePrice) error {
+	args := m.Called(price)
+	return args.Error(0)
+}
+
+func (m *ServicePriceRepository) FindAll(query models.ServicePriceQuery) ([]models.ServicePrice, error) {
+	args := m.Called(query)
+	return args.Get(0).([]models.ServicePrice), args.Error(1)
+}
+
+func (m *ServicePriceRepository) FindByID(id uint) (*models.ServicePrice, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.ServicePrice), args.Error(1)
+}
+
+func (m *ServicePriceRepository) Update(price *models.ServicePrice) error {
+	args := m.Called(price)
+	return args.Error(0)
+}
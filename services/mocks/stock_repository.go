@@ -0,0 +1,50 @@
+package mocks
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type StockRepository struct {
+	mock.Mock
+}
+
+func (m *StockRepository) ReceiveGoods(receipt *models.GoodsReceipt) error {
+	args := m.Called(receipt)
+	return args.Error(0)
+}
+
+func (m *StockRepository) FindReceipts(page models.PageQuery) ([]models.GoodsReceipt, int64, error) {
+	args := m.Called(page)
+	return args.Get(0).([]models.GoodsReceipt), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *StockRepository) FindReceiptByID(id uint) (*models.GoodsReceipt, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.GoodsReceipt), args.Error(1)
+}
+
+func (m *StockRepository) FindAvailableBatches(medicationID uint, today string) ([]models.StockBatch, error) {
+	args := m.Called(medicationID, today)
+	return args.Get(0).([]models.StockBatch), args.Error(1)
+}
+
+func (m *StockRepository) FindBatchesByMedicationID(medicationID uint) ([]models.StockBatch, error) {
+	args := m.Called(medicationID)
+	return args.Get(0).([]models.StockBatch), args.Error(1)
+}
+
+func (m *StockRepository) FindExpiringBatches(before string) ([]models.StockBatch, error) {
+	args := m.Called(before)
+	return args.Get(0).([]models.StockBatch), args.Error(1)
+}
+
+func (m *StockRepository) FindStockLevels(today string) ([]models.StockLevel, error) {
+	args := m.Called(today)
+	return args.Get(0).([]models.StockLevel), args.Error(1)
+}
+
+func (m *StockRepository) FindLedger(medicationID uint, page models.PageQuery) ([]models.StockLedgerEntry, int64, error) {
+	args := m.Called(medicationID, page)
+	return args.Get(0).([]models.StockLedgerEntry), args.Get(1).(int64), args.Error(2)
+}
@@ -0,0 +1,33 @@
+package mocks
+
+import (
+	"io"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type StorageBackend struct {
+	mock.Mock
+}
+
+func (m *StorageBackend) Name() string {
+	return "mock"
+}
+
+func (m *StorageBackend) Put(key string, content []byte, contentType string) error {
+	args := m.Called(key, content, contentType)
+	return args.Error(0)
+}
+
+func (m *StorageBackend) Get(key string) (io.ReadCloser, error) {
+	args := m.Called(key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func (m *StorageBackend) Delete(key string) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
@@ -0,0 +1,15 @@
+package mocks
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type TimelineRepository struct {
+	mock.Mock
+}
+
+func (m *TimelineRepository) FindByPatientID(patientID uint, filter models.TimelineFilter, page models.PageQuery) ([]models.TimelineEvent, int64, error) {
+	args := m.Called(patientID, filter, page)
+	return args.Get(0).([]models.TimelineEvent), args.Get(1).(int64), args.Error(2)
+}
@@ -0,0 +1,35 @@
+package mocks
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type VaccineRepository struct {
+	mock.Mock
+}
+
+func (m *VaccineRepository) Create(vaccine *models.Vaccine) error {
+	args := m.Called(vaccine)
+	return args.Error(0)
+}
+
+func (m *VaccineRepository) FindAll(activeOnly bool) ([]models.Vaccine, error) {
+	args := m.Called(activeOnly)
+	return args.Get(0).([]models.Vaccine), args.Error(1)
+}
+
+func (m *VaccineRepository) FindByID(id uint) (*models.Vaccine, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.Vaccine), args.Error(1)
+}
+
+func (m *VaccineRepository) Update(vaccine *models.Vaccine) error {
+	args := m.Called(vaccine)
+	return args.Error(0)
+}
+
+func (m *VaccineRepository) ReplaceSchedule(vaccineID uint, doses []models.VaccineScheduleDose) error {
+	args := m.Called(vaccineID, doses)
+	return args.Error(0)
+}
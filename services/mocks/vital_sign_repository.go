@@ -0,0 +1,25 @@
+package mocks
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type VitalSignRepository struct {
+	mock.Mock
+}
+
+func (m *VitalSignRepository) Create(vitals *models.VitalSign) error {
+	args := m.Called(vitals)
+	return args.Error(0)
+}
+
+func (m *VitalSignRepository) FindByPatientID(patientID uint, page models.PageQuery) ([]models.VitalSign, int64, error) {
+	args := m.Called(patientID, page)
+	return args.Get(0).([]models.VitalSign), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *VitalSignRepository) FindLatestByAdmissionID(admissionID uint) (*models.VitalSign, error) {
+	args := m.Called(admissionID)
+	return args.Get(0).(*models.VitalSign), args.Error(1)
+}
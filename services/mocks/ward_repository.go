@@ -0,0 +1,50 @@
+package mocks
+
+import (
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type WardRepository struct {
+	mock.Mock
+}
+
+func (m *WardRepository) Create(ward *models.Ward) error {
+	args := m.Called(ward)
+	return args.Error(0)
+}
+
+func (m *WardRepository) FindAll(activeOnly bool) ([]models.Ward, error) {
+	args := m.Called(activeOnly)
+	return args.Get(0).([]models.Ward), args.Error(1)
+}
+
+func (m *WardRepository) FindByID(id uint) (*models.Ward, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.Ward), args.Error(1)
+}
+
+func (m *WardRepository) Update(ward *models.Ward) error {
+	args := m.Called(ward)
+	return args.Error(0)
+}
+
+func (m *WardRepository) CreateBed(bed *models.Bed) error {
+	args := m.Called(bed)
+	return args.Error(0)
+}
+
+func (m *WardRepository) FindBedByID(id uint) (*models.Bed, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.Bed), args.Error(1)
+}
+
+func (m *WardRepository) UpdateBedStatus(bed *models.Bed) error {
+	args := m.Called(bed)
+	return args.Error(0)
+}
+
+func (m *WardRepository) FindOccupancy(wardID uint) ([]models.BedOccupancy, error) {
+	args := m.Called(wardID)
+	return args.Get(0).([]models.BedOccupancy), args.Error(1)
+}
@@ -0,0 +1,512 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+)
+
+type NursingService interface {
+	RecordVitals(patientID uint, input models.RecordVitalsInput, recordedBy uint) (*models.VitalSign, error)
+	GetVitals(patientID uint, page models.PageQuery) ([]models.VitalSign, int64, error)
+	CreateNursingNote(patientID uint, input models.CreateNursingNoteInput, nurseID uint) (*models.NursingNote, error)
+	GetNursingNotes(patientID uint, page models.PageQuery) ([]models.NursingNote, int64, error)
+	AdministerMedication(patientID uint, input models.AdministerMedicationInput, nurseID uint) (*models.MedicationAdministration, error)
+	GetMedicationAdministrations(patientID uint, page models.PageQuery) ([]models.MedicationAdministration, int64, error)
+	GetAdmissionMAR(admissionID uint, query models.MarQuery) (*models.MedicationAdministrationRecord, error)
+	GetMedicationDashboard(wardID uint) (*models.WardMedicationDashboard, error)
+	GetTasks(wardID uint) (*models.NursingTaskList, error)
+}
+
+type nursingService struct {
+	vitalSignRepository                repositories.VitalSignRepository
+	nursingNoteRepository              repositories.NursingNoteRepository
+	medicationAdministrationRepository repositories.MedicationAdministrationRepository
+	prescriptionRepository             repositories.PrescriptionRepository
+	labOrderRepository                 repositories.LabOrderRepository
+	admissionRepository                repositories.AdmissionRepository
+	wardRepository                     repositories.WardRepository
+	patientRepository                  repositories.PatientRepository
+	overdueWindow                      time.Duration
+}
+
+func NewNursingService(
+	vitalSignRepository repositories.VitalSignRepository,
+	nursingNoteRepository repositories.NursingNoteRepository,
+	medicationAdministrationRepository repositories.MedicationAdministrationRepository,
+	prescriptionRepository repositories.PrescriptionRepository,
+	labOrderRepository repositories.LabOrderRepository,
+	admissionRepository repositories.AdmissionRepository,
+	wardRepository repositories.WardRepository,
+	patientRepository repositories.PatientRepository,
+	overdueWindow time.Duration,
+) NursingService {
+	return &nursingService{
+		vitalSignRepository:                vitalSignRepository,
+		nursingNoteRepository:              nursingNoteRepository,
+		medicationAdministrationRepository: medicationAdministrationRepository,
+		prescriptionRepository:             prescriptionRepository,
+		labOrderRepository:                 labOrderRepository,
+		admissionRepository:                admissionRepository,
+		wardRepository:                     wardRepository,
+		patientRepository:                  patientRepository,
+		overdueWindow:                      overdueWindow,
+	}
+}
+
+// MarOverdueWindowFromEnv reads MAR_OVERDUE_MINUTES, falling back to the
+// default window when it is unset or invalid.
+func MarOverdueWindowFromEnv() time.Duration {
+	minutes, err := strconv.Atoi(os.Getenv("MAR_OVERDUE_MINUTES"))
+	if err != nil || minutes <= 0 {
+		minutes = constants.DefaultMarOverdueMinutes
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+func (ns *nursingService) RecordVitals(patientID uint, input models.RecordVitalsInput, recordedBy uint) (*models.VitalSign, error) {
+	if err := ns.checkPatient(patientID); err != nil {
+		return nil, err
+	}
+
+	if input.TemperatureC == nil && input.PulseRate == nil && input.RespiratoryRate == nil &&
+		input.SystolicBP == nil && input.OxygenSaturation == nil && input.PainScore == nil {
+		return nil, errors.New("at least one observation is required")
+	}
+	if input.SystolicBP != nil && input.DiastolicBP != nil && *input.DiastolicBP >= *input.SystolicBP {
+		return nil, errors.New("diastolic pressure must be lower than systolic pressure")
+	}
+
+	recordedAt := time.Now()
+	if input.RecordedAt != nil {
+		if input.RecordedAt.After(recordedAt) {
+			return nil, errors.New("observation time cannot be in the future")
+		}
+		recordedAt = *input.RecordedAt
+	}
+
+	vitals := &models.VitalSign{
+		PatientID:        patientID,
+		AdmissionID:      ns.activeAdmissionID(patientID),
+		RecordedAt:       recordedAt,
+		TemperatureC:     input.TemperatureC,
+		PulseRate:        input.PulseRate,
+		RespiratoryRate:  input.RespiratoryRate,
+		SystolicBP:       input.SystolicBP,
+		DiastolicBP:      input.DiastolicBP,
+		OxygenSaturation: input.OxygenSaturation,
+		PainScore:        input.PainScore,
+		Notes:            input.Notes,
+		RecordedBy:       recordedBy,
+	}
+
+	if err := ns.vitalSignRepository.Create(vitals); err != nil {
+		return nil, err
+	}
+
+	return vitals, nil
+}
+
+func (ns *nursingService) GetVitals(patientID uint, page models.PageQuery) ([]models.VitalSign, int64, error) {
+	if err := ns.checkPatient(patientID); err != nil {
+		return nil, 0, err
+	}
+	return ns.vitalSignRepository.FindByPatientID(patientID, page)
+}
+
+func (ns *nursingService) CreateNursingNote(patientID uint, input models.CreateNursingNoteInput, nurseID uint) (*models.NursingNote, error) {
+	if err := ns.checkPatient(patientID); err != nil {
+		return nil, err
+	}
+
+	note := &models.NursingNote{
+		PatientID:   patientID,
+		AdmissionID: ns.activeAdmissionID(patientID),
+		NurseID:     nurseID,
+		Note:        input.Note,
+	}
+
+	if err := ns.nursingNoteRepository.Create(note); err != nil {
+		return nil, err
+	}
+
+	return note, nil
+}
+
+func (ns *nursingService) GetNursingNotes(patientID uint, page models.PageQuery) ([]models.NursingNote, int64, error) {
+	if err := ns.checkPatient(patientID); err != nil {
+		return nil, 0, err
+	}
+	return ns.nursingNoteRepository.FindByPatientID(patientID, page)
+}
+
+// AdministerMedication charts a dose given, held or refused against one of
+// the patient's current prescriptions, active or dispensed and within the
+// course. A given dose defaults to the prescribed amount and may not exceed
+// it. Doses of scheduled medications must name one of the prescription's dose
+// times on the medication administration record, and each can only be
+// charted once; a STAT dose answers the time it was prescribed. Only
+// as-needed doses are charted without a scheduled time.
+func (ns *nursingService) AdministerMedication(patientID uint, input models.AdministerMedicationInput, nurseID uint) (*models.MedicationAdministration, error) {
+	if err := ns.checkPatient(patientID); err != nil {
+		return nil, err
+	}
+
+	status := input.Status
+	if status == "" {
+		status = constants.AdministrationStatus.GIVEN
+	}
+	reason := strings.TrimSpace(input.Reason)
+	if status != constants.AdministrationStatus.GIVEN {
+		if input.ScheduledAt == nil {
+			return nil, errors.New("a scheduled dose time is required to hold or refuse a dose")
+		}
+		if reason == "" {
+			return nil, errors.New("a reason is required to hold or refuse a dose")
+		}
+	}
+
+	prescription, err := ns.prescriptionRepository.FindByID(input.PrescriptionID)
+	if err != nil {
+		return nil, errors.New("prescription not found")
+	}
+	if prescription.PatientID != patientID {
+		return nil, errors.New("prescription does not belong to this patient")
+	}
+
+	now := time.Now()
+	administeredAt := now
+	if input.AdministeredAt != nil {
+		if input.AdministeredAt.After(now) {
+			return nil, errors.New("administration time cannot be in the future")
+		}
+		if input.AdministeredAt.Before(prescription.CreatedAt) {
+			return nil, errors.New("administration time cannot be before the prescription was written")
+		}
+		administeredAt = *input.AdministeredAt
+	}
+	if prescription.Status == constants.PrescriptionStatus.DISCONTINUED || !courseRunning(*prescription, administeredAt) {
+		return nil, errors.New("prescription is not active")
+	}
+
+	scheduledAt := input.ScheduledAt
+	switch prescription.Frequency {
+	case constants.DoseFrequency.PRN:
+	case constants.DoseFrequency.STAT:
+		if scheduledAt == nil {
+			scheduledAt = &prescription.CreatedAt
+		}
+	default:
+		if scheduledAt == nil {
+			return nil, errors.New("a scheduled dose time is required for scheduled medications")
+		}
+	}
+
+	if scheduledAt != nil {
+		scheduledAt := *scheduledAt
+		if !slices.ContainsFunc(doseTimes(*prescription, scheduledAt, scheduledAt.Add(time.Second)), scheduledAt.Equal) {
+			return nil, errors.New("no dose of this prescription is scheduled at that time")
+		}
+		charted, err := ns.medicationAdministrationRepository.CountByScheduledDose(prescription.ID, scheduledAt)
+		if err != nil {
+			return nil, err
+		}
+		if charted > 0 {
+			return nil, errors.New("this dose has already been charted")
+		}
+	}
+
+	if prescription.Frequency == constants.DoseFrequency.STAT {
+		given, err := ns.medicationAdministrationRepository.CountByPrescriptionID(prescription.ID)
+		if err != nil {
+			return nil, err
+		}
+		if given > 0 {
+			return nil, errors.New("the stat dose has already been given")
+		}
+	}
+
+	dose := prescription.DoseAmount
+	if input.DoseAmount != nil {
+		if *input.DoseAmount > prescription.DoseAmount {
+			return nil, errors.New("dose exceeds the prescribed amount")
+		}
+		dose = *input.DoseAmount
+	}
+	if status != constants.AdministrationStatus.GIVEN {
+		dose = 0
+	}
+
+	administration := &models.MedicationAdministration{
+		PrescriptionID: prescription.ID,
+		PatientID:      patientID,
+		AdmissionID:    ns.activeAdmissionID(patientID),
+		ScheduledAt:    scheduledAt,
+		Status:         status,
+		Reason:         reason,
+		DoseAmount:     dose,
+		DoseUnit:       prescription.DoseUnit,
+		Route:          prescription.Route,
+		AdministeredAt: administeredAt,
+		AdministeredBy: nurseID,
+		Notes:          input.Notes,
+	}
+
+	if err := ns.medicationAdministrationRepository.Create(administration); err != nil {
+		return nil, err
+	}
+
+	administration.Prescription = prescription
+	return administration, nil
+}
+
+func (ns *nursingService) GetMedicationAdministrations(patientID uint, page models.PageQuery) ([]models.MedicationAdministration, int64, error) {
+	if err := ns.checkPatient(patientID); err != nil {
+		return nil, 0, err
+	}
+	return ns.medicationAdministrationRepository.FindByPatientID(patientID, page)
+}
+
+// GetAdmissionMAR lays out one day of the admission's medication
+// administration record, today by default, with every scheduled dose and what
+// was charted against it.
+func (ns *nursingService) GetAdmissionMAR(admissionID uint, query models.MarQuery) (*models.MedicationAdministrationRecord, error) {
+	admission, err := ns.admissionRepository.FindByID(admissionID)
+	if err != nil {
+		return nil, errors.New("admission not found")
+	}
+
+	now := time.Now()
+	year, month, day := now.Date()
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+	if query.Date != "" {
+		date, err = time.ParseInLocation("2006-01-02", query.Date, time.Local)
+		if err != nil {
+			return nil, errors.New("invalid date format, use YYYY-MM-DD")
+		}
+	}
+
+	from, to := date, date.AddDate(0, 0, 1)
+	if admission.AdmittedAt.After(from) {
+		from = admission.AdmittedAt
+	}
+	if admission.DischargedAt != nil && admission.DischargedAt.Before(to) {
+		to = *admission.DischargedAt
+	}
+	if !from.Before(to) {
+		return nil, errors.New("date is outside the admission")
+	}
+
+	prescriptions, err := ns.prescriptionRepository.FindByPatientID(admission.PatientID, marPrescriptionStatuses)
+	if err != nil {
+		return nil, err
+	}
+	administrations, err := ns.medicationAdministrationRepository.FindByAdmissionID(admission.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.MedicationAdministrationRecord{
+		AdmissionID: admission.ID,
+		PatientID:   admission.PatientID,
+		Date:        date.Format("2006-01-02"),
+		Entries:     buildMarEntries(prescriptions, administrations, from, to, now, ns.overdueWindow),
+	}, nil
+}
+
+// GetMedicationDashboard counts, for each patient on the ward, the doses from
+// the last day that are due or overdue and have not been charted.
+func (ns *nursingService) GetMedicationDashboard(wardID uint) (*models.WardMedicationDashboard, error) {
+	ward, err := ns.wardRepository.FindByID(wardID)
+	if err != nil {
+		return nil, errors.New("ward not found")
+	}
+
+	beds, err := ns.wardRepository.FindOccupancy(wardID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	dashboard := &models.WardMedicationDashboard{
+		WardID:               ward.ID,
+		WardName:             ward.Name,
+		OverdueWindowMinutes: int(ns.overdueWindow / time.Minute),
+		Patients:             []models.PatientDoseSummary{},
+	}
+	for _, bed := range beds {
+		if bed.AdmissionID == nil || bed.PatientID == nil {
+			continue
+		}
+		entries, err := ns.marForBed(bed, now.Add(-constants.MarLookback), now, now)
+		if err != nil {
+			return nil, err
+		}
+
+		summary := models.PatientDoseSummary{
+			AdmissionID:  *bed.AdmissionID,
+			PatientID:    *bed.PatientID,
+			PatientName:  bed.PatientName,
+			BedLabel:     bed.Label,
+			OverdueDoses: []models.OverdueDose{},
+		}
+		for _, entry := range entries {
+			for _, dose := range entry.Doses {
+				switch dose.Status {
+				case constants.MarDoseStatus.DUE:
+					summary.DueCount++
+				case constants.MarDoseStatus.OVERDUE:
+					summary.OverdueCount++
+					summary.OverdueDoses = append(summary.OverdueDoses, models.OverdueDose{
+						PrescriptionID: entry.Prescription.ID,
+						Description:    doseDescription(entry.Prescription),
+						ScheduledAt:    dose.ScheduledAt,
+						MinutesOverdue: int(now.Sub(dose.ScheduledAt) / time.Minute),
+					})
+				}
+			}
+		}
+		dashboard.Patients = append(dashboard.Patients, summary)
+	}
+
+	return dashboard, nil
+}
+
+// marForBed builds the medication administration record of the patient in
+// the bed for [from, to), starting no earlier than the admission.
+func (ns *nursingService) marForBed(bed models.BedOccupancy, from, to, now time.Time) ([]models.MarEntry, error) {
+	if bed.AdmittedAt != nil && bed.AdmittedAt.After(from) {
+		from = *bed.AdmittedAt
+	}
+
+	prescriptions, err := ns.prescriptionRepository.FindByPatientID(*bed.PatientID, marPrescriptionStatuses)
+	if err != nil {
+		return nil, err
+	}
+	administrations, err := ns.medicationAdministrationRepository.FindByAdmissionID(*bed.AdmissionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildMarEntries(prescriptions, administrations, from, to, now, ns.overdueWindow), nil
+}
+
+// GetTasks works out what is due for every patient on the ward from the
+// doctors' orders: doses on the medication administration record not yet
+// charted, samples for lab orders not yet collected, and routine
+// observations. Tasks are listed by due time.
+func (ns *nursingService) GetTasks(wardID uint) (*models.NursingTaskList, error) {
+	ward, err := ns.wardRepository.FindByID(wardID)
+	if err != nil {
+		return nil, errors.New("ward not found")
+	}
+
+	beds, err := ns.wardRepository.FindOccupancy(wardID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	list := &models.NursingTaskList{WardID: ward.ID, WardName: ward.Name, Tasks: []models.NursingTask{}}
+	for _, bed := range beds {
+		if bed.AdmissionID == nil || bed.PatientID == nil {
+			continue
+		}
+		tasks, err := ns.tasksForBed(bed, now)
+		if err != nil {
+			return nil, err
+		}
+		list.Tasks = append(list.Tasks, tasks...)
+	}
+
+	sort.SliceStable(list.Tasks, func(i, j int) bool {
+		return list.Tasks[i].DueAt.Before(list.Tasks[j].DueAt)
+	})
+	return list, nil
+}
+
+func (ns *nursingService) tasksForBed(bed models.BedOccupancy, now time.Time) ([]models.NursingTask, error) {
+	var tasks []models.NursingTask
+	addTask := func(taskType, description string, dueAt time.Time) *models.NursingTask {
+		tasks = append(tasks, models.NursingTask{
+			Type:        taskType,
+			AdmissionID: *bed.AdmissionID,
+			PatientID:   *bed.PatientID,
+			PatientName: bed.PatientName,
+			BedLabel:    bed.Label,
+			Description: description,
+			DueAt:       dueAt,
+			Overdue:     dueAt.Before(now),
+		})
+		return &tasks[len(tasks)-1]
+	}
+
+	latest, err := ns.vitalSignRepository.FindLatestByAdmissionID(*bed.AdmissionID)
+	if err == nil {
+		addTask(constants.NursingTaskTypes.VITAL_SIGNS, "Record vital signs",
+			latest.RecordedAt.Add(constants.VitalSignsInterval))
+	} else {
+		admittedAt := now
+		if bed.AdmittedAt != nil {
+			admittedAt = *bed.AdmittedAt
+		}
+		addTask(constants.NursingTaskTypes.VITAL_SIGNS, "Record admission vital signs", admittedAt)
+	}
+
+	entries, err := ns.marForBed(bed, now.Add(-constants.MarLookback), now.Add(constants.NursingTaskLookahead), now)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		for _, dose := range entry.Doses {
+			if dose.Administration != nil {
+				continue
+			}
+			task := addTask(constants.NursingTaskTypes.MEDICATION, doseDescription(entry.Prescription), dose.ScheduledAt)
+			task.PrescriptionID = &entry.Prescription.ID
+			task.Overdue = dose.Status == constants.MarDoseStatus.OVERDUE
+		}
+	}
+
+	orders, err := ns.labOrderRepository.FindAll(map[string]interface{}{
+		"patient_id": *bed.PatientID,
+		"status":     constants.LabOrderStatus.ORDERED,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, order := range orders {
+		task := addTask(constants.NursingTaskTypes.SAMPLE_COLLECTION,
+			fmt.Sprintf("Collect samples for lab order #%d (%s)", order.ID, order.Priority), order.CreatedAt)
+		task.LabOrderID = &order.ID
+	}
+
+	return tasks, nil
+}
+
+func (ns *nursingService) checkPatient(patientID uint) error {
+	patient, err := ns.patientRepository.FindByID(patientID)
+	if err != nil || patient == nil {
+		return errors.New("patient record not found")
+	}
+	return nil
+}
+
+// activeAdmissionID links nursing records to the admission the patient is
+// currently in, if any.
+func (ns *nursingService) activeAdmissionID(patientID uint) *uint {
+	admission, err := ns.admissionRepository.FindActiveByPatientID(patientID)
+	if err != nil || admission == nil {
+		return nil
+	}
+	return &admission.ID
+}
@@ -0,0 +1,615 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type nursingMocks struct {
+	vitals          *mocks.VitalSignRepository
+	notes           *mocks.NursingNoteRepository
+	administrations *mocks.MedicationAdministrationRepository
+	prescriptions   *mocks.PrescriptionRepository
+	labOrders       *mocks.LabOrderRepository
+	admissions      *mocks.AdmissionRepository
+	wards           *mocks.WardRepository
+	patients        *mocks.PatientRepository
+}
+
+func newNursingServiceWithMocks() (NursingService, nursingMocks) {
+	m := nursingMocks{
+		vitals:          new(mocks.VitalSignRepository),
+		notes:           new(mocks.NursingNoteRepository),
+		administrations: new(mocks.MedicationAdministrationRepository),
+		prescriptions:   new(mocks.PrescriptionRepository),
+		labOrders:       new(mocks.LabOrderRepository),
+		admissions:      new(mocks.AdmissionRepository),
+		wards:           new(mocks.WardRepository),
+		patients:        new(mocks.PatientRepository),
+	}
+	service := NewNursingService(m.vitals, m.notes, m.administrations, m.prescriptions,
+		m.labOrders, m.admissions, m.wards, m.patients, time.Hour)
+	return service, m
+}
+
+func TestRecordVitals(t *testing.T) {
+	patient := &models.Patient{Model: gorm.Model{ID: 4}}
+
+	t.Run("LinksActiveAdmission", func(t *testing.T) {
+		service, m := newNursingServiceWithMocks()
+
+		m.patients.On("FindByID", uint(4)).Return(patient, nil)
+		m.admissions.On("FindActiveByPatientID", uint(4)).Return(&models.Admission{Model: gorm.Model{ID: 9}}, nil)
+		m.vitals.On("Create", mock.AnythingOfType("*models.VitalSign")).Return(nil)
+
+		vitals, err := service.RecordVitals(4, models.RecordVitalsInput{
+			TemperatureC: floatPtr(38.2),
+			PulseRate:    intPtr(104),
+			SystolicBP:   intPtr(118),
+			DiastolicBP:  intPtr(76),
+		}, 6)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(9), *vitals.AdmissionID)
+		assert.Equal(t, uint(6), vitals.RecordedBy)
+		assert.WithinDuration(t, time.Now(), vitals.RecordedAt, time.Minute)
+		m.vitals.AssertExpectations(t)
+	})
+
+	t.Run("Outpatient", func(t *testing.T) {
+		service, m := newNursingServiceWithMocks()
+
+		m.patients.On("FindByID", uint(4)).Return(patient, nil)
+		m.admissions.On("FindActiveByPatientID", uint(4)).Return(&models.Admission{}, gorm.ErrRecordNotFound)
+		m.vitals.On("Create", mock.AnythingOfType("*models.VitalSign")).Return(nil)
+
+		vitals, err := service.RecordVitals(4, models.RecordVitalsInput{OxygenSaturation: intPtr(97)}, 6)
+
+		assert.NoError(t, err)
+		assert.Nil(t, vitals.AdmissionID)
+	})
+
+	t.Run("NoObservations", func(t *testing.T) {
+		service, m := newNursingServiceWithMocks()
+
+		m.patients.On("FindByID", uint(4)).Return(patient, nil)
+
+		_, err := service.RecordVitals(4, models.RecordVitalsInput{Notes: "Patient asleep"}, 6)
+
+		assert.EqualError(t, err, "at least one observation is required")
+		m.vitals.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("DiastolicNotBelowSystolic", func(t *testing.T) {
+		service, m := newNursingServiceWithMocks()
+
+		m.patients.On("FindByID", uint(4)).Return(patient, nil)
+
+		_, err := service.RecordVitals(4, models.RecordVitalsInput{
+			SystolicBP:  intPtr(80),
+			DiastolicBP: intPtr(90),
+		}, 6)
+
+		assert.EqualError(t, err, "diastolic pressure must be lower than systolic pressure")
+	})
+
+	t.Run("FutureTime", func(t *testing.T) {
+		service, m := newNursingServiceWithMocks()
+		later := time.Now().Add(time.Hour)
+
+		m.patients.On("FindByID", uint(4)).Return(patient, nil)
+
+		_, err := service.RecordVitals(4, models.RecordVitalsInput{RecordedAt: &later, PulseRate: intPtr(80)}, 6)
+
+		assert.EqualError(t, err, "observation time cannot be in the future")
+	})
+}
+
+func TestAdministerMedication(t *testing.T) {
+	patient := &models.Patient{Model: gorm.Model{ID: 4}}
+	prescription := func(frequency, status string) *models.Prescription {
+		return &models.Prescription{
+			Model:        gorm.Model{ID: 12, CreatedAt: time.Now().AddDate(0, 0, -1)},
+			PatientID:    4,
+			DoseAmount:   500,
+			DoseUnit:     "mg",
+			Route:        "oral",
+			Frequency:    frequency,
+			DurationDays: 5,
+			Status:       status,
+		}
+	}
+
+	t.Run("DefaultsToPrescribedDose", func(t *testing.T) {
+		service, m := newNursingServiceWithMocks()
+		year, month, day := time.Now().Date()
+		scheduledAt := time.Date(year, month, day, 6, 0, 0, 0, time.Local)
+
+		m.patients.On("FindByID", uint(4)).Return(patient, nil)
+		m.prescriptions.On("FindByID", uint(12)).Return(prescription(constants.DoseFrequency.TDS, constants.PrescriptionStatus.ACTIVE), nil)
+		m.administrations.On("CountByScheduledDose", uint(12), scheduledAt).Return(int64(0), nil)
+		m.admissions.On("FindActiveByPatientID", uint(4)).Return(&models.Admission{Model: gorm.Model{ID: 9}}, nil)
+		m.administrations.On("Create", mock.AnythingOfType("*models.MedicationAdministration")).Return(nil)
+
+		administration, err := service.AdministerMedication(4, models.AdministerMedicationInput{
+			PrescriptionID: 12,
+			ScheduledAt:    &scheduledAt,
+		}, 6)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 500.0, administration.DoseAmount)
+		assert.Equal(t, "mg", administration.DoseUnit)
+		assert.Equal(t, "oral", administration.Route)
+		assert.Equal(t, uint(9), *administration.AdmissionID)
+		assert.Equal(t, uint(6), administration.AdministeredBy)
+		m.administrations.AssertExpectations(t)
+	})
+
+	t.Run("DispensedPrescription", func(t *testing.T) {
+		service, m := newNursingServiceWithMocks()
+
+		m.patients.On("FindByID", uint(4)).Return(patient, nil)
+		m.prescriptions.On("FindByID", uint(12)).Return(prescription(constants.DoseFrequency.PRN, constants.PrescriptionStatus.DISPENSED), nil)
+		m.admissions.On("FindActiveByPatientID", uint(4)).Return(&models.Admission{}, gorm.ErrRecordNotFound)
+		m.administrations.On("Create", mock.AnythingOfType("*models.MedicationAdministration")).Return(nil)
+
+		administration, err := service.AdministerMedication(4, models.AdministerMedicationInput{
+			PrescriptionID: 12,
+			DoseAmount:     floatPtr(250),
+		}, 6)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 250.0, administration.DoseAmount)
+		assert.Nil(t, administration.AdmissionID)
+		assert.Nil(t, administration.ScheduledAt)
+	})
+
+	t.Run("ScheduledDoseNeedsTime", func(t *testing.T) {
+		service, m := newNursingServiceWithMocks()
+
+		m.patients.On("FindByID", uint(4)).Return(patient, nil)
+		m.prescriptions.On("FindByID", uint(12)).Return(prescription(constants.DoseFrequency.BD, constants.PrescriptionStatus.ACTIVE), nil)
+
+		_, err := service.AdministerMedication(4, models.AdministerMedicationInput{PrescriptionID: 12}, 6)
+
+		assert.EqualError(t, err, "a scheduled dose time is required for scheduled medications")
+		m.administrations.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("StatDoseAnswersPrescribedTime", func(t *testing.T) {
+		service, m := newNursingServiceWithMocks()
+		stat := prescription(constants.DoseFrequency.STAT, constants.PrescriptionStatus.ACTIVE)
+
+		m.patients.On("FindByID", uint(4)).Return(patient, nil)
+		m.prescriptions.On("FindByID", uint(12)).Return(stat, nil)
+		m.administrations.On("CountByScheduledDose", uint(12), stat.CreatedAt).Return(int64(0), nil)
+		m.administrations.On("CountByPrescriptionID", uint(12)).Return(int64(0), nil)
+		m.admissions.On("FindActiveByPatientID", uint(4)).Return(&models.Admission{Model: gorm.Model{ID: 9}}, nil)
+		m.administrations.On("Create", mock.AnythingOfType("*models.MedicationAdministration")).Return(nil)
+
+		administration, err := service.AdministerMedication(4, models.AdministerMedicationInput{PrescriptionID: 12}, 6)
+
+		assert.NoError(t, err)
+		assert.Equal(t, stat.CreatedAt, *administration.ScheduledAt)
+	})
+
+	t.Run("OtherPatientsPrescription", func(t *testing.T) {
+		service, m := newNursingServiceWithMocks()
+		other := prescription(constants.DoseFrequency.TDS, constants.PrescriptionStatus.ACTIVE)
+		other.PatientID = 5
+
+		m.patients.On("FindByID", uint(4)).Return(patient, nil)
+		m.prescriptions.On("FindByID", uint(12)).Return(other, nil)
+
+		_, err := service.AdministerMedication(4, models.AdministerMedicationInput{PrescriptionID: 12}, 6)
+
+		assert.EqualError(t, err, "prescription does not belong to this patient")
+	})
+
+	t.Run("Discontinued", func(t *testing.T) {
+		service, m := newNursingServiceWithMocks()
+
+		m.patients.On("FindByID", uint(4)).Return(patient, nil)
+		m.prescriptions.On("FindByID", uint(12)).Return(prescription(constants.DoseFrequency.TDS, constants.PrescriptionStatus.DISCONTINUED), nil)
+
+		_, err := service.AdministerMedication(4, models.AdministerMedicationInput{PrescriptionID: 12}, 6)
+
+		assert.EqualError(t, err, "prescription is not active")
+	})
+
+	t.Run("CourseFinished", func(t *testing.T) {
+		service, m := newNursingServiceWithMocks()
+		finished := prescription(constants.DoseFrequency.TDS, constants.PrescriptionStatus.ACTIVE)
+		finished.CreatedAt = time.Now().AddDate(0, 0, -6)
+
+		m.patients.On("FindByID", uint(4)).Return(patient, nil)
+		m.prescriptions.On("FindByID", uint(12)).Return(finished, nil)
+
+		_, err := service.AdministerMedication(4, models.AdministerMedicationInput{PrescriptionID: 12}, 6)
+
+		assert.EqualError(t, err, "prescription is not active")
+	})
+
+	t.Run("StatDoseAlreadyGiven", func(t *testing.T) {
+		service, m := newNursingServiceWithMocks()
+
+		m.patients.On("FindByID", uint(4)).Return(patient, nil)
+		m.prescriptions.On("FindByID", uint(12)).Return(prescription(constants.DoseFrequency.STAT, constants.PrescriptionStatus.ACTIVE), nil)
+		m.administrations.On("CountByScheduledDose", uint(12), mock.AnythingOfType("time.Time")).Return(int64(0), nil)
+		m.administrations.On("CountByPrescriptionID", uint(12)).Return(int64(1), nil)
+
+		_, err := service.AdministerMedication(4, models.AdministerMedicationInput{PrescriptionID: 12}, 6)
+
+		assert.EqualError(t, err, "the stat dose has already been given")
+		m.administrations.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("DoseAbovePrescribed", func(t *testing.T) {
+		service, m := newNursingServiceWithMocks()
+
+		m.patients.On("FindByID", uint(4)).Return(patient, nil)
+		m.prescriptions.On("FindByID", uint(12)).Return(prescription(constants.DoseFrequency.PRN, constants.PrescriptionStatus.ACTIVE), nil)
+
+		_, err := service.AdministerMedication(4, models.AdministerMedicationInput{
+			PrescriptionID: 12,
+			DoseAmount:     floatPtr(1000),
+		}, 6)
+
+		assert.EqualError(t, err, "dose exceeds the prescribed amount")
+	})
+
+	t.Run("HeldDose", func(t *testing.T) {
+		service, m := newNursingServiceWithMocks()
+		year, month, day := time.Now().Date()
+		scheduledAt := time.Date(year, month, day, 6, 0, 0, 0, time.Local)
+
+		m.patients.On("FindByID", uint(4)).Return(patient, nil)
+		m.prescriptions.On("FindByID", uint(12)).Return(prescription(constants.DoseFrequency.TDS, constants.PrescriptionStatus.ACTIVE), nil)
+		m.administrations.On("CountByScheduledDose", uint(12), scheduledAt).Return(int64(0), nil)
+		m.admissions.On("FindActiveByPatientID", uint(4)).Return(&models.Admission{Model: gorm.Model{ID: 9}}, nil)
+		m.administrations.On("Create", mock.AnythingOfType("*models.MedicationAdministration")).Return(nil)
+
+		administration, err := service.AdministerMedication(4, models.AdministerMedicationInput{
+			PrescriptionID: 12,
+			ScheduledAt:    &scheduledAt,
+			Status:         constants.AdministrationStatus.HELD,
+			Reason:         " Systolic pressure below 90 ",
+		}, 6)
+
+		assert.NoError(t, err)
+		assert.Equal(t, constants.AdministrationStatus.HELD, administration.Status)
+		assert.Equal(t, "Systolic pressure below 90", administration.Reason)
+		assert.Equal(t, 0.0, administration.DoseAmount)
+		assert.Equal(t, scheduledAt, *administration.ScheduledAt)
+	})
+
+	t.Run("RefusedNeedsReason", func(t *testing.T) {
+		service, m := newNursingServiceWithMocks()
+		scheduledAt := time.Now()
+
+		m.patients.On("FindByID", uint(4)).Return(patient, nil)
+
+		_, err := service.AdministerMedication(4, models.AdministerMedicationInput{
+			PrescriptionID: 12,
+			ScheduledAt:    &scheduledAt,
+			Status:         constants.AdministrationStatus.REFUSED,
+		}, 6)
+
+		assert.EqualError(t, err, "a reason is required to hold or refuse a dose")
+	})
+
+	t.Run("HeldNeedsScheduledTime", func(t *testing.T) {
+		service, m := newNursingServiceWithMocks()
+
+		m.patients.On("FindByID", uint(4)).Return(patient, nil)
+
+		_, err := service.AdministerMedication(4, models.AdministerMedicationInput{
+			PrescriptionID: 12,
+			Status:         constants.AdministrationStatus.HELD,
+			Reason:         "Nil by mouth",
+		}, 6)
+
+		assert.EqualError(t, err, "a scheduled dose time is required to hold or refuse a dose")
+	})
+
+	t.Run("NotAScheduledTime", func(t *testing.T) {
+		service, m := newNursingServiceWithMocks()
+		year, month, day := time.Now().Date()
+		scheduledAt := time.Date(year, month, day, 7, 0, 0, 0, time.Local)
+
+		m.patients.On("FindByID", uint(4)).Return(patient, nil)
+		m.prescriptions.On("FindByID", uint(12)).Return(prescription(constants.DoseFrequency.TDS, constants.PrescriptionStatus.ACTIVE), nil)
+
+		_, err := service.AdministerMedication(4, models.AdministerMedicationInput{
+			PrescriptionID: 12,
+			ScheduledAt:    &scheduledAt,
+		}, 6)
+
+		assert.EqualError(t, err, "no dose of this prescription is scheduled at that time")
+	})
+
+	t.Run("AlreadyCharted", func(t *testing.T) {
+		service, m := newNursingServiceWithMocks()
+		year, month, day := time.Now().Date()
+		scheduledAt := time.Date(year, month, day, 6, 0, 0, 0, time.Local)
+
+		m.patients.On("FindByID", uint(4)).Return(patient, nil)
+		m.prescriptions.On("FindByID", uint(12)).Return(prescription(constants.DoseFrequency.TDS, constants.PrescriptionStatus.ACTIVE), nil)
+		m.administrations.On("CountByScheduledDose", uint(12), scheduledAt).Return(int64(1), nil)
+
+		_, err := service.AdministerMedication(4, models.AdministerMedicationInput{
+			PrescriptionID: 12,
+			ScheduledAt:    &scheduledAt,
+		}, 6)
+
+		assert.EqualError(t, err, "this dose has already been charted")
+		m.administrations.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestGetNursingTasks(t *testing.T) {
+	ward := &models.Ward{Model: gorm.Model{ID: 2}, Name: "Male Medical"}
+	admittedAt := time.Now().Add(-10 * time.Hour)
+
+	t.Run("BuildsTasksFromOrders", func(t *testing.T) {
+		service, m := newNursingServiceWithMocks()
+		now := time.Now()
+		given := now.Add(-5 * time.Hour)
+		stat := now.Add(-90 * time.Minute)
+
+		m.wards.On("FindByID", uint(2)).Return(ward, nil)
+		m.wards.On("FindOccupancy", uint(2)).Return([]models.BedOccupancy{
+			{BedID: 1, Label: "A1", Status: constants.BedStatus.AVAILABLE},
+			{BedID: 2, Label: "A2", Status: constants.BedStatus.OCCUPIED, AdmissionID: uintPtr(9),
+				PatientID: uintPtr(4), PatientName: "Ada Obi", AdmittedAt: &admittedAt},
+		}, nil)
+		m.vitals.On("FindLatestByAdmissionID", uint(9)).Return(&models.VitalSign{RecordedAt: now.Add(-time.Hour)}, nil)
+		m.prescriptions.On("FindByPatientID", uint(4), marPrescriptionStatuses).Return([]models.Prescription{
+			{Model: gorm.Model{ID: 11, CreatedAt: given}, DoseAmount: 4, DoseUnit: "mg", Route: "iv",
+				Frequency: constants.DoseFrequency.STAT, DurationDays: 1},
+			{Model: gorm.Model{ID: 12, CreatedAt: stat}, Medication: &models.Medication{Name: "Ceftriaxone"},
+				DoseAmount: 1, DoseUnit: "g", Route: "iv", Frequency: constants.DoseFrequency.STAT, DurationDays: 1},
+			{Model: gorm.Model{ID: 13, CreatedAt: stat}, DoseAmount: 1, DoseUnit: "g", Route: "oral",
+				Frequency: constants.DoseFrequency.PRN, DurationDays: 5},
+			{Model: gorm.Model{ID: 14, CreatedAt: now.AddDate(0, 0, -10)}, DoseAmount: 40, DoseUnit: "mg", Route: "oral",
+				Frequency: constants.DoseFrequency.OD, DurationDays: 7},
+		}, nil)
+		m.administrations.On("FindByAdmissionID", uint(9)).Return([]models.MedicationAdministration{
+			{PrescriptionID: 11, ScheduledAt: &given, Status: constants.AdministrationStatus.GIVEN, AdministeredAt: given},
+		}, nil)
+		m.labOrders.On("FindAll", map[string]interface{}{
+			"patient_id": uint(4),
+			"status":     constants.LabOrderStatus.ORDERED,
+		}).Return([]models.LabOrder{
+			{Model: gorm.Model{ID: 30, CreatedAt: now.Add(-2 * time.Hour)}, Priority: constants.LabPriority.URGENT},
+		}, nil)
+
+		list, err := service.GetTasks(2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Male Medical", list.WardName)
+		assert.Len(t, list.Tasks, 3)
+
+		// Ordered by due time: the sample, the stat dose not yet charted, then
+		// observations four hours after the last set.
+		assert.Equal(t, constants.NursingTaskTypes.SAMPLE_COLLECTION, list.Tasks[0].Type)
+		assert.Equal(t, uint(30), *list.Tasks[0].LabOrderID)
+		assert.True(t, list.Tasks[0].Overdue)
+
+		assert.Equal(t, constants.NursingTaskTypes.MEDICATION, list.Tasks[1].Type)
+		assert.Equal(t, uint(12), *list.Tasks[1].PrescriptionID)
+		assert.Equal(t, "Give Ceftriaxone 1 g iv (STAT)", list.Tasks[1].Description)
+		assert.Equal(t, stat, list.Tasks[1].DueAt)
+		assert.True(t, list.Tasks[1].Overdue)
+
+		assert.Equal(t, constants.NursingTaskTypes.VITAL_SIGNS, list.Tasks[2].Type)
+		assert.False(t, list.Tasks[2].Overdue)
+		assert.Equal(t, "A2", list.Tasks[2].BedLabel)
+		assert.Equal(t, "Ada Obi", list.Tasks[2].PatientName)
+	})
+
+	t.Run("NoObservationsSinceAdmission", func(t *testing.T) {
+		service, m := newNursingServiceWithMocks()
+
+		m.wards.On("FindByID", uint(2)).Return(ward, nil)
+		m.wards.On("FindOccupancy", uint(2)).Return([]models.BedOccupancy{
+			{BedID: 2, Label: "A2", AdmissionID: uintPtr(9), PatientID: uintPtr(4), AdmittedAt: &admittedAt},
+		}, nil)
+		m.vitals.On("FindLatestByAdmissionID", uint(9)).Return(&models.VitalSign{}, gorm.ErrRecordNotFound)
+		m.prescriptions.On("FindByPatientID", uint(4), mock.Anything).Return([]models.Prescription{}, nil)
+		m.administrations.On("FindByAdmissionID", uint(9)).Return([]models.MedicationAdministration{}, nil)
+		m.labOrders.On("FindAll", mock.Anything).Return([]models.LabOrder{}, nil)
+
+		list, err := service.GetTasks(2)
+
+		assert.NoError(t, err)
+		assert.Len(t, list.Tasks, 1)
+		assert.Equal(t, "Record admission vital signs", list.Tasks[0].Description)
+		assert.Equal(t, admittedAt, list.Tasks[0].DueAt)
+		assert.True(t, list.Tasks[0].Overdue)
+	})
+
+	t.Run("WardNotFound", func(t *testing.T) {
+		service, m := newNursingServiceWithMocks()
+
+		m.wards.On("FindByID", uint(2)).Return(&models.Ward{}, errors.New("record not found"))
+
+		_, err := service.GetTasks(2)
+
+		assert.EqualError(t, err, "ward not found")
+	})
+}
+
+func TestDoseTimes(t *testing.T) {
+	created := time.Date(2025, 3, 1, 10, 0, 0, 0, time.Local)
+	at := func(day, hour int) time.Time {
+		return time.Date(2025, 3, day, hour, 0, 0, 0, time.Local)
+	}
+	prescription := func(frequency string) models.Prescription {
+		return models.Prescription{
+			Model:        gorm.Model{ID: 1, CreatedAt: created},
+			Frequency:    frequency,
+			DurationDays: 2,
+			Status:       constants.PrescriptionStatus.ACTIVE,
+		}
+	}
+
+	t.Run("StandardTimesWithinCourse", func(t *testing.T) {
+		times := doseTimes(prescription(constants.DoseFrequency.BD), created, created.AddDate(0, 0, 7))
+
+		assert.Equal(t, []time.Time{at(1, 20), at(2, 8), at(2, 20), at(3, 8)}, times)
+	})
+
+	t.Run("WithinWindow", func(t *testing.T) {
+		times := doseTimes(prescription(constants.DoseFrequency.TDS), at(2, 0), at(3, 0))
+
+		assert.Equal(t, []time.Time{at(2, 6), at(2, 14), at(2, 22)}, times)
+	})
+
+	t.Run("StopsWhenDiscontinued", func(t *testing.T) {
+		stopped := at(2, 9)
+		discontinued := prescription(constants.DoseFrequency.BD)
+		discontinued.Status = constants.PrescriptionStatus.DISCONTINUED
+		discontinued.StatusChangedAt = &stopped
+
+		times := doseTimes(discontinued, created, created.AddDate(0, 0, 7))
+
+		assert.Equal(t, []time.Time{at(1, 20), at(2, 8)}, times)
+	})
+
+	t.Run("StatWhenPrescribed", func(t *testing.T) {
+		times := doseTimes(prescription(constants.DoseFrequency.STAT), at(1, 0), at(2, 0))
+
+		assert.Equal(t, []time.Time{created}, times)
+	})
+
+	t.Run("AsNeededHasNoTimes", func(t *testing.T) {
+		assert.Empty(t, doseTimes(prescription(constants.DoseFrequency.PRN), created, created.AddDate(0, 0, 7)))
+	})
+}
+
+func TestBuildMarEntries(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2025, 3, 1, hour, minute, 0, 0, time.Local)
+	}
+	from, to := at(0, 0), at(0, 0).AddDate(0, 0, 1)
+	held := at(6, 0)
+	prescriptions := []models.Prescription{
+		{Model: gorm.Model{ID: 1, CreatedAt: at(5, 0)}, Frequency: constants.DoseFrequency.TDS, DurationDays: 3},
+		{Model: gorm.Model{ID: 2, CreatedAt: at(5, 0)}, Frequency: constants.DoseFrequency.PRN, DurationDays: 3},
+	}
+	administrations := []models.MedicationAdministration{
+		{PrescriptionID: 1, ScheduledAt: &held, Status: constants.AdministrationStatus.HELD, Reason: "Nil by mouth"},
+		{PrescriptionID: 2, Status: constants.AdministrationStatus.GIVEN, AdministeredAt: at(12, 0)},
+	}
+
+	t.Run("MatchesChartedDoses", func(t *testing.T) {
+		entries := buildMarEntries(prescriptions, administrations, from, to, at(14, 30), time.Hour)
+
+		assert.Len(t, entries, 2)
+		doses := entries[0].Doses
+		assert.Len(t, doses, 3)
+		assert.Equal(t, constants.AdministrationStatus.HELD, doses[0].Status)
+		assert.Equal(t, "Nil by mouth", doses[0].Administration.Reason)
+		assert.Equal(t, constants.MarDoseStatus.DUE, doses[1].Status)
+		assert.Equal(t, constants.MarDoseStatus.UPCOMING, doses[2].Status)
+		assert.Empty(t, entries[0].Unscheduled)
+
+		assert.Empty(t, entries[1].Doses)
+		assert.Len(t, entries[1].Unscheduled, 1)
+	})
+
+	t.Run("OverdueAfterWindow", func(t *testing.T) {
+		entries := buildMarEntries(prescriptions, administrations, from, to, at(15, 1), time.Hour)
+
+		assert.Equal(t, constants.MarDoseStatus.OVERDUE, entries[0].Doses[1].Status)
+	})
+
+	t.Run("SkipsCoursesOutsideWindow", func(t *testing.T) {
+		later := at(0, 0).AddDate(0, 0, 5)
+		entries := buildMarEntries(prescriptions, administrations, later, later.AddDate(0, 0, 1), later, time.Hour)
+
+		assert.Empty(t, entries)
+	})
+}
+
+func TestGetAdmissionMAR(t *testing.T) {
+	admittedAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.Local)
+	dischargedAt := time.Date(2025, 3, 3, 12, 0, 0, 0, time.Local)
+	admission := &models.Admission{
+		Model:        gorm.Model{ID: 9},
+		PatientID:    4,
+		AdmittedAt:   admittedAt,
+		DischargedAt: &dischargedAt,
+		Status:       constants.AdmissionStatus.DISCHARGED,
+	}
+
+	t.Run("BuildsDay", func(t *testing.T) {
+		service, m := newNursingServiceWithMocks()
+		given := time.Date(2025, 3, 2, 8, 0, 0, 0, time.Local)
+
+		m.admissions.On("FindByID", uint(9)).Return(admission, nil)
+		m.prescriptions.On("FindByPatientID", uint(4), marPrescriptionStatuses).Return([]models.Prescription{
+			{Model: gorm.Model{ID: 12, CreatedAt: admittedAt}, Frequency: constants.DoseFrequency.BD, DurationDays: 5},
+		}, nil)
+		m.administrations.On("FindByAdmissionID", uint(9)).Return([]models.MedicationAdministration{
+			{PrescriptionID: 12, ScheduledAt: &given, Status: constants.AdministrationStatus.GIVEN, AdministeredAt: given},
+		}, nil)
+
+		record, err := service.GetAdmissionMAR(9, models.MarQuery{Date: "2025-03-02"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2025-03-02", record.Date)
+		assert.Len(t, record.Entries, 1)
+		doses := record.Entries[0].Doses
+		assert.Len(t, doses, 2)
+		assert.Equal(t, constants.AdministrationStatus.GIVEN, doses[0].Status)
+		assert.Equal(t, constants.MarDoseStatus.OVERDUE, doses[1].Status)
+	})
+
+	t.Run("DateOutsideAdmission", func(t *testing.T) {
+		service, m := newNursingServiceWithMocks()
+
+		m.admissions.On("FindByID", uint(9)).Return(admission, nil)
+
+		_, err := service.GetAdmissionMAR(9, models.MarQuery{Date: "2025-03-05"})
+
+		assert.EqualError(t, err, "date is outside the admission")
+	})
+}
+
+func TestGetMedicationDashboard(t *testing.T) {
+	service, m := newNursingServiceWithMocks()
+	now := time.Now()
+	admittedAt := now.Add(-48 * time.Hour)
+	late := now.Add(-3 * time.Hour)
+
+	m.wards.On("FindByID", uint(2)).Return(&models.Ward{Model: gorm.Model{ID: 2}, Name: "Male Medical"}, nil)
+	m.wards.On("FindOccupancy", uint(2)).Return([]models.BedOccupancy{
+		{BedID: 2, Label: "A2", AdmissionID: uintPtr(9), PatientID: uintPtr(4), PatientName: "Ada Obi", AdmittedAt: &admittedAt},
+	}, nil)
+	m.prescriptions.On("FindByPatientID", uint(4), marPrescriptionStatuses).Return([]models.Prescription{
+		{Model: gorm.Model{ID: 12, CreatedAt: late}, Frequency: constants.DoseFrequency.STAT, DurationDays: 1},
+		{Model: gorm.Model{ID: 13, CreatedAt: now.Add(-30 * time.Minute)}, Frequency: constants.DoseFrequency.STAT, DurationDays: 1},
+	}, nil)
+	m.administrations.On("FindByAdmissionID", uint(9)).Return([]models.MedicationAdministration{}, nil)
+
+	dashboard, err := service.GetMedicationDashboard(2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 60, dashboard.OverdueWindowMinutes)
+	assert.Len(t, dashboard.Patients, 1)
+	summary := dashboard.Patients[0]
+	assert.Equal(t, 1, summary.DueCount)
+	assert.Equal(t, 1, summary.OverdueCount)
+	assert.Equal(t, uint(12), summary.OverdueDoses[0].PrescriptionID)
+	assert.Equal(t, 180, summary.OverdueDoses[0].MinutesOverdue)
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+	"github.com/ofojichigozie/hms-go-backend/utils"
+)
+
+var errGuardianRequired = errors.New("patients under 18 must have a guardian contact")
+
+type PatientContactService interface {
+	CreateContact(patientID uint, input models.PatientContactInput, createdBy uint) (*models.PatientContact, error)
+	GetContactsByPatientID(patientID uint) ([]models.PatientContact, error)
+	UpdateContact(patientID uint, contactID uint, input models.UpdatePatientContactInput, updatedBy uint) (*models.PatientContact, error)
+	DeleteContact(patientID uint, contactID uint) error
+}
+
+type patientContactService struct {
+	patientContactRepository repositories.PatientContactRepository
+	patientRepository        repositories.PatientRepository
+}
+
+func NewPatientContactService(
+	patientContactRepository repositories.PatientContactRepository,
+	patientRepository repositories.PatientRepository,
+) PatientContactService {
+	return &patientContactService{
+		patientContactRepository: patientContactRepository,
+		patientRepository:        patientRepository,
+	}
+}
+
+func (cs *patientContactService) CreateContact(patientID uint, input models.PatientContactInput, createdBy uint) (*models.PatientContact, error) {
+	patient, err := cs.patientRepository.FindByID(patientID)
+	if err != nil || patient == nil {
+		return nil, errors.New("patient record not found")
+	}
+
+	if input.Priority == 0 {
+		existing, err := cs.patientContactRepository.FindByPatientID(patientID)
+		if err != nil {
+			return nil, err
+		}
+		input.Priority = nextContactPriority(existing)
+	}
+
+	contact := newPatientContact(patientID, input, createdBy)
+	if err := cs.patientContactRepository.Create(&contact); err != nil {
+		return nil, err
+	}
+
+	return &contact, nil
+}
+
+func (cs *patientContactService) GetContactsByPatientID(patientID uint) ([]models.PatientContact, error) {
+	patient, err := cs.patientRepository.FindByID(patientID)
+	if err != nil || patient == nil {
+		return nil, errors.New("patient record not found")
+	}
+
+	return cs.patientContactRepository.FindByPatientID(patientID)
+}
+
+func (cs *patientContactService) UpdateContact(patientID uint, contactID uint, input models.UpdatePatientContactInput, updatedBy uint) (*models.PatientContact, error) {
+	contact, err := cs.patientContactRepository.FindByID(contactID)
+	if err != nil || contact.PatientID != patientID {
+		return nil, errors.New("contact not found")
+	}
+
+	if input.ContactType != nil && *input.ContactType != constants.ContactTypes.GUARDIAN &&
+		contact.ContactType == constants.ContactTypes.GUARDIAN {
+		if err := cs.ensureGuardianRemains(patientID, contact.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.ContactType != nil {
+		contact.ContactType = *input.ContactType
+	}
+	if input.Relationship != nil {
+		contact.Relationship = *input.Relationship
+	}
+	if input.Priority != nil {
+		contact.Priority = *input.Priority
+	}
+	if input.FullName != nil {
+		contact.FullName = strings.TrimSpace(*input.FullName)
+	}
+	if input.PhoneNumber != nil {
+		contact.PhoneNumber = *input.PhoneNumber
+	}
+	if input.Email != nil {
+		contact.Email = *input.Email
+	}
+	if input.Address != nil {
+		contact.Address = *input.Address
+	}
+	contact.UpdatedBy = updatedBy
+
+	if err := cs.patientContactRepository.Update(contact); err != nil {
+		return nil, err
+	}
+
+	return contact, nil
+}
+
+func (cs *patientContactService) DeleteContact(patientID uint, contactID uint) error {
+	contact, err := cs.patientContactRepository.FindByID(contactID)
+	if err != nil || contact.PatientID != patientID {
+		return errors.New("contact not found")
+	}
+
+	if contact.ContactType == constants.ContactTypes.GUARDIAN {
+		if err := cs.ensureGuardianRemains(patientID, contact.ID); err != nil {
+			return err
+		}
+	}
+
+	return cs.patientContactRepository.Delete(contact.ID)
+}
+
+// ensureGuardianRemains stops a minor's last guardian from being removed.
+func (cs *patientContactService) ensureGuardianRemains(patientID uint, removedContactID uint) error {
+	patient, err := cs.patientRepository.FindByID(patientID)
+	if err != nil {
+		return errors.New("patient record not found")
+	}
+	if !isMinor(patient.DateOfBirth) {
+		return nil
+	}
+
+	contacts, err := cs.patientContactRepository.FindByPatientID(patientID)
+	if err != nil {
+		return err
+	}
+	if !hasGuardian(contacts, removedContactID) {
+		return errGuardianRequired
+	}
+	return nil
+}
+
+// hasGuardian reports whether any contact other than excludedContactID is a
+// guardian.
+func hasGuardian(contacts []models.PatientContact, excludedContactID uint) bool {
+	for _, contact := range contacts {
+		if contact.ID != excludedContactID && contact.ContactType == constants.ContactTypes.GUARDIAN {
+			return true
+		}
+	}
+	return false
+}
+
+func newPatientContact(patientID uint, input models.PatientContactInput, createdBy uint) models.PatientContact {
+	priority := input.Priority
+	if priority == 0 {
+		priority = 1
+	}
+	return models.PatientContact{
+		PatientID:    patientID,
+		ContactType:  input.ContactType,
+		Relationship: input.Relationship,
+		Priority:     priority,
+		FullName:     strings.TrimSpace(input.FullName),
+		PhoneNumber:  input.PhoneNumber,
+		Email:        input.Email,
+		Address:      input.Address,
+		CreatedBy:    createdBy,
+		UpdatedBy:    createdBy,
+	}
+}
+
+func nextContactPriority(contacts []models.PatientContact) int {
+	priority := 1
+	for _, contact := range contacts {
+		if contact.Priority >= priority {
+			priority = contact.Priority + 1
+		}
+	}
+	return min(priority, 10)
+}
+
+func isMinor(dateOfBirth time.Time) bool {
+	return utils.AgeInYears(dateOfBirth, time.Now()) < constants.AgeOfMajority
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestCreatePatientContact(t *testing.T) {
+	t.Run("DefaultsPriorityAfterExisting", func(t *testing.T) {
+		mockContactRepo := new(mocks.PatientContactRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		service := NewPatientContactService(mockContactRepo, mockPatientRepo)
+
+		mockPatientRepo.On("FindByID", uint(1)).Return(&models.Patient{Model: gorm.Model{ID: 1}}, nil)
+		mockContactRepo.On("FindByPatientID", uint(1)).Return([]models.PatientContact{
+			{Model: gorm.Model{ID: 3}, Priority: 1},
+			{Model: gorm.Model{ID: 4}, Priority: 2},
+		}, nil)
+		mockContactRepo.On("Create", mock.AnythingOfType("*models.PatientContact")).Return(nil)
+
+		contact, err := service.CreateContact(1, models.PatientContactInput{
+			ContactType:  constants.ContactTypes.EMERGENCY,
+			Relationship: "friend",
+			FullName:     " Emeka Obi ",
+			PhoneNumber:  "+2348030000000",
+		}, 5)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, contact.Priority)
+		assert.Equal(t, "Emeka Obi", contact.FullName)
+		assert.Equal(t, uint(5), contact.CreatedBy)
+	})
+}
+
+func TestDeletePatientContact(t *testing.T) {
+	minorDOB := time.Now().AddDate(-10, 0, 0)
+
+	t.Run("LastGuardianOfMinor", func(t *testing.T) {
+		mockContactRepo := new(mocks.PatientContactRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		service := NewPatientContactService(mockContactRepo, mockPatientRepo)
+
+		guardian := models.PatientContact{Model: gorm.Model{ID: 3}, PatientID: 1,
+			ContactType: constants.ContactTypes.GUARDIAN}
+
+		mockContactRepo.On("FindByID", uint(3)).Return(&guardian, nil)
+		mockPatientRepo.On("FindByID", uint(1)).Return(&models.Patient{Model: gorm.Model{ID: 1}, DateOfBirth: minorDOB}, nil)
+		mockContactRepo.On("FindByPatientID", uint(1)).Return([]models.PatientContact{guardian}, nil)
+
+		err := service.DeleteContact(1, 3)
+
+		assert.EqualError(t, err, "patients under 18 must have a guardian contact")
+		mockContactRepo.AssertNotCalled(t, "Delete", mock.Anything)
+	})
+
+	t.Run("AnotherGuardianRemains", func(t *testing.T) {
+		mockContactRepo := new(mocks.PatientContactRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		service := NewPatientContactService(mockContactRepo, mockPatientRepo)
+
+		guardian := models.PatientContact{Model: gorm.Model{ID: 3}, PatientID: 1,
+			ContactType: constants.ContactTypes.GUARDIAN}
+		otherGuardian := models.PatientContact{Model: gorm.Model{ID: 4}, PatientID: 1,
+			ContactType: constants.ContactTypes.GUARDIAN}
+
+		mockContactRepo.On("FindByID", uint(3)).Return(&guardian, nil)
+		mockPatientRepo.On("FindByID", uint(1)).Return(&models.Patient{Model: gorm.Model{ID: 1}, DateOfBirth: minorDOB}, nil)
+		mockContactRepo.On("FindByPatientID", uint(1)).Return([]models.PatientContact{guardian, otherGuardian}, nil)
+		mockContactRepo.On("Delete", uint(3)).Return(nil)
+
+		err := service.DeleteContact(1, 3)
+
+		assert.NoError(t, err)
+		mockContactRepo.AssertExpectations(t)
+	})
+
+	t.Run("BelongsToAnotherPatient", func(t *testing.T) {
+		mockContactRepo := new(mocks.PatientContactRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		service := NewPatientContactService(mockContactRepo, mockPatientRepo)
+
+		mockContactRepo.On("FindByID", uint(3)).Return(&models.PatientContact{Model: gorm.Model{ID: 3}, PatientID: 2}, nil)
+
+		err := service.DeleteContact(1, 3)
+
+		assert.EqualError(t, err, "contact not found")
+	})
+}
+
+func TestUpdatePatientContact(t *testing.T) {
+	t.Run("CannotDemoteLastGuardianOfMinor", func(t *testing.T) {
+		mockContactRepo := new(mocks.PatientContactRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		service := NewPatientContactService(mockContactRepo, mockPatientRepo)
+
+		guardian := models.PatientContact{Model: gorm.Model{ID: 3}, PatientID: 1,
+			ContactType: constants.ContactTypes.GUARDIAN}
+		emergency := constants.ContactTypes.EMERGENCY
+
+		mockContactRepo.On("FindByID", uint(3)).Return(&guardian, nil)
+		mockPatientRepo.On("FindByID", uint(1)).Return(&models.Patient{Model: gorm.Model{ID: 1},
+			DateOfBirth: time.Now().AddDate(-5, 0, 0)}, nil)
+		mockContactRepo.On("FindByPatientID", uint(1)).Return([]models.PatientContact{guardian}, nil)
+
+		contact, err := service.UpdateContact(1, 3, models.UpdatePatientContactInput{ContactType: &emergency}, 5)
+
+		assert.Nil(t, contact)
+		assert.EqualError(t, err, "patients under 18 must have a guardian contact")
+		mockContactRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+}
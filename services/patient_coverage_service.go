@@ -0,0 +1,212 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+)
+
+type PatientCoverageService interface {
+	CreateCoverage(patientID uint, input models.CreateCoverageInput, createdBy uint) (*models.PatientCoverage, error)
+	GetCoveragesByPatientID(patientID uint) ([]models.PatientCoverage, error)
+	UpdateCoverage(patientID uint, coverageID uint, input models.UpdateCoverageInput, updatedBy uint) (*models.PatientCoverage, error)
+	CheckEligibility(patientID uint, coverageID uint) (*EligibilityResult, error)
+}
+
+type patientCoverageService struct {
+	patientCoverageRepository repositories.PatientCoverageRepository
+	payerRepository           repositories.PayerRepository
+	patientRepository         repositories.PatientRepository
+	eligibilityChecker        EligibilityChecker
+}
+
+func NewPatientCoverageService(
+	patientCoverageRepository repositories.PatientCoverageRepository,
+	payerRepository repositories.PayerRepository,
+	patientRepository repositories.PatientRepository,
+	eligibilityChecker EligibilityChecker,
+) PatientCoverageService {
+	return &patientCoverageService{
+		patientCoverageRepository: patientCoverageRepository,
+		payerRepository:           payerRepository,
+		patientRepository:         patientRepository,
+		eligibilityChecker:        eligibilityChecker,
+	}
+}
+
+func (cs *patientCoverageService) CreateCoverage(patientID uint, input models.CreateCoverageInput, createdBy uint) (*models.PatientCoverage, error) {
+	patient, err := cs.patientRepository.FindByID(patientID)
+	if err != nil || patient == nil {
+		return nil, errors.New("patient record not found")
+	}
+
+	plan, err := cs.payerRepository.FindPlanByID(input.PlanID)
+	if err != nil || !plan.IsActive || plan.Payer == nil || !plan.Payer.IsActive {
+		return nil, errors.New("insurance plan not found or inactive")
+	}
+
+	coverage := &models.PatientCoverage{
+		PatientID:    patientID,
+		PlanID:       plan.ID,
+		MemberNumber: strings.TrimSpace(input.MemberNumber),
+		Priority:     input.Priority,
+		IsActive:     true,
+		CreatedBy:    createdBy,
+		UpdatedBy:    createdBy,
+	}
+	if coverage.Priority == "" {
+		coverage.Priority = constants.CoveragePriority.PRIMARY
+	}
+	if err := applyCoverageDates(coverage, &input.ValidFrom, &input.ValidTo); err != nil {
+		return nil, err
+	}
+	if err := cs.ensureSinglePrimary(coverage); err != nil {
+		return nil, err
+	}
+
+	if err := cs.patientCoverageRepository.Create(coverage); err != nil {
+		return nil, err
+	}
+
+	coverage.Plan = plan
+	return coverage, nil
+}
+
+func (cs *patientCoverageService) GetCoveragesByPatientID(patientID uint) ([]models.PatientCoverage, error) {
+	patient, err := cs.patientRepository.FindByID(patientID)
+	if err != nil || patient == nil {
+		return nil, errors.New("patient record not found")
+	}
+
+	return cs.patientCoverageRepository.FindByPatientID(patientID)
+}
+
+func (cs *patientCoverageService) UpdateCoverage(patientID uint, coverageID uint, input models.UpdateCoverageInput, updatedBy uint) (*models.PatientCoverage, error) {
+	coverage, err := cs.patientCoverageRepository.FindByID(coverageID)
+	if err != nil || coverage.PatientID != patientID {
+		return nil, errors.New("coverage not found")
+	}
+
+	if input.MemberNumber != nil {
+		coverage.MemberNumber = strings.TrimSpace(*input.MemberNumber)
+	}
+	if input.Priority != nil {
+		coverage.Priority = *input.Priority
+	}
+	if input.IsActive != nil {
+		coverage.IsActive = *input.IsActive
+	}
+	if err := applyCoverageDates(coverage, input.ValidFrom, input.ValidTo); err != nil {
+		return nil, err
+	}
+	if err := cs.ensureSinglePrimary(coverage); err != nil {
+		return nil, err
+	}
+	coverage.UpdatedBy = updatedBy
+
+	if err := cs.patientCoverageRepository.Update(coverage); err != nil {
+		return nil, err
+	}
+
+	return coverage, nil
+}
+
+// CheckEligibility asks the configured checker and keeps the latest answer on
+// the coverage.
+func (cs *patientCoverageService) CheckEligibility(patientID uint, coverageID uint) (*EligibilityResult, error) {
+	coverage, err := cs.patientCoverageRepository.FindByID(coverageID)
+	if err != nil || coverage.PatientID != patientID {
+		return nil, errors.New("coverage not found")
+	}
+
+	result, err := cs.eligibilityChecker.CheckEligibility(*coverage, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("eligibility check failed: %w", err)
+	}
+
+	coverage.EligibilityStatus = result.Status
+	coverage.EligibilityCheckedAt = &result.CheckedAt
+	if err := cs.patientCoverageRepository.Update(coverage); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ensureSinglePrimary allows one active primary coverage per patient at a time.
+func (cs *patientCoverageService) ensureSinglePrimary(coverage *models.PatientCoverage) error {
+	if !coverage.IsActive || coverage.Priority != constants.CoveragePriority.PRIMARY {
+		return nil
+	}
+
+	existing, err := cs.patientCoverageRepository.FindByPatientID(coverage.PatientID)
+	if err != nil {
+		return err
+	}
+	for _, other := range existing {
+		if other.ID != coverage.ID && other.IsActive && other.Priority == constants.CoveragePriority.PRIMARY &&
+			other.CoversDate(time.Now()) {
+			return errors.New("patient already has an active primary coverage; make it secondary or inactive first")
+		}
+	}
+	return nil
+}
+
+func applyCoverageDates(coverage *models.PatientCoverage, validFrom, validTo *string) error {
+	if validFrom != nil && *validFrom != "" {
+		parsed, err := time.Parse("2006-01-02", *validFrom)
+		if err != nil {
+			return errors.New("invalid date format, use YYYY-MM-DD")
+		}
+		coverage.ValidFrom = parsed
+	}
+	if validTo != nil {
+		if *validTo == "" {
+			coverage.ValidTo = nil
+		} else {
+			parsed, err := time.Parse("2006-01-02", *validTo)
+			if err != nil {
+				return errors.New("invalid date format, use YYYY-MM-DD")
+			}
+			coverage.ValidTo = &parsed
+		}
+	}
+
+	if coverage.ValidTo != nil && coverage.ValidTo.Before(coverage.ValidFrom) {
+		return errors.New("coverage cannot end before it starts")
+	}
+	return nil
+}
+
+// coverageWarnings describes active coverages that are not in force on the
+// given day. Patients without coverage are self-paying and get no warning.
+func coverageWarnings(coverages []models.PatientCoverage, at time.Time) []string {
+	var warnings []string
+	for _, coverage := range coverages {
+		if !coverage.IsActive || coverage.CoversDate(at) {
+			continue
+		}
+
+		name := "insurance coverage"
+		if coverage.Plan != nil {
+			name = coverage.Plan.Name
+			if coverage.Plan.Payer != nil {
+				name = coverage.Plan.Payer.Name + " " + name
+			}
+		}
+
+		if coverage.ValidTo != nil && at.After(*coverage.ValidTo) {
+			warnings = append(warnings, fmt.Sprintf("%s coverage (member %s) expired on %s",
+				name, coverage.MemberNumber, coverage.ValidTo.Format("2006-01-02")))
+		} else {
+			warnings = append(warnings, fmt.Sprintf("%s coverage (member %s) starts on %s",
+				name, coverage.MemberNumber, coverage.ValidFrom.Format("2006-01-02")))
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func activePlan() *models.InsurancePlan {
+	return &models.InsurancePlan{
+		Model:    gorm.Model{ID: 3},
+		Name:     "Gold",
+		IsActive: true,
+		Payer:    &models.Payer{Name: "Acme HMO", IsActive: true},
+	}
+}
+
+func TestCreateCoverage(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockCoverageRepo := new(mocks.PatientCoverageRepository)
+		mockPayerRepo := new(mocks.PayerRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		service := NewPatientCoverageService(mockCoverageRepo, mockPayerRepo, mockPatientRepo, NewLocalEligibilityChecker())
+
+		mockPatientRepo.On("FindByID", uint(1)).Return(&models.Patient{Model: gorm.Model{ID: 1}}, nil)
+		mockPayerRepo.On("FindPlanByID", uint(3)).Return(activePlan(), nil)
+		mockCoverageRepo.On("FindByPatientID", uint(1)).Return([]models.PatientCoverage{}, nil)
+		mockCoverageRepo.On("Create", mock.AnythingOfType("*models.PatientCoverage")).Return(nil)
+
+		coverage, err := service.CreateCoverage(1, models.CreateCoverageInput{
+			PlanID:       3,
+			MemberNumber: "HMO-123",
+			ValidFrom:    "2024-01-01",
+			ValidTo:      "2030-12-31",
+		}, 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, constants.CoveragePriority.PRIMARY, coverage.Priority)
+		assert.Equal(t, "2030-12-31", coverage.ValidTo.Format("2006-01-02"))
+		assert.Equal(t, uint(2), coverage.CreatedBy)
+		mockCoverageRepo.AssertExpectations(t)
+	})
+
+	t.Run("EndsBeforeStart", func(t *testing.T) {
+		mockCoverageRepo := new(mocks.PatientCoverageRepository)
+		mockPayerRepo := new(mocks.PayerRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		service := NewPatientCoverageService(mockCoverageRepo, mockPayerRepo, mockPatientRepo, NewLocalEligibilityChecker())
+
+		mockPatientRepo.On("FindByID", uint(1)).Return(&models.Patient{Model: gorm.Model{ID: 1}}, nil)
+		mockPayerRepo.On("FindPlanByID", uint(3)).Return(activePlan(), nil)
+
+		coverage, err := service.CreateCoverage(1, models.CreateCoverageInput{
+			PlanID:       3,
+			MemberNumber: "HMO-123",
+			ValidFrom:    "2025-01-01",
+			ValidTo:      "2024-12-31",
+		}, 2)
+
+		assert.EqualError(t, err, "coverage cannot end before it starts")
+		assert.Nil(t, coverage)
+	})
+
+	t.Run("SecondActivePrimary", func(t *testing.T) {
+		mockCoverageRepo := new(mocks.PatientCoverageRepository)
+		mockPayerRepo := new(mocks.PayerRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		service := NewPatientCoverageService(mockCoverageRepo, mockPayerRepo, mockPatientRepo, NewLocalEligibilityChecker())
+
+		mockPatientRepo.On("FindByID", uint(1)).Return(&models.Patient{Model: gorm.Model{ID: 1}}, nil)
+		mockPayerRepo.On("FindPlanByID", uint(3)).Return(activePlan(), nil)
+		mockCoverageRepo.On("FindByPatientID", uint(1)).Return([]models.PatientCoverage{{
+			Model:     gorm.Model{ID: 7},
+			Priority:  constants.CoveragePriority.PRIMARY,
+			IsActive:  true,
+			ValidFrom: time.Now().AddDate(-1, 0, 0),
+		}}, nil)
+
+		coverage, err := service.CreateCoverage(1, models.CreateCoverageInput{
+			PlanID:       3,
+			MemberNumber: "HMO-456",
+			ValidFrom:    "2024-01-01",
+		}, 2)
+
+		assert.Error(t, err)
+		assert.Nil(t, coverage)
+		mockCoverageRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("InactivePlan", func(t *testing.T) {
+		mockCoverageRepo := new(mocks.PatientCoverageRepository)
+		mockPayerRepo := new(mocks.PayerRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		service := NewPatientCoverageService(mockCoverageRepo, mockPayerRepo, mockPatientRepo, NewLocalEligibilityChecker())
+
+		plan := activePlan()
+		plan.IsActive = false
+		mockPatientRepo.On("FindByID", uint(1)).Return(&models.Patient{Model: gorm.Model{ID: 1}}, nil)
+		mockPayerRepo.On("FindPlanByID", uint(3)).Return(plan, nil)
+
+		coverage, err := service.CreateCoverage(1, models.CreateCoverageInput{
+			PlanID:       3,
+			MemberNumber: "HMO-123",
+			ValidFrom:    "2024-01-01",
+		}, 2)
+
+		assert.EqualError(t, err, "insurance plan not found or inactive")
+		assert.Nil(t, coverage)
+	})
+}
+
+func TestCheckEligibility(t *testing.T) {
+	t.Run("StoresResult", func(t *testing.T) {
+		mockCoverageRepo := new(mocks.PatientCoverageRepository)
+		service := NewPatientCoverageService(mockCoverageRepo, new(mocks.PayerRepository), new(mocks.PatientRepository), NewLocalEligibilityChecker())
+
+		expired := time.Now().AddDate(0, 0, -2)
+		mockCoverageRepo.On("FindByID", uint(7)).Return(&models.PatientCoverage{
+			Model:     gorm.Model{ID: 7},
+			PatientID: 1,
+			IsActive:  true,
+			ValidFrom: expired.AddDate(-1, 0, 0),
+			ValidTo:   &expired,
+			Plan:      activePlan(),
+		}, nil)
+		mockCoverageRepo.On("Update", mock.MatchedBy(func(coverage *models.PatientCoverage) bool {
+			return coverage.EligibilityStatus == constants.EligibilityStatus.NOT_ELIGIBLE && coverage.EligibilityCheckedAt != nil
+		})).Return(nil)
+
+		result, err := service.CheckEligibility(1, 7)
+
+		assert.NoError(t, err)
+		assert.Equal(t, constants.EligibilityStatus.NOT_ELIGIBLE, result.Status)
+		assert.Equal(t, "coverage is outside its validity period", result.Reason)
+		mockCoverageRepo.AssertExpectations(t)
+	})
+
+	t.Run("OtherPatientsCoverage", func(t *testing.T) {
+		mockCoverageRepo := new(mocks.PatientCoverageRepository)
+		service := NewPatientCoverageService(mockCoverageRepo, new(mocks.PayerRepository), new(mocks.PatientRepository), NewLocalEligibilityChecker())
+
+		mockCoverageRepo.On("FindByID", uint(7)).Return(&models.PatientCoverage{Model: gorm.Model{ID: 7}, PatientID: 2}, nil)
+
+		result, err := service.CheckEligibility(1, 7)
+
+		assert.EqualError(t, err, "coverage not found")
+		assert.Nil(t, result)
+	})
+}
+
+func TestLocalEligibilityChecker(t *testing.T) {
+	checker := NewLocalEligibilityChecker()
+	now := time.Now()
+
+	t.Run("Eligible", func(t *testing.T) {
+		result, err := checker.CheckEligibility(models.PatientCoverage{
+			IsActive:  true,
+			ValidFrom: now.AddDate(0, -1, 0),
+			Plan:      activePlan(),
+		}, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, constants.EligibilityStatus.ELIGIBLE, result.Status)
+		assert.Empty(t, result.Reason)
+	})
+
+	t.Run("InactivePayer", func(t *testing.T) {
+		plan := activePlan()
+		plan.Payer.IsActive = false
+
+		result, err := checker.CheckEligibility(models.PatientCoverage{
+			IsActive:  true,
+			ValidFrom: now.AddDate(0, -1, 0),
+			Plan:      plan,
+		}, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, constants.EligibilityStatus.NOT_ELIGIBLE, result.Status)
+		assert.Equal(t, "payer is inactive", result.Reason)
+	})
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+	"github.com/ofojichigozie/hms-go-backend/storage"
+)
+
+var (
+	ErrDocumentTooLarge        = errors.New("document exceeds the maximum upload size")
+	ErrUnsupportedDocumentType = errors.New("document type is not supported")
+	ErrDocumentRejected        = errors.New("document was rejected by the virus scanner")
+)
+
+type PatientDocumentService interface {
+	UploadDocument(patientID uint, input models.UploadDocumentInput, fileName string, content []byte, uploadedBy uint) (*models.PatientDocument, error)
+	GetDocumentsByPatientID(patientID uint, query models.DocumentQuery) ([]models.PatientDocument, error)
+	// OpenDocument returns the document and its content; the caller closes it.
+	OpenDocument(patientID uint, documentID uint) (*models.PatientDocument, io.ReadCloser, error)
+	MaxSizeBytes() int64
+}
+
+type patientDocumentService struct {
+	patientDocumentRepository repositories.PatientDocumentRepository
+	patientRepository         repositories.PatientRepository
+	storage                   storage.Backend
+	scanner                   DocumentScanner
+	maxSizeBytes              int64
+}
+
+func NewPatientDocumentService(
+	patientDocumentRepository repositories.PatientDocumentRepository,
+	patientRepository repositories.PatientRepository,
+	documentStorage storage.Backend,
+	scanner DocumentScanner,
+	maxSizeBytes int64,
+) PatientDocumentService {
+	return &patientDocumentService{
+		patientDocumentRepository: patientDocumentRepository,
+		patientRepository:         patientRepository,
+		storage:                   documentStorage,
+		scanner:                   scanner,
+		maxSizeBytes:              maxSizeBytes,
+	}
+}
+
+// DocumentMaxSizeFromEnv reads DOCUMENT_MAX_SIZE_MB, falling back to the
+// default limit when it is unset or invalid.
+func DocumentMaxSizeFromEnv() int64 {
+	megabytes, err := strconv.Atoi(os.Getenv("DOCUMENT_MAX_SIZE_MB"))
+	if err != nil || megabytes <= 0 {
+		megabytes = constants.DefaultDocumentMaxSizeMB
+	}
+	return int64(megabytes) << 20
+}
+
+func (ds *patientDocumentService) MaxSizeBytes() int64 {
+	return ds.maxSizeBytes
+}
+
+func (ds *patientDocumentService) UploadDocument(patientID uint, input models.UploadDocumentInput, fileName string, content []byte, uploadedBy uint) (*models.PatientDocument, error) {
+	patient, err := ds.patientRepository.FindByID(patientID)
+	if err != nil || patient == nil {
+		return nil, errors.New("patient record not found")
+	}
+
+	if int64(len(content)) > ds.maxSizeBytes {
+		return nil, ErrDocumentTooLarge
+	}
+	if len(content) == 0 {
+		return nil, errors.New("document is empty")
+	}
+
+	detected := mimetype.Detect(content)
+	if !slices.ContainsFunc(constants.DocumentContentTypes, detected.Is) {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedDocumentType, detected.String())
+	}
+
+	fileName = filepath.Base(fileName)
+	if err := ds.scanner.Scan(fileName, content); err != nil {
+		return nil, err
+	}
+
+	checksum := sha256.Sum256(content)
+	key, err := documentStorageKey(patientID, detected.Extension())
+	if err != nil {
+		return nil, err
+	}
+
+	contentType, _, _ := strings.Cut(detected.String(), ";")
+	if err := ds.storage.Put(key, content, contentType); err != nil {
+		return nil, fmt.Errorf("failed to store document: %w", err)
+	}
+
+	document := &models.PatientDocument{
+		PatientID:      patientID,
+		Category:       input.Category,
+		Title:          strings.TrimSpace(input.Title),
+		Description:    input.Description,
+		FileName:       fileName,
+		ContentType:    contentType,
+		SizeBytes:      int64(len(content)),
+		ChecksumSHA256: hex.EncodeToString(checksum[:]),
+		StorageBackend: ds.storage.Name(),
+		StorageKey:     key,
+		UploadedBy:     uploadedBy,
+	}
+
+	if err := ds.patientDocumentRepository.Create(document); err != nil {
+		// Do not leave an orphaned object behind when the record cannot be saved.
+		ds.storage.Delete(key)
+		return nil, err
+	}
+
+	return document, nil
+}
+
+func (ds *patientDocumentService) GetDocumentsByPatientID(patientID uint, query models.DocumentQuery) ([]models.PatientDocument, error) {
+	patient, err := ds.patientRepository.FindByID(patientID)
+	if err != nil || patient == nil {
+		return nil, errors.New("patient record not found")
+	}
+
+	return ds.patientDocumentRepository.FindByPatientID(patientID, query.Category)
+}
+
+func (ds *patientDocumentService) OpenDocument(patientID uint, documentID uint) (*models.PatientDocument, io.ReadCloser, error) {
+	document, err := ds.patientDocumentRepository.FindByID(documentID)
+	if err != nil || document.PatientID != patientID {
+		return nil, nil, errors.New("document not found")
+	}
+
+	content, err := ds.storage.Get(document.StorageKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read document: %w", err)
+	}
+
+	return document, content, nil
+}
+
+// documentStorageKey uses a random name so keys never leak patient-supplied
+// file names and never collide.
+func documentStorageKey(patientID uint, extension string) (string, error) {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("patients/%d/%s%s", patientID, hex.EncodeToString(random), extension), nil
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+var samplePDF = []byte("%PDF-1.4\n1 0 obj << /Type /Catalog >> endobj\ntrailer << /Root 1 0 R >>\n%%EOF\n")
+
+func newDocumentServiceWithMocks(maxSize int64) (PatientDocumentService, *mocks.PatientDocumentRepository, *mocks.PatientRepository, *mocks.StorageBackend, *mocks.DocumentScanner) {
+	documentRepo := new(mocks.PatientDocumentRepository)
+	patientRepo := new(mocks.PatientRepository)
+	backend := new(mocks.StorageBackend)
+	scanner := new(mocks.DocumentScanner)
+	service := NewPatientDocumentService(documentRepo, patientRepo, backend, scanner, maxSize)
+	return service, documentRepo, patientRepo, backend, scanner
+}
+
+func TestUploadDocument(t *testing.T) {
+	input := models.UploadDocumentInput{Category: constants.DocumentCategories.REFERRAL_LETTER, Title: "Referral from GP"}
+
+	t.Run("Success", func(t *testing.T) {
+		service, documentRepo, patientRepo, backend, scanner := newDocumentServiceWithMocks(1 << 20)
+
+		patientRepo.On("FindByID", uint(1)).Return(&models.Patient{Model: gorm.Model{ID: 1}}, nil)
+		scanner.On("Scan", "letter.pdf", samplePDF).Return(nil)
+		backend.On("Put", mock.MatchedBy(func(key string) bool {
+			return strings.HasPrefix(key, "patients/1/") && strings.HasSuffix(key, ".pdf")
+		}), samplePDF, "application/pdf").Return(nil)
+		documentRepo.On("Create", mock.AnythingOfType("*models.PatientDocument")).Return(nil)
+
+		document, err := service.UploadDocument(1, input, "../../letter.pdf", samplePDF, 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "letter.pdf", document.FileName)
+		assert.Equal(t, "application/pdf", document.ContentType)
+		assert.Equal(t, int64(len(samplePDF)), document.SizeBytes)
+		assert.Len(t, document.ChecksumSHA256, 64)
+		assert.Equal(t, "mock", document.StorageBackend)
+		backend.AssertExpectations(t)
+		documentRepo.AssertExpectations(t)
+	})
+
+	t.Run("TooLarge", func(t *testing.T) {
+		service, _, patientRepo, backend, _ := newDocumentServiceWithMocks(10)
+
+		patientRepo.On("FindByID", uint(1)).Return(&models.Patient{Model: gorm.Model{ID: 1}}, nil)
+
+		document, err := service.UploadDocument(1, input, "letter.pdf", samplePDF, 2)
+
+		assert.ErrorIs(t, err, ErrDocumentTooLarge)
+		assert.Nil(t, document)
+		backend.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("UnsupportedType", func(t *testing.T) {
+		service, _, patientRepo, backend, _ := newDocumentServiceWithMocks(1 << 20)
+
+		patientRepo.On("FindByID", uint(1)).Return(&models.Patient{Model: gorm.Model{ID: 1}}, nil)
+
+		// A script renamed to .pdf is still detected as text.
+		document, err := service.UploadDocument(1, input, "letter.pdf", []byte("#!/bin/sh\necho hi\n"), 2)
+
+		assert.ErrorIs(t, err, ErrUnsupportedDocumentType)
+		assert.Nil(t, document)
+		backend.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("RejectedByScanner", func(t *testing.T) {
+		service, _, patientRepo, backend, scanner := newDocumentServiceWithMocks(1 << 20)
+
+		patientRepo.On("FindByID", uint(1)).Return(&models.Patient{Model: gorm.Model{ID: 1}}, nil)
+		scanner.On("Scan", "letter.pdf", samplePDF).Return(fmt.Errorf("%w: Eicar-Signature FOUND", ErrDocumentRejected))
+
+		document, err := service.UploadDocument(1, input, "letter.pdf", samplePDF, 2)
+
+		assert.ErrorIs(t, err, ErrDocumentRejected)
+		assert.Nil(t, document)
+		backend.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("RemovesStoredFileWhenSaveFails", func(t *testing.T) {
+		service, documentRepo, patientRepo, backend, scanner := newDocumentServiceWithMocks(1 << 20)
+
+		patientRepo.On("FindByID", uint(1)).Return(&models.Patient{Model: gorm.Model{ID: 1}}, nil)
+		scanner.On("Scan", "letter.pdf", samplePDF).Return(nil)
+		backend.On("Put", mock.Anything, samplePDF, "application/pdf").Return(nil)
+		backend.On("Delete", mock.Anything).Return(nil)
+		documentRepo.On("Create", mock.AnythingOfType("*models.PatientDocument")).Return(errors.New("database error"))
+
+		document, err := service.UploadDocument(1, input, "letter.pdf", samplePDF, 2)
+
+		assert.Error(t, err)
+		assert.Nil(t, document)
+		backend.AssertCalled(t, "Delete", backend.Calls[0].Arguments.String(0))
+	})
+}
+
+func TestOpenDocument(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		service, documentRepo, _, backend, _ := newDocumentServiceWithMocks(1 << 20)
+
+		documentRepo.On("FindByID", uint(4)).Return(&models.PatientDocument{
+			Model:      gorm.Model{ID: 4},
+			PatientID:  1,
+			StorageKey: "patients/1/abc.pdf",
+		}, nil)
+		backend.On("Get", "patients/1/abc.pdf").Return(io.NopCloser(strings.NewReader("content")), nil)
+
+		document, content, err := service.OpenDocument(1, 4)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(4), document.ID)
+		body, _ := io.ReadAll(content)
+		assert.Equal(t, "content", string(body))
+	})
+
+	t.Run("OtherPatientsDocument", func(t *testing.T) {
+		service, documentRepo, _, backend, _ := newDocumentServiceWithMocks(1 << 20)
+
+		documentRepo.On("FindByID", uint(4)).Return(&models.PatientDocument{Model: gorm.Model{ID: 4}, PatientID: 2}, nil)
+
+		document, content, err := service.OpenDocument(1, 4)
+
+		assert.EqualError(t, err, "document not found")
+		assert.Nil(t, document)
+		assert.Nil(t, content)
+		backend.AssertNotCalled(t, "Get", mock.Anything)
+	})
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/utils"
+)
+
+// duplicateScoreThreshold is the score at which an existing record is offered
+// to reception as a possible duplicate. Name and date of birth together reach
+// it, as do any two exact identifiers.
+const duplicateScoreThreshold = 50
+
+// DuplicateCandidate is an existing patient that resembles a new registration.
+type DuplicateCandidate struct {
+	Patient models.Patient `json:"patient"`
+	Score   int            `json:"score"`
+	Reasons []string       `json:"reasons"`
+}
+
+// DuplicatePatientError stops a registration until reception confirms the
+// patient is not one of the candidates.
+type DuplicatePatientError struct {
+	Candidates []DuplicateCandidate
+}
+
+func (e *DuplicatePatientError) Error() string {
+	return "possible duplicate patients found; review the candidates and resend with confirmNotDuplicate to register anyway"
+}
+
+func scoreDuplicateCandidates(input models.CreatePatientInput, dateOfBirth time.Time,
+	patients []models.Patient) []DuplicateCandidate {
+	var candidates []DuplicateCandidate
+	for _, patient := range patients {
+		if candidate := scoreDuplicate(input, dateOfBirth, patient); candidate.Score >= duplicateScoreThreshold {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+	return candidates
+}
+
+func scoreDuplicate(input models.CreatePatientInput, dateOfBirth time.Time, patient models.Patient) DuplicateCandidate {
+	candidate := DuplicateCandidate{Patient: patient}
+
+	name := input.FirstName + " " + input.LastName
+	nameSimilarity := max(
+		utils.TrigramSimilarity(name, patient.FirstName+" "+patient.LastName),
+		utils.TrigramSimilarity(name, patient.LastName+" "+patient.FirstName),
+	)
+	switch {
+	case nameSimilarity >= 0.9:
+		candidate.Score += 40
+		candidate.Reasons = append(candidate.Reasons, "same name")
+	case nameSimilarity >= 0.5:
+		candidate.Score += 25
+		candidate.Reasons = append(candidate.Reasons, "similar name")
+	}
+
+	if sameDate(dateOfBirth, patient.DateOfBirth) {
+		candidate.Score += 30
+		candidate.Reasons = append(candidate.Reasons, "same date of birth")
+	}
+
+	phone := utils.NormalizePhone(input.PhoneNumber)
+	if phone != "" && phone == utils.NormalizePhone(patient.PhoneNumber) {
+		candidate.Score += 25
+		candidate.Reasons = append(candidate.Reasons, "same phone number")
+	}
+
+	if input.Email != "" && strings.EqualFold(input.Email, patient.Email) {
+		candidate.Score += 25
+		candidate.Reasons = append(candidate.Reasons, "same email")
+	}
+
+	return candidate
+}
+
+func sameDate(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
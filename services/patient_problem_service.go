@@ -0,0 +1,208 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+)
+
+type PatientProblemService interface {
+	CreateProblem(patientID uint, input models.CreateProblemInput, recordedBy uint) (*models.PatientProblem, error)
+	PromoteDiagnosis(patientID uint, input models.PromoteDiagnosisInput, recordedBy uint) (*models.PatientProblem, error)
+	GetProblemsByPatientID(patientID uint, status string) ([]models.PatientProblem, error)
+	UpdateProblem(patientID uint, problemID uint, input models.UpdateProblemInput, updatedBy uint) (*models.PatientProblem, error)
+}
+
+type patientProblemService struct {
+	patientProblemRepository repositories.PatientProblemRepository
+	patientRepository        repositories.PatientRepository
+	clinicalNoteRepository   repositories.ClinicalNoteRepository
+	diagnosisCodeRepository  repositories.DiagnosisCodeRepository
+}
+
+func NewPatientProblemService(
+	patientProblemRepository repositories.PatientProblemRepository,
+	patientRepository repositories.PatientRepository,
+	clinicalNoteRepository repositories.ClinicalNoteRepository,
+	diagnosisCodeRepository repositories.DiagnosisCodeRepository,
+) PatientProblemService {
+	return &patientProblemService{
+		patientProblemRepository: patientProblemRepository,
+		patientRepository:        patientRepository,
+		clinicalNoteRepository:   clinicalNoteRepository,
+		diagnosisCodeRepository:  diagnosisCodeRepository,
+	}
+}
+
+func (ps *patientProblemService) CreateProblem(patientID uint, input models.CreateProblemInput, recordedBy uint) (*models.PatientProblem, error) {
+	patient, err := ps.patientRepository.FindByID(patientID)
+	if err != nil || patient == nil {
+		return nil, errors.New("patient record not found")
+	}
+
+	problem := &models.PatientProblem{
+		PatientID:  patientID,
+		Title:      strings.TrimSpace(input.Title),
+		Status:     input.Status,
+		Notes:      input.Notes,
+		RecordedBy: recordedBy,
+		UpdatedBy:  recordedBy,
+	}
+	if problem.Status == "" {
+		problem.Status = constants.ProblemStatus.ACTIVE
+	}
+
+	if input.Code != "" {
+		code, err := ps.diagnosisCodeRepository.FindByCode(NormalizeDiagnosisCode(input.Code))
+		if err != nil {
+			return nil, errors.New("unknown diagnosis code: " + input.Code)
+		}
+		problem.DiagnosisCodeID = &code.ID
+		problem.DiagnosisCode = code
+	}
+
+	if input.OnsetDate != "" {
+		onset, err := time.Parse("2006-01-02", input.OnsetDate)
+		if err != nil {
+			return nil, errors.New("invalid date format, use YYYY-MM-DD")
+		}
+		problem.OnsetDate = &onset
+	}
+
+	return ps.saveNewProblem(problem)
+}
+
+// PromoteDiagnosis puts a coded diagnosis from one of the patient's notes on
+// the problem list, so it is carried forward to later visits.
+func (ps *patientProblemService) PromoteDiagnosis(patientID uint, input models.PromoteDiagnosisInput, recordedBy uint) (*models.PatientProblem, error) {
+	note, err := ps.clinicalNoteRepository.FindByID(input.ClinicalNoteID)
+	if err != nil || note.PatientID != patientID {
+		return nil, errors.New("clinical note not found")
+	}
+
+	code := NormalizeDiagnosisCode(input.Code)
+	var diagnosis *models.NoteDiagnosis
+	for i := range note.Diagnoses {
+		if note.Diagnoses[i].DiagnosisCode != nil && note.Diagnoses[i].DiagnosisCode.Code == code {
+			diagnosis = &note.Diagnoses[i]
+			break
+		}
+	}
+	if diagnosis == nil {
+		return nil, errors.New("the clinical note has no diagnosis with code " + code)
+	}
+
+	onset := time.Date(note.CreatedAt.Year(), note.CreatedAt.Month(), note.CreatedAt.Day(), 0, 0, 0, 0, time.UTC)
+	if input.OnsetDate != "" {
+		onset, err = time.Parse("2006-01-02", input.OnsetDate)
+		if err != nil {
+			return nil, errors.New("invalid date format, use YYYY-MM-DD")
+		}
+	}
+
+	problem := &models.PatientProblem{
+		PatientID:       patientID,
+		Title:           diagnosis.DiagnosisCode.Description,
+		DiagnosisCodeID: &diagnosis.DiagnosisCodeID,
+		DiagnosisCode:   diagnosis.DiagnosisCode,
+		Status:          constants.ProblemStatus.ACTIVE,
+		OnsetDate:       &onset,
+		Notes:           input.Notes,
+		SourceNoteID:    &note.ID,
+		RecordedBy:      recordedBy,
+		UpdatedBy:       recordedBy,
+	}
+
+	return ps.saveNewProblem(problem)
+}
+
+func (ps *patientProblemService) GetProblemsByPatientID(patientID uint, status string) ([]models.PatientProblem, error) {
+	patient, err := ps.patientRepository.FindByID(patientID)
+	if err != nil || patient == nil {
+		return nil, errors.New("patient record not found")
+	}
+
+	return ps.patientProblemRepository.FindByPatientID(patientID, status)
+}
+
+func (ps *patientProblemService) UpdateProblem(patientID uint, problemID uint, input models.UpdateProblemInput, updatedBy uint) (*models.PatientProblem, error) {
+	problem, err := ps.patientProblemRepository.FindByID(problemID)
+	if err != nil || problem.PatientID != patientID {
+		return nil, errors.New("problem not found")
+	}
+
+	if input.Title != nil {
+		problem.Title = strings.TrimSpace(*input.Title)
+	}
+	if input.Notes != nil {
+		problem.Notes = *input.Notes
+	}
+	if input.OnsetDate != nil {
+		onset, err := time.Parse("2006-01-02", *input.OnsetDate)
+		if err != nil {
+			return nil, errors.New("invalid date format, use YYYY-MM-DD")
+		}
+		problem.OnsetDate = &onset
+	}
+	if input.ResolvedDate != nil {
+		resolved, err := time.Parse("2006-01-02", *input.ResolvedDate)
+		if err != nil {
+			return nil, errors.New("invalid date format, use YYYY-MM-DD")
+		}
+		problem.ResolvedDate = &resolved
+	}
+	if input.Status != nil {
+		problem.Status = *input.Status
+	}
+
+	// A resolved problem carries the date it was resolved; reopening it clears it.
+	if problem.Status == constants.ProblemStatus.RESOLVED && problem.ResolvedDate == nil {
+		today := time.Now().UTC().Truncate(24 * time.Hour)
+		problem.ResolvedDate = &today
+	}
+	if problem.Status != constants.ProblemStatus.RESOLVED {
+		problem.ResolvedDate = nil
+	}
+	if problem.OnsetDate != nil && problem.ResolvedDate != nil && problem.ResolvedDate.Before(*problem.OnsetDate) {
+		return nil, errors.New("a problem cannot be resolved before its onset")
+	}
+	problem.UpdatedBy = updatedBy
+
+	if err := ps.patientProblemRepository.Update(problem); err != nil {
+		return nil, err
+	}
+
+	return problem, nil
+}
+
+// saveNewProblem keeps one open entry per coded condition; a resolved entry
+// may be recorded again if the condition recurs.
+func (ps *patientProblemService) saveNewProblem(problem *models.PatientProblem) (*models.PatientProblem, error) {
+	if problem.DiagnosisCodeID != nil {
+		existing, err := ps.patientProblemRepository.FindByPatientID(problem.PatientID, "")
+		if err != nil {
+			return nil, err
+		}
+		for _, other := range existing {
+			if other.DiagnosisCodeID != nil && *other.DiagnosisCodeID == *problem.DiagnosisCodeID &&
+				other.Status != constants.ProblemStatus.RESOLVED {
+				return nil, errors.New("this condition is already on the patient's problem list")
+			}
+		}
+	}
+
+	if problem.Status == constants.ProblemStatus.RESOLVED {
+		today := time.Now().UTC().Truncate(24 * time.Hour)
+		problem.ResolvedDate = &today
+	}
+
+	if err := ps.patientProblemRepository.Create(problem); err != nil {
+		return nil, err
+	}
+
+	return problem, nil
+}
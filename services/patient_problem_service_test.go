@@ -0,0 +1,246 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestCreateProblem(t *testing.T) {
+	hypertension := &models.DiagnosisCode{Model: gorm.Model{ID: 5}, Code: "I10", Description: "Essential (primary) hypertension"}
+
+	t.Run("Success", func(t *testing.T) {
+		mockPatientProblemRepo := new(mocks.PatientProblemRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockDiagnosisCodeRepo := new(mocks.DiagnosisCodeRepository)
+
+		service := NewPatientProblemService(mockPatientProblemRepo, mockPatientRepo,
+			new(mocks.ClinicalNoteRepository), mockDiagnosisCodeRepo)
+
+		mockPatientRepo.On("FindByID", uint(1)).Return(&models.Patient{Model: gorm.Model{ID: 1}}, nil)
+		mockDiagnosisCodeRepo.On("FindByCode", "I10").Return(hypertension, nil)
+		mockPatientProblemRepo.On("FindByPatientID", uint(1), "").Return([]models.PatientProblem{}, nil)
+		mockPatientProblemRepo.On("Create", mock.AnythingOfType("*models.PatientProblem")).Return(nil)
+
+		problem, err := service.CreateProblem(1, models.CreateProblemInput{
+			Title:     "Hypertension",
+			Code:      "i10",
+			OnsetDate: "2019-06-01",
+		}, 3)
+
+		assert.NoError(t, err)
+		assert.Equal(t, constants.ProblemStatus.ACTIVE, problem.Status)
+		assert.Equal(t, uint(5), *problem.DiagnosisCodeID)
+		assert.Equal(t, "2019-06-01", problem.OnsetDate.Format("2006-01-02"))
+		assert.Equal(t, uint(3), problem.RecordedBy)
+		mockPatientProblemRepo.AssertExpectations(t)
+	})
+
+	t.Run("UndottedCode", func(t *testing.T) {
+		mockPatientProblemRepo := new(mocks.PatientProblemRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockDiagnosisCodeRepo := new(mocks.DiagnosisCodeRepository)
+
+		service := NewPatientProblemService(mockPatientProblemRepo, mockPatientRepo,
+			new(mocks.ClinicalNoteRepository), mockDiagnosisCodeRepo)
+		diabetes := &models.DiagnosisCode{Model: gorm.Model{ID: 6}, Code: "E11.9", Description: "Type 2 diabetes mellitus without complications"}
+
+		mockPatientRepo.On("FindByID", uint(1)).Return(&models.Patient{Model: gorm.Model{ID: 1}}, nil)
+		mockDiagnosisCodeRepo.On("FindByCode", "E11.9").Return(diabetes, nil)
+		mockPatientProblemRepo.On("FindByPatientID", uint(1), "").Return([]models.PatientProblem{}, nil)
+		mockPatientProblemRepo.On("Create", mock.AnythingOfType("*models.PatientProblem")).Return(nil)
+
+		problem, err := service.CreateProblem(1, models.CreateProblemInput{Title: "Diabetes", Code: " e119 "}, 3)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(6), *problem.DiagnosisCodeID)
+	})
+
+	t.Run("AlreadyOpen", func(t *testing.T) {
+		mockPatientProblemRepo := new(mocks.PatientProblemRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockDiagnosisCodeRepo := new(mocks.DiagnosisCodeRepository)
+
+		service := NewPatientProblemService(mockPatientProblemRepo, mockPatientRepo,
+			new(mocks.ClinicalNoteRepository), mockDiagnosisCodeRepo)
+
+		existingCodeID := uint(5)
+		mockPatientRepo.On("FindByID", uint(1)).Return(&models.Patient{Model: gorm.Model{ID: 1}}, nil)
+		mockDiagnosisCodeRepo.On("FindByCode", "I10").Return(hypertension, nil)
+		mockPatientProblemRepo.On("FindByPatientID", uint(1), "").Return([]models.PatientProblem{
+			{DiagnosisCodeID: &existingCodeID, Status: constants.ProblemStatus.INACTIVE},
+		}, nil)
+
+		problem, err := service.CreateProblem(1, models.CreateProblemInput{Title: "Hypertension", Code: "I10"}, 3)
+
+		assert.EqualError(t, err, "this condition is already on the patient's problem list")
+		assert.Nil(t, problem)
+		mockPatientProblemRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("UnknownCode", func(t *testing.T) {
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockDiagnosisCodeRepo := new(mocks.DiagnosisCodeRepository)
+
+		service := NewPatientProblemService(new(mocks.PatientProblemRepository), mockPatientRepo,
+			new(mocks.ClinicalNoteRepository), mockDiagnosisCodeRepo)
+
+		mockPatientRepo.On("FindByID", uint(1)).Return(&models.Patient{Model: gorm.Model{ID: 1}}, nil)
+		mockDiagnosisCodeRepo.On("FindByCode", "X99").Return((*models.DiagnosisCode)(nil), errors.New("record not found"))
+
+		problem, err := service.CreateProblem(1, models.CreateProblemInput{Title: "Something", Code: "X99"}, 3)
+
+		assert.Error(t, err)
+		assert.Nil(t, problem)
+	})
+}
+
+func TestPromoteDiagnosis(t *testing.T) {
+	sickleCell := &models.DiagnosisCode{Model: gorm.Model{ID: 8}, Code: "D57.1", Description: "Sickle-cell disease without crisis"}
+	note := &models.ClinicalNote{
+		Model:     gorm.Model{ID: 12, CreatedAt: time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC)},
+		PatientID: 1,
+		Diagnoses: []models.NoteDiagnosis{{DiagnosisCodeID: 8, DiagnosisCode: sickleCell, Type: "primary"}},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockPatientProblemRepo := new(mocks.PatientProblemRepository)
+		mockClinicalNoteRepo := new(mocks.ClinicalNoteRepository)
+
+		service := NewPatientProblemService(mockPatientProblemRepo, new(mocks.PatientRepository),
+			mockClinicalNoteRepo, new(mocks.DiagnosisCodeRepository))
+
+		mockClinicalNoteRepo.On("FindByID", uint(12)).Return(note, nil)
+		mockPatientProblemRepo.On("FindByPatientID", uint(1), "").Return([]models.PatientProblem{}, nil)
+		mockPatientProblemRepo.On("Create", mock.AnythingOfType("*models.PatientProblem")).Return(nil)
+
+		problem, err := service.PromoteDiagnosis(1, models.PromoteDiagnosisInput{ClinicalNoteID: 12, Code: "d57.1"}, 3)
+
+		assert.NoError(t, err)
+		assert.Equal(t, sickleCell.Description, problem.Title)
+		assert.Equal(t, uint(8), *problem.DiagnosisCodeID)
+		assert.Equal(t, uint(12), *problem.SourceNoteID)
+		assert.Equal(t, "2024-03-15", problem.OnsetDate.Format("2006-01-02"))
+	})
+
+	t.Run("UndottedCode", func(t *testing.T) {
+		mockPatientProblemRepo := new(mocks.PatientProblemRepository)
+		mockClinicalNoteRepo := new(mocks.ClinicalNoteRepository)
+
+		service := NewPatientProblemService(mockPatientProblemRepo, new(mocks.PatientRepository),
+			mockClinicalNoteRepo, new(mocks.DiagnosisCodeRepository))
+
+		mockClinicalNoteRepo.On("FindByID", uint(12)).Return(note, nil)
+		mockPatientProblemRepo.On("FindByPatientID", uint(1), "").Return([]models.PatientProblem{}, nil)
+		mockPatientProblemRepo.On("Create", mock.AnythingOfType("*models.PatientProblem")).Return(nil)
+
+		problem, err := service.PromoteDiagnosis(1, models.PromoteDiagnosisInput{ClinicalNoteID: 12, Code: "D571"}, 3)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(8), *problem.DiagnosisCodeID)
+	})
+
+	t.Run("DiagnosisNotOnNote", func(t *testing.T) {
+		mockPatientProblemRepo := new(mocks.PatientProblemRepository)
+		mockClinicalNoteRepo := new(mocks.ClinicalNoteRepository)
+
+		service := NewPatientProblemService(mockPatientProblemRepo, new(mocks.PatientRepository),
+			mockClinicalNoteRepo, new(mocks.DiagnosisCodeRepository))
+
+		mockClinicalNoteRepo.On("FindByID", uint(12)).Return(note, nil)
+
+		problem, err := service.PromoteDiagnosis(1, models.PromoteDiagnosisInput{ClinicalNoteID: 12, Code: "I10"}, 3)
+
+		assert.EqualError(t, err, "the clinical note has no diagnosis with code I10")
+		assert.Nil(t, problem)
+		mockPatientProblemRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("NoteOfAnotherPatient", func(t *testing.T) {
+		mockClinicalNoteRepo := new(mocks.ClinicalNoteRepository)
+
+		service := NewPatientProblemService(new(mocks.PatientProblemRepository),
+			new(mocks.PatientRepository), mockClinicalNoteRepo, new(mocks.DiagnosisCodeRepository))
+
+		mockClinicalNoteRepo.On("FindByID", uint(12)).Return(note, nil)
+
+		problem, err := service.PromoteDiagnosis(2, models.PromoteDiagnosisInput{ClinicalNoteID: 12, Code: "D57.1"}, 3)
+
+		assert.EqualError(t, err, "clinical note not found")
+		assert.Nil(t, problem)
+	})
+}
+
+func TestUpdateProblem(t *testing.T) {
+	t.Run("ResolveSetsDate", func(t *testing.T) {
+		mockPatientProblemRepo := new(mocks.PatientProblemRepository)
+
+		service := NewPatientProblemService(mockPatientProblemRepo, new(mocks.PatientRepository),
+			new(mocks.ClinicalNoteRepository), new(mocks.DiagnosisCodeRepository))
+
+		mockPatientProblemRepo.On("FindByID", uint(4)).Return(&models.PatientProblem{
+			Model:     gorm.Model{ID: 4},
+			PatientID: 1,
+			Status:    constants.ProblemStatus.ACTIVE,
+		}, nil)
+		mockPatientProblemRepo.On("Update", mock.AnythingOfType("*models.PatientProblem")).Return(nil)
+
+		problem, err := service.UpdateProblem(1, 4, models.UpdateProblemInput{Status: stringPtr("resolved")}, 3)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, problem.ResolvedDate)
+		assert.Equal(t, uint(3), problem.UpdatedBy)
+	})
+
+	t.Run("ReopenClearsResolvedDate", func(t *testing.T) {
+		mockPatientProblemRepo := new(mocks.PatientProblemRepository)
+
+		service := NewPatientProblemService(mockPatientProblemRepo, new(mocks.PatientRepository),
+			new(mocks.ClinicalNoteRepository), new(mocks.DiagnosisCodeRepository))
+
+		resolved := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		mockPatientProblemRepo.On("FindByID", uint(4)).Return(&models.PatientProblem{
+			Model:        gorm.Model{ID: 4},
+			PatientID:    1,
+			Status:       constants.ProblemStatus.RESOLVED,
+			ResolvedDate: &resolved,
+		}, nil)
+		mockPatientProblemRepo.On("Update", mock.AnythingOfType("*models.PatientProblem")).Return(nil)
+
+		problem, err := service.UpdateProblem(1, 4, models.UpdateProblemInput{Status: stringPtr("active")}, 3)
+
+		assert.NoError(t, err)
+		assert.Nil(t, problem.ResolvedDate)
+	})
+
+	t.Run("ResolvedBeforeOnset", func(t *testing.T) {
+		mockPatientProblemRepo := new(mocks.PatientProblemRepository)
+
+		service := NewPatientProblemService(mockPatientProblemRepo, new(mocks.PatientRepository),
+			new(mocks.ClinicalNoteRepository), new(mocks.DiagnosisCodeRepository))
+
+		onset := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+		mockPatientProblemRepo.On("FindByID", uint(4)).Return(&models.PatientProblem{
+			Model:     gorm.Model{ID: 4},
+			PatientID: 1,
+			Status:    constants.ProblemStatus.ACTIVE,
+			OnsetDate: &onset,
+		}, nil)
+
+		problem, err := service.UpdateProblem(1, 4, models.UpdateProblemInput{
+			Status:       stringPtr("resolved"),
+			ResolvedDate: stringPtr("2024-04-01"),
+		}, 3)
+
+		assert.EqualError(t, err, "a problem cannot be resolved before its onset")
+		assert.Nil(t, problem)
+		mockPatientProblemRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+}
@@ -1,9 +1,13 @@
 package services
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/ofojichigozie/hms-go-backend/constants"
 	"github.com/ofojichigozie/hms-go-backend/models"
 	"github.com/ofojichigozie/hms-go-backend/repositories"
 	"github.com/ofojichigozie/hms-go-backend/utils"
@@ -12,22 +16,42 @@ import (
 type PatientService interface {
 	CreatePatient(input models.CreatePatientInput, createdBy uint) (*models.Patient, error)
 	GetAllPatients(filters map[string]interface{}) ([]models.Patient, error)
+	SearchPatients(query models.PatientSearchQuery) ([]models.Patient, int64, error)
 	GetPatientByID(id uint) (*models.Patient, error)
 	GetPatientByRegistrationNumber(regNumber string) (*models.Patient, error)
 	UpdatePatient(id uint, input models.UpdatePatientInput, updatedBy uint) (*models.Patient, error)
 	DeletePatient(id uint) error
+	MergePatients(input models.MergePatientsInput, mergedBy uint) (*models.Patient, error)
 }
 
 type patientService struct {
-	patientRepository repositories.PatientRepository
-	staffRepository   repositories.StaffRepository
+	patientRepository        repositories.PatientRepository
+	staffRepository          repositories.StaffRepository
+	sequenceRepository       repositories.SequenceRepository
+	auditLogRepository       repositories.AuditLogRepository
+	patientContactRepository repositories.PatientContactRepository
+	admissionRepository      repositories.AdmissionRepository
+	immunizationRepository   repositories.ImmunizationRepository
+	registrationFormat       *utils.RegistrationNumberFormat
 }
 
 func NewPatientService(patientRepository repositories.PatientRepository,
-	staffRepository repositories.StaffRepository) PatientService {
+	staffRepository repositories.StaffRepository,
+	sequenceRepository repositories.SequenceRepository,
+	auditLogRepository repositories.AuditLogRepository,
+	patientContactRepository repositories.PatientContactRepository,
+	admissionRepository repositories.AdmissionRepository,
+	immunizationRepository repositories.ImmunizationRepository,
+	registrationFormat *utils.RegistrationNumberFormat) PatientService {
 	return &patientService{
-		patientRepository: patientRepository,
-		staffRepository:   staffRepository,
+		patientRepository:        patientRepository,
+		staffRepository:          staffRepository,
+		sequenceRepository:       sequenceRepository,
+		auditLogRepository:       auditLogRepository,
+		patientContactRepository: patientContactRepository,
+		admissionRepository:      admissionRepository,
+		immunizationRepository:   immunizationRepository,
+		registrationFormat:       registrationFormat,
 	}
 }
 
@@ -37,7 +61,34 @@ func (ps *patientService) CreatePatient(input models.CreatePatientInput, created
 		return nil, errors.New("invalid date format, use YYYY-MM-DD")
 	}
 
-	regNumber := utils.GeneratePatientID()
+	contacts := make([]models.PatientContact, 0, len(input.Contacts))
+	hasGuardian := false
+	for i, contactInput := range input.Contacts {
+		if contactInput.Priority == 0 {
+			contactInput.Priority = i + 1
+		}
+		contacts = append(contacts, newPatientContact(0, contactInput, createdBy))
+		hasGuardian = hasGuardian || contactInput.ContactType == constants.ContactTypes.GUARDIAN
+	}
+	if isMinor(dateOfBirth) && !hasGuardian {
+		return nil, errGuardianRequired
+	}
+
+	if !input.ConfirmNotDuplicate {
+		existing, err := ps.patientRepository.FindDuplicateCandidates(input.FirstName, input.LastName,
+			dateOfBirth, utils.NormalizePhone(input.PhoneNumber), input.Email)
+		if err != nil {
+			return nil, err
+		}
+		if candidates := scoreDuplicateCandidates(input, dateOfBirth, existing); len(candidates) > 0 {
+			return nil, &DuplicatePatientError{Candidates: candidates}
+		}
+	}
+
+	regNumber, err := ps.nextRegistrationNumber()
+	if err != nil {
+		return nil, err
+	}
 
 	patient := &models.Patient{
 		RegistrationNumber: regNumber,
@@ -49,6 +100,7 @@ func (ps *patientService) CreatePatient(input models.CreatePatientInput, created
 		Email:              input.Email,
 		Address:            input.Address,
 		CreatedBy:          createdBy,
+		Contacts:           contacts,
 	}
 
 	if err := ps.patientRepository.Create(patient); err != nil {
@@ -62,11 +114,43 @@ func (ps *patientService) GetAllPatients(filters map[string]interface{}) ([]mode
 	return ps.patientRepository.FindAll(filters)
 }
 
+func (ps *patientService) SearchPatients(query models.PatientSearchQuery) ([]models.Patient, int64, error) {
+	query.Normalize()
+	query.Name = strings.TrimSpace(query.Name)
+	query.Email = strings.TrimSpace(query.Email)
+	query.RegistrationNumber = strings.TrimSpace(query.RegistrationNumber)
+	if query.Phone != "" {
+		query.Phone = utils.NormalizePhone(query.Phone)
+		if query.Phone == "" {
+			return nil, 0, errors.New("phone search must contain digits")
+		}
+	}
+
+	var dateOfBirth *time.Time
+	if query.DateOfBirth != "" {
+		parsed, err := time.Parse("2006-01-02", query.DateOfBirth)
+		if err != nil {
+			return nil, 0, errors.New("invalid date format, use YYYY-MM-DD")
+		}
+		dateOfBirth = &parsed
+	}
+
+	return ps.patientRepository.Search(query, dateOfBirth)
+}
+
 func (ps *patientService) GetPatientByID(id uint) (*models.Patient, error) {
-	return ps.patientRepository.FindByID(id)
+	return ps.patientRepository.FindSummaryByID(id)
 }
 
+// GetPatientByRegistrationNumber rejects numbers whose check digit does not
+// match before looking them up, so a mistyped number is reported as such
+// rather than resolving to another patient. Numbers in the configured format
+// are looked up in its casing; any other number is looked up as entered.
 func (ps *patientService) GetPatientByRegistrationNumber(regNumber string) (*models.Patient, error) {
+	regNumber = ps.registrationFormat.Normalize(regNumber)
+	if err := ps.registrationFormat.Validate(regNumber); err != nil {
+		return nil, err
+	}
 	return ps.patientRepository.FindByRegistrationNumber(regNumber)
 }
 
@@ -76,43 +160,69 @@ func (ps *patientService) UpdatePatient(id uint, input models.UpdatePatientInput
 		return nil, errors.New("patient not found")
 	}
 
-	if input.FirstName != nil {
-		patient.FirstName = *input.FirstName
-	}
-	if input.LastName != nil {
-		patient.LastName = *input.LastName
+	// Demographic edits are audited field by field so they show up on the
+	// patient's timeline.
+	changes := map[string]models.FieldChange{}
+	track := func(field string, current *string, value *string) {
+		if value != nil && *value != *current {
+			changes[field] = models.FieldChange{From: *current, To: *value}
+			*current = *value
+		}
 	}
+
+	track("firstName", &patient.FirstName, input.FirstName)
+	track("lastName", &patient.LastName, input.LastName)
 	if input.DateOfBirth != nil {
 		dateOfBirth, err := time.Parse("2006-01-02", *input.DateOfBirth)
 		if err != nil {
 			return nil, errors.New("invalid date format, use YYYY-MM-DD")
 		}
+		if previous := patient.DateOfBirth.Format("2006-01-02"); previous != *input.DateOfBirth {
+			// A corrected date of birth can make the patient a minor, who
+			// must have a guardian on file like one registered as a minor.
+			if isMinor(dateOfBirth) {
+				contacts, err := ps.patientContactRepository.FindByPatientID(patient.ID)
+				if err != nil {
+					return nil, err
+				}
+				if !hasGuardian(contacts, 0) {
+					return nil, errGuardianRequired
+				}
+			}
+			changes["dateOfBirth"] = models.FieldChange{From: previous, To: *input.DateOfBirth}
+		}
 		patient.DateOfBirth = dateOfBirth
 	}
-	if input.Gender != nil {
-		patient.Gender = *input.Gender
-	}
-	if input.PhoneNumber != nil {
-		patient.PhoneNumber = *input.PhoneNumber
-	}
-	if input.Email != nil {
-		patient.Email = *input.Email
-	}
-	if input.Address != nil {
-		patient.Address = *input.Address
-	}
-	if input.BloodGroup != nil {
-		patient.BloodGroup = *input.BloodGroup
-	}
-	if input.Genotype != nil {
-		patient.Genotype = *input.Genotype
-	}
+	track("gender", &patient.Gender, input.Gender)
+	track("phoneNumber", &patient.PhoneNumber, input.PhoneNumber)
+	track("email", &patient.Email, input.Email)
+	track("address", &patient.Address, input.Address)
+	track("bloodGroup", &patient.BloodGroup, input.BloodGroup)
+	track("genotype", &patient.Genotype, input.Genotype)
 	patient.UpdatedBy = updatedBy
 
 	if err := ps.patientRepository.Update(patient); err != nil {
 		return nil, err
 	}
 
+	if len(changes) > 0 {
+		details, err := json.Marshal(changes)
+		if err != nil {
+			return nil, err
+		}
+		entry := &models.AuditLog{
+			Action:     constants.AuditActions.PATIENT_UPDATE,
+			EntityType: constants.AuditEntities.PATIENT,
+			EntityID:   patient.ID,
+			PatientID:  &patient.ID,
+			StaffID:    updatedBy,
+			Details:    string(details),
+		}
+		if err := ps.auditLogRepository.Create(entry); err != nil {
+			return nil, fmt.Errorf("patient updated but the audit entry failed: %w", err)
+		}
+	}
+
 	return patient, nil
 }
 
@@ -126,3 +236,100 @@ func (ps *patientService) DeletePatient(id uint) error {
 	}
 	return ps.patientRepository.Delete(id)
 }
+
+// MergePatients folds a duplicate registration into the surviving record. The
+// duplicate's registration number keeps resolving to the survivor.
+func (ps *patientService) MergePatients(input models.MergePatientsInput, mergedBy uint) (*models.Patient, error) {
+	survivor, err := ps.patientRepository.FindByID(input.SurvivorID)
+	if err != nil {
+		return nil, errors.New("surviving patient not found")
+	}
+	duplicate, err := ps.patientRepository.FindByID(input.DuplicateID)
+	if err != nil {
+		return nil, errors.New("duplicate patient not found")
+	}
+	if survivor.ID == duplicate.ID {
+		return nil, errors.New("a patient cannot be merged into itself")
+	}
+
+	// A patient can only have one active admission, so one of the two stays
+	// has to be closed before the records can become one.
+	if _, err := ps.admissionRepository.FindActiveByPatientID(survivor.ID); err == nil {
+		if _, err := ps.admissionRepository.FindActiveByPatientID(duplicate.ID); err == nil {
+			return nil, errors.New("both patients are currently admitted; discharge one of the admissions before merging")
+		}
+	}
+
+	supersededDoses, err := ps.supersededImmunizations(survivor.ID, duplicate.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	alias := &models.PatientAlias{
+		RegistrationNumber: duplicate.RegistrationNumber,
+		PatientID:          survivor.ID,
+		MergedPatientID:    duplicate.ID,
+		MergedBy:           mergedBy,
+		Reason:             input.Reason,
+	}
+	if err := ps.patientRepository.Merge(survivor.ID, duplicate.ID, alias, supersededDoses); err != nil {
+		return nil, err
+	}
+
+	details, err := json.Marshal(map[string]string{
+		"survivorRegistrationNumber":  survivor.RegistrationNumber,
+		"duplicateRegistrationNumber": duplicate.RegistrationNumber,
+	})
+	if err != nil {
+		return nil, err
+	}
+	entry := &models.AuditLog{
+		Action:     constants.AuditActions.PATIENT_MERGE,
+		EntityType: constants.AuditEntities.PATIENT,
+		EntityID:   duplicate.ID,
+		PatientID:  &survivor.ID,
+		StaffID:    mergedBy,
+		Reason:     input.Reason,
+		Details:    string(details),
+	}
+	if err := ps.auditLogRepository.Create(entry); err != nil {
+		return nil, fmt.Errorf("patients merged but the audit entry failed: %w", err)
+	}
+
+	return survivor, nil
+}
+
+// supersededImmunizations lists the duplicate's immunization records for
+// doses the survivor already has on file. A dose is recorded once per
+// patient, so these are dropped when the records are merged.
+func (ps *patientService) supersededImmunizations(survivorID, duplicateID uint) ([]uint, error) {
+	survivorDoses, err := ps.immunizationRepository.FindByPatientID(survivorID)
+	if err != nil {
+		return nil, err
+	}
+	duplicateDoses, err := ps.immunizationRepository.FindByPatientID(duplicateID)
+	if err != nil {
+		return nil, err
+	}
+
+	recorded := make(map[[2]uint]bool, len(survivorDoses))
+	for _, dose := range survivorDoses {
+		recorded[[2]uint{dose.VaccineID, uint(dose.DoseNumber)}] = true
+	}
+	var superseded []uint
+	for _, dose := range duplicateDoses {
+		if recorded[[2]uint{dose.VaccineID, uint(dose.DoseNumber)}] {
+			superseded = append(superseded, dose.ID)
+		}
+	}
+	return superseded, nil
+}
+
+func (ps *patientService) nextRegistrationNumber() (string, error) {
+	now := time.Now()
+	seq, err := ps.sequenceRepository.Next(ps.registrationFormat.SequenceName(now))
+	if err != nil {
+		return "", errors.New("failed to allocate registration number")
+	}
+	return ps.registrationFormat.Render(seq, now), nil
+}
@@ -1,23 +1,34 @@
 package services
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/ofojichigozie/hms-go-backend/constants"
 	"github.com/ofojichigozie/hms-go-backend/models"
 	"github.com/ofojichigozie/hms-go-backend/services/mocks"
+	"github.com/ofojichigozie/hms-go-backend/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
 )
 
+var defaultRegistrationFormat, _ = utils.NewRegistrationNumberFormat(
+	utils.DefaultRegistrationNumberTemplate, utils.CheckDigitLuhn, "")
+
 func TestCreatePatient(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		mockPatientRepo := new(mocks.PatientRepository)
 		mockStaffRepo := new(mocks.StaffRepository)
 
-		service := NewPatientService(mockPatientRepo, mockStaffRepo)
+		mockSequenceRepo := new(mocks.SequenceRepository)
+
+		service := NewPatientService(mockPatientRepo, mockStaffRepo, mockSequenceRepo, new(mocks.AuditLogRepository),
+			new(mocks.PatientContactRepository), new(mocks.AdmissionRepository), new(mocks.ImmunizationRepository),
+			defaultRegistrationFormat)
 
 		input := models.CreatePatientInput{
 			FirstName:   "John",
@@ -29,9 +40,13 @@ func TestCreatePatient(t *testing.T) {
 			Address:     "123 Main St",
 		}
 
+		mockPatientRepo.On("FindDuplicateCandidates", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return([]models.Patient{}, nil)
+		year := time.Now().Year()
+		mockSequenceRepo.On("Next", fmt.Sprintf("patient_registration:%d", year)).Return(int64(42), nil)
 		mockPatientRepo.On("Create", mock.AnythingOfType("*models.Patient")).Return(nil).Run(func(args mock.Arguments) {
 			patient := args.Get(0).(*models.Patient)
-			assert.NotEmpty(t, patient.RegistrationNumber)
+			assert.Regexp(t, fmt.Sprintf(`^PAT-%d-000042\d$`, year), patient.RegistrationNumber)
 			assert.Equal(t, input.FirstName, patient.FirstName)
 			assert.Equal(t, input.LastName, patient.LastName)
 			assert.Equal(t, "male", patient.Gender)
@@ -52,7 +67,9 @@ func TestCreatePatient(t *testing.T) {
 		mockPatientRepo := new(mocks.PatientRepository)
 		mockStaffRepo := new(mocks.StaffRepository)
 
-		service := NewPatientService(mockPatientRepo, mockStaffRepo)
+		service := NewPatientService(mockPatientRepo, mockStaffRepo, new(mocks.SequenceRepository),
+			new(mocks.AuditLogRepository), new(mocks.PatientContactRepository), new(mocks.AdmissionRepository),
+			new(mocks.ImmunizationRepository), defaultRegistrationFormat)
 
 		input := models.CreatePatientInput{
 			FirstName:   "John",
@@ -70,11 +87,174 @@ func TestCreatePatient(t *testing.T) {
 		mockPatientRepo.AssertNotCalled(t, "Create")
 	})
 
+	t.Run("MinorWithoutGuardian", func(t *testing.T) {
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockStaffRepo := new(mocks.StaffRepository)
+
+		service := NewPatientService(mockPatientRepo, mockStaffRepo, new(mocks.SequenceRepository),
+			new(mocks.AuditLogRepository), new(mocks.PatientContactRepository), new(mocks.AdmissionRepository),
+			new(mocks.ImmunizationRepository), defaultRegistrationFormat)
+
+		input := models.CreatePatientInput{
+			FirstName:   "Ada",
+			LastName:    "Eze",
+			DateOfBirth: time.Now().AddDate(-7, 0, 0).Format("2006-01-02"),
+			Gender:      "female",
+			PhoneNumber: "+2348031234567",
+			Contacts: []models.PatientContactInput{
+				{ContactType: "emergency", Relationship: "relative", FullName: "Uche Eze", PhoneNumber: "+2348030000001"},
+			},
+		}
+
+		result, err := service.CreatePatient(input, 1)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "patients under 18 must have a guardian contact")
+		mockPatientRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("MinorWithGuardian", func(t *testing.T) {
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockStaffRepo := new(mocks.StaffRepository)
+		mockSequenceRepo := new(mocks.SequenceRepository)
+
+		service := NewPatientService(mockPatientRepo, mockStaffRepo, mockSequenceRepo, new(mocks.AuditLogRepository),
+			new(mocks.PatientContactRepository), new(mocks.AdmissionRepository), new(mocks.ImmunizationRepository),
+			defaultRegistrationFormat)
+
+		input := models.CreatePatientInput{
+			FirstName:   "Ada",
+			LastName:    "Eze",
+			DateOfBirth: time.Now().AddDate(-7, 0, 0).Format("2006-01-02"),
+			Gender:      "female",
+			PhoneNumber: "+2348031234567",
+			Contacts: []models.PatientContactInput{
+				{ContactType: "guardian", Relationship: "parent", FullName: "Ngozi Eze", PhoneNumber: "+2348030000002"},
+				{ContactType: "emergency", Relationship: "relative", FullName: "Uche Eze", PhoneNumber: "+2348030000001"},
+			},
+		}
+
+		mockPatientRepo.On("FindDuplicateCandidates", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return([]models.Patient{}, nil)
+		mockSequenceRepo.On("Next", mock.AnythingOfType("string")).Return(int64(3), nil)
+		mockPatientRepo.On("Create", mock.AnythingOfType("*models.Patient")).Return(nil).Run(func(args mock.Arguments) {
+			patient := args.Get(0).(*models.Patient)
+			assert.Len(t, patient.Contacts, 2)
+			assert.Equal(t, 1, patient.Contacts[0].Priority)
+			assert.Equal(t, 2, patient.Contacts[1].Priority)
+		})
+
+		result, err := service.CreatePatient(input, 1)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		mockPatientRepo.AssertExpectations(t)
+	})
+
+	t.Run("PossibleDuplicate", func(t *testing.T) {
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockStaffRepo := new(mocks.StaffRepository)
+		mockSequenceRepo := new(mocks.SequenceRepository)
+
+		service := NewPatientService(mockPatientRepo, mockStaffRepo, mockSequenceRepo, new(mocks.AuditLogRepository),
+			new(mocks.PatientContactRepository), new(mocks.AdmissionRepository), new(mocks.ImmunizationRepository),
+			defaultRegistrationFormat)
+
+		input := models.CreatePatientInput{
+			FirstName:   "Jon",
+			LastName:    "Doe",
+			DateOfBirth: "1990-01-01",
+			Gender:      "male",
+			PhoneNumber: "+2348031234567",
+		}
+		existing := []models.Patient{
+			{Model: gorm.Model{ID: 4}, FirstName: "John", LastName: "Doe",
+				DateOfBirth: time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC), PhoneNumber: "08031234567"},
+			{Model: gorm.Model{ID: 5}, FirstName: "Mary", LastName: "Okafor",
+				DateOfBirth: time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC), PhoneNumber: "+2348099999999"},
+		}
+
+		mockPatientRepo.On("FindDuplicateCandidates", "Jon", "Doe", mock.AnythingOfType("time.Time"), "8031234567", "").
+			Return(existing, nil)
+
+		result, err := service.CreatePatient(input, 1)
+
+		assert.Nil(t, result)
+		var duplicateErr *DuplicatePatientError
+		assert.ErrorAs(t, err, &duplicateErr)
+		assert.Len(t, duplicateErr.Candidates, 1)
+		assert.Equal(t, uint(4), duplicateErr.Candidates[0].Patient.ID)
+		assert.Contains(t, duplicateErr.Candidates[0].Reasons, "same phone number")
+		mockSequenceRepo.AssertNotCalled(t, "Next", mock.Anything)
+		mockPatientRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("ConfirmedNotDuplicate", func(t *testing.T) {
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockStaffRepo := new(mocks.StaffRepository)
+		mockSequenceRepo := new(mocks.SequenceRepository)
+
+		service := NewPatientService(mockPatientRepo, mockStaffRepo, mockSequenceRepo, new(mocks.AuditLogRepository),
+			new(mocks.PatientContactRepository), new(mocks.AdmissionRepository), new(mocks.ImmunizationRepository),
+			defaultRegistrationFormat)
+
+		input := models.CreatePatientInput{
+			FirstName:           "Jon",
+			LastName:            "Doe",
+			DateOfBirth:         "1990-01-01",
+			Gender:              "male",
+			PhoneNumber:         "+2348031234567",
+			ConfirmNotDuplicate: true,
+		}
+
+		mockSequenceRepo.On("Next", mock.AnythingOfType("string")).Return(int64(8), nil)
+		mockPatientRepo.On("Create", mock.AnythingOfType("*models.Patient")).Return(nil)
+
+		result, err := service.CreatePatient(input, 1)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		mockPatientRepo.AssertNotCalled(t, "FindDuplicateCandidates", mock.Anything, mock.Anything,
+			mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("SequenceError", func(t *testing.T) {
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockStaffRepo := new(mocks.StaffRepository)
+		mockSequenceRepo := new(mocks.SequenceRepository)
+
+		service := NewPatientService(mockPatientRepo, mockStaffRepo, mockSequenceRepo, new(mocks.AuditLogRepository),
+			new(mocks.PatientContactRepository), new(mocks.AdmissionRepository), new(mocks.ImmunizationRepository),
+			defaultRegistrationFormat)
+
+		input := models.CreatePatientInput{
+			FirstName:   "John",
+			LastName:    "Doe",
+			DateOfBirth: "1990-01-01",
+			Gender:      "male",
+			PhoneNumber: "+1234567890",
+		}
+
+		mockPatientRepo.On("FindDuplicateCandidates", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return([]models.Patient{}, nil)
+		mockSequenceRepo.On("Next", mock.AnythingOfType("string")).Return(int64(0), errors.New("connection reset"))
+
+		result, err := service.CreatePatient(input, 1)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "failed to allocate registration number")
+		mockPatientRepo.AssertNotCalled(t, "Create")
+	})
+
 	t.Run("RepositoryError", func(t *testing.T) {
 		mockPatientRepo := new(mocks.PatientRepository)
 		mockStaffRepo := new(mocks.StaffRepository)
 
-		service := NewPatientService(mockPatientRepo, mockStaffRepo)
+		mockSequenceRepo := new(mocks.SequenceRepository)
+
+		service := NewPatientService(mockPatientRepo, mockStaffRepo, mockSequenceRepo, new(mocks.AuditLogRepository),
+			new(mocks.PatientContactRepository), new(mocks.AdmissionRepository), new(mocks.ImmunizationRepository),
+			defaultRegistrationFormat)
 
 		input := models.CreatePatientInput{
 			FirstName:   "John",
@@ -84,6 +264,9 @@ func TestCreatePatient(t *testing.T) {
 			PhoneNumber: "+1234567890",
 		}
 
+		mockPatientRepo.On("FindDuplicateCandidates", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return([]models.Patient{}, nil)
+		mockSequenceRepo.On("Next", mock.AnythingOfType("string")).Return(int64(7), nil)
 		mockPatientRepo.On("Create", mock.AnythingOfType("*models.Patient")).Return(errors.New("database error"))
 
 		result, err := service.CreatePatient(input, 1)
@@ -100,7 +283,9 @@ func TestGetAllPatients(t *testing.T) {
 		mockPatientRepo := new(mocks.PatientRepository)
 		mockStaffRepo := new(mocks.StaffRepository)
 
-		service := NewPatientService(mockPatientRepo, mockStaffRepo)
+		service := NewPatientService(mockPatientRepo, mockStaffRepo, new(mocks.SequenceRepository),
+			new(mocks.AuditLogRepository), new(mocks.PatientContactRepository), new(mocks.AdmissionRepository),
+			new(mocks.ImmunizationRepository), defaultRegistrationFormat)
 
 		filters := map[string]interface{}{"gender": "male"}
 		expectedPatients := []models.Patient{
@@ -126,7 +311,9 @@ func TestGetAllPatients(t *testing.T) {
 		mockPatientRepo := new(mocks.PatientRepository)
 		mockStaffRepo := new(mocks.StaffRepository)
 
-		service := NewPatientService(mockPatientRepo, mockStaffRepo)
+		service := NewPatientService(mockPatientRepo, mockStaffRepo, new(mocks.SequenceRepository),
+			new(mocks.AuditLogRepository), new(mocks.PatientContactRepository), new(mocks.AdmissionRepository),
+			new(mocks.ImmunizationRepository), defaultRegistrationFormat)
 
 		filters := map[string]interface{}{}
 		mockPatientRepo.On("FindAll", filters).Return([]models.Patient{}, errors.New("database error"))
@@ -145,7 +332,9 @@ func TestGetPatientByID(t *testing.T) {
 		mockPatientRepo := new(mocks.PatientRepository)
 		mockStaffRepo := new(mocks.StaffRepository)
 
-		service := NewPatientService(mockPatientRepo, mockStaffRepo)
+		service := NewPatientService(mockPatientRepo, mockStaffRepo, new(mocks.SequenceRepository),
+			new(mocks.AuditLogRepository), new(mocks.PatientContactRepository), new(mocks.AdmissionRepository),
+			new(mocks.ImmunizationRepository), defaultRegistrationFormat)
 
 		expectedPatient := &models.Patient{
 			Model:              gorm.Model{ID: 1},
@@ -154,7 +343,7 @@ func TestGetPatientByID(t *testing.T) {
 			LastName:           "Doe",
 		}
 
-		mockPatientRepo.On("FindByID", uint(1)).Return(expectedPatient, nil)
+		mockPatientRepo.On("FindSummaryByID", uint(1)).Return(expectedPatient, nil)
 
 		result, err := service.GetPatientByID(1)
 
@@ -167,9 +356,11 @@ func TestGetPatientByID(t *testing.T) {
 		mockPatientRepo := new(mocks.PatientRepository)
 		mockStaffRepo := new(mocks.StaffRepository)
 
-		service := NewPatientService(mockPatientRepo, mockStaffRepo)
+		service := NewPatientService(mockPatientRepo, mockStaffRepo, new(mocks.SequenceRepository),
+			new(mocks.AuditLogRepository), new(mocks.PatientContactRepository), new(mocks.AdmissionRepository),
+			new(mocks.ImmunizationRepository), defaultRegistrationFormat)
 
-		mockPatientRepo.On("FindByID", uint(1)).Return(&models.Patient{}, errors.New("not found"))
+		mockPatientRepo.On("FindSummaryByID", uint(1)).Return(&models.Patient{}, errors.New("not found"))
 
 		result, err := service.GetPatientByID(1)
 
@@ -184,7 +375,9 @@ func TestGetPatientByRegistrationNumber(t *testing.T) {
 		mockPatientRepo := new(mocks.PatientRepository)
 		mockStaffRepo := new(mocks.StaffRepository)
 
-		service := NewPatientService(mockPatientRepo, mockStaffRepo)
+		service := NewPatientService(mockPatientRepo, mockStaffRepo, new(mocks.SequenceRepository),
+			new(mocks.AuditLogRepository), new(mocks.PatientContactRepository), new(mocks.AdmissionRepository),
+			new(mocks.ImmunizationRepository), defaultRegistrationFormat)
 
 		expectedPatient := &models.Patient{
 			Model:              gorm.Model{ID: 1},
@@ -206,7 +399,9 @@ func TestGetPatientByRegistrationNumber(t *testing.T) {
 		mockPatientRepo := new(mocks.PatientRepository)
 		mockStaffRepo := new(mocks.StaffRepository)
 
-		service := NewPatientService(mockPatientRepo, mockStaffRepo)
+		service := NewPatientService(mockPatientRepo, mockStaffRepo, new(mocks.SequenceRepository),
+			new(mocks.AuditLogRepository), new(mocks.PatientContactRepository), new(mocks.AdmissionRepository),
+			new(mocks.ImmunizationRepository), defaultRegistrationFormat)
 
 		mockPatientRepo.On("FindByRegistrationNumber", "PAT001").Return(&models.Patient{}, errors.New("not found"))
 
@@ -216,14 +411,67 @@ func TestGetPatientByRegistrationNumber(t *testing.T) {
 		assert.Equal(t, &models.Patient{}, result)
 		mockPatientRepo.AssertExpectations(t)
 	})
+
+	t.Run("InvalidCheckDigit", func(t *testing.T) {
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockStaffRepo := new(mocks.StaffRepository)
+
+		service := NewPatientService(mockPatientRepo, mockStaffRepo, new(mocks.SequenceRepository),
+			new(mocks.AuditLogRepository), new(mocks.PatientContactRepository), new(mocks.AdmissionRepository),
+			new(mocks.ImmunizationRepository), defaultRegistrationFormat)
+
+		// PAT-2026-0000425 carries the correct Luhn digit; the last digit is mistyped.
+		result, err := service.GetPatientByRegistrationNumber("PAT-2026-0000421")
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, utils.ErrInvalidCheckDigit)
+		mockPatientRepo.AssertNotCalled(t, "FindByRegistrationNumber")
+	})
+
+	t.Run("NormalizesCase", func(t *testing.T) {
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockStaffRepo := new(mocks.StaffRepository)
+
+		service := NewPatientService(mockPatientRepo, mockStaffRepo, new(mocks.SequenceRepository),
+			new(mocks.AuditLogRepository), new(mocks.PatientContactRepository), new(mocks.AdmissionRepository),
+			new(mocks.ImmunizationRepository), defaultRegistrationFormat)
+
+		expectedPatient := &models.Patient{Model: gorm.Model{ID: 1}, RegistrationNumber: "PAT-2026-0000425"}
+		mockPatientRepo.On("FindByRegistrationNumber", "PAT-2026-0000425").Return(expectedPatient, nil)
+
+		result, err := service.GetPatientByRegistrationNumber(" pat-2026-0000425 ")
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedPatient, result)
+		mockPatientRepo.AssertExpectations(t)
+	})
+
+	t.Run("OtherFormatLookedUpAsEntered", func(t *testing.T) {
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockStaffRepo := new(mocks.StaffRepository)
+
+		service := NewPatientService(mockPatientRepo, mockStaffRepo, new(mocks.SequenceRepository),
+			new(mocks.AuditLogRepository), new(mocks.PatientContactRepository), new(mocks.AdmissionRepository),
+			new(mocks.ImmunizationRepository), defaultRegistrationFormat)
+
+		mockPatientRepo.On("FindByRegistrationNumber", "hms/88/b").Return(&models.Patient{}, errors.New("not found"))
+
+		_, err := service.GetPatientByRegistrationNumber("hms/88/b")
+
+		assert.Error(t, err)
+		mockPatientRepo.AssertExpectations(t)
+	})
 }
 
 func TestUpdatePatient(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		mockPatientRepo := new(mocks.PatientRepository)
 		mockStaffRepo := new(mocks.StaffRepository)
+		mockAuditRepo := new(mocks.AuditLogRepository)
 
-		service := NewPatientService(mockPatientRepo, mockStaffRepo)
+		service := NewPatientService(mockPatientRepo, mockStaffRepo, new(mocks.SequenceRepository), mockAuditRepo,
+			new(mocks.PatientContactRepository), new(mocks.AdmissionRepository), new(mocks.ImmunizationRepository),
+			defaultRegistrationFormat)
 
 		existingPatient := &models.Patient{
 			Model:              gorm.Model{ID: 1},
@@ -247,6 +495,15 @@ func TestUpdatePatient(t *testing.T) {
 
 		mockPatientRepo.On("FindByID", uint(1)).Return(existingPatient, nil)
 		mockPatientRepo.On("Update", mock.AnythingOfType("*models.Patient")).Return(nil)
+		mockAuditRepo.On("Create", mock.MatchedBy(func(entry *models.AuditLog) bool {
+			var changes map[string]models.FieldChange
+			json.Unmarshal([]byte(entry.Details), &changes)
+			return entry.Action == constants.AuditActions.PATIENT_UPDATE &&
+				*entry.PatientID == 1 &&
+				len(changes) == 3 &&
+				changes["firstName"] == models.FieldChange{From: "John", To: "Jonathan"} &&
+				changes["dateOfBirth"] == models.FieldChange{From: "1990-01-01", To: "1991-02-02"}
+		})).Return(nil)
 
 		result, err := service.UpdatePatient(1, input, 2)
 
@@ -256,13 +513,16 @@ func TestUpdatePatient(t *testing.T) {
 		assert.Equal(t, time.Date(1991, 2, 2, 0, 0, 0, 0, time.UTC), result.DateOfBirth)
 		assert.Equal(t, uint(2), result.UpdatedBy)
 		mockPatientRepo.AssertExpectations(t)
+		mockAuditRepo.AssertExpectations(t)
 	})
 
 	t.Run("PatientNotFound", func(t *testing.T) {
 		mockPatientRepo := new(mocks.PatientRepository)
 		mockStaffRepo := new(mocks.StaffRepository)
 
-		service := NewPatientService(mockPatientRepo, mockStaffRepo)
+		service := NewPatientService(mockPatientRepo, mockStaffRepo, new(mocks.SequenceRepository),
+			new(mocks.AuditLogRepository), new(mocks.PatientContactRepository), new(mocks.AdmissionRepository),
+			new(mocks.ImmunizationRepository), defaultRegistrationFormat)
 
 		mockPatientRepo.On("FindByID", uint(1)).Return(&models.Patient{}, errors.New("not found"))
 
@@ -283,7 +543,9 @@ func TestUpdatePatient(t *testing.T) {
 		mockPatientRepo := new(mocks.PatientRepository)
 		mockStaffRepo := new(mocks.StaffRepository)
 
-		service := NewPatientService(mockPatientRepo, mockStaffRepo)
+		service := NewPatientService(mockPatientRepo, mockStaffRepo, new(mocks.SequenceRepository),
+			new(mocks.AuditLogRepository), new(mocks.PatientContactRepository), new(mocks.AdmissionRepository),
+			new(mocks.ImmunizationRepository), defaultRegistrationFormat)
 
 		existingPatient := &models.Patient{
 			Model:              gorm.Model{ID: 1},
@@ -311,7 +573,9 @@ func TestUpdatePatient(t *testing.T) {
 		mockPatientRepo := new(mocks.PatientRepository)
 		mockStaffRepo := new(mocks.StaffRepository)
 
-		service := NewPatientService(mockPatientRepo, mockStaffRepo)
+		service := NewPatientService(mockPatientRepo, mockStaffRepo, new(mocks.SequenceRepository),
+			new(mocks.AuditLogRepository), new(mocks.PatientContactRepository), new(mocks.AdmissionRepository),
+			new(mocks.ImmunizationRepository), defaultRegistrationFormat)
 
 		existingPatient := &models.Patient{
 			Model:              gorm.Model{ID: 1},
@@ -334,6 +598,61 @@ func TestUpdatePatient(t *testing.T) {
 		assert.Equal(t, "update failed", err.Error())
 		mockPatientRepo.AssertExpectations(t)
 	})
+
+	t.Run("DateOfBirthMakesMinorWithoutGuardian", func(t *testing.T) {
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockContactRepo := new(mocks.PatientContactRepository)
+
+		service := NewPatientService(mockPatientRepo, new(mocks.StaffRepository), new(mocks.SequenceRepository),
+			new(mocks.AuditLogRepository), mockContactRepo, new(mocks.AdmissionRepository),
+			new(mocks.ImmunizationRepository), defaultRegistrationFormat)
+
+		existingPatient := &models.Patient{
+			Model:       gorm.Model{ID: 1},
+			DateOfBirth: time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+		}
+		childDateOfBirth := time.Now().AddDate(-10, 0, 0).Format("2006-01-02")
+
+		mockPatientRepo.On("FindByID", uint(1)).Return(existingPatient, nil)
+		mockContactRepo.On("FindByPatientID", uint(1)).Return([]models.PatientContact{
+			{Model: gorm.Model{ID: 3}, ContactType: constants.ContactTypes.EMERGENCY},
+		}, nil)
+
+		result, err := service.UpdatePatient(1, models.UpdatePatientInput{DateOfBirth: &childDateOfBirth}, 2)
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, errGuardianRequired)
+		mockPatientRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("DateOfBirthMakesMinorWithGuardian", func(t *testing.T) {
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockAuditRepo := new(mocks.AuditLogRepository)
+		mockContactRepo := new(mocks.PatientContactRepository)
+
+		service := NewPatientService(mockPatientRepo, new(mocks.StaffRepository), new(mocks.SequenceRepository),
+			mockAuditRepo, mockContactRepo, new(mocks.AdmissionRepository), new(mocks.ImmunizationRepository),
+			defaultRegistrationFormat)
+
+		existingPatient := &models.Patient{
+			Model:       gorm.Model{ID: 1},
+			DateOfBirth: time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+		}
+		childDateOfBirth := time.Now().AddDate(-10, 0, 0).Format("2006-01-02")
+
+		mockPatientRepo.On("FindByID", uint(1)).Return(existingPatient, nil)
+		mockPatientRepo.On("Update", mock.AnythingOfType("*models.Patient")).Return(nil)
+		mockAuditRepo.On("Create", mock.AnythingOfType("*models.AuditLog")).Return(nil)
+		mockContactRepo.On("FindByPatientID", uint(1)).Return([]models.PatientContact{
+			{Model: gorm.Model{ID: 3}, ContactType: constants.ContactTypes.GUARDIAN},
+		}, nil)
+
+		result, err := service.UpdatePatient(1, models.UpdatePatientInput{DateOfBirth: &childDateOfBirth}, 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, childDateOfBirth, result.DateOfBirth.Format("2006-01-02"))
+		mockPatientRepo.AssertExpectations(t)
+	})
 }
 
 func TestDeletePatient(t *testing.T) {
@@ -341,7 +660,9 @@ func TestDeletePatient(t *testing.T) {
 		mockPatientRepo := new(mocks.PatientRepository)
 		mockStaffRepo := new(mocks.StaffRepository)
 
-		service := NewPatientService(mockPatientRepo, mockStaffRepo)
+		service := NewPatientService(mockPatientRepo, mockStaffRepo, new(mocks.SequenceRepository),
+			new(mocks.AuditLogRepository), new(mocks.PatientContactRepository), new(mocks.AdmissionRepository),
+			new(mocks.ImmunizationRepository), defaultRegistrationFormat)
 
 		existingPatient := &models.Patient{
 			Model:              gorm.Model{ID: 1},
@@ -361,7 +682,9 @@ func TestDeletePatient(t *testing.T) {
 		mockPatientRepo := new(mocks.PatientRepository)
 		mockStaffRepo := new(mocks.StaffRepository)
 
-		service := NewPatientService(mockPatientRepo, mockStaffRepo)
+		service := NewPatientService(mockPatientRepo, mockStaffRepo, new(mocks.SequenceRepository),
+			new(mocks.AuditLogRepository), new(mocks.PatientContactRepository), new(mocks.AdmissionRepository),
+			new(mocks.ImmunizationRepository), defaultRegistrationFormat)
 
 		mockPatientRepo.On("FindByID", uint(1)).Return(&models.Patient{}, errors.New("patient not found"))
 
@@ -377,7 +700,9 @@ func TestDeletePatient(t *testing.T) {
 		mockPatientRepo := new(mocks.PatientRepository)
 		mockStaffRepo := new(mocks.StaffRepository)
 
-		service := NewPatientService(mockPatientRepo, mockStaffRepo)
+		service := NewPatientService(mockPatientRepo, mockStaffRepo, new(mocks.SequenceRepository),
+			new(mocks.AuditLogRepository), new(mocks.PatientContactRepository), new(mocks.AdmissionRepository),
+			new(mocks.ImmunizationRepository), defaultRegistrationFormat)
 
 		existingPatient := &models.Patient{
 			Model:              gorm.Model{ID: 1},
@@ -398,3 +723,160 @@ func TestDeletePatient(t *testing.T) {
 // func stringPtr(s string) *string {
 // 	return &s
 // }
+
+func TestMergePatients(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockAuditLogRepo := new(mocks.AuditLogRepository)
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
+		mockImmunizationRepo := new(mocks.ImmunizationRepository)
+
+		service := NewPatientService(mockPatientRepo, new(mocks.StaffRepository), new(mocks.SequenceRepository),
+			mockAuditLogRepo, new(mocks.PatientContactRepository), mockAdmissionRepo, mockImmunizationRepo,
+			defaultRegistrationFormat)
+
+		survivor := &models.Patient{Model: gorm.Model{ID: 1}, RegistrationNumber: "PAT-2026-0000011"}
+		duplicate := &models.Patient{Model: gorm.Model{ID: 2}, RegistrationNumber: "PAT-2026-0000029"}
+
+		mockPatientRepo.On("FindByID", uint(1)).Return(survivor, nil)
+		mockPatientRepo.On("FindByID", uint(2)).Return(duplicate, nil)
+		mockAdmissionRepo.On("FindActiveByPatientID", uint(1)).Return(&models.Admission{}, gorm.ErrRecordNotFound)
+		mockImmunizationRepo.On("FindByPatientID", uint(1)).Return([]models.Immunization{}, nil)
+		mockImmunizationRepo.On("FindByPatientID", uint(2)).Return([]models.Immunization{}, nil)
+		mockPatientRepo.On("Merge", uint(1), uint(2), mock.AnythingOfType("*models.PatientAlias"), []uint(nil)).
+			Return(nil).
+			Run(func(args mock.Arguments) {
+				alias := args.Get(2).(*models.PatientAlias)
+				assert.Equal(t, "PAT-2026-0000029", alias.RegistrationNumber)
+				assert.Equal(t, uint(1), alias.PatientID)
+				assert.Equal(t, uint(9), alias.MergedBy)
+			})
+		mockAuditLogRepo.On("Create", mock.AnythingOfType("*models.AuditLog")).Return(nil).Run(func(args mock.Arguments) {
+			entry := args.Get(0).(*models.AuditLog)
+			assert.Equal(t, "patient_merge", entry.Action)
+			assert.Equal(t, uint(2), entry.EntityID)
+			assert.Equal(t, "Registered twice", entry.Reason)
+		})
+
+		result, err := service.MergePatients(models.MergePatientsInput{
+			SurvivorID: 1, DuplicateID: 2, Reason: "Registered twice",
+		}, 9)
+
+		assert.NoError(t, err)
+		assert.Equal(t, survivor, result)
+		mockPatientRepo.AssertExpectations(t)
+		mockAuditLogRepo.AssertExpectations(t)
+	})
+
+	t.Run("DuplicateNotFound", func(t *testing.T) {
+		mockPatientRepo := new(mocks.PatientRepository)
+
+		service := NewPatientService(mockPatientRepo, new(mocks.StaffRepository), new(mocks.SequenceRepository),
+			new(mocks.AuditLogRepository), new(mocks.PatientContactRepository), new(mocks.AdmissionRepository),
+			new(mocks.ImmunizationRepository), defaultRegistrationFormat)
+
+		mockPatientRepo.On("FindByID", uint(1)).Return(&models.Patient{Model: gorm.Model{ID: 1}}, nil)
+		mockPatientRepo.On("FindByID", uint(2)).Return(&models.Patient{}, errors.New("record not found"))
+
+		result, err := service.MergePatients(models.MergePatientsInput{SurvivorID: 1, DuplicateID: 2, Reason: "x"}, 9)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "duplicate patient not found")
+		mockPatientRepo.AssertNotCalled(t, "Merge", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("BothAdmitted", func(t *testing.T) {
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
+
+		service := NewPatientService(mockPatientRepo, new(mocks.StaffRepository), new(mocks.SequenceRepository),
+			new(mocks.AuditLogRepository), new(mocks.PatientContactRepository), mockAdmissionRepo,
+			new(mocks.ImmunizationRepository), defaultRegistrationFormat)
+
+		mockPatientRepo.On("FindByID", uint(1)).Return(&models.Patient{Model: gorm.Model{ID: 1}}, nil)
+		mockPatientRepo.On("FindByID", uint(2)).Return(&models.Patient{Model: gorm.Model{ID: 2}}, nil)
+		mockAdmissionRepo.On("FindActiveByPatientID", uint(1)).Return(&models.Admission{PatientID: 1}, nil)
+		mockAdmissionRepo.On("FindActiveByPatientID", uint(2)).Return(&models.Admission{PatientID: 2}, nil)
+
+		result, err := service.MergePatients(models.MergePatientsInput{SurvivorID: 1, DuplicateID: 2, Reason: "x"}, 9)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "both patients are currently admitted; discharge one of the admissions before merging")
+		mockPatientRepo.AssertNotCalled(t, "Merge", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("ClashingImmunizations", func(t *testing.T) {
+		mockPatientRepo := new(mocks.PatientRepository)
+		mockAuditLogRepo := new(mocks.AuditLogRepository)
+		mockAdmissionRepo := new(mocks.AdmissionRepository)
+		mockImmunizationRepo := new(mocks.ImmunizationRepository)
+
+		service := NewPatientService(mockPatientRepo, new(mocks.StaffRepository), new(mocks.SequenceRepository),
+			mockAuditLogRepo, new(mocks.PatientContactRepository), mockAdmissionRepo, mockImmunizationRepo,
+			defaultRegistrationFormat)
+
+		mockPatientRepo.On("FindByID", uint(1)).Return(&models.Patient{Model: gorm.Model{ID: 1}}, nil)
+		mockPatientRepo.On("FindByID", uint(2)).Return(&models.Patient{Model: gorm.Model{ID: 2}}, nil)
+		mockAdmissionRepo.On("FindActiveByPatientID", uint(1)).Return(&models.Admission{PatientID: 1}, nil)
+		mockAdmissionRepo.On("FindActiveByPatientID", uint(2)).Return(&models.Admission{}, gorm.ErrRecordNotFound)
+		mockImmunizationRepo.On("FindByPatientID", uint(1)).Return([]models.Immunization{
+			{Model: gorm.Model{ID: 11}, PatientID: 1, VaccineID: 4, DoseNumber: 1},
+		}, nil)
+		mockImmunizationRepo.On("FindByPatientID", uint(2)).Return([]models.Immunization{
+			{Model: gorm.Model{ID: 21}, PatientID: 2, VaccineID: 4, DoseNumber: 1},
+			{Model: gorm.Model{ID: 22}, PatientID: 2, VaccineID: 4, DoseNumber: 2},
+			{Model: gorm.Model{ID: 23}, PatientID: 2, VaccineID: 5, DoseNumber: 1},
+		}, nil)
+		mockPatientRepo.On("Merge", uint(1), uint(2), mock.AnythingOfType("*models.PatientAlias"), []uint{21}).
+			Return(nil)
+		mockAuditLogRepo.On("Create", mock.AnythingOfType("*models.AuditLog")).Return(nil)
+
+		_, err := service.MergePatients(models.MergePatientsInput{SurvivorID: 1, DuplicateID: 2, Reason: "x"}, 9)
+
+		assert.NoError(t, err)
+		mockPatientRepo.AssertExpectations(t)
+	})
+}
+
+func TestSearchPatients(t *testing.T) {
+	t.Run("NormalizesInput", func(t *testing.T) {
+		mockPatientRepo := new(mocks.PatientRepository)
+
+		service := NewPatientService(mockPatientRepo, new(mocks.StaffRepository), new(mocks.SequenceRepository),
+			new(mocks.AuditLogRepository), new(mocks.PatientContactRepository), new(mocks.AdmissionRepository),
+			new(mocks.ImmunizationRepository), defaultRegistrationFormat)
+
+		expected := []models.Patient{{Model: gorm.Model{ID: 1}, FirstName: "Chioma", LastName: "Okeke"}}
+		dateOfBirth := time.Date(1988, 4, 12, 0, 0, 0, 0, time.UTC)
+
+		mockPatientRepo.On("Search", mock.MatchedBy(func(query models.PatientSearchQuery) bool {
+			return query.Name == "chiom okeke" && query.Phone == "8031234567" &&
+				query.Page == 1 && query.PageSize == models.DefaultPageSize
+		}), &dateOfBirth).Return(expected, int64(1), nil)
+
+		patients, total, err := service.SearchPatients(models.PatientSearchQuery{
+			Name:        "  chiom okeke ",
+			Phone:       "+234 (803) 123-4567",
+			DateOfBirth: "1988-04-12",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, patients)
+		assert.Equal(t, int64(1), total)
+		mockPatientRepo.AssertExpectations(t)
+	})
+
+	t.Run("PhoneWithoutDigits", func(t *testing.T) {
+		mockPatientRepo := new(mocks.PatientRepository)
+
+		service := NewPatientService(mockPatientRepo, new(mocks.StaffRepository), new(mocks.SequenceRepository),
+			new(mocks.AuditLogRepository), new(mocks.PatientContactRepository), new(mocks.AdmissionRepository),
+			new(mocks.ImmunizationRepository), defaultRegistrationFormat)
+
+		patients, _, err := service.SearchPatients(models.PatientSearchQuery{Phone: "abc"})
+
+		assert.Nil(t, patients)
+		assert.EqualError(t, err, "phone search must contain digits")
+		mockPatientRepo.AssertNotCalled(t, "Search", mock.Anything, mock.Anything)
+	})
+}
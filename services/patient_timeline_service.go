@@ -0,0 +1,157 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+)
+
+// timelineEventTypes is the default feed when no type filter is given.
+var timelineEventTypes = []string{
+	constants.TimelineEventTypes.APPOINTMENT,
+	constants.TimelineEventTypes.CLINICAL_NOTE,
+	constants.TimelineEventTypes.STATUS_CHANGE,
+	constants.TimelineEventTypes.DEMOGRAPHIC_EDIT,
+	constants.TimelineEventTypes.VITAL_SIGNS,
+	constants.TimelineEventTypes.NURSING_NOTE,
+}
+
+type PatientTimelineService interface {
+	GetTimeline(patientID uint, query models.TimelineQuery) ([]models.TimelineEvent, int64, error)
+}
+
+type patientTimelineService struct {
+	timelineRepository repositories.TimelineRepository
+	patientRepository  repositories.PatientRepository
+}
+
+func NewPatientTimelineService(
+	timelineRepository repositories.TimelineRepository,
+	patientRepository repositories.PatientRepository,
+) PatientTimelineService {
+	return &patientTimelineService{
+		timelineRepository: timelineRepository,
+		patientRepository:  patientRepository,
+	}
+}
+
+func (ts *patientTimelineService) GetTimeline(patientID uint, query models.TimelineQuery) ([]models.TimelineEvent, int64, error) {
+	patient, err := ts.patientRepository.FindByID(patientID)
+	if err != nil || patient == nil {
+		return nil, 0, errors.New("patient record not found")
+	}
+
+	query.Normalize()
+	filter, err := parseTimelineQuery(query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	events, total, err := ts.timelineRepository.FindByPatientID(patientID, filter, query.PageQuery)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := range events {
+		describeAuditEvent(&events[i])
+	}
+	return events, total, nil
+}
+
+func parseTimelineQuery(query models.TimelineQuery) (models.TimelineFilter, error) {
+	var filter models.TimelineFilter
+
+	if strings.TrimSpace(query.Types) == "" {
+		filter.Types = timelineEventTypes
+	} else {
+		for _, eventType := range strings.Split(query.Types, ",") {
+			eventType = strings.TrimSpace(eventType)
+			if !slices.Contains(timelineEventTypes, eventType) {
+				return filter, fmt.Errorf("unknown timeline type %q", eventType)
+			}
+			filter.Types = append(filter.Types, eventType)
+		}
+	}
+
+	if query.From != "" {
+		from, err := time.Parse("2006-01-02", query.From)
+		if err != nil {
+			return filter, errors.New("invalid date format, use YYYY-MM-DD")
+		}
+		filter.From = &from
+	}
+	if query.To != "" {
+		to, err := time.Parse("2006-01-02", query.To)
+		if err != nil {
+			return filter, errors.New("invalid date format, use YYYY-MM-DD")
+		}
+		// Include the whole of the last day.
+		to = to.AddDate(0, 0, 1)
+		filter.To = &to
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return filter, errors.New("from date must not be after to date")
+	}
+
+	return filter, nil
+}
+
+// describeAuditEvent turns the change details stored by the audit log into a
+// readable summary.
+func describeAuditEvent(event *models.TimelineEvent) {
+	switch event.Type {
+	case constants.TimelineEventTypes.STATUS_CHANGE:
+		var change models.FieldChange
+		if json.Unmarshal([]byte(event.Details), &change) != nil {
+			return
+		}
+		entity := humanize(event.EntityType)
+		if entity != "" {
+			entity = strings.ToUpper(entity[:1]) + entity[1:]
+		}
+		event.Summary = fmt.Sprintf("%s status changed from %s to %s", entity,
+			strings.ReplaceAll(change.From, "_", " "), strings.ReplaceAll(change.To, "_", " "))
+		event.Changes = map[string]models.FieldChange{"status": change}
+		event.Details = ""
+	case constants.TimelineEventTypes.DEMOGRAPHIC_EDIT:
+		var changes map[string]models.FieldChange
+		if json.Unmarshal([]byte(event.Details), &changes) != nil {
+			return
+		}
+		fields := make([]string, 0, len(changes))
+		for field := range changes {
+			fields = append(fields, humanize(field))
+		}
+		sort.Strings(fields)
+		event.Summary = "Updated " + strings.Join(fields, ", ")
+		event.Changes = changes
+		event.Details = ""
+	}
+}
+
+// humanize turns identifiers like "phoneNumber" or "clinical_note" into
+// "phone number" and "clinical note".
+func humanize(identifier string) string {
+	var b strings.Builder
+	for i, r := range identifier {
+		switch {
+		case r == '_':
+			b.WriteRune(' ')
+		case unicode.IsUpper(r) && i > 0:
+			b.WriteRune(' ')
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestGetTimeline(t *testing.T) {
+	t.Run("DefaultsToAllTypes", func(t *testing.T) {
+		mockTimelineRepo := new(mocks.TimelineRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		service := NewPatientTimelineService(mockTimelineRepo, mockPatientRepo)
+
+		mockPatientRepo.On("FindByID", uint(1)).Return(&models.Patient{Model: gorm.Model{ID: 1}}, nil)
+		mockTimelineRepo.On("FindByPatientID", uint(1), models.TimelineFilter{Types: timelineEventTypes},
+			models.PageQuery{Page: 1, PageSize: models.DefaultPageSize}).
+			Return([]models.TimelineEvent{}, int64(0), nil)
+
+		events, total, err := service.GetTimeline(1, models.TimelineQuery{})
+
+		assert.NoError(t, err)
+		assert.Empty(t, events)
+		assert.Equal(t, int64(0), total)
+		mockTimelineRepo.AssertExpectations(t)
+	})
+
+	t.Run("FiltersTypesAndDates", func(t *testing.T) {
+		mockTimelineRepo := new(mocks.TimelineRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		service := NewPatientTimelineService(mockTimelineRepo, mockPatientRepo)
+
+		mockPatientRepo.On("FindByID", uint(1)).Return(&models.Patient{Model: gorm.Model{ID: 1}}, nil)
+		mockTimelineRepo.On("FindByPatientID", uint(1), mock.MatchedBy(func(filter models.TimelineFilter) bool {
+			return assert.ObjectsAreEqual([]string{"appointment", "clinical_note"}, filter.Types) &&
+				filter.From.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) &&
+				filter.To.Equal(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+		}), models.PageQuery{Page: 2, PageSize: 10}).Return([]models.TimelineEvent{}, int64(12), nil)
+
+		_, total, err := service.GetTimeline(1, models.TimelineQuery{
+			PageQuery: models.PageQuery{Page: 2, PageSize: 10},
+			Types:     "appointment, clinical_note",
+			From:      "2024-01-01",
+			To:        "2024-01-31",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(12), total)
+		mockTimelineRepo.AssertExpectations(t)
+	})
+
+	t.Run("UnknownType", func(t *testing.T) {
+		mockTimelineRepo := new(mocks.TimelineRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		service := NewPatientTimelineService(mockTimelineRepo, mockPatientRepo)
+
+		mockPatientRepo.On("FindByID", uint(1)).Return(&models.Patient{Model: gorm.Model{ID: 1}}, nil)
+
+		_, _, err := service.GetTimeline(1, models.TimelineQuery{Types: "appointment,invoice"})
+
+		assert.EqualError(t, err, `unknown timeline type "invoice"`)
+		mockTimelineRepo.AssertNotCalled(t, "FindByPatientID", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("FromAfterTo", func(t *testing.T) {
+		mockTimelineRepo := new(mocks.TimelineRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		service := NewPatientTimelineService(mockTimelineRepo, mockPatientRepo)
+
+		mockPatientRepo.On("FindByID", uint(1)).Return(&models.Patient{Model: gorm.Model{ID: 1}}, nil)
+
+		_, _, err := service.GetTimeline(1, models.TimelineQuery{From: "2024-03-01", To: "2024-02-01"})
+
+		assert.EqualError(t, err, "from date must not be after to date")
+	})
+
+	t.Run("DescribesAuditEvents", func(t *testing.T) {
+		mockTimelineRepo := new(mocks.TimelineRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		service := NewPatientTimelineService(mockTimelineRepo, mockPatientRepo)
+
+		mockPatientRepo.On("FindByID", uint(1)).Return(&models.Patient{Model: gorm.Model{ID: 1}}, nil)
+		mockTimelineRepo.On("FindByPatientID", uint(1), mock.Anything, mock.Anything).Return([]models.TimelineEvent{
+			{
+				Type:       constants.TimelineEventTypes.STATUS_CHANGE,
+				EntityType: constants.AuditEntities.APPOINTMENT,
+				EntityID:   4,
+				Summary:    constants.AuditActions.APPOINTMENT_STATUS_CHANGE,
+				Details:    `{"from":"scheduled","to":"no_show"}`,
+			},
+			{
+				Type:       constants.TimelineEventTypes.DEMOGRAPHIC_EDIT,
+				EntityType: constants.AuditEntities.PATIENT,
+				EntityID:   1,
+				Summary:    constants.AuditActions.PATIENT_UPDATE,
+				Details:    `{"phoneNumber":{"from":"+2348000000000","to":"+2348111111111"},"address":{"from":"","to":"Lagos"}}`,
+			},
+		}, int64(2), nil)
+
+		events, _, err := service.GetTimeline(1, models.TimelineQuery{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Appointment status changed from scheduled to no show", events[0].Summary)
+		assert.Equal(t, "no_show", events[0].Changes["status"].To)
+		assert.Equal(t, "Updated address, phone number", events[1].Summary)
+		assert.Equal(t, "Lagos", events[1].Changes["address"].To)
+		assert.Empty(t, events[1].Details)
+	})
+
+	t.Run("PatientNotFound", func(t *testing.T) {
+		mockTimelineRepo := new(mocks.TimelineRepository)
+		mockPatientRepo := new(mocks.PatientRepository)
+		service := NewPatientTimelineService(mockTimelineRepo, mockPatientRepo)
+
+		mockPatientRepo.On("FindByID", uint(9)).Return((*models.Patient)(nil), gorm.ErrRecordNotFound)
+
+		_, _, err := service.GetTimeline(9, models.TimelineQuery{})
+
+		assert.EqualError(t, err, "patient record not found")
+	})
+}
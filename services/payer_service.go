@@ -0,0 +1,135 @@
+package services
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+)
+
+type PayerService interface {
+	CreatePayer(input models.CreatePayerInput) (*models.Payer, error)
+	GetAllPayers(activeOnly bool) ([]models.Payer, error)
+	GetPayerByID(id uint) (*models.Payer, error)
+	UpdatePayer(id uint, input models.UpdatePayerInput) (*models.Payer, error)
+	CreatePlan(payerID uint, input models.CreateInsurancePlanInput) (*models.InsurancePlan, error)
+	UpdatePlan(payerID uint, planID uint, input models.UpdateInsurancePlanInput) (*models.InsurancePlan, error)
+}
+
+type payerService struct {
+	payerRepository repositories.PayerRepository
+}
+
+func NewPayerService(payerRepository repositories.PayerRepository) PayerService {
+	return &payerService{payerRepository: payerRepository}
+}
+
+func (ps *payerService) CreatePayer(input models.CreatePayerInput) (*models.Payer, error) {
+	payer := &models.Payer{
+		Code:         strings.ToUpper(strings.TrimSpace(input.Code)),
+		Name:         strings.TrimSpace(input.Name),
+		PayerType:    input.PayerType,
+		ContactEmail: input.ContactEmail,
+		ContactPhone: input.ContactPhone,
+		IsActive:     true,
+	}
+
+	if err := ps.payerRepository.Create(payer); err != nil {
+		return nil, err
+	}
+
+	return payer, nil
+}
+
+func (ps *payerService) GetAllPayers(activeOnly bool) ([]models.Payer, error) {
+	return ps.payerRepository.FindAll(activeOnly)
+}
+
+func (ps *payerService) GetPayerByID(id uint) (*models.Payer, error) {
+	return ps.payerRepository.FindByID(id)
+}
+
+func (ps *payerService) UpdatePayer(id uint, input models.UpdatePayerInput) (*models.Payer, error) {
+	payer, err := ps.payerRepository.FindByID(id)
+	if err != nil {
+		return nil, errors.New("payer not found")
+	}
+
+	if input.Name != nil {
+		payer.Name = strings.TrimSpace(*input.Name)
+	}
+	if input.PayerType != nil {
+		payer.PayerType = *input.PayerType
+	}
+	if input.ContactEmail != nil {
+		payer.ContactEmail = *input.ContactEmail
+	}
+	if input.ContactPhone != nil {
+		payer.ContactPhone = *input.ContactPhone
+	}
+	if input.IsActive != nil {
+		payer.IsActive = *input.IsActive
+	}
+
+	if err := ps.payerRepository.Update(payer); err != nil {
+		return nil, err
+	}
+
+	return payer, nil
+}
+
+func (ps *payerService) CreatePlan(payerID uint, input models.CreateInsurancePlanInput) (*models.InsurancePlan, error) {
+	payer, err := ps.payerRepository.FindByID(payerID)
+	if err != nil {
+		return nil, errors.New("payer not found")
+	}
+
+	code := strings.ToUpper(strings.TrimSpace(input.Code))
+	for _, plan := range payer.Plans {
+		if plan.Code == code {
+			return nil, errors.New("payer already has a plan with this code")
+		}
+	}
+
+	plan := &models.InsurancePlan{
+		PayerID:      payer.ID,
+		Code:         code,
+		Name:         strings.TrimSpace(input.Name),
+		CopayPercent: input.CopayPercent,
+		Description:  input.Description,
+		IsActive:     true,
+	}
+
+	if err := ps.payerRepository.CreatePlan(plan); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+func (ps *payerService) UpdatePlan(payerID uint, planID uint, input models.UpdateInsurancePlanInput) (*models.InsurancePlan, error) {
+	plan, err := ps.payerRepository.FindPlanByID(planID)
+	if err != nil || plan.PayerID != payerID {
+		return nil, errors.New("plan not found")
+	}
+
+	if input.Name != nil {
+		plan.Name = strings.TrimSpace(*input.Name)
+	}
+	if input.CopayPercent != nil {
+		plan.CopayPercent = *input.CopayPercent
+	}
+	if input.Description != nil {
+		plan.Description = *input.Description
+	}
+	if input.IsActive != nil {
+		plan.IsActive = *input.IsActive
+	}
+
+	if err := ps.payerRepository.UpdatePlan(plan); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
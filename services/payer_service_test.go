@@ -0,0 +1,93 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestCreatePayer(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockPayerRepo := new(mocks.PayerRepository)
+		service := NewPayerService(mockPayerRepo)
+
+		mockPayerRepo.On("Create", mock.AnythingOfType("*models.Payer")).Return(nil)
+
+		payer, err := service.CreatePayer(models.CreatePayerInput{
+			Code:      " acme ",
+			Name:      "Acme HMO",
+			PayerType: "hmo",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ACME", payer.Code)
+		assert.True(t, payer.IsActive)
+		mockPayerRepo.AssertExpectations(t)
+	})
+}
+
+func TestCreatePlan(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockPayerRepo := new(mocks.PayerRepository)
+		service := NewPayerService(mockPayerRepo)
+
+		mockPayerRepo.On("FindByID", uint(1)).Return(&models.Payer{Model: gorm.Model{ID: 1}}, nil)
+		mockPayerRepo.On("CreatePlan", mock.AnythingOfType("*models.InsurancePlan")).Return(nil)
+
+		plan, err := service.CreatePlan(1, models.CreateInsurancePlanInput{Code: "gold", Name: "Gold", CopayPercent: 10})
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(1), plan.PayerID)
+		assert.Equal(t, "GOLD", plan.Code)
+		assert.Equal(t, 10.0, plan.CopayPercent)
+	})
+
+	t.Run("DuplicateCode", func(t *testing.T) {
+		mockPayerRepo := new(mocks.PayerRepository)
+		service := NewPayerService(mockPayerRepo)
+
+		mockPayerRepo.On("FindByID", uint(1)).Return(&models.Payer{
+			Model: gorm.Model{ID: 1},
+			Plans: []models.InsurancePlan{{Code: "GOLD"}},
+		}, nil)
+
+		plan, err := service.CreatePlan(1, models.CreateInsurancePlanInput{Code: "gold", Name: "Gold"})
+
+		assert.Error(t, err)
+		assert.Nil(t, plan)
+		mockPayerRepo.AssertNotCalled(t, "CreatePlan", mock.Anything)
+	})
+
+	t.Run("PayerNotFound", func(t *testing.T) {
+		mockPayerRepo := new(mocks.PayerRepository)
+		service := NewPayerService(mockPayerRepo)
+
+		mockPayerRepo.On("FindByID", uint(9)).Return((*models.Payer)(nil), errors.New("record not found"))
+
+		plan, err := service.CreatePlan(9, models.CreateInsurancePlanInput{Code: "gold", Name: "Gold"})
+
+		assert.EqualError(t, err, "payer not found")
+		assert.Nil(t, plan)
+	})
+}
+
+func TestUpdatePlan(t *testing.T) {
+	t.Run("PlanBelongsToAnotherPayer", func(t *testing.T) {
+		mockPayerRepo := new(mocks.PayerRepository)
+		service := NewPayerService(mockPayerRepo)
+
+		mockPayerRepo.On("FindPlanByID", uint(5)).Return(&models.InsurancePlan{Model: gorm.Model{ID: 5}, PayerID: 2}, nil)
+
+		active := false
+		plan, err := service.UpdatePlan(1, 5, models.UpdateInsurancePlanInput{IsActive: &active})
+
+		assert.EqualError(t, err, "plan not found")
+		assert.Nil(t, plan)
+		mockPayerRepo.AssertNotCalled(t, "UpdatePlan", mock.Anything)
+	})
+}
@@ -0,0 +1,244 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+)
+
+type PaymentService interface {
+	RecordPayment(input models.CreatePaymentInput, staffID uint) (*models.Payment, error)
+	GetPayments(query models.PaymentQuery) ([]models.Payment, int64, error)
+	GetPaymentByID(id uint) (*models.Payment, error)
+	GetPrintableReceipt(id uint) (*ReceiptDocument, error)
+	RefundPayment(id uint, input models.RefundPaymentInput, staffID uint) (*models.Payment, error)
+}
+
+// ReceiptDocument gathers everything needed to print a payment receipt.
+type ReceiptDocument struct {
+	HospitalName string
+	Payment      *models.Payment
+	Invoice      *models.Invoice
+	Patient      *models.Patient
+	Cashier      *models.Staff
+	Balance      float64
+	PrintedAt    time.Time
+}
+
+type paymentService struct {
+	paymentRepository      repositories.PaymentRepository
+	invoiceRepository      repositories.InvoiceRepository
+	cashierShiftRepository repositories.CashierShiftRepository
+	sequenceRepository     repositories.SequenceRepository
+	patientRepository      repositories.PatientRepository
+	staffRepository        repositories.StaffRepository
+	branch                 string
+}
+
+func NewPaymentService(
+	paymentRepository repositories.PaymentRepository,
+	invoiceRepository repositories.InvoiceRepository,
+	cashierShiftRepository repositories.CashierShiftRepository,
+	sequenceRepository repositories.SequenceRepository,
+	patientRepository repositories.PatientRepository,
+	staffRepository repositories.StaffRepository,
+	branch string,
+) PaymentService {
+	return &paymentService{
+		paymentRepository:      paymentRepository,
+		invoiceRepository:      invoiceRepository,
+		cashierShiftRepository: cashierShiftRepository,
+		sequenceRepository:     sequenceRepository,
+		patientRepository:      patientRepository,
+		staffRepository:        staffRepository,
+		branch:                 strings.TrimSpace(branch),
+	}
+}
+
+// RecordPayment takes payment against an issued invoice, up to its balance.
+// Cash can only be taken in an open cashier shift; other methods need a
+// reference such as the card slip, transfer or remittance number.
+func (ps *paymentService) RecordPayment(input models.CreatePaymentInput, staffID uint) (*models.Payment, error) {
+	invoice, err := ps.invoiceRepository.FindByID(input.InvoiceID)
+	if err != nil {
+		return nil, errors.New("invoice not found")
+	}
+
+	statuses := constants.InvoiceStatus
+	switch invoice.Status {
+	case statuses.DRAFT:
+		return nil, errors.New("only issued invoices can be paid")
+	case statuses.VOID:
+		return nil, errors.New("cannot pay a void invoice")
+	case statuses.PAID:
+		return nil, errors.New("invoice is already paid")
+	}
+
+	methods := constants.PaymentMethods
+	reference := strings.TrimSpace(input.Reference)
+	if input.Method != methods.CASH && reference == "" {
+		return nil, errors.New("a reference is required for card, transfer and insurance payments")
+	}
+	if input.Method == methods.INSURANCE && invoice.PayerID == nil {
+		return nil, errors.New("invoice is not billed to an insurer")
+	}
+
+	amount := roundMoney(input.Amount)
+	balance := roundMoney(invoice.Total - invoice.AmountPaid)
+	if amount > balance {
+		return nil, fmt.Errorf("amount exceeds the invoice balance of %.2f", balance)
+	}
+
+	shiftID, err := ps.shiftFor(staffID, input.Method)
+	if err != nil {
+		return nil, err
+	}
+
+	number, err := nextDocumentNumber(ps.sequenceRepository, constants.ReceiptSequence, "RCT", ps.branch, "receipt")
+	if err != nil {
+		return nil, err
+	}
+
+	payment := &models.Payment{
+		ReceiptNumber: number,
+		InvoiceID:     invoice.ID,
+		PatientID:     invoice.PatientID,
+		Method:        input.Method,
+		Amount:        amount,
+		Reference:     reference,
+		Notes:         strings.TrimSpace(input.Notes),
+		ShiftID:       shiftID,
+		ReceivedBy:    staffID,
+		ReceivedAt:    time.Now(),
+	}
+
+	if err := ps.paymentRepository.Create(payment); err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+func (ps *paymentService) GetPayments(query models.PaymentQuery) ([]models.Payment, int64, error) {
+	return ps.paymentRepository.FindAll(query)
+}
+
+func (ps *paymentService) GetPaymentByID(id uint) (*models.Payment, error) {
+	payment, err := ps.paymentRepository.FindByID(id)
+	if err != nil {
+		return nil, errors.New("payment not found")
+	}
+	return payment, nil
+}
+
+func (ps *paymentService) GetPrintableReceipt(id uint) (*ReceiptDocument, error) {
+	payment, err := ps.paymentRepository.FindByID(id)
+	if err != nil {
+		return nil, errors.New("payment not found")
+	}
+
+	invoice, err := ps.invoiceRepository.FindByID(payment.InvoiceID)
+	if err != nil {
+		return nil, errors.New("invoice not found")
+	}
+
+	patient, err := ps.patientRepository.FindByID(payment.PatientID)
+	if err != nil || patient == nil {
+		return nil, errors.New("patient record not found")
+	}
+
+	cashier, err := ps.staffRepository.FindByID(payment.ReceivedBy)
+	if err != nil || cashier == nil {
+		return nil, errors.New("receiving staff member not found")
+	}
+
+	return &ReceiptDocument{
+		HospitalName: os.Getenv("HOSPITAL_NAME"),
+		Payment:      payment,
+		Invoice:      invoice,
+		Patient:      patient,
+		Cashier:      cashier,
+		Balance:      roundMoney(invoice.Total - invoice.AmountPaid),
+		PrintedAt:    time.Now(),
+	}, nil
+}
+
+// RefundPayment returns money by the method it was paid with, reopening the
+// invoice balance. Cash refunds come out of the refunding staff member's
+// open shift.
+func (ps *paymentService) RefundPayment(id uint, input models.RefundPaymentInput, staffID uint) (*models.Payment, error) {
+	payment, err := ps.paymentRepository.FindByID(id)
+	if err != nil {
+		return nil, errors.New("payment not found")
+	}
+
+	amount := roundMoney(input.Amount)
+	refundable := roundMoney(payment.Amount - payment.AmountRefunded)
+	if amount > refundable {
+		return nil, fmt.Errorf("amount exceeds the refundable balance of %.2f", refundable)
+	}
+
+	shiftID, err := ps.shiftFor(staffID, payment.Method)
+	if err != nil {
+		return nil, err
+	}
+
+	refund := models.Refund{
+		PaymentID:  payment.ID,
+		InvoiceID:  payment.InvoiceID,
+		Method:     payment.Method,
+		Amount:     amount,
+		Reason:     strings.TrimSpace(input.Reason),
+		ShiftID:    shiftID,
+		RefundedBy: staffID,
+		RefundedAt: time.Now(),
+	}
+
+	details, err := json.Marshal(map[string]models.FieldChange{
+		"amountRefunded": {
+			From: fmt.Sprintf("%.2f", payment.AmountRefunded),
+			To:   fmt.Sprintf("%.2f", roundMoney(payment.AmountRefunded+amount)),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	entry := &models.AuditLog{
+		Action:     constants.AuditActions.PAYMENT_REFUND,
+		EntityType: constants.AuditEntities.PAYMENT,
+		EntityID:   payment.ID,
+		PatientID:  &payment.PatientID,
+		StaffID:    staffID,
+		Reason:     refund.Reason,
+		Details:    string(details),
+	}
+
+	if err := ps.paymentRepository.Refund(&refund, entry); err != nil {
+		return nil, err
+	}
+
+	payment.AmountRefunded = roundMoney(payment.AmountRefunded + amount)
+	payment.Refunds = append(payment.Refunds, refund)
+
+	return payment, nil
+}
+
+// shiftFor returns the staff member's open shift, which cash must go
+// through. Other methods are linked to the shift only when one is open.
+func (ps *paymentService) shiftFor(staffID uint, method string) (*uint, error) {
+	shift, err := ps.cashierShiftRepository.FindOpenByStaffID(staffID)
+	if err != nil {
+		if method == constants.PaymentMethods.CASH {
+			return nil, errors.New("open a cashier shift before handling cash")
+		}
+		return nil, nil
+	}
+	return &shift.ID, nil
+}
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type paymentMocks struct {
+	payments  *mocks.PaymentRepository
+	invoices  *mocks.InvoiceRepository
+	shifts    *mocks.CashierShiftRepository
+	sequences *mocks.SequenceRepository
+	patients  *mocks.PatientRepository
+	staff     *mocks.StaffRepository
+}
+
+func newPaymentServiceWithMocks(branch string) (PaymentService, paymentMocks) {
+	m := paymentMocks{
+		payments:  new(mocks.PaymentRepository),
+		invoices:  new(mocks.InvoiceRepository),
+		shifts:    new(mocks.CashierShiftRepository),
+		sequences: new(mocks.SequenceRepository),
+		patients:  new(mocks.PatientRepository),
+		staff:     new(mocks.StaffRepository),
+	}
+	service := NewPaymentService(m.payments, m.invoices, m.shifts, m.sequences, m.patients,
+		m.staff, branch)
+	return service, m
+}
+
+func issuedInvoice(total float64, paid float64) *models.Invoice {
+	return &models.Invoice{
+		Model:         gorm.Model{ID: 30},
+		InvoiceNumber: "INV-000030",
+		PatientID:     3,
+		Status:        constants.InvoiceStatus.ISSUED,
+		Total:         total,
+		AmountPaid:    paid,
+	}
+}
+
+func TestRecordPayment(t *testing.T) {
+	t.Run("CashInOpenShift", func(t *testing.T) {
+		service, m := newPaymentServiceWithMocks("LOS")
+
+		m.invoices.On("FindByID", uint(30)).Return(issuedInvoice(5000, 1000), nil)
+		m.shifts.On("FindOpenByStaffID", uint(9)).Return(&models.CashierShift{Model: gorm.Model{ID: 12}}, nil)
+		m.sequences.On("Next", "receipt:LOS").Return(int64(3), nil)
+		m.payments.On("Create", mock.AnythingOfType("*models.Payment")).Return(nil)
+
+		payment, err := service.RecordPayment(models.CreatePaymentInput{
+			InvoiceID: 30, Method: constants.PaymentMethods.CASH, Amount: 2500.004,
+		}, 9)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "RCT-LOS-000003", payment.ReceiptNumber)
+		assert.Equal(t, 2500.0, payment.Amount)
+		assert.Equal(t, uint(3), payment.PatientID)
+		assert.Equal(t, uint(12), *payment.ShiftID)
+		m.payments.AssertExpectations(t)
+	})
+
+	t.Run("CashWithoutShift", func(t *testing.T) {
+		service, m := newPaymentServiceWithMocks("")
+
+		m.invoices.On("FindByID", uint(30)).Return(issuedInvoice(5000, 0), nil)
+		m.shifts.On("FindOpenByStaffID", uint(9)).Return(&models.CashierShift{}, gorm.ErrRecordNotFound)
+
+		_, err := service.RecordPayment(models.CreatePaymentInput{
+			InvoiceID: 30, Method: constants.PaymentMethods.CASH, Amount: 100,
+		}, 9)
+
+		assert.EqualError(t, err, "open a cashier shift before handling cash")
+		m.payments.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("CardWithoutShift", func(t *testing.T) {
+		service, m := newPaymentServiceWithMocks("")
+
+		m.invoices.On("FindByID", uint(30)).Return(issuedInvoice(5000, 0), nil)
+		m.shifts.On("FindOpenByStaffID", uint(9)).Return(&models.CashierShift{}, gorm.ErrRecordNotFound)
+		m.sequences.On("Next", "receipt").Return(int64(8), nil)
+		m.payments.On("Create", mock.AnythingOfType("*models.Payment")).Return(nil)
+
+		payment, err := service.RecordPayment(models.CreatePaymentInput{
+			InvoiceID: 30, Method: constants.PaymentMethods.CARD, Amount: 5000, Reference: " POS-1182 ",
+		}, 9)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "RCT-000008", payment.ReceiptNumber)
+		assert.Equal(t, "POS-1182", payment.Reference)
+		assert.Nil(t, payment.ShiftID)
+	})
+
+	t.Run("ReferenceRequired", func(t *testing.T) {
+		service, m := newPaymentServiceWithMocks("")
+
+		m.invoices.On("FindByID", uint(30)).Return(issuedInvoice(5000, 0), nil)
+
+		_, err := service.RecordPayment(models.CreatePaymentInput{
+			InvoiceID: 30, Method: constants.PaymentMethods.TRANSFER, Amount: 100,
+		}, 9)
+
+		assert.EqualError(t, err, "a reference is required for card, transfer and insurance payments")
+	})
+
+	t.Run("InsuranceWithoutPayer", func(t *testing.T) {
+		service, m := newPaymentServiceWithMocks("")
+
+		m.invoices.On("FindByID", uint(30)).Return(issuedInvoice(5000, 0), nil)
+
+		_, err := service.RecordPayment(models.CreatePaymentInput{
+			InvoiceID: 30, Method: constants.PaymentMethods.INSURANCE, Amount: 100, Reference: "REM-77",
+		}, 9)
+
+		assert.EqualError(t, err, "invoice is not billed to an insurer")
+	})
+
+	t.Run("ExceedsBalance", func(t *testing.T) {
+		service, m := newPaymentServiceWithMocks("")
+
+		m.invoices.On("FindByID", uint(30)).Return(issuedInvoice(5000, 4000), nil)
+
+		_, err := service.RecordPayment(models.CreatePaymentInput{
+			InvoiceID: 30, Method: constants.PaymentMethods.CASH, Amount: 1000.01,
+		}, 9)
+
+		assert.EqualError(t, err, "amount exceeds the invoice balance of 1000.00")
+	})
+
+	t.Run("DraftInvoice", func(t *testing.T) {
+		service, m := newPaymentServiceWithMocks("")
+
+		m.invoices.On("FindByID", uint(30)).Return(draftInvoice(), nil)
+
+		_, err := service.RecordPayment(models.CreatePaymentInput{
+			InvoiceID: 30, Method: constants.PaymentMethods.CASH, Amount: 100,
+		}, 9)
+
+		assert.EqualError(t, err, "only issued invoices can be paid")
+	})
+}
+
+func TestRefundPayment(t *testing.T) {
+	payment := func() *models.Payment {
+		return &models.Payment{
+			Model:          gorm.Model{ID: 70},
+			InvoiceID:      30,
+			PatientID:      3,
+			Method:         constants.PaymentMethods.CASH,
+			Amount:         2000,
+			AmountRefunded: 500,
+		}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		service, m := newPaymentServiceWithMocks("")
+
+		m.payments.On("FindByID", uint(70)).Return(payment(), nil)
+		m.shifts.On("FindOpenByStaffID", uint(9)).Return(&models.CashierShift{Model: gorm.Model{ID: 13}}, nil)
+		m.payments.On("Refund", mock.MatchedBy(func(refund *models.Refund) bool {
+			return refund.InvoiceID == 30 && refund.Amount == 1500 && *refund.ShiftID == 13 &&
+				refund.Method == constants.PaymentMethods.CASH
+		}), mock.MatchedBy(func(entry *models.AuditLog) bool {
+			return entry.Action == constants.AuditActions.PAYMENT_REFUND && entry.EntityID == 70 &&
+				entry.Reason == "Lab test not done" &&
+				entry.Details == `{"amountRefunded":{"from":"500.00","to":"2000.00"}}`
+		})).Return(nil)
+
+		refunded, err := service.RefundPayment(70, models.RefundPaymentInput{Amount: 1500, Reason: " Lab test not done "}, 9)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2000.0, refunded.AmountRefunded)
+		assert.Len(t, refunded.Refunds, 1)
+		m.payments.AssertExpectations(t)
+	})
+
+	t.Run("ExceedsRefundable", func(t *testing.T) {
+		service, m := newPaymentServiceWithMocks("")
+
+		m.payments.On("FindByID", uint(70)).Return(payment(), nil)
+
+		_, err := service.RefundPayment(70, models.RefundPaymentInput{Amount: 1600, Reason: "Overcharged"}, 9)
+
+		assert.EqualError(t, err, "amount exceeds the refundable balance of 1500.00")
+		m.payments.AssertNotCalled(t, "Refund", mock.Anything, mock.Anything)
+	})
+
+	t.Run("CashWithoutShift", func(t *testing.T) {
+		service, m := newPaymentServiceWithMocks("")
+
+		m.payments.On("FindByID", uint(70)).Return(payment(), nil)
+		m.shifts.On("FindOpenByStaffID", uint(9)).Return(&models.CashierShift{}, gorm.ErrRecordNotFound)
+
+		_, err := service.RefundPayment(70, models.RefundPaymentInput{Amount: 100, Reason: "Overcharged"}, 9)
+
+		assert.EqualError(t, err, "open a cashier shift before handling cash")
+	})
+}
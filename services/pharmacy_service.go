@@ -0,0 +1,291 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+)
+
+type PharmacyService interface {
+	ReceiveGoods(input models.CreateGoodsReceiptInput, staffID uint) (*models.GoodsReceipt, error)
+	GetGoodsReceipts(page models.PageQuery) ([]models.GoodsReceipt, int64, error)
+	GetGoodsReceiptByID(id uint) (*models.GoodsReceipt, error)
+	GetStockLevels() ([]models.StockLevel, error)
+	GetBatches(medicationID uint) ([]models.StockBatch, error)
+	GetStockLedger(medicationID uint, page models.PageQuery) ([]models.StockLedgerEntry, int64, error)
+	GetLowStockAlerts() ([]models.StockLevel, error)
+	GetExpiryAlerts(query models.ExpiryAlertQuery) ([]models.ExpiryAlert, error)
+	GetDispensingQueue(page models.PageQuery) ([]models.Prescription, int64, error)
+	DispensePrescription(prescriptionID uint, input models.DispensePrescriptionInput, staffID uint) (*models.Dispensation, error)
+	GetDispensations(prescriptionID uint) ([]models.Dispensation, error)
+}
+
+type pharmacyService struct {
+	stockRepository        repositories.StockRepository
+	dispensationRepository repositories.DispensationRepository
+	prescriptionRepository repositories.PrescriptionRepository
+	medicationRepository   repositories.MedicationRepository
+	expiryAlertDays        int
+}
+
+func NewPharmacyService(
+	stockRepository repositories.StockRepository,
+	dispensationRepository repositories.DispensationRepository,
+	prescriptionRepository repositories.PrescriptionRepository,
+	medicationRepository repositories.MedicationRepository,
+	expiryAlertDays int,
+) PharmacyService {
+	return &pharmacyService{
+		stockRepository:        stockRepository,
+		dispensationRepository: dispensationRepository,
+		prescriptionRepository: prescriptionRepository,
+		medicationRepository:   medicationRepository,
+		expiryAlertDays:        expiryAlertDays,
+	}
+}
+
+// ExpiryAlertDaysFromEnv reads how many days ahead batches are flagged as
+// nearing expiry.
+func ExpiryAlertDaysFromEnv() int {
+	days, err := strconv.Atoi(os.Getenv("PHARMACY_EXPIRY_ALERT_DAYS"))
+	if err != nil || days <= 0 {
+		days = constants.DefaultExpiryAlertDays
+	}
+	return days
+}
+
+func (ps *pharmacyService) ReceiveGoods(input models.CreateGoodsReceiptInput, staffID uint) (*models.GoodsReceipt, error) {
+	today := pharmacyToday()
+	seen := make(map[string]bool)
+
+	receipt := &models.GoodsReceipt{
+		Supplier:      strings.TrimSpace(input.Supplier),
+		InvoiceNumber: strings.TrimSpace(input.InvoiceNumber),
+		ReceivedAt:    time.Now(),
+		ReceivedBy:    staffID,
+		Notes:         input.Notes,
+	}
+
+	for _, lineInput := range input.Lines {
+		medication, err := ps.medicationRepository.FindByID(lineInput.MedicationID)
+		if err != nil {
+			return nil, fmt.Errorf("medication %d not found", lineInput.MedicationID)
+		}
+
+		batchNumber := strings.TrimSpace(lineInput.BatchNumber)
+		key := fmt.Sprintf("%d/%s", medication.ID, batchNumber)
+		if seen[key] {
+			return nil, fmt.Errorf("batch %s of %s is listed more than once", batchNumber, medication.Name)
+		}
+		seen[key] = true
+
+		expiryDate, err := time.Parse("2006-01-02", lineInput.ExpiryDate)
+		if err != nil {
+			return nil, errors.New("invalid expiry date format, use YYYY-MM-DD")
+		}
+		if lineInput.ExpiryDate < today {
+			return nil, fmt.Errorf("batch %s of %s has already expired", batchNumber, medication.Name)
+		}
+
+		receipt.Lines = append(receipt.Lines, models.GoodsReceiptLine{
+			MedicationID: medication.ID,
+			BatchNumber:  batchNumber,
+			ExpiryDate:   expiryDate,
+			Quantity:     lineInput.Quantity,
+			UnitCost:     lineInput.UnitCost,
+		})
+	}
+
+	if err := ps.stockRepository.ReceiveGoods(receipt); err != nil {
+		return nil, err
+	}
+
+	return receipt, nil
+}
+
+func (ps *pharmacyService) GetGoodsReceipts(page models.PageQuery) ([]models.GoodsReceipt, int64, error) {
+	return ps.stockRepository.FindReceipts(page)
+}
+
+func (ps *pharmacyService) GetGoodsReceiptByID(id uint) (*models.GoodsReceipt, error) {
+	receipt, err := ps.stockRepository.FindReceiptByID(id)
+	if err != nil {
+		return nil, errors.New("goods receipt not found")
+	}
+	return receipt, nil
+}
+
+func (ps *pharmacyService) GetStockLevels() ([]models.StockLevel, error) {
+	return ps.stockRepository.FindStockLevels(pharmacyToday())
+}
+
+func (ps *pharmacyService) GetBatches(medicationID uint) ([]models.StockBatch, error) {
+	if _, err := ps.medicationRepository.FindByID(medicationID); err != nil {
+		return nil, errors.New("medication not found")
+	}
+	return ps.stockRepository.FindBatchesByMedicationID(medicationID)
+}
+
+func (ps *pharmacyService) GetStockLedger(medicationID uint, page models.PageQuery) ([]models.StockLedgerEntry, int64, error) {
+	if _, err := ps.medicationRepository.FindByID(medicationID); err != nil {
+		return nil, 0, errors.New("medication not found")
+	}
+	return ps.stockRepository.FindLedger(medicationID, page)
+}
+
+// GetLowStockAlerts lists medications whose usable stock has fallen to their
+// reorder level. Medications without a reorder level are never flagged.
+func (ps *pharmacyService) GetLowStockAlerts() ([]models.StockLevel, error) {
+	levels, err := ps.stockRepository.FindStockLevels(pharmacyToday())
+	if err != nil {
+		return nil, err
+	}
+
+	alerts := []models.StockLevel{}
+	for _, level := range levels {
+		if level.ReorderLevel > 0 && level.OnHand <= level.ReorderLevel {
+			alerts = append(alerts, level)
+		}
+	}
+	return alerts, nil
+}
+
+// GetExpiryAlerts lists batches with stock that expire within the window,
+// along with expired batches still on the shelf.
+func (ps *pharmacyService) GetExpiryAlerts(query models.ExpiryAlertQuery) ([]models.ExpiryAlert, error) {
+	withinDays := query.WithinDays
+	if withinDays == 0 {
+		withinDays = ps.expiryAlertDays
+	}
+
+	today, _ := time.Parse("2006-01-02", pharmacyToday())
+	batches, err := ps.stockRepository.FindExpiringBatches(today.AddDate(0, 0, withinDays).Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+
+	alerts := []models.ExpiryAlert{}
+	for _, batch := range batches {
+		year, month, day := batch.ExpiryDate.Date()
+		expiry := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		daysToExpiry := int(expiry.Sub(today).Hours() / 24)
+		alerts = append(alerts, models.ExpiryAlert{
+			Batch:        batch,
+			DaysToExpiry: daysToExpiry,
+			Expired:      daysToExpiry < 0,
+		})
+	}
+	return alerts, nil
+}
+
+func (ps *pharmacyService) GetDispensingQueue(page models.PageQuery) ([]models.Prescription, int64, error) {
+	return ps.prescriptionRepository.FindAwaitingDispensing(page)
+}
+
+// DispensePrescription issues stock against a prescription, taking it from the
+// batches that expire soonest. A prescription can be dispensed in parts and
+// is marked dispensed once its full quantity has been issued.
+func (ps *pharmacyService) DispensePrescription(prescriptionID uint, input models.DispensePrescriptionInput, staffID uint) (*models.Dispensation, error) {
+	prescription, err := ps.prescriptionRepository.FindByID(prescriptionID)
+	if err != nil {
+		return nil, errors.New("prescription not found")
+	}
+
+	remaining := prescription.Quantity - prescription.QuantityDispensed
+	switch {
+	case prescription.Status == constants.PrescriptionStatus.DISCONTINUED:
+		return nil, errors.New("cannot dispense a discontinued prescription")
+	case prescription.Status == constants.PrescriptionStatus.DISPENSED || remaining <= 0:
+		return nil, errors.New("prescription has already been fully dispensed")
+	}
+
+	quantity := remaining
+	if input.Quantity != nil {
+		quantity = *input.Quantity
+	}
+	if quantity > remaining {
+		return nil, fmt.Errorf("only %d left to dispense on this prescription", remaining)
+	}
+
+	batches, err := ps.stockRepository.FindAvailableBatches(prescription.MedicationID, pharmacyToday())
+	if err != nil {
+		return nil, err
+	}
+	items, err := allocateFEFO(batches, quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	dispensation := &models.Dispensation{
+		PrescriptionID: prescription.ID,
+		PatientID:      prescription.PatientID,
+		MedicationID:   prescription.MedicationID,
+		Quantity:       quantity,
+		DispensedAt:    now,
+		DispensedBy:    staffID,
+		Notes:          input.Notes,
+		Items:          items,
+	}
+
+	prescription.QuantityDispensed += quantity
+	if prescription.QuantityDispensed == prescription.Quantity {
+		prescription.Status = constants.PrescriptionStatus.DISPENSED
+		prescription.StatusChangedBy = &staffID
+		prescription.StatusChangedAt = &now
+	}
+
+	if err := ps.dispensationRepository.Dispense(dispensation, prescription); err != nil {
+		return nil, err
+	}
+
+	return dispensation, nil
+}
+
+func (ps *pharmacyService) GetDispensations(prescriptionID uint) ([]models.Dispensation, error) {
+	if _, err := ps.prescriptionRepository.FindByID(prescriptionID); err != nil {
+		return nil, errors.New("prescription not found")
+	}
+	return ps.dispensationRepository.FindByPrescriptionID(prescriptionID)
+}
+
+// allocateFEFO takes quantity from batches in the order given, which the
+// repository returns soonest expiry first.
+func allocateFEFO(batches []models.StockBatch, quantity int) ([]models.DispensationItem, error) {
+	available := 0
+	for _, batch := range batches {
+		available += batch.QuantityOnHand
+	}
+	if available < quantity {
+		return nil, fmt.Errorf("insufficient stock: %d available", available)
+	}
+
+	items := []models.DispensationItem{}
+	for _, batch := range batches {
+		if quantity == 0 {
+			break
+		}
+		take := min(batch.QuantityOnHand, quantity)
+		items = append(items, models.DispensationItem{
+			StockBatchID: batch.ID,
+			BatchNumber:  batch.BatchNumber,
+			ExpiryDate:   batch.ExpiryDate,
+			Quantity:     take,
+		})
+		quantity -= take
+	}
+	return items, nil
+}
+
+// pharmacyToday is the hospital's current date, against which batch expiry
+// dates are compared.
+func pharmacyToday() string {
+	return time.Now().Format("2006-01-02")
+}
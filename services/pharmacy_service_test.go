@@ -0,0 +1,362 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func stockBatch(id uint, number string, expiresInDays, onHand int) models.StockBatch {
+	return models.StockBatch{
+		Model:          gorm.Model{ID: id},
+		MedicationID:   5,
+		BatchNumber:    number,
+		ExpiryDate:     time.Now().AddDate(0, 0, expiresInDays),
+		QuantityOnHand: onHand,
+	}
+}
+
+func TestReceiveGoods(t *testing.T) {
+	amoxicillin := &models.Medication{Model: gorm.Model{ID: 5}, Name: "Amoxicillin"}
+	nextYear := time.Now().AddDate(1, 0, 0).Format("2006-01-02")
+
+	t.Run("Success", func(t *testing.T) {
+		mockStockRepo := new(mocks.StockRepository)
+		mockMedicationRepo := new(mocks.MedicationRepository)
+
+		service := NewPharmacyService(mockStockRepo, new(mocks.DispensationRepository),
+			new(mocks.PrescriptionRepository), mockMedicationRepo, 90)
+
+		mockMedicationRepo.On("FindByID", uint(5)).Return(amoxicillin, nil)
+		mockStockRepo.On("ReceiveGoods", mock.AnythingOfType("*models.GoodsReceipt")).Return(nil)
+
+		receipt, err := service.ReceiveGoods(models.CreateGoodsReceiptInput{
+			Supplier: " Emzor ",
+			Lines: []models.GoodsReceiptLineInput{
+				{MedicationID: 5, BatchNumber: " AMX-01 ", ExpiryDate: nextYear, Quantity: 100},
+				{MedicationID: 5, BatchNumber: "AMX-02", ExpiryDate: nextYear, Quantity: 50},
+			},
+		}, 8)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Emzor", receipt.Supplier)
+		assert.Equal(t, uint(8), receipt.ReceivedBy)
+		assert.Len(t, receipt.Lines, 2)
+		assert.Equal(t, "AMX-01", receipt.Lines[0].BatchNumber)
+		mockStockRepo.AssertExpectations(t)
+	})
+
+	t.Run("ExpiredBatch", func(t *testing.T) {
+		mockStockRepo := new(mocks.StockRepository)
+		mockMedicationRepo := new(mocks.MedicationRepository)
+
+		service := NewPharmacyService(mockStockRepo, new(mocks.DispensationRepository),
+			new(mocks.PrescriptionRepository), mockMedicationRepo, 90)
+
+		mockMedicationRepo.On("FindByID", uint(5)).Return(amoxicillin, nil)
+
+		_, err := service.ReceiveGoods(models.CreateGoodsReceiptInput{
+			Supplier: "Emzor",
+			Lines: []models.GoodsReceiptLineInput{
+				{MedicationID: 5, BatchNumber: "AMX-01", ExpiryDate: time.Now().AddDate(0, 0, -1).Format("2006-01-02"), Quantity: 10},
+			},
+		}, 8)
+
+		assert.EqualError(t, err, "batch AMX-01 of Amoxicillin has already expired")
+		mockStockRepo.AssertNotCalled(t, "ReceiveGoods", mock.Anything)
+	})
+
+	t.Run("DuplicateBatch", func(t *testing.T) {
+		mockMedicationRepo := new(mocks.MedicationRepository)
+
+		service := NewPharmacyService(new(mocks.StockRepository), new(mocks.DispensationRepository),
+			new(mocks.PrescriptionRepository), mockMedicationRepo, 90)
+
+		mockMedicationRepo.On("FindByID", uint(5)).Return(amoxicillin, nil)
+
+		_, err := service.ReceiveGoods(models.CreateGoodsReceiptInput{
+			Supplier: "Emzor",
+			Lines: []models.GoodsReceiptLineInput{
+				{MedicationID: 5, BatchNumber: "AMX-01", ExpiryDate: nextYear, Quantity: 10},
+				{MedicationID: 5, BatchNumber: "AMX-01", ExpiryDate: nextYear, Quantity: 20},
+			},
+		}, 8)
+
+		assert.EqualError(t, err, "batch AMX-01 of Amoxicillin is listed more than once")
+	})
+
+	t.Run("UnknownMedication", func(t *testing.T) {
+		mockMedicationRepo := new(mocks.MedicationRepository)
+
+		service := NewPharmacyService(new(mocks.StockRepository), new(mocks.DispensationRepository),
+			new(mocks.PrescriptionRepository), mockMedicationRepo, 90)
+
+		mockMedicationRepo.On("FindByID", uint(77)).Return(&models.Medication{}, gorm.ErrRecordNotFound)
+
+		_, err := service.ReceiveGoods(models.CreateGoodsReceiptInput{
+			Supplier: "Emzor",
+			Lines: []models.GoodsReceiptLineInput{
+				{MedicationID: 77, BatchNumber: "X", ExpiryDate: nextYear, Quantity: 1},
+			},
+		}, 8)
+
+		assert.EqualError(t, err, "medication 77 not found")
+	})
+}
+
+func TestAllocateFEFO(t *testing.T) {
+	batches := []models.StockBatch{
+		stockBatch(1, "A", 10, 4),
+		stockBatch(2, "B", 40, 10),
+		stockBatch(3, "C", 200, 10),
+	}
+
+	t.Run("SpansBatches", func(t *testing.T) {
+		items, err := allocateFEFO(batches, 9)
+
+		assert.NoError(t, err)
+		assert.Len(t, items, 2)
+		assert.Equal(t, uint(1), items[0].StockBatchID)
+		assert.Equal(t, 4, items[0].Quantity)
+		assert.Equal(t, uint(2), items[1].StockBatchID)
+		assert.Equal(t, 5, items[1].Quantity)
+	})
+
+	t.Run("SingleBatch", func(t *testing.T) {
+		items, err := allocateFEFO(batches, 3)
+
+		assert.NoError(t, err)
+		assert.Len(t, items, 1)
+		assert.Equal(t, "A", items[0].BatchNumber)
+	})
+
+	t.Run("Insufficient", func(t *testing.T) {
+		_, err := allocateFEFO(batches, 25)
+
+		assert.EqualError(t, err, "insufficient stock: 24 available")
+	})
+}
+
+func TestDispensePrescription(t *testing.T) {
+	prescription := func(quantity, dispensed int, status string) *models.Prescription {
+		return &models.Prescription{
+			Model:             gorm.Model{ID: 12},
+			PatientID:         3,
+			MedicationID:      5,
+			Quantity:          quantity,
+			QuantityDispensed: dispensed,
+			Status:            status,
+		}
+	}
+	batches := []models.StockBatch{stockBatch(1, "A", 10, 6), stockBatch(2, "B", 40, 30)}
+
+	t.Run("FullQuantity", func(t *testing.T) {
+		mockStockRepo := new(mocks.StockRepository)
+		mockDispensationRepo := new(mocks.DispensationRepository)
+		mockPrescriptionRepo := new(mocks.PrescriptionRepository)
+
+		service := NewPharmacyService(mockStockRepo, mockDispensationRepo, mockPrescriptionRepo,
+			new(mocks.MedicationRepository), 90)
+
+		mockPrescriptionRepo.On("FindByID", uint(12)).Return(prescription(21, 0, constants.PrescriptionStatus.ACTIVE), nil)
+		mockStockRepo.On("FindAvailableBatches", uint(5), mock.AnythingOfType("string")).Return(batches, nil)
+		mockDispensationRepo.On("Dispense", mock.AnythingOfType("*models.Dispensation"),
+			mock.MatchedBy(func(p *models.Prescription) bool {
+				return p.QuantityDispensed == 21 && p.Status == constants.PrescriptionStatus.DISPENSED &&
+					*p.StatusChangedBy == 8
+			})).Return(nil)
+
+		dispensation, err := service.DispensePrescription(12, models.DispensePrescriptionInput{}, 8)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 21, dispensation.Quantity)
+		assert.Equal(t, uint(3), dispensation.PatientID)
+		assert.Len(t, dispensation.Items, 2)
+		assert.Equal(t, 6, dispensation.Items[0].Quantity)
+		assert.Equal(t, 15, dispensation.Items[1].Quantity)
+		mockDispensationRepo.AssertExpectations(t)
+	})
+
+	t.Run("Partial", func(t *testing.T) {
+		mockStockRepo := new(mocks.StockRepository)
+		mockDispensationRepo := new(mocks.DispensationRepository)
+		mockPrescriptionRepo := new(mocks.PrescriptionRepository)
+
+		service := NewPharmacyService(mockStockRepo, mockDispensationRepo, mockPrescriptionRepo,
+			new(mocks.MedicationRepository), 90)
+
+		mockPrescriptionRepo.On("FindByID", uint(12)).Return(prescription(21, 0, constants.PrescriptionStatus.ACTIVE), nil)
+		mockStockRepo.On("FindAvailableBatches", uint(5), mock.AnythingOfType("string")).Return(batches, nil)
+		mockDispensationRepo.On("Dispense", mock.AnythingOfType("*models.Dispensation"),
+			mock.MatchedBy(func(p *models.Prescription) bool {
+				return p.QuantityDispensed == 7 && p.Status == constants.PrescriptionStatus.ACTIVE
+			})).Return(nil)
+
+		dispensation, err := service.DispensePrescription(12, models.DispensePrescriptionInput{Quantity: intPtr(7)}, 8)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 7, dispensation.Quantity)
+		mockDispensationRepo.AssertExpectations(t)
+	})
+
+	t.Run("CompletesPartial", func(t *testing.T) {
+		mockStockRepo := new(mocks.StockRepository)
+		mockDispensationRepo := new(mocks.DispensationRepository)
+		mockPrescriptionRepo := new(mocks.PrescriptionRepository)
+
+		service := NewPharmacyService(mockStockRepo, mockDispensationRepo, mockPrescriptionRepo,
+			new(mocks.MedicationRepository), 90)
+
+		mockPrescriptionRepo.On("FindByID", uint(12)).Return(prescription(21, 7, constants.PrescriptionStatus.ACTIVE), nil)
+		mockStockRepo.On("FindAvailableBatches", uint(5), mock.AnythingOfType("string")).Return(batches, nil)
+		mockDispensationRepo.On("Dispense", mock.MatchedBy(func(d *models.Dispensation) bool {
+			return d.Quantity == 14
+		}), mock.MatchedBy(func(p *models.Prescription) bool {
+			return p.QuantityDispensed == 21 && p.Status == constants.PrescriptionStatus.DISPENSED
+		})).Return(nil)
+
+		_, err := service.DispensePrescription(12, models.DispensePrescriptionInput{}, 8)
+
+		assert.NoError(t, err)
+		mockDispensationRepo.AssertExpectations(t)
+	})
+
+	t.Run("MoreThanRemaining", func(t *testing.T) {
+		mockPrescriptionRepo := new(mocks.PrescriptionRepository)
+
+		service := NewPharmacyService(new(mocks.StockRepository), new(mocks.DispensationRepository),
+			mockPrescriptionRepo, new(mocks.MedicationRepository), 90)
+
+		mockPrescriptionRepo.On("FindByID", uint(12)).Return(prescription(21, 14, constants.PrescriptionStatus.ACTIVE), nil)
+
+		_, err := service.DispensePrescription(12, models.DispensePrescriptionInput{Quantity: intPtr(10)}, 8)
+
+		assert.EqualError(t, err, "only 7 left to dispense on this prescription")
+	})
+
+	t.Run("InsufficientStock", func(t *testing.T) {
+		mockStockRepo := new(mocks.StockRepository)
+		mockDispensationRepo := new(mocks.DispensationRepository)
+		mockPrescriptionRepo := new(mocks.PrescriptionRepository)
+
+		service := NewPharmacyService(mockStockRepo, mockDispensationRepo, mockPrescriptionRepo,
+			new(mocks.MedicationRepository), 90)
+
+		mockPrescriptionRepo.On("FindByID", uint(12)).Return(prescription(50, 0, constants.PrescriptionStatus.ACTIVE), nil)
+		mockStockRepo.On("FindAvailableBatches", uint(5), mock.AnythingOfType("string")).Return(batches, nil)
+
+		_, err := service.DispensePrescription(12, models.DispensePrescriptionInput{}, 8)
+
+		assert.EqualError(t, err, "insufficient stock: 36 available")
+		mockDispensationRepo.AssertNotCalled(t, "Dispense", mock.Anything, mock.Anything)
+	})
+
+	t.Run("AlreadyDispensed", func(t *testing.T) {
+		mockPrescriptionRepo := new(mocks.PrescriptionRepository)
+
+		service := NewPharmacyService(new(mocks.StockRepository), new(mocks.DispensationRepository),
+			mockPrescriptionRepo, new(mocks.MedicationRepository), 90)
+
+		mockPrescriptionRepo.On("FindByID", uint(12)).Return(prescription(21, 21, constants.PrescriptionStatus.DISPENSED), nil)
+
+		_, err := service.DispensePrescription(12, models.DispensePrescriptionInput{}, 8)
+
+		assert.EqualError(t, err, "prescription has already been fully dispensed")
+	})
+
+	t.Run("Discontinued", func(t *testing.T) {
+		mockPrescriptionRepo := new(mocks.PrescriptionRepository)
+
+		service := NewPharmacyService(new(mocks.StockRepository), new(mocks.DispensationRepository),
+			mockPrescriptionRepo, new(mocks.MedicationRepository), 90)
+
+		mockPrescriptionRepo.On("FindByID", uint(12)).Return(prescription(21, 0, constants.PrescriptionStatus.DISCONTINUED), nil)
+
+		_, err := service.DispensePrescription(12, models.DispensePrescriptionInput{}, 8)
+
+		assert.EqualError(t, err, "cannot dispense a discontinued prescription")
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockPrescriptionRepo := new(mocks.PrescriptionRepository)
+
+		service := NewPharmacyService(new(mocks.StockRepository), new(mocks.DispensationRepository),
+			mockPrescriptionRepo, new(mocks.MedicationRepository), 90)
+
+		mockPrescriptionRepo.On("FindByID", uint(99)).Return(&models.Prescription{}, gorm.ErrRecordNotFound)
+
+		_, err := service.DispensePrescription(99, models.DispensePrescriptionInput{}, 8)
+
+		assert.EqualError(t, err, "prescription not found")
+	})
+}
+
+func TestGetLowStockAlerts(t *testing.T) {
+	mockStockRepo := new(mocks.StockRepository)
+
+	service := NewPharmacyService(mockStockRepo, new(mocks.DispensationRepository),
+		new(mocks.PrescriptionRepository), new(mocks.MedicationRepository), 90)
+
+	mockStockRepo.On("FindStockLevels", mock.AnythingOfType("string")).Return([]models.StockLevel{
+		{MedicationID: 1, Name: "Amoxicillin", ReorderLevel: 50, OnHand: 20},
+		{MedicationID: 2, Name: "Paracetamol", ReorderLevel: 100, OnHand: 400},
+		{MedicationID: 3, Name: "Ceftriaxone", ReorderLevel: 0, OnHand: 0},
+		{MedicationID: 4, Name: "Metformin", ReorderLevel: 30, OnHand: 30, Expired: 60},
+	}, nil)
+
+	alerts, err := service.GetLowStockAlerts()
+
+	assert.NoError(t, err)
+	assert.Len(t, alerts, 2)
+	assert.Equal(t, "Amoxicillin", alerts[0].Name)
+	assert.Equal(t, "Metformin", alerts[1].Name)
+}
+
+func TestGetExpiryAlerts(t *testing.T) {
+	today, _ := time.Parse("2006-01-02", time.Now().Format("2006-01-02"))
+	batches := []models.StockBatch{
+		{Model: gorm.Model{ID: 1}, BatchNumber: "OLD", ExpiryDate: today.AddDate(0, 0, -3), QuantityOnHand: 5},
+		{Model: gorm.Model{ID: 2}, BatchNumber: "TODAY", ExpiryDate: today, QuantityOnHand: 5},
+		{Model: gorm.Model{ID: 3}, BatchNumber: "SOON", ExpiryDate: today.AddDate(0, 0, 20), QuantityOnHand: 5},
+	}
+
+	t.Run("DefaultWindow", func(t *testing.T) {
+		mockStockRepo := new(mocks.StockRepository)
+
+		service := NewPharmacyService(mockStockRepo, new(mocks.DispensationRepository),
+			new(mocks.PrescriptionRepository), new(mocks.MedicationRepository), 90)
+
+		mockStockRepo.On("FindExpiringBatches", today.AddDate(0, 0, 90).Format("2006-01-02")).Return(batches, nil)
+
+		alerts, err := service.GetExpiryAlerts(models.ExpiryAlertQuery{})
+
+		assert.NoError(t, err)
+		assert.Len(t, alerts, 3)
+		assert.Equal(t, -3, alerts[0].DaysToExpiry)
+		assert.True(t, alerts[0].Expired)
+		assert.Equal(t, 0, alerts[1].DaysToExpiry)
+		assert.False(t, alerts[1].Expired)
+		assert.Equal(t, 20, alerts[2].DaysToExpiry)
+	})
+
+	t.Run("CustomWindow", func(t *testing.T) {
+		mockStockRepo := new(mocks.StockRepository)
+
+		service := NewPharmacyService(mockStockRepo, new(mocks.DispensationRepository),
+			new(mocks.PrescriptionRepository), new(mocks.MedicationRepository), 90)
+
+		mockStockRepo.On("FindExpiringBatches", today.AddDate(0, 0, 30).Format("2006-01-02")).Return([]models.StockBatch{}, nil)
+
+		alerts, err := service.GetExpiryAlerts(models.ExpiryAlertQuery{WithinDays: 30})
+
+		assert.NoError(t, err)
+		assert.Empty(t, alerts)
+		mockStockRepo.AssertExpectations(t)
+	})
+}
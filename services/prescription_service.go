@@ -0,0 +1,233 @@
+package services
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+)
+
+type PrescriptionService interface {
+	CreatePrescription(input models.CreatePrescriptionInput, doctorID uint) (*models.Prescription, error)
+	GetPrescriptionByID(id uint) (*models.Prescription, error)
+	GetPrescriptionsByNoteID(noteID uint) ([]models.Prescription, error)
+	GetCurrentMedications(patientID uint) ([]models.Prescription, error)
+	UpdatePrescriptionStatus(id uint, input models.UpdatePrescriptionStatusInput, staffID uint) (*models.Prescription, error)
+	GetPrintablePrescription(id uint) (*PrescriptionDocument, error)
+}
+
+// PrescriptionDocument gathers everything needed to print a prescription.
+type PrescriptionDocument struct {
+	HospitalName   string
+	Prescription   *models.Prescription
+	Patient        *models.Patient
+	Doctor         *models.Staff
+	FrequencyLabel string
+	PrintedAt      time.Time
+}
+
+type prescriptionService struct {
+	prescriptionRepository repositories.PrescriptionRepository
+	medicationRepository   repositories.MedicationRepository
+	clinicalNoteRepository repositories.ClinicalNoteRepository
+	patientRepository      repositories.PatientRepository
+	staffRepository        repositories.StaffRepository
+	allergyScreen          allergyScreen
+	decisionSupport        DecisionSupport
+}
+
+func NewPrescriptionService(
+	prescriptionRepository repositories.PrescriptionRepository,
+	medicationRepository repositories.MedicationRepository,
+	clinicalNoteRepository repositories.ClinicalNoteRepository,
+	patientRepository repositories.PatientRepository,
+	staffRepository repositories.StaffRepository,
+	allergyRepository repositories.AllergyRepository,
+	decisionSupport DecisionSupport,
+) PrescriptionService {
+	return &prescriptionService{
+		prescriptionRepository: prescriptionRepository,
+		medicationRepository:   medicationRepository,
+		clinicalNoteRepository: clinicalNoteRepository,
+		patientRepository:      patientRepository,
+		staffRepository:        staffRepository,
+		allergyScreen: allergyScreen{
+			allergyRepository:    allergyRepository,
+			medicationRepository: medicationRepository,
+		},
+		decisionSupport: decisionSupport,
+	}
+}
+
+func (ps *prescriptionService) CreatePrescription(input models.CreatePrescriptionInput, doctorID uint) (*models.Prescription, error) {
+	note, err := ps.clinicalNoteRepository.FindByID(input.ClinicalNoteID)
+	if err != nil {
+		return nil, errors.New("associated clinical note not found")
+	}
+
+	medication, err := ps.medicationRepository.FindByID(input.MedicationID)
+	if err != nil {
+		return nil, errors.New("medication not found")
+	}
+	if !medication.IsActive {
+		return nil, errors.New("medication is no longer available for prescribing")
+	}
+
+	alerts, err := ps.allergyScreen.screenMedication(note.PatientID, medication)
+	if err != nil {
+		return nil, err
+	}
+	if len(alerts) > 0 && input.AllergyOverrideReason == "" {
+		return nil, &AllergyConflictError{Alerts: alerts}
+	}
+
+	prescription := &models.Prescription{
+		ClinicalNoteID: note.ID,
+		PatientID:      note.PatientID,
+		DoctorID:       doctorID,
+		MedicationID:   medication.ID,
+		DoseAmount:     input.DoseAmount,
+		DoseUnit:       input.DoseUnit,
+		Route:          input.Route,
+		Frequency:      input.Frequency,
+		DurationDays:   input.DurationDays,
+		Quantity:       input.Quantity,
+		Instructions:   input.Instructions,
+		Status:         constants.PrescriptionStatus.ACTIVE,
+	}
+	if len(alerts) > 0 {
+		prescription.AllergyOverrideReason = input.AllergyOverrideReason
+	}
+	prescription.Medication = medication
+
+	cdsAlerts, err := ps.decisionSupport.Evaluate(models.CdsEvent{
+		Trigger:      constants.CdsTriggers.PRESCRIPTION,
+		PatientID:    prescription.PatientID,
+		Prescription: prescription,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := checkCdsAlerts(cdsAlerts, input.AlertOverrideReason); err != nil {
+		return nil, err
+	}
+
+	writes := cdsAlertWrites(cdsAlerts, doctorID, input.AlertOverrideReason)
+	writes.Overrides, err = allergyOverrideEntries(alerts, constants.AuditEntities.PRESCRIPTION,
+		prescription.PatientID, doctorID, input.AllergyOverrideReason)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ps.prescriptionRepository.Create(prescription, writes); err != nil {
+		return nil, err
+	}
+	prescription.Alerts = cdsAlerts
+
+	return prescription, nil
+}
+
+func (ps *prescriptionService) GetPrescriptionByID(id uint) (*models.Prescription, error) {
+	return ps.prescriptionRepository.FindByID(id)
+}
+
+func (ps *prescriptionService) GetPrescriptionsByNoteID(noteID uint) ([]models.Prescription, error) {
+	if _, err := ps.clinicalNoteRepository.FindByID(noteID); err != nil {
+		return nil, errors.New("clinical note not found")
+	}
+
+	return ps.prescriptionRepository.FindByNoteID(noteID)
+}
+
+// GetCurrentMedications lists active or dispensed prescriptions whose course
+// has not yet run its full duration.
+func (ps *prescriptionService) GetCurrentMedications(patientID uint) ([]models.Prescription, error) {
+	patient, err := ps.patientRepository.FindByID(patientID)
+	if err != nil || patient == nil {
+		return nil, errors.New("patient record not found")
+	}
+
+	prescriptions, err := ps.prescriptionRepository.FindByPatientID(patientID, []string{
+		constants.PrescriptionStatus.ACTIVE,
+		constants.PrescriptionStatus.DISPENSED,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	current := make([]models.Prescription, 0, len(prescriptions))
+	for _, prescription := range prescriptions {
+		if courseRunning(prescription, now) {
+			current = append(current, prescription)
+		}
+	}
+
+	return current, nil
+}
+
+// courseRunning reports whether the prescription's course has not yet run its
+// full duration at the given time.
+func courseRunning(prescription models.Prescription, at time.Time) bool {
+	return prescription.CreatedAt.AddDate(0, 0, prescription.DurationDays).After(at)
+}
+
+func (ps *prescriptionService) UpdatePrescriptionStatus(id uint, input models.UpdatePrescriptionStatusInput, staffID uint) (*models.Prescription, error) {
+	prescription, err := ps.prescriptionRepository.FindByID(id)
+	if err != nil {
+		return nil, errors.New("prescription not found")
+	}
+
+	statuses := constants.PrescriptionStatus
+	switch {
+	case input.Status == statuses.DISPENSED:
+		return nil, errors.New("prescriptions are marked dispensed by the pharmacy")
+	case input.Status != statuses.DISCONTINUED:
+		return nil, errors.New("invalid prescription status")
+	case prescription.Status == statuses.DISCONTINUED:
+		return nil, errors.New("prescription has already been discontinued")
+	case input.Reason == "":
+		return nil, errors.New("a reason is required to discontinue a prescription")
+	}
+
+	now := time.Now()
+	prescription.Status = input.Status
+	prescription.StatusReason = input.Reason
+	prescription.StatusChangedBy = &staffID
+	prescription.StatusChangedAt = &now
+
+	if err := ps.prescriptionRepository.Update(prescription); err != nil {
+		return nil, err
+	}
+
+	return prescription, nil
+}
+
+func (ps *prescriptionService) GetPrintablePrescription(id uint) (*PrescriptionDocument, error) {
+	prescription, err := ps.prescriptionRepository.FindByID(id)
+	if err != nil {
+		return nil, errors.New("prescription not found")
+	}
+
+	patient, err := ps.patientRepository.FindByID(prescription.PatientID)
+	if err != nil || patient == nil {
+		return nil, errors.New("patient record not found")
+	}
+
+	doctor, err := ps.staffRepository.FindByID(prescription.DoctorID)
+	if err != nil || doctor == nil {
+		return nil, errors.New("prescribing doctor not found")
+	}
+
+	return &PrescriptionDocument{
+		HospitalName:   os.Getenv("HOSPITAL_NAME"),
+		Prescription:   prescription,
+		Patient:        patient,
+		Doctor:         doctor,
+		FrequencyLabel: constants.DoseFrequencyLabels[prescription.Frequency],
+		PrintedAt:      time.Now(),
+	}, nil
+}
@@ -0,0 +1,394 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type prescriptionServiceMocks struct {
+	prescriptionRepo *mocks.PrescriptionRepository
+	medicationRepo   *mocks.MedicationRepository
+	noteRepo         *mocks.ClinicalNoteRepository
+	patientRepo      *mocks.PatientRepository
+	staffRepo        *mocks.StaffRepository
+	allergyRepo      *mocks.AllergyRepository
+	decisionSupport  *mocks.DecisionSupport
+}
+
+func newPrescriptionServiceWithMocks() (PrescriptionService, prescriptionServiceMocks) {
+	m := prescriptionServiceMocks{
+		prescriptionRepo: new(mocks.PrescriptionRepository),
+		medicationRepo:   new(mocks.MedicationRepository),
+		noteRepo:         new(mocks.ClinicalNoteRepository),
+		patientRepo:      new(mocks.PatientRepository),
+		staffRepo:        new(mocks.StaffRepository),
+		allergyRepo:      new(mocks.AllergyRepository),
+		decisionSupport:  noCdsAlerts(),
+	}
+	service := NewPrescriptionService(m.prescriptionRepo, m.medicationRepo, m.noteRepo, m.patientRepo,
+		m.staffRepo, m.allergyRepo, m.decisionSupport)
+	return service, m
+}
+
+func TestCreatePrescription(t *testing.T) {
+	input := models.CreatePrescriptionInput{
+		ClinicalNoteID: 1,
+		MedicationID:   5,
+		DoseAmount:     500,
+		DoseUnit:       "mg",
+		Route:          "oral",
+		Frequency:      constants.DoseFrequency.TDS,
+		DurationDays:   5,
+		Quantity:       15,
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		service, m := newPrescriptionServiceWithMocks()
+
+		note := &models.ClinicalNote{Model: gorm.Model{ID: 1}, PatientID: 7}
+		medication := &models.Medication{Model: gorm.Model{ID: 5}, Name: "Amoxil", IsActive: true}
+
+		m.noteRepo.On("FindByID", uint(1)).Return(note, nil)
+		m.medicationRepo.On("FindByID", uint(5)).Return(medication, nil)
+		m.allergyRepo.On("FindByPatientID", uint(7)).Return([]models.PatientAllergy{}, nil)
+		m.prescriptionRepo.On("Create", mock.AnythingOfType("*models.Prescription"), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			prescription := args.Get(0).(*models.Prescription)
+			assert.Equal(t, uint(7), prescription.PatientID)
+			assert.Equal(t, uint(2), prescription.DoctorID)
+			assert.Equal(t, constants.PrescriptionStatus.ACTIVE, prescription.Status)
+			assert.Equal(t, 15, prescription.Quantity)
+		})
+
+		result, err := service.CreatePrescription(input, 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, medication, result.Medication)
+		m.prescriptionRepo.AssertExpectations(t)
+	})
+
+	t.Run("AllergyConflict", func(t *testing.T) {
+		service, m := newPrescriptionServiceWithMocks()
+
+		medication := &models.Medication{Model: gorm.Model{ID: 5}, Name: "Amoxil", GenericName: "Amoxicillin",
+			DrugClass: "Penicillins", IsActive: true}
+		allergies := []models.PatientAllergy{
+			{Model: gorm.Model{ID: 3}, Substance: "Penicillin", SubstanceClass: "Penicillins",
+				VerificationStatus: constants.AllergyVerificationStatus.CONFIRMED},
+			{Model: gorm.Model{ID: 4}, Substance: "Amoxicillin",
+				VerificationStatus: constants.AllergyVerificationStatus.REFUTED},
+		}
+
+		m.noteRepo.On("FindByID", uint(1)).Return(&models.ClinicalNote{Model: gorm.Model{ID: 1}, PatientID: 7}, nil)
+		m.medicationRepo.On("FindByID", uint(5)).Return(medication, nil)
+		m.allergyRepo.On("FindByPatientID", uint(7)).Return(allergies, nil)
+
+		result, err := service.CreatePrescription(input, 2)
+
+		assert.Nil(t, result)
+		var conflict *AllergyConflictError
+		assert.ErrorAs(t, err, &conflict)
+		assert.Len(t, conflict.Alerts, 1)
+		assert.Equal(t, uint(3), conflict.Alerts[0].AllergyID)
+		assert.Equal(t, "penicillins", conflict.Alerts[0].MatchedOn)
+		m.prescriptionRepo.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("AllergyMatchesWholeWords", func(t *testing.T) {
+		service, m := newPrescriptionServiceWithMocks()
+
+		medication := &models.Medication{Model: gorm.Model{ID: 5}, Name: "Ferrous Sulfate", GenericName: "Ferrous Sulfate",
+			DrugClass: "Iron Preparations", IsActive: true}
+		allergies := []models.PatientAllergy{{Model: gorm.Model{ID: 3}, Substance: "Sulfa", SubstanceClass: "Sulfonamides",
+			VerificationStatus: constants.AllergyVerificationStatus.CONFIRMED}}
+
+		m.noteRepo.On("FindByID", uint(1)).Return(&models.ClinicalNote{Model: gorm.Model{ID: 1}, PatientID: 7}, nil)
+		m.medicationRepo.On("FindByID", uint(5)).Return(medication, nil)
+		m.allergyRepo.On("FindByPatientID", uint(7)).Return(allergies, nil)
+		m.prescriptionRepo.On("Create", mock.AnythingOfType("*models.Prescription"),
+			mock.MatchedBy(func(writes models.ClinicalRecordWrites) bool {
+				return len(writes.Overrides) == 0
+			})).Return(nil)
+
+		result, err := service.CreatePrescription(input, 2)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		m.prescriptionRepo.AssertExpectations(t)
+	})
+
+	t.Run("AllergyClassMatchesPlural", func(t *testing.T) {
+		service, m := newPrescriptionServiceWithMocks()
+
+		medication := &models.Medication{Model: gorm.Model{ID: 5}, Name: "Augmentin", GenericName: "Co-amoxiclav",
+			DrugClass: "Penicillins", IsActive: true}
+		allergies := []models.PatientAllergy{{Model: gorm.Model{ID: 3}, Substance: "Ampicillin", SubstanceClass: "penicillin"}}
+
+		m.noteRepo.On("FindByID", uint(1)).Return(&models.ClinicalNote{Model: gorm.Model{ID: 1}, PatientID: 7}, nil)
+		m.medicationRepo.On("FindByID", uint(5)).Return(medication, nil)
+		m.allergyRepo.On("FindByPatientID", uint(7)).Return(allergies, nil)
+
+		_, err := service.CreatePrescription(input, 2)
+
+		var conflict *AllergyConflictError
+		assert.ErrorAs(t, err, &conflict)
+		assert.Len(t, conflict.Alerts, 1)
+		assert.Equal(t, "penicillins", conflict.Alerts[0].MatchedOn)
+		m.prescriptionRepo.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("AllergyOverrideIsAudited", func(t *testing.T) {
+		service, m := newPrescriptionServiceWithMocks()
+
+		overrideInput := input
+		overrideInput.AllergyOverrideReason = "Tolerated amoxicillin previously; rash only"
+
+		medication := &models.Medication{Model: gorm.Model{ID: 5}, Name: "Amoxil", DrugClass: "Penicillins", IsActive: true}
+		allergies := []models.PatientAllergy{{Model: gorm.Model{ID: 3}, Substance: "Penicillin", SubstanceClass: "Penicillins"}}
+
+		m.noteRepo.On("FindByID", uint(1)).Return(&models.ClinicalNote{Model: gorm.Model{ID: 1}, PatientID: 7}, nil)
+		m.medicationRepo.On("FindByID", uint(5)).Return(medication, nil)
+		m.allergyRepo.On("FindByPatientID", uint(7)).Return(allergies, nil)
+		m.prescriptionRepo.On("Create", mock.AnythingOfType("*models.Prescription"),
+			mock.AnythingOfType("models.ClinicalRecordWrites")).Return(nil).Run(func(args mock.Arguments) {
+			prescription := args.Get(0).(*models.Prescription)
+			assert.Equal(t, overrideInput.AllergyOverrideReason, prescription.AllergyOverrideReason)
+
+			writes := args.Get(1).(models.ClinicalRecordWrites)
+			assert.Len(t, writes.Overrides, 1)
+			entry := writes.Overrides[0]
+			assert.Equal(t, constants.AuditActions.ALLERGY_OVERRIDE, entry.Action)
+			assert.Equal(t, constants.AuditEntities.PRESCRIPTION, entry.EntityType)
+			assert.Equal(t, uint(2), entry.StaffID)
+			assert.Contains(t, entry.Details, `"allergyId":3`)
+		})
+
+		result, err := service.CreatePrescription(overrideInput, 2)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		m.prescriptionRepo.AssertExpectations(t)
+	})
+
+	t.Run("BlockingClinicalAlert", func(t *testing.T) {
+		service, m := newPrescriptionServiceWithMocks()
+
+		medication := &models.Medication{Model: gorm.Model{ID: 5}, Name: "Paracetamol", IsActive: true}
+		alert := models.CdsAlert{RuleID: 2, PatientID: 7, Severity: constants.CdsSeverity.BLOCKING,
+			Message: "Paracetamol dose exceeds 15 mg/kg"}
+
+		m.noteRepo.On("FindByID", uint(1)).Return(&models.ClinicalNote{Model: gorm.Model{ID: 1}, PatientID: 7}, nil)
+		m.medicationRepo.On("FindByID", uint(5)).Return(medication, nil)
+		m.allergyRepo.On("FindByPatientID", uint(7)).Return([]models.PatientAllergy{}, nil)
+		raiseCdsAlerts(m.decisionSupport, alert)
+
+		result, err := service.CreatePrescription(input, 2)
+
+		assert.Nil(t, result)
+		var blocked *CdsAlertError
+		assert.ErrorAs(t, err, &blocked)
+		assert.Equal(t, []models.CdsAlert{alert}, blocked.Alerts)
+		m.prescriptionRepo.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("ClinicalAlertOverride", func(t *testing.T) {
+		service, m := newPrescriptionServiceWithMocks()
+
+		overrideInput := input
+		overrideInput.AlertOverrideReason = "Loading dose agreed with the consultant"
+
+		medication := &models.Medication{Model: gorm.Model{ID: 5}, Name: "Paracetamol", IsActive: true}
+		alert := models.CdsAlert{RuleID: 2, PatientID: 7, Severity: constants.CdsSeverity.BLOCKING}
+
+		m.noteRepo.On("FindByID", uint(1)).Return(&models.ClinicalNote{Model: gorm.Model{ID: 1}, PatientID: 7}, nil)
+		m.medicationRepo.On("FindByID", uint(5)).Return(medication, nil)
+		m.allergyRepo.On("FindByPatientID", uint(7)).Return([]models.PatientAllergy{}, nil)
+		raiseCdsAlerts(m.decisionSupport, alert)
+		m.prescriptionRepo.On("Create", mock.AnythingOfType("*models.Prescription"),
+			mock.AnythingOfType("models.ClinicalRecordWrites")).Return(nil).Run(func(args mock.Arguments) {
+			writes := args.Get(1).(models.ClinicalRecordWrites)
+			assert.Len(t, writes.NewAlerts, 1)
+			assert.Equal(t, uint(2), writes.NewAlerts[0].TriggeredBy)
+			assert.Equal(t, overrideInput.AlertOverrideReason, writes.NewAlerts[0].AcknowledgementReason)
+		})
+
+		result, err := service.CreatePrescription(overrideInput, 2)
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Alerts, 1)
+		m.prescriptionRepo.AssertExpectations(t)
+	})
+
+	t.Run("NoteNotFound", func(t *testing.T) {
+		service, m := newPrescriptionServiceWithMocks()
+
+		m.noteRepo.On("FindByID", uint(1)).Return(&models.ClinicalNote{}, errors.New("not found"))
+
+		result, err := service.CreatePrescription(input, 2)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Equal(t, "associated clinical note not found", err.Error())
+		m.prescriptionRepo.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("InactiveMedication", func(t *testing.T) {
+		service, m := newPrescriptionServiceWithMocks()
+
+		m.noteRepo.On("FindByID", uint(1)).Return(&models.ClinicalNote{Model: gorm.Model{ID: 1}}, nil)
+		m.medicationRepo.On("FindByID", uint(5)).Return(&models.Medication{Model: gorm.Model{ID: 5}}, nil)
+
+		result, err := service.CreatePrescription(input, 2)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Equal(t, "medication is no longer available for prescribing", err.Error())
+		m.prescriptionRepo.AssertNotCalled(t, "Create")
+	})
+}
+
+func TestGetCurrentMedications(t *testing.T) {
+	t.Run("ExcludesCompletedCourses", func(t *testing.T) {
+		service, m := newPrescriptionServiceWithMocks()
+
+		ongoing := models.Prescription{Model: gorm.Model{ID: 1, CreatedAt: time.Now().AddDate(0, 0, -2)}, DurationDays: 5}
+		finished := models.Prescription{Model: gorm.Model{ID: 2, CreatedAt: time.Now().AddDate(0, 0, -10)}, DurationDays: 5}
+
+		m.patientRepo.On("FindByID", uint(7)).Return(&models.Patient{Model: gorm.Model{ID: 7}}, nil)
+		m.prescriptionRepo.On("FindByPatientID", uint(7), []string{
+			constants.PrescriptionStatus.ACTIVE,
+			constants.PrescriptionStatus.DISPENSED,
+		}).Return([]models.Prescription{ongoing, finished}, nil)
+
+		result, err := service.GetCurrentMedications(7)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []models.Prescription{ongoing}, result)
+	})
+
+	t.Run("PatientNotFound", func(t *testing.T) {
+		service, m := newPrescriptionServiceWithMocks()
+
+		m.patientRepo.On("FindByID", uint(7)).Return(&models.Patient{}, errors.New("not found"))
+
+		result, err := service.GetCurrentMedications(7)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Equal(t, "patient record not found", err.Error())
+	})
+}
+
+func TestUpdatePrescriptionStatus(t *testing.T) {
+	t.Run("Discontinue", func(t *testing.T) {
+		service, m := newPrescriptionServiceWithMocks()
+
+		existing := &models.Prescription{Model: gorm.Model{ID: 1}, Status: constants.PrescriptionStatus.ACTIVE}
+		m.prescriptionRepo.On("FindByID", uint(1)).Return(existing, nil)
+		m.prescriptionRepo.On("Update", mock.AnythingOfType("*models.Prescription")).Return(nil)
+
+		result, err := service.UpdatePrescriptionStatus(1, models.UpdatePrescriptionStatusInput{
+			Status: constants.PrescriptionStatus.DISCONTINUED,
+			Reason: "Rash after second dose",
+		}, 3)
+
+		assert.NoError(t, err)
+		assert.Equal(t, constants.PrescriptionStatus.DISCONTINUED, result.Status)
+		assert.Equal(t, "Rash after second dose", result.StatusReason)
+		assert.Equal(t, uint(3), *result.StatusChangedBy)
+		assert.NotNil(t, result.StatusChangedAt)
+	})
+
+	t.Run("RejectsDispensed", func(t *testing.T) {
+		service, m := newPrescriptionServiceWithMocks()
+
+		existing := &models.Prescription{Model: gorm.Model{ID: 1}, Status: constants.PrescriptionStatus.ACTIVE}
+		m.prescriptionRepo.On("FindByID", uint(1)).Return(existing, nil)
+
+		result, err := service.UpdatePrescriptionStatus(1, models.UpdatePrescriptionStatusInput{
+			Status: constants.PrescriptionStatus.DISPENSED,
+		}, 3)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Equal(t, "prescriptions are marked dispensed by the pharmacy", err.Error())
+		assert.Equal(t, constants.PrescriptionStatus.ACTIVE, existing.Status)
+		m.prescriptionRepo.AssertNotCalled(t, "Update")
+	})
+
+	t.Run("DiscontinueRequiresReason", func(t *testing.T) {
+		service, m := newPrescriptionServiceWithMocks()
+
+		existing := &models.Prescription{Model: gorm.Model{ID: 1}, Status: constants.PrescriptionStatus.ACTIVE}
+		m.prescriptionRepo.On("FindByID", uint(1)).Return(existing, nil)
+
+		result, err := service.UpdatePrescriptionStatus(1, models.UpdatePrescriptionStatusInput{
+			Status: constants.PrescriptionStatus.DISCONTINUED,
+		}, 3)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Equal(t, "a reason is required to discontinue a prescription", err.Error())
+		m.prescriptionRepo.AssertNotCalled(t, "Update")
+	})
+
+	t.Run("AlreadyDiscontinued", func(t *testing.T) {
+		service, m := newPrescriptionServiceWithMocks()
+
+		existing := &models.Prescription{Model: gorm.Model{ID: 1}, Status: constants.PrescriptionStatus.DISCONTINUED}
+		m.prescriptionRepo.On("FindByID", uint(1)).Return(existing, nil)
+
+		result, err := service.UpdatePrescriptionStatus(1, models.UpdatePrescriptionStatusInput{
+			Status: constants.PrescriptionStatus.DISCONTINUED,
+			Reason: "Duplicate order",
+		}, 3)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Equal(t, "prescription has already been discontinued", err.Error())
+	})
+}
+
+func TestGetPrintablePrescription(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		service, m := newPrescriptionServiceWithMocks()
+
+		prescription := &models.Prescription{
+			Model:      gorm.Model{ID: 1},
+			PatientID:  7,
+			DoctorID:   2,
+			Frequency:  constants.DoseFrequency.BD,
+			Medication: &models.Medication{Name: "Amoxil"},
+		}
+		m.prescriptionRepo.On("FindByID", uint(1)).Return(prescription, nil)
+		m.patientRepo.On("FindByID", uint(7)).Return(&models.Patient{Model: gorm.Model{ID: 7}}, nil)
+		m.staffRepo.On("FindByID", uint(2)).Return(&models.Staff{Model: gorm.Model{ID: 2}}, nil)
+
+		result, err := service.GetPrintablePrescription(1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "twice daily", result.FrequencyLabel)
+		assert.Equal(t, prescription, result.Prescription)
+	})
+
+	t.Run("DoctorNotFound", func(t *testing.T) {
+		service, m := newPrescriptionServiceWithMocks()
+
+		m.prescriptionRepo.On("FindByID", uint(1)).Return(&models.Prescription{PatientID: 7, DoctorID: 2}, nil)
+		m.patientRepo.On("FindByID", uint(7)).Return(&models.Patient{Model: gorm.Model{ID: 7}}, nil)
+		m.staffRepo.On("FindByID", uint(2)).Return((*models.Staff)(nil), nil)
+
+		result, err := service.GetPrintablePrescription(1)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Equal(t, "prescribing doctor not found", err.Error())
+	})
+}
@@ -0,0 +1,285 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+)
+
+type ReferralService interface {
+	CreateReferral(input models.CreateReferralInput, doctorID uint) (*models.Referral, error)
+	GetAllReferrals(filters map[string]interface{}) ([]models.Referral, error)
+	GetReferralByID(id uint) (*models.Referral, error)
+	GetWorklist(department string, status string, staffID uint) ([]models.Referral, error)
+	AcceptReferral(id uint, input models.AcceptReferralInput, staffID uint) (*models.Referral, error)
+	ScheduleReferral(id uint, input models.ScheduleReferralInput, staffID uint) (*models.Referral, error)
+	CompleteReferral(id uint) (*models.Referral, error)
+	DeclineReferral(id uint, input models.DeclineReferralInput, staffID uint) (*models.Referral, error)
+}
+
+type referralService struct {
+	referralRepository     repositories.ReferralRepository
+	appointmentRepository  repositories.AppointmentRepository
+	clinicalNoteRepository repositories.ClinicalNoteRepository
+	staffRepository        repositories.StaffRepository
+}
+
+func NewReferralService(
+	referralRepository repositories.ReferralRepository,
+	appointmentRepository repositories.AppointmentRepository,
+	clinicalNoteRepository repositories.ClinicalNoteRepository,
+	staffRepository repositories.StaffRepository,
+) ReferralService {
+	return &referralService{
+		referralRepository:     referralRepository,
+		appointmentRepository:  appointmentRepository,
+		clinicalNoteRepository: clinicalNoteRepository,
+		staffRepository:        staffRepository,
+	}
+}
+
+func (rs *referralService) CreateReferral(input models.CreateReferralInput, doctorID uint) (*models.Referral, error) {
+	referral := &models.Referral{
+		ReferringDoctorID: doctorID,
+		TargetDepartment:  input.TargetDepartment,
+		Urgency:           input.Urgency,
+		Reason:            input.Reason,
+		Status:            constants.ReferralStatus.PENDING,
+	}
+	if referral.Urgency == "" {
+		referral.Urgency = constants.ReferralUrgency.ROUTINE
+	}
+
+	if input.SourceNoteID != nil {
+		note, err := rs.clinicalNoteRepository.FindByID(*input.SourceNoteID)
+		if err != nil {
+			return nil, errors.New("source clinical note not found")
+		}
+		referral.SourceNoteID = &note.ID
+		referral.SourceAppointmentID = note.AppointmentID
+		referral.PatientID = note.PatientID
+	} else {
+		appointment, err := rs.appointmentRepository.FindByID(*input.SourceAppointmentID)
+		if err != nil {
+			return nil, errors.New("source appointment not found")
+		}
+		referral.SourceAppointmentID = &appointment.ID
+		referral.PatientID = appointment.PatientID
+	}
+
+	if input.TargetDoctorID != nil {
+		if _, err := rs.findActiveDoctor(*input.TargetDoctorID); err != nil {
+			return nil, err
+		}
+		referral.TargetDoctorID = input.TargetDoctorID
+	}
+
+	if err := rs.referralRepository.Create(referral); err != nil {
+		return nil, err
+	}
+
+	return referral, nil
+}
+
+func (rs *referralService) GetAllReferrals(filters map[string]interface{}) ([]models.Referral, error) {
+	return rs.referralRepository.FindAll(filters)
+}
+
+func (rs *referralService) GetReferralByID(id uint) (*models.Referral, error) {
+	return rs.referralRepository.FindByID(id)
+}
+
+// GetWorklist lists incoming referrals for a department, defaulting to the
+// requesting staff member's own department. Without a status it returns the
+// referrals that still need action: pending and accepted.
+func (rs *referralService) GetWorklist(department string, status string, staffID uint) ([]models.Referral, error) {
+	if department == "" {
+		staff, err := rs.staffRepository.FindByID(staffID)
+		if err != nil || staff.Department == nil {
+			return nil, errors.New("department is required")
+		}
+		department = strings.ToLower(*staff.Department)
+	}
+
+	statuses := []string{constants.ReferralStatus.PENDING, constants.ReferralStatus.ACCEPTED}
+	if status != "" {
+		statuses = []string{status}
+	}
+
+	return rs.referralRepository.FindWorklist(department, statuses)
+}
+
+// AcceptReferral is done by the targeted doctor or any doctor in the target
+// department, and books the downstream appointment for the patient.
+func (rs *referralService) AcceptReferral(id uint, input models.AcceptReferralInput, staffID uint) (*models.Referral, error) {
+	referral, err := rs.findReferralInStatus(id, constants.ReferralStatus.PENDING)
+	if err != nil {
+		return nil, err
+	}
+
+	staff, err := rs.staffRepository.FindByID(staffID)
+	if err != nil {
+		return nil, errors.New("staff not found")
+	}
+	if !canRespondToReferral(referral, staff) {
+		return nil, errors.New("only the referred doctor or a doctor in the target department can respond to this referral")
+	}
+
+	doctorID := referral.TargetDoctorID
+	if input.DoctorID != nil {
+		if _, err := rs.findActiveDoctor(*input.DoctorID); err != nil {
+			return nil, err
+		}
+		doctorID = input.DoctorID
+	}
+	if doctorID == nil {
+		doctorID = &staff.ID
+	}
+
+	appointment := &models.Appointment{
+		PatientID:      referral.PatientID,
+		ReceptionistID: staffID,
+		DoctorID:       doctorID,
+		Department:     referral.TargetDepartment,
+		ScheduledAt:    time.Now(),
+		Duration:       input.Duration,
+		Reason:         referralAppointmentReason(referral.Reason),
+		Status:         constants.AppointmentStatus.SCHEDULED,
+		UpdatedBy:      staffID,
+	}
+
+	now := time.Now()
+	referral.Status = constants.ReferralStatus.ACCEPTED
+	referral.RespondedBy = &staffID
+	referral.RespondedAt = &now
+
+	if err := rs.referralRepository.Accept(referral, appointment); err != nil {
+		return nil, err
+	}
+
+	referral.Appointment = appointment
+	return referral, nil
+}
+
+func (rs *referralService) ScheduleReferral(id uint, input models.ScheduleReferralInput, staffID uint) (*models.Referral, error) {
+	referral, err := rs.findReferralInStatus(id, constants.ReferralStatus.ACCEPTED, constants.ReferralStatus.SCHEDULED)
+	if err != nil {
+		return nil, err
+	}
+	if referral.AppointmentID == nil {
+		return nil, errors.New("referral has no linked appointment")
+	}
+
+	appointment, err := rs.appointmentRepository.FindByID(*referral.AppointmentID)
+	if err != nil {
+		return nil, errors.New("linked appointment not found")
+	}
+
+	if input.DoctorID != nil {
+		if _, err := rs.findActiveDoctor(*input.DoctorID); err != nil {
+			return nil, err
+		}
+		appointment.DoctorID = input.DoctorID
+	}
+	appointment.ScheduledAt = input.ScheduledAt
+	appointment.UpdatedBy = staffID
+
+	if err := rs.appointmentRepository.Update(appointment); err != nil {
+		return nil, err
+	}
+
+	referral.Status = constants.ReferralStatus.SCHEDULED
+	if err := rs.referralRepository.Update(referral); err != nil {
+		return nil, err
+	}
+
+	referral.Appointment = appointment
+	return referral, nil
+}
+
+func (rs *referralService) CompleteReferral(id uint) (*models.Referral, error) {
+	referral, err := rs.findReferralInStatus(id, constants.ReferralStatus.SCHEDULED)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	referral.Status = constants.ReferralStatus.COMPLETED
+	referral.CompletedAt = &now
+
+	if err := rs.referralRepository.Update(referral); err != nil {
+		return nil, err
+	}
+
+	return referral, nil
+}
+
+func (rs *referralService) DeclineReferral(id uint, input models.DeclineReferralInput, staffID uint) (*models.Referral, error) {
+	referral, err := rs.findReferralInStatus(id, constants.ReferralStatus.PENDING)
+	if err != nil {
+		return nil, err
+	}
+
+	staff, err := rs.staffRepository.FindByID(staffID)
+	if err != nil {
+		return nil, errors.New("staff not found")
+	}
+	if !canRespondToReferral(referral, staff) {
+		return nil, errors.New("only the referred doctor or a doctor in the target department can respond to this referral")
+	}
+
+	now := time.Now()
+	referral.Status = constants.ReferralStatus.DECLINED
+	referral.DeclineReason = input.Reason
+	referral.RespondedBy = &staffID
+	referral.RespondedAt = &now
+
+	if err := rs.referralRepository.Update(referral); err != nil {
+		return nil, err
+	}
+
+	return referral, nil
+}
+
+func (rs *referralService) findReferralInStatus(id uint, statuses ...string) (*models.Referral, error) {
+	referral, err := rs.referralRepository.FindByID(id)
+	if err != nil {
+		return nil, errors.New("referral not found")
+	}
+
+	for _, status := range statuses {
+		if referral.Status == status {
+			return referral, nil
+		}
+	}
+
+	return nil, fmt.Errorf("referral is %s and cannot be updated", referral.Status)
+}
+
+func (rs *referralService) findActiveDoctor(id uint) (*models.Staff, error) {
+	doctor, err := rs.staffRepository.FindByID(id)
+	if err != nil || doctor.Role != constants.Roles.DOCTOR || !doctor.IsActive {
+		return nil, errors.New("target doctor not found")
+	}
+	return doctor, nil
+}
+
+func canRespondToReferral(referral *models.Referral, staff *models.Staff) bool {
+	if referral.TargetDoctorID != nil && *referral.TargetDoctorID == staff.ID {
+		return true
+	}
+	return staff.Department != nil && strings.EqualFold(*staff.Department, referral.TargetDepartment)
+}
+
+func referralAppointmentReason(reason string) string {
+	runes := []rune("Referral: " + reason)
+	if len(runes) > 500 {
+		runes = runes[:500]
+	}
+	return string(runes)
+}
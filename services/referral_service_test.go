@@ -0,0 +1,201 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestCreateReferral(t *testing.T) {
+	t.Run("FromAppointment", func(t *testing.T) {
+		mockReferralRepo := new(mocks.ReferralRepository)
+		mockAppointmentRepo := new(mocks.AppointmentRepository)
+
+		service := NewReferralService(mockReferralRepo, mockAppointmentRepo,
+			new(mocks.ClinicalNoteRepository), new(mocks.StaffRepository))
+
+		appointmentID := uint(3)
+		mockAppointmentRepo.On("FindByID", appointmentID).Return(&models.Appointment{Model: gorm.Model{ID: 3}, PatientID: 2}, nil)
+		mockReferralRepo.On("Create", mock.AnythingOfType("*models.Referral")).Return(nil)
+
+		referral, err := service.CreateReferral(models.CreateReferralInput{
+			SourceAppointmentID: &appointmentID,
+			TargetDepartment:    "cardiology",
+			Reason:              "Exertional chest pain",
+		}, 5)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(2), referral.PatientID)
+		assert.Equal(t, constants.ReferralStatus.PENDING, referral.Status)
+		assert.Equal(t, constants.ReferralUrgency.ROUTINE, referral.Urgency)
+	})
+
+	t.Run("TargetDoctorMustBeDoctor", func(t *testing.T) {
+		mockReferralRepo := new(mocks.ReferralRepository)
+		mockAppointmentRepo := new(mocks.AppointmentRepository)
+		mockStaffRepo := new(mocks.StaffRepository)
+
+		service := NewReferralService(mockReferralRepo, mockAppointmentRepo,
+			new(mocks.ClinicalNoteRepository), mockStaffRepo)
+
+		appointmentID := uint(3)
+		targetID := uint(9)
+		mockAppointmentRepo.On("FindByID", appointmentID).Return(&models.Appointment{Model: gorm.Model{ID: 3}, PatientID: 2}, nil)
+		mockStaffRepo.On("FindByID", targetID).Return(&models.Staff{Model: gorm.Model{ID: 9},
+			Role: constants.Roles.RECEPTIONIST, IsActive: true}, nil)
+
+		referral, err := service.CreateReferral(models.CreateReferralInput{
+			SourceAppointmentID: &appointmentID,
+			TargetDepartment:    "cardiology",
+			TargetDoctorID:      &targetID,
+			Reason:              "Murmur",
+		}, 5)
+
+		assert.Nil(t, referral)
+		assert.EqualError(t, err, "target doctor not found")
+		mockReferralRepo.AssertNotCalled(t, "Create")
+	})
+}
+
+func TestAcceptReferral(t *testing.T) {
+	t.Run("CreatesLinkedAppointment", func(t *testing.T) {
+		mockReferralRepo := new(mocks.ReferralRepository)
+		mockStaffRepo := new(mocks.StaffRepository)
+
+		service := NewReferralService(mockReferralRepo, new(mocks.AppointmentRepository),
+			new(mocks.ClinicalNoteRepository), mockStaffRepo)
+
+		mockReferralRepo.On("FindByID", uint(1)).Return(&models.Referral{
+			Model:            gorm.Model{ID: 1},
+			PatientID:        2,
+			TargetDepartment: "cardiology",
+			Reason:           "Palpitations",
+			Status:           constants.ReferralStatus.PENDING,
+		}, nil)
+		mockStaffRepo.On("FindByID", uint(7)).Return(&models.Staff{Model: gorm.Model{ID: 7},
+			Role: constants.Roles.DOCTOR, IsActive: true, Department: stringPtr("Cardiology")}, nil)
+		mockReferralRepo.On("Accept", mock.AnythingOfType("*models.Referral"), mock.AnythingOfType("*models.Appointment")).
+			Return(nil).Run(func(args mock.Arguments) {
+			appointment := args.Get(1).(*models.Appointment)
+			assert.Equal(t, uint(2), appointment.PatientID)
+			assert.Equal(t, "cardiology", appointment.Department)
+			assert.Equal(t, uint(7), *appointment.DoctorID)
+			assert.Equal(t, "Referral: Palpitations", appointment.Reason)
+		})
+
+		referral, err := service.AcceptReferral(1, models.AcceptReferralInput{}, 7)
+
+		assert.NoError(t, err)
+		assert.Equal(t, constants.ReferralStatus.ACCEPTED, referral.Status)
+		assert.Equal(t, uint(7), *referral.RespondedBy)
+		assert.NotNil(t, referral.Appointment)
+	})
+
+	t.Run("DoctorOutsideTargetDepartment", func(t *testing.T) {
+		mockReferralRepo := new(mocks.ReferralRepository)
+		mockStaffRepo := new(mocks.StaffRepository)
+
+		service := NewReferralService(mockReferralRepo, new(mocks.AppointmentRepository),
+			new(mocks.ClinicalNoteRepository), mockStaffRepo)
+
+		mockReferralRepo.On("FindByID", uint(1)).Return(&models.Referral{
+			Model:            gorm.Model{ID: 1},
+			TargetDepartment: "cardiology",
+			Status:           constants.ReferralStatus.PENDING,
+		}, nil)
+		mockStaffRepo.On("FindByID", uint(7)).Return(&models.Staff{Model: gorm.Model{ID: 7},
+			Role: constants.Roles.DOCTOR, IsActive: true, Department: stringPtr("pediatrics")}, nil)
+
+		referral, err := service.AcceptReferral(1, models.AcceptReferralInput{}, 7)
+
+		assert.Nil(t, referral)
+		assert.Error(t, err)
+		mockReferralRepo.AssertNotCalled(t, "Accept")
+	})
+
+	t.Run("AlreadyDeclined", func(t *testing.T) {
+		mockReferralRepo := new(mocks.ReferralRepository)
+
+		service := NewReferralService(mockReferralRepo, new(mocks.AppointmentRepository),
+			new(mocks.ClinicalNoteRepository), new(mocks.StaffRepository))
+
+		mockReferralRepo.On("FindByID", uint(1)).Return(&models.Referral{
+			Model:  gorm.Model{ID: 1},
+			Status: constants.ReferralStatus.DECLINED,
+		}, nil)
+
+		referral, err := service.AcceptReferral(1, models.AcceptReferralInput{}, 7)
+
+		assert.Nil(t, referral)
+		assert.EqualError(t, err, "referral is declined and cannot be updated")
+	})
+}
+
+func TestScheduleReferral(t *testing.T) {
+	t.Run("UpdatesAppointmentTime", func(t *testing.T) {
+		mockReferralRepo := new(mocks.ReferralRepository)
+		mockAppointmentRepo := new(mocks.AppointmentRepository)
+
+		service := NewReferralService(mockReferralRepo, mockAppointmentRepo,
+			new(mocks.ClinicalNoteRepository), new(mocks.StaffRepository))
+
+		appointmentID := uint(12)
+		scheduledAt := time.Date(2026, 11, 2, 10, 30, 0, 0, time.UTC)
+
+		mockReferralRepo.On("FindByID", uint(1)).Return(&models.Referral{
+			Model:         gorm.Model{ID: 1},
+			Status:        constants.ReferralStatus.ACCEPTED,
+			AppointmentID: &appointmentID,
+		}, nil)
+		mockAppointmentRepo.On("FindByID", appointmentID).Return(&models.Appointment{Model: gorm.Model{ID: 12}}, nil)
+		mockAppointmentRepo.On("Update", mock.AnythingOfType("*models.Appointment")).Return(nil)
+		mockReferralRepo.On("Update", mock.AnythingOfType("*models.Referral")).Return(nil)
+
+		referral, err := service.ScheduleReferral(1, models.ScheduleReferralInput{ScheduledAt: scheduledAt}, 4)
+
+		assert.NoError(t, err)
+		assert.Equal(t, constants.ReferralStatus.SCHEDULED, referral.Status)
+		assert.Equal(t, scheduledAt, referral.Appointment.ScheduledAt)
+	})
+}
+
+func TestGetReferralWorklist(t *testing.T) {
+	t.Run("DefaultsToStaffDepartment", func(t *testing.T) {
+		mockReferralRepo := new(mocks.ReferralRepository)
+		mockStaffRepo := new(mocks.StaffRepository)
+
+		service := NewReferralService(mockReferralRepo, new(mocks.AppointmentRepository),
+			new(mocks.ClinicalNoteRepository), mockStaffRepo)
+
+		mockStaffRepo.On("FindByID", uint(7)).Return(&models.Staff{Model: gorm.Model{ID: 7},
+			Department: stringPtr("Cardiology")}, nil)
+		mockReferralRepo.On("FindWorklist", "cardiology",
+			[]string{constants.ReferralStatus.PENDING, constants.ReferralStatus.ACCEPTED}).Return([]models.Referral{}, nil)
+
+		_, err := service.GetWorklist("", "", 7)
+
+		assert.NoError(t, err)
+		mockReferralRepo.AssertExpectations(t)
+	})
+
+	t.Run("NoDepartment", func(t *testing.T) {
+		mockStaffRepo := new(mocks.StaffRepository)
+
+		service := NewReferralService(new(mocks.ReferralRepository), new(mocks.AppointmentRepository),
+			new(mocks.ClinicalNoteRepository), mockStaffRepo)
+
+		mockStaffRepo.On("FindByID", uint(7)).Return(&models.Staff{}, errors.New("record not found"))
+
+		referrals, err := service.GetWorklist("", "", 7)
+
+		assert.Nil(t, referrals)
+		assert.EqualError(t, err, "department is required")
+	})
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+)
+
+type ServicePriceService interface {
+	CreateServicePrice(input models.CreateServicePriceInput) (*models.ServicePrice, error)
+	GetServicePrices(query models.ServicePriceQuery) ([]models.ServicePrice, error)
+	GetServicePriceByID(id uint) (*models.ServicePrice, error)
+	UpdateServicePrice(id uint, input models.UpdateServicePriceInput) (*models.ServicePrice, error)
+}
+
+type servicePriceService struct {
+	servicePriceRepository repositories.ServicePriceRepository
+	labTestRepository      repositories.LabTestRepository
+	medicationRepository   repositories.MedicationRepository
+}
+
+func NewServicePriceService(
+	servicePriceRepository repositories.ServicePriceRepository,
+	labTestRepository repositories.LabTestRepository,
+	medicationRepository repositories.MedicationRepository,
+) ServicePriceService {
+	return &servicePriceService{
+		servicePriceRepository: servicePriceRepository,
+		labTestRepository:      labTestRepository,
+		medicationRepository:   medicationRepository,
+	}
+}
+
+// CreateServicePrice adds a price to the catalog. Lab test and medication
+// prices must name the item they charge for; other categories must not.
+func (ss *servicePriceService) CreateServicePrice(input models.CreateServicePriceInput) (*models.ServicePrice, error) {
+	categories := constants.ChargeCategories
+	switch input.Category {
+	case categories.LAB_TEST:
+		if input.LabTestID == nil || input.MedicationID != nil {
+			return nil, errors.New("a lab test price must reference a lab test only")
+		}
+		if _, err := ss.labTestRepository.FindByID(*input.LabTestID); err != nil {
+			return nil, errors.New("lab test not found")
+		}
+	case categories.MEDICATION:
+		if input.MedicationID == nil || input.LabTestID != nil {
+			return nil, errors.New("a medication price must reference a medication only")
+		}
+		if _, err := ss.medicationRepository.FindByID(*input.MedicationID); err != nil {
+			return nil, errors.New("medication not found")
+		}
+	default:
+		if input.LabTestID != nil || input.MedicationID != nil {
+			return nil, errors.New("only lab test and medication prices can reference a lab test or medication")
+		}
+	}
+
+	price := &models.ServicePrice{
+		Code:         strings.ToUpper(strings.TrimSpace(input.Code)),
+		Name:         strings.TrimSpace(input.Name),
+		Category:     input.Category,
+		Department:   input.Department,
+		LabTestID:    input.LabTestID,
+		MedicationID: input.MedicationID,
+		UnitPrice:    roundMoney(input.UnitPrice),
+		TaxPercent:   input.TaxPercent,
+		IsActive:     true,
+	}
+
+	if err := ss.servicePriceRepository.Create(price); err != nil {
+		return nil, err
+	}
+
+	return price, nil
+}
+
+func (ss *servicePriceService) GetServicePrices(query models.ServicePriceQuery) ([]models.ServicePrice, error) {
+	query.Q = strings.TrimSpace(query.Q)
+	return ss.servicePriceRepository.FindAll(query)
+}
+
+func (ss *servicePriceService) GetServicePriceByID(id uint) (*models.ServicePrice, error) {
+	price, err := ss.servicePriceRepository.FindByID(id)
+	if err != nil {
+		return nil, errors.New("service price not found")
+	}
+	return price, nil
+}
+
+// UpdateServicePrice changes a catalog price. Invoices already raised keep
+// the price they were generated with.
+func (ss *servicePriceService) UpdateServicePrice(id uint, input models.UpdateServicePriceInput) (*models.ServicePrice, error) {
+	price, err := ss.servicePriceRepository.FindByID(id)
+	if err != nil {
+		return nil, errors.New("service price not found")
+	}
+
+	if input.Name != nil {
+		price.Name = strings.TrimSpace(*input.Name)
+	}
+	if input.UnitPrice != nil {
+		price.UnitPrice = roundMoney(*input.UnitPrice)
+	}
+	if input.TaxPercent != nil {
+		price.TaxPercent = *input.TaxPercent
+	}
+	if input.IsActive != nil {
+		price.IsActive = *input.IsActive
+	}
+
+	if err := ss.servicePriceRepository.Update(price); err != nil {
+		return nil, err
+	}
+
+	return price, nil
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestCreateServicePrice(t *testing.T) {
+	t.Run("LabTestPrice", func(t *testing.T) {
+		priceRepo := new(mocks.ServicePriceRepository)
+		labTestRepo := new(mocks.LabTestRepository)
+		service := NewServicePriceService(priceRepo, labTestRepo, new(mocks.MedicationRepository))
+
+		labTestRepo.On("FindByID", uint(7)).Return(&models.LabTest{Model: gorm.Model{ID: 7}}, nil)
+		priceRepo.On("Create", mock.AnythingOfType("*models.ServicePrice")).Return(nil)
+
+		price, err := service.CreateServicePrice(models.CreateServicePriceInput{
+			Code:      " lab-fbc ",
+			Name:      "Full blood count",
+			Category:  constants.ChargeCategories.LAB_TEST,
+			LabTestID: uintPtr(7),
+			UnitPrice: 3000.456,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "LAB-FBC", price.Code)
+		assert.Equal(t, 3000.46, price.UnitPrice)
+		assert.True(t, price.IsActive)
+		priceRepo.AssertExpectations(t)
+	})
+
+	t.Run("DepartmentConsultation", func(t *testing.T) {
+		priceRepo := new(mocks.ServicePriceRepository)
+		service := NewServicePriceService(priceRepo, new(mocks.LabTestRepository), new(mocks.MedicationRepository))
+
+		priceRepo.On("Create", mock.AnythingOfType("*models.ServicePrice")).Return(nil)
+
+		price, err := service.CreateServicePrice(models.CreateServicePriceInput{
+			Code:       "CONS-CARD",
+			Name:       "Cardiology consultation",
+			Category:   constants.ChargeCategories.CONSULTATION,
+			Department: stringPtr("cardiology"),
+			UnitPrice:  15000,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "cardiology", *price.Department)
+	})
+
+	t.Run("MedicationPriceWithoutMedication", func(t *testing.T) {
+		service := NewServicePriceService(new(mocks.ServicePriceRepository), new(mocks.LabTestRepository), new(mocks.MedicationRepository))
+
+		_, err := service.CreateServicePrice(models.CreateServicePriceInput{
+			Code:     "MED-X",
+			Name:     "Something",
+			Category: constants.ChargeCategories.MEDICATION,
+		})
+
+		assert.EqualError(t, err, "a medication price must reference a medication only")
+	})
+
+	t.Run("ProcedureWithLabTest", func(t *testing.T) {
+		service := NewServicePriceService(new(mocks.ServicePriceRepository), new(mocks.LabTestRepository), new(mocks.MedicationRepository))
+
+		_, err := service.CreateServicePrice(models.CreateServicePriceInput{
+			Code:      "PROC-1",
+			Name:      "Dressing",
+			Category:  constants.ChargeCategories.PROCEDURE,
+			LabTestID: uintPtr(7),
+		})
+
+		assert.EqualError(t, err, "only lab test and medication prices can reference a lab test or medication")
+	})
+
+	t.Run("UnknownLabTest", func(t *testing.T) {
+		labTestRepo := new(mocks.LabTestRepository)
+		service := NewServicePriceService(new(mocks.ServicePriceRepository), labTestRepo, new(mocks.MedicationRepository))
+
+		labTestRepo.On("FindByID", uint(99)).Return(&models.LabTest{}, gorm.ErrRecordNotFound)
+
+		_, err := service.CreateServicePrice(models.CreateServicePriceInput{
+			Code:      "LAB-X",
+			Name:      "Unknown",
+			Category:  constants.ChargeCategories.LAB_TEST,
+			LabTestID: uintPtr(99),
+		})
+
+		assert.EqualError(t, err, "lab test not found")
+	})
+}
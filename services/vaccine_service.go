@@ -0,0 +1,113 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+)
+
+type VaccineService interface {
+	CreateVaccine(input models.CreateVaccineInput) (*models.Vaccine, error)
+	GetAllVaccines(activeOnly bool) ([]models.Vaccine, error)
+	UpdateVaccine(id uint, input models.UpdateVaccineInput) (*models.Vaccine, error)
+	ReplaceSchedule(id uint, input models.ReplaceScheduleInput) (*models.Vaccine, error)
+}
+
+type vaccineService struct {
+	vaccineRepository repositories.VaccineRepository
+}
+
+func NewVaccineService(vaccineRepository repositories.VaccineRepository) VaccineService {
+	return &vaccineService{vaccineRepository: vaccineRepository}
+}
+
+func (vs *vaccineService) CreateVaccine(input models.CreateVaccineInput) (*models.Vaccine, error) {
+	vaccine := &models.Vaccine{
+		Code:        strings.ToUpper(strings.TrimSpace(input.Code)),
+		Name:        strings.TrimSpace(input.Name),
+		Description: input.Description,
+		IsActive:    true,
+	}
+
+	if err := vs.vaccineRepository.Create(vaccine); err != nil {
+		return nil, err
+	}
+
+	return vaccine, nil
+}
+
+func (vs *vaccineService) GetAllVaccines(activeOnly bool) ([]models.Vaccine, error) {
+	return vs.vaccineRepository.FindAll(activeOnly)
+}
+
+func (vs *vaccineService) UpdateVaccine(id uint, input models.UpdateVaccineInput) (*models.Vaccine, error) {
+	vaccine, err := vs.vaccineRepository.FindByID(id)
+	if err != nil {
+		return nil, errors.New("vaccine not found")
+	}
+
+	if input.Name != nil {
+		vaccine.Name = strings.TrimSpace(*input.Name)
+	}
+	if input.Description != nil {
+		vaccine.Description = *input.Description
+	}
+	if input.IsActive != nil {
+		vaccine.IsActive = *input.IsActive
+	}
+
+	if err := vs.vaccineRepository.Update(vaccine); err != nil {
+		return nil, err
+	}
+
+	return vaccine, nil
+}
+
+// ReplaceSchedule sets the doses of a vaccine on the national schedule. Doses
+// must be numbered uniquely and fall due in dose order.
+func (vs *vaccineService) ReplaceSchedule(id uint, input models.ReplaceScheduleInput) (*models.Vaccine, error) {
+	vaccine, err := vs.vaccineRepository.FindByID(id)
+	if err != nil {
+		return nil, errors.New("vaccine not found")
+	}
+
+	doses := make([]models.VaccineScheduleDose, 0, len(input.Doses))
+	for _, dose := range input.Doses {
+		overdueAfter := dose.OverdueAfterDays
+		if overdueAfter == 0 {
+			overdueAfter = constants.DefaultOverdueAfterDays
+		}
+		if dose.MaxAgeDays != 0 && dose.MaxAgeDays < dose.DueAgeDays {
+			return nil, fmt.Errorf("dose %d has a maximum age below its due age", dose.DoseNumber)
+		}
+		doses = append(doses, models.VaccineScheduleDose{
+			VaccineID:        vaccine.ID,
+			DoseNumber:       dose.DoseNumber,
+			DueAgeDays:       dose.DueAgeDays,
+			OverdueAfterDays: overdueAfter,
+			MaxAgeDays:       dose.MaxAgeDays,
+		})
+	}
+
+	sort.Slice(doses, func(i, j int) bool { return doses[i].DoseNumber < doses[j].DoseNumber })
+	for i := 1; i < len(doses); i++ {
+		if doses[i].DoseNumber == doses[i-1].DoseNumber {
+			return nil, fmt.Errorf("dose %d is listed more than once", doses[i].DoseNumber)
+		}
+		if doses[i].DueAgeDays < doses[i-1].DueAgeDays {
+			return nil, fmt.Errorf("dose %d falls due before dose %d", doses[i].DoseNumber, doses[i-1].DoseNumber)
+		}
+	}
+
+	if err := vs.vaccineRepository.ReplaceSchedule(vaccine.ID, doses); err != nil {
+		return nil, err
+	}
+
+	vaccine.Doses = doses
+	return vaccine, nil
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestReplaceSchedule(t *testing.T) {
+	opv := &models.Vaccine{Model: gorm.Model{ID: 3}, Code: "OPV", Name: "Oral polio vaccine", IsActive: true}
+
+	t.Run("Success", func(t *testing.T) {
+		vaccineRepo := new(mocks.VaccineRepository)
+		service := NewVaccineService(vaccineRepo)
+
+		vaccineRepo.On("FindByID", uint(3)).Return(opv, nil)
+		vaccineRepo.On("ReplaceSchedule", uint(3), mock.AnythingOfType("[]models.VaccineScheduleDose")).Return(nil)
+
+		vaccine, err := service.ReplaceSchedule(3, models.ReplaceScheduleInput{Doses: []models.ScheduleDoseInput{
+			{DoseNumber: 2, DueAgeDays: 42, OverdueAfterDays: 14},
+			{DoseNumber: 1, DueAgeDays: 0},
+		}})
+
+		assert.NoError(t, err)
+		assert.Len(t, vaccine.Doses, 2)
+		assert.Equal(t, 1, vaccine.Doses[0].DoseNumber)
+		assert.Equal(t, constants.DefaultOverdueAfterDays, vaccine.Doses[0].OverdueAfterDays)
+		assert.Equal(t, 14, vaccine.Doses[1].OverdueAfterDays)
+		vaccineRepo.AssertExpectations(t)
+	})
+
+	t.Run("DuplicateDose", func(t *testing.T) {
+		vaccineRepo := new(mocks.VaccineRepository)
+		service := NewVaccineService(vaccineRepo)
+
+		vaccineRepo.On("FindByID", uint(3)).Return(opv, nil)
+
+		_, err := service.ReplaceSchedule(3, models.ReplaceScheduleInput{Doses: []models.ScheduleDoseInput{
+			{DoseNumber: 1, DueAgeDays: 0},
+			{DoseNumber: 1, DueAgeDays: 42},
+		}})
+
+		assert.EqualError(t, err, "dose 1 is listed more than once")
+		vaccineRepo.AssertNotCalled(t, "ReplaceSchedule", mock.Anything, mock.Anything)
+	})
+
+	t.Run("DosesOutOfOrder", func(t *testing.T) {
+		vaccineRepo := new(mocks.VaccineRepository)
+		service := NewVaccineService(vaccineRepo)
+
+		vaccineRepo.On("FindByID", uint(3)).Return(opv, nil)
+
+		_, err := service.ReplaceSchedule(3, models.ReplaceScheduleInput{Doses: []models.ScheduleDoseInput{
+			{DoseNumber: 1, DueAgeDays: 70},
+			{DoseNumber: 2, DueAgeDays: 42},
+		}})
+
+		assert.EqualError(t, err, "dose 2 falls due before dose 1")
+	})
+
+	t.Run("MaxAgeBelowDueAge", func(t *testing.T) {
+		vaccineRepo := new(mocks.VaccineRepository)
+		service := NewVaccineService(vaccineRepo)
+
+		vaccineRepo.On("FindByID", uint(3)).Return(opv, nil)
+
+		_, err := service.ReplaceSchedule(3, models.ReplaceScheduleInput{Doses: []models.ScheduleDoseInput{
+			{DoseNumber: 1, DueAgeDays: 70, MaxAgeDays: 30},
+		}})
+
+		assert.EqualError(t, err, "dose 1 has a maximum age below its due age")
+	})
+}
@@ -0,0 +1,175 @@
+package services
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/repositories"
+)
+
+type WardService interface {
+	CreateWard(input models.CreateWardInput) (*models.Ward, error)
+	GetAllWards(activeOnly bool) ([]models.Ward, error)
+	GetWardByID(id uint) (*models.Ward, error)
+	UpdateWard(id uint, input models.UpdateWardInput) (*models.Ward, error)
+	AddBed(wardID uint, input models.CreateBedInput) (*models.Bed, error)
+	UpdateBedStatus(wardID uint, bedID uint, input models.UpdateBedStatusInput) (*models.Bed, error)
+	GetBoard(wardID uint) (*models.WardBoard, error)
+	GetBoards() ([]models.WardBoard, error)
+}
+
+type wardService struct {
+	wardRepository repositories.WardRepository
+}
+
+func NewWardService(wardRepository repositories.WardRepository) WardService {
+	return &wardService{wardRepository}
+}
+
+func (ws *wardService) CreateWard(input models.CreateWardInput) (*models.Ward, error) {
+	ward := &models.Ward{
+		Name:        strings.TrimSpace(input.Name),
+		Department:  input.Department,
+		Description: input.Description,
+		IsActive:    true,
+	}
+
+	if err := ws.wardRepository.Create(ward); err != nil {
+		return nil, errors.New("a ward with this name already exists")
+	}
+
+	return ward, nil
+}
+
+func (ws *wardService) GetAllWards(activeOnly bool) ([]models.Ward, error) {
+	return ws.wardRepository.FindAll(activeOnly)
+}
+
+func (ws *wardService) GetWardByID(id uint) (*models.Ward, error) {
+	ward, err := ws.wardRepository.FindByID(id)
+	if err != nil {
+		return nil, errors.New("ward not found")
+	}
+	return ward, nil
+}
+
+func (ws *wardService) UpdateWard(id uint, input models.UpdateWardInput) (*models.Ward, error) {
+	ward, err := ws.wardRepository.FindByID(id)
+	if err != nil {
+		return nil, errors.New("ward not found")
+	}
+
+	if input.Name != nil {
+		ward.Name = strings.TrimSpace(*input.Name)
+	}
+	if input.Description != nil {
+		ward.Description = *input.Description
+	}
+	if input.IsActive != nil {
+		if !*input.IsActive {
+			for _, bed := range ward.Beds {
+				if bed.Status == constants.BedStatus.OCCUPIED {
+					return nil, errors.New("cannot deactivate a ward with admitted patients")
+				}
+			}
+		}
+		ward.IsActive = *input.IsActive
+	}
+
+	if err := ws.wardRepository.Update(ward); err != nil {
+		return nil, err
+	}
+
+	return ward, nil
+}
+
+func (ws *wardService) AddBed(wardID uint, input models.CreateBedInput) (*models.Bed, error) {
+	ward, err := ws.wardRepository.FindByID(wardID)
+	if err != nil {
+		return nil, errors.New("ward not found")
+	}
+
+	bed := &models.Bed{
+		WardID: ward.ID,
+		Label:  strings.ToUpper(strings.TrimSpace(input.Label)),
+		Status: constants.BedStatus.AVAILABLE,
+	}
+
+	if err := ws.wardRepository.CreateBed(bed); err != nil {
+		return nil, errors.New("a bed with this label already exists on the ward")
+	}
+
+	return bed, nil
+}
+
+// UpdateBedStatus is used by housekeeping to mark a bed cleaned, under
+// cleaning or reserved. Occupied beds are released through discharge or
+// transfer only.
+func (ws *wardService) UpdateBedStatus(wardID uint, bedID uint, input models.UpdateBedStatusInput) (*models.Bed, error) {
+	bed, err := ws.wardRepository.FindBedByID(bedID)
+	if err != nil || bed.WardID != wardID {
+		return nil, errors.New("bed not found on this ward")
+	}
+	if bed.Status == constants.BedStatus.OCCUPIED {
+		return nil, errors.New("bed is occupied")
+	}
+
+	bed.Status = input.Status
+	if err := ws.wardRepository.UpdateBedStatus(bed); err != nil {
+		return nil, err
+	}
+
+	return bed, nil
+}
+
+func (ws *wardService) GetBoard(wardID uint) (*models.WardBoard, error) {
+	ward, err := ws.wardRepository.FindByID(wardID)
+	if err != nil {
+		return nil, errors.New("ward not found")
+	}
+	return ws.buildBoard(ward)
+}
+
+func (ws *wardService) GetBoards() ([]models.WardBoard, error) {
+	wards, err := ws.wardRepository.FindAll(true)
+	if err != nil {
+		return nil, err
+	}
+
+	boards := make([]models.WardBoard, 0, len(wards))
+	for i := range wards {
+		board, err := ws.buildBoard(&wards[i])
+		if err != nil {
+			return nil, err
+		}
+		boards = append(boards, *board)
+	}
+	return boards, nil
+}
+
+func (ws *wardService) buildBoard(ward *models.Ward) (*models.WardBoard, error) {
+	beds, err := ws.wardRepository.FindOccupancy(ward.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := map[string]int{
+		constants.BedStatus.AVAILABLE: 0,
+		constants.BedStatus.OCCUPIED:  0,
+		constants.BedStatus.CLEANING:  0,
+		constants.BedStatus.RESERVED:  0,
+	}
+	for _, bed := range beds {
+		counts[bed.Status]++
+	}
+
+	return &models.WardBoard{
+		WardID:     ward.ID,
+		WardName:   ward.Name,
+		Department: ward.Department,
+		Counts:     counts,
+		Beds:       beds,
+	}, nil
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/ofojichigozie/hms-go-backend/constants"
+	"github.com/ofojichigozie/hms-go-backend/models"
+	"github.com/ofojichigozie/hms-go-backend/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestUpdateBedStatus(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		wardRepo := new(mocks.WardRepository)
+		service := NewWardService(wardRepo)
+
+		wardRepo.On("FindBedByID", uint(7)).Return(&models.Bed{Model: gorm.Model{ID: 7}, WardID: 2, Status: constants.BedStatus.CLEANING}, nil)
+		wardRepo.On("UpdateBedStatus", mock.AnythingOfType("*models.Bed")).Return(nil)
+
+		bed, err := service.UpdateBedStatus(2, 7, models.UpdateBedStatusInput{Status: constants.BedStatus.AVAILABLE})
+
+		assert.NoError(t, err)
+		assert.Equal(t, constants.BedStatus.AVAILABLE, bed.Status)
+	})
+
+	t.Run("Occupied", func(t *testing.T) {
+		wardRepo := new(mocks.WardRepository)
+		service := NewWardService(wardRepo)
+
+		wardRepo.On("FindBedByID", uint(7)).Return(&models.Bed{Model: gorm.Model{ID: 7}, WardID: 2, Status: constants.BedStatus.OCCUPIED}, nil)
+
+		_, err := service.UpdateBedStatus(2, 7, models.UpdateBedStatusInput{Status: constants.BedStatus.CLEANING})
+
+		assert.EqualError(t, err, "bed is occupied")
+		wardRepo.AssertNotCalled(t, "UpdateBedStatus", mock.Anything)
+	})
+
+	t.Run("OtherWard", func(t *testing.T) {
+		wardRepo := new(mocks.WardRepository)
+		service := NewWardService(wardRepo)
+
+		wardRepo.On("FindBedByID", uint(7)).Return(&models.Bed{Model: gorm.Model{ID: 7}, WardID: 5}, nil)
+
+		_, err := service.UpdateBedStatus(2, 7, models.UpdateBedStatusInput{Status: constants.BedStatus.RESERVED})
+
+		assert.EqualError(t, err, "bed not found on this ward")
+	})
+}
+
+func TestGetWardBoard(t *testing.T) {
+	wardRepo := new(mocks.WardRepository)
+	service := NewWardService(wardRepo)
+
+	wardRepo.On("FindByID", uint(2)).Return(&models.Ward{Model: gorm.Model{ID: 2}, Name: "Paediatrics", Department: "pediatrics"}, nil)
+	wardRepo.On("FindOccupancy", uint(2)).Return([]models.BedOccupancy{
+		{BedID: 1, Label: "A1", Status: constants.BedStatus.OCCUPIED, AdmissionID: uintPtr(4), PatientName: "Ada Obi"},
+		{BedID: 2, Label: "A2", Status: constants.BedStatus.OCCUPIED, AdmissionID: uintPtr(6), PatientName: "Chidi Eze"},
+		{BedID: 3, Label: "A3", Status: constants.BedStatus.CLEANING},
+	}, nil)
+
+	board, err := service.GetBoard(2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"available": 0, "occupied": 2, "cleaning": 1, "reserved": 0}, board.Counts)
+	assert.Len(t, board.Beds, 3)
+	assert.Equal(t, "Paediatrics", board.WardName)
+}
+
+func TestDeactivateWardWithPatients(t *testing.T) {
+	wardRepo := new(mocks.WardRepository)
+	service := NewWardService(wardRepo)
+
+	inactive := false
+	wardRepo.On("FindByID", uint(2)).Return(&models.Ward{Model: gorm.Model{ID: 2}, IsActive: true, Beds: []models.Bed{
+		{Label: "A1", Status: constants.BedStatus.OCCUPIED},
+	}}, nil)
+
+	_, err := service.UpdateWard(2, models.UpdateWardInput{IsActive: &inactive})
+
+	assert.EqualError(t, err, "cannot deactivate a ward with admitted patients")
+	wardRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
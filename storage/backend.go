@@ -0,0 +1,59 @@
+// Package storage keeps uploaded files outside the database. Callers work
+// with a Backend and opaque keys, so the local filesystem and S3-compatible
+// object stores are interchangeable.
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+var ErrObjectNotFound = errors.New("stored object not found")
+
+type Backend interface {
+	// Name identifies the backend so stored keys can be traced to it.
+	Name() string
+	Put(key string, content []byte, contentType string) error
+	Get(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
+
+// NewBackendFromEnv picks the backend named by DOCUMENT_STORAGE ("local" by
+// default, or "s3") and reads its settings from the environment.
+func NewBackendFromEnv() (Backend, error) {
+	switch strings.ToLower(os.Getenv("DOCUMENT_STORAGE")) {
+	case "", "local":
+		root := os.Getenv("DOCUMENT_STORAGE_PATH")
+		if root == "" {
+			root = "uploads"
+		}
+		return NewLocalBackend(root), nil
+	case "s3":
+		return NewS3Backend(S3Config{
+			Endpoint:        os.Getenv("S3_ENDPOINT"),
+			Region:          os.Getenv("S3_REGION"),
+			Bucket:          os.Getenv("S3_BUCKET"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+			UsePathStyle:    os.Getenv("S3_USE_PATH_STYLE") == "true",
+		})
+	default:
+		return nil, fmt.Errorf("unknown DOCUMENT_STORAGE %q", os.Getenv("DOCUMENT_STORAGE"))
+	}
+}
+
+// validKey rejects keys that could escape the storage root or bucket prefix.
+func validKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return fmt.Errorf("invalid storage key %q", key)
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return fmt.Errorf("invalid storage key %q", key)
+		}
+	}
+	return nil
+}
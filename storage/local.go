@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+type localBackend struct {
+	root string
+}
+
+// NewLocalBackend stores objects as files under root, creating directories
+// on first write.
+func NewLocalBackend(root string) Backend {
+	return &localBackend{root: root}
+}
+
+func (lb *localBackend) Name() string {
+	return "local"
+}
+
+func (lb *localBackend) Put(key string, content []byte, contentType string) error {
+	if err := validKey(key); err != nil {
+		return err
+	}
+
+	path := lb.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+
+	// Write beside the target and rename so readers never see a partial file.
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (lb *localBackend) Get(key string) (io.ReadCloser, error) {
+	if err := validKey(key); err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(lb.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrObjectNotFound
+	}
+	return file, err
+}
+
+func (lb *localBackend) Delete(key string) error {
+	if err := validKey(key); err != nil {
+		return err
+	}
+
+	err := os.Remove(lb.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (lb *localBackend) path(key string) string {
+	return filepath.Join(lb.root, filepath.FromSlash(key))
+}
@@ -0,0 +1,48 @@
+package templates
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	"os"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/ofojichigozie/hms-go-backend/pdf"
+)
+
+//go:embed *.html *.tmpl
+var files embed.FS
+
+var funcs = template.FuncMap{
+	"date": func(t time.Time) string {
+		return t.Format("02 Jan 2006")
+	},
+	"datetime": func(t time.Time) string {
+		return t.Format("02 Jan 2006 15:04")
+	},
+	"money": func(amount float64) string {
+		return fmt.Sprintf("%.2f", amount)
+	},
+}
+
+var Prescription = template.Must(template.New("prescription.html").Funcs(funcs).ParseFS(files, "prescription.html"))
+
+var Receipt = template.Must(template.New("receipt.html").Funcs(funcs).ParseFS(files, "receipt.html"))
+
+// LoadDischargeSummary parses the discharge summary layout from path, or the
+// built-in layout when path is empty. The layout renders to the markup read
+// by pdf.FromMarkup; its "text" function escapes free text for that markup.
+func LoadDischargeSummary(path string) (*texttemplate.Template, error) {
+	summary := texttemplate.New("discharge_summary.tmpl").Funcs(texttemplate.FuncMap(funcs)).
+		Funcs(texttemplate.FuncMap{"text": pdf.EscapeMarkup})
+	if path == "" {
+		return summary.ParseFS(files, "discharge_summary.tmpl")
+	}
+
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return summary.Parse(string(source))
+}
@@ -0,0 +1,24 @@
+package utils
+
+import "time"
+
+// AgeInYears returns completed years between dateOfBirth and at.
+func AgeInYears(dateOfBirth time.Time, at time.Time) int {
+	years := at.Year() - dateOfBirth.Year()
+	if at.Month() < dateOfBirth.Month() ||
+		(at.Month() == dateOfBirth.Month() && at.Day() < dateOfBirth.Day()) {
+		years--
+	}
+	return years
+}
+
+// AgeInDays returns whole calendar days between dateOfBirth and at.
+func AgeInDays(dateOfBirth time.Time, at time.Time) int {
+	return int(CalendarDay(at).Sub(CalendarDay(dateOfBirth)).Hours() / 24)
+}
+
+// CalendarDay drops the time of day, keeping the date as midnight UTC.
+func CalendarDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
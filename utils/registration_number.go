@@ -0,0 +1,246 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	CheckDigitNone  = "none"
+	CheckDigitLuhn  = "luhn"
+	CheckDigitMod11 = "mod11"
+
+	DefaultRegistrationNumberTemplate = "PAT-{YYYY}-{SEQ:6}"
+)
+
+var (
+	ErrInvalidCheckDigit  = errors.New("registration number check digit is invalid")
+	registrationTokenExpr = regexp.MustCompile(`\{(YYYY|YY|BRANCH|SEQ(?::\d+)?|CHECK)\}`)
+)
+
+// RegistrationNumberFormat renders patient registration numbers from a
+// template such as "PAT-{YYYY}-{BRANCH}-{SEQ:6}{CHECK}". Supported tokens are
+// {YYYY}, {YY}, {BRANCH}, {SEQ} or {SEQ:width} and {CHECK}. The check digit is
+// computed over every digit that precedes it and is appended to the template
+// when a scheme is set but the template has no {CHECK} token.
+type RegistrationNumberFormat struct {
+	Template   string
+	CheckDigit string
+	Branch     string
+	pattern    *regexp.Regexp
+	folded     *regexp.Regexp
+	seqWidth   int
+}
+
+// NewRegistrationNumberFormat validates the template and check digit scheme.
+func NewRegistrationNumberFormat(template, checkDigit, branch string) (*RegistrationNumberFormat, error) {
+	if checkDigit == "" {
+		checkDigit = CheckDigitNone
+	}
+	if checkDigit != CheckDigitNone && checkDigit != CheckDigitLuhn && checkDigit != CheckDigitMod11 {
+		return nil, fmt.Errorf("unsupported check digit scheme %q", checkDigit)
+	}
+	if strings.Count(template, "{SEQ") != 1 {
+		return nil, errors.New("registration number template must contain exactly one {SEQ} token")
+	}
+	if strings.Contains(template, "{BRANCH}") && branch == "" {
+		return nil, errors.New("registration number template uses {BRANCH} but no branch code is set")
+	}
+
+	hasCheck := strings.Contains(template, "{CHECK}")
+	if hasCheck && checkDigit == CheckDigitNone {
+		return nil, errors.New("registration number template has {CHECK} but no check digit scheme is set")
+	}
+	if !hasCheck && checkDigit != CheckDigitNone {
+		template += "{CHECK}"
+	}
+	if idx := strings.Index(template, "{CHECK}"); registrationTokenExpr.MatchString(template[idx+len("{CHECK}"):]) {
+		return nil, errors.New("{CHECK} must be the last token in the registration number template")
+	}
+
+	format := &RegistrationNumberFormat{Template: template, CheckDigit: checkDigit, Branch: branch}
+
+	// folded is the same pattern without regard to case, capturing each token
+	// so Normalize can rebuild the number around the template's own text.
+	var pattern, folded strings.Builder
+	pattern.WriteString("^")
+	folded.WriteString("(?i)^")
+	last := 0
+	for _, loc := range registrationTokenExpr.FindAllStringSubmatchIndex(template, -1) {
+		pattern.WriteString(regexp.QuoteMeta(template[last:loc[0]]))
+		folded.WriteString(regexp.QuoteMeta(template[last:loc[0]]))
+		token := template[loc[2]:loc[3]]
+		var expr string
+		switch {
+		case token == "YYYY":
+			expr = `\d{4}`
+		case token == "YY":
+			expr = `\d{2}`
+		case token == "BRANCH":
+			expr = regexp.QuoteMeta(branch)
+		case token == "CHECK":
+			expr = `[0-9X]`
+		default:
+			if width, found := strings.CutPrefix(token, "SEQ:"); found {
+				format.seqWidth, _ = strconv.Atoi(width)
+			}
+			expr = fmt.Sprintf(`\d{%d,}`, max(format.seqWidth, 1))
+		}
+		pattern.WriteString(expr)
+		folded.WriteString("(" + expr + ")")
+		last = loc[1]
+	}
+	pattern.WriteString(regexp.QuoteMeta(template[last:]))
+	pattern.WriteString("$")
+	folded.WriteString(regexp.QuoteMeta(template[last:]))
+	folded.WriteString("$")
+	format.pattern = regexp.MustCompile(pattern.String())
+	format.folded = regexp.MustCompile(folded.String())
+
+	return format, nil
+}
+
+// RegistrationNumberFormatFromEnv reads PATIENT_ID_FORMAT, PATIENT_ID_CHECK_DIGIT
+// and BRANCH_CODE, defaulting to the default template with a Luhn check digit.
+func RegistrationNumberFormatFromEnv() (*RegistrationNumberFormat, error) {
+	template := os.Getenv("PATIENT_ID_FORMAT")
+	if template == "" {
+		template = DefaultRegistrationNumberTemplate
+	}
+	checkDigit := strings.ToLower(os.Getenv("PATIENT_ID_CHECK_DIGIT"))
+	if checkDigit == "" {
+		checkDigit = CheckDigitLuhn
+	}
+	return NewRegistrationNumberFormat(template, checkDigit, os.Getenv("BRANCH_CODE"))
+}
+
+// SequenceName scopes the counter by year and/or branch, depending on which
+// of those the template includes, so numbering restarts when they change.
+func (f *RegistrationNumberFormat) SequenceName(at time.Time) string {
+	name := "patient_registration"
+	if strings.Contains(f.Template, "{YYYY}") || strings.Contains(f.Template, "{YY}") {
+		name += ":" + strconv.Itoa(at.Year())
+	}
+	if strings.Contains(f.Template, "{BRANCH}") {
+		name += ":" + f.Branch
+	}
+	return name
+}
+
+func (f *RegistrationNumberFormat) Render(seq int64, at time.Time) string {
+	rendered := registrationTokenExpr.ReplaceAllStringFunc(f.Template, func(token string) string {
+		switch token {
+		case "{YYYY}":
+			return strconv.Itoa(at.Year())
+		case "{YY}":
+			return fmt.Sprintf("%02d", at.Year()%100)
+		case "{BRANCH}":
+			return f.Branch
+		case "{CHECK}":
+			return token
+		}
+		return fmt.Sprintf("%0*d", f.seqWidth, seq)
+	})
+
+	if idx := strings.Index(rendered, "{CHECK}"); idx >= 0 {
+		rendered = rendered[:idx] + f.checkCharacter(rendered[:idx]) + rendered[idx+len("{CHECK}"):]
+	}
+	return rendered
+}
+
+// Matches reports whether number has the shape produced by this format.
+// Numbers issued under an earlier format do not match.
+func (f *RegistrationNumberFormat) Matches(number string) bool {
+	return f.pattern.MatchString(number)
+}
+
+// Normalize trims number and, if it has this format's shape in any casing,
+// returns it in the casing the format issues, so "pat-2026-0000425" becomes
+// "PAT-2026-0000425" and a mod-11 "x" becomes "X". Any other number, such as
+// one issued under an earlier format, is returned as entered.
+func (f *RegistrationNumberFormat) Normalize(number string) string {
+	number = strings.TrimSpace(number)
+	groups := f.folded.FindStringSubmatch(number)
+	if groups == nil {
+		return number
+	}
+
+	i := 0
+	return registrationTokenExpr.ReplaceAllStringFunc(f.Template, func(token string) string {
+		i++
+		switch token {
+		case "{BRANCH}":
+			return f.Branch
+		case "{CHECK}":
+			return strings.ToUpper(groups[i])
+		}
+		return groups[i]
+	})
+}
+
+// Validate checks the check digit of a number produced by this format.
+// Numbers that do not match the format are not checked.
+func (f *RegistrationNumberFormat) Validate(number string) error {
+	if f.CheckDigit == CheckDigitNone || !f.Matches(number) {
+		return nil
+	}
+
+	idx := strings.Index(f.Template, "{CHECK}")
+	suffix := len(f.Template) - idx - len("{CHECK}")
+	checkPos := len(number) - suffix - 1
+	if f.checkCharacter(number[:checkPos]) != number[checkPos:checkPos+1] {
+		return ErrInvalidCheckDigit
+	}
+	return nil
+}
+
+func (f *RegistrationNumberFormat) checkCharacter(prefix string) string {
+	digits := make([]int, 0, len(prefix))
+	for _, r := range prefix {
+		if r >= '0' && r <= '9' {
+			digits = append(digits, int(r-'0'))
+		}
+	}
+
+	if f.CheckDigit == CheckDigitMod11 {
+		return Mod11CheckCharacter(digits)
+	}
+	return strconv.Itoa(LuhnCheckDigit(digits))
+}
+
+// LuhnCheckDigit returns the digit that makes digits+check pass the Luhn test.
+func LuhnCheckDigit(digits []int) int {
+	sum := 0
+	double := true
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := digits[i]
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return (10 - sum%10) % 10
+}
+
+// Mod11CheckCharacter weights digits 2, 3, 4, ... from the right and returns
+// the mod-11 complement, using "X" for 10.
+func Mod11CheckCharacter(digits []int) string {
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		sum += digits[i] * (len(digits) - i + 1)
+	}
+	check := (11 - sum%11) % 11
+	if check == 10 {
+		return "X"
+	}
+	return strconv.Itoa(check)
+}
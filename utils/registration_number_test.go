@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLuhnCheckDigit(t *testing.T) {
+	t.Run("KnownNumber", func(t *testing.T) {
+		// 79927398713 is the usual Luhn example.
+		assert.Equal(t, 3, LuhnCheckDigit([]int{7, 9, 9, 2, 7, 3, 9, 8, 7, 1}))
+	})
+
+	t.Run("ZeroCheckDigit", func(t *testing.T) {
+		assert.Equal(t, 0, LuhnCheckDigit([]int{1, 9}))
+		assert.Equal(t, 0, LuhnCheckDigit([]int{}))
+	})
+
+	t.Run("DetectsTransposition", func(t *testing.T) {
+		assert.NotEqual(t, LuhnCheckDigit([]int{1, 2, 3, 4}), LuhnCheckDigit([]int{1, 2, 4, 3}))
+	})
+}
+
+func TestMod11CheckCharacter(t *testing.T) {
+	t.Run("KnownNumber", func(t *testing.T) {
+		// ISBN-10 uses the same weighting: 0-306-40615-2.
+		assert.Equal(t, "2", Mod11CheckCharacter([]int{0, 3, 0, 6, 4, 0, 6, 1, 5}))
+	})
+
+	t.Run("TenIsX", func(t *testing.T) {
+		// ISBN-10 0-8044-2957-X.
+		assert.Equal(t, "X", Mod11CheckCharacter([]int{0, 8, 0, 4, 4, 2, 9, 5, 7}))
+	})
+
+	t.Run("ZeroCheckDigit", func(t *testing.T) {
+		assert.Equal(t, "0", Mod11CheckCharacter([]int{0, 0, 0}))
+	})
+}
+
+func TestRegistrationNumberFormat(t *testing.T) {
+	issuedAt := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("RenderAndValidate", func(t *testing.T) {
+		format, err := NewRegistrationNumberFormat(DefaultRegistrationNumberTemplate, CheckDigitLuhn, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "PAT-2026-0000425", format.Render(42, issuedAt))
+		assert.NoError(t, format.Validate("PAT-2026-0000425"))
+		assert.ErrorIs(t, format.Validate("PAT-2026-0000421"), ErrInvalidCheckDigit)
+		assert.NoError(t, format.Validate("PAT001"))
+	})
+
+	t.Run("Mod11WithBranch", func(t *testing.T) {
+		format, err := NewRegistrationNumberFormat("{BRANCH}/{YY}/{SEQ:4}-{CHECK}", CheckDigitMod11, "Ikj")
+
+		assert.NoError(t, err)
+		number := format.Render(7, issuedAt)
+		assert.Regexp(t, `^Ikj/26/0007-[0-9X]$`, number)
+		assert.NoError(t, format.Validate(number))
+	})
+
+	t.Run("Normalize", func(t *testing.T) {
+		format, _ := NewRegistrationNumberFormat("{BRANCH}-{SEQ:3}{CHECK}", CheckDigitMod11, "Ikj")
+
+		assert.Equal(t, "Ikj-001X", format.Normalize(" IKJ-001x "))
+		assert.Equal(t, "pat-001", format.Normalize("pat-001"))
+	})
+
+	t.Run("InvalidConfig", func(t *testing.T) {
+		_, err := NewRegistrationNumberFormat(DefaultRegistrationNumberTemplate, "crc", "")
+		assert.Error(t, err)
+
+		_, err = NewRegistrationNumberFormat("PAT-{YYYY}", CheckDigitLuhn, "")
+		assert.Error(t, err)
+
+		_, err = NewRegistrationNumberFormat("{BRANCH}-{SEQ}", CheckDigitNone, "")
+		assert.Error(t, err)
+
+		_, err = NewRegistrationNumberFormat("{CHECK}-{SEQ}", CheckDigitLuhn, "")
+		assert.Error(t, err)
+	})
+}